@@ -388,6 +388,17 @@ func (j *Jobs) PeriodicForce(jobID string, q *WriteOptions) (string, *WriteMeta,
 	return resp.EvalID, wm, nil
 }
 
+// Rebalance is used to create an evaluation which migrates the allocations
+// of a service job that would score better on other nodes.
+func (j *Jobs) Rebalance(jobID string, q *WriteOptions) (string, *WriteMeta, error) {
+	var resp JobRegisterResponse
+	wm, err := j.client.write("/v1/job/"+url.PathEscape(jobID)+"/rebalance", nil, &resp, q)
+	if err != nil {
+		return "", nil, err
+	}
+	return resp.EvalID, wm, nil
+}
+
 // PlanOptions is used to pass through job planning parameters
 type PlanOptions struct {
 	Diff           bool
@@ -853,6 +864,7 @@ type Job struct {
 	Priority         *int                    `hcl:"priority,optional"`
 	AllAtOnce        *bool                   `mapstructure:"all_at_once" hcl:"all_at_once,optional"`
 	Datacenters      []string                `hcl:"datacenters,optional"`
+	NodePool         *string                 `mapstructure:"node_pool" hcl:"node_pool,optional"`
 	Constraints      []*Constraint           `hcl:"constraint,block"`
 	Affinities       []*Affinity             `hcl:"affinity,block"`
 	TaskGroups       []*TaskGroup            `hcl:"group,block"`
@@ -863,6 +875,8 @@ type Job struct {
 	ParameterizedJob *ParameterizedJobConfig `hcl:"parameterized,block"`
 	Reschedule       *ReschedulePolicy       `hcl:"reschedule,block"`
 	Migrate          *MigrateStrategy        `hcl:"migrate,block"`
+	Preemption       *PreemptionPolicy       `hcl:"preemption,block"`
+	Window           *SchedulingWindow       `hcl:"window,block"`
 	Meta             map[string]string       `hcl:"meta,block"`
 	ConsulToken      *string                 `mapstructure:"consul_token" hcl:"consul_token,optional"`
 	VaultToken       *string                 `mapstructure:"vault_token" hcl:"vault_token,optional"`
@@ -933,6 +947,9 @@ func (j *Job) Canonicalize() {
 	if j.AllAtOnce == nil {
 		j.AllAtOnce = pointerOf(false)
 	}
+	if j.NodePool == nil {
+		j.NodePool = pointerOf(NodePoolDefault)
+	}
 	if j.ConsulToken == nil {
 		j.ConsulToken = pointerOf("")
 	}
@@ -980,6 +997,8 @@ func (j *Job) Canonicalize() {
 	if j.Multiregion != nil {
 		j.Multiregion.Canonicalize()
 	}
+	j.Preemption.Canonicalize()
+	j.Window.Canonicalize()
 
 	for _, tg := range j.TaskGroups {
 		tg.Canonicalize(j)
@@ -1050,6 +1069,7 @@ type JobListStub struct {
 	Name              string
 	Namespace         string `json:",omitempty"`
 	Datacenters       []string
+	NodePool          string
 	Type              string
 	Priority          int
 	Periodic          bool
@@ -1326,8 +1346,9 @@ type ObjectDiff struct {
 }
 
 type PlanAnnotations struct {
-	DesiredTGUpdates map[string]*DesiredUpdates
-	PreemptedAllocs  []*AllocationListStub
+	DesiredTGUpdates  map[string]*DesiredUpdates
+	PreemptedAllocs   []*AllocationListStub
+	PreemptionReasons map[string]string
 }
 
 type DesiredUpdates struct {
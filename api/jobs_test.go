@@ -296,6 +296,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ParentID:          pointerOf(""),
 				Priority:          pointerOf(50),
 				AllAtOnce:         pointerOf(false),
+				NodePool:          pointerOf(NodePoolDefault),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -391,6 +392,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ParentID:          pointerOf(""),
 				Priority:          pointerOf(50),
 				AllAtOnce:         pointerOf(false),
+				NodePool:          pointerOf(NodePoolDefault),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -469,6 +471,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ParentID:          pointerOf("lol"),
 				Priority:          pointerOf(50),
 				AllAtOnce:         pointerOf(false),
+				NodePool:          pointerOf(NodePoolDefault),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -640,6 +643,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Region:            pointerOf("global"),
 				Type:              pointerOf("service"),
 				AllAtOnce:         pointerOf(false),
+				NodePool:          pointerOf(NodePoolDefault),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -806,6 +810,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Type:              pointerOf("service"),
 				Priority:          pointerOf(50),
 				AllAtOnce:         pointerOf(false),
+				NodePool:          pointerOf(NodePoolDefault),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -897,6 +902,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ParentID:          pointerOf("lol"),
 				Priority:          pointerOf(50),
 				AllAtOnce:         pointerOf(false),
+				NodePool:          pointerOf(NodePoolDefault),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -1073,6 +1079,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ParentID:          pointerOf("lol"),
 				Priority:          pointerOf(50),
 				AllAtOnce:         pointerOf(false),
+				NodePool:          pointerOf(NodePoolDefault),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -1244,6 +1251,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				ParentID:          pointerOf("lol"),
 				Priority:          pointerOf(50),
 				AllAtOnce:         pointerOf(false),
+				NodePool:          pointerOf(NodePoolDefault),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api/internal/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestNodePools_CRUD(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	nodePools := c.NodePools()
+
+	// Create a node pool and register it
+	pool := &NodePool{
+		Name:        "test-pool",
+		Description: "Testing node pools",
+		Meta:        map[string]string{"team": "test"},
+	}
+	wm, err := nodePools.Register(pool, nil)
+	must.NoError(t, err)
+	assertWriteMeta(t, wm)
+
+	// Query the node pools back out again, which includes the built-ins
+	resp, qm, err := nodePools.List(nil)
+	must.NoError(t, err)
+	assertQueryMeta(t, qm)
+	must.Len(t, 3, resp)
+
+	// Read the node pool back
+	out, qm, err := nodePools.Info(pool.Name, nil)
+	must.NoError(t, err)
+	assertQueryMeta(t, qm)
+	must.Eq(t, pool.Description, out.Description)
+	must.Eq(t, pool.Meta, out.Meta)
+
+	// Delete the node pool
+	wm, err = nodePools.Delete(pool.Name, nil)
+	must.NoError(t, err)
+	assertWriteMeta(t, wm)
+
+	_, _, err = nodePools.Info(pool.Name, nil)
+	must.Error(t, err)
+	must.StrContains(t, err.Error(), "404")
+}
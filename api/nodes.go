@@ -538,6 +538,7 @@ type DrainMetadata struct {
 type Node struct {
 	ID                    string
 	Datacenter            string
+	NodePool              string
 	Name                  string
 	HTTPAddr              string
 	TLSEnabled            bool
@@ -576,6 +577,9 @@ type NodeResources struct {
 
 	MinDynamicPort int
 	MaxDynamicPort int
+
+	CpuOversubscriptionRatio    float64
+	MemoryOversubscriptionRatio float64
 }
 
 type NodeCpuResources struct {
@@ -908,6 +912,7 @@ type NodeListStub struct {
 	ID                    string
 	Attributes            map[string]string `json:",omitempty"`
 	Datacenter            string
+	NodePool              string
 	Name                  string
 	NodeClass             string
 	Version               string
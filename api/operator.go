@@ -138,11 +138,39 @@ type SchedulerConfiguration struct {
 	// until the configuration is updated and written to the Nomad servers.
 	PauseEvalBroker bool
 
+	// NodeScorers are weighted expressions over node attributes and metadata
+	// which are used to score nodes in addition to the built-in scoring.
+	NodeScorers []*NodeScorer
+
+	// EvalBrokerFairShare configures weighted fair-share dequeuing of
+	// evaluations across namespaces by the evaluation broker.
+	EvalBrokerFairShare *EvalBrokerFairShare
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
 }
 
+// NodeScorer is a weighted expression over the attributes or metadata of a
+// node, such as "${meta.cost_per_hour}". The value of the expression is
+// scaled to the range between Min and Max and multiplied by the Weight.
+type NodeScorer struct {
+	Name       string
+	Expression string
+	Weight     int8
+	Min        float64
+	Max        float64
+}
+
+// EvalBrokerFairShare configures the evaluation broker to dequeue the ready
+// evaluations of each scheduler fairly across namespaces, in proportion to
+// the weights of the namespaces. Namespaces without a weight have a weight
+// of 1.
+type EvalBrokerFairShare struct {
+	Enabled bool
+	Weights map[string]int
+}
+
 // SchedulerConfigurationResponse is the response object that wraps SchedulerConfiguration
 type SchedulerConfigurationResponse struct {
 	// SchedulerConfig contains scheduler config options
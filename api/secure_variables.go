@@ -69,6 +69,35 @@ func (sv *SecureVariables) Read(path string, qo *QueryOptions) (*SecureVariable,
 	return svar, qm, nil
 }
 
+// ReadVersion is used to query a tracked version of a secure variable by
+// path. This will error if the version is not found.
+func (sv *SecureVariables) ReadVersion(path string, version uint64, qo *QueryOptions) (*SecureVariable, *QueryMeta, error) {
+
+	path = cleanPathString(path)
+	var svar = new(SecureVariable)
+	qm, err := sv.readInternal("/v1/var/"+path+"?version="+fmt.Sprint(version), &svar, qo)
+	if err != nil {
+		return nil, nil, err
+	}
+	if svar == nil {
+		return nil, qm, errors.New(ErrVariableNotFound)
+	}
+	return svar, qm, nil
+}
+
+// History is used to list the metadata of the tracked versions of a secure
+// variable, ordered from the newest version.
+func (sv *SecureVariables) History(path string, qo *QueryOptions) ([]*SecureVariableMetadata, *QueryMeta, error) {
+
+	path = cleanPathString(path)
+	var resp []*SecureVariableMetadata
+	qm, err := sv.client.query("/v1/var/"+path+"?history", &resp, qo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
 // Peek is used to query a single secure variable by path, but does not error
 // when the variable is not found
 func (sv *SecureVariables) Peek(path string, qo *QueryOptions) (*SecureVariable, *QueryMeta, error) {
@@ -135,6 +164,44 @@ func (sv *SecureVariables) CheckedDelete(path string, checkIndex uint64, qo *Wri
 	return wm, nil
 }
 
+// AcquireLock is used to acquire the lock on a secure variable, creating the
+// variable if it doesn't exist. The lock TTL may be set with v.Lock, and the
+// returned variable holds the lock ID. If the variable is already locked, it
+// will return an ErrLockConflict.
+func (sv *SecureVariables) AcquireLock(v *SecureVariable, qo *WriteOptions) (*SecureVariable, *WriteMeta, error) {
+	return sv.lockOp("lock-acquire", v, qo)
+}
+
+// RenewLock is used to renew the TTL of a lock held on a secure variable.
+// v.Lock must hold the lock ID. If the lock is no longer held, it will return
+// an ErrLockConflict.
+func (sv *SecureVariables) RenewLock(v *SecureVariable, qo *WriteOptions) (*SecureVariable, *WriteMeta, error) {
+	return sv.lockOp("lock-renew", v, qo)
+}
+
+// ReleaseLock is used to release a lock held on a secure variable. v.Lock
+// must hold the lock ID. If the lock is no longer held, it will return an
+// ErrLockConflict.
+func (sv *SecureVariables) ReleaseLock(v *SecureVariable, qo *WriteOptions) (*SecureVariable, *WriteMeta, error) {
+	return sv.lockOp("lock-release", v, qo)
+}
+
+// lockOp performs the lock operation op on the secure variable.
+func (sv *SecureVariables) lockOp(op string, v *SecureVariable, qo *WriteOptions) (*SecureVariable, *WriteMeta, error) {
+
+	v.Path = cleanPathString(v.Path)
+	var out SecureVariable
+	wm, err := sv.writeChecked("/v1/var/"+v.Path+"?"+op, v, &out, qo)
+	if err != nil {
+		var casErr ErrCASConflict
+		if errors.As(err, &casErr) {
+			return nil, wm, ErrLockConflict{Conflict: casErr.Conflict}
+		}
+		return nil, wm, err
+	}
+	return &out, wm, nil
+}
+
 // List is used to dump all of the secure variables, can be used to pass prefix
 // via QueryOptions rather than as a parameter
 func (sv *SecureVariables) List(qo *QueryOptions) ([]*SecureVariableMetadata, *QueryMeta, error) {
@@ -325,6 +392,14 @@ type SecureVariable struct {
 	CreateTime int64
 	ModifyTime int64
 
+	// Version is incremented each time the contents of the secure variable
+	// are modified
+	Version uint64
+
+	// Lock is set when the secure variable is locked. The lock ID is only
+	// returned to the lock holder.
+	Lock *SecureVariableLock `json:",omitempty"`
+
 	Items SecureVariableItems
 }
 
@@ -343,10 +418,28 @@ type SecureVariableMetadata struct {
 	// Times provided as a convenience for operators expressed time.UnixNanos
 	CreateTime int64
 	ModifyTime int64
+
+	// Version is incremented each time the contents of the secure variable
+	// are modified
+	Version uint64
+
+	// Lock is set when the secure variable is locked. The lock ID is only
+	// returned to the lock holder.
+	Lock *SecureVariableLock `json:",omitempty"`
 }
 
 type SecureVariableItems map[string]string
 
+// SecureVariableLock is a TTL based lock held on a secure variable.
+type SecureVariableLock struct {
+	// ID identifies the lock holder and must be provided to renew or release
+	// the lock, or to modify the locked variable.
+	ID string
+
+	// TTL is the duration the lock is held for without being renewed.
+	TTL time.Duration
+}
+
 // NewSecureVariable is a convenience method to more easily create a
 // ready-to-use secure variable
 func NewSecureVariable(path string) *SecureVariable {
@@ -380,6 +473,8 @@ func (sv *SecureVariable) Metadata() *SecureVariableMetadata {
 		ModifyIndex: sv.ModifyIndex,
 		CreateTime:  sv.CreateTime,
 		ModifyTime:  sv.ModifyTime,
+		Version:     sv.Version,
+		Lock:        sv.Lock,
 	}
 }
 
@@ -420,6 +515,20 @@ func (e ErrCASConflict) Error() string {
 	return fmt.Sprintf("cas conflict: expected ModifyIndex %v; found %v", e.CheckIndex, e.Conflict.ModifyIndex)
 }
 
+// ErrLockConflict is returned when a secure variable lock operation fails
+// because the lock is held by another holder, or is no longer held by the
+// caller.
+type ErrLockConflict struct {
+	Conflict *SecureVariable
+}
+
+func (e ErrLockConflict) Error() string {
+	if e.Conflict != nil && e.Conflict.Lock != nil {
+		return fmt.Sprintf("lock conflict: secure variable %q is locked", e.Conflict.Path)
+	}
+	return "lock conflict: lock is not held"
+}
+
 // doRequestWrapper is a function that wraps the client's doRequest method
 // and can be used to provide error and response handling
 type doRequestWrapper = func(time.Duration, *http.Response, error) (time.Duration, *http.Response, error)
@@ -0,0 +1,201 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrLockLost is returned by Locker.Hold when the lock could not be renewed
+// before its TTL expired, or was released by another party.
+var ErrLockLost = errors.New("secure variable lock lost")
+
+// Locker is used to hold the lock on a secure variable, renewing it in the
+// background for as long as it is needed. It can be used for leader election
+// between processes which share a secure variable path.
+type Locker struct {
+	client *SecureVariables
+	path   string
+	ttl    time.Duration
+	opts   *WriteOptions
+
+	l    sync.Mutex
+	lock *SecureVariableLock
+}
+
+// NewLocker returns a Locker for the secure variable at path. A zero ttl uses
+// the server's default lock TTL.
+func (sv *SecureVariables) NewLocker(path string, ttl time.Duration, qo *WriteOptions) *Locker {
+	return &Locker{
+		client: sv,
+		path:   cleanPathString(path),
+		ttl:    ttl,
+		opts:   qo,
+	}
+}
+
+// Acquire attempts to acquire the lock once. If the lock is held by another
+// holder, it returns an ErrLockConflict.
+func (l *Locker) Acquire() error {
+	v := &SecureVariable{
+		Path: l.path,
+		Lock: &SecureVariableLock{TTL: l.ttl},
+	}
+	out, _, err := l.client.AcquireLock(v, l.opts)
+	if err != nil {
+		return err
+	}
+
+	l.l.Lock()
+	defer l.l.Unlock()
+	l.lock = out.Lock
+	if l.ttl == 0 && out.Lock != nil {
+		l.ttl = out.Lock.TTL
+	}
+	return nil
+}
+
+// AcquireWait blocks until the lock is acquired or the context is done. While
+// the lock is held by another holder, the acquire is retried every half TTL.
+func (l *Locker) AcquireWait(ctx context.Context) error {
+	for {
+		err := l.Acquire()
+		if err == nil {
+			return nil
+		}
+		var conflict ErrLockConflict
+		if !errors.As(err, &conflict) {
+			return err
+		}
+
+		wait := l.retryInterval(conflict.Conflict)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// Renew renews the TTL of the held lock.
+func (l *Locker) Renew() error {
+	v, err := l.heldVariable()
+	if err != nil {
+		return err
+	}
+	_, _, err = l.client.RenewLock(v, l.opts)
+	return err
+}
+
+// Release releases the held lock.
+func (l *Locker) Release() error {
+	v, err := l.heldVariable()
+	if err != nil {
+		return err
+	}
+	_, _, err = l.client.ReleaseLock(v, l.opts)
+
+	l.l.Lock()
+	l.lock = nil
+	l.l.Unlock()
+	return err
+}
+
+// Hold acquires the lock, waiting until it is available, and then calls fn
+// while renewing the lock in the background. The context passed to fn is
+// cancelled if the lock is lost, in which case Hold returns ErrLockLost. The
+// lock is released once fn returns.
+func (l *Locker) Hold(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := l.AcquireWait(ctx); err != nil {
+		return err
+	}
+
+	holdCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lostCh := make(chan struct{})
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		if l.renewLoop(holdCtx) {
+			close(lostCh)
+			cancel()
+		}
+	}()
+
+	err := fn(holdCtx)
+	cancel()
+	<-doneCh
+
+	select {
+	case <-lostCh:
+		return ErrLockLost
+	default:
+	}
+
+	if releaseErr := l.Release(); releaseErr != nil && err == nil {
+		err = releaseErr
+	}
+	return err
+}
+
+// renewLoop renews the lock every half TTL until the context is done. It
+// returns true if the lock was lost, either because the server reported a
+// conflict or because it could not be renewed within the TTL.
+func (l *Locker) renewLoop(ctx context.Context) bool {
+	interval := l.ttl / 2
+	lastRenew := time.Now()
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timer.C:
+		}
+
+		err := l.Renew()
+		switch {
+		case err == nil:
+			lastRenew = time.Now()
+			timer.Reset(interval)
+		case errors.As(err, &ErrLockConflict{}):
+			return true
+		case time.Since(lastRenew) >= l.ttl:
+			return true
+		default:
+			// Retry transient errors more aggressively until the TTL
+			// expires.
+			timer.Reset(interval / 4)
+		}
+	}
+}
+
+// heldVariable returns the secure variable used to identify the held lock.
+func (l *Locker) heldVariable() (*SecureVariable, error) {
+	l.l.Lock()
+	defer l.l.Unlock()
+	if l.lock == nil {
+		return nil, ErrLockConflict{}
+	}
+	return &SecureVariable{
+		Path: l.path,
+		Lock: &SecureVariableLock{ID: l.lock.ID, TTL: l.lock.TTL},
+	}, nil
+}
+
+// retryInterval returns how long to wait before retrying to acquire a lock
+// held by another holder.
+func (l *Locker) retryInterval(conflict *SecureVariable) time.Duration {
+	ttl := l.ttl
+	if conflict != nil && conflict.Lock != nil && conflict.Lock.TTL > 0 {
+		ttl = conflict.Lock.TTL
+	}
+	if ttl <= 0 {
+		return time.Second
+	}
+	return ttl / 2
+}
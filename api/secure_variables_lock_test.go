@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecureVariables_Locks(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	nsv := c.SecureVariables()
+
+	// Acquire the lock, which creates the variable
+	held, _, err := nsv.AcquireLock(&SecureVariable{Path: "locks/leader"}, nil)
+	require.NoError(t, err)
+	require.NotNil(t, held.Lock)
+	require.NotEmpty(t, held.Lock.ID)
+
+	// A second acquire is a conflict
+	_, _, err = nsv.AcquireLock(&SecureVariable{Path: "locks/leader"}, nil)
+	require.Error(t, err)
+	require.True(t, errors.As(err, &ErrLockConflict{}))
+
+	// Renew and release the lock
+	_, _, err = nsv.RenewLock(held, nil)
+	require.NoError(t, err)
+	_, _, err = nsv.ReleaseLock(held, nil)
+	require.NoError(t, err)
+
+	// Releasing a lock which is no longer held is a conflict
+	_, _, err = nsv.ReleaseLock(held, nil)
+	require.True(t, errors.As(err, &ErrLockConflict{}))
+}
+
+func TestSecureVariables_Locker(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	nsv := c.SecureVariables()
+	locker := nsv.NewLocker("locks/locker", 10*time.Second, nil)
+
+	err := locker.Hold(context.Background(), func(ctx context.Context) error {
+		// The lock is held while fn runs
+		other := nsv.NewLocker("locks/locker", 10*time.Second, nil)
+		err := other.Acquire()
+		require.True(t, errors.As(err, &ErrLockConflict{}))
+		return nil
+	})
+	require.NoError(t, err)
+
+	// The lock is released once fn returns
+	sv, _, err := nsv.Read("locks/locker", nil)
+	require.NoError(t, err)
+	require.Nil(t, sv.Lock)
+}
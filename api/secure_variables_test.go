@@ -252,3 +252,32 @@ func TestSecureVariable_CreateReturnsContent(t *testing.T) {
 	require.NotNil(t, sv1n)
 	require.Equal(t, sv1.Items, sv1n.Items)
 }
+
+func TestSecureVariables_History(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	nsv := c.SecureVariables()
+	sv1 := NewSecureVariable("history/variable")
+	sv1.Items["k1"] = "v1"
+	_, _, err := nsv.Create(sv1, nil)
+	require.NoError(t, err)
+
+	sv1.Items["k1"] = "v2"
+	_, _, err = nsv.Update(sv1, nil)
+	require.NoError(t, err)
+
+	versions, _, err := nsv.History("history/variable", nil)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	require.Equal(t, uint64(1), versions[0].Version)
+	require.Equal(t, uint64(0), versions[1].Version)
+
+	prior, _, err := nsv.ReadVersion("history/variable", 0, nil)
+	require.NoError(t, err)
+	require.Equal(t, "v1", prior.Items["k1"])
+
+	_, _, err = nsv.ReadVersion("history/variable", 5, nil)
+	require.EqualError(t, err, ErrVariableNotFound)
+}
@@ -21,3 +21,20 @@ func (s *System) ReconcileSummaries() error {
 	_, err := s.client.write("/v1/system/reconcile/summaries", &req, nil, nil)
 	return err
 }
+
+// SystemRebalanceResponse is used to respond to a system rebalance request.
+type SystemRebalanceResponse struct {
+	EvalIDs []string
+	WriteMeta
+}
+
+// Rebalance creates a rebalance evaluation for every running service job in
+// the cluster and returns their IDs.
+func (s *System) Rebalance(q *WriteOptions) ([]string, *WriteMeta, error) {
+	var resp SystemRebalanceResponse
+	wm, err := s.client.write("/v1/system/rebalance", nil, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.EvalIDs, wm, nil
+}
@@ -276,6 +276,7 @@ type Spread struct {
 	Attribute    string          `hcl:"attribute,optional"`
 	Weight       *int8           `hcl:"weight,optional"`
 	SpreadTarget []*SpreadTarget `hcl:"target,block"`
+	MaxSkew      *int            `mapstructure:"max_skew" hcl:"max_skew,optional"`
 }
 
 // SpreadTarget is used to serialize target allocation spread percentages
@@ -339,6 +340,7 @@ type MigrateStrategy struct {
 	HealthCheck     *string        `mapstructure:"health_check" hcl:"health_check,optional"`
 	MinHealthyTime  *time.Duration `mapstructure:"min_healthy_time" hcl:"min_healthy_time,optional"`
 	HealthyDeadline *time.Duration `mapstructure:"healthy_deadline" hcl:"healthy_deadline,optional"`
+	Checkpoint      *bool          `mapstructure:"checkpoint" hcl:"checkpoint,optional"`
 }
 
 func DefaultMigrateStrategy() *MigrateStrategy {
@@ -347,6 +349,7 @@ func DefaultMigrateStrategy() *MigrateStrategy {
 		HealthCheck:     pointerOf("checks"),
 		MinHealthyTime:  pointerOf(10 * time.Second),
 		HealthyDeadline: pointerOf(5 * time.Minute),
+		Checkpoint:      pointerOf(false),
 	}
 }
 
@@ -367,6 +370,9 @@ func (m *MigrateStrategy) Canonicalize() {
 	if m.HealthyDeadline == nil {
 		m.HealthyDeadline = defaults.HealthyDeadline
 	}
+	if m.Checkpoint == nil {
+		m.Checkpoint = defaults.Checkpoint
+	}
 }
 
 func (m *MigrateStrategy) Merge(o *MigrateStrategy) {
@@ -382,6 +388,9 @@ func (m *MigrateStrategy) Merge(o *MigrateStrategy) {
 	if o.HealthyDeadline != nil {
 		m.HealthyDeadline = o.HealthyDeadline
 	}
+	if o.Checkpoint != nil {
+		m.Checkpoint = o.Checkpoint
+	}
 }
 
 func (m *MigrateStrategy) Copy() *MigrateStrategy {
@@ -393,6 +402,73 @@ func (m *MigrateStrategy) Copy() *MigrateStrategy {
 	return nm
 }
 
+const (
+	GangScopeGroup = "group"
+	GangScopeJob   = "job"
+)
+
+// GangStrategy requires all of the allocations of a task group, or of every
+// task group of the job with a job scoped gang, to be placed together.
+type GangStrategy struct {
+	Scope *string `mapstructure:"scope" hcl:"scope,optional"`
+}
+
+func (g *GangStrategy) Canonicalize() {
+	if g == nil {
+		return
+	}
+	if g.Scope == nil {
+		g.Scope = pointerOf(GangScopeGroup)
+	}
+}
+
+// PreemptionPolicy bounds the preemption performed to place the allocations
+// of a task group, and controls whether they may be preempted themselves.
+type PreemptionPolicy struct {
+	MaxPreemptions    *int  `mapstructure:"max_preemptions" hcl:"max_preemptions,optional"`
+	ProtectedPriority *int  `mapstructure:"protected_priority" hcl:"protected_priority,optional"`
+	Protected         *bool `mapstructure:"protected" hcl:"protected,optional"`
+}
+
+func (p *PreemptionPolicy) Canonicalize() {
+	if p == nil {
+		return
+	}
+	if p.MaxPreemptions == nil {
+		p.MaxPreemptions = pointerOf(0)
+	}
+	if p.ProtectedPriority == nil {
+		p.ProtectedPriority = pointerOf(0)
+	}
+	if p.Protected == nil {
+		p.Protected = pointerOf(false)
+	}
+}
+
+// SchedulingWindow restricts the times at which the allocations of a task
+// group may be placed or updated.
+type SchedulingWindow struct {
+	Start    *string  `mapstructure:"start" hcl:"start,optional"`
+	End      *string  `mapstructure:"end" hcl:"end,optional"`
+	TimeZone *string  `mapstructure:"time_zone" hcl:"time_zone,optional"`
+	Days     []string `mapstructure:"days" hcl:"days,optional"`
+}
+
+func (w *SchedulingWindow) Canonicalize() {
+	if w == nil {
+		return
+	}
+	if w.Start == nil {
+		w.Start = pointerOf("")
+	}
+	if w.End == nil {
+		w.End = pointerOf("")
+	}
+	if w.TimeZone == nil {
+		w.TimeZone = pointerOf("UTC")
+	}
+}
+
 // VolumeRequest is a representation of a storage volume that a TaskGroup wishes to use.
 type VolumeRequest struct {
 	Name           string           `hcl:"name,label"`
@@ -452,6 +528,9 @@ type TaskGroup struct {
 	MaxClientDisconnect       *time.Duration            `mapstructure:"max_client_disconnect" hcl:"max_client_disconnect,optional"`
 	Scaling                   *ScalingPolicy            `hcl:"scaling,block"`
 	Consul                    *Consul                   `hcl:"consul,block"`
+	Gang                      *GangStrategy             `hcl:"gang,block"`
+	Preemption                *PreemptionPolicy         `hcl:"preemption,block"`
+	Window                    *SchedulingWindow         `hcl:"window,block"`
 }
 
 // NewTaskGroup creates a new TaskGroup.
@@ -541,6 +620,10 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 		g.Migrate.Canonicalize()
 	}
 
+	g.Gang.Canonicalize()
+	g.Preemption.Canonicalize()
+	g.Window.Canonicalize()
+
 	var defaultRestartPolicy *RestartPolicy
 	switch *job.Type {
 	case "service", "system":
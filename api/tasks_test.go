@@ -665,6 +665,7 @@ func TestTaskGroup_Canonicalize_MigrateStrategy(t *testing.T) {
 				HealthCheck:     pointerOf("checks"),
 				MinHealthyTime:  pointerOf(10 * time.Second),
 				HealthyDeadline: pointerOf(5 * time.Minute),
+				Checkpoint:      pointerOf(false),
 			},
 		},
 		{
@@ -682,6 +683,7 @@ func TestTaskGroup_Canonicalize_MigrateStrategy(t *testing.T) {
 				HealthCheck:     pointerOf(""),
 				MinHealthyTime:  pointerOf(time.Duration(0)),
 				HealthyDeadline: pointerOf(time.Duration(0)),
+				Checkpoint:      pointerOf(false),
 			},
 		},
 		{
@@ -699,6 +701,7 @@ func TestTaskGroup_Canonicalize_MigrateStrategy(t *testing.T) {
 				HealthCheck:     pointerOf("checks"),
 				MinHealthyTime:  pointerOf(time.Duration(2)),
 				HealthyDeadline: pointerOf(time.Duration(2)),
+				Checkpoint:      pointerOf(false),
 			},
 		},
 		{
@@ -716,6 +719,7 @@ func TestTaskGroup_Canonicalize_MigrateStrategy(t *testing.T) {
 				HealthCheck:     pointerOf("checks"),
 				MinHealthyTime:  pointerOf(time.Duration(2)),
 				HealthyDeadline: pointerOf(time.Duration(2)),
+				Checkpoint:      pointerOf(false),
 			},
 		},
 		{
@@ -734,6 +738,7 @@ func TestTaskGroup_Canonicalize_MigrateStrategy(t *testing.T) {
 				HealthCheck:     pointerOf("checks"),
 				MinHealthyTime:  pointerOf(time.Duration(2)),
 				HealthyDeadline: pointerOf(time.Duration(2)),
+				Checkpoint:      pointerOf(false),
 			},
 		},
 		{
@@ -753,6 +758,7 @@ func TestTaskGroup_Canonicalize_MigrateStrategy(t *testing.T) {
 				HealthCheck:     pointerOf("checks"),
 				MinHealthyTime:  pointerOf(time.Duration(2)),
 				HealthyDeadline: pointerOf(time.Duration(2)),
+				Checkpoint:      pointerOf(false),
 			},
 		},
 		{
@@ -767,6 +773,24 @@ func TestTaskGroup_Canonicalize_MigrateStrategy(t *testing.T) {
 				HealthCheck:     pointerOf("checks"),
 				MinHealthyTime:  pointerOf(10 * time.Second),
 				HealthyDeadline: pointerOf(5 * time.Minute),
+				Checkpoint:      pointerOf(false),
+			},
+		},
+		{
+			desc:    "Checkpoint from job",
+			jobType: "service",
+			jobMigrate: &MigrateStrategy{
+				Checkpoint: pointerOf(true),
+			},
+			taskMigrate: &MigrateStrategy{
+				MaxParallel: pointerOf(2),
+			},
+			expected: &MigrateStrategy{
+				MaxParallel:     pointerOf(2),
+				HealthCheck:     pointerOf("checks"),
+				MinHealthyTime:  pointerOf(10 * time.Second),
+				HealthyDeadline: pointerOf(5 * time.Minute),
+				Checkpoint:      pointerOf(true),
 			},
 		},
 	}
@@ -6,6 +6,7 @@ import (
 	metrics "github.com/armon/go-metrics"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -21,6 +22,11 @@ const (
 	// tokenCacheSize is the number of ACL tokens to keep cached. Tokens have a fetching cost,
 	// so we keep the hot tokens cached to reduce the lookups.
 	tokenCacheSize = 64
+
+	// roleCacheSize is the number of ACL roles to keep cached. Looking up
+	// roles requires an RPC call, so we keep the hot roles cached to reduce
+	// the number of lookups.
+	roleCacheSize = 64
 )
 
 // clientACLResolver holds the state required for client resolution
@@ -34,6 +40,9 @@ type clientACLResolver struct {
 
 	// tokenCache is used to maintain the fetched token objects
 	tokenCache *lru.TwoQueueCache
+
+	// roleCache is used to maintain the fetched ACL role objects
+	roleCache *lru.TwoQueueCache
 }
 
 // init is used to setup the client resolver state
@@ -52,13 +61,18 @@ func (c *clientACLResolver) init() error {
 	if err != nil {
 		return err
 	}
+	c.roleCache, err = lru.New2Q(roleCacheSize)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
-// cachedACLValue is used to manage ACL Token or Policy TTLs
+// cachedACLValue is used to manage ACL Token, Policy, or Role TTLs
 type cachedACLValue struct {
 	Token     *structs.ACLToken
 	Policy    *structs.ACLPolicy
+	Role      *structs.ACLRole
 	CacheTime time.Time
 }
 
@@ -95,13 +109,26 @@ func (c *Client) resolveTokenAndACL(secretID string) (*acl.ACL, *structs.ACLToke
 		return nil, nil, structs.ErrTokenNotFound
 	}
 
+	// Give the token expiry some slight leeway in case the client and server
+	// clocks are skewed.
+	if token.IsExpired(time.Now().Add(2 * time.Second)) {
+		return nil, nil, structs.ErrTokenExpired
+	}
+
 	// Check if this is a management token
 	if token.Type == structs.ACLManagementToken {
 		return acl.ManagementACL, token, nil
 	}
 
+	// Resolve the policies granted directly to the token, along with those
+	// granted by any linked ACL roles
+	policyNames, err := c.resolveTokenPolicyNames(token)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Resolve the policies
-	policies, err := c.resolvePolicies(token.SecretID, token.Policies)
+	policies, err := c.resolvePolicies(token.SecretID, policyNames)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -227,3 +254,96 @@ func (c *Client) resolvePolicies(secretID string, policies []string) ([]*structs
 	// Return the valid policies
 	return out, nil
 }
+
+// resolveTokenPolicyNames returns the names of all ACL policies granted to the
+// token, either directly or via linked ACL roles. Duplicate names are removed.
+func (c *Client) resolveTokenPolicyNames(token *structs.ACLToken) ([]string, error) {
+	if len(token.Roles) == 0 {
+		return token.Policies, nil
+	}
+
+	roles, err := c.resolveRoles(token.SecretID, token.Roles)
+	if err != nil {
+		return nil, err
+	}
+
+	policyNames := make(map[string]struct{}, len(token.Policies))
+	for _, policyName := range token.Policies {
+		policyNames[policyName] = struct{}{}
+	}
+	for _, role := range roles {
+		for _, policyLink := range role.Policies {
+			policyNames[policyLink.Name] = struct{}{}
+		}
+	}
+	return helper.SetToSliceString(policyNames), nil
+}
+
+// resolveRoles is used to translate a set of ACL role links into the role
+// objects. Roles are cached in the same manner as policies, using the policy
+// TTL, and are faulted from a server as necessary. Roles which no longer exist
+// are ignored, as the servers do when resolving a token.
+func (c *Client) resolveRoles(secretID string, roleLinks []*structs.ACLTokenRoleLink) ([]*structs.ACLRole, error) {
+	var out []*structs.ACLRole
+	var expired []*structs.ACLRole
+	var missing []string
+
+	// Scan the cache for each role
+	for _, roleLink := range roleLinks {
+		// Lookup the role in the cache
+		raw, ok := c.roleCache.Get(roleLink.ID)
+		if !ok {
+			missing = append(missing, roleLink.ID)
+			continue
+		}
+
+		// Check if the cached value is valid or expired
+		cached := raw.(*cachedACLValue)
+		if cached.Age() <= c.GetConfig().ACLPolicyTTL {
+			out = append(out, cached.Role)
+		} else {
+			expired = append(expired, cached.Role)
+		}
+	}
+
+	// Hot-path if we have no missing or expired roles
+	if len(missing)+len(expired) == 0 {
+		return out, nil
+	}
+
+	// Lookup the missing and expired roles
+	fetch := missing
+	for _, r := range expired {
+		fetch = append(fetch, r.ID)
+	}
+	req := structs.ACLRolesByIDRequest{
+		ACLRoleIDs: fetch,
+		QueryOptions: structs.QueryOptions{
+			Region:     c.Region(),
+			AuthToken:  secretID,
+			AllowStale: true,
+		},
+	}
+	var resp structs.ACLRolesByIDResponse
+	if err := c.RPC(structs.ACLGetRolesByIDRPCMethod, &req, &resp); err != nil {
+		// If we encounter an error but have cached roles, mask the error and extend the cache
+		if len(missing) == 0 {
+			c.logger.Warn("failed to resolve roles, using expired cached value", "error", err)
+			out = append(out, expired...)
+			return out, nil
+		}
+		return nil, err
+	}
+
+	// Handle each output
+	for _, role := range resp.ACLRoles {
+		c.roleCache.Add(role.ID, &cachedACLValue{
+			Role:      role,
+			CacheTime: time.Now(),
+		})
+		out = append(out, role)
+	}
+
+	// Return the valid roles
+	return out, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestClient_ACL_resolveTokenValue(t *testing.T) {
@@ -106,6 +107,60 @@ func TestClient_ACL_resolvePolicies(t *testing.T) {
 	}
 }
 
+func TestClient_ACL_resolveRoles(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, _, _, cleanupS1 := testACLServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	c1, cleanup := TestClient(t, func(c *config.Config) {
+		c.RPCHandler = s1
+		c.ACLEnabled = true
+	})
+	defer cleanup()
+
+	// Create the policies and ACL roles, along with a token that is linked to
+	// both roles.
+	policy1 := mock.ACLPolicy()
+	policy1.Name = "mocked-test-policy-1"
+	policy2 := mock.ACLPolicy()
+	policy2.Name = "mocked-test-policy-2"
+	require.NoError(t, s1.State().UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 100, []*structs.ACLPolicy{policy1, policy2}))
+
+	aclRole1 := mock.ACLRole()
+	aclRole2 := mock.ACLRole()
+	aclRole2.Policies = []*structs.ACLRolePolicyLink{{Name: policy2.Name}}
+	require.NoError(t, s1.State().UpsertACLRoles(
+		structs.MsgTypeTestSetup, 110, []*structs.ACLRole{aclRole1, aclRole2}, false))
+
+	token := mock.ACLToken()
+	token.Policies = nil
+	token.Roles = []*structs.ACLTokenRoleLink{
+		{ID: aclRole1.ID, Name: aclRole1.Name},
+		{ID: aclRole2.ID, Name: aclRole2.Name},
+	}
+	require.NoError(t, s1.State().UpsertACLTokens(
+		structs.MsgTypeTestSetup, 120, []*structs.ACLToken{token}))
+
+	// Test the client resolution.
+	out, err := c1.resolveRoles(token.SecretID, token.Roles)
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+
+	// Test caching.
+	out2, err := c1.resolveRoles(token.SecretID, token.Roles)
+	require.NoError(t, err)
+	require.Len(t, out2, 2)
+	require.ElementsMatch(t, out, out2)
+
+	// The token should be granted the deduplicated policies of both roles.
+	policyNames, err := c1.resolveTokenPolicyNames(token)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{policy1.Name, policy2.Name}, policyNames)
+}
+
 func TestClient_ACL_ResolveToken_Disabled(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/fs"
 	"io/ioutil"
 	"net/http"
 	"os"
@@ -58,6 +59,11 @@ var (
 	// directory
 	TaskSecrets = "secrets"
 
+	// CheckpointDirName is the name of the directory in each alloc directory
+	// holding the checkpoints of its tasks. It is included in snapshots but
+	// is not visible to tasks.
+	CheckpointDirName = "checkpoint"
+
 	// TaskDirs is the set of directories created in each tasks directory.
 	TaskDirs = map[string]os.FileMode{TmpDirName: os.ModeSticky | 0777}
 
@@ -131,7 +137,7 @@ func (d *AllocDir) NewTaskDir(name string) *TaskDir {
 }
 
 // Snapshot creates an archive of the files and directories in the data dir of
-// the allocation and the task local directories
+// the allocation, the task local directories and the task checkpoints
 //
 // Since a valid tar may have been written even when an error occurs, a special
 // file "NOMAD-${ALLOC_ID}-ERROR.log" will be appended to the tar with the
@@ -144,6 +150,12 @@ func (d *AllocDir) Snapshot(w io.Writer) error {
 	rootPaths := []string{allocDataDir}
 	for _, taskdir := range d.TaskDirs {
 		rootPaths = append(rootPaths, taskdir.LocalDir)
+
+		// checkpoints only exist for tasks stopped by their drivers with
+		// a checkpoint
+		if pathExists(taskdir.CheckpointDir) {
+			rootPaths = append(rootPaths, taskdir.CheckpointDir)
+		}
 	}
 
 	tw := tar.NewWriter(w)
@@ -215,7 +227,8 @@ func (d *AllocDir) Snapshot(w io.Writer) error {
 	return nil
 }
 
-// Move other alloc directory's shared path and local dir to this alloc dir.
+// Move other alloc directory's shared path, local dirs and checkpoints to this
+// alloc dir.
 func (d *AllocDir) Move(other *AllocDir, tasks []*structs.Task) error {
 	d.mu.RLock()
 	if !d.built {
@@ -255,6 +268,17 @@ func (d *AllocDir) Move(other *AllocDir, tasks []*structs.Task) error {
 				return fmt.Errorf("error moving task %q local dir: %v", task.Name, err)
 			}
 		}
+
+		otherCheckpoint := filepath.Join(other.AllocDir, CheckpointDirName, task.Name)
+		if pathExists(otherCheckpoint) {
+			checkpointDir := filepath.Join(d.AllocDir, CheckpointDirName)
+			if err := os.MkdirAll(checkpointDir, 0700); err != nil {
+				return fmt.Errorf("error creating checkpoint dir: %v", err)
+			}
+			if err := os.Rename(otherCheckpoint, filepath.Join(checkpointDir, task.Name)); err != nil {
+				return fmt.Errorf("error moving task %q checkpoint: %v", task.Name, err)
+			}
+		}
 	}
 
 	return nil
@@ -545,6 +569,36 @@ func pathExists(path string) bool {
 	return true
 }
 
+// chownTree changes the owner of root and everything beneath it to the given
+// user and group, except for files already owned by one of the size IDs
+// starting at them, such as those created by the tasks of a user namespace.
+// Symlinks are not followed, and files with several links are skipped as
+// they may be linked to files outside of the tree.
+func chownTree(root string, uid, gid, size int) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		owner, group := getOwner(fi)
+		if owner >= uid && owner < uid+size && group >= gid && group < gid+size {
+			return nil
+		}
+		if !fi.IsDir() && getLinks(fi) > 1 {
+			return nil
+		}
+
+		if err := os.Lchown(path, uid, gid); err != nil {
+			return fmt.Errorf("Couldn't change owner/group of %v to (uid: %v, gid: %v): %v", path, uid, gid, err)
+		}
+		return nil
+	})
+}
+
 // pathEmpty returns true if a path exists, is listable, and is empty. If the
 // path does not exist or is not listable an error is returned.
 func pathEmpty(path string) (bool, error) {
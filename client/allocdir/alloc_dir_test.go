@@ -186,6 +186,14 @@ func TestAllocDir_Snapshot(t *testing.T) {
 		t.Fatalf("couldn't write symlink to task local directory :%v", err)
 	}
 
+	// Write a checkpoint of the first task only
+	if err := os.MkdirAll(td1.CheckpointDir, 0700); err != nil {
+		t.Fatalf("couldn't create checkpoint directory: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(td1.CheckpointDir, "inventory.img"), exp, 0600); err != nil {
+		t.Fatalf("couldn't write file to checkpoint directory: %v", err)
+	}
+
 	var b bytes.Buffer
 	if err := d.Snapshot(&b); err != nil {
 		t.Fatalf("err: %v", err)
@@ -209,7 +217,7 @@ func TestAllocDir_Snapshot(t *testing.T) {
 		}
 	}
 
-	if len(files) != 2 {
+	if len(files) != 3 {
 		t.Fatalf("bad files: %#v", files)
 	}
 	if len(links) != 2 {
@@ -261,6 +269,15 @@ func TestAllocDir_Move(t *testing.T) {
 		t.Fatalf("couldn't write to task local directory: %v", err)
 	}
 
+	// Write a checkpoint of the task
+	if err := os.MkdirAll(td1.CheckpointDir, 0700); err != nil {
+		t.Fatalf("couldn't create checkpoint directory: %v", err)
+	}
+	file3 := "inventory.img"
+	if err := ioutil.WriteFile(filepath.Join(td1.CheckpointDir, file3), exp2, 0600); err != nil {
+		t.Fatalf("couldn't write to checkpoint directory: %v", err)
+	}
+
 	// Move the d1 allocdir to d2
 	if err := d2.Move(d1, []*structs.Task{t1}); err != nil {
 		t.Fatalf("err: %v", err)
@@ -276,6 +293,11 @@ func TestAllocDir_Move(t *testing.T) {
 	if err != nil || fi == nil {
 		t.Fatalf("task local dir was not moved")
 	}
+
+	fi, err = os.Stat(filepath.Join(d2.TaskDirs[t1.Name].CheckpointDir, file3))
+	if err != nil || fi == nil {
+		t.Fatalf("task checkpoint was not moved")
+	}
 }
 
 func TestAllocDir_EscapeChecking(t *testing.T) {
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
 	"golang.org/x/sys/unix"
 )
 
@@ -157,3 +158,79 @@ func TestLinuxUnprivilegedSecretDir(t *testing.T) {
 		t.Fatalf("error removing nonexistent secrets dir %q: %v", secretsDir, err)
 	}
 }
+
+// TestLinuxRootChown asserts the directories written by tasks and their
+// contents are owned by the root of their user namespace, but not files
+// embedded in the chroot or linked from elsewhere.
+func TestLinuxRootChown(t *testing.T) {
+	ci.Parallel(t)
+	if unix.Geteuid() != 0 {
+		t.Skip("Must be run as root")
+	}
+
+	d := NewAllocDir(testlog.HCLogger(t), t.TempDir(), "test")
+	defer d.Destroy()
+	td := d.NewTaskDir(t1.Name)
+	if err := d.Build(); err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+	if err := td.Build(true, map[string]string{"/etc/passwd": "/etc/passwd"}); err != nil {
+		t.Fatalf("TaskDir.Build failed: %v", err)
+	}
+
+	// files written before the task starts, such as artifacts and migrated
+	// data, and one created by a user of the namespace
+	artifact := filepath.Join(td.LocalDir, "artifact", "app.conf")
+	data := filepath.Join(d.SharedDir, SharedDataDir, "db")
+	owned := filepath.Join(td.LocalDir, "owned")
+	linked := filepath.Join(td.LocalDir, "passwd")
+	for _, path := range []string{artifact, data, owned} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("error creating %q: %v", path, err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatalf("error writing %q: %v", path, err)
+		}
+	}
+	if err := os.Lchown(owned, 201000, 301000); err != nil {
+		t.Fatalf("error chowning %q: %v", owned, err)
+	}
+	if err := os.Link(filepath.Join(td.Dir, "etc/passwd"), linked); err != nil {
+		t.Fatalf("error linking %q: %v", linked, err)
+	}
+
+	if err := td.Chown(200000, 300000, 65536); err != nil {
+		t.Fatalf("TaskDir.Chown failed: %v", err)
+	}
+
+	owner := func(path string) (uint32, uint32) {
+		var st unix.Stat_t
+		if err := unix.Lstat(path, &st); err != nil {
+			t.Fatalf("error stat'ing %q: %v", path, err)
+		}
+		return st.Uid, st.Gid
+	}
+	for _, path := range []string{
+		d.SharedDir,
+		filepath.Join(d.SharedDir, SharedDataDir),
+		data,
+		td.Dir,
+		td.LocalDir,
+		td.SecretsDir,
+		filepath.Join(td.Dir, TmpDirName),
+		filepath.Dir(artifact),
+		artifact,
+	} {
+		if uid, gid := owner(path); uid != 200000 || gid != 300000 {
+			t.Fatalf("expected %q to be owned by 200000:300000 but found %d:%d", path, uid, gid)
+		}
+	}
+	if uid, gid := owner(owned); uid != 201000 || gid != 301000 {
+		t.Fatalf("expected files of the namespace to keep their owner but found %d:%d", uid, gid)
+	}
+	for _, path := range []string{filepath.Join(td.Dir, "etc/passwd"), linked} {
+		if uid, gid := owner(path); uid != 0 || gid != 0 {
+			t.Fatalf("expected %q to keep its owner but found %d:%d", path, uid, gid)
+		}
+	}
+}
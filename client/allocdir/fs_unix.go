@@ -105,3 +105,12 @@ func getOwner(fi os.FileInfo) (int, int) {
 	}
 	return int(stat.Uid), int(stat.Gid)
 }
+
+// getLinks returns the number of hard links to the file.
+func getLinks(fi os.FileInfo) uint64 {
+	stat, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 1
+	}
+	return uint64(stat.Nlink)
+}
@@ -59,3 +59,8 @@ func MountSpecialDirs(taskDir string) error {
 func getOwner(os.FileInfo) (int, int) {
 	return idUnsupported, idUnsupported
 }
+
+// getLinks doesn't work on Windows, where files are never chowned
+func getLinks(os.FileInfo) uint64 {
+	return 1
+}
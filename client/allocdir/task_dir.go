@@ -39,6 +39,11 @@ type TaskDir struct {
 	// <task_dir>/secrets/
 	SecretsDir string
 
+	// CheckpointDir is the path to the directory drivers checkpoint the
+	// task to on the host
+	// <alloc_dir>/checkpoint/<task>/
+	CheckpointDir string
+
 	// skip embedding these paths in chroots. Used for avoiding embedding
 	// client.alloc_dir recursively.
 	skip map[string]struct{}
@@ -66,6 +71,7 @@ func newTaskDir(logger hclog.Logger, clientAllocDir, allocDir, taskName string)
 		SharedTaskDir:  filepath.Join(taskDir, SharedAllocName),
 		LocalDir:       filepath.Join(taskDir, TaskLocal),
 		SecretsDir:     filepath.Join(taskDir, TaskSecrets),
+		CheckpointDir:  filepath.Join(allocDir, CheckpointDirName, taskName),
 		skip:           skip,
 		logger:         logger,
 	}
@@ -138,6 +144,28 @@ func (t *TaskDir) Build(createChroot bool, chroot map[string]string) error {
 	return nil
 }
 
+// Chown changes the owner of the directories the task writes to, the ones
+// created by Build and the shared alloc dir, and everything in them to the
+// given user and group, such as the root of the user namespace the task is run
+// in. Files embedded in the chroot, and those already owned by one of the size
+// IDs starting at uid and gid, keep their owner.
+func (t *TaskDir) Chown(uid, gid, size int) error {
+	if err := os.Lchown(t.Dir, uid, gid); err != nil {
+		return fmt.Errorf("Couldn't change owner/group of %v to (uid: %v, gid: %v): %v", t.Dir, uid, gid, err)
+	}
+
+	paths := []string{t.SharedAllocDir, t.LocalDir, t.SecretsDir}
+	for dir := range TaskDirs {
+		paths = append(paths, filepath.Join(t.Dir, dir))
+	}
+	for _, path := range paths {
+		if err := chownTree(path, uid, gid, size); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // buildChroot takes a mapping of absolute directory or file paths on the host
 // to their intended, relative location within the task directory. This
 // attempts hardlink and then defaults to copying. If the path exists on the
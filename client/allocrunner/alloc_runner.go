@@ -20,6 +20,7 @@ import (
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/client/lib/cgutil"
+	"github.com/hashicorp/nomad/client/lib/userns"
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	"github.com/hashicorp/nomad/client/serviceregistration"
@@ -159,6 +160,9 @@ type allocRunner struct {
 	// cpusetManager is responsible for configuring task cgroups if supported by the platform
 	cpusetManager cgutil.CpusetManager
 
+	// usernsPool allocates the user namespaces of tasks if configured
+	usernsPool *userns.Pool
+
 	// devicemanager is used to mount devices as well as lookup device
 	// statistics
 	devicemanager devicemanager.Manager
@@ -231,6 +235,7 @@ func NewAllocRunner(config *Config) (*allocRunner, error) {
 		dynamicRegistry:          config.DynamicRegistry,
 		csiManager:               config.CSIManager,
 		cpusetManager:            config.CpusetManager,
+		usernsPool:               config.UserNamespacePool,
 		devicemanager:            config.DeviceManager,
 		driverManager:            config.DriverManager,
 		serversContactedCh:       config.ServersContactedCh,
@@ -117,6 +117,9 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 	// create network isolation setting shim
 	ns := &allocNetworkIsolationSetter{ar: ar}
 
+	// create user namespace setting shim
+	uns := &allocUserNamespaceSetter{ar: ar}
+
 	// create hook resource setting shim
 	hrs := &allocHookResourceSetter{ar: ar}
 	hrs.SetAllocHookResources(&cstructs.AllocHookResources{})
@@ -149,6 +152,7 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 		newCgroupHook(ar.Alloc(), ar.cpusetManager),
 		newUpstreamAllocsHook(hookLogger, ar.prevAllocWatcher),
 		newDiskMigrationHook(hookLogger, ar.prevAllocMigrator, ar.allocDir),
+		newUsernsHook(hookLogger, alloc, ar.usernsPool, ar.driverManager, uns),
 		newAllocHealthWatcherHook(hookLogger, alloc, hs, ar.Listener(), ar.consulClient, ar.checkStore),
 		newNetworkHook(hookLogger, ns, alloc, nm, nc, ar, builtTaskEnv),
 		newGroupServiceHook(groupServiceHookConfig{
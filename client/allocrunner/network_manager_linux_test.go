@@ -53,6 +53,14 @@ var mockDrivers = map[string]drivers.DriverPlugin{
 			}, nil
 		},
 	},
+	"userns": &testutils.MockDriver{
+		CapabilitiesF: func() (*drivers.Capabilities, error) {
+			return &drivers.Capabilities{
+				NetIsolationModes: []drivers.NetIsolationMode{drivers.NetIsolationModeHost},
+				UserNamespaces:    true,
+			}, nil
+		},
+	},
 }
 
 type mockDriverManager struct {
@@ -0,0 +1,96 @@
+package taskrunner
+
+import (
+	"context"
+	"os"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+var _ interfaces.TaskPrestartHook = (*checkpointHook)(nil)
+var _ interfaces.TaskPoststartHook = (*checkpointHook)(nil)
+var _ interfaces.TaskPreKillHook = (*checkpointHook)(nil)
+
+// checkpointHook checkpoints tasks of allocations migrating off a draining
+// node, and restores them from the checkpoint migrated with the ephemeral
+// disk of the previous allocation.
+type checkpointHook struct {
+	tr *TaskRunner
+
+	logger hclog.Logger
+}
+
+func newCheckpointHook(tr *TaskRunner, logger hclog.Logger) *checkpointHook {
+	h := &checkpointHook{
+		tr: tr,
+	}
+	h.logger = logger.Named(h.Name())
+	return h
+}
+
+func (h *checkpointHook) Name() string {
+	return "checkpoint"
+}
+
+// Prestart has the task restored if a checkpoint was migrated from the
+// previous allocation.
+func (h *checkpointHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
+	// Only the first start of the task is restored
+	resp.Done = true
+
+	if !h.enabled(h.tr.Alloc()) {
+		return nil
+	}
+
+	dir := req.TaskDir.CheckpointDir
+	if entries, err := os.ReadDir(dir); err != nil || len(entries) == 0 {
+		return nil
+	}
+
+	h.logger.Debug("restoring task from checkpoint", "dir", dir)
+	h.tr.setRestore(true)
+	h.tr.EmitEvent(structs.NewTaskEvent(structs.TaskSetup).SetMessage("Restoring task from checkpoint"))
+	return nil
+}
+
+// Poststart removes the checkpoint once the task has been restored from it,
+// so that restarts of the task start it afresh.
+func (h *checkpointHook) Poststart(ctx context.Context, req *interfaces.TaskPoststartRequest, resp *interfaces.TaskPoststartResponse) error {
+	if !h.tr.getRestore() {
+		return nil
+	}
+
+	h.tr.setRestore(false)
+	if err := os.RemoveAll(h.tr.taskDir.CheckpointDir); err != nil {
+		h.logger.Warn("failed to remove checkpoint", "error", err)
+	}
+	return nil
+}
+
+// PreKilling tells the driver to checkpoint the task before stopping it if
+// the allocation is migrating.
+func (h *checkpointHook) PreKilling(ctx context.Context, req *interfaces.TaskPreKillRequest, resp *interfaces.TaskPreKillResponse) error {
+	alloc := h.tr.Alloc()
+	if !h.enabled(alloc) || !alloc.DesiredTransition.ShouldMigrate() {
+		return nil
+	}
+
+	driverHandle := h.tr.getDriverHandle()
+	if driverHandle == nil {
+		return nil
+	}
+
+	h.logger.Debug("checkpointing task since alloc was drained")
+	driverHandle.SetKillSignal(drivers.CheckpointKillSignal(h.tr.Task().KillSignal))
+	return nil
+}
+
+// enabled returns whether the task group of the allocation migrates
+// checkpoints.
+func (h *checkpointHook) enabled(alloc *structs.Allocation) bool {
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	return tg != nil && tg.Migrate != nil && tg.Migrate.Checkpoint
+}
@@ -0,0 +1,71 @@
+package taskrunner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskRunner_CheckpointHook(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].Migrate.Checkpoint = true
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name)
+	defer cleanup()
+	tr, err := NewTaskRunner(conf)
+	require.NoError(t, err)
+
+	hook := newCheckpointHook(tr, testlog.HCLogger(t))
+	req := &interfaces.TaskPrestartRequest{TaskDir: tr.taskDir}
+
+	// no checkpoint was migrated
+	require.NoError(t, hook.Prestart(context.Background(), req, &interfaces.TaskPrestartResponse{}))
+	require.False(t, tr.buildTaskConfig().Restore)
+
+	// a checkpoint was migrated
+	dir := tr.taskDir.CheckpointDir
+	require.NoError(t, os.MkdirAll(dir, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "inventory.img"), []byte{}, 0600))
+
+	resp := &interfaces.TaskPrestartResponse{}
+	require.NoError(t, hook.Prestart(context.Background(), req, resp))
+	require.True(t, resp.Done)
+	require.True(t, tr.buildTaskConfig().Restore)
+
+	// the checkpoint is removed once the task is restored
+	require.NoError(t, hook.Poststart(context.Background(), &interfaces.TaskPoststartRequest{}, &interfaces.TaskPoststartResponse{}))
+	require.False(t, tr.buildTaskConfig().Restore)
+	require.NoDirExists(t, dir)
+
+	// the task is only checkpointed when the alloc migrates
+	handle := NewDriverHandle(tr.driver, "id", task, tr.clientConfig.MaxKillTimeout, nil)
+	tr.setDriverHandle(handle)
+	require.NoError(t, hook.PreKilling(context.Background(), &interfaces.TaskPreKillRequest{}, &interfaces.TaskPreKillResponse{}))
+	require.Equal(t, task.KillSignal, handle.killSignal)
+
+	alloc = alloc.Copy()
+	alloc.DesiredTransition.Migrate = pointer.Of(true)
+	tr.setAlloc(alloc, task)
+	require.NoError(t, hook.PreKilling(context.Background(), &interfaces.TaskPreKillRequest{}, &interfaces.TaskPreKillResponse{}))
+	require.Equal(t, drivers.CheckpointKillSignal(task.KillSignal), handle.killSignal)
+
+	// the kill signal of the task follows the checkpoint request
+	task.KillSignal = "SIGUSR1"
+	tr.setAlloc(alloc, task)
+	handle = NewDriverHandle(tr.driver, "id", task, tr.clientConfig.MaxKillTimeout, nil)
+	tr.setDriverHandle(handle)
+	require.NoError(t, hook.PreKilling(context.Background(), &interfaces.TaskPreKillRequest{}, &interfaces.TaskPreKillResponse{}))
+	require.Equal(t, "CHECKPOINT:SIGUSR1", handle.killSignal)
+}
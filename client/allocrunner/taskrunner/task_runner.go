@@ -252,6 +252,16 @@ type TaskRunner struct {
 	networkIsolationLock sync.Mutex
 	networkIsolationSpec *drivers.NetworkIsolationSpec
 
+	// userNamespace is the user namespace the task is run in, if its driver
+	// supports them and the client is configured with a pool of IDs
+	userNamespaceLock sync.Mutex
+	userNamespace     *drivers.UserNamespace
+
+	// restore is set by the checkpoint hook when the task should be
+	// restored from the checkpoint migrated with its task directory
+	restoreLock sync.Mutex
+	restore     bool
+
 	allocHookResources *cstructs.AllocHookResources
 
 	// serviceRegWrapper is the handler wrapper that is used by service hooks
@@ -1148,6 +1158,8 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 		StderrPath:       tr.logmonHookConfig.stderrFifo,
 		AllocID:          tr.allocID,
 		NetworkIsolation: tr.networkIsolationSpec,
+		UserNamespace:    tr.getUserNamespace(),
+		Restore:          tr.getRestore(),
 		DNS:              dns,
 	}
 }
@@ -1407,6 +1419,32 @@ func (tr *TaskRunner) SetNetworkIsolation(n *drivers.NetworkIsolationSpec) {
 	tr.networkIsolationLock.Unlock()
 }
 
+// SetUserNamespace is called by the PreRun allocation hook after allocating
+// the user namespace of the allocation
+func (tr *TaskRunner) SetUserNamespace(ns *drivers.UserNamespace) {
+	tr.userNamespaceLock.Lock()
+	tr.userNamespace = ns
+	tr.userNamespaceLock.Unlock()
+}
+
+func (tr *TaskRunner) getUserNamespace() *drivers.UserNamespace {
+	tr.userNamespaceLock.Lock()
+	defer tr.userNamespaceLock.Unlock()
+	return tr.userNamespace
+}
+
+func (tr *TaskRunner) setRestore(restore bool) {
+	tr.restoreLock.Lock()
+	tr.restore = restore
+	tr.restoreLock.Unlock()
+}
+
+func (tr *TaskRunner) getRestore() bool {
+	tr.restoreLock.Lock()
+	defer tr.restoreLock.Unlock()
+	return tr.restore
+}
+
 // triggerUpdate if there isn't already an update pending. Should be called
 // instead of calling updateHooks directly to serialize runs of update hooks.
 // TaskRunner state should be updated prior to triggering update hooks.
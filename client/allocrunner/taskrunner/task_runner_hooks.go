@@ -171,6 +171,17 @@ func (tr *TaskRunner) initHooks() {
 	if tr.driverCapabilities.RemoteTasks {
 		tr.runnerHooks = append(tr.runnerHooks, newRemoteTaskHook(tr, hookLogger))
 	}
+
+	// If this task driver can checkpoint tasks, add the checkpoint hook.
+	if tr.driverCapabilities.Checkpoint {
+		tr.runnerHooks = append(tr.runnerHooks, newCheckpointHook(tr, hookLogger))
+	}
+
+	// If this task driver supports user namespaces, add the user namespace
+	// hook last so it covers the files written by the other hooks.
+	if tr.driverCapabilities.UserNamespaces {
+		tr.runnerHooks = append(tr.runnerHooks, newUsernsHook(tr, hookLogger))
+	}
 }
 
 func (tr *TaskRunner) emitHookError(err error, hookName string) {
@@ -0,0 +1,53 @@
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+var _ interfaces.TaskPrestartHook = (*usernsHook)(nil)
+
+// usernsHook gives the root user of the user namespace of a task ownership of
+// the files in its directories, so they aren't seen as owned by an unmapped
+// user. It runs after the other prestart hooks to cover the artifacts,
+// templates and migrated data they write.
+type usernsHook struct {
+	tr *TaskRunner
+
+	logger hclog.Logger
+}
+
+func newUsernsHook(tr *TaskRunner, logger hclog.Logger) *usernsHook {
+	h := &usernsHook{
+		tr: tr,
+	}
+	h.logger = logger.Named(h.Name())
+	return h
+}
+
+func (h *usernsHook) Name() string {
+	return "userns"
+}
+
+func (h *usernsHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
+	ns := h.tr.getUserNamespace()
+	if ns == nil {
+		resp.Done = true
+		return nil
+	}
+
+	// the remapped tasks of the group share the same namespace, so the shared
+	// alloc dir can be owned by it too
+	if err := req.TaskDir.Chown(int(ns.HostUID), int(ns.HostGID), int(ns.Size)); err != nil {
+		return fmt.Errorf("failed to change owner of task dir: %v", err)
+	}
+
+	h.logger.Debug("changed owner of task files", "namespace", ns)
+	h.tr.EmitEvent(structs.NewTaskEvent(structs.TaskSetup).
+		SetMessage(fmt.Sprintf("Remapping task users to user namespace with %s", ns)))
+	return nil
+}
@@ -0,0 +1,60 @@
+package taskrunner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskRunner_UsernsHook(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name)
+	defer cleanup()
+	tr, err := NewTaskRunner(conf)
+	require.NoError(t, err)
+	require.NoError(t, tr.taskDir.Build(false, nil))
+
+	// files written by earlier hooks, such as artifacts
+	artifact := filepath.Join(tr.taskDir.LocalDir, "app.conf")
+	require.NoError(t, os.WriteFile(artifact, []byte("x"), 0644))
+	data := filepath.Join(tr.taskDir.SharedAllocDir, "data", "db")
+	require.NoError(t, os.WriteFile(data, []byte("x"), 0644))
+
+	owner := func(path string) uint32 {
+		fi, err := os.Lstat(path)
+		require.NoError(t, err)
+		return fi.Sys().(*syscall.Stat_t).Uid
+	}
+
+	hook := newUsernsHook(tr, testlog.HCLogger(t))
+	req := &interfaces.TaskPrestartRequest{TaskDir: tr.taskDir}
+
+	// the files of tasks outside of a user namespace are left untouched
+	resp := &interfaces.TaskPrestartResponse{}
+	require.NoError(t, hook.Prestart(context.Background(), req, resp))
+	require.True(t, resp.Done)
+	require.Zero(t, owner(artifact))
+
+	tr.SetUserNamespace(&drivers.UserNamespace{HostUID: 200000, HostGID: 300000, Size: 65536})
+	resp = &interfaces.TaskPrestartResponse{}
+	require.NoError(t, hook.Prestart(context.Background(), req, resp))
+	require.False(t, resp.Done)
+	for _, path := range []string{tr.taskDir.LocalDir, artifact, tr.taskDir.SharedAllocDir, data} {
+		require.Equal(t, uint32(200000), owner(path), path)
+	}
+}
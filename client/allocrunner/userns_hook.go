@@ -0,0 +1,130 @@
+package allocrunner
+
+import (
+	"fmt"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/lib/userns"
+	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// userNamespaceSetter is called by the user namespace hook to set the user
+// namespace of the tasks whose drivers support them
+type userNamespaceSetter interface {
+	SetUserNamespace(tasks []string, ns *drivers.UserNamespace)
+}
+
+// allocUserNamespaceSetter is a shim to allow the user namespace hook to set
+// the user namespace of tasks without full access to the alloc runner
+type allocUserNamespaceSetter struct {
+	ar *allocRunner
+}
+
+func (a *allocUserNamespaceSetter) SetUserNamespace(tasks []string, ns *drivers.UserNamespace) {
+	for _, name := range tasks {
+		if tr, ok := a.ar.tasks[name]; ok {
+			tr.SetUserNamespace(ns)
+		}
+	}
+}
+
+// usernsHook is an alloc lifecycle hook that allocates the range of host IDs
+// the tasks of an alloc are remapped to when their drivers support user
+// namespaces
+type usernsHook struct {
+	alloc         *structs.Allocation
+	pool          *userns.Pool
+	driverManager drivermanager.Manager
+	setter        userNamespaceSetter
+	logger        hclog.Logger
+}
+
+func newUsernsHook(logger hclog.Logger, alloc *structs.Allocation, pool *userns.Pool,
+	driverManager drivermanager.Manager, setter userNamespaceSetter) *usernsHook {
+	h := &usernsHook{
+		alloc:         alloc,
+		pool:          pool,
+		driverManager: driverManager,
+		setter:        setter,
+	}
+	h.logger = logger.Named(h.Name())
+	return h
+}
+
+func (h *usernsHook) Name() string {
+	return "userns"
+}
+
+func (h *usernsHook) Prerun() error {
+	if h.pool == nil {
+		return nil
+	}
+
+	tasks, others, err := h.remappedTasks()
+	if err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ns, err := h.pool.Allocate(h.alloc.ID)
+	if err != nil {
+		return err
+	}
+
+	// only the tasks whose drivers opt in are remapped, the others keep their
+	// host users and see the files of the shared alloc dir owned by the IDs
+	// of the namespace
+	if len(others) > 0 {
+		h.logger.Warn("running tasks of drivers without user namespace support outside of the user namespace",
+			"tasks", others)
+	}
+
+	h.logger.Debug("running tasks in user namespace", "tasks", tasks, "namespace", ns)
+	h.setter.SetUserNamespace(tasks, ns)
+	return nil
+}
+
+// remappedTasks returns the names of the tasks whose drivers support user
+// namespaces, followed by the names of the other tasks. Drivers opt into user
+// namespaces through their capabilities.
+func (h *usernsHook) remappedTasks() ([]string, []string, error) {
+	tg := h.alloc.Job.LookupTaskGroup(h.alloc.TaskGroup)
+
+	// driverCaps tracks which drivers we've checked capabilities for so as
+	// not to do extra work
+	driverCaps := make(map[string]bool)
+	var tasks, others []string
+	for _, task := range tg.Tasks {
+		supported, ok := driverCaps[task.Driver]
+		if !ok {
+			driver, err := h.driverManager.Dispense(task.Driver)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to dispense driver %s: %v", task.Driver, err)
+			}
+			caps, err := driver.Capabilities()
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to retrieve capabilities for driver %s: %v",
+					task.Driver, err)
+			}
+			supported = caps.UserNamespaces
+			driverCaps[task.Driver] = supported
+		}
+		if supported {
+			tasks = append(tasks, task.Name)
+		} else {
+			others = append(others, task.Name)
+		}
+	}
+	return tasks, others, nil
+}
+
+func (h *usernsHook) Destroy() error {
+	if h.pool == nil {
+		return nil
+	}
+	return h.pool.Release(h.alloc.ID)
+}
@@ -0,0 +1,124 @@
+package allocrunner
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/lib/userns"
+	"github.com/hashicorp/nomad/client/state"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/stretchr/testify/require"
+)
+
+// statically assert user namespace hook implements the expected interfaces
+var _ interfaces.RunnerPrerunHook = (*usernsHook)(nil)
+var _ interfaces.RunnerDestroyHook = (*usernsHook)(nil)
+
+type mockUserNamespaceSetter struct {
+	tasks []string
+	ns    *drivers.UserNamespace
+}
+
+func (m *mockUserNamespaceSetter) SetUserNamespace(tasks []string, ns *drivers.UserNamespace) {
+	m.tasks = tasks
+	m.ns = ns
+}
+
+func TestUsernsHook_Prerun_Destroy(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	logger := testlog.HCLogger(t)
+
+	alloc := mock.Alloc()
+	tg := alloc.Job.TaskGroups[0]
+	web := tg.Tasks[0].Copy()
+	web.Name = "web"
+	web.Driver = "userns"
+	sidecar := tg.Tasks[0].Copy()
+	sidecar.Name = "sidecar"
+	sidecar.Driver = "userns"
+	tg.Tasks = append(tg.Tasks[:0], web, sidecar)
+
+	db := state.NewMemDB(logger)
+	pool, err := userns.NewPool(logger, &userns.PoolConfig{
+		UIDs: userns.Range{Start: 200000, Count: 65536},
+		GIDs: userns.Range{Start: 300000, Count: 65536},
+		Size: 65536,
+	}, db)
+	require.NoError(err)
+
+	setter := &mockUserNamespaceSetter{}
+	hook := newUsernsHook(logger, alloc, pool, &mockDriverManager{}, setter)
+	require.NoError(hook.Prerun())
+
+	// every task of the group is remapped to the namespace
+	expected := &drivers.UserNamespace{HostUID: 200000, HostGID: 300000, Size: 65536}
+	require.Equal([]string{"web", "sidecar"}, setter.tasks)
+	require.Equal(expected, setter.ns)
+
+	ps, err := db.GetUserNamespacePoolState()
+	require.NoError(err)
+	require.Equal(expected, ps.Allocations[alloc.ID])
+
+	// the namespace is released when the alloc is destroyed
+	require.NoError(hook.Destroy())
+	ps, err = db.GetUserNamespacePoolState()
+	require.NoError(err)
+	require.Empty(ps.Allocations)
+}
+
+func TestUsernsHook_Prerun_Mixed(t *testing.T) {
+	ci.Parallel(t)
+	logger := testlog.HCLogger(t)
+
+	alloc := mock.Alloc()
+	tg := alloc.Job.TaskGroups[0]
+	web := tg.Tasks[0].Copy()
+	web.Name = "web"
+	web.Driver = "userns"
+	sidecar := tg.Tasks[0].Copy()
+	sidecar.Name = "sidecar"
+	sidecar.Driver = "hostonly"
+	tg.Tasks = append(tg.Tasks[:0], web, sidecar)
+
+	pool, err := userns.NewPool(logger, &userns.PoolConfig{
+		UIDs: userns.Range{Start: 200000, Count: 65536},
+		GIDs: userns.Range{Start: 300000, Count: 65536},
+		Size: 65536,
+	}, state.NewMemDB(logger))
+	require.NoError(t, err)
+
+	// only the tasks of drivers supporting user namespaces are remapped
+	setter := &mockUserNamespaceSetter{}
+	hook := newUsernsHook(logger, alloc, pool, &mockDriverManager{}, setter)
+	require.NoError(t, hook.Prerun())
+	require.Equal(t, []string{"web"}, setter.tasks)
+	require.Equal(t, &drivers.UserNamespace{HostUID: 200000, HostGID: 300000, Size: 65536}, setter.ns)
+}
+
+func TestUsernsHook_Prerun_Unsupported(t *testing.T) {
+	ci.Parallel(t)
+	logger := testlog.HCLogger(t)
+
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].Tasks[0].Driver = "hostonly"
+
+	pool, err := userns.NewPool(logger, &userns.PoolConfig{
+		UIDs: userns.Range{Start: 200000, Count: 65536},
+		GIDs: userns.Range{Start: 300000, Count: 65536},
+		Size: 65536,
+	}, state.NewMemDB(logger))
+	require.NoError(t, err)
+
+	// no namespace is allocated when no driver supports them
+	setter := &mockUserNamespaceSetter{}
+	hook := newUsernsHook(logger, alloc, pool, &mockDriverManager{}, setter)
+	require.NoError(t, hook.Prerun())
+	require.Nil(t, setter.ns)
+
+	_, err = pool.Allocate("other")
+	require.NoError(t, err)
+}
@@ -31,6 +31,8 @@ import (
 	"github.com/hashicorp/nomad/client/fingerprint"
 	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/client/lib/cgutil"
+	"github.com/hashicorp/nomad/client/lib/ociimage"
+	"github.com/hashicorp/nomad/client/lib/userns"
 	"github.com/hashicorp/nomad/client/pluginmanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
@@ -332,6 +334,9 @@ type Client struct {
 	// cpusetManager configures cpusets on supported platforms
 	cpusetManager cgutil.CpusetManager
 
+	// usernsPool allocates the user namespaces of tasks if configured
+	usernsPool *userns.Pool
+
 	// EnterpriseClient is used to set and check enterprise features for clients
 	EnterpriseClient *EnterpriseClient
 
@@ -427,6 +432,15 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 			},
 		})
 
+	// initialize the user namespace pool (needs to happen after init)
+	if cfg.UserNamespace != nil {
+		pool, err := userns.NewPool(c.logger, cfg.UserNamespace, c.stateDB)
+		if err != nil {
+			return nil, err
+		}
+		c.usernsPool = pool
+	}
+
 	// Setup the clients RPC server
 	c.setupClientRpc(rpcs)
 
@@ -525,6 +539,7 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 		Interval:            cfg.GCInterval,
 		ParallelDestroys:    cfg.GCParallelDestroys,
 		ReservedDiskMB:      cfg.Node.Reserved.DiskMB,
+		ImageCacheDir:       ociimage.CacheDir(cfg.AllocDir),
 	}
 	c.garbageCollector = NewAllocGarbageCollector(c.logger, statsCollector, c, gcConfig)
 	go c.garbageCollector.Run()
@@ -1202,6 +1217,7 @@ func (c *Client) restoreState() error {
 			DynamicRegistry:     c.dynamicRegistry,
 			CSIManager:          c.csimanager,
 			CpusetManager:       c.cpusetManager,
+			UserNamespacePool:   c.usernsPool,
 			DeviceManager:       c.devicemanager,
 			DriverManager:       c.drivermanager,
 			ServersContactedCh:  c.serversContactedCh,
@@ -1245,6 +1261,19 @@ func (c *Client) restoreState() error {
 		c.heartbeatStop.allocHook(alloc)
 	}
 
+	// Release the user namespaces of allocs which weren't restored
+	if c.usernsPool != nil {
+		c.allocLock.RLock()
+		allocIDs := make([]string, 0, len(c.allocs))
+		for allocID := range c.allocs {
+			allocIDs = append(allocIDs, allocID)
+		}
+		c.allocLock.RUnlock()
+		if err := c.usernsPool.Reconcile(allocIDs); err != nil {
+			c.logger.Error("error releasing user namespaces", "error", err)
+		}
+	}
+
 	// All allocs restored successfully, run them!
 	c.allocLock.Lock()
 	for _, ar := range c.allocs {
@@ -1459,6 +1488,8 @@ func (c *Client) setupNode() error {
 		node.NodeResources = &structs.NodeResources{}
 		node.NodeResources.MinDynamicPort = newConfig.MinDynamicPort
 		node.NodeResources.MaxDynamicPort = newConfig.MaxDynamicPort
+		node.NodeResources.CpuOversubscriptionRatio = newConfig.CpuOversubscriptionRatio
+		node.NodeResources.MemoryOversubscriptionRatio = newConfig.MemoryOversubscriptionRatio
 	}
 	if node.ReservedResources == nil {
 		node.ReservedResources = &structs.NodeReservedResources{}
@@ -1472,6 +1503,9 @@ func (c *Client) setupNode() error {
 	if node.Datacenter == "" {
 		node.Datacenter = "dc1"
 	}
+	if node.NodePool == "" {
+		node.NodePool = structs.NodePoolDefault
+	}
 	if node.Name == "" {
 		node.Name, _ = os.Hostname()
 	}
@@ -1589,6 +1623,15 @@ func (c *Client) updateNodeFromFingerprint(response *fingerprint.FingerprintResp
 			nodeHasChanged = true
 		}
 
+		response.NodeResources.CpuOversubscriptionRatio = newConfig.CpuOversubscriptionRatio
+		response.NodeResources.MemoryOversubscriptionRatio = newConfig.MemoryOversubscriptionRatio
+		if newConfig.Node.NodeResources.CpuOversubscriptionRatio != response.NodeResources.CpuOversubscriptionRatio ||
+			newConfig.Node.NodeResources.MemoryOversubscriptionRatio != response.NodeResources.MemoryOversubscriptionRatio {
+			newConfig.Node.NodeResources.CpuOversubscriptionRatio = response.NodeResources.CpuOversubscriptionRatio
+			newConfig.Node.NodeResources.MemoryOversubscriptionRatio = response.NodeResources.MemoryOversubscriptionRatio
+			nodeHasChanged = true
+		}
+
 	}
 
 	if nodeHasChanged {
@@ -2538,6 +2581,7 @@ func (c *Client) addAlloc(alloc *structs.Allocation, migrateToken string) error
 		DynamicRegistry:     c.dynamicRegistry,
 		CSIManager:          c.csimanager,
 		CpusetManager:       c.cpusetManager,
+		UserNamespacePool:   c.usernsPool,
 		DeviceManager:       c.devicemanager,
 		DriverManager:       c.drivermanager,
 		ServiceRegWrapper:   c.serviceRegWrapper,
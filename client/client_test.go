@@ -1115,6 +1115,7 @@ func TestClient_UpdateNodeFromDevicesAccumulates(t *testing.T) {
 		Networks:     conf.Node.NodeResources.Networks,
 		NodeNetworks: conf.Node.NodeResources.NodeNetworks,
 		Disk:         conf.Node.NodeResources.Disk,
+		NUMA:         conf.Node.NodeResources.NUMA,
 
 		// injected
 		Cpu: structs.NodeCpuResources{
@@ -1159,6 +1160,7 @@ func TestClient_UpdateNodeFromDevicesAccumulates(t *testing.T) {
 		Networks:     conf.Node.NodeResources.Networks,
 		NodeNetworks: conf.Node.NodeResources.NodeNetworks,
 		Disk:         conf.Node.NodeResources.Disk,
+		NUMA:         conf.Node.NodeResources.NUMA,
 
 		// injected
 		Cpu: structs.NodeCpuResources{
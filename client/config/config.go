@@ -12,6 +12,7 @@ import (
 
 	"github.com/hashicorp/consul-template/config"
 	"github.com/hashicorp/nomad/client/lib/cgutil"
+	"github.com/hashicorp/nomad/client/lib/userns"
 	"github.com/hashicorp/nomad/command/agent/host"
 	"golang.org/x/exp/slices"
 
@@ -118,6 +119,12 @@ type Config struct {
 	// determined dynamically.
 	MemoryMB int
 
+	// CpuOversubscriptionRatio and MemoryOversubscriptionRatio are the
+	// factors by which the CPU and memory of the node may be oversubscribed
+	// by the scheduler. Zero disables oversubscription.
+	CpuOversubscriptionRatio    float64
+	MemoryOversubscriptionRatio float64
+
 	// MaxKillTimeout allows capping the user-specifiable KillTimeout. If the
 	// task's KillTimeout is greater than the MaxKillTimeout, MaxKillTimeout is
 	// used.
@@ -285,6 +292,10 @@ type Config struct {
 	// ReservableCores if set overrides the set of reservable cores reported in fingerprinting.
 	ReservableCores []uint16
 
+	// UserNamespace if set is the pool of host user and group IDs that the
+	// tasks of drivers supporting user namespaces are remapped to.
+	UserNamespace *userns.PoolConfig
+
 	// NomadServiceDiscovery determines whether the Nomad native service
 	// discovery client functionality is enabled.
 	NomadServiceDiscovery bool
@@ -713,6 +724,7 @@ func (c *Config) Copy() *Config {
 	nc.TemplateConfig = c.TemplateConfig.Copy()
 	nc.ReservableCores = slices.Clone(c.ReservableCores)
 	nc.Artifact = c.Artifact.Copy()
+	nc.UserNamespace = c.UserNamespace.Copy()
 	return &nc
 }
 
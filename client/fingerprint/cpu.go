@@ -32,7 +32,7 @@ func NewCPUFingerprint(logger log.Logger) Fingerprint {
 
 func (f *CPUFingerprint) Fingerprint(req *FingerprintRequest, resp *FingerprintResponse) error {
 	cfg := req.Config
-	setResourcesCPU := func(totalCompute int, totalCores uint16, reservableCores []uint16) {
+	setResourcesCPU := func(totalCompute int, totalCores uint16, reservableCores []uint16, numa *structs.NodeNUMAResources) {
 		// COMPAT(0.10): Remove in 0.10
 		resp.Resources = &structs.Resources{
 			CPU: totalCompute,
@@ -44,6 +44,7 @@ func (f *CPUFingerprint) Fingerprint(req *FingerprintRequest, resp *FingerprintR
 				TotalCpuCores:      totalCores,
 				ReservableCpuCores: reservableCores,
 			},
+			NUMA: numa,
 		}
 	}
 
@@ -81,6 +82,14 @@ func (f *CPUFingerprint) Fingerprint(req *FingerprintRequest, resp *FingerprintR
 		}
 	}
 
+	numa, err := f.deriveNUMATopology()
+	if err != nil {
+		f.logger.Warn("failed to detect NUMA topology", "error", err)
+	} else if numa != nil {
+		resp.AddAttribute("cpu.numanodes", fmt.Sprintf("%d", len(numa.Nodes)))
+		f.logger.Debug("detected NUMA topology", "nodes", len(numa.Nodes))
+	}
+
 	tt := int(stats.TotalTicksAvailable())
 	if cfg.CpuCompute > 0 {
 		f.logger.Debug("using user specified cpu compute", "cpu_compute", cfg.CpuCompute)
@@ -97,7 +106,7 @@ func (f *CPUFingerprint) Fingerprint(req *FingerprintRequest, resp *FingerprintR
 	}
 
 	resp.AddAttribute("cpu.totalcompute", fmt.Sprintf("%d", tt))
-	setResourcesCPU(tt, uint16(numCores), reservableCores)
+	setResourcesCPU(tt, uint16(numCores), reservableCores, numa)
 	resp.Detected = true
 
 	return nil
@@ -1,7 +1,23 @@
 package fingerprint
 
 import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/hashicorp/nomad/client/lib/cgutil"
+	"github.com/hashicorp/nomad/lib/cpuset"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// sysfsNUMANodePath is where the kernel exposes the NUMA nodes of the
+	// host.
+	sysfsNUMANodePath = "/sys/devices/system/node"
 )
 
 func (f *CPUFingerprint) deriveReservableCores(req *FingerprintRequest) ([]uint16, error) {
@@ -10,3 +26,103 @@ func (f *CPUFingerprint) deriveReservableCores(req *FingerprintRequest) ([]uint1
 	// We may assume the hierarchy is already setup.
 	return cgutil.GetCPUsFromCgroup(req.Config.CgroupParent)
 }
+
+func (f *CPUFingerprint) deriveNUMATopology() (*structs.NodeNUMAResources, error) {
+	return numaTopologyFromSysfs(sysfsNUMANodePath)
+}
+
+// numaTopologyFromSysfs reads the NUMA topology from the node directories
+// under the given sysfs path. It returns nil if the path doesn't exist,
+// which is the case for kernels built without NUMA support.
+func numaTopologyFromSysfs(root string) (*structs.NodeNUMAResources, error) {
+	entries, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	topology := &structs.NodeNUMAResources{}
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), "node") {
+			continue
+		}
+		id, err := strconv.Atoi(strings.TrimPrefix(entry.Name(), "node"))
+		if err != nil {
+			continue
+		}
+
+		node, err := numaNodeFromSysfs(filepath.Join(root, entry.Name()), id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read NUMA node %d: %v", id, err)
+		}
+		topology.Nodes = append(topology.Nodes, node)
+	}
+
+	if len(topology.Nodes) == 0 {
+		return nil, nil
+	}
+	sort.Slice(topology.Nodes, func(i, j int) bool {
+		return topology.Nodes[i].ID < topology.Nodes[j].ID
+	})
+	return topology, nil
+}
+
+func numaNodeFromSysfs(dir string, id int) (*structs.NUMANode, error) {
+	node := &structs.NUMANode{ID: id}
+
+	cpulist, err := os.ReadFile(filepath.Join(dir, "cpulist"))
+	if err != nil {
+		return nil, err
+	}
+	cores, err := cpuset.Parse(string(cpulist))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cpulist: %v", err)
+	}
+	node.Cores = cores.ToSlice()
+
+	if node.MemoryMB, err = numaNodeMemoryMB(filepath.Join(dir, "meminfo")); err != nil {
+		return nil, err
+	}
+
+	distance, err := os.ReadFile(filepath.Join(dir, "distance"))
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range strings.Fields(string(distance)) {
+		d, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse distance: %v", err)
+		}
+		node.Distances = append(node.Distances, d)
+	}
+
+	return node, nil
+}
+
+// numaNodeMemoryMB returns the total memory from a NUMA node's meminfo file,
+// whose lines take the form "Node 0 MemTotal:       16314788 kB".
+func numaNodeMemoryMB(path string) (int64, error) {
+	fh, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer fh.Close()
+
+	scanner := bufio.NewScanner(fh)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || fields[2] != "MemTotal:" {
+			continue
+		}
+		kb, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse MemTotal: %v", err)
+		}
+		return kb / 1024, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("MemTotal not found in %s", path)
+}
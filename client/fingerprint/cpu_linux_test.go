@@ -0,0 +1,61 @@
+package fingerprint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCPUFingerprint_numaTopologyFromSysfs(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("two nodes", func(t *testing.T) {
+		topology, err := numaTopologyFromSysfs("test_fixtures/numa/two_nodes")
+		require.NoError(t, err)
+		require.Equal(t, &structs.NodeNUMAResources{
+			Nodes: []*structs.NUMANode{
+				{
+					ID:        0,
+					Cores:     []uint16{0, 1, 2, 3, 8, 9, 10, 11},
+					MemoryMB:  15932,
+					Distances: []int{10, 21},
+				},
+				{
+					ID:        1,
+					Cores:     []uint16{4, 5, 6, 7, 12, 13, 14, 15},
+					MemoryMB:  16123,
+					Distances: []int{21, 10},
+				},
+			},
+		}, topology)
+	})
+
+	t.Run("single node", func(t *testing.T) {
+		topology, err := numaTopologyFromSysfs("test_fixtures/numa/single_node")
+		require.NoError(t, err)
+		require.Len(t, topology.Nodes, 1)
+		require.Equal(t, []uint16{0, 1, 2, 3}, topology.Nodes[0].Cores)
+		require.Equal(t, int64(7857), topology.Nodes[0].MemoryMB)
+	})
+
+	t.Run("no numa support", func(t *testing.T) {
+		topology, err := numaTopologyFromSysfs(filepath.Join(t.TempDir(), "node"))
+		require.NoError(t, err)
+		require.Nil(t, topology)
+	})
+
+	t.Run("missing meminfo", func(t *testing.T) {
+		root := t.TempDir()
+		dir := filepath.Join(root, "node0")
+		require.NoError(t, os.Mkdir(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "cpulist"), []byte("0-1\n"), 0644))
+
+		_, err := numaTopologyFromSysfs(root)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read NUMA node 0")
+	})
+}
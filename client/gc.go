@@ -7,6 +7,7 @@ import (
 	"time"
 
 	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/lib/ociimage"
 	"github.com/hashicorp/nomad/client/stats"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -26,6 +27,11 @@ type GCConfig struct {
 	Interval            time.Duration
 	ReservedDiskMB      int
 	ParallelDestroys    int
+
+	// ImageCacheDir is the directory of the node's image layer cache, whose
+	// unused layers are pruned before allocations are collected to make disk
+	// space available.
+	ImageCacheDir string
 }
 
 // AllocCounter is used by AllocGarbageCollector to discover how many un-GC'd
@@ -57,6 +63,9 @@ type AllocGarbageCollector struct {
 	// triggerCh is ticked by the Trigger method to cause a GC
 	triggerCh chan struct{}
 
+	// imageCache is the node's image layer cache, if configured
+	imageCache *ociimage.Cache
+
 	logger hclog.Logger
 }
 
@@ -82,6 +91,10 @@ func NewAllocGarbageCollector(logger hclog.Logger, statsCollector stats.NodeStat
 		triggerCh:      make(chan struct{}, 1),
 	}
 
+	if config.ImageCacheDir != "" {
+		gc.imageCache = ociimage.NewCache(logger, config.ImageCacheDir)
+	}
+
 	return gc
 }
 
@@ -115,6 +128,10 @@ func (a *AllocGarbageCollector) Trigger() {
 // keepUsageBelowThreshold collects disk usage information and garbage collects
 // allocations to make disk space available.
 func (a *AllocGarbageCollector) keepUsageBelowThreshold() error {
+	// whether unused image layers have been pruned since the last allocation
+	// was collected
+	pruned := false
+
 	for {
 		select {
 		case <-a.shutdownCh:
@@ -134,6 +151,7 @@ func (a *AllocGarbageCollector) keepUsageBelowThreshold() error {
 
 		liveAllocs := a.allocCounter.NumAllocs()
 
+		diskReason := true
 		switch {
 		case diskStats.UsedPercent > a.config.DiskUsageThreshold:
 			reason = fmt.Sprintf("disk usage of %.0f is over gc threshold of %.0f",
@@ -147,6 +165,7 @@ func (a *AllocGarbageCollector) keepUsageBelowThreshold() error {
 				logf = a.logger.Info
 			}
 			reason = fmt.Sprintf("number of allocations (%d) is over the limit (%d)", liveAllocs, a.config.MaxAllocs)
+			diskReason = false
 		}
 
 		if reason == "" {
@@ -154,6 +173,15 @@ func (a *AllocGarbageCollector) keepUsageBelowThreshold() error {
 			break
 		}
 
+		// Unused image layers are cheaper to lose than allocations, so try
+		// freeing space by pruning them first
+		if diskReason && !pruned && a.imageCache != nil {
+			pruned = true
+			if removed := a.pruneImageCache(reason); removed > 0 {
+				continue
+			}
+		}
+
 		// Collect an allocation
 		gcAlloc := a.allocRunners.Pop()
 		if gcAlloc == nil {
@@ -163,10 +191,26 @@ func (a *AllocGarbageCollector) keepUsageBelowThreshold() error {
 
 		// Destroy the alloc runner and wait until it exits
 		a.destroyAllocRunner(gcAlloc.allocID, gcAlloc.allocRunner, reason)
+
+		// the allocation may have held the last references to some layers
+		pruned = false
 	}
 	return nil
 }
 
+// pruneImageCache removes the image layers no longer used by any task,
+// returning the number of layers removed.
+func (a *AllocGarbageCollector) pruneImageCache(reason string) int {
+	removed, err := a.imageCache.Prune()
+	if err != nil {
+		a.logger.Error("failed to prune image cache", "error", err)
+	}
+	if removed > 0 {
+		a.logger.Info("pruned unused image layers", "layers", removed, "reason", reason)
+	}
+	return removed
+}
+
 // destroyAllocRunner is used to destroy an allocation runner. It will acquire a
 // lock to restrict parallelism and then destroy the alloc runner, returning
 // once the allocation has been destroyed.
@@ -2,6 +2,9 @@ package client
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -585,3 +588,34 @@ func TestAllocGarbageCollector_UsedPercentThreshold(t *testing.T) {
 		t.Fatalf("gcAlloc: %v", gcAlloc)
 	}
 }
+
+func TestAllocGarbageCollector_PrunesImageCache(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	statsCollector := &MockStatsCollector{}
+	conf := gcConfig()
+	conf.ImageCacheDir = t.TempDir()
+	gc := NewAllocGarbageCollector(logger, statsCollector, &MockAllocCounter{}, conf)
+
+	// an unreferenced layer
+	layer := filepath.Join(conf.ImageCacheDir, "layers", "sha256", strings.Repeat("a", 64))
+	require.NoError(t, os.MkdirAll(layer, 0755))
+
+	ar1, cleanup1 := allocrunner.TestAllocRunnerFromAlloc(t, mock.Alloc())
+	defer cleanup1()
+	go ar1.Run()
+	gc.MarkForCollection(ar1.Alloc().ID, ar1)
+	exitAllocRunner(ar1)
+
+	statsCollector.availableValues = []uint64{1000, 800}
+	statsCollector.usedPercents = []float64{85, 60}
+	statsCollector.inodePercents = []float64{50, 30}
+
+	require.NoError(t, gc.keepUsageBelowThreshold())
+
+	// Pruning the layer brought usage below the threshold, so the alloc
+	// shouldn't have been collected
+	require.NoDirExists(t, layer)
+	require.NotNil(t, gc.allocRunners.Pop())
+}
@@ -58,6 +58,7 @@ type TaskCgroupInfo struct {
 	CgroupPath         string
 	RelativeCgroupPath string
 	Cpuset             cpuset.CPUSet
+	Mems               cpuset.CPUSet
 	Error              error
 }
 
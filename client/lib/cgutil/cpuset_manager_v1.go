@@ -116,6 +116,7 @@ func (c *cpusetManagerV1) AddAlloc(alloc *structs.Allocation) {
 			CgroupPath:         cgroupPath,
 			RelativeCgroupPath: relativeCgroupPath,
 			Cpuset:             taskCpuset,
+			Mems:               cpuset.New(resources.Memory.NUMANodes...),
 		}
 	}
 	c.mu.Lock()
@@ -256,15 +257,20 @@ func (c *cpusetManagerV1) reconcileCpusets() {
 			continue
 		}
 
-		// copy cpuset.mems from parent
-		_, parentMems, err := getCpusetSubsystemSettingsV1(filepath.Dir(info.CgroupPath))
-		if err != nil {
-			c.logger.Error("failed to read parent cgroup settings for task", "path", info.CgroupPath, "error", err)
-			info.Error = err
-			continue
+		// bind the task to the NUMA nodes it was placed on, or copy
+		// cpuset.mems from parent
+		mems := info.Mems.String()
+		if mems == "" {
+			_, parentMems, err := getCpusetSubsystemSettingsV1(filepath.Dir(info.CgroupPath))
+			if err != nil {
+				c.logger.Error("failed to read parent cgroup settings for task", "path", info.CgroupPath, "error", err)
+				info.Error = err
+				continue
+			}
+			mems = parentMems
 		}
-		if err := cgroups.WriteFile(info.CgroupPath, "cpuset.mems", parentMems); err != nil {
-			c.logger.Error("failed to write cgroup cpuset.mems setting for task", "path", info.CgroupPath, "mems", parentMems, "error", err)
+		if err := cgroups.WriteFile(info.CgroupPath, "cpuset.mems", mems); err != nil {
+			c.logger.Error("failed to write cgroup cpuset.mems setting for task", "path", info.CgroupPath, "mems", mems, "error", err)
 			info.Error = err
 			continue
 		}
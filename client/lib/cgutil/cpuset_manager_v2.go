@@ -50,6 +50,7 @@ type cpusetManagerV2 struct {
 	pool      cpuset.CPUSet              // pool of cores being shared among all tasks
 	sharing   map[identity]nothing       // sharing tasks using cores only from the pool
 	isolating map[identity]cpuset.CPUSet // isolating tasks using cores from the pool + reserved cores
+	mems      map[identity]cpuset.CPUSet // NUMA nodes the memory of isolating tasks is bound to
 }
 
 func NewCpusetManagerV2(parent string, reservable []uint16, logger hclog.Logger) CpusetManager {
@@ -76,6 +77,7 @@ func NewCpusetManagerV2(parent string, reservable []uint16, logger hclog.Logger)
 		logger:    logger,
 		sharing:   make(map[identity]nothing),
 		isolating: make(map[identity]cpuset.CPUSet),
+		mems:      make(map[identity]cpuset.CPUSet),
 	}
 }
 
@@ -98,6 +100,9 @@ func (c *cpusetManagerV2) AddAlloc(alloc *structs.Allocation) {
 		id := makeID(alloc.ID, task)
 		if len(resources.Cpu.ReservedCores) > 0 {
 			c.isolating[id] = cpuset.New(resources.Cpu.ReservedCores...)
+			if len(resources.Memory.NUMANodes) > 0 {
+				c.mems[id] = cpuset.New(resources.Memory.NUMANodes...)
+			}
 		} else {
 			c.sharing[id] = present
 		}
@@ -130,6 +135,7 @@ func (c *cpusetManagerV2) RemoveAlloc(allocID string) {
 	for id := range c.isolating {
 		if strings.HasPrefix(string(id), allocID) {
 			delete(c.isolating, id)
+			delete(c.mems, id)
 		}
 	}
 
@@ -187,11 +193,11 @@ func (c *cpusetManagerV2) recalculate() {
 // must be called while holding c.lock
 func (c *cpusetManagerV2) reconcile() {
 	for id := range c.sharing {
-		c.write(id, c.pool)
+		c.write(id, c.pool, cpuset.New())
 	}
 
 	for id, set := range c.isolating {
-		c.write(id, c.pool.Union(set))
+		c.write(id, c.pool.Union(set), c.mems[id])
 	}
 }
 
@@ -269,8 +275,9 @@ func (c *cpusetManagerV2) remove(path string) {
 	}
 }
 
-// write does the actual write of cpuset set for cgroup id
-func (c *cpusetManagerV2) write(id identity, set cpuset.CPUSet) {
+// write does the actual write of cpuset set for cgroup id, along with the
+// NUMA nodes in mems if the memory of the task is bound to them
+func (c *cpusetManagerV2) write(id identity, set, mems cpuset.CPUSet) {
 	path := c.pathOf(id)
 
 	// make a manager for the cgroup
@@ -289,6 +296,7 @@ func (c *cpusetManagerV2) write(id identity, set cpuset.CPUSet) {
 	// set the cpuset value for the cgroup
 	if err = m.Set(&configs.Resources{
 		CpusetCpus: set.String(),
+		CpusetMems: mems.String(),
 	}); err != nil {
 		c.logger.Error("failed to set cgroup", "path", path, "err", err)
 		return
@@ -51,6 +51,18 @@ func TestCpusetManager_V2_AddAlloc(t *testing.T) {
 		cpusetIs(t, "1", parent, alloc.ID, "web")
 	})
 
+	// add third alloc, bound to a NUMA node
+	t.Run("numa", func(t *testing.T) {
+		alloc := mock.Alloc()
+		alloc.AllocatedResources.Tasks["web"].Cpu.ReservedCores = cpuset.New(1).ToSlice()
+		alloc.AllocatedResources.Tasks["web"].Memory.NUMANodes = []uint16{0}
+		manager.AddAlloc(alloc)
+		scope := makeScope(makeID(alloc.ID, "web"))
+		value, err := cgroups.ReadFile(filepath.Join(CgroupRoot, parent, scope), "cpuset.mems")
+		require.NoError(t, err)
+		require.Equal(t, "0", strings.TrimSpace(value))
+	})
+
 	// note that the scheduler, not the cpuset manager, is what prevents over-subscription
 	// and as such no logic exists here to prevent that
 }
@@ -0,0 +1,366 @@
+package ociimage
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/klauspost/compress/zstd"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// cacheDirName is the name of the layer cache within the client's
+	// allocation directory
+	cacheDirName = ".image-cache"
+
+	// layersDir holds the unpacked layers, addressed by digest
+	layersDir = "layers"
+
+	// refsDir holds one file per task listing the layers it uses
+	refsDir = "refs"
+
+	// tmpDir holds layers and image layouts while they are being unpacked
+	tmpDir = "tmp"
+
+	// lockFile is locked while unpacking layers or pruning the cache
+	lockName = "lock"
+
+	// mediaTypeDockerLayer and mediaTypeDockerLayerGzip are the media types
+	// of Docker v2 schema 2 layers
+	mediaTypeDockerLayer     = "application/vnd.docker.image.rootfs.diff.tar"
+	mediaTypeDockerLayerGzip = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+)
+
+// CacheDir returns the location of the layer cache for the given client
+// allocation directory. Keeping the cache within the allocation directory
+// means it is accounted for by the disk usage thresholds of the garbage
+// collector.
+func CacheDir(allocDir string) string {
+	return filepath.Join(allocDir, cacheDirName)
+}
+
+// Cache is a per node cache of unpacked image layers. Caches of the same
+// directory may be used concurrently, including from different processes, as
+// unpacking layers and pruning are serialized by a lock file.
+type Cache struct {
+	dir    string
+	logger hclog.Logger
+}
+
+// Image is an image whose layers have been unpacked into the cache.
+type Image struct {
+	// Digest is the digest of the image manifest
+	Digest digest.Digest
+
+	// Layers are the paths of the unpacked layers, from the base layer up
+	Layers []string
+
+	// Shift is the offset applied to the owners of the files of the layers
+	Shift IDShift
+}
+
+// IDShift is an offset added to the user and group IDs of the files of
+// unpacked layers, so that an image used by a task in a user namespace is
+// owned by the same users within the namespace as it would be outside of it.
+// Layers unpacked with different shifts are cached separately.
+type IDShift struct {
+	UID uint32
+	GID uint32
+}
+
+// ref records the layers used by a task
+type ref struct {
+	// Dir is the task directory of the owner. The reference is stale once
+	// the directory no longer exists.
+	Dir string
+
+	// Layers are the digests of the layers used by the owner
+	Layers []digest.Digest
+
+	// Shift is the offset applied to the owners of the layers
+	Shift IDShift
+}
+
+// NewCache returns a layer cache stored in dir.
+func NewCache(logger hclog.Logger, dir string) *Cache {
+	return &Cache{
+		dir:    dir,
+		logger: logger.Named("image_cache"),
+	}
+}
+
+// Unpack unpacks the layers of the OCI image layout at path into the cache
+// and references them on behalf of owner, whose task directory is ownerDir.
+// The owners of the unpacked files are offset by shift. Layers already in the
+// cache are reused.
+func (c *Cache) Unpack(owner, ownerDir, path string, shift IDShift) (*Image, error) {
+	unlock, err := c.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	layout, err := OpenLayout(path, filepath.Join(c.dir, tmpDir))
+	if err != nil {
+		return nil, err
+	}
+	defer layout.Close()
+
+	manifest, manifestDigest, err := layout.Manifest()
+	if err != nil {
+		return nil, err
+	}
+
+	// reference the layers before unpacking them, so they can't be pruned
+	// before the owner is running
+	r := &ref{Dir: ownerDir, Shift: shift}
+	for _, desc := range manifest.Layers {
+		r.Layers = append(r.Layers, desc.Digest)
+	}
+	if err := c.writeRef(owner, r); err != nil {
+		return nil, err
+	}
+
+	image := &Image{Digest: manifestDigest, Shift: shift}
+	for _, desc := range manifest.Layers {
+		path, err := c.unpackLayer(layout, desc, shift)
+		if err != nil {
+			return nil, err
+		}
+		image.Layers = append(image.Layers, path)
+	}
+	return image, nil
+}
+
+// Release drops the references to layers held by owner.
+func (c *Cache) Release(owner string) error {
+	err := os.Remove(c.refPath(owner))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to release image layers: %v", err)
+	}
+	return nil
+}
+
+// Prune removes the layers no longer referenced by any task, as well as
+// references whose task directory has been garbage collected. It returns the
+// number of layers removed.
+func (c *Cache) Prune() (int, error) {
+	if _, err := os.Stat(c.dir); errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+
+	unlock, err := c.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	// leftovers from interrupted unpacks
+	if err := os.RemoveAll(filepath.Join(c.dir, tmpDir)); err != nil {
+		return 0, fmt.Errorf("failed to remove image cache temp dir: %v", err)
+	}
+
+	used, err := c.usedLayers()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	algorithms, err := os.ReadDir(filepath.Join(c.dir, layersDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to list image layers: %v", err)
+	}
+	for _, alg := range algorithms {
+		layers, err := os.ReadDir(filepath.Join(c.dir, layersDir, alg.Name()))
+		if err != nil {
+			return removed, fmt.Errorf("failed to list image layers: %v", err)
+		}
+		for _, layer := range layers {
+			path := filepath.Join(c.dir, layersDir, alg.Name(), layer.Name())
+			if _, ok := used[path]; ok {
+				continue
+			}
+			c.logger.Debug("removing unused image layer", "layer", alg.Name()+":"+layer.Name())
+			if err := os.RemoveAll(path); err != nil {
+				return removed, fmt.Errorf("failed to remove image layer %s:%s: %v", alg.Name(), layer.Name(), err)
+			}
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// usedLayers returns the set of paths of the layers referenced by live
+// tasks, removing stale references along the way.
+func (c *Cache) usedLayers() (map[string]struct{}, error) {
+	used := make(map[string]struct{})
+
+	entries, err := os.ReadDir(filepath.Join(c.dir, refsDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return used, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to list image references: %v", err)
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(c.dir, refsDir, entry.Name())
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read image reference: %v", err)
+		}
+
+		var r ref
+		if err := json.Unmarshal(b, &r); err != nil {
+			c.logger.Warn("removing invalid image reference", "ref", entry.Name(), "error", err)
+			os.Remove(path)
+			continue
+		}
+
+		if _, err := os.Stat(r.Dir); errors.Is(err, os.ErrNotExist) {
+			c.logger.Debug("removing stale image reference", "task_dir", r.Dir)
+			os.Remove(path)
+			continue
+		}
+
+		for _, d := range r.Layers {
+			used[c.layerPath(d, r.Shift)] = struct{}{}
+		}
+	}
+	return used, nil
+}
+
+// lock takes the lock of the cache, returning the function releasing it.
+func (c *Cache) lock() (func(), error) {
+	if err := os.MkdirAll(c.dir, 0711); err != nil {
+		return nil, fmt.Errorf("failed to create image cache dir: %v", err)
+	}
+	f, err := os.OpenFile(filepath.Join(c.dir, lockName), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image cache lock: %v", err)
+	}
+	if err := flock(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to lock image cache: %v", err)
+	}
+	return func() { f.Close() }, nil
+}
+
+func (c *Cache) writeRef(owner string, r *ref) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(c.dir, refsDir), 0700); err != nil {
+		return fmt.Errorf("failed to create image reference dir: %v", err)
+	}
+	if err := os.WriteFile(c.refPath(owner), b, 0600); err != nil {
+		return fmt.Errorf("failed to write image reference: %v", err)
+	}
+	return nil
+}
+
+// refPath returns the path of the reference file of owner. Owners are hashed
+// as they may contain characters not valid in file names.
+func (c *Cache) refPath(owner string) string {
+	return filepath.Join(c.dir, refsDir, digest.FromString(owner).Encoded())
+}
+
+// layerPath returns the path of a layer unpacked with the given shift. Shifted
+// layers are suffixed with the offsets.
+func (c *Cache) layerPath(d digest.Digest, shift IDShift) string {
+	name := d.Encoded()
+	if shift != (IDShift{}) {
+		name = fmt.Sprintf("%s-%d-%d", name, shift.UID, shift.GID)
+	}
+	return filepath.Join(c.dir, layersDir, d.Algorithm().String(), name)
+}
+
+// unpackLayer unpacks a layer into the cache unless it is already present,
+// returning its path.
+func (c *Cache) unpackLayer(layout *Layout, desc ocispec.Descriptor, shift IDShift) (string, error) {
+	path := c.layerPath(desc.Digest, shift)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	c.logger.Debug("unpacking image layer", "layer", desc.Digest)
+
+	blob, err := layout.Blob(desc)
+	if err != nil {
+		return "", err
+	}
+	defer blob.Close()
+
+	verifier := desc.Digest.Verifier()
+	r, err := layerReader(desc.MediaType, io.TeeReader(blob, verifier))
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	if err := os.MkdirAll(filepath.Join(c.dir, tmpDir), 0700); err != nil {
+		return "", fmt.Errorf("failed to create image cache temp dir: %v", err)
+	}
+	tmp, err := os.MkdirTemp(filepath.Join(c.dir, tmpDir), "layer-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create image cache temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := unpackLayer(r, tmp, shift); err != nil {
+		return "", fmt.Errorf("failed to unpack layer %s: %v", desc.Digest, err)
+	}
+
+	// consume any trailing data so the whole blob is verified
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return "", fmt.Errorf("failed to read layer %s: %v", desc.Digest, err)
+	}
+	if !verifier.Verified() {
+		return "", fmt.Errorf("layer %s failed digest verification", desc.Digest)
+	}
+
+	// tar entries for the root of the layer are skipped, so the root keeps
+	// the permissions and owner of the temp dir and must be opened up
+	if err := os.Chmod(tmp, 0755); err != nil {
+		return "", err
+	}
+	if shift != (IDShift{}) {
+		if err := os.Lchown(tmp, int(shift.UID), int(shift.GID)); err != nil {
+			return "", err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", fmt.Errorf("failed to create image layer dir: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return "", fmt.Errorf("failed to store layer %s: %v", desc.Digest, err)
+	}
+	return path, nil
+}
+
+// layerReader returns a reader of the uncompressed tar stream of a layer.
+func layerReader(mediaType string, r io.Reader) (io.ReadCloser, error) {
+	switch mediaType {
+	case ocispec.MediaTypeImageLayer, ocispec.MediaTypeImageLayerNonDistributable, mediaTypeDockerLayer:
+		return io.NopCloser(r), nil
+	case ocispec.MediaTypeImageLayerGzip, ocispec.MediaTypeImageLayerNonDistributableGzip, mediaTypeDockerLayerGzip:
+		return gzip.NewReader(r)
+	case ocispec.MediaTypeImageLayerZstd, ocispec.MediaTypeImageLayerNonDistributableZstd:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unsupported layer media type %q", mediaType)
+	}
+}
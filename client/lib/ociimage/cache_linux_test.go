@@ -0,0 +1,258 @@
+//go:build linux
+
+package ociimage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/opencontainers/go-digest"
+	"github.com/shoenig/test/must"
+	"golang.org/x/sys/unix"
+)
+
+func TestCache_Unpack(t *testing.T) {
+	ci.Parallel(t)
+
+	base := testLayer(t,
+		testEntry{name: "bin/"},
+		testEntry{name: "bin/app", content: "base"},
+		testEntry{name: "etc/"},
+		testEntry{name: "etc/hostname", content: "nomad"},
+	)
+	top := testLayer(t,
+		testEntry{name: "bin/app", content: "top"},
+		testEntry{name: "bin/sh", link: "/bin/app"},
+	)
+
+	image1 := t.TempDir()
+	testLayout(t, image1, base, top)
+	image2 := t.TempDir()
+	testLayout(t, image2, base)
+
+	cache := NewCache(testlog.HCLogger(t), filepath.Join(t.TempDir(), cacheDirName))
+	task1, task2 := t.TempDir(), t.TempDir()
+
+	img1, err := cache.Unpack("task1", task1, image1, IDShift{})
+	must.NoError(t, err)
+	must.Len(t, 2, img1.Layers)
+
+	b, err := os.ReadFile(filepath.Join(img1.Layers[1], "bin/app"))
+	must.NoError(t, err)
+	must.Eq(t, "top", string(b))
+	link, err := os.Readlink(filepath.Join(img1.Layers[1], "bin/sh"))
+	must.NoError(t, err)
+	must.Eq(t, "/bin/app", link)
+
+	// layers shared with other images are reused
+	img2, err := cache.Unpack("task2", task2, image2, IDShift{})
+	must.NoError(t, err)
+	must.Eq(t, img1.Layers[:1], img2.Layers)
+
+	// layers are kept while referenced
+	removed, err := cache.Prune()
+	must.NoError(t, err)
+	must.Zero(t, removed)
+
+	// released layers are pruned
+	must.NoError(t, cache.Release("task1"))
+	removed, err = cache.Prune()
+	must.NoError(t, err)
+	must.Eq(t, 1, removed)
+	_, err = os.Stat(img1.Layers[0])
+	must.NoError(t, err)
+
+	// references of task directories which were removed are stale
+	must.NoError(t, os.RemoveAll(task2))
+	removed, err = cache.Prune()
+	must.NoError(t, err)
+	must.Eq(t, 1, removed)
+
+	entries, err := os.ReadDir(filepath.Join(cache.dir, refsDir))
+	must.NoError(t, err)
+	must.Len(t, 0, entries)
+}
+
+func TestCache_Unpack_Shift(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	image := t.TempDir()
+	testLayout(t, image, testLayer(t,
+		testEntry{name: "etc/"},
+		testEntry{name: "etc/hostname", content: "nomad"},
+	))
+
+	cache := NewCache(testlog.HCLogger(t), filepath.Join(t.TempDir(), cacheDirName))
+	plain, err := cache.Unpack("task1", t.TempDir(), image, IDShift{})
+	must.NoError(t, err)
+	shift := IDShift{UID: 200000, GID: 300000}
+	shifted, err := cache.Unpack("task2", t.TempDir(), image, shift)
+	must.NoError(t, err)
+
+	// shifted layers are cached separately from the original ones
+	must.NotEq(t, plain.Layers, shifted.Layers)
+	must.Eq(t, shift, shifted.Shift)
+
+	var st unix.Stat_t
+	for _, path := range []string{shifted.Layers[0], filepath.Join(shifted.Layers[0], "etc/hostname")} {
+		must.NoError(t, unix.Lstat(path, &st))
+		must.Eq(t, shift.UID, st.Uid)
+		must.Eq(t, shift.GID, st.Gid)
+	}
+	must.NoError(t, unix.Lstat(filepath.Join(plain.Layers[0], "etc/hostname"), &st))
+	must.Zero(t, st.Uid)
+
+	// the layers of each shift are referenced separately
+	must.NoError(t, cache.Release("task1"))
+	removed, err := cache.Prune()
+	must.NoError(t, err)
+	must.Eq(t, 1, removed)
+	_, err = os.Stat(shifted.Layers[0])
+	must.NoError(t, err)
+
+	dir := t.TempDir()
+	rootfs, err := shifted.Mount(dir)
+	must.NoError(t, err)
+	defer Unmount(dir)
+	must.NoError(t, unix.Lstat(rootfs, &st))
+	must.Eq(t, shift.UID, st.Uid)
+}
+
+func TestCache_Unpack_Corrupt(t *testing.T) {
+	ci.Parallel(t)
+
+	image := t.TempDir()
+	layer := testLayer(t, testEntry{name: "etc/"})
+	testLayout(t, image, layer)
+
+	// replace the layer with another one, keeping its digest
+	d := digest.FromBytes(layer)
+	path := filepath.Join(image, blobsDir, d.Algorithm().String(), d.Encoded())
+	must.NoError(t, os.WriteFile(path, testLayer(t, testEntry{name: "bin/"}), 0644))
+
+	cache := NewCache(testlog.HCLogger(t), filepath.Join(t.TempDir(), cacheDirName))
+	_, err := cache.Unpack("task", t.TempDir(), image, IDShift{})
+	must.Error(t, err)
+	must.StrContains(t, err.Error(), "failed digest verification")
+
+	_, err = os.Stat(cache.layerPath(d, IDShift{}))
+	must.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestCache_Prune_Empty(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := filepath.Join(t.TempDir(), cacheDirName)
+	cache := NewCache(testlog.HCLogger(t), dir)
+	removed, err := cache.Prune()
+	must.NoError(t, err)
+	must.Zero(t, removed)
+
+	// pruning doesn't create the cache
+	_, err = os.Stat(dir)
+	must.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestUnpackLayer_Escape(t *testing.T) {
+	ci.Parallel(t)
+
+	outside := t.TempDir()
+	layer := testLayer(t,
+		testEntry{name: "escape", link: outside},
+		testEntry{name: "escape/file", content: "escaped"},
+		testEntry{name: "../../dotdot", content: "escaped"},
+	)
+	r, err := layerReader("application/vnd.oci.image.layer.v1.tar+gzip", bytes.NewReader(layer))
+	must.NoError(t, err)
+
+	dst := t.TempDir()
+	must.NoError(t, unpackLayer(r, dst, IDShift{}))
+
+	entries, err := os.ReadDir(outside)
+	must.NoError(t, err)
+	must.Len(t, 0, entries)
+	_, err = os.Stat(filepath.Join(dst, "dotdot"))
+	must.NoError(t, err)
+}
+
+func TestUnpackLayer_Whiteouts(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	layer := testLayer(t,
+		testEntry{name: "etc/"},
+		testEntry{name: "etc/.wh.hostname"},
+		testEntry{name: "var/"},
+		testEntry{name: "var/.wh..wh..opq"},
+	)
+	r, err := layerReader("application/vnd.oci.image.layer.v1.tar+gzip", bytes.NewReader(layer))
+	must.NoError(t, err)
+
+	dst := t.TempDir()
+	must.NoError(t, unpackLayer(r, dst, IDShift{}))
+
+	var st unix.Stat_t
+	must.NoError(t, unix.Lstat(filepath.Join(dst, "etc/hostname"), &st))
+	must.Eq(t, uint32(unix.S_IFCHR), st.Mode&unix.S_IFMT)
+	must.Eq(t, uint64(0), st.Rdev)
+
+	buf := make([]byte, 1)
+	_, err = unix.Getxattr(filepath.Join(dst, "var"), "trusted.overlay.opaque", buf)
+	must.NoError(t, err)
+	must.Eq(t, "y", string(buf))
+}
+
+func TestImage_Mount(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	base := testLayer(t,
+		testEntry{name: "etc/"},
+		testEntry{name: "etc/hostname", content: "base"},
+		testEntry{name: "etc/motd", content: "hello"},
+	)
+	top := testLayer(t,
+		testEntry{name: "etc/"},
+		testEntry{name: "etc/hostname", content: "top"},
+		testEntry{name: "etc/.wh.motd"},
+	)
+	image := t.TempDir()
+	testLayout(t, image, base, top)
+
+	cache := NewCache(testlog.HCLogger(t), filepath.Join(t.TempDir(), cacheDirName))
+	img, err := cache.Unpack("task", t.TempDir(), image, IDShift{})
+	must.NoError(t, err)
+
+	dir := t.TempDir()
+	rootfs, err := img.Mount(dir)
+	must.NoError(t, err)
+	defer Unmount(dir)
+
+	// mounting again is a no-op
+	again, err := img.Mount(dir)
+	must.NoError(t, err)
+	must.Eq(t, rootfs, again)
+
+	b, err := os.ReadFile(filepath.Join(rootfs, "etc/hostname"))
+	must.NoError(t, err)
+	must.Eq(t, "top", string(b))
+	_, err = os.Stat(filepath.Join(rootfs, "etc/motd"))
+	must.ErrorIs(t, err, os.ErrNotExist)
+
+	// changes are stored within dir rather than in the cached layers
+	must.NoError(t, os.WriteFile(filepath.Join(rootfs, "etc/hostname"), []byte("changed"), 0644))
+	b, err = os.ReadFile(filepath.Join(img.Layers[1], "etc/hostname"))
+	must.NoError(t, err)
+	must.Eq(t, "top", string(b))
+
+	must.NoError(t, Unmount(dir))
+	_, err = os.Stat(filepath.Join(rootfs, "etc"))
+	must.ErrorIs(t, err, os.ErrNotExist)
+	must.NoError(t, Unmount(dir))
+}
@@ -0,0 +1,10 @@
+/*
+Package ociimage unpacks OCI image layouts into root filesystems for tasks.
+
+Image layers are extracted once per node into a layer cache and shared by all
+tasks using them, with each task's root filesystem assembled as an overlay of
+the cached layers. Tasks hold a reference on the layers of their image for as
+long as they run, and unreferenced layers are pruned by the client's garbage
+collector when disk usage requires it.
+*/
+package ociimage
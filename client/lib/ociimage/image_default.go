@@ -0,0 +1,32 @@
+//go:build !linux
+
+package ociimage
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// errUnsupported is returned when using images outside of Linux
+var errUnsupported = errors.New("images are only supported on linux")
+
+// Mount is not supported on non-Linux operating systems.
+func (i *Image) Mount(dir string) (string, error) {
+	return "", errUnsupported
+}
+
+// Unmount is a no-op on non-Linux operating systems.
+func Unmount(dir string) error {
+	return nil
+}
+
+func unpackLayer(r io.Reader, dst string, shift IDShift) error {
+	return errUnsupported
+}
+
+// flock is a no-op on non-Linux operating systems, where layers are never
+// unpacked.
+func flock(f *os.File) error {
+	return nil
+}
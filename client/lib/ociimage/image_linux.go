@@ -0,0 +1,240 @@
+//go:build linux
+
+package ociimage
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/moby/sys/mountinfo"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// whiteoutPrefix marks a file removed from the layers below
+	whiteoutPrefix = ".wh."
+
+	// whiteoutOpaque marks a directory whose contents in the layers below
+	// are hidden
+	whiteoutOpaque = whiteoutPrefix + whiteoutPrefix + ".opq"
+
+	// paxXattrPrefix is the prefix of PAX records holding extended attributes
+	paxXattrPrefix = "SCHILY.xattr."
+)
+
+// Mount assembles a root filesystem from the image layers as an overlay
+// mounted at dir/rootfs, with changes made by the task stored in dir. The
+// path of the root filesystem is returned. The root of the filesystem is owned
+// by the shifted root user of the image. Mounting an image which is already
+// mounted is a no-op.
+func (i *Image) Mount(dir string) (string, error) {
+	rootfs := filepath.Join(dir, "rootfs")
+	upper := filepath.Join(dir, "upper")
+	work := filepath.Join(dir, "work")
+	for _, d := range []string{rootfs, upper, work} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			return "", fmt.Errorf("failed to create image dir: %v", err)
+		}
+	}
+
+	if i.Shift != (IDShift{}) {
+		if err := os.Lchown(upper, int(i.Shift.UID), int(i.Shift.GID)); err != nil {
+			return "", fmt.Errorf("failed to change owner of image dir: %v", err)
+		}
+	}
+
+	if mounted, err := mountinfo.Mounted(rootfs); err != nil {
+		return "", fmt.Errorf("failed to check image mount: %v", err)
+	} else if mounted {
+		return rootfs, nil
+	}
+
+	// overlayfs expects the lower layers from the top down
+	lower := make([]string, len(i.Layers))
+	for n, layer := range i.Layers {
+		lower[len(i.Layers)-n-1] = layer
+	}
+	data := fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s", strings.Join(lower, ":"), upper, work)
+	if len(data) >= unix.Getpagesize() {
+		return "", fmt.Errorf("image has too many layers to mount (%d)", len(i.Layers))
+	}
+
+	if err := unix.Mount("overlay", rootfs, "overlay", 0, data); err != nil {
+		return "", fmt.Errorf("failed to mount image: %v", err)
+	}
+	return rootfs, nil
+}
+
+// Unmount removes the root filesystem mounted by Image.Mount in dir, if any.
+func Unmount(dir string) error {
+	rootfs := filepath.Join(dir, "rootfs")
+	mounted, err := mountinfo.Mounted(rootfs)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to check image mount: %v", err)
+	}
+	if !mounted {
+		return nil
+	}
+	if err := unix.Unmount(rootfs, unix.MNT_DETACH); err != nil {
+		return fmt.Errorf("failed to unmount image: %v", err)
+	}
+	return nil
+}
+
+// unpackLayer extracts a layer tar stream into dst, converting OCI whiteouts
+// into their overlayfs representation. The owners of the files are offset by
+// shift.
+func unpackLayer(r io.Reader, dst string, shift IDShift) error {
+	type dirTimes struct {
+		path  string
+		mtime time.Time
+	}
+	var dirs []dirTimes
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		name := filepath.Clean(string(filepath.Separator) + hdr.Name)
+		if name == string(filepath.Separator) {
+			continue
+		}
+
+		// resolve the parent within dst so symlinks in the layer can't be
+		// used to write outside of it
+		parentName, base := filepath.Split(name)
+		parent, err := securejoin.SecureJoin(dst, parentName)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(parent, 0755); err != nil {
+			return err
+		}
+
+		if base == whiteoutOpaque {
+			if err := unix.Setxattr(parent, "trusted.overlay.opaque", []byte("y"), 0); err != nil {
+				return fmt.Errorf("failed to mark %s opaque: %v", parentName, err)
+			}
+			continue
+		}
+
+		path := filepath.Join(parent, base)
+		if strings.HasPrefix(base, whiteoutPrefix) {
+			path = filepath.Join(parent, strings.TrimPrefix(base, whiteoutPrefix))
+			if err := os.RemoveAll(path); err != nil {
+				return err
+			}
+			if err := unix.Mknod(path, unix.S_IFCHR, 0); err != nil {
+				return fmt.Errorf("failed to create whiteout for %s: %v", name, err)
+			}
+			continue
+		}
+
+		// later entries replace earlier ones, except for directories
+		if fi, err := os.Lstat(path); err == nil && !(fi.IsDir() && hdr.Typeflag == tar.TypeDir) {
+			if err := os.RemoveAll(path); err != nil {
+				return err
+			}
+		}
+
+		mode := uint32(hdr.Mode & 07777)
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.Mkdir(path, 0755); err != nil && !errors.Is(err, os.ErrExist) {
+				return err
+			}
+			dirs = append(dirs, dirTimes{path: path, mtime: hdr.ModTime})
+		case tar.TypeReg, tar.TypeRegA:
+			if err := writeFile(path, tr); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.Symlink(hdr.Linkname, path); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			target, err := securejoin.SecureJoin(dst, hdr.Linkname)
+			if err != nil {
+				return err
+			}
+			if err := os.Link(target, path); err != nil {
+				return err
+			}
+		case tar.TypeChar:
+			if err := unix.Mknod(path, unix.S_IFCHR|mode, int(unix.Mkdev(uint32(hdr.Devmajor), uint32(hdr.Devminor)))); err != nil {
+				return err
+			}
+		case tar.TypeBlock:
+			if err := unix.Mknod(path, unix.S_IFBLK|mode, int(unix.Mkdev(uint32(hdr.Devmajor), uint32(hdr.Devminor)))); err != nil {
+				return err
+			}
+		case tar.TypeFifo:
+			if err := unix.Mkfifo(path, mode); err != nil {
+				return err
+			}
+		default:
+			continue
+		}
+
+		if err := os.Lchown(path, hdr.Uid+int(shift.UID), hdr.Gid+int(shift.GID)); err != nil {
+			return err
+		}
+		for key, value := range hdr.PAXRecords {
+			if !strings.HasPrefix(key, paxXattrPrefix) {
+				continue
+			}
+			attr := strings.TrimPrefix(key, paxXattrPrefix)
+			if err := unix.Lsetxattr(path, attr, []byte(value), 0); err != nil && !errors.Is(err, unix.ENOTSUP) {
+				return fmt.Errorf("failed to set xattr %s on %s: %v", attr, name, err)
+			}
+		}
+		if hdr.Typeflag == tar.TypeSymlink {
+			if err := lchtimes(path, hdr.ModTime); err != nil {
+				return err
+			}
+			continue
+		}
+		// chmod after chown, which clears the setuid and setgid bits
+		if err := os.Chmod(path, hdr.FileInfo().Mode()); err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeDir {
+			if err := lchtimes(path, hdr.ModTime); err != nil {
+				return err
+			}
+		}
+	}
+
+	// directory times are set last, as creating their entries modifies them
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := lchtimes(dirs[i].path, dirs[i].mtime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flock takes an exclusive lock on f, released when f is closed.
+func flock(f *os.File) error {
+	return unix.Flock(int(f.Fd()), unix.LOCK_EX)
+}
+
+func lchtimes(path string, mtime time.Time) error {
+	ts := []unix.Timespec{unix.NsecToTimespec(mtime.UnixNano()), unix.NsecToTimespec(mtime.UnixNano())}
+	return unix.UtimesNanoAt(unix.AT_FDCWD, path, ts, unix.AT_SYMLINK_NOFOLLOW)
+}
@@ -0,0 +1,225 @@
+package ociimage
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// indexFile is the name of the image index at the root of a layout
+	indexFile = "index.json"
+
+	// blobsDir is the directory of a layout containing its content
+	// addressable blobs
+	blobsDir = "blobs"
+
+	// mediaTypeDockerManifest and mediaTypeDockerManifestList are the media
+	// types of Docker v2 schema 2 manifests, which are commonly found in OCI
+	// layouts exported by Docker tooling.
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+// Layout is an OCI image layout, either a directory or a tarball of one.
+type Layout struct {
+	root string
+
+	// tmp is set when the layout was extracted from a tarball, and removed
+	// when the layout is closed
+	tmp string
+}
+
+// OpenLayout opens the OCI image layout at path. Tarballs, optionally gzip
+// compressed, are extracted into a temporary directory within tmpDir.
+func OpenLayout(path, tmpDir string) (*Layout, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image: %v", err)
+	}
+	if fi.IsDir() {
+		return &Layout{root: path}, nil
+	}
+
+	if err := os.MkdirAll(tmpDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create image temp dir: %v", err)
+	}
+	tmp, err := os.MkdirTemp(tmpDir, "layout-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create image temp dir: %v", err)
+	}
+	if err := extractLayout(path, tmp); err != nil {
+		os.RemoveAll(tmp)
+		return nil, err
+	}
+	return &Layout{root: tmp, tmp: tmp}, nil
+}
+
+// Close removes any temporary files created by opening the layout.
+func (l *Layout) Close() error {
+	if l.tmp == "" {
+		return nil
+	}
+	return os.RemoveAll(l.tmp)
+}
+
+// Manifest returns the image manifest of the layout. If the layout indexes
+// multiple manifests, the first matching the client's platform is used.
+func (l *Layout) Manifest() (*ocispec.Manifest, digest.Digest, error) {
+	b, err := os.ReadFile(filepath.Join(l.root, indexFile))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read image index: %v", err)
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(b, &index); err != nil {
+		return nil, "", fmt.Errorf("failed to decode image index: %v", err)
+	}
+	return l.resolve(&index)
+}
+
+// resolve finds the manifest for the client's platform within an index,
+// descending into nested indexes.
+func (l *Layout) resolve(index *ocispec.Index) (*ocispec.Manifest, digest.Digest, error) {
+	for _, desc := range index.Manifests {
+		if !platformMatches(desc.Platform) {
+			continue
+		}
+
+		b, err := l.readBlob(desc)
+		if err != nil {
+			return nil, "", err
+		}
+
+		switch desc.MediaType {
+		case ocispec.MediaTypeImageManifest, mediaTypeDockerManifest:
+			var manifest ocispec.Manifest
+			if err := json.Unmarshal(b, &manifest); err != nil {
+				return nil, "", fmt.Errorf("failed to decode image manifest: %v", err)
+			}
+			return &manifest, desc.Digest, nil
+		case ocispec.MediaTypeImageIndex, mediaTypeDockerManifestList:
+			var nested ocispec.Index
+			if err := json.Unmarshal(b, &nested); err != nil {
+				return nil, "", fmt.Errorf("failed to decode image index: %v", err)
+			}
+			return l.resolve(&nested)
+		}
+	}
+	return nil, "", fmt.Errorf("no image manifest found for platform %s/%s", runtime.GOOS, runtime.GOARCH)
+}
+
+// Blob opens the blob referenced by desc.
+func (l *Layout) Blob(desc ocispec.Descriptor) (*os.File, error) {
+	if err := desc.Digest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid blob digest %q: %v", desc.Digest, err)
+	}
+	f, err := os.Open(filepath.Join(l.root, blobsDir, desc.Digest.Algorithm().String(), desc.Digest.Encoded()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob %s: %v", desc.Digest, err)
+	}
+	return f, nil
+}
+
+// readBlob reads and verifies the contents of a small blob such as a
+// manifest or index.
+func (l *Layout) readBlob(desc ocispec.Descriptor) ([]byte, error) {
+	f, err := l.Blob(desc)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob %s: %v", desc.Digest, err)
+	}
+	if desc.Digest.Algorithm().FromBytes(b) != desc.Digest {
+		return nil, fmt.Errorf("blob %s failed digest verification", desc.Digest)
+	}
+	return b, nil
+}
+
+func platformMatches(p *ocispec.Platform) bool {
+	if p == nil {
+		return true
+	}
+	return p.OS == runtime.GOOS && p.Architecture == runtime.GOARCH
+}
+
+// extractLayout extracts the regular files and directories of an image
+// layout tarball into dst.
+func extractLayout(src, dst string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open image: %v", err)
+	}
+	defer f.Close()
+
+	r, err := decompress(f)
+	if err != nil {
+		return fmt.Errorf("failed to read image: %v", err)
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read image: %v", err)
+		}
+
+		name := filepath.Clean(string(filepath.Separator) + hdr.Name)
+		path := filepath.Join(dst, name)
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+				return err
+			}
+			if err := writeFile(path, tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeFile(path string, r io.Reader) error {
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// decompress returns a reader of r, decompressing it if it is gzip
+// compressed.
+func decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		return gzip.NewReader(br)
+	}
+	return br, nil
+}
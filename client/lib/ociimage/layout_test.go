@@ -0,0 +1,201 @@
+package ociimage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/shoenig/test/must"
+)
+
+// testEntry is an entry of a test layer. Entries with a trailing slash are
+// directories, entries with a link are symlinks.
+type testEntry struct {
+	name    string
+	content string
+	link    string
+}
+
+// testLayer returns a gzip compressed layer containing entries.
+func testLayer(t *testing.T, entries ...testEntry) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{
+			Name: e.name,
+			Mode: 0644,
+			Uid:  os.Getuid(),
+			Gid:  os.Getgid(),
+		}
+		switch {
+		case e.link != "":
+			hdr.Typeflag = tar.TypeSymlink
+			hdr.Linkname = e.link
+		case e.name[len(e.name)-1] == '/':
+			hdr.Typeflag = tar.TypeDir
+			hdr.Mode = 0755
+		default:
+			hdr.Typeflag = tar.TypeReg
+			hdr.Size = int64(len(e.content))
+		}
+		must.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write([]byte(e.content))
+		must.NoError(t, err)
+	}
+	must.NoError(t, tw.Close())
+	must.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// writeBlob writes b as a blob of the layout in dir, returning its
+// descriptor.
+func writeBlob(t *testing.T, dir, mediaType string, b []byte) ocispec.Descriptor {
+	d := digest.FromBytes(b)
+	path := filepath.Join(dir, blobsDir, d.Algorithm().String(), d.Encoded())
+	must.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	must.NoError(t, os.WriteFile(path, b, 0644))
+	return ocispec.Descriptor{MediaType: mediaType, Digest: d, Size: int64(len(b))}
+}
+
+// testLayout writes an OCI image layout of the layers to dir, returning the
+// digest of its manifest.
+func testLayout(t *testing.T, dir string, layers ...[]byte) digest.Digest {
+	config := writeBlob(t, dir, ocispec.MediaTypeImageConfig, []byte("{}"))
+	manifest := ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    config,
+	}
+	for _, layer := range layers {
+		manifest.Layers = append(manifest.Layers, writeBlob(t, dir, ocispec.MediaTypeImageLayerGzip, layer))
+	}
+	b, err := json.Marshal(manifest)
+	must.NoError(t, err)
+	desc := writeBlob(t, dir, ocispec.MediaTypeImageManifest, b)
+
+	index := ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Manifests: []ocispec.Descriptor{
+			{
+				MediaType: ocispec.MediaTypeImageManifest,
+				Digest:    digest.FromString("other platform"),
+				Platform:  &ocispec.Platform{OS: "plan9", Architecture: "mips"},
+			},
+			{
+				MediaType: desc.MediaType,
+				Digest:    desc.Digest,
+				Size:      desc.Size,
+				Platform:  &ocispec.Platform{OS: runtime.GOOS, Architecture: runtime.GOARCH},
+			},
+		},
+	}
+	b, err = json.Marshal(index)
+	must.NoError(t, err)
+	must.NoError(t, os.WriteFile(filepath.Join(dir, indexFile), b, 0644))
+	return desc.Digest
+}
+
+// tarDir writes a gzip compressed tarball of the regular files and
+// directories in dir to path.
+func tarDir(t *testing.T, dir, path string) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	err := filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(fi, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = rel
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(b)
+		return err
+	})
+	must.NoError(t, err)
+	must.NoError(t, tw.Close())
+	must.NoError(t, gz.Close())
+	must.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+}
+
+func TestLayout_Manifest(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	layer := testLayer(t, testEntry{name: "etc/"}, testEntry{name: "etc/hostname", content: "nomad"})
+	exp := testLayout(t, dir, layer)
+
+	t.Run("directory", func(t *testing.T) {
+		layout, err := OpenLayout(dir, t.TempDir())
+		must.NoError(t, err)
+		defer layout.Close()
+
+		manifest, d, err := layout.Manifest()
+		must.NoError(t, err)
+		must.Eq(t, exp, d)
+		must.Len(t, 1, manifest.Layers)
+		must.Eq(t, digest.FromBytes(layer), manifest.Layers[0].Digest)
+	})
+
+	t.Run("tarball", func(t *testing.T) {
+		tarball := filepath.Join(t.TempDir(), "image.tar.gz")
+		tarDir(t, dir, tarball)
+
+		tmp := t.TempDir()
+		layout, err := OpenLayout(tarball, tmp)
+		must.NoError(t, err)
+
+		_, d, err := layout.Manifest()
+		must.NoError(t, err)
+		must.Eq(t, exp, d)
+
+		must.NoError(t, layout.Close())
+		entries, err := os.ReadDir(tmp)
+		must.NoError(t, err)
+		must.Len(t, 0, entries)
+	})
+
+	t.Run("corrupt blob", func(t *testing.T) {
+		corrupt := t.TempDir()
+		d := testLayout(t, corrupt, layer)
+		path := filepath.Join(corrupt, blobsDir, d.Algorithm().String(), d.Encoded())
+		must.NoError(t, os.WriteFile(path, []byte(`{"layers":[]}`), 0644))
+
+		layout, err := OpenLayout(corrupt, t.TempDir())
+		must.NoError(t, err)
+		_, _, err = layout.Manifest()
+		must.Error(t, err)
+		must.StrContains(t, err.Error(), "failed digest verification")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := OpenLayout(filepath.Join(dir, "missing"), t.TempDir())
+		must.Error(t, err)
+		must.StrContains(t, err.Error(), "failed to open image")
+	})
+}
@@ -0,0 +1,246 @@
+// Package userns allocates the ranges of host user and group IDs which the
+// tasks of allocations are remapped to when running in user namespaces.
+package userns
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// DefaultSize is the number of IDs mapped into each user namespace when the
+// client configuration doesn't set one, which covers the 16 bit IDs used by
+// most images.
+const DefaultSize = 65536
+
+// Range is a range of host user or group IDs.
+type Range struct {
+	Start uint32
+	Count uint32
+}
+
+// ParseRange parses a range in the "start:count" format of /etc/subuid.
+func ParseRange(s string) (Range, error) {
+	start, count, ok := strings.Cut(s, ":")
+	if !ok {
+		return Range{}, fmt.Errorf("invalid range %q: must be of the form start:count", s)
+	}
+	st, err := strconv.ParseUint(start, 10, 32)
+	if err != nil {
+		return Range{}, fmt.Errorf("invalid range %q: invalid start: %v", s, err)
+	}
+	c, err := strconv.ParseUint(count, 10, 32)
+	if err != nil {
+		return Range{}, fmt.Errorf("invalid range %q: invalid count: %v", s, err)
+	}
+	return Range{Start: uint32(st), Count: uint32(c)}, nil
+}
+
+func (r Range) String() string {
+	return fmt.Sprintf("%d:%d", r.Start, r.Count)
+}
+
+// validate returns an error if the range can't hold a namespace of size IDs
+// or includes the root user of the host.
+func (r Range) validate(size uint32) error {
+	switch {
+	case r.Start == 0:
+		return fmt.Errorf("range %s must not include ID 0", r)
+	case r.Count < size:
+		return fmt.Errorf("range %s must hold at least %d IDs", r, size)
+	case uint64(r.Start)+uint64(r.Count) > math.MaxUint32:
+		return fmt.Errorf("range %s exceeds the maximum ID", r)
+	}
+	return nil
+}
+
+// PoolConfig is the configuration of the IDs available to a Pool.
+type PoolConfig struct {
+	// UIDs and GIDs are the host IDs which are mapped into namespaces.
+	UIDs Range
+	GIDs Range
+
+	// Size is the number of IDs mapped into each namespace.
+	Size uint32
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *PoolConfig) Validate() error {
+	if c.Size == 0 {
+		return fmt.Errorf("user namespace size must be greater than 0")
+	}
+	if err := c.UIDs.validate(c.Size); err != nil {
+		return fmt.Errorf("invalid subuids: %v", err)
+	}
+	if err := c.GIDs.validate(c.Size); err != nil {
+		return fmt.Errorf("invalid subgids: %v", err)
+	}
+	return nil
+}
+
+func (c *PoolConfig) Copy() *PoolConfig {
+	if c == nil {
+		return nil
+	}
+	nc := *c
+	return &nc
+}
+
+// PoolState is what we persist in the client state store.
+type PoolState struct {
+	// Allocations maps the IDs of allocations to their namespaces.
+	Allocations map[string]*drivers.UserNamespace
+}
+
+// StateStorage is used to persist the allocated namespaces across agent
+// restarts.
+type StateStorage interface {
+	// GetUserNamespacePoolState is used to restore the pool state
+	GetUserNamespacePoolState() (*PoolState, error)
+
+	// PutUserNamespacePoolState is used to store the pool state
+	PutUserNamespacePoolState(state *PoolState) error
+}
+
+// Pool allocates a distinct range of host IDs to each allocation, so that
+// the tasks of an allocation can't access the processes and files of
+// another one or of the host.
+type Pool struct {
+	logger hclog.Logger
+	config *PoolConfig
+	state  StateStorage
+
+	allocs map[string]*drivers.UserNamespace
+	mu     sync.Mutex
+}
+
+// NewPool returns a pool of the IDs of config, restoring the namespaces
+// previously allocated from the state.
+func NewPool(logger hclog.Logger, config *PoolConfig, state StateStorage) (*Pool, error) {
+	p := &Pool{
+		logger: logger.Named("userns"),
+		config: config,
+		state:  state,
+		allocs: make(map[string]*drivers.UserNamespace),
+	}
+
+	ps, err := state.GetUserNamespacePoolState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore user namespace pool: %v", err)
+	}
+	if ps != nil && ps.Allocations != nil {
+		p.allocs = ps.Allocations
+	}
+	return p, nil
+}
+
+// Allocate returns the namespace of the allocation, allocating one if it
+// doesn't have one yet.
+func (p *Pool) Allocate(allocID string) (*drivers.UserNamespace, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if ns, ok := p.allocs[allocID]; ok {
+		return ns.Copy(), nil
+	}
+
+	slots := p.config.UIDs.Count / p.config.Size
+	if n := p.config.GIDs.Count / p.config.Size; n < slots {
+		slots = n
+	}
+
+	// namespaces restored from a previous configuration may overlap any
+	// slot, so check each against all of them
+	for i := uint32(0); i < slots; i++ {
+		ns := &drivers.UserNamespace{
+			HostUID: p.config.UIDs.Start + i*p.config.Size,
+			HostGID: p.config.GIDs.Start + i*p.config.Size,
+			Size:    p.config.Size,
+		}
+		if p.inUse(ns) {
+			continue
+		}
+
+		p.allocs[allocID] = ns
+		if err := p.persist(); err != nil {
+			delete(p.allocs, allocID)
+			return nil, err
+		}
+		p.logger.Debug("allocated user namespace", "alloc_id", allocID, "namespace", ns)
+		return ns.Copy(), nil
+	}
+	return nil, fmt.Errorf("no user namespaces available: all %d are allocated", slots)
+}
+
+// inUse returns whether the IDs of ns overlap those of an allocated
+// namespace.
+func (p *Pool) inUse(ns *drivers.UserNamespace) bool {
+	overlaps := func(a, b, size uint32) bool {
+		return a < b+size && b < a+ns.Size
+	}
+	for _, other := range p.allocs {
+		if overlaps(ns.HostUID, other.HostUID, other.Size) || overlaps(ns.HostGID, other.HostGID, other.Size) {
+			return true
+		}
+	}
+	return false
+}
+
+// Release returns the namespace of the allocation to the pool.
+func (p *Pool) Release(allocID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ns, ok := p.allocs[allocID]
+	if !ok {
+		return nil
+	}
+	delete(p.allocs, allocID)
+	if err := p.persist(); err != nil {
+		p.allocs[allocID] = ns
+		return err
+	}
+	p.logger.Debug("released user namespace", "alloc_id", allocID, "namespace", ns)
+	return nil
+}
+
+// Reconcile releases the namespaces of allocations which aren't in
+// allocIDs, such as those destroyed while the client wasn't running.
+func (p *Pool) Reconcile(allocIDs []string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	keep := make(map[string]struct{}, len(allocIDs))
+	for _, id := range allocIDs {
+		keep[id] = struct{}{}
+	}
+
+	removed := 0
+	for id := range p.allocs {
+		if _, ok := keep[id]; !ok {
+			delete(p.allocs, id)
+			removed++
+		}
+	}
+	if removed == 0 {
+		return nil
+	}
+	p.logger.Debug("released user namespaces of unknown allocations", "count", removed)
+	return p.persist()
+}
+
+func (p *Pool) persist() error {
+	allocs := make(map[string]*drivers.UserNamespace, len(p.allocs))
+	for id, ns := range p.allocs {
+		allocs[id] = ns.Copy()
+	}
+	if err := p.state.PutUserNamespacePoolState(&PoolState{Allocations: allocs}); err != nil {
+		return fmt.Errorf("failed to store user namespace pool: %v", err)
+	}
+	return nil
+}
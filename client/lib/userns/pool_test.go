@@ -0,0 +1,150 @@
+package userns
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+)
+
+// memState is a StateStorage keeping the pool state in memory.
+type memState struct {
+	state *PoolState
+	err   error
+}
+
+func (m *memState) GetUserNamespacePoolState() (*PoolState, error) {
+	return m.state, nil
+}
+
+func (m *memState) PutUserNamespacePoolState(state *PoolState) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.state = state
+	return nil
+}
+
+func TestParseRange(t *testing.T) {
+	ci.Parallel(t)
+
+	r, err := ParseRange("100000:65536")
+	must.NoError(t, err)
+	must.Eq(t, Range{Start: 100000, Count: 65536}, r)
+
+	for _, s := range []string{"100000", "a:1", "1:b", "1:-1", "4294967296:1"} {
+		_, err := ParseRange(s)
+		must.Error(t, err)
+	}
+}
+
+func TestPoolConfig_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	valid := PoolConfig{
+		UIDs: Range{Start: 100000, Count: 65536 * 4},
+		GIDs: Range{Start: 100000, Count: 65536 * 4},
+		Size: 65536,
+	}
+	must.NoError(t, valid.Validate())
+
+	cases := []struct {
+		name   string
+		modify func(*PoolConfig)
+		err    string
+	}{
+		{"zero size", func(c *PoolConfig) { c.Size = 0 }, "size must be greater than 0"},
+		{"root uid", func(c *PoolConfig) { c.UIDs.Start = 0 }, "must not include ID 0"},
+		{"small gids", func(c *PoolConfig) { c.GIDs.Count = 1000 }, "must hold at least 65536 IDs"},
+		{"overflow", func(c *PoolConfig) { c.UIDs.Start = 4294967000 }, "exceeds the maximum ID"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := valid.Copy()
+			tc.modify(c)
+			err := c.Validate()
+			must.Error(t, err)
+			must.StrContains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestPool_Allocate(t *testing.T) {
+	ci.Parallel(t)
+
+	state := &memState{}
+	config := &PoolConfig{
+		UIDs: Range{Start: 100000, Count: 300},
+		GIDs: Range{Start: 200000, Count: 200},
+		Size: 100,
+	}
+	pool, err := NewPool(testlog.HCLogger(t), config, state)
+	must.NoError(t, err)
+
+	ns1, err := pool.Allocate("alloc1")
+	must.NoError(t, err)
+	must.Eq(t, &drivers.UserNamespace{HostUID: 100000, HostGID: 200000, Size: 100}, ns1)
+
+	// allocating is idempotent
+	again, err := pool.Allocate("alloc1")
+	must.NoError(t, err)
+	must.Eq(t, ns1, again)
+
+	ns2, err := pool.Allocate("alloc2")
+	must.NoError(t, err)
+	must.Eq(t, &drivers.UserNamespace{HostUID: 100100, HostGID: 200100, Size: 100}, ns2)
+
+	// the smaller of the ranges bounds the number of namespaces
+	_, err = pool.Allocate("alloc3")
+	must.Error(t, err)
+	must.StrContains(t, err.Error(), "all 2 are allocated")
+
+	// released namespaces are reused
+	must.NoError(t, pool.Release("alloc1"))
+	ns3, err := pool.Allocate("alloc3")
+	must.NoError(t, err)
+	must.Eq(t, ns1, ns3)
+
+	// failing to persist leaves the pool unchanged
+	must.NoError(t, pool.Release("alloc3"))
+	state.err = errors.New("disk full")
+	_, err = pool.Allocate("alloc4")
+	must.Error(t, err)
+	must.MapLen(t, 1, pool.allocs)
+}
+
+func TestPool_Restore(t *testing.T) {
+	ci.Parallel(t)
+
+	// alloc1 was allocated with a larger size, overlapping the first two
+	// namespaces of the current configuration
+	state := &memState{state: &PoolState{
+		Allocations: map[string]*drivers.UserNamespace{
+			"alloc1": {HostUID: 100000, HostGID: 100000, Size: 150},
+			"alloc2": {HostUID: 100200, HostGID: 100200, Size: 100},
+		},
+	}}
+	config := &PoolConfig{
+		UIDs: Range{Start: 100000, Count: 1000},
+		GIDs: Range{Start: 100000, Count: 1000},
+		Size: 100,
+	}
+	pool, err := NewPool(testlog.HCLogger(t), config, state)
+	must.NoError(t, err)
+
+	ns, err := pool.Allocate("alloc3")
+	must.NoError(t, err)
+	must.Eq(t, uint32(100300), ns.HostUID)
+
+	// namespaces of unknown allocations are released
+	must.NoError(t, pool.Reconcile([]string{"alloc2", "alloc3"}))
+	must.MapLen(t, 2, state.state.Allocations)
+	must.MapContainsKeys(t, state.state.Allocations, []string{"alloc2", "alloc3"})
+
+	ns, err = pool.Allocate("alloc4")
+	must.NoError(t, err)
+	must.Eq(t, uint32(100000), ns.HostUID)
+}
@@ -11,6 +11,7 @@ import (
 	trstate "github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
 	dmstate "github.com/hashicorp/nomad/client/devicemanager/state"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
+	"github.com/hashicorp/nomad/client/lib/userns"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
 	"github.com/hashicorp/nomad/client/serviceregistration/checks"
 	"github.com/hashicorp/nomad/helper/boltdd"
@@ -43,6 +44,9 @@ drivermanager/
 
 dynamicplugins/
 |--> registry_state -> *dynamicplugins.RegistryState
+
+userns/
+|--> pool_state -> *userns.PoolState
 */
 
 var (
@@ -102,6 +106,14 @@ var (
 
 	// registryStateKey is the key at which dynamic plugin registry state is stored
 	registryStateKey = []byte("registry_state")
+
+	// usernsBucketName is the bucket name containing the user namespace
+	// pool data
+	usernsBucketName = []byte("userns")
+
+	// poolStateKey is the key at which the user namespace pool state is
+	// stored
+	poolStateKey = []byte("pool_state")
 )
 
 // taskBucketName returns the bucket name for the given task name.
@@ -725,6 +737,50 @@ func (s *BoltStateDB) GetDynamicPluginRegistryState() (*dynamicplugins.RegistryS
 	return ps, nil
 }
 
+// PutUserNamespacePoolState stores the user namespace pool's state or
+// returns an error.
+func (s *BoltStateDB) PutUserNamespacePoolState(ps *userns.PoolState) error {
+	return s.db.Update(func(tx *boltdd.Tx) error {
+		bkt, err := tx.CreateBucketIfNotExists(usernsBucketName)
+		if err != nil {
+			return err
+		}
+		return bkt.Put(poolStateKey, ps)
+	})
+}
+
+// GetUserNamespacePoolState retrieves the user namespace pool's state or
+// returns an error.
+func (s *BoltStateDB) GetUserNamespacePoolState() (*userns.PoolState, error) {
+	var ps *userns.PoolState
+
+	err := s.db.View(func(tx *boltdd.Tx) error {
+		bkt := tx.Bucket(usernsBucketName)
+		if bkt == nil {
+			// No state, return
+			return nil
+		}
+
+		ps = &userns.PoolState{}
+		if err := bkt.Get(poolStateKey, ps); err != nil {
+			if !boltdd.IsErrNotFound(err) {
+				return fmt.Errorf("failed to read user namespace pool state: %v", err)
+			}
+
+			// Key not found, reset ps to nil
+			ps = nil
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ps, nil
+}
+
 func keyForCheck(allocID string, checkID structs.CheckID) []byte {
 	return []byte(fmt.Sprintf("%s_%s", allocID, checkID))
 }
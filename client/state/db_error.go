@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
 	dmstate "github.com/hashicorp/nomad/client/devicemanager/state"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
+	"github.com/hashicorp/nomad/client/lib/userns"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
 	"github.com/hashicorp/nomad/client/serviceregistration/checks"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -81,6 +82,14 @@ func (m *ErrDB) PutDynamicPluginRegistryState(state *dynamicplugins.RegistryStat
 	return fmt.Errorf("Error!")
 }
 
+func (m *ErrDB) GetUserNamespacePoolState() (*userns.PoolState, error) {
+	return nil, fmt.Errorf("Error!")
+}
+
+func (m *ErrDB) PutUserNamespacePoolState(state *userns.PoolState) error {
+	return fmt.Errorf("Error!")
+}
+
 func (m *ErrDB) GetDevicePluginState() (*dmstate.PluginState, error) {
 	return nil, fmt.Errorf("Error!")
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
 	dmstate "github.com/hashicorp/nomad/client/devicemanager/state"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
+	"github.com/hashicorp/nomad/client/lib/userns"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
 	"github.com/hashicorp/nomad/client/serviceregistration/checks"
 	"github.com/hashicorp/nomad/helper"
@@ -41,6 +42,9 @@ type MemDB struct {
 	// dynamicmanager -> registry-state
 	dynamicManagerPs *dynamicplugins.RegistryState
 
+	// userns -> pool-state
+	usernsPs *userns.PoolState
+
 	logger hclog.Logger
 
 	mu sync.RWMutex
@@ -233,6 +237,19 @@ func (m *MemDB) PutDynamicPluginRegistryState(ps *dynamicplugins.RegistryState)
 	return nil
 }
 
+func (m *MemDB) GetUserNamespacePoolState() (*userns.PoolState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.usernsPs, nil
+}
+
+func (m *MemDB) PutUserNamespacePoolState(ps *userns.PoolState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.usernsPs = ps
+	return nil
+}
+
 func (m *MemDB) PutCheckResult(allocID string, qr *structs.CheckQueryResult) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
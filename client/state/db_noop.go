@@ -4,6 +4,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
 	dmstate "github.com/hashicorp/nomad/client/devicemanager/state"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
+	"github.com/hashicorp/nomad/client/lib/userns"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
 	"github.com/hashicorp/nomad/client/serviceregistration/checks"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -88,6 +89,14 @@ func (n NoopDB) GetDynamicPluginRegistryState() (*dynamicplugins.RegistryState,
 	return nil, nil
 }
 
+func (n NoopDB) PutUserNamespacePoolState(ps *userns.PoolState) error {
+	return nil
+}
+
+func (n NoopDB) GetUserNamespacePoolState() (*userns.PoolState, error) {
+	return nil, nil
+}
+
 func (n NoopDB) PutCheckResult(allocID string, qr *structs.CheckQueryResult) error {
 	return nil
 }
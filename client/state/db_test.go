@@ -11,10 +11,12 @@ import (
 	trstate "github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
 	dmstate "github.com/hashicorp/nomad/client/devicemanager/state"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
+	"github.com/hashicorp/nomad/client/lib/userns"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/kr/pretty"
 	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
@@ -381,6 +383,34 @@ func TestStateDB_DynamicRegistry(t *testing.T) {
 	})
 }
 
+// TestStateDB_UserNamespacePool asserts the behavior of user namespace pool
+// state related StateDB methods.
+func TestStateDB_UserNamespacePool(t *testing.T) {
+	ci.Parallel(t)
+
+	testDB(t, func(t *testing.T, db StateDB) {
+		require := require.New(t)
+
+		// Getting nonexistent state should return nils
+		ps, err := db.GetUserNamespacePoolState()
+		require.NoError(err)
+		require.Nil(ps)
+
+		// Putting PoolState should work
+		state := &userns.PoolState{
+			Allocations: map[string]*drivers.UserNamespace{
+				"alloc1": {HostUID: 100000, HostGID: 100000, Size: 65536},
+			},
+		}
+		require.NoError(db.PutUserNamespacePoolState(state))
+
+		// Getting should return the available state
+		ps, err = db.GetUserNamespacePoolState()
+		require.NoError(err)
+		require.Equal(state, ps)
+	})
+}
+
 func TestStateDB_CheckResult_keyForCheck(t *testing.T) {
 	ci.Parallel(t)
 
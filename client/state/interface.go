@@ -4,6 +4,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
 	dmstate "github.com/hashicorp/nomad/client/devicemanager/state"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
+	"github.com/hashicorp/nomad/client/lib/userns"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
 	"github.com/hashicorp/nomad/client/serviceregistration/checks"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -84,6 +85,14 @@ type StateDB interface {
 	// PutDynamicPluginRegistryState is used to store the dynamic plugin manager's state.
 	PutDynamicPluginRegistryState(state *dynamicplugins.RegistryState) error
 
+	// GetUserNamespacePoolState is used to retrieve the user namespace
+	// pool's state.
+	GetUserNamespacePoolState() (*userns.PoolState, error)
+
+	// PutUserNamespacePoolState is used to store the user namespace pool's
+	// state.
+	PutUserNamespacePoolState(state *userns.PoolState) error
+
 	// PutCheckResult sets the query result for the check implied in qr.
 	PutCheckResult(allocID string, qr *structs.CheckQueryResult) error
 
@@ -0,0 +1,152 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+)
+
+// Ensure ACLAuthMethodCommand satisfies the cli.Command interface.
+var _ cli.Command = &ACLAuthMethodCommand{}
+
+// ACLAuthMethodCommand implements cli.Command.
+type ACLAuthMethodCommand struct {
+	Meta
+}
+
+// Help satisfies the cli.Command Help function.
+func (a *ACLAuthMethodCommand) Help() string {
+	helpText := `
+Usage: nomad acl auth-method <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with ACL auth methods.
+  Auth methods allow operators and workloads to login to Nomad using an
+  external identity provider, such as an OIDC provider or JWT issuer. The
+  claims of the identity are mapped onto ACL roles and policies using ACL
+  binding rules. For a full guide see:
+  https://www.nomadproject.io/guides/acl.html
+
+  Create an ACL auth method:
+
+      $ nomad acl auth-method create -name="name" -type="OIDC" \
+          -max-token-ttl="1h" -token-locality="local" -config=config.json
+
+  List all ACL auth methods:
+
+      $ nomad acl auth-method list
+
+  Lookup a specific ACL auth method:
+
+      $ nomad acl auth-method info <acl_auth_method_name>
+
+  Update an ACL auth method:
+
+      $ nomad acl auth-method update -default=true <acl_auth_method_name>
+
+  Delete an ACL auth method:
+
+      $ nomad acl auth-method delete <acl_auth_method_name>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis satisfies the cli.Command Synopsis function.
+func (a *ACLAuthMethodCommand) Synopsis() string { return "Interact with ACL auth methods" }
+
+// Name returns the name of this command.
+func (a *ACLAuthMethodCommand) Name() string { return "acl auth-method" }
+
+// Run satisfies the cli.Command Run function.
+func (a *ACLAuthMethodCommand) Run(_ []string) int { return cli.RunResultHelp }
+
+// formatACLAuthMethod formats and converts the ACL auth method API object into
+// a string KV representation suitable for console output.
+func formatACLAuthMethod(authMethod *api.ACLAuthMethod) string {
+	out := formatKV([]string{
+		fmt.Sprintf("Name|%s", authMethod.Name),
+		fmt.Sprintf("Type|%s", authMethod.Type),
+		fmt.Sprintf("Locality|%s", authMethod.TokenLocality),
+		fmt.Sprintf("Max Token TTL|%s", authMethod.MaxTokenTTL.String()),
+		fmt.Sprintf("Default|%t", authMethod.Default),
+		fmt.Sprintf("Create Index|%d", authMethod.CreateIndex),
+		fmt.Sprintf("Modify Index|%d", authMethod.ModifyIndex),
+	})
+
+	if authMethod.Config != nil {
+		out += "\n\n" + formatKV(formatACLAuthMethodConfig(authMethod.Type, authMethod.Config))
+	}
+	return out
+}
+
+// formatACLAuthMethodConfig converts the ACL auth method config into a list
+// of KV entries suitable for use with formatKV. Only the entries relevant to
+// the auth method type are included.
+func formatACLAuthMethodConfig(methodType string, config *api.ACLAuthMethodConfig) []string {
+	var out []string
+
+	switch methodType {
+	case api.ACLAuthMethodTypeJWT:
+		out = []string{
+			fmt.Sprintf("JWKS URL|%s", config.JWKSURL),
+			fmt.Sprintf("JWT validation public keys|%d", len(config.JWTValidationPubKeys)),
+			fmt.Sprintf("Bound issuer|%s", config.BoundIssuer),
+			fmt.Sprintf("Clock skew leeway|%s", config.ClockSkewLeeway),
+		}
+	default:
+		out = []string{
+			fmt.Sprintf("OIDC Discovery URL|%s", config.OIDCDiscoveryURL),
+			fmt.Sprintf("OIDC Client ID|%s", config.OIDCClientID),
+		}
+	}
+
+	return append(out,
+		fmt.Sprintf("Bound audiences|%s", strings.Join(config.BoundAudiences, ",")),
+		fmt.Sprintf("Signing algorithms|%s", strings.Join(config.SigningAlgs, ",")),
+		fmt.Sprintf("Claim mappings|%s", formatACLAuthMethodClaimMappings(config.ClaimMappings)),
+		fmt.Sprintf("List claim mappings|%s", formatACLAuthMethodClaimMappings(config.ListClaimMappings)),
+	)
+}
+
+// formatACLAuthMethodClaimMappings converts a claim mapping into a sorted,
+// semicolon separated list of "claim=name" pairs.
+func formatACLAuthMethodClaimMappings(mappings map[string]string) string {
+	out := make([]string, 0, len(mappings))
+	for claim, name := range mappings {
+		out = append(out, fmt.Sprintf("%s=%s", claim, name))
+	}
+	sort.Strings(out)
+	return strings.Join(out, "; ")
+}
+
+// readACLAuthMethodConfig reads and decodes a JSON formatted ACL auth method
+// config from the passed file path. A path of "-" reads the config from
+// stdin.
+func readACLAuthMethodConfig(path string) (*api.ACLAuthMethodConfig, error) {
+	var (
+		raw []byte
+		err error
+	)
+
+	if path == "-" {
+		raw, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		raw, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %v", err)
+	}
+
+	var config api.ACLAuthMethodConfig
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+	return &config, nil
+}
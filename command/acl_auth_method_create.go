@@ -0,0 +1,192 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+// Ensure ACLAuthMethodCreateCommand satisfies the cli.Command interface.
+var _ cli.Command = &ACLAuthMethodCreateCommand{}
+
+// ACLAuthMethodCreateCommand implements cli.Command.
+type ACLAuthMethodCreateCommand struct {
+	Meta
+
+	name          string
+	methodType    string
+	tokenLocality string
+	maxTokenTTL   time.Duration
+	isDefault     bool
+	configPath    string
+	json          bool
+	tmpl          string
+}
+
+// Help satisfies the cli.Command Help function.
+func (a *ACLAuthMethodCreateCommand) Help() string {
+	helpText := `
+Usage: nomad acl auth-method create [options]
+
+  Create is used to create new ACL auth methods. Use requires a management
+  token.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+ACL Auth Method Create Options:
+
+  -name
+    Sets the human readable name for the ACL auth method. The name must be
+    between 1-128 characters and is a required parameter.
+
+  -type
+    Sets the type of the auth method. Supported types are "OIDC" and "JWT".
+
+  -max-token-ttl
+    Sets the duration for which a token created by this auth method will be
+    valid, such as "1h". This is a required parameter.
+
+  -token-locality
+    Defines the kind of token that this auth method should produce. This can
+    be either "local" or "global". This is a required parameter.
+
+  -default
+    Specifies whether this auth method should be treated as the default one
+    when logging in without specifying a method. Only one auth method can be
+    the default.
+
+  -config
+    The path to a JSON file containing the auth method configuration. A path
+    of "-" reads the configuration from stdin. This is a required parameter.
+
+  -json
+    Output the ACL auth method in a JSON format.
+
+  -t
+    Format and display the ACL auth method using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (a *ACLAuthMethodCreateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(a.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-name":           complete.PredictAnything,
+			"-type":           complete.PredictSet(api.ACLAuthMethodTypeOIDC, api.ACLAuthMethodTypeJWT),
+			"-max-token-ttl":  complete.PredictAnything,
+			"-token-locality": complete.PredictSet(api.ACLAuthMethodTokenLocalityLocal, api.ACLAuthMethodTokenLocalityGlobal),
+			"-default":        complete.PredictSet("true", "false"),
+			"-config":         complete.PredictFiles("*.json"),
+			"-json":           complete.PredictNothing,
+			"-t":              complete.PredictAnything,
+		})
+}
+
+func (a *ACLAuthMethodCreateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+// Synopsis satisfies the cli.Command Synopsis function.
+func (a *ACLAuthMethodCreateCommand) Synopsis() string { return "Create a new ACL auth method" }
+
+// Name returns the name of this command.
+func (a *ACLAuthMethodCreateCommand) Name() string { return "acl auth-method create" }
+
+// Run satisfies the cli.Command Run function.
+func (a *ACLAuthMethodCreateCommand) Run(args []string) int {
+
+	flags := a.Meta.FlagSet(a.Name(), FlagSetClient)
+	flags.Usage = func() { a.Ui.Output(a.Help()) }
+	flags.StringVar(&a.name, "name", "", "")
+	flags.StringVar(&a.methodType, "type", "", "")
+	flags.StringVar(&a.tokenLocality, "token-locality", "", "")
+	flags.DurationVar(&a.maxTokenTTL, "max-token-ttl", 0, "")
+	flags.BoolVar(&a.isDefault, "default", false, "")
+	flags.StringVar(&a.configPath, "config", "", "")
+	flags.BoolVar(&a.json, "json", false, "")
+	flags.StringVar(&a.tmpl, "t", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments.
+	if len(flags.Args()) != 0 {
+		a.Ui.Error("This command takes no arguments")
+		a.Ui.Error(commandErrorText(a))
+		return 1
+	}
+
+	// Perform some basic validation on the submitted auth method information
+	// to avoid sending API and RPC requests which will fail basic validation.
+	if a.name == "" {
+		a.Ui.Error("ACL auth method name must be specified using the -name flag")
+		return 1
+	}
+	if a.methodType != api.ACLAuthMethodTypeOIDC && a.methodType != api.ACLAuthMethodTypeJWT {
+		a.Ui.Error("ACL auth method type must be set to 'OIDC' or 'JWT'")
+		return 1
+	}
+	if a.tokenLocality != api.ACLAuthMethodTokenLocalityLocal &&
+		a.tokenLocality != api.ACLAuthMethodTokenLocalityGlobal {
+		a.Ui.Error("Token locality must be set to either 'local' or 'global'")
+		return 1
+	}
+	if a.maxTokenTTL < 1 {
+		a.Ui.Error("Max token TTL must be set to a value greater than zero")
+		return 1
+	}
+	if a.configPath == "" {
+		a.Ui.Error("ACL auth method config must be specified using the -config flag")
+		return 1
+	}
+
+	config, err := readACLAuthMethodConfig(a.configPath)
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error reading ACL auth method config: %s", err))
+		return 1
+	}
+
+	// Set up the auth method with the passed parameters.
+	authMethod := api.ACLAuthMethod{
+		Name:          a.name,
+		Type:          a.methodType,
+		TokenLocality: a.tokenLocality,
+		MaxTokenTTL:   a.maxTokenTTL,
+		Default:       a.isDefault,
+		Config:        config,
+	}
+
+	// Get the HTTP client.
+	client, err := a.Meta.Client()
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Create the auth method via the API.
+	method, _, err := client.ACLAuthMethods().Create(&authMethod, nil)
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error creating ACL auth method: %s", err))
+		return 1
+	}
+
+	if a.json || len(a.tmpl) > 0 {
+		out, err := Format(a.json, a.tmpl, method)
+		if err != nil {
+			a.Ui.Error(err.Error())
+			return 1
+		}
+
+		a.Ui.Output(out)
+		return 0
+	}
+
+	a.Ui.Output(formatACLAuthMethod(method))
+	return 0
+}
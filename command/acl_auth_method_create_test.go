@@ -0,0 +1,108 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestACLAuthMethodCreateCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer stopTestAgent(srv)
+
+	// Wait for the server to start fully and ensure we have a bootstrap token.
+	must.NotNil(t, srv.RootToken)
+
+	ui := cli.NewMockUi()
+	cmd := &ACLAuthMethodCreateCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+	}
+
+	// Test the basic validation on the command.
+	must.One(t, cmd.Run([]string{"-address=" + url, "this-command-does-not-take-args"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes no arguments")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	must.One(t, cmd.Run([]string{"-address=" + url}))
+	must.StrContains(t, ui.ErrorWriter.String(), "ACL auth method name must be specified using the -name flag")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	must.One(t, cmd.Run([]string{"-address=" + url, "-name=acl-auth-method-cli-test", "-type=LDAP"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "ACL auth method type must be set to 'OIDC' or 'JWT'")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	must.One(t, cmd.Run([]string{
+		"-address=" + url, "-name=acl-auth-method-cli-test", "-type=OIDC",
+		"-token-locality=local", "-max-token-ttl=1h"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "ACL auth method config must be specified using the -config flag")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Write an auth method config file.
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	must.NoError(t, os.WriteFile(configFile, []byte(`{
+  "OIDCDiscoveryURL": "https://example.com",
+  "OIDCClientID": "nomad",
+  "ClaimMappings": {"team": "team"}
+}`), 0644))
+
+	// Create an ACL auth method.
+	args := []string{
+		"-address=" + url, "-token=" + srv.RootToken.SecretID, "-name=acl-auth-method-cli-test",
+		"-type=OIDC", "-token-locality=global", "-max-token-ttl=10m", "-default=true",
+		"-config=" + configFile,
+	}
+	must.Zero(t, cmd.Run(args))
+	s := ui.OutputWriter.String()
+	must.StrContains(t, s, "acl-auth-method-cli-test")
+	must.StrContains(t, s, "global")
+	must.StrContains(t, s, "10m0s")
+	must.StrContains(t, s, "https://example.com")
+	must.StrContains(t, s, "team=team")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Create a JWT auth method which fetches keys from a JWKS endpoint.
+	jwtConfigFile := filepath.Join(t.TempDir(), "jwt-config.json")
+	must.NoError(t, os.WriteFile(jwtConfigFile, []byte(`{
+  "JWKSURL": "https://ci.example.com/keys",
+  "BoundIssuer": "https://ci.example.com",
+  "ClaimMappings": {"project": "project"}
+}`), 0644))
+
+	args = []string{
+		"-address=" + url, "-token=" + srv.RootToken.SecretID, "-name=acl-auth-method-cli-jwt",
+		"-type=JWT", "-token-locality=local", "-max-token-ttl=10m",
+		"-config=" + jwtConfigFile,
+	}
+	must.Zero(t, cmd.Run(args))
+	s = ui.OutputWriter.String()
+	must.StrContains(t, s, "acl-auth-method-cli-jwt")
+	must.StrContains(t, s, "https://ci.example.com/keys")
+	must.StrContains(t, s, "project=project")
+	must.StrNotContains(t, s, "OIDC Discovery URL")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+}
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestACLAuthMethodDeleteCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer stopTestAgent(srv)
+
+	// Wait for the server to start fully and ensure we have a bootstrap token.
+	must.NotNil(t, srv.RootToken)
+
+	ui := cli.NewMockUi()
+	cmd := &ACLAuthMethodDeleteCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+	}
+
+	// Try and delete more than one auth method.
+	must.One(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, "one", "two"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes one argument")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Create an ACL auth method directly within state.
+	authMethod := mock.ACLAuthMethod()
+	err := srv.Agent.Server().State().UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod})
+	must.NoError(t, err)
+
+	// Delete the auth method without a token, which should fail.
+	must.One(t, cmd.Run([]string{"-address=" + url, authMethod.Name}))
+	must.StrContains(t, ui.ErrorWriter.String(), "Permission denied")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Delete the auth method using the management token.
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, authMethod.Name}))
+	must.StrContains(t, ui.OutputWriter.String(), "successfully deleted")
+
+	out, err := srv.Agent.Server().State().GetACLAuthMethodByName(nil, authMethod.Name)
+	must.NoError(t, err)
+	must.Nil(t, out)
+}
@@ -0,0 +1,66 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestACLAuthMethodInfoCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer stopTestAgent(srv)
+
+	// Wait for the server to start fully and ensure we have a bootstrap token.
+	must.NotNil(t, srv.RootToken)
+
+	ui := cli.NewMockUi()
+	cmd := &ACLAuthMethodInfoCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+	}
+
+	// Perform a lookup without specifying an auth method name.
+	must.One(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID}))
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes one argument")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Perform a lookup of an auth method that does not exist.
+	must.One(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, "does-not-exist"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "ACL auth-method not found")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Create an ACL auth method directly within state.
+	authMethod := mock.ACLAuthMethod()
+	err := srv.Agent.Server().State().UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod})
+	must.NoError(t, err)
+
+	// Look up the auth method.
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, authMethod.Name}))
+	s := ui.OutputWriter.String()
+	must.StrContains(t, s, authMethod.Name)
+	must.StrContains(t, s, authMethod.Config.OIDCDiscoveryURL)
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Look up the auth method using JSON output.
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, "-json", authMethod.Name}))
+	must.StrContains(t, ui.OutputWriter.String(), `"OIDCClientID": "mock"`)
+}
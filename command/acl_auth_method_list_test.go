@@ -0,0 +1,62 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestACLAuthMethodListCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer stopTestAgent(srv)
+
+	// Wait for the server to start fully and ensure we have a bootstrap token.
+	must.NotNil(t, srv.RootToken)
+
+	ui := cli.NewMockUi()
+	cmd := &ACLAuthMethodListCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+	}
+
+	// Perform a list straight away without any auth methods held in state.
+	// Listing auth methods does not require a token.
+	must.Zero(t, cmd.Run([]string{"-address=" + url}))
+	must.StrContains(t, ui.OutputWriter.String(), "No ACL auth methods found")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Create an ACL auth method directly within state.
+	authMethod := mock.ACLAuthMethod()
+	authMethod.Default = true
+	err := srv.Agent.Server().State().UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod})
+	must.NoError(t, err)
+
+	// Perform a listing to get the created auth method.
+	must.Zero(t, cmd.Run([]string{"-address=" + url}))
+	s := ui.OutputWriter.String()
+	must.StrContains(t, s, authMethod.Name)
+	must.StrContains(t, s, "OIDC")
+	must.StrContains(t, s, "true")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Perform a listing using JSON output.
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-json"}))
+	must.StrContains(t, ui.OutputWriter.String(), `"Name": "`+authMethod.Name+`"`)
+}
@@ -0,0 +1,196 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+// Ensure ACLAuthMethodUpdateCommand satisfies the cli.Command interface.
+var _ cli.Command = &ACLAuthMethodUpdateCommand{}
+
+// ACLAuthMethodUpdateCommand implements cli.Command.
+type ACLAuthMethodUpdateCommand struct {
+	Meta
+
+	methodType    string
+	tokenLocality string
+	maxTokenTTL   time.Duration
+	isDefault     bool
+	configPath    string
+	json          bool
+	tmpl          string
+}
+
+// Help satisfies the cli.Command Help function.
+func (a *ACLAuthMethodUpdateCommand) Help() string {
+	helpText := `
+Usage: nomad acl auth-method update [options] <acl_auth_method_name>
+
+  Update is used to update an existing ACL auth method. Only the fields
+  specified via flags are modified. Use requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+ACL Auth Method Update Options:
+
+  -type
+    Updates the type of the auth method. Supported types are "OIDC" and
+    "JWT".
+
+  -max-token-ttl
+    Updates the duration for which a token created by this auth method will
+    be valid, such as "1h".
+
+  -token-locality
+    Updates the kind of token that this auth method should produce. This can
+    be either "local" or "global".
+
+  -default
+    Specifies whether this auth method should be treated as the default one
+    when logging in without specifying a method. Only one auth method can be
+    the default.
+
+  -config
+    The path to a JSON file containing the auth method configuration, which
+    replaces the existing configuration. A path of "-" reads the
+    configuration from stdin.
+
+  -json
+    Output the ACL auth method in a JSON format.
+
+  -t
+    Format and display the ACL auth method using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (a *ACLAuthMethodUpdateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(a.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-type":           complete.PredictSet(api.ACLAuthMethodTypeOIDC, api.ACLAuthMethodTypeJWT),
+			"-max-token-ttl":  complete.PredictAnything,
+			"-token-locality": complete.PredictSet(api.ACLAuthMethodTokenLocalityLocal, api.ACLAuthMethodTokenLocalityGlobal),
+			"-default":        complete.PredictSet("true", "false"),
+			"-config":         complete.PredictFiles("*.json"),
+			"-json":           complete.PredictNothing,
+			"-t":              complete.PredictAnything,
+		})
+}
+
+func (a *ACLAuthMethodUpdateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+// Synopsis satisfies the cli.Command Synopsis function.
+func (a *ACLAuthMethodUpdateCommand) Synopsis() string { return "Update an existing ACL auth method" }
+
+// Name returns the name of this command.
+func (*ACLAuthMethodUpdateCommand) Name() string { return "acl auth-method update" }
+
+// Run satisfies the cli.Command Run function.
+func (a *ACLAuthMethodUpdateCommand) Run(args []string) int {
+
+	flags := a.Meta.FlagSet(a.Name(), FlagSetClient)
+	flags.Usage = func() { a.Ui.Output(a.Help()) }
+	flags.StringVar(&a.methodType, "type", "", "")
+	flags.StringVar(&a.tokenLocality, "token-locality", "", "")
+	flags.DurationVar(&a.maxTokenTTL, "max-token-ttl", 0, "")
+	flags.BoolVar(&a.isDefault, "default", false, "")
+	flags.StringVar(&a.configPath, "config", "", "")
+	flags.BoolVar(&a.json, "json", false, "")
+	flags.StringVar(&a.tmpl, "t", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument which is expected to be the ACL
+	// auth method name.
+	if len(flags.Args()) != 1 {
+		a.Ui.Error("This command takes one argument: <acl_auth_method_name>")
+		a.Ui.Error(commandErrorText(a))
+		return 1
+	}
+
+	// Track which flags were explicitly set, so that boolean flags can be
+	// updated to false.
+	setFlags := make(map[string]struct{})
+	flags.Visit(func(f *flag.Flag) { setFlags[f.Name] = struct{}{} })
+
+	_, defaultSet := setFlags["default"]
+
+	// Check that the operator specified at least one flag to update the ACL
+	// auth method with.
+	if a.methodType == "" && a.tokenLocality == "" && a.maxTokenTTL == 0 &&
+		a.configPath == "" && !defaultSet {
+		a.Ui.Error("Please provide at least one flag to update the ACL auth method")
+		a.Ui.Error(commandErrorText(a))
+		return 1
+	}
+
+	// Get the HTTP client.
+	client, err := a.Meta.Client()
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	methodName := flags.Args()[0]
+
+	// Read the current auth method, so we can merge the updates and fail
+	// better if it is not found.
+	updatedMethod, _, err := client.ACLAuthMethods().Get(methodName, nil)
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error when retrieving ACL auth method: %v", err))
+		return 1
+	}
+
+	if a.methodType != "" {
+		updatedMethod.Type = a.methodType
+	}
+	if a.tokenLocality != "" {
+		updatedMethod.TokenLocality = a.tokenLocality
+	}
+	if a.maxTokenTTL != 0 {
+		updatedMethod.MaxTokenTTL = a.maxTokenTTL
+	}
+	if defaultSet {
+		updatedMethod.Default = a.isDefault
+	}
+	if a.configPath != "" {
+		config, err := readACLAuthMethodConfig(a.configPath)
+		if err != nil {
+			a.Ui.Error(fmt.Sprintf("Error reading ACL auth method config: %s", err))
+			return 1
+		}
+		updatedMethod.Config = config
+	}
+
+	// Update the ACL auth method with the new information via the API.
+	method, _, err := client.ACLAuthMethods().Update(updatedMethod, nil)
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error updating ACL auth method: %s", err))
+		return 1
+	}
+
+	if a.json || len(a.tmpl) > 0 {
+		out, err := Format(a.json, a.tmpl, method)
+		if err != nil {
+			a.Ui.Error(err.Error())
+			return 1
+		}
+
+		a.Ui.Output(out)
+		return 0
+	}
+
+	a.Ui.Output(formatACLAuthMethod(method))
+	return 0
+}
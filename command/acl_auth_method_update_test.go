@@ -0,0 +1,79 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestACLAuthMethodUpdateCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer stopTestAgent(srv)
+
+	// Wait for the server to start fully and ensure we have a bootstrap token.
+	must.NotNil(t, srv.RootToken)
+
+	ui := cli.NewMockUi()
+	cmd := &ACLAuthMethodUpdateCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+	}
+
+	// Try calling the command without setting an auth method name.
+	must.One(t, cmd.Run([]string{"-address=" + url}))
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes one argument")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Try calling the command without any update flags.
+	must.One(t, cmd.Run([]string{"-address=" + url, "acl-auth-method-cli-test"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "Please provide at least one flag to update the ACL auth method")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Try updating an auth method that does not exist.
+	must.One(t, cmd.Run([]string{
+		"-address=" + url, "-token=" + srv.RootToken.SecretID, "-default=false", "acl-auth-method-cli-test"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "ACL auth-method not found")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Create an ACL auth method directly within state and mark it as the
+	// default.
+	authMethod := mock.ACLAuthMethod()
+	authMethod.Default = true
+	err := srv.Agent.Server().State().UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod})
+	must.NoError(t, err)
+
+	// Update the auth method, ensuring the default flag can be unset.
+	must.Zero(t, cmd.Run([]string{
+		"-address=" + url, "-token=" + srv.RootToken.SecretID,
+		"-default=false", "-max-token-ttl=30m", authMethod.Name}))
+	s := ui.OutputWriter.String()
+	must.StrContains(t, s, authMethod.Name)
+	must.StrContains(t, s, "30m0s")
+
+	updated, err := srv.Agent.Server().State().GetACLAuthMethodByName(nil, authMethod.Name)
+	must.NoError(t, err)
+	must.False(t, updated.Default)
+	must.Eq(t, authMethod.Config.OIDCDiscoveryURL, updated.Config.OIDCDiscoveryURL)
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+}
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+)
+
+// Ensure ACLBindingRuleCommand satisfies the cli.Command interface.
+var _ cli.Command = &ACLBindingRuleCommand{}
+
+// ACLBindingRuleCommand implements cli.Command.
+type ACLBindingRuleCommand struct {
+	Meta
+}
+
+// Help satisfies the cli.Command Help function.
+func (a *ACLBindingRuleCommand) Help() string {
+	helpText := `
+Usage: nomad acl binding-rule <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with ACL binding rules.
+  Binding rules belong to an ACL auth method and control which ACL roles and
+  policies are granted to the tokens created when logging in using the auth
+  method. For a full guide see: https://www.nomadproject.io/guides/acl.html
+
+  Create an ACL binding rule:
+
+      $ nomad acl binding-rule create -auth-method="auth0" \
+          -selector="engineering in list.roles" -bind-type="role" \
+          -bind-name="engineering"
+
+  List all ACL binding rules:
+
+      $ nomad acl binding-rule list
+
+  Lookup a specific ACL binding rule:
+
+      $ nomad acl binding-rule info <acl_binding_rule_id>
+
+  Update an ACL binding rule:
+
+      $ nomad acl binding-rule update -description="new-description" \
+          <acl_binding_rule_id>
+
+  Delete an ACL binding rule:
+
+      $ nomad acl binding-rule delete <acl_binding_rule_id>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis satisfies the cli.Command Synopsis function.
+func (a *ACLBindingRuleCommand) Synopsis() string { return "Interact with ACL binding rules" }
+
+// Name returns the name of this command.
+func (a *ACLBindingRuleCommand) Name() string { return "acl binding-rule" }
+
+// Run satisfies the cli.Command Run function.
+func (a *ACLBindingRuleCommand) Run(_ []string) int { return cli.RunResultHelp }
+
+// formatACLBindingRule formats and converts the ACL binding rule API object
+// into a string KV representation suitable for console output.
+func formatACLBindingRule(bindingRule *api.ACLBindingRule) string {
+	return formatKV([]string{
+		fmt.Sprintf("ID|%s", bindingRule.ID),
+		fmt.Sprintf("Description|%s", bindingRule.Description),
+		fmt.Sprintf("Auth Method|%s", bindingRule.AuthMethod),
+		fmt.Sprintf("Selector|%q", bindingRule.Selector),
+		fmt.Sprintf("Bind Type|%s", bindingRule.BindType),
+		fmt.Sprintf("Bind Name|%s", bindingRule.BindName),
+		fmt.Sprintf("Create Index|%d", bindingRule.CreateIndex),
+		fmt.Sprintf("Modify Index|%d", bindingRule.ModifyIndex),
+	})
+}
+
+// validateACLBindingRuleBindType performs basic validation of the bind type
+// and name passed by the operator, to avoid sending API and RPC requests
+// which will fail basic validation.
+func validateACLBindingRuleBindType(bindType, bindName string) error {
+	switch bindType {
+	case api.ACLBindingRuleBindTypeRole, api.ACLBindingRuleBindTypePolicy:
+		if bindName == "" {
+			return fmt.Errorf("Bind name must be specified when using the %q bind type", bindType)
+		}
+	case api.ACLBindingRuleBindTypeManagement:
+		if bindName != "" {
+			return fmt.Errorf("Bind name must not be specified when using the %q bind type", bindType)
+		}
+	default:
+		return fmt.Errorf("Bind type must be one of %q, %q, or %q",
+			api.ACLBindingRuleBindTypeRole, api.ACLBindingRuleBindTypePolicy,
+			api.ACLBindingRuleBindTypeManagement)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestACLBindingRuleCreateCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer stopTestAgent(srv)
+
+	// Wait for the server to start fully and ensure we have a bootstrap token.
+	must.NotNil(t, srv.RootToken)
+
+	ui := cli.NewMockUi()
+	cmd := &ACLBindingRuleCreateCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+	}
+
+	// Test the basic validation on the command.
+	must.One(t, cmd.Run([]string{"-address=" + url, "this-command-does-not-take-args"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes no arguments")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	must.One(t, cmd.Run([]string{"-address=" + url}))
+	must.StrContains(t, ui.ErrorWriter.String(), "ACL binding rule auth method must be specified using the -auth-method flag")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	must.One(t, cmd.Run([]string{"-address=" + url, "-auth-method=auth0", "-bind-type=role"}))
+	must.StrContains(t, ui.ErrorWriter.String(), `Bind name must be specified when using the "role" bind type`)
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Create the ACL auth method the binding rule will reference.
+	authMethod := mock.ACLAuthMethod()
+	err := srv.Agent.Server().State().UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod})
+	must.NoError(t, err)
+
+	// Create an ACL binding rule.
+	args := []string{
+		"-address=" + url, "-token=" + srv.RootToken.SecretID, "-auth-method=" + authMethod.Name,
+		"-bind-type=role", "-bind-name=engineering", "-selector=engineering in list.groups",
+		"-description=acl-binding-rule-cli-test",
+	}
+	must.Zero(t, cmd.Run(args))
+	s := ui.OutputWriter.String()
+	must.StrContains(t, s, authMethod.Name)
+	must.StrContains(t, s, "engineering in list.groups")
+	must.StrContains(t, s, "acl-binding-rule-cli-test")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+}
@@ -0,0 +1,54 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestACLBindingRuleDeleteCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer stopTestAgent(srv)
+
+	// Wait for the server to start fully and ensure we have a bootstrap token.
+	must.NotNil(t, srv.RootToken)
+
+	ui := cli.NewMockUi()
+	cmd := &ACLBindingRuleDeleteCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+	}
+
+	// Try and delete more than one binding rule.
+	must.One(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, "one", "two"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes one argument")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Create an ACL binding rule directly within state.
+	bindingRule := mock.ACLBindingRule()
+	err := srv.Agent.Server().State().UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLBindingRule{bindingRule}, true)
+	must.NoError(t, err)
+
+	// Delete the binding rule using the management token.
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, bindingRule.ID}))
+	must.StrContains(t, ui.OutputWriter.String(), "successfully deleted")
+
+	out, err := srv.Agent.Server().State().GetACLBindingRule(nil, bindingRule.ID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+}
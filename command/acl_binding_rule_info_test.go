@@ -0,0 +1,61 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestACLBindingRuleInfoCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer stopTestAgent(srv)
+
+	// Wait for the server to start fully and ensure we have a bootstrap token.
+	must.NotNil(t, srv.RootToken)
+
+	ui := cli.NewMockUi()
+	cmd := &ACLBindingRuleInfoCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+	}
+
+	// Perform a lookup without specifying a binding rule ID.
+	must.One(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID}))
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes one argument")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Create an ACL binding rule directly within state. The auth method does
+	// not need to exist for the purposes of this lookup.
+	bindingRule := mock.ACLBindingRule()
+	err := srv.Agent.Server().State().UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLBindingRule{bindingRule}, true)
+	must.NoError(t, err)
+
+	// Look up the binding rule.
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, bindingRule.ID}))
+	s := ui.OutputWriter.String()
+	must.StrContains(t, s, bindingRule.ID)
+	must.StrContains(t, s, bindingRule.AuthMethod)
+	must.StrContains(t, s, bindingRule.BindName)
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Look up the binding rule using JSON output.
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, "-json", bindingRule.ID}))
+	must.StrContains(t, ui.OutputWriter.String(), `"ID": "`+bindingRule.ID+`"`)
+}
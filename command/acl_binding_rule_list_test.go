@@ -0,0 +1,62 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestACLBindingRuleListCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer stopTestAgent(srv)
+
+	// Wait for the server to start fully and ensure we have a bootstrap token.
+	must.NotNil(t, srv.RootToken)
+
+	ui := cli.NewMockUi()
+	cmd := &ACLBindingRuleListCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+	}
+
+	// Perform a list straight away without any binding rules held in state.
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID}))
+	must.StrContains(t, ui.OutputWriter.String(), "No ACL binding rules found")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Create an ACL binding rule directly within state. The auth method does
+	// not need to exist for the purposes of this listing.
+	bindingRule := mock.ACLBindingRule()
+	bindingRule.Description = "acl-binding-rule-cli-test"
+	err := srv.Agent.Server().State().UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLBindingRule{bindingRule}, true)
+	must.NoError(t, err)
+
+	// Perform a listing to get the created binding rule.
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID}))
+	s := ui.OutputWriter.String()
+	must.StrContains(t, s, bindingRule.ID)
+	must.StrContains(t, s, "acl-binding-rule-cli-test")
+	must.StrContains(t, s, bindingRule.AuthMethod)
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Perform a listing using JSON output.
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, "-json"}))
+	must.StrContains(t, ui.OutputWriter.String(), `"ID": "`+bindingRule.ID+`"`)
+}
@@ -0,0 +1,196 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+// Ensure ACLBindingRuleUpdateCommand satisfies the cli.Command interface.
+var _ cli.Command = &ACLBindingRuleUpdateCommand{}
+
+// ACLBindingRuleUpdateCommand implements cli.Command.
+type ACLBindingRuleUpdateCommand struct {
+	Meta
+
+	description string
+	selector    string
+	bindType    string
+	bindName    string
+	json        bool
+	tmpl        string
+}
+
+// Help satisfies the cli.Command Help function.
+func (a *ACLBindingRuleUpdateCommand) Help() string {
+	helpText := `
+Usage: nomad acl binding-rule update [options] <acl_binding_rule_id>
+
+  Update is used to update an existing ACL binding rule. Only the fields
+  specified via flags are modified. The auth method of a binding rule cannot
+  be changed. Use requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+ACL Binding Rule Update Options:
+
+  -description
+    A free form text description of the binding rule that must not exceed 256
+    characters.
+
+  -selector
+    An expression that matches against the identity attributes returned from
+    the auth method during login. Setting an empty selector makes the rule
+    match all logins.
+
+  -bind-type
+    Specifies how the binding rule affects the token created at login. This
+    can be one of "role", "policy", or "management".
+
+  -bind-name
+    The name of the ACL role or policy to bind to the token. This can be
+    templated using the mapped claims, such as "${value.team}-admins".
+
+  -json
+    Output the ACL binding rule in a JSON format.
+
+  -t
+    Format and display the ACL binding rule using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (a *ACLBindingRuleUpdateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(a.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-description": complete.PredictAnything,
+			"-selector":    complete.PredictAnything,
+			"-bind-type": complete.PredictSet(
+				api.ACLBindingRuleBindTypeRole,
+				api.ACLBindingRuleBindTypePolicy,
+				api.ACLBindingRuleBindTypeManagement,
+			),
+			"-bind-name": complete.PredictAnything,
+			"-json":      complete.PredictNothing,
+			"-t":         complete.PredictAnything,
+		})
+}
+
+func (a *ACLBindingRuleUpdateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+// Synopsis satisfies the cli.Command Synopsis function.
+func (a *ACLBindingRuleUpdateCommand) Synopsis() string {
+	return "Update an existing ACL binding rule"
+}
+
+// Name returns the name of this command.
+func (*ACLBindingRuleUpdateCommand) Name() string { return "acl binding-rule update" }
+
+// Run satisfies the cli.Command Run function.
+func (a *ACLBindingRuleUpdateCommand) Run(args []string) int {
+
+	flags := a.Meta.FlagSet(a.Name(), FlagSetClient)
+	flags.Usage = func() { a.Ui.Output(a.Help()) }
+	flags.StringVar(&a.description, "description", "", "")
+	flags.StringVar(&a.selector, "selector", "", "")
+	flags.StringVar(&a.bindType, "bind-type", "", "")
+	flags.StringVar(&a.bindName, "bind-name", "", "")
+	flags.BoolVar(&a.json, "json", false, "")
+	flags.StringVar(&a.tmpl, "t", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument which is expected to be the ACL
+	// binding rule ID.
+	if len(flags.Args()) != 1 {
+		a.Ui.Error("This command takes one argument: <acl_binding_rule_id>")
+		a.Ui.Error(commandErrorText(a))
+		return 1
+	}
+
+	// Track which flags were explicitly set, so that the selector can be
+	// updated to an empty string.
+	setFlags := make(map[string]struct{})
+	flags.Visit(func(f *flag.Flag) { setFlags[f.Name] = struct{}{} })
+
+	_, selectorSet := setFlags["selector"]
+
+	// Check that the operator specified at least one flag to update the ACL
+	// binding rule with.
+	if a.description == "" && a.bindType == "" && a.bindName == "" && !selectorSet {
+		a.Ui.Error("Please provide at least one flag to update the ACL binding rule")
+		a.Ui.Error(commandErrorText(a))
+		return 1
+	}
+
+	// Get the HTTP client.
+	client, err := a.Meta.Client()
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	bindingRuleID := flags.Args()[0]
+
+	// Read the current binding rule, so we can merge the updates and fail
+	// better if it is not found.
+	updatedRule, _, err := client.ACLBindingRules().Get(bindingRuleID, nil)
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error when retrieving ACL binding rule: %v", err))
+		return 1
+	}
+
+	if a.description != "" {
+		updatedRule.Description = a.description
+	}
+	if selectorSet {
+		updatedRule.Selector = a.selector
+	}
+	if a.bindType != "" {
+		updatedRule.BindType = a.bindType
+
+		// The management bind type does not use a bind name, so ensure any
+		// previous name is cleared.
+		if a.bindType == api.ACLBindingRuleBindTypeManagement {
+			updatedRule.BindName = ""
+		}
+	}
+	if a.bindName != "" {
+		updatedRule.BindName = a.bindName
+	}
+
+	if err := validateACLBindingRuleBindType(updatedRule.BindType, updatedRule.BindName); err != nil {
+		a.Ui.Error(err.Error())
+		return 1
+	}
+
+	// Update the ACL binding rule with the new information via the API.
+	bindingRule, _, err := client.ACLBindingRules().Update(updatedRule, nil)
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error updating ACL binding rule: %s", err))
+		return 1
+	}
+
+	if a.json || len(a.tmpl) > 0 {
+		out, err := Format(a.json, a.tmpl, bindingRule)
+		if err != nil {
+			a.Ui.Error(err.Error())
+			return 1
+		}
+
+		a.Ui.Output(out)
+		return 0
+	}
+
+	a.Ui.Output(formatACLBindingRule(bindingRule))
+	return 0
+}
@@ -0,0 +1,86 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestACLBindingRuleUpdateCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer stopTestAgent(srv)
+
+	// Wait for the server to start fully and ensure we have a bootstrap token.
+	must.NotNil(t, srv.RootToken)
+
+	ui := cli.NewMockUi()
+	cmd := &ACLBindingRuleUpdateCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+	}
+
+	// Try calling the command without setting a binding rule ID.
+	must.One(t, cmd.Run([]string{"-address=" + url}))
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes one argument")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Create an ACL binding rule, along with its auth method, directly within
+	// state.
+	authMethod := mock.ACLAuthMethod()
+	err := srv.Agent.Server().State().UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod})
+	must.NoError(t, err)
+
+	bindingRule := mock.ACLBindingRule()
+	bindingRule.AuthMethod = authMethod.Name
+	err = srv.Agent.Server().State().UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLBindingRule{bindingRule}, false)
+	must.NoError(t, err)
+
+	// Try calling the command without any update flags.
+	must.One(t, cmd.Run([]string{"-address=" + url, bindingRule.ID}))
+	must.StrContains(t, ui.ErrorWriter.String(), "Please provide at least one flag to update the ACL binding rule")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Update the binding rule to a management rule, which should clear the
+	// existing bind name.
+	must.Zero(t, cmd.Run([]string{
+		"-address=" + url, "-token=" + srv.RootToken.SecretID,
+		"-bind-type=management", "-description=updated", bindingRule.ID}))
+	s := ui.OutputWriter.String()
+	must.StrContains(t, s, bindingRule.ID)
+	must.StrContains(t, s, "management")
+	must.StrContains(t, s, "updated")
+
+	updated, err := srv.Agent.Server().State().GetACLBindingRule(nil, bindingRule.ID)
+	must.NoError(t, err)
+	must.Eq(t, "", updated.BindName)
+	must.Eq(t, bindingRule.Selector, updated.Selector)
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Update the selector to an empty string, making it a catch-all rule.
+	must.Zero(t, cmd.Run([]string{
+		"-address=" + url, "-token=" + srv.RootToken.SecretID, "-selector=", bindingRule.ID}))
+
+	updated, err = srv.Agent.Server().State().GetACLBindingRule(nil, bindingRule.ID)
+	must.NoError(t, err)
+	must.Eq(t, "", updated.Selector)
+}
@@ -160,6 +160,20 @@ func formatACLPolicy(policy *api.ACLPolicy) string {
 }
 
 // formatKVACLToken returns a K/V formatted ACL token
+// formatACLTokenRoleLinks returns the names of the roles linked to the token,
+// falling back to the ID where a name is not available.
+func formatACLTokenRoleLinks(links []*api.ACLTokenRoleLink) []string {
+	out := make([]string, 0, len(links))
+	for _, link := range links {
+		if link.Name != "" {
+			out = append(out, link.Name)
+		} else {
+			out = append(out, link.ID)
+		}
+	}
+	return out
+}
+
 func formatKVACLToken(token *api.ACLToken) string {
 	// Add the fixed preamble
 	output := []string{
@@ -170,16 +184,27 @@ func formatKVACLToken(token *api.ACLToken) string {
 		fmt.Sprintf("Global|%v", token.Global),
 	}
 
-	// Special case the policy output
+	// Special case the policy and role output
 	if token.Type == "management" {
-		output = append(output, "Policies|n/a")
+		output = append(output, "Policies|n/a", "Roles|n/a")
 	} else {
-		output = append(output, fmt.Sprintf("Policies|%v", token.Policies))
+		output = append(output,
+			fmt.Sprintf("Policies|%v", token.Policies),
+			fmt.Sprintf("Roles|%v", formatACLTokenRoleLinks(token.Roles)),
+		)
+	}
+
+	// Only display the expiry time when the token has one set, otherwise the
+	// column is rendered empty.
+	var expiryTime string
+	if token.ExpirationTime != nil && !token.ExpirationTime.IsZero() {
+		expiryTime = token.ExpirationTime.String()
 	}
 
 	// Add the generic output
 	output = append(output,
 		fmt.Sprintf("Create Time|%v", token.CreateTime),
+		fmt.Sprintf("Expiry Time|%s", expiryTime),
 		fmt.Sprintf("Create Index|%d", token.CreateIndex),
 		fmt.Sprintf("Modify Index|%d", token.ModifyIndex),
 	)
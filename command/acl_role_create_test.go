@@ -0,0 +1,77 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestACLRoleCreateCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer stopTestAgent(srv)
+
+	// Wait for the server to start fully and ensure we have a bootstrap token.
+	must.NotNil(t, srv.RootToken)
+
+	ui := cli.NewMockUi()
+	cmd := &ACLRoleCreateCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+	}
+
+	// Test the basic validation on the command.
+	must.One(t, cmd.Run([]string{"-address=" + url, "this-command-does-not-take-args"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes no arguments")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	must.One(t, cmd.Run([]string{"-address=" + url}))
+	must.StrContains(t, ui.ErrorWriter.String(), "ACL role name must be specified using the -name flag")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	must.One(t, cmd.Run([]string{"-address=" + url, `-name="foobar"`}))
+	must.StrContains(t, ui.ErrorWriter.String(), "At least one policy name must be specified using the -policy flag")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Create an ACL policy that can be referenced within the ACL role.
+	aclPolicy := structs.ACLPolicy{
+		Name: "acl-role-cli-test-policy",
+		Rules: `namespace "default" {
+			policy = "read"
+		}
+		`,
+	}
+	err := srv.Agent.Server().State().UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLPolicy{&aclPolicy})
+	must.NoError(t, err)
+
+	// Create an ACL role.
+	args := []string{
+		"-address=" + url, "-token=" + srv.RootToken.SecretID, "-name=acl-role-cli-test",
+		"-policy=acl-role-cli-test-policy", "-description=acl-role-all-the-things",
+	}
+	must.Zero(t, cmd.Run(args))
+	s := ui.OutputWriter.String()
+	must.StrContains(t, s, "acl-role-cli-test")
+	must.StrContains(t, s, "acl-role-all-the-things")
+	must.StrContains(t, s, "acl-role-cli-test-policy")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+}
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestACLRoleDeleteCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer stopTestAgent(srv)
+
+	// Wait for the server to start fully and ensure we have a bootstrap token.
+	must.NotNil(t, srv.RootToken)
+
+	ui := cli.NewMockUi()
+	cmd := &ACLRoleDeleteCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+	}
+
+	// Try and delete more than one ACL role.
+	must.One(t, cmd.Run([]string{"-address=" + url, "acl-role-1", "acl-role-2"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes one argument")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Try deleting a role that does not exist.
+	must.One(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, "acl-role-1"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "ACL role not found")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Create an ACL role directly within state.
+	aclRole := structs.ACLRole{
+		ID:       "e3b0c442-98fc-1c14-9afb-f4c8996fb924",
+		Name:     "acl-role-cli-test",
+		Policies: []*structs.ACLRolePolicyLink{{Name: "acl-role-policy-cli-test"}},
+	}
+	err := srv.Agent.Server().State().UpsertACLRoles(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLRole{&aclRole}, true)
+	must.NoError(t, err)
+
+	// Delete the existing ACL role.
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, aclRole.ID}))
+	must.StrContains(t, ui.OutputWriter.String(), fmt.Sprintf("ACL role %s successfully deleted", aclRole.ID))
+}
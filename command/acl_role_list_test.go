@@ -0,0 +1,64 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestACLRoleListCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer stopTestAgent(srv)
+
+	// Wait for the server to start fully and ensure we have a bootstrap token.
+	must.NotNil(t, srv.RootToken)
+
+	ui := cli.NewMockUi()
+	cmd := &ACLRoleListCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+	}
+
+	// Perform a list straight away without any roles held in state.
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID}))
+	must.StrContains(t, ui.OutputWriter.String(), "No ACL roles found")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Create an ACL role directly within state. The policy does not need to
+	// exist for the purposes of this listing.
+	aclRole := structs.ACLRole{
+		ID:       "a7ca4e8c-0d3b-4d1a-b0a4-3cbac2ebd8f5",
+		Name:     "acl-role-cli-test",
+		Policies: []*structs.ACLRolePolicyLink{{Name: "acl-role-policy-cli-test"}},
+	}
+	err := srv.Agent.Server().State().UpsertACLRoles(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLRole{&aclRole}, true)
+	must.NoError(t, err)
+
+	// Perform a listing to get the created role.
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID}))
+	s := ui.OutputWriter.String()
+	must.StrContains(t, s, aclRole.ID)
+	must.StrContains(t, s, "acl-role-cli-test")
+	must.StrContains(t, s, "acl-role-policy-cli-test")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Perform a listing using JSON output.
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, "-json"}))
+	must.StrContains(t, ui.OutputWriter.String(), `"Name": "acl-role-cli-test"`)
+}
@@ -0,0 +1,97 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestACLRoleUpdateCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer stopTestAgent(srv)
+
+	// Wait for the server to start fully and ensure we have a bootstrap token.
+	must.NotNil(t, srv.RootToken)
+
+	ui := cli.NewMockUi()
+	cmd := &ACLRoleUpdateCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+	}
+
+	// Try calling the command without setting an ACL Role ID arg.
+	must.One(t, cmd.Run([]string{"-address=" + url}))
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes one argument")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Try calling the command with an ACL role ID that does not exist.
+	must.One(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, "catch-me-if-you-can"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "ACL role not found")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Create the ACL policies and role used within this test.
+	aclPolicies := []*structs.ACLPolicy{
+		{Name: "acl-role-cli-test-policy-1", Rules: `namespace "default" { policy = "read" }`},
+		{Name: "acl-role-cli-test-policy-2", Rules: `node { policy = "read" }`},
+	}
+	err := srv.Agent.Server().State().UpsertACLPolicies(structs.MsgTypeTestSetup, 10, aclPolicies)
+	must.NoError(t, err)
+
+	aclRole := structs.ACLRole{
+		ID:       "9ab2b81e-a5a1-5b5e-8a7f-2b8e4a9d6c31",
+		Name:     "acl-role-cli-test",
+		Policies: []*structs.ACLRolePolicyLink{{Name: "acl-role-cli-test-policy-1"}},
+	}
+	err = srv.Agent.Server().State().UpsertACLRoles(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLRole{&aclRole}, false)
+	must.NoError(t, err)
+
+	// Try a merge update without setting any parameters to update.
+	must.One(t, cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, aclRole.ID}))
+	must.StrContains(t, ui.ErrorWriter.String(), "Please provide at least one flag to update the ACL role")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Update the description using the merge method.
+	must.Zero(t, cmd.Run([]string{
+		"-address=" + url, "-token=" + srv.RootToken.SecretID, "-description=badger-badger-badger", aclRole.ID}))
+	s := ui.OutputWriter.String()
+	must.StrContains(t, s, "badger-badger-badger")
+	must.StrContains(t, s, "acl-role-cli-test-policy-1")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Add a policy link using the merge method.
+	must.Zero(t, cmd.Run([]string{
+		"-address=" + url, "-token=" + srv.RootToken.SecretID, "-policy=acl-role-cli-test-policy-2", aclRole.ID}))
+	must.StrContains(t, ui.OutputWriter.String(), "acl-role-cli-test-policy-1,acl-role-cli-test-policy-2")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Perform an update using the no-merge method, which replaces all fields.
+	must.Zero(t, cmd.Run([]string{
+		"-address=" + url, "-token=" + srv.RootToken.SecretID, "-no-merge", "-name=acl-role-cli-test-renamed",
+		"-policy=acl-role-cli-test-policy-2", aclRole.ID}))
+	s = ui.OutputWriter.String()
+	must.StrContains(t, s, "acl-role-cli-test-renamed")
+	must.StrContains(t, s, "<none>")
+	must.StrContains(t, s, "acl-role-cli-test-policy-2")
+}
@@ -37,4 +37,19 @@ func TestACLTokenCreateCommand(t *testing.T) {
 	// Check the output
 	out := ui.OutputWriter.String()
 	must.StrContains(t, out, "[foo]")
+	must.StrContains(t, out, "Expiry Time  = <none>")
+
+	ui.OutputWriter.Reset()
+
+	// Create a new token that has a TTL set and check the correct
+	// expiration time is displayed.
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-policy=foo", "-type=client", "-ttl=10m"})
+	must.Zero(t, code)
+
+	out = ui.OutputWriter.String()
+	must.StrNotContains(t, out, "Expiry Time  = <none>")
+
+	// An invalid TTL should be rejected before any request is made.
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-policy=foo", "-type=client", "-ttl=foo"})
+	must.One(t, code)
 }
@@ -4,8 +4,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/lib/auth/oidc"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/stretchr/testify/assert"
@@ -558,3 +560,359 @@ func TestHTTP_OneTimeToken(t *testing.T) {
 		require.EqualError(t, err, structs.ErrPermissionDenied.Error())
 	})
 }
+
+func TestHTTPServer_ACLRoles(t *testing.T) {
+	ci.Parallel(t)
+	httpACLTest(t, nil, func(srv *TestAgent) {
+
+		// Create the policies our ACL role wants to link to.
+		policy1 := mock.ACLPolicy()
+		policy1.Name = "mocked-test-policy-1"
+		policy2 := mock.ACLPolicy()
+		policy2.Name = "mocked-test-policy-2"
+
+		policyArgs := structs.ACLPolicyUpsertRequest{
+			Policies: []*structs.ACLPolicy{policy1, policy2},
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: srv.RootToken.SecretID,
+			},
+		}
+		var policyResp structs.GenericResponse
+		require.NoError(t, srv.Agent.RPC("ACL.UpsertPolicies", &policyArgs, &policyResp))
+
+		// Create an ACL role via the HTTP API.
+		mockACLRole := mock.ACLRole()
+		mockACLRole.ID = ""
+
+		req, err := http.NewRequest(http.MethodPut, "/v1/acl/role", encodeReq(mockACLRole))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		obj, err := srv.Server.ACLRoleRequest(respW, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, respW.Result().Header.Get("X-Nomad-Index"))
+
+		createdRole := obj.(*structs.ACLRole)
+		require.NotEmpty(t, createdRole.ID)
+		require.Equal(t, mockACLRole.Name, createdRole.Name)
+
+		// List the ACL roles.
+		req, err = http.NewRequest(http.MethodGet, "/v1/acl/roles", nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		obj, err = srv.Server.ACLRoleListRequest(respW, req)
+		require.NoError(t, err)
+		require.Len(t, obj.([]*structs.ACLRoleListStub), 1)
+
+		// Read the ACL role using its ID.
+		req, err = http.NewRequest(http.MethodGet, "/v1/acl/role/"+createdRole.ID, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		obj, err = srv.Server.ACLRoleSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, createdRole.ID, obj.(*structs.ACLRole).ID)
+
+		// Read the ACL role using its name.
+		req, err = http.NewRequest(http.MethodGet, "/v1/acl/role/name/"+createdRole.Name, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		obj, err = srv.Server.ACLRoleSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, createdRole.ID, obj.(*structs.ACLRole).ID)
+
+		// Updating the role using a mismatched ID should fail.
+		updatedRole := createdRole.Copy()
+		updatedRole.Description = "updated description"
+
+		req, err = http.NewRequest(http.MethodPost, "/v1/acl/role/not-the-id", encodeReq(updatedRole))
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		_, err = srv.Server.ACLRoleSpecificRequest(respW, req)
+		require.ErrorContains(t, err, "does not match request path")
+
+		// Update the role using the correct path.
+		req, err = http.NewRequest(http.MethodPost, "/v1/acl/role/"+createdRole.ID, encodeReq(updatedRole))
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		obj, err = srv.Server.ACLRoleSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, "updated description", obj.(*structs.ACLRole).Description)
+
+		// Delete the ACL role and ensure it can no longer be read.
+		req, err = http.NewRequest(http.MethodDelete, "/v1/acl/role/"+createdRole.ID, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		obj, err = srv.Server.ACLRoleSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Nil(t, obj)
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/acl/role/"+createdRole.ID, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		_, err = srv.Server.ACLRoleSpecificRequest(respW, req)
+		require.ErrorContains(t, err, "ACL role not found")
+
+		// Requests using an invalid URI should be rejected.
+		req, err = http.NewRequest(http.MethodGet, "/v1/acl/role/foo/bar", nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		_, err = srv.Server.ACLRoleSpecificRequest(respW, req)
+		require.ErrorContains(t, err, "invalid URI")
+	})
+}
+
+func TestHTTPServer_ACLAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+	httpACLTest(t, nil, func(srv *TestAgent) {
+
+		// Create an ACL auth-method via the HTTP API.
+		mockAuthMethod := mock.ACLAuthMethod()
+
+		req, err := http.NewRequest(http.MethodPut, "/v1/acl/auth-method", encodeReq(mockAuthMethod))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		obj, err := srv.Server.ACLAuthMethodRequest(respW, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, respW.Result().Header.Get("X-Nomad-Index"))
+
+		createdMethod := obj.(*structs.ACLAuthMethod)
+		require.Equal(t, mockAuthMethod.Name, createdMethod.Name)
+
+		// List the ACL auth-methods without a token, which is allowed.
+		req, err = http.NewRequest(http.MethodGet, "/v1/acl/auth-methods", nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		obj, err = srv.Server.ACLAuthMethodListRequest(respW, req)
+		require.NoError(t, err)
+		require.Len(t, obj.([]*structs.ACLAuthMethodStub), 1)
+
+		// Read the ACL auth-method using its name.
+		req, err = http.NewRequest(http.MethodGet, "/v1/acl/auth-method/"+createdMethod.Name, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		obj, err = srv.Server.ACLAuthMethodSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, createdMethod.Hash, obj.(*structs.ACLAuthMethod).Hash)
+
+		// Updating the auth-method using a mismatched name should fail.
+		updatedMethod := createdMethod.Copy()
+		updatedMethod.MaxTokenTTL = 2 * time.Hour
+
+		req, err = http.NewRequest(http.MethodPost, "/v1/acl/auth-method/not-the-name", encodeReq(updatedMethod))
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		_, err = srv.Server.ACLAuthMethodSpecificRequest(respW, req)
+		require.ErrorContains(t, err, "does not match request path")
+
+		// Update the auth-method using the correct path.
+		req, err = http.NewRequest(http.MethodPost, "/v1/acl/auth-method/"+createdMethod.Name, encodeReq(updatedMethod))
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		obj, err = srv.Server.ACLAuthMethodSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, 2*time.Hour, obj.(*structs.ACLAuthMethod).MaxTokenTTL)
+
+		// Delete the ACL auth-method and ensure it can no longer be read.
+		req, err = http.NewRequest(http.MethodDelete, "/v1/acl/auth-method/"+createdMethod.Name, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		obj, err = srv.Server.ACLAuthMethodSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Nil(t, obj)
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/acl/auth-method/"+createdMethod.Name, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		_, err = srv.Server.ACLAuthMethodSpecificRequest(respW, req)
+		require.ErrorContains(t, err, "ACL auth-method not found")
+	})
+}
+
+func TestHTTPServer_ACLBindingRules(t *testing.T) {
+	ci.Parallel(t)
+	httpACLTest(t, nil, func(srv *TestAgent) {
+
+		// Create the auth-method our binding rule is linked to.
+		mockAuthMethod := mock.ACLAuthMethod()
+		authMethodArgs := structs.ACLAuthMethodsUpsertRequest{
+			AuthMethods: []*structs.ACLAuthMethod{mockAuthMethod},
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: srv.RootToken.SecretID,
+			},
+		}
+		var authMethodResp structs.ACLAuthMethodsUpsertResponse
+		require.NoError(t, srv.Agent.RPC(structs.ACLUpsertAuthMethodsRPCMethod, &authMethodArgs, &authMethodResp))
+
+		// Create an ACL binding rule via the HTTP API.
+		mockBindingRule := mock.ACLBindingRule()
+		mockBindingRule.ID = ""
+		mockBindingRule.AuthMethod = mockAuthMethod.Name
+
+		req, err := http.NewRequest(http.MethodPut, "/v1/acl/binding-rule", encodeReq(mockBindingRule))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		obj, err := srv.Server.ACLBindingRuleRequest(respW, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, respW.Result().Header.Get("X-Nomad-Index"))
+
+		createdRule := obj.(*structs.ACLBindingRule)
+		require.NotEmpty(t, createdRule.ID)
+
+		// List the ACL binding rules.
+		req, err = http.NewRequest(http.MethodGet, "/v1/acl/binding-rules", nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		obj, err = srv.Server.ACLBindingRuleListRequest(respW, req)
+		require.NoError(t, err)
+		require.Len(t, obj.([]*structs.ACLBindingRuleListStub), 1)
+
+		// Read the ACL binding rule using its ID.
+		req, err = http.NewRequest(http.MethodGet, "/v1/acl/binding-rule/"+createdRule.ID, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		obj, err = srv.Server.ACLBindingRuleSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, createdRule.ID, obj.(*structs.ACLBindingRule).ID)
+
+		// Updating the binding rule using a mismatched ID should fail.
+		updatedRule := createdRule.Copy()
+		updatedRule.Description = "updated description"
+
+		req, err = http.NewRequest(http.MethodPost, "/v1/acl/binding-rule/not-the-id", encodeReq(updatedRule))
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		_, err = srv.Server.ACLBindingRuleSpecificRequest(respW, req)
+		require.ErrorContains(t, err, "does not match request path")
+
+		// Update the binding rule using the correct path.
+		req, err = http.NewRequest(http.MethodPost, "/v1/acl/binding-rule/"+createdRule.ID, encodeReq(updatedRule))
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		obj, err = srv.Server.ACLBindingRuleSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, "updated description", obj.(*structs.ACLBindingRule).Description)
+
+		// Delete the ACL binding rule and ensure it can no longer be read.
+		req, err = http.NewRequest(http.MethodDelete, "/v1/acl/binding-rule/"+createdRule.ID, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		obj, err = srv.Server.ACLBindingRuleSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Nil(t, obj)
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/acl/binding-rule/"+createdRule.ID, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, srv.RootToken)
+
+		_, err = srv.Server.ACLBindingRuleSpecificRequest(respW, req)
+		require.ErrorContains(t, err, "ACL binding rule not found")
+	})
+}
+
+func TestHTTPServer_ACLLogin(t *testing.T) {
+	ci.Parallel(t)
+	httpACLTest(t, nil, func(srv *TestAgent) {
+
+		// Create an auth-method backed by a test OIDC provider, along with a
+		// binding rule which grants management privileges.
+		oidcProvider := oidc.NewTestProvider(t)
+
+		mockAuthMethod := mock.ACLAuthMethod()
+		mockAuthMethod.Config = &structs.ACLAuthMethodConfig{
+			OIDCDiscoveryURL: oidcProvider.URL(),
+			OIDCClientID:     "nomad",
+		}
+		authMethodArgs := structs.ACLAuthMethodsUpsertRequest{
+			AuthMethods: []*structs.ACLAuthMethod{mockAuthMethod},
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: srv.RootToken.SecretID,
+			},
+		}
+		var authMethodResp structs.ACLAuthMethodsUpsertResponse
+		require.NoError(t, srv.Agent.RPC(structs.ACLUpsertAuthMethodsRPCMethod, &authMethodArgs, &authMethodResp))
+
+		bindingRuleArgs := structs.ACLBindingRulesUpsertRequest{
+			ACLBindingRules: []*structs.ACLBindingRule{{
+				AuthMethod: mockAuthMethod.Name,
+				BindType:   structs.ACLBindingRuleBindTypeManagement,
+			}},
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: srv.RootToken.SecretID,
+			},
+		}
+		var bindingRuleResp structs.ACLBindingRulesUpsertResponse
+		require.NoError(t, srv.Agent.RPC(structs.ACLUpsertBindingRulesRPCMethod, &bindingRuleArgs, &bindingRuleResp))
+
+		// Only PUT and POST are supported.
+		req, err := http.NewRequest(http.MethodGet, "/v1/acl/login", nil)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		_, err = srv.Server.ACLLoginRequest(respW, req)
+		require.ErrorContains(t, err, ErrInvalidMethod)
+
+		// Perform the login.
+		loginArgs := structs.ACLLoginRequest{
+			AuthMethodName: mockAuthMethod.Name,
+			LoginToken:     oidcProvider.SignIDToken(t, map[string]interface{}{"aud": "nomad"}),
+		}
+		req, err = http.NewRequest(http.MethodPost, "/v1/acl/login", encodeReq(loginArgs))
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		obj, err := srv.Server.ACLLoginRequest(respW, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, respW.Result().Header.Get("X-Nomad-Index"))
+
+		token := obj.(*structs.ACLToken)
+		require.Equal(t, structs.ACLManagementToken, token.Type)
+		require.NotEmpty(t, token.SecretID)
+	})
+}
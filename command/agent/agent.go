@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	golog "log"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -21,6 +22,7 @@ import (
 	"github.com/hashicorp/nomad/client"
 	clientconfig "github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/lib/cgutil"
+	"github.com/hashicorp/nomad/client/lib/userns"
 	"github.com/hashicorp/nomad/client/state"
 	"github.com/hashicorp/nomad/command/agent/consul"
 	"github.com/hashicorp/nomad/command/agent/event"
@@ -241,6 +243,12 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 	if agentConfig.ACL.ReplicationToken != "" {
 		conf.ReplicationToken = agentConfig.ACL.ReplicationToken
 	}
+	if agentConfig.ACL.TokenMinExpirationTTL != 0 {
+		conf.ACLTokenMinExpirationTTL = agentConfig.ACL.TokenMinExpirationTTL
+	}
+	if agentConfig.ACL.TokenMaxExpirationTTL != 0 {
+		conf.ACLTokenMaxExpirationTTL = agentConfig.ACL.TokenMaxExpirationTTL
+	}
 	if agentConfig.Sentinel != nil {
 		conf.SentinelConfig = agentConfig.Sentinel
 	}
@@ -377,6 +385,63 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		}
 		conf.CSIPluginGCThreshold = dur
 	}
+	if gcThreshold := agentConfig.Server.ACLTokenGCThreshold; gcThreshold != "" {
+		dur, err := time.ParseDuration(gcThreshold)
+		if err != nil {
+			return nil, err
+		}
+		conf.ACLTokenExpirationGCThreshold = dur
+	}
+	if gcInterval := agentConfig.Server.RootKeyGCInterval; gcInterval != "" {
+		dur, err := time.ParseDuration(gcInterval)
+		if err != nil {
+			return nil, err
+		}
+		conf.RootKeyGCInterval = dur
+	}
+	if gcThreshold := agentConfig.Server.RootKeyGCThreshold; gcThreshold != "" {
+		dur, err := time.ParseDuration(gcThreshold)
+		if err != nil {
+			return nil, err
+		}
+		conf.RootKeyGCThreshold = dur
+	}
+	if rotationThreshold := agentConfig.Server.RootKeyRotationThreshold; rotationThreshold != "" {
+		dur, err := time.ParseDuration(rotationThreshold)
+		if err != nil {
+			return nil, err
+		}
+		conf.RootKeyRotationInterval = dur
+	}
+	if issuer := agentConfig.Server.OIDCIssuer; issuer != "" {
+		u, err := url.Parse(issuer)
+		if err != nil {
+			return nil, fmt.Errorf("oidc_issuer: %v", err)
+		}
+		if u.Scheme != "https" && u.Scheme != "http" || u.Host == "" {
+			return nil, fmt.Errorf("oidc_issuer must be an absolute http or https URL")
+		}
+		conf.OIDCIssuer = issuer
+	}
+	if audience := agentConfig.Server.OIDCAudience; len(audience) != 0 {
+		conf.OIDCAudience = audience
+	}
+	if keyring := agentConfig.Server.Keyring; keyring != nil {
+		if keyring.RotationInterval != "" {
+			dur, err := time.ParseDuration(keyring.RotationInterval)
+			if err != nil {
+				return nil, fmt.Errorf("keyring.rotation_interval: %v", err)
+			}
+			if dur < 0 {
+				return nil, fmt.Errorf("keyring.rotation_interval must not be negative")
+			}
+			conf.RootKeyRotationInterval = dur
+		}
+		if keyring.Rekey != nil {
+			conf.RootKeyRekeyOnRotation = *keyring.Rekey
+		}
+		conf.KeyringKEK = keyring.KEK.Copy()
+	}
 
 	if heartbeatGrace := agentConfig.Server.HeartbeatGrace; heartbeatGrace != 0 {
 		conf.HeartbeatGrace = heartbeatGrace
@@ -608,6 +673,8 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	if agentConfig.Client.MemoryMB != 0 {
 		conf.MemoryMB = agentConfig.Client.MemoryMB
 	}
+	conf.CpuOversubscriptionRatio = agentConfig.Client.CpuOversubscriptionRatio
+	conf.MemoryOversubscriptionRatio = agentConfig.Client.MemoryOversubscriptionRatio
 	if agentConfig.Client.MaxKillTimeout != "" {
 		dur, err := time.ParseDuration(agentConfig.Client.MaxKillTimeout)
 		if err != nil {
@@ -637,6 +704,7 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.Node.Name = agentConfig.NodeName
 	conf.Node.Meta = agentConfig.Client.Meta
 	conf.Node.NodeClass = agentConfig.Client.NodeClass
+	conf.Node.NodePool = agentConfig.Client.NodePool
 
 	// Set up the HTTP advertise address
 	conf.Node.HTTPAddr = agentConfig.AdvertiseAddrs.HTTP
@@ -728,6 +796,14 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 		conf.ReservableCores = cores.ToSlice()
 	}
 
+	if agentConfig.Client.UserNamespaceSubUIDs != "" || agentConfig.Client.UserNamespaceSubGIDs != "" {
+		usernsConfig, err := convertUserNamespaceConfig(agentConfig.Client)
+		if err != nil {
+			return nil, err
+		}
+		conf.UserNamespace = usernsConfig
+	}
+
 	if agentConfig.Client.NomadServiceDiscovery != nil {
 		conf.NomadServiceDiscovery = *agentConfig.Client.NomadServiceDiscovery
 	}
@@ -741,6 +817,33 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	return conf, nil
 }
 
+// convertUserNamespaceConfig parses the user namespace pool of the client.
+func convertUserNamespaceConfig(c *ClientConfig) (*userns.PoolConfig, error) {
+	if c.UserNamespaceSubUIDs == "" || c.UserNamespaceSubGIDs == "" {
+		return nil, fmt.Errorf("'user_namespace_subuids' and 'user_namespace_subgids' must be set together")
+	}
+	uids, err := userns.ParseRange(c.UserNamespaceSubUIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse 'user_namespace_subuids': %v", err)
+	}
+	gids, err := userns.ParseRange(c.UserNamespaceSubGIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse 'user_namespace_subgids': %v", err)
+	}
+	config := &userns.PoolConfig{
+		UIDs: uids,
+		GIDs: gids,
+		Size: userns.DefaultSize,
+	}
+	if c.UserNamespaceSize != 0 {
+		config.Size = uint32(c.UserNamespaceSize)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid user namespace configuration: %v", err)
+	}
+	return config, nil
+}
+
 // setupServer is used to setup the server if enabled
 func (a *Agent) setupServer() error {
 	if !a.config.Server.Enabled {
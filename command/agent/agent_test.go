@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/userns"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/testlog"
@@ -444,6 +445,131 @@ func TestAgent_ServerConfig_PlanRejectionTracker(t *testing.T) {
 	}
 }
 
+func TestAgent_ServerConfig_Keyring(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name             string
+		legacyThreshold  string
+		keyringConfig    *KeyringConfig
+		expectedInterval time.Duration
+		expectedRekey    bool
+		expectedErr      string
+	}{
+		{
+			name:             "default",
+			expectedInterval: 720 * time.Hour,
+			expectedRekey:    true,
+		},
+		{
+			name: "valid config",
+			keyringConfig: &KeyringConfig{
+				RotationInterval: "24h",
+				Rekey:            pointer.Of(false),
+				KEK: &config.KEKConfig{
+					Provider: config.KEKProviderAEAD,
+					KeyFile:  "/etc/nomad/kek",
+				},
+			},
+			expectedInterval: 24 * time.Hour,
+			expectedRekey:    false,
+		},
+		{
+			name: "disabled",
+			keyringConfig: &KeyringConfig{
+				RotationInterval: "0",
+			},
+			expectedInterval: 0,
+			expectedRekey:    true,
+		},
+		{
+			name:             "legacy threshold",
+			legacyThreshold:  "48h",
+			expectedInterval: 48 * time.Hour,
+			expectedRekey:    true,
+		},
+		{
+			name:            "keyring overrides legacy threshold",
+			legacyThreshold: "48h",
+			keyringConfig: &KeyringConfig{
+				RotationInterval: "12h",
+			},
+			expectedInterval: 12 * time.Hour,
+			expectedRekey:    true,
+		},
+		{
+			name: "invalid interval",
+			keyringConfig: &KeyringConfig{
+				RotationInterval: "never",
+			},
+			expectedErr: "keyring.rotation_interval",
+		},
+		{
+			name: "negative interval",
+			keyringConfig: &KeyringConfig{
+				RotationInterval: "-1h",
+			},
+			expectedErr: "keyring.rotation_interval must not be negative",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := DevConfig(nil)
+			must.NoError(t, config.normalizeAddrs())
+
+			config.Server.RootKeyRotationThreshold = tc.legacyThreshold
+			config.Server.Keyring = tc.keyringConfig
+
+			serverConfig, err := convertServerConfig(config)
+			if tc.expectedErr != "" {
+				must.Error(t, err)
+				must.StrContains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.expectedInterval, serverConfig.RootKeyRotationInterval)
+			must.Eq(t, tc.expectedRekey, serverConfig.RootKeyRekeyOnRotation)
+			if tc.keyringConfig != nil {
+				must.Eq(t, tc.keyringConfig.KEK, serverConfig.KeyringKEK)
+			}
+		})
+	}
+}
+
+func TestAgent_ServerConfig_OIDCIssuer(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		issuer      string
+		expectedErr string
+	}{
+		{issuer: ""},
+		{issuer: "https://nomad.example.com"},
+		{issuer: "http://127.0.0.1:4646/"},
+		{issuer: "nomad.example.com", expectedErr: "must be an absolute http or https URL"},
+		{issuer: "ftp://nomad.example.com", expectedErr: "must be an absolute http or https URL"},
+		{issuer: "https://%zz", expectedErr: "oidc_issuer: "},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.issuer, func(t *testing.T) {
+			config := DevConfig(nil)
+			must.NoError(t, config.normalizeAddrs())
+			config.Server.OIDCIssuer = tc.issuer
+
+			serverConfig, err := convertServerConfig(config)
+			if tc.expectedErr != "" {
+				must.Error(t, err)
+				must.StrContains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.issuer, serverConfig.OIDCIssuer)
+		})
+	}
+}
+
 func TestAgent_ServerConfig_RaftMultiplier_Ok(t *testing.T) {
 	ci.Parallel(t)
 
@@ -641,6 +767,36 @@ func TestAgent_ClientConfig_ReservedCores(t *testing.T) {
 	require.Exactly(t, []uint16{0, 2, 3}, c.Node.ReservedResources.Cpu.ReservedCpuCores)
 }
 
+func TestAgent_ClientConfig_UserNamespace(t *testing.T) {
+	ci.Parallel(t)
+	conf := DefaultConfig()
+	conf.Client.Enabled = true
+	a := &Agent{config: conf}
+
+	c, err := a.clientConfig()
+	require.NoError(t, err)
+	require.Nil(t, c.UserNamespace)
+
+	conf.Client.UserNamespaceSubUIDs = "100000:655360"
+	conf.Client.UserNamespaceSubGIDs = "200000:131072"
+	c, err = a.clientConfig()
+	require.NoError(t, err)
+	require.Equal(t, &userns.PoolConfig{
+		UIDs: userns.Range{Start: 100000, Count: 655360},
+		GIDs: userns.Range{Start: 200000, Count: 131072},
+		Size: userns.DefaultSize,
+	}, c.UserNamespace)
+
+	conf.Client.UserNamespaceSize = 262144
+	_, err = a.clientConfig()
+	require.ErrorContains(t, err, "must hold at least 262144 IDs")
+
+	conf.Client.UserNamespaceSize = 0
+	conf.Client.UserNamespaceSubGIDs = ""
+	_, err = a.clientConfig()
+	require.ErrorContains(t, err, "must be set together")
+}
+
 // Clients should inherit telemetry configuration
 func TestAgent_Client_TelemetryConfiguration(t *testing.T) {
 	ci.Parallel(t)
@@ -385,6 +385,15 @@ func (c *Command) IsValidConfig(config, cmdConfig *Config) bool {
 		return false
 	}
 
+	if r := config.Client.CpuOversubscriptionRatio; r != 0 && r < 1 {
+		c.Ui.Error(fmt.Sprintf("Invalid cpu_oversubscription_ratio=%v: must be at least 1", r))
+		return false
+	}
+	if r := config.Client.MemoryOversubscriptionRatio; r != 0 && r < 1 {
+		c.Ui.Error(fmt.Sprintf("Invalid memory_oversubscription_ratio=%v: must be at least 1", r))
+		return false
+	}
+
 	if config.Client.Reserved == nil {
 		// Coding error; should always be set by DefaultConfig()
 		c.Ui.Error("client.reserved must be initialized. Please report a bug.")
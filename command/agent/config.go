@@ -200,6 +200,10 @@ type ClientConfig struct {
 	// NodeClass is used to group the node by class
 	NodeClass string `hcl:"node_class"`
 
+	// NodePool is the node pool the node belongs to. Nodes which do not set
+	// a node pool are placed in the default node pool.
+	NodePool string `hcl:"node_pool"`
+
 	// Options is used for configuration of nomad internals,
 	// like fingerprinters and drivers. The format is:
 	//
@@ -226,9 +230,27 @@ type ClientConfig struct {
 	// MemoryMB is used to override any detected or default total memory.
 	MemoryMB int `hcl:"memory_total_mb"`
 
+	// CpuOversubscriptionRatio is the factor by which the CPU of the node may
+	// be oversubscribed by the scheduler.
+	CpuOversubscriptionRatio float64 `hcl:"cpu_oversubscription_ratio"`
+
+	// MemoryOversubscriptionRatio is the factor by which the memory of the
+	// node may be oversubscribed by the scheduler.
+	MemoryOversubscriptionRatio float64 `hcl:"memory_oversubscription_ratio"`
+
 	// ReservableCores is used to override detected reservable cpu cores.
 	ReserveableCores string `hcl:"reservable_cores"`
 
+	// UserNamespaceSubUIDs and UserNamespaceSubGIDs are the ranges of host
+	// user and group IDs, in the "start:count" format of /etc/subuid, that
+	// tasks are remapped to when running in user namespaces.
+	UserNamespaceSubUIDs string `hcl:"user_namespace_subuids"`
+	UserNamespaceSubGIDs string `hcl:"user_namespace_subgids"`
+
+	// UserNamespaceSize is the number of IDs mapped into the user namespace
+	// of each allocation.
+	UserNamespaceSize int `hcl:"user_namespace_size"`
+
 	// MaxKillTimeout allows capping the user-specifiable KillTimeout.
 	MaxKillTimeout string `hcl:"max_kill_timeout"`
 
@@ -375,6 +397,18 @@ type ACLConfig struct {
 	PolicyTTL    time.Duration
 	PolicyTTLHCL string `hcl:"policy_ttl" json:"-"`
 
+	// TokenMinExpirationTTL is used to enforce the lowest acceptable value
+	// for ACL token expiration. This is used by the Nomad servers to validate
+	// ACL tokens with an expiration value set upon creation.
+	TokenMinExpirationTTL    time.Duration
+	TokenMinExpirationTTLHCL string `hcl:"token_min_expiration_ttl" json:"-"`
+
+	// TokenMaxExpirationTTL is used to enforce the highest acceptable value
+	// for ACL token expiration. This is used by the Nomad servers to validate
+	// ACL tokens with an expiration value set upon creation.
+	TokenMaxExpirationTTL    time.Duration
+	TokenMaxExpirationTTLHCL string `hcl:"token_max_expiration_ttl" json:"-"`
+
 	// ReplicationToken is used by servers to replicate tokens and policies
 	// from the authoritative region. This must be a valid management token
 	// within the authoritative region.
@@ -466,6 +500,10 @@ type ServerConfig struct {
 	// GCed but the threshold can be used to filter by age.
 	CSIPluginGCThreshold string `hcl:"csi_plugin_gc_threshold"`
 
+	// ACLTokenGCThreshold controls how "old" an expired ACL token must be to
+	// be collected by GC.
+	ACLTokenGCThreshold string `hcl:"acl_token_gc_threshold"`
+
 	// RootKeyGCInterval is how often we dispatch a job to GC
 	// encryption key metadata
 	RootKeyGCInterval string `hcl:"root_key_gc_interval"`
@@ -475,10 +513,22 @@ type ServerConfig struct {
 	RootKeyGCThreshold string `hcl:"root_key_gc_threshold"`
 
 	// RootKeyRotationThreshold is how "old" an encryption key must be
-	// before it is automatically rotated on the next garbage
-	// collection interval.
+	// before it is automatically rotated.
+	//
+	// Deprecated: use Keyring.RotationInterval instead.
 	RootKeyRotationThreshold string `hcl:"root_key_rotation_threshold"`
 
+	// OIDCIssuer is the issuer of workload identities, which must be the
+	// URL at which third parties can retrieve the OIDC discovery document.
+	OIDCIssuer string `hcl:"oidc_issuer"`
+
+	// OIDCAudience is the audience (aud claim) of workload identities.
+	OIDCAudience []string `hcl:"oidc_audience"`
+
+	// Keyring configures the automatic rotation of the root keys used to
+	// encrypt secure variables and sign workload identities.
+	Keyring *KeyringConfig `hcl:"keyring"`
+
 	// HeartbeatGrace is the grace period beyond the TTL to account for network,
 	// processing delays and clock skew before marking a node as "down".
 	HeartbeatGrace    time.Duration
@@ -603,6 +653,7 @@ func (s *ServerConfig) Copy() *ServerConfig {
 	ns.ServerJoin = s.ServerJoin.Copy()
 	ns.DefaultSchedulerConfig = s.DefaultSchedulerConfig.Copy()
 	ns.PlanRejectionTracker = s.PlanRejectionTracker.Copy()
+	ns.Keyring = s.Keyring.Copy()
 	ns.EnableEventBroker = pointer.Copy(s.EnableEventBroker)
 	ns.EventBufferSize = pointer.Copy(s.EventBufferSize)
 	ns.licenseAdditionalPublicKeys = slices.Clone(s.licenseAdditionalPublicKeys)
@@ -690,6 +741,60 @@ func (p *PlanRejectionTracker) Merge(b *PlanRejectionTracker) *PlanRejectionTrac
 	return &result
 }
 
+// KeyringConfig is used in servers to configure the keyring.
+type KeyringConfig struct {
+	// RotationInterval is how "old" the active root key can be before the
+	// leader rotates it. A value of "0" disables automatic rotation.
+	RotationInterval string `hcl:"rotation_interval"`
+
+	// Rekey controls whether secure variables encrypted with the previous
+	// root key are re-encrypted with the new key after an automatic
+	// rotation.
+	Rekey *bool `hcl:"rekey"`
+
+	// KEK configures the key encryption key provider used to wrap root keys
+	// before they are written to the on-disk keystore.
+	KEK *config.KEKConfig `hcl:"kek"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+func (k *KeyringConfig) Copy() *KeyringConfig {
+	if k == nil {
+		return nil
+	}
+
+	nk := *k
+	nk.Rekey = pointer.Copy(k.Rekey)
+	nk.KEK = k.KEK.Copy()
+	nk.ExtraKeysHCL = slices.Clone(k.ExtraKeysHCL)
+	return &nk
+}
+
+func (k *KeyringConfig) Merge(b *KeyringConfig) *KeyringConfig {
+	if k == nil {
+		return b
+	}
+
+	result := *k
+
+	if b == nil {
+		return &result
+	}
+
+	if b.RotationInterval != "" {
+		result.RotationInterval = b.RotationInterval
+	}
+	if b.Rekey != nil {
+		result.Rekey = b.Rekey
+	}
+	if b.KEK != nil {
+		result.KEK = result.KEK.Merge(b.KEK)
+	}
+	return &result
+}
+
 // Search is used in servers to configure search API options.
 type Search struct {
 	// FuzzyEnabled toggles whether the FuzzySearch API is enabled. If not
@@ -1738,6 +1843,18 @@ func (a *ACLConfig) Merge(b *ACLConfig) *ACLConfig {
 	if b.PolicyTTLHCL != "" {
 		result.PolicyTTLHCL = b.PolicyTTLHCL
 	}
+	if b.TokenMinExpirationTTL != 0 {
+		result.TokenMinExpirationTTL = b.TokenMinExpirationTTL
+	}
+	if b.TokenMinExpirationTTLHCL != "" {
+		result.TokenMinExpirationTTLHCL = b.TokenMinExpirationTTLHCL
+	}
+	if b.TokenMaxExpirationTTL != 0 {
+		result.TokenMaxExpirationTTL = b.TokenMaxExpirationTTL
+	}
+	if b.TokenMaxExpirationTTLHCL != "" {
+		result.TokenMaxExpirationTTLHCL = b.TokenMaxExpirationTTLHCL
+	}
 	if b.ReplicationToken != "" {
 		result.ReplicationToken = b.ReplicationToken
 	}
@@ -1794,6 +1911,9 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.CSIPluginGCThreshold != "" {
 		result.CSIPluginGCThreshold = b.CSIPluginGCThreshold
 	}
+	if b.ACLTokenGCThreshold != "" {
+		result.ACLTokenGCThreshold = b.ACLTokenGCThreshold
+	}
 	if b.RootKeyGCInterval != "" {
 		result.RootKeyGCInterval = b.RootKeyGCInterval
 	}
@@ -1867,6 +1987,18 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		result.PlanRejectionTracker = result.PlanRejectionTracker.Merge(b.PlanRejectionTracker)
 	}
 
+	if b.Keyring != nil {
+		result.Keyring = result.Keyring.Merge(b.Keyring)
+	}
+
+	if b.OIDCIssuer != "" {
+		result.OIDCIssuer = b.OIDCIssuer
+	}
+
+	if len(b.OIDCAudience) != 0 {
+		result.OIDCAudience = b.OIDCAudience
+	}
+
 	if b.DefaultSchedulerConfig != nil {
 		c := *b.DefaultSchedulerConfig
 		result.DefaultSchedulerConfig = &c
@@ -1927,6 +2059,9 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.NodeClass != "" {
 		result.NodeClass = b.NodeClass
 	}
+	if b.NodePool != "" {
+		result.NodePool = b.NodePool
+	}
 	if b.NetworkInterface != "" {
 		result.NetworkInterface = b.NetworkInterface
 	}
@@ -1939,6 +2074,12 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.MemoryMB != 0 {
 		result.MemoryMB = b.MemoryMB
 	}
+	if b.CpuOversubscriptionRatio != 0 {
+		result.CpuOversubscriptionRatio = b.CpuOversubscriptionRatio
+	}
+	if b.MemoryOversubscriptionRatio != 0 {
+		result.MemoryOversubscriptionRatio = b.MemoryOversubscriptionRatio
+	}
 	if b.MaxKillTimeout != "" {
 		result.MaxKillTimeout = b.MaxKillTimeout
 	}
@@ -1963,6 +2104,15 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.ReserveableCores != "" {
 		result.ReserveableCores = b.ReserveableCores
 	}
+	if b.UserNamespaceSubUIDs != "" {
+		result.UserNamespaceSubUIDs = b.UserNamespaceSubUIDs
+	}
+	if b.UserNamespaceSubGIDs != "" {
+		result.UserNamespaceSubGIDs = b.UserNamespaceSubGIDs
+	}
+	if b.UserNamespaceSize != 0 {
+		result.UserNamespaceSize = b.UserNamespaceSize
+	}
 	if b.GCInterval != 0 {
 		result.GCInterval = b.GCInterval
 	}
@@ -45,6 +45,7 @@ var basicConfig = &Config{
 		AllocDir:  "/tmp/alloc",
 		Servers:   []string{"a.b.c:80", "127.0.0.1:1234"},
 		NodeClass: "linux-medium-64bit",
+		NodePool:  "dev",
 		ServerJoin: &ServerJoin{
 			RetryJoin:        []string{"1.1.1.1", "2.2.2.2"},
 			RetryInterval:    time.Duration(15) * time.Second,
@@ -70,6 +71,9 @@ var basicConfig = &Config{
 		MaxKillTimeout:   "10s",
 		ClientMinPort:    1000,
 		ClientMaxPort:    2000,
+
+		CpuOversubscriptionRatio:    2.0,
+		MemoryOversubscriptionRatio: 1.5,
 		Reserved: &Resources{
 			CPU:           10,
 			MemoryMB:      10,
@@ -87,9 +91,12 @@ var basicConfig = &Config{
 		HostVolumes: []*structs.ClientHostVolumeConfig{
 			{Name: "tmp", Path: "/tmp"},
 		},
-		CNIPath:             "/tmp/cni_path",
-		BridgeNetworkName:   "custom_bridge_name",
-		BridgeNetworkSubnet: "custom_bridge_subnet",
+		CNIPath:              "/tmp/cni_path",
+		BridgeNetworkName:    "custom_bridge_name",
+		BridgeNetworkSubnet:  "custom_bridge_subnet",
+		UserNamespaceSubUIDs: "100000:655360",
+		UserNamespaceSubGIDs: "100000:655360",
+		UserNamespaceSize:    65536,
 	},
 	Server: &ServerConfig{
 		Enabled:                   true,
@@ -107,6 +114,7 @@ var basicConfig = &Config{
 		DeploymentGCThreshold:     "12h",
 		CSIVolumeClaimGCThreshold: "12h",
 		CSIPluginGCThreshold:      "12h",
+		ACLTokenGCThreshold:       "12h",
 		HeartbeatGrace:            30 * time.Second,
 		HeartbeatGraceHCL:         "30s",
 		MinHeartbeatTTL:           33 * time.Second,
@@ -132,6 +140,14 @@ var basicConfig = &Config{
 			NodeWindow:    41 * time.Minute,
 			NodeWindowHCL: "41m",
 		},
+		Keyring: &KeyringConfig{
+			RotationInterval: "72h",
+			Rekey:            pointer.Of(false),
+			KEK: &config.KEKConfig{
+				Provider: "aead",
+				KeyFile:  "/etc/nomad/kek",
+			},
+		},
 		ServerJoin: &ServerJoin{
 			RetryJoin:        []string{"1.1.1.1", "2.2.2.2"},
 			RetryInterval:    time.Duration(15) * time.Second,
@@ -149,12 +165,16 @@ var basicConfig = &Config{
 		LicensePath: "/tmp/nomad.hclic",
 	},
 	ACL: &ACLConfig{
-		Enabled:          true,
-		TokenTTL:         60 * time.Second,
-		TokenTTLHCL:      "60s",
-		PolicyTTL:        60 * time.Second,
-		PolicyTTLHCL:     "60s",
-		ReplicationToken: "foobar",
+		Enabled:                  true,
+		TokenTTL:                 60 * time.Second,
+		TokenTTLHCL:              "60s",
+		PolicyTTL:                60 * time.Second,
+		PolicyTTLHCL:             "60s",
+		TokenMinExpirationTTLHCL: "1h",
+		TokenMinExpirationTTL:    1 * time.Hour,
+		TokenMaxExpirationTTLHCL: "100h",
+		TokenMaxExpirationTTL:    100 * time.Hour,
+		ReplicationToken:         "foobar",
 	},
 	Audit: &config.AuditConfig{
 		Enabled: pointer.Of(true),
@@ -108,6 +108,7 @@ func TestConfig_Merge(t *testing.T) {
 			StateDir:  "/tmp/state1",
 			AllocDir:  "/tmp/alloc1",
 			NodeClass: "class1",
+			NodePool:  "pool1",
 			Options: map[string]string{
 				"foo": "bar",
 			},
@@ -153,6 +154,10 @@ func TestConfig_Merge(t *testing.T) {
 				NodeThreshold: 100,
 				NodeWindow:    11 * time.Minute,
 			},
+			Keyring: &KeyringConfig{
+				RotationInterval: "24h",
+				Rekey:            pointer.Of(true),
+			},
 		},
 		ACL: &ACLConfig{
 			Enabled:          true,
@@ -291,6 +296,7 @@ func TestConfig_Merge(t *testing.T) {
 			StateDir:  "/tmp/state2",
 			AllocDir:  "/tmp/alloc2",
 			NodeClass: "class2",
+			NodePool:  "pool2",
 			Servers:   []string{"server2"},
 			Meta: map[string]string{
 				"baz": "zip",
@@ -353,6 +359,10 @@ func TestConfig_Merge(t *testing.T) {
 				NodeThreshold: 100,
 				NodeWindow:    11 * time.Minute,
 			},
+			Keyring: &KeyringConfig{
+				RotationInterval: "48h",
+				Rekey:            pointer.Of(false),
+			},
 		},
 		ACL: &ACLConfig{
 			Enabled:          true,
@@ -54,6 +54,9 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/evaluate"):
 		jobName := strings.TrimSuffix(path, "/evaluate")
 		return s.jobForceEvaluate(resp, req, jobName)
+	case strings.HasSuffix(path, "/rebalance"):
+		jobName := strings.TrimSuffix(path, "/rebalance")
+		return s.jobRebalance(resp, req, jobName)
 	case strings.HasSuffix(path, "/allocations"):
 		jobName := strings.TrimSuffix(path, "/allocations")
 		return s.jobAllocations(resp, req, jobName)
@@ -133,6 +136,25 @@ func (s *HTTPServer) jobForceEvaluate(resp http.ResponseWriter, req *http.Reques
 	return out, nil
 }
 
+func (s *HTTPServer) jobRebalance(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+	if req.Method != "PUT" && req.Method != "POST" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.JobRebalanceRequest{
+		JobID: jobName,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.JobRegisterResponse
+	if err := s.agent.RPC("Job.Rebalance", &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
 func (s *HTTPServer) jobPlan(resp http.ResponseWriter, req *http.Request,
 	jobName string) (interface{}, error) {
 	if req.Method != "PUT" && req.Method != "POST" {
@@ -907,6 +929,7 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		Priority:       *job.Priority,
 		AllAtOnce:      *job.AllAtOnce,
 		Datacenters:    job.Datacenters,
+		NodePool:       *job.NodePool,
 		Payload:        job.Payload,
 		Meta:           job.Meta,
 		ConsulToken:    *job.ConsulToken,
@@ -975,6 +998,9 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		}
 	}
 
+	j.Preemption = ApiPreemptionPolicyToStructs(job.Preemption)
+	j.Window = ApiSchedulingWindowToStructs(job.Window)
+
 	if len(job.TaskGroups) > 0 {
 		j.TaskGroups = []*structs.TaskGroup{}
 		for _, taskGroup := range job.TaskGroups {
@@ -987,6 +1013,31 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 	return j
 }
 
+// ApiPreemptionPolicyToStructs converts a canonicalized preemption policy.
+func ApiPreemptionPolicyToStructs(p *api.PreemptionPolicy) *structs.PreemptionPolicy {
+	if p == nil {
+		return nil
+	}
+	return &structs.PreemptionPolicy{
+		MaxPreemptions:    *p.MaxPreemptions,
+		ProtectedPriority: *p.ProtectedPriority,
+		Protected:         *p.Protected,
+	}
+}
+
+// ApiSchedulingWindowToStructs converts a canonicalized scheduling window.
+func ApiSchedulingWindowToStructs(w *api.SchedulingWindow) *structs.SchedulingWindow {
+	if w == nil {
+		return nil
+	}
+	return &structs.SchedulingWindow{
+		Start:    *w.Start,
+		End:      *w.End,
+		TimeZone: *w.TimeZone,
+		Days:     helper.CopySliceString(w.Days),
+	}
+}
+
 func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.TaskGroup) {
 	tg.Name = *taskGroup.Name
 	tg.Count = *taskGroup.Count
@@ -1033,9 +1084,19 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 			HealthCheck:     *taskGroup.Migrate.HealthCheck,
 			MinHealthyTime:  *taskGroup.Migrate.MinHealthyTime,
 			HealthyDeadline: *taskGroup.Migrate.HealthyDeadline,
+			Checkpoint:      *taskGroup.Migrate.Checkpoint,
 		}
 	}
 
+	if taskGroup.Gang != nil {
+		tg.Gang = &structs.GangStrategy{
+			Scope: *taskGroup.Gang.Scope,
+		}
+	}
+
+	tg.Preemption = ApiPreemptionPolicyToStructs(taskGroup.Preemption)
+	tg.Window = ApiSchedulingWindowToStructs(taskGroup.Window)
+
 	if taskGroup.Scaling != nil {
 		tg.Scaling = ApiScalingPolicyToStructs(tg.Count, taskGroup.Scaling).TargetTaskGroup(job, tg)
 	}
@@ -1323,6 +1384,12 @@ func ApiResourcesToStructs(in *api.Resources) *structs.Resources {
 		}
 	}
 
+	if in.NUMA != nil {
+		out.NUMA = &structs.NUMA{
+			Affinity: in.NUMA.Affinity,
+		}
+	}
+
 	return out
 }
 
@@ -1790,6 +1857,9 @@ func ApiSpreadToStructs(a1 *api.Spread) *structs.Spread {
 	ret := &structs.Spread{}
 	ret.Attribute = a1.Attribute
 	ret.Weight = *a1.Weight
+	if a1.MaxSkew != nil {
+		ret.MaxSkew = *a1.MaxSkew
+	}
 	if a1.SpreadTarget != nil {
 		ret.SpreadTarget = make([]*structs.SpreadTarget, len(a1.SpreadTarget))
 		for i, st := range a1.SpreadTarget {
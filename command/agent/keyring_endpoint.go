@@ -132,3 +132,81 @@ func (s *HTTPServer) keyringDeleteRequest(resp http.ResponseWriter, req *http.Re
 	setIndex(resp, out.Index)
 	return out, nil
 }
+
+// jwksCacheMaxAge is how long third parties may cache the JWKS and OIDC
+// discovery responses. Rotating the keyring signs new identities with the new
+// key right away, so this is kept short to bound how long a cached key set
+// can be missing the active key. Verifiers should still refetch the key set
+// when they see an unknown key ID.
+const jwksCacheMaxAge = "max-age=60"
+
+// JWKSet is the JSON Web Key Set (RFC 7517) of the public keys used to
+// verify workload identities.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK is a JSON Web Key (RFC 7517) for an Ed25519 public key (RFC 8037).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+}
+
+// JWKSRequest returns the public keys used to verify workload identities.
+func (s *HTTPServer) JWKSRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.GenericRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.KeyringListPublicResponse
+	if err := s.agent.RPC("Keyring.ListPublic", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	resp.Header().Set("Cache-Control", jwksCacheMaxAge)
+
+	jwks := &JWKSet{Keys: make([]JWK, 0, len(out.PublicKeys))}
+	for _, pubKey := range out.PublicKeys {
+		jwks.Keys = append(jwks.Keys, JWK{
+			KeyType:   "OKP",
+			Curve:     "Ed25519",
+			X:         base64.RawURLEncoding.EncodeToString(pubKey.PublicKey),
+			KeyID:     pubKey.KeyID,
+			Algorithm: pubKey.Algorithm,
+			Use:       pubKey.Use,
+		})
+	}
+	return jwks, nil
+}
+
+// OIDCDiscoveryRequest returns the OIDC discovery document for workload
+// identities.
+func (s *HTTPServer) OIDCDiscoveryRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.GenericRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.KeyringGetOIDCDiscoveryResponse
+	if err := s.agent.RPC("Keyring.GetOIDCDiscovery", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	resp.Header().Set("Cache-Control", jwksCacheMaxAge)
+	return out.OIDCDiscovery, nil
+}
@@ -101,3 +101,52 @@ func TestHTTP_Keyring_CRUD(t *testing.T) {
 		}
 	})
 }
+
+func TestHTTP_Keyring_JWKS(t *testing.T) {
+	ci.Parallel(t)
+
+	httpTest(t, func(c *Config) {
+		c.Server.OIDCIssuer = "https://nomad.example.com"
+	}, func(s *TestAgent) {
+
+		// JWKS
+
+		respW := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+		require.NoError(t, err)
+		obj, err := s.Server.JWKSRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, jwksCacheMaxAge, respW.Header().Get("Cache-Control"))
+
+		jwks := obj.(*JWKSet)
+		require.Len(t, jwks.Keys, 1)
+		key := jwks.Keys[0]
+		require.Equal(t, "OKP", key.KeyType)
+		require.Equal(t, "Ed25519", key.Curve)
+		require.Equal(t, "EdDSA", key.Algorithm)
+		require.Equal(t, "sig", key.Use)
+		require.NotEmpty(t, key.KeyID)
+		x, err := base64.RawURLEncoding.DecodeString(key.X)
+		require.NoError(t, err)
+		require.Len(t, x, 32)
+
+		// OIDC discovery
+
+		respW = httptest.NewRecorder()
+		req, err = http.NewRequest(http.MethodGet, "/.well-known/openid-configuration", nil)
+		require.NoError(t, err)
+		obj, err = s.Server.OIDCDiscoveryRequest(respW, req)
+		require.NoError(t, err)
+
+		discovery := obj.(*structs.OIDCDiscoveryConfig)
+		require.Equal(t, "https://nomad.example.com", discovery.Issuer)
+		require.Equal(t, "https://nomad.example.com/.well-known/jwks.json", discovery.JWKS)
+
+		// Invalid method
+
+		req, err = http.NewRequest(http.MethodPut, "/.well-known/jwks.json", nil)
+		require.NoError(t, err)
+		_, err = s.Server.JWKSRequest(respW, req)
+		require.EqualError(t, err, ErrInvalidMethod)
+	})
+}
@@ -0,0 +1,99 @@
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_NodePoolList(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		pool1 := mock.NodePool()
+		pool2 := mock.NodePool()
+		args := structs.NodePoolUpsertRequest{
+			NodePools:    []*structs.NodePool{pool1, pool2},
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.GenericResponse
+		require.NoError(t, s.Agent.RPC(structs.NodePoolUpsertRPCMethod, &args, &resp))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/node/pools", nil)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.NodePoolsRequest(respW, req)
+		require.NoError(t, err)
+
+		// Check for the index
+		require.NotZero(t, respW.HeaderMap.Get("X-Nomad-Index"))
+		require.Equal(t, "true", respW.HeaderMap.Get("X-Nomad-KnownLeader"))
+		require.NotZero(t, respW.HeaderMap.Get("X-Nomad-LastContact"))
+
+		// Check the output (the 2 we register + the built-in pools)
+		require.Len(t, obj.([]*structs.NodePool), 4)
+	})
+}
+
+func TestHTTP_NodePoolCRUD(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		pool := mock.NodePool()
+
+		// Create the node pool
+		buf := encodeReq(pool)
+		req, err := http.NewRequest("PUT", "/v1/node/pool/"+pool.Name, buf)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.NodePoolSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Nil(t, obj)
+		require.NotZero(t, respW.HeaderMap.Get("X-Nomad-Index"))
+
+		// Read the node pool back
+		req, err = http.NewRequest("GET", "/v1/node/pool/"+pool.Name, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		obj, err = s.Server.NodePoolSpecificRequest(respW, req)
+		require.NoError(t, err)
+		out := obj.(*structs.NodePool)
+		require.Equal(t, pool.Name, out.Name)
+		require.Equal(t, pool.Description, out.Description)
+		require.Equal(t, pool.Meta, out.Meta)
+
+		// A mismatched name should be rejected
+		buf = encodeReq(pool)
+		req, err = http.NewRequest("PUT", "/v1/node/pool/other", buf)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		_, err = s.Server.NodePoolSpecificRequest(respW, req)
+		require.ErrorContains(t, err, "does not match request path")
+
+		// Delete the node pool
+		req, err = http.NewRequest("DELETE", "/v1/node/pool/"+pool.Name, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		_, err = s.Server.NodePoolSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.NotZero(t, respW.HeaderMap.Get("X-Nomad-Index"))
+
+		// The node pool should no longer be found
+		req, err = http.NewRequest("GET", "/v1/node/pool/"+pool.Name, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		_, err = s.Server.NodePoolSpecificRequest(respW, req)
+		require.ErrorContains(t, err, "Node pool not found")
+	})
+}
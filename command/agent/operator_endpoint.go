@@ -270,6 +270,26 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 			ServiceSchedulerEnabled:  conf.PreemptionConfig.ServiceSchedulerEnabled},
 	}
 
+	for _, scorer := range conf.NodeScorers {
+		if scorer == nil {
+			continue
+		}
+		args.Config.NodeScorers = append(args.Config.NodeScorers, &structs.NodeScorer{
+			Name:       scorer.Name,
+			Expression: scorer.Expression,
+			Weight:     scorer.Weight,
+			Min:        scorer.Min,
+			Max:        scorer.Max,
+		})
+	}
+
+	if conf.EvalBrokerFairShare != nil {
+		args.Config.EvalBrokerFairShare = &structs.EvalBrokerFairShare{
+			Enabled: conf.EvalBrokerFairShare.Enabled,
+			Weights: conf.EvalBrokerFairShare.Weights,
+		}
+	}
+
 	if err := args.Config.Validate(); err != nil {
 		return nil, CodedError(http.StatusBadRequest, err.Error())
 	}
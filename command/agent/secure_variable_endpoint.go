@@ -39,6 +39,9 @@ func (s *HTTPServer) SecureVariableSpecificRequest(resp http.ResponseWriter, req
 	}
 	switch req.Method {
 	case http.MethodGet:
+		if _, ok := req.URL.Query()["history"]; ok {
+			return s.secureVariableHistory(resp, req, path)
+		}
 		return s.secureVariableQuery(resp, req, path)
 	case http.MethodPut, http.MethodPost:
 		return s.secureVariableUpsert(resp, req, path)
@@ -57,6 +60,13 @@ func (s *HTTPServer) secureVariableQuery(resp http.ResponseWriter, req *http.Req
 	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
 		return nil, nil
 	}
+	if vq := req.URL.Query().Get("version"); vq != "" {
+		version, err := strconv.ParseUint(vq, 10, 64)
+		if err != nil {
+			return nil, CodedError(http.StatusBadRequest, fmt.Sprintf("can not parse version: %v", err))
+		}
+		args.Version = &version
+	}
 	var out structs.SecureVariablesReadResponse
 	if err := s.agent.RPC(structs.SecureVariablesReadRPCMethod, &args, &out); err != nil {
 		return nil, err
@@ -70,6 +80,27 @@ func (s *HTTPServer) secureVariableQuery(resp http.ResponseWriter, req *http.Req
 	return out.Data, nil
 }
 
+func (s *HTTPServer) secureVariableHistory(resp http.ResponseWriter, req *http.Request,
+	path string) (interface{}, error) {
+	args := structs.SecureVariablesHistoryRequest{
+		Path: path,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+	var out structs.SecureVariablesHistoryResponse
+	if err := s.agent.RPC(structs.SecureVariablesHistoryRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+
+	if len(out.Versions) == 0 {
+		return nil, CodedError(http.StatusNotFound, "secure variable not found")
+	}
+	return out.Versions, nil
+}
+
 func (s *HTTPServer) secureVariableUpsert(resp http.ResponseWriter, req *http.Request,
 	path string) (interface{}, error) {
 	// Parse the SecureVariable
@@ -77,7 +108,14 @@ func (s *HTTPServer) secureVariableUpsert(resp http.ResponseWriter, req *http.Re
 	if err := decodeBody(req, &SecureVariable); err != nil {
 		return nil, CodedError(http.StatusBadRequest, err.Error())
 	}
-	if len(SecureVariable.Items) == 0 {
+
+	lockOp, err := parseLockOp(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lock operations don't require the variable to hold any items
+	if lockOp == "" && len(SecureVariable.Items) == 0 {
 		return nil, CodedError(http.StatusBadRequest, "secure variable missing required Items object")
 	}
 
@@ -90,7 +128,9 @@ func (s *HTTPServer) secureVariableUpsert(resp http.ResponseWriter, req *http.Re
 
 	s.parseWriteRequest(req, &args.WriteRequest)
 
-	if isCas, checkIndex, err := parseCAS(req); err != nil {
+	if lockOp != "" {
+		args.Op = lockOp
+	} else if isCas, checkIndex, err := parseCAS(req); err != nil {
 		return nil, err
 	} else if isCas {
 		args.Op = structs.SVOpCAS
@@ -145,6 +185,11 @@ func (s *HTTPServer) secureVariableDelete(resp http.ResponseWriter, req *http.Re
 		args.Var.ModifyIndex = checkIndex
 	}
 
+	// A locked variable can only be deleted by the lock holder
+	if lockID := req.URL.Query().Get("lock-id"); lockID != "" {
+		args.Var.Lock = &structs.SecureVariableLock{ID: lockID}
+	}
+
 	var out structs.SecureVariablesApplyResponse
 	if err := s.agent.RPC(structs.SecureVariablesApplyRPCMethod, &args, &out); err != nil {
 
@@ -183,3 +228,23 @@ func parseCAS(req *http.Request) (bool, uint64, error) {
 	}
 	return false, 0, nil
 }
+
+// parseLockOp returns the lock operation requested by the query parameters
+// of a secure variable write, if any. The lock operations are mutually
+// exclusive.
+func parseLockOp(req *http.Request) (structs.SVOp, error) {
+	var op structs.SVOp
+	query := req.URL.Query()
+	for _, lockOp := range []structs.SVOp{
+		structs.SVOpLockAcquire, structs.SVOpLockRenew, structs.SVOpLockRelease} {
+		if _, ok := query[string(lockOp)]; !ok {
+			continue
+		}
+		if op != "" {
+			return "", CodedError(http.StatusBadRequest,
+				fmt.Sprintf("only one of %s and %s may be set", op, lockOp))
+		}
+		op = lockOp
+	}
+	return op, nil
+}
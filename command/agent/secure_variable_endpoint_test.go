@@ -290,6 +290,7 @@ func TestHTTP_SecureVariables(t *testing.T) {
 				// can use a simple equality check
 				svU.ModifyIndex = out.ModifyIndex
 				svU.ModifyTime = out.ModifyTime
+				svU.Version = out.Version
 				require.Equal(t, &svU, out)
 			}
 		})
@@ -368,6 +369,7 @@ func TestHTTP_SecureVariables(t *testing.T) {
 				// can use a simple equality check
 				svU.CreateIndex, svU.ModifyIndex = out.CreateIndex, out.ModifyIndex
 				svU.CreateTime, svU.ModifyTime = out.CreateTime, out.ModifyTime
+				svU.Version = out.Version
 				require.Equal(t, svU.SecureVariableMetadata, out.SecureVariableMetadata)
 
 				// fmt writes sorted output of maps for testability.
@@ -465,6 +467,116 @@ func TestHTTP_SecureVariables(t *testing.T) {
 			require.NoError(t, err)
 			require.Nil(t, sv)
 		})
+		rpcResetSV(s)
+
+		t.Run("error_multiple_lock_ops", func(t *testing.T) {
+			buf := encodeReq(sv1)
+			req, err := http.NewRequest("PUT", "/v1/var/"+sv1.Path+"?lock-acquire&lock-release", buf)
+			require.NoError(t, err)
+			respW := httptest.NewRecorder()
+			obj, err := s.Server.SecureVariableSpecificRequest(respW, req)
+			require.EqualError(t, err, "only one of lock-acquire and lock-release may be set")
+			require.Nil(t, obj)
+		})
+		t.Run("lock", func(t *testing.T) {
+			sv := &structs.SecureVariableDecrypted{
+				SecureVariableMetadata: structs.SecureVariableMetadata{
+					Namespace: "default",
+					Path:      "locks/leader",
+				},
+			}
+
+			// Acquire the lock without any items
+			req, err := http.NewRequest("PUT", "/v1/var/"+sv.Path+"?lock-acquire", encodeReq(sv))
+			require.NoError(t, err)
+			respW := httptest.NewRecorder()
+			obj, err := s.Server.SecureVariableSpecificRequest(respW, req)
+			require.NoError(t, err)
+			locked, ok := obj.(*structs.SecureVariableDecrypted)
+			require.True(t, ok, "Unable to convert obj to SecureVariableDecrypted")
+			require.NotNil(t, locked.Lock)
+			require.NotEmpty(t, locked.Lock.ID)
+
+			// A second acquire is a conflict
+			req, err = http.NewRequest("PUT", "/v1/var/"+sv.Path+"?lock-acquire", encodeReq(sv))
+			require.NoError(t, err)
+			respW = httptest.NewRecorder()
+			obj, err = s.Server.SecureVariableSpecificRequest(respW, req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusConflict, respW.Result().StatusCode)
+			conflict, ok := obj.(*structs.SecureVariableDecrypted)
+			require.True(t, ok, "Unable to convert obj to SecureVariableDecrypted")
+			require.Empty(t, conflict.Lock.ID)
+
+			// Deleting the variable requires the lock ID
+			req, err = http.NewRequest("DELETE", "/v1/var/"+sv.Path, nil)
+			require.NoError(t, err)
+			respW = httptest.NewRecorder()
+			_, err = s.Server.SecureVariableSpecificRequest(respW, req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusConflict, respW.Result().StatusCode)
+
+			// Release the lock
+			sv.Lock = &structs.SecureVariableLock{ID: locked.Lock.ID}
+			req, err = http.NewRequest("PUT", "/v1/var/"+sv.Path+"?lock-release", encodeReq(sv))
+			require.NoError(t, err)
+			respW = httptest.NewRecorder()
+			obj, err = s.Server.SecureVariableSpecificRequest(respW, req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, respW.Result().StatusCode)
+			require.Nil(t, obj.(*structs.SecureVariableDecrypted).Lock)
+
+			out, err := rpcReadSV(s, sv.Namespace, sv.Path)
+			require.NoError(t, err)
+			require.NotNil(t, out)
+			require.Nil(t, out.Lock)
+		})
+		rpcResetSV(s)
+
+		t.Run("error_parse_version", func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/v1/var/does/not/exist?version=latest", nil)
+			require.NoError(t, err)
+			respW := httptest.NewRecorder()
+			obj, err := s.Server.SecureVariableSpecificRequest(respW, req)
+			require.EqualError(t, err, `can not parse version: strconv.ParseUint: parsing "latest": invalid syntax`)
+			require.Nil(t, obj)
+		})
+		t.Run("history_unset_variable", func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/v1/var/does/not/exist?history", nil)
+			require.NoError(t, err)
+			respW := httptest.NewRecorder()
+			obj, err := s.Server.SecureVariableSpecificRequest(respW, req)
+			require.EqualError(t, err, "secure variable not found")
+			require.Nil(t, obj)
+		})
+		t.Run("history", func(t *testing.T) {
+			sv := mock.SecureVariable()
+			require.NoError(t, rpcWriteSV(s, sv, nil))
+			prior := sv.Items.Copy()
+			sv.Items["new"] = "value"
+			require.NoError(t, rpcWriteSV(s, sv, nil))
+
+			req, err := http.NewRequest("GET", "/v1/var/"+sv.Path+"?history", nil)
+			require.NoError(t, err)
+			respW := httptest.NewRecorder()
+			obj, err := s.Server.SecureVariableSpecificRequest(respW, req)
+			require.NoError(t, err)
+			versions, ok := obj.([]*structs.SecureVariableMetadata)
+			require.True(t, ok, "Unable to convert obj to []*SecureVariableMetadata")
+			require.Len(t, versions, 2)
+			require.Equal(t, uint64(1), versions[0].Version)
+
+			// Read the prior version
+			req, err = http.NewRequest("GET", "/v1/var/"+sv.Path+"?version=0", nil)
+			require.NoError(t, err)
+			respW = httptest.NewRecorder()
+			obj, err = s.Server.SecureVariableSpecificRequest(respW, req)
+			require.NoError(t, err)
+			out, ok := obj.(*structs.SecureVariableDecrypted)
+			require.True(t, ok, "Unable to convert obj to SecureVariableDecrypted")
+			require.Equal(t, uint64(0), out.Version)
+			require.Equal(t, prior, out.Items)
+		})
 	})
 }
 
@@ -39,3 +39,19 @@ func (s *HTTPServer) ReconcileJobSummaries(resp http.ResponseWriter, req *http.R
 	}
 	return nil, nil
 }
+
+func (s *HTTPServer) SystemRebalanceRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "PUT" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.SystemRebalanceRequest
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.SystemRebalanceResponse
+	if err := s.agent.RPC("System.Rebalance", &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
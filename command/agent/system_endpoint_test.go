@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
 )
 
 func TestHTTP_SystemGarbageCollect(t *testing.T) {
@@ -45,3 +46,27 @@ func TestHTTP_ReconcileJobSummaries(t *testing.T) {
 		}
 	})
 }
+
+func TestHTTP_SystemRebalance(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Make the HTTP request
+		req, err := http.NewRequest("PUT", "/v1/system/rebalance", nil)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.SystemRebalanceRequest(respW, req)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+
+		// There are no jobs to rebalance
+		out := obj.(structs.SystemRebalanceResponse)
+		if len(out.EvalIDs) != 0 {
+			t.Fatalf("bad: %v", out)
+		}
+	})
+}
@@ -42,6 +42,7 @@ client {
   alloc_dir  = "/tmp/alloc"
   servers    = ["a.b.c:80", "127.0.0.1:1234"]
   node_class = "linux-medium-64bit"
+  node_pool  = "dev"
 
   meta {
     foo = "bar"
@@ -68,6 +69,9 @@ client {
   network_speed     = 100
   cpu_total_compute = 4444
 
+  cpu_oversubscription_ratio    = 2.0
+  memory_oversubscription_ratio = 1.5
+
   reserved {
     cpu            = 10
     memory         = 10
@@ -99,6 +103,10 @@ client {
   cni_path              = "/tmp/cni_path"
   bridge_network_name   = "custom_bridge_name"
   bridge_network_subnet = "custom_bridge_subnet"
+
+  user_namespace_subuids = "100000:655360"
+  user_namespace_subgids = "100000:655360"
+  user_namespace_size    = 65536
 }
 
 server {
@@ -116,6 +124,7 @@ server {
   deployment_gc_threshold       = "12h"
   csi_volume_claim_gc_threshold = "12h"
   csi_plugin_gc_threshold       = "12h"
+  acl_token_gc_threshold        = "12h"
   heartbeat_grace               = "30s"
   min_heartbeat_ttl             = "33s"
   max_heartbeats_per_second     = 11.0
@@ -139,6 +148,16 @@ server {
     node_window    = "41m"
   }
 
+  keyring {
+    rotation_interval = "72h"
+    rekey             = false
+
+    kek {
+      provider = "aead"
+      key_file = "/etc/nomad/kek"
+    }
+  }
+
   server_join {
     retry_join     = ["1.1.1.1", "2.2.2.2"]
     retry_max      = 3
@@ -159,10 +178,12 @@ server {
 }
 
 acl {
-  enabled           = true
-  token_ttl         = "60s"
-  policy_ttl        = "60s"
-  replication_token = "foobar"
+  enabled                  = true
+  token_ttl                = "60s"
+  policy_ttl               = "60s"
+  token_min_expiration_ttl = "1h"
+  token_max_expiration_ttl = "100h"
+  replication_token        = "foobar"
 }
 
 audit {
@@ -328,9 +328,13 @@ func (c *JobPlanCommand) addPreemptions(resp *api.JobPlanResponse) {
 	c.Ui.Output(c.Colorize().Color("[bold][yellow]Preemptions:\n[reset]"))
 	if len(resp.Annotations.PreemptedAllocs) < preemptionDisplayThreshold {
 		var allocs []string
-		allocs = append(allocs, "Alloc ID|Job ID|Task Group")
+		allocs = append(allocs, "Alloc ID|Job ID|Task Group|Reason")
 		for _, alloc := range resp.Annotations.PreemptedAllocs {
-			allocs = append(allocs, fmt.Sprintf("%s|%s|%s", alloc.ID, alloc.JobID, alloc.TaskGroup))
+			reason := resp.Annotations.PreemptionReasons[alloc.ID]
+			if reason == "" {
+				reason = "<none>"
+			}
+			allocs = append(allocs, fmt.Sprintf("%s|%s|%s|%s", alloc.ID, alloc.JobID, alloc.TaskGroup, reason))
 		}
 		c.Ui.Output(formatList(allocs))
 		return
@@ -246,12 +246,16 @@ func TestPlanCommad_Preemptions(t *testing.T) {
 					Namespace: "test",
 				},
 			},
+			PreemptionReasons: map[string]string{
+				"alloc1": "job priority 20 is lower than 80, frees cpu, memory and disk",
+			},
 		},
 	}
 	cmd.addPreemptions(resp1)
 	out := ui.OutputWriter.String()
 	require.Contains(out, "Alloc ID")
 	require.Contains(out, "alloc1")
+	require.Contains(out, "job priority 20 is lower than 80, frees cpu, memory and disk")
 
 	// Less than 10 unique job ids
 	var preemptedAllocs []*api.AllocationListStub
@@ -0,0 +1,122 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type JobRebalanceCommand struct {
+	Meta
+}
+
+func (c *JobRebalanceCommand) Help() string {
+	helpText := `
+Usage: nomad job rebalance [options] <job_id>
+
+  Create a rebalance evaluation for the provided service job ID. The scheduler
+  scores each running allocation of the job on its current node and on the
+  other feasible nodes, and migrates the allocations that would score
+  meaningfully better elsewhere. Allocations are migrated according to the
+  task group's migrate block, so no more than max_parallel allocations are
+  migrated at once, and only healthy allocations are migrated. Jobs with an
+  active deployment are not rebalanced.
+
+  When ACLs are enabled, this command requires a token with the 'submit-job'
+  capability for the job's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Rebalance Options:
+
+  -detach
+    Return immediately instead of entering monitor mode. The ID
+    of the evaluation created will be printed to the screen, which can be
+    used to examine the evaluation using the eval-status command.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobRebalanceCommand) Synopsis() string {
+	return "Migrate allocations of a job to better scoring nodes"
+}
+
+func (c *JobRebalanceCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-detach":  complete.PredictNothing,
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *JobRebalanceCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobRebalanceCommand) Name() string { return "job rebalance" }
+
+func (c *JobRebalanceCommand) Run(args []string) int {
+	var detach, verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&detach, "detach", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one job
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <job>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	evalID, _, err := client.Jobs().Rebalance(args[0], nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error rebalancing job: %s", err))
+		return 1
+	}
+
+	if detach {
+		c.Ui.Output(fmt.Sprintf("Created eval ID: %q ", limit(evalID, length)))
+		return 0
+	}
+
+	mon := newMonitor(c.Ui, client, length)
+	return mon.monitor(evalID)
+}
@@ -0,0 +1,66 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobRebalanceCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &JobRebalanceCommand{}
+}
+
+func TestJobRebalanceCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &JobRebalanceCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails when job ID is not specified
+	code = cmd.Run([]string{})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "This command takes one argument")
+}
+
+func TestJobRebalanceCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &JobRebalanceCommand{Meta: Meta{Ui: ui}}
+
+	state := srv.Agent.Server().State()
+	job := mock.Job()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+	batchJob := mock.BatchJob()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, batchJob))
+
+	code := cmd.Run([]string{"-address=" + url, "-detach", job.ID})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Created eval ID")
+
+	evals, err := state.EvalsByJob(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, evals)
+	triggers := []string{}
+	for _, eval := range evals {
+		triggers = append(triggers, eval.TriggeredBy)
+	}
+	require.Contains(t, triggers, structs.EvalTriggerRebalance)
+
+	// Batch jobs can't be rebalanced
+	code = cmd.Run([]string{"-address=" + url, "-detach", batchJob.ID})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "can't rebalance batch job")
+}
@@ -0,0 +1,171 @@
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+// Ensure LoginCommand satisfies the cli.Command interface.
+var _ cli.Command = &LoginCommand{}
+
+// LoginCommand implements cli.Command.
+type LoginCommand struct {
+	Meta
+
+	authMethodName string
+	loginToken     string
+	json           bool
+	tmpl           string
+}
+
+// Help satisfies the cli.Command Help function.
+func (l *LoginCommand) Help() string {
+	helpText := `
+Usage: nomad login [options]
+
+  The login command will exchange the provided third party credentials with
+  the requested auth method for a newly minted Nomad ACL token. The token is
+  written to stdout and can be exported as the NOMAD_TOKEN environment
+  variable for use by subsequent commands.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsNoNamespace) + `
+
+Login Options:
+
+  -method
+    The name of the ACL auth method to login with. If not specified, the
+    default auth method will be used.
+
+  -login-token
+    The third party credential, such as an OIDC ID token, to exchange for a
+    Nomad ACL token. Passing "-" reads the credential from stdin. This is a
+    required parameter.
+
+  -json
+    Output the ACL token in a JSON format.
+
+  -t
+    Format and display the ACL token using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (l *LoginCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(l.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-method":      complete.PredictAnything,
+			"-login-token": complete.PredictAnything,
+			"-json":        complete.PredictNothing,
+			"-t":           complete.PredictAnything,
+		})
+}
+
+func (l *LoginCommand) AutocompleteArgs() complete.Predictor { return complete.PredictNothing }
+
+// Synopsis satisfies the cli.Command Synopsis function.
+func (l *LoginCommand) Synopsis() string {
+	return "Login to Nomad using an auth method"
+}
+
+// Name returns the name of this command.
+func (l *LoginCommand) Name() string { return "login" }
+
+// Run satisfies the cli.Command Run function.
+func (l *LoginCommand) Run(args []string) int {
+
+	flags := l.Meta.FlagSet(l.Name(), FlagSetClient)
+	flags.Usage = func() { l.Ui.Output(l.Help()) }
+	flags.StringVar(&l.authMethodName, "method", "", "")
+	flags.StringVar(&l.loginToken, "login-token", "", "")
+	flags.BoolVar(&l.json, "json", false, "")
+	flags.StringVar(&l.tmpl, "t", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments.
+	if len(flags.Args()) != 0 {
+		l.Ui.Error("This command takes no arguments")
+		l.Ui.Error(commandErrorText(l))
+		return 1
+	}
+
+	if l.loginToken == "" {
+		l.Ui.Error("Login token must be specified using the -login-token flag")
+		return 1
+	}
+
+	// Read the login token from stdin if requested, removing any trailing
+	// newline.
+	if l.loginToken == "-" {
+		raw, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			l.Ui.Error(fmt.Sprintf("Error reading login token: %v", err))
+			return 1
+		}
+		l.loginToken = strings.TrimSpace(string(raw))
+	}
+
+	// Get the HTTP client.
+	client, err := l.Meta.Client()
+	if err != nil {
+		l.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// If the operator did not specify an auth method, look up the default
+	// one. Listing auth methods does not require a token.
+	if l.authMethodName == "" {
+		authMethodList, _, err := client.ACLAuthMethods().List(nil)
+		if err != nil {
+			l.Ui.Error(fmt.Sprintf("Error listing ACL auth methods: %s", err))
+			return 1
+		}
+
+		for _, authMethod := range authMethodList {
+			if authMethod.Default {
+				l.authMethodName = authMethod.Name
+				break
+			}
+		}
+
+		if l.authMethodName == "" {
+			l.Ui.Error("Must specify an auth method name, no default found")
+			return 1
+		}
+	}
+
+	req := api.ACLLoginRequest{
+		AuthMethodName: l.authMethodName,
+		LoginToken:     l.loginToken,
+	}
+
+	token, _, err := client.ACLAuth().Login(&req, nil)
+	if err != nil {
+		l.Ui.Error(fmt.Sprintf("Error performing login: %v", err))
+		return 1
+	}
+
+	if l.json || len(l.tmpl) > 0 {
+		out, err := Format(l.json, l.tmpl, token)
+		if err != nil {
+			l.Ui.Error(err.Error())
+			return 1
+		}
+
+		l.Ui.Output(out)
+		return 0
+	}
+
+	l.Ui.Output(fmt.Sprintf("Successfully logged in via %s\n", l.authMethodName))
+	l.Ui.Output(formatKVACLToken(token))
+	return 0
+}
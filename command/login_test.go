@@ -0,0 +1,95 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/lib/auth/oidc"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestLoginCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer stopTestAgent(srv)
+
+	// Wait for the server to start fully and ensure we have a bootstrap token.
+	must.NotNil(t, srv.RootToken)
+
+	ui := cli.NewMockUi()
+	cmd := &LoginCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+	}
+
+	// Test the basic validation on the command.
+	must.One(t, cmd.Run([]string{"-address=" + url, "this-command-does-not-take-args"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes no arguments")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	must.One(t, cmd.Run([]string{"-address=" + url}))
+	must.StrContains(t, ui.ErrorWriter.String(), "Login token must be specified using the -login-token flag")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Without an auth method name or a default method, the login should fail.
+	must.One(t, cmd.Run([]string{"-address=" + url, "-login-token=foo"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "Must specify an auth method name, no default found")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Create a default auth method backed by a test OIDC provider, along with
+	// a binding rule which grants a policy.
+	oidcProvider := oidc.NewTestProvider(t)
+
+	authMethod := mock.ACLAuthMethod()
+	authMethod.Default = true
+	authMethod.Config = &structs.ACLAuthMethodConfig{
+		OIDCDiscoveryURL: oidcProvider.URL(),
+		OIDCClientID:     "nomad",
+	}
+	authMethod.SetHash()
+	must.NoError(t, srv.Agent.Server().State().UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod}))
+
+	policy := mock.ACLPolicy()
+	must.NoError(t, srv.Agent.Server().State().UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLPolicy{policy}))
+
+	bindingRule := mock.ACLBindingRule()
+	bindingRule.AuthMethod = authMethod.Name
+	bindingRule.Selector = ""
+	bindingRule.BindType = structs.ACLBindingRuleBindTypePolicy
+	bindingRule.BindName = policy.Name
+	bindingRule.SetHash()
+	must.NoError(t, srv.Agent.Server().State().UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 30, []*structs.ACLBindingRule{bindingRule}, false))
+
+	// Login using the default auth method.
+	loginToken := oidcProvider.SignIDToken(t, map[string]interface{}{"aud": "nomad"})
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-login-token=" + loginToken}))
+	s := ui.OutputWriter.String()
+	must.StrContains(t, s, "Successfully logged in via "+authMethod.Name)
+	must.StrContains(t, s, policy.Name)
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Login naming an auth method that does not exist.
+	must.One(t, cmd.Run([]string{"-address=" + url, "-method=does-not-exist", "-login-token=" + loginToken}))
+	must.StrContains(t, ui.ErrorWriter.String(), "Error performing login")
+}
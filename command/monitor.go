@@ -353,6 +353,11 @@ func formatAllocMetrics(metrics *api.AllocationMetric, scores bool, prefix strin
 		out += fmt.Sprintf("%s* Quota limit hit %q\n", prefix, dim)
 	}
 
+	// Print gang info
+	if metrics.GangFailure != "" {
+		out += fmt.Sprintf("%s* Gang placement withheld: %s\n", prefix, metrics.GangFailure)
+	}
+
 	// Print scores
 	if scores {
 		if len(metrics.ScoreMetaData) > 0 {
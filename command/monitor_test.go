@@ -263,6 +263,16 @@ Node    score-1  score-2  score-3  score-4  final score
 node-1  1        2        0        0        1
 node-2  1        0        3        0        2
 node-3  0        0        0        4        3
+`,
+		},
+		{
+			Name: "display gang failure",
+			Metrics: &api.AllocationMetric{
+				NodesEvaluated: 3,
+				GangFailure:    "1 of 4 allocations of gang [web] could not be placed",
+			},
+			Expected: `
+* Gang placement withheld: 1 of 4 allocations of gang [web] could not be placed
 `,
 		},
 	}
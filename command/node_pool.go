@@ -0,0 +1,51 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type NodePoolCommand struct {
+	Meta
+}
+
+func (c *NodePoolCommand) Help() string {
+	helpText := `
+Usage: nomad node pool <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with node pools. Node pools
+  partition the clients of a cluster, so that jobs can be restricted to run
+  only on the nodes of a specific pool.
+
+  Create or update a node pool:
+
+      $ nomad node pool apply -description "GPU nodes" <name>
+
+  List node pools:
+
+      $ nomad node pool list
+
+  View the details of a node pool:
+
+      $ nomad node pool info <name>
+
+  Delete a node pool:
+
+      $ nomad node pool delete <name>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodePoolCommand) Synopsis() string {
+	return "Interact with node pools"
+}
+
+func (c *NodePoolCommand) Name() string { return "node pool" }
+
+func (c *NodePoolCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
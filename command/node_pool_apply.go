@@ -0,0 +1,277 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/hashicorp/nomad/api"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/mitchellh/mapstructure"
+	"github.com/posener/complete"
+)
+
+type NodePoolApplyCommand struct {
+	Meta
+}
+
+func (c *NodePoolApplyCommand) Help() string {
+	helpText := `
+Usage: nomad node pool apply [options] <input>
+
+  Apply is used to create or update a node pool. The specification file
+  will be read from stdin by specifying "-", otherwise a path to the file is
+  expected.
+
+  Instead of a file, you may instead pass the node pool name to create
+  or update as the only argument.
+
+  If ACLs are enabled, this command requires a management ACL token.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Apply Options:
+
+  -description
+    An optional description for the node pool.
+
+  -scheduler-algorithm
+    Overrides the cluster scheduler algorithm for jobs in the node pool. Must
+    be one of "binpack" or "spread".
+
+  -memory-oversubscription
+    Overrides whether memory oversubscription is enabled for jobs in the node
+    pool.
+
+  -json
+    Parse the input as a JSON node pool specification.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodePoolApplyCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-description":             complete.PredictAnything,
+			"-scheduler-algorithm":     complete.PredictSet(string(api.SchedulerAlgorithmBinpack), string(api.SchedulerAlgorithmSpread)),
+			"-memory-oversubscription": complete.PredictSet("true", "false"),
+			"-json":                    complete.PredictNothing,
+		})
+}
+
+func (c *NodePoolApplyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictOr(
+		complete.PredictFiles("*.hcl"),
+		complete.PredictFiles("*.json"),
+	)
+}
+
+func (c *NodePoolApplyCommand) Synopsis() string {
+	return "Create or update a node pool"
+}
+
+func (c *NodePoolApplyCommand) Name() string { return "node pool apply" }
+
+func (c *NodePoolApplyCommand) Run(args []string) int {
+	var jsonInput bool
+	var description, algorithm, memOversub *string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.Var((flaghelper.FuncVar)(func(s string) error {
+		description = &s
+		return nil
+	}), "description", "")
+	flags.Var((flaghelper.FuncVar)(func(s string) error {
+		algorithm = &s
+		return nil
+	}), "scheduler-algorithm", "")
+	flags.Var((flaghelper.FuncVar)(func(s string) error {
+		memOversub = &s
+		return nil
+	}), "memory-oversubscription", "")
+	flags.BoolVar(&jsonInput, "json", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we get exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <input>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	file := args[0]
+	var rawPool []byte
+	var err error
+	var pool *api.NodePool
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	if fi, err := os.Stat(file); (file == "-" || err == nil) && !fi.IsDir() {
+		if description != nil || algorithm != nil || memOversub != nil {
+			c.Ui.Warn("Flags are ignored when a file is specified!")
+		}
+
+		if file == "-" {
+			rawPool, err = ioutil.ReadAll(os.Stdin)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Failed to read stdin: %v", err))
+				return 1
+			}
+		} else {
+			rawPool, err = ioutil.ReadFile(file)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Failed to read file: %v", err))
+				return 1
+			}
+		}
+		if jsonInput {
+			var jsonSpec api.NodePool
+			dec := json.NewDecoder(bytes.NewBuffer(rawPool))
+			if err := dec.Decode(&jsonSpec); err != nil {
+				c.Ui.Error(fmt.Sprintf("Failed to parse node pool: %v", err))
+				return 1
+			}
+			pool = &jsonSpec
+		} else {
+			hclSpec, err := parseNodePoolSpec(rawPool)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error parsing node pool specification: %s", err))
+				return 1
+			}
+
+			pool = hclSpec
+		}
+	} else {
+		name := args[0]
+
+		// Validate we have at-least a name
+		if name == "" {
+			c.Ui.Error("Node pool name required")
+			return 1
+		}
+
+		// Lookup the given node pool
+		pool, _, err = client.NodePools().Info(name, nil)
+		if err != nil && !strings.Contains(err.Error(), "404") {
+			c.Ui.Error(fmt.Sprintf("Error looking up node pool: %s", err))
+			return 1
+		}
+
+		if pool == nil {
+			pool = &api.NodePool{
+				Name: name,
+			}
+		}
+
+		// Add what is set
+		if description != nil {
+			pool.Description = *description
+		}
+		if algorithm != nil || memOversub != nil {
+			if pool.SchedulerConfiguration == nil {
+				pool.SchedulerConfiguration = &api.NodePoolSchedulerConfiguration{}
+			}
+			if algorithm != nil {
+				pool.SchedulerConfiguration.SchedulerAlgorithm = api.SchedulerAlgorithm(*algorithm)
+			}
+			if memOversub != nil {
+				enabled, err := strconv.ParseBool(*memOversub)
+				if err != nil {
+					c.Ui.Error(fmt.Sprintf("Invalid -memory-oversubscription value: %s", err))
+					return 1
+				}
+				pool.SchedulerConfiguration.MemoryOversubscriptionEnabled = &enabled
+			}
+		}
+	}
+
+	_, err = client.NodePools().Register(pool, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error applying node pool: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully applied node pool %q!", pool.Name))
+
+	return 0
+}
+
+// parseNodePoolSpec is used to parse the node pool specification from HCL
+func parseNodePoolSpec(input []byte) (*api.NodePool, error) {
+	root, err := hcl.ParseBytes(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Top-level item should be a list
+	list, ok := root.Node.(*ast.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("error parsing: root should be an object")
+	}
+
+	// Decode the full thing into a map[string]interface for ease
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, list); err != nil {
+		return nil, err
+	}
+
+	delete(m, "scheduler_config")
+	delete(m, "meta")
+
+	// Decode the rest
+	var spec api.NodePool
+	if err := mapstructure.WeakDecode(m, &spec); err != nil {
+		return nil, err
+	}
+
+	if sObj := list.Filter("scheduler_config"); len(sObj.Items) > 0 {
+		for _, o := range sObj.Elem().Items {
+			ot, ok := o.Val.(*ast.ObjectType)
+			if !ok {
+				break
+			}
+			var config map[string]interface{}
+			if err := hcl.DecodeObject(&config, ot.List); err != nil {
+				return nil, err
+			}
+			var opts api.NodePoolSchedulerConfiguration
+			if err := mapstructure.WeakDecode(config, &opts); err != nil {
+				return nil, err
+			}
+			spec.SchedulerConfiguration = &opts
+			break
+		}
+	}
+
+	if metaO := list.Filter("meta"); len(metaO.Items) > 0 {
+		for _, o := range metaO.Elem().Items {
+			var m map[string]interface{}
+			if err := hcl.DecodeObject(&m, o.Val); err != nil {
+				return nil, err
+			}
+			if err := mapstructure.WeakDecode(m, &spec.Meta); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &spec, nil
+}
@@ -0,0 +1,106 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+var _ cli.Command = &NodePoolApplyCommand{}
+
+func TestNodePoolApplyCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &NodePoolApplyCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	must.One(t, cmd.Run([]string{"some", "bad", "args"}))
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	must.One(t, cmd.Run([]string{"-address=nope"}))
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+}
+
+func TestNodePoolApplyCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create a server
+	srv, client, url := testServer(t, true, nil)
+	defer stopTestAgent(srv)
+
+	ui := cli.NewMockUi()
+	cmd := &NodePoolApplyCommand{Meta: Meta{Ui: ui}}
+
+	// Create a node pool using flags
+	must.Zero(t, cmd.Run([]string{
+		"-address=" + url,
+		"-description=GPU nodes",
+		"-scheduler-algorithm=spread",
+		"-memory-oversubscription=true",
+		"gpu",
+	}))
+	must.StrContains(t, ui.OutputWriter.String(), `Successfully applied node pool "gpu"!`)
+	ui.OutputWriter.Reset()
+
+	pool, _, err := client.NodePools().Info("gpu", nil)
+	must.NoError(t, err)
+	must.Eq(t, "GPU nodes", pool.Description)
+	must.NotNil(t, pool.SchedulerConfiguration)
+	must.Eq(t, api.SchedulerAlgorithmSpread, pool.SchedulerConfiguration.SchedulerAlgorithm)
+	must.True(t, *pool.SchedulerConfiguration.MemoryOversubscriptionEnabled)
+
+	// Update the node pool using an HCL specification
+	spec := `
+Name        = "gpu"
+Description = "Updated GPU nodes"
+
+scheduler_config {
+  scheduler_algorithm = "binpack"
+}
+
+meta {
+  team = "ml"
+}
+`
+	specFile := filepath.Join(t.TempDir(), "pool.hcl")
+	must.NoError(t, os.WriteFile(specFile, []byte(spec), 0o644))
+
+	must.Zero(t, cmd.Run([]string{"-address=" + url, specFile}))
+
+	pool, _, err = client.NodePools().Info("gpu", nil)
+	must.NoError(t, err)
+	must.Eq(t, "Updated GPU nodes", pool.Description)
+	must.Eq(t, api.SchedulerAlgorithmBinpack, pool.SchedulerConfiguration.SchedulerAlgorithm)
+	must.Nil(t, pool.SchedulerConfiguration.MemoryOversubscriptionEnabled)
+	must.Eq(t, map[string]string{"team": "ml"}, pool.Meta)
+
+	// Ensure the pool is listed and can be detailed
+	listUI := cli.NewMockUi()
+	listCmd := &NodePoolListCommand{Meta: Meta{Ui: listUI}}
+	must.Zero(t, listCmd.Run([]string{"-address=" + url}))
+	must.StrContains(t, listUI.OutputWriter.String(), "gpu")
+	must.StrContains(t, listUI.OutputWriter.String(), "default")
+
+	infoUI := cli.NewMockUi()
+	infoCmd := &NodePoolInfoCommand{Meta: Meta{Ui: infoUI}}
+	must.Zero(t, infoCmd.Run([]string{"-address=" + url, "gpu"}))
+	must.StrContains(t, infoUI.OutputWriter.String(), "Updated GPU nodes")
+	must.StrContains(t, infoUI.OutputWriter.String(), "binpack")
+	must.StrContains(t, infoUI.OutputWriter.String(), "team")
+
+	// Delete the node pool
+	deleteUI := cli.NewMockUi()
+	deleteCmd := &NodePoolDeleteCommand{Meta: Meta{Ui: deleteUI}}
+	must.Zero(t, deleteCmd.Run([]string{"-address=" + url, "gpu"}))
+	must.StrContains(t, deleteUI.OutputWriter.String(), `Successfully deleted node pool "gpu"!`)
+
+	// Built-in node pools cannot be deleted
+	must.One(t, deleteCmd.Run([]string{"-address=" + url, "default"}))
+	must.StrContains(t, deleteUI.ErrorWriter.String(), "built-in")
+}
@@ -537,6 +537,13 @@ func (c *NodeStatusCommand) formatNode(client *api.Client, node *api.Node) int {
 	// Emit node events
 	c.outputNodeStatusEvents(node)
 
+	// In verbose mode, show the physical capacity of the node separately from
+	// the capacity which may be allocated when it is oversubscribed
+	if c.verbose {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Resource Capacity[reset]"))
+		c.Ui.Output(formatList(getResourceCapacity(node)))
+	}
+
 	// Get list of running allocations on the node
 	allocatedResources := getAllocatedResources(client, runningAllocs, node)
 	c.Ui.Output(c.Colorize().Color("\n[bold]Allocated Resources[reset]"))
@@ -908,8 +915,8 @@ func getRunningAllocs(client *api.Client, nodeID string) ([]*api.Allocation, err
 
 // getAllocatedResources returns the resource usage of the node.
 func getAllocatedResources(client *api.Client, runningAllocs []*api.Allocation, node *api.Node) []string {
-	// Compute the total
-	total := computeNodeTotalResources(node)
+	// Compute the total, including any oversubscription
+	total := computeNodeAllocatableResources(node)
 
 	// Get Resources
 	var cpu, mem, disk int
@@ -948,6 +955,58 @@ func computeNodeTotalResources(node *api.Node) api.Resources {
 	return total
 }
 
+// computeNodeAllocatableResources returns the resources the scheduler may
+// allocate on the node, which are the total allocatable resources scaled by
+// the oversubscription ratios of the node.
+func computeNodeAllocatableResources(node *api.Node) api.Resources {
+	total := computeNodeTotalResources(node)
+	cpuRatio, memRatio := nodeOversubscriptionRatios(node)
+	total.CPU = pointer.Of(int(float64(*total.CPU) * cpuRatio))
+	total.MemoryMB = pointer.Of(int(float64(*total.MemoryMB) * memRatio))
+	return total
+}
+
+// nodeOversubscriptionRatios returns the factors by which the CPU and memory
+// of the node may be oversubscribed, defaulting to 1.
+func nodeOversubscriptionRatios(node *api.Node) (cpu, memory float64) {
+	cpu, memory = 1, 1
+	if node.NodeResources == nil {
+		return
+	}
+	if r := node.NodeResources.CpuOversubscriptionRatio; r > 1 {
+		cpu = r
+	}
+	if r := node.NodeResources.MemoryOversubscriptionRatio; r > 1 {
+		memory = r
+	}
+	return
+}
+
+// getResourceCapacity returns the physical, reserved and allocatable CPU and
+// memory of the node, along with their oversubscription ratios.
+func getResourceCapacity(node *api.Node) []string {
+	res := node.Reserved
+	if res == nil {
+		res = &api.Resources{}
+	}
+	allocatable := computeNodeAllocatableResources(node)
+	cpuRatio, memRatio := nodeOversubscriptionRatios(node)
+
+	resources := make([]string, 3)
+	resources[0] = "Resource|Physical|Reserved|Oversubscription|Allocatable"
+	resources[1] = fmt.Sprintf("CPU|%d MHz|%d MHz|%vx|%d MHz",
+		*node.Resources.CPU,
+		*res.CPU,
+		cpuRatio,
+		*allocatable.CPU)
+	resources[2] = fmt.Sprintf("Memory|%s|%s|%vx|%s",
+		humanize.IBytes(uint64(*node.Resources.MemoryMB*bytesPerMegabyte)),
+		humanize.IBytes(uint64(*res.MemoryMB*bytesPerMegabyte)),
+		memRatio,
+		humanize.IBytes(uint64(*allocatable.MemoryMB*bytesPerMegabyte)))
+	return resources
+}
+
 // getActualResources returns the actual resource usage of the allocations.
 func getActualResources(client *api.Client, runningAllocs []*api.Allocation, node *api.Node) ([]string, error) {
 	// Compute the total
@@ -9,10 +9,12 @@ import (
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/mitchellh/cli"
 	"github.com/posener/complete"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNodeStatusCommand_Implements(t *testing.T) {
@@ -294,3 +296,29 @@ func TestNodeStatusCommand_FormatDrain(t *testing.T) {
 	node.DrainStrategy.IgnoreSystemJobs = true
 	assert.Equal("true; 1970-01-01T00:00:01Z deadline; ignoring system jobs", formatDrain(node))
 }
+
+func TestNodeStatusCommand_GetResourceCapacity(t *testing.T) {
+	ci.Parallel(t)
+
+	node := &api.Node{
+		Resources: &api.Resources{
+			CPU:      pointer.Of(4000),
+			MemoryMB: pointer.Of(2048),
+			DiskMB:   pointer.Of(10000),
+		},
+		Reserved: &api.Resources{
+			CPU:      pointer.Of(1000),
+			MemoryMB: pointer.Of(1024),
+			DiskMB:   pointer.Of(0),
+		},
+		NodeResources: &api.NodeResources{
+			CpuOversubscriptionRatio: 2.5,
+		},
+	}
+
+	require.Equal(t, []string{
+		"Resource|Physical|Reserved|Oversubscription|Allocatable",
+		"CPU|4000 MHz|1000 MHz|2.5x|7500 MHz",
+		"Memory|2.0 GiB|1.0 GiB|1x|1.0 GiB",
+	}, getResourceCapacity(node))
+}
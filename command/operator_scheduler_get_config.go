@@ -2,6 +2,7 @@ package command
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/mitchellh/cli"
@@ -83,8 +84,36 @@ func (o *OperatorSchedulerGetConfig) Run(args []string) int {
 		fmt.Sprintf("Preemption Service Scheduler|%v", schedConfig.PreemptionConfig.ServiceSchedulerEnabled),
 		fmt.Sprintf("Preemption Batch Scheduler|%v", schedConfig.PreemptionConfig.BatchSchedulerEnabled),
 		fmt.Sprintf("Preemption SysBatch Scheduler|%v", schedConfig.PreemptionConfig.SysBatchSchedulerEnabled),
+		fmt.Sprintf("Eval Broker Fair Share|%v", schedConfig.EvalBrokerFairShare != nil && schedConfig.EvalBrokerFairShare.Enabled),
 		fmt.Sprintf("Modify Index|%v", resp.SchedulerConfig.ModifyIndex),
 	}))
+
+	if schedConfig.EvalBrokerFairShare != nil && len(schedConfig.EvalBrokerFairShare.Weights) > 0 {
+		namespaces := make([]string, 0, len(schedConfig.EvalBrokerFairShare.Weights))
+		for namespace := range schedConfig.EvalBrokerFairShare.Weights {
+			namespaces = append(namespaces, namespace)
+		}
+		sort.Strings(namespaces)
+
+		weights := make([]string, len(namespaces)+1)
+		weights[0] = "Namespace|Weight"
+		for i, namespace := range namespaces {
+			weights[i+1] = fmt.Sprintf("%s|%d", namespace, schedConfig.EvalBrokerFairShare.Weights[namespace])
+		}
+		o.Ui.Output(o.Colorize().Color("\n[bold]Namespace Weights[reset]"))
+		o.Ui.Output(formatList(weights))
+	}
+
+	if len(schedConfig.NodeScorers) > 0 {
+		scorers := make([]string, len(schedConfig.NodeScorers)+1)
+		scorers[0] = "Name|Expression|Weight|Min|Max"
+		for i, scorer := range schedConfig.NodeScorers {
+			scorers[i+1] = fmt.Sprintf("%s|%s|%d|%v|%v",
+				scorer.Name, scorer.Expression, scorer.Weight, scorer.Min, scorer.Max)
+		}
+		o.Ui.Output(o.Colorize().Color("\n[bold]Node Scorers[reset]"))
+		o.Ui.Output(formatList(scorers))
+	}
 	return 0
 }
 
@@ -2,6 +2,7 @@ package command
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
@@ -28,6 +29,9 @@ type OperatorSchedulerSetConfig struct {
 	preemptServiceScheduler  flagHelper.BoolValue
 	preemptSysBatchScheduler flagHelper.BoolValue
 	preemptSystemScheduler   flagHelper.BoolValue
+	nodeScores               flagHelper.StringFlag
+	evalBrokerFairShare      flagHelper.BoolValue
+	namespaceWeights         flagHelper.StringFlag
 }
 
 func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
@@ -45,6 +49,9 @@ func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
 			"-preempt-service-scheduler":  complete.PredictSet("true", "false"),
 			"-preempt-sysbatch-scheduler": complete.PredictSet("true", "false"),
 			"-preempt-system-scheduler":   complete.PredictSet("true", "false"),
+			"-node-score":                 complete.PredictAnything,
+			"-eval-broker-fair-share":     complete.PredictSet("true", "false"),
+			"-namespace-weight":           complete.PredictAnything,
 		},
 	)
 }
@@ -69,6 +76,11 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	flags.Var(&o.preemptServiceScheduler, "preempt-service-scheduler", "")
 	flags.Var(&o.preemptSysBatchScheduler, "preempt-sysbatch-scheduler", "")
 	flags.Var(&o.preemptSystemScheduler, "preempt-system-scheduler", "")
+	o.nodeScores = nil
+	flags.Var(&o.nodeScores, "node-score", "")
+	flags.Var(&o.evalBrokerFairShare, "eval-broker-fair-share", "")
+	o.namespaceWeights = nil
+	flags.Var(&o.namespaceWeights, "namespace-weight", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -89,6 +101,19 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 		return 1
 	}
 
+	// Parse the node scorers before making any requests, so that typos are
+	// reported early.
+	nodeScorers, err := parseNodeScorers(o.nodeScores)
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error parsing node-score value: %v", err))
+		return 1
+	}
+	namespaceWeights, err := parseNamespaceWeights(o.namespaceWeights)
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error parsing namespace-weight value: %v", err))
+		return 1
+	}
+
 	// Convert the check index string and handle any errors before adding this
 	// to our request. This parsing handles empty values correctly.
 	checkIndex, _, err := parseCheckIndex(o.checkIndex)
@@ -131,6 +156,20 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	o.preemptServiceScheduler.Merge(&schedulerConfig.PreemptionConfig.ServiceSchedulerEnabled)
 	o.preemptSysBatchScheduler.Merge(&schedulerConfig.PreemptionConfig.SysBatchSchedulerEnabled)
 	o.preemptSystemScheduler.Merge(&schedulerConfig.PreemptionConfig.SystemSchedulerEnabled)
+	if len(o.nodeScores) > 0 {
+		schedulerConfig.NodeScorers = nodeScorers
+	}
+	fairShare := schedulerConfig.EvalBrokerFairShare
+	if fairShare == nil {
+		fairShare = &api.EvalBrokerFairShare{}
+	}
+	o.evalBrokerFairShare.Merge(&fairShare.Enabled)
+	if len(o.namespaceWeights) > 0 {
+		fairShare.Weights = namespaceWeights
+	}
+	if fairShare.Enabled || len(fairShare.Weights) > 0 {
+		schedulerConfig.EvalBrokerFairShare = fairShare
+	}
 
 	// Check-and-set the new configuration.
 	result, _, err := client.Operator().SchedulerCASConfiguration(schedulerConfig, nil)
@@ -205,6 +244,102 @@ Scheduler Set Config Options:
   -preempt-system-scheduler=[true|false]
     Specifies whether preemption for system jobs is enabled. Note that if this
     is set to true, then system jobs can preempt any other jobs.
+
+  -node-score=<spec>
+    Specifies a weighted expression over node attributes or metadata that is
+    used to score nodes, in the form
+    "name=<name>,expression=<expression>,weight=<weight>,min=<min>,max=<max>".
+    For example, "name=cost,expression=${meta.cost_per_hour},weight=-50,min=0,max=10"
+    prefers nodes with a lower cost. The value of the expression is scaled to
+    the range between min and max and multiplied by the weight, which must be
+    between -100 and 100. This flag may be repeated, and replaces all of the
+    current node scorers. Pass an empty value to remove all node scorers.
+
+  -eval-broker-fair-share=[true|false]
+    When set to true, the eval broker dequeues the evaluations of each
+    scheduler fairly across namespaces in proportion to their weights, instead
+    of strictly by priority. Priority still orders the evaluations within a
+    namespace.
+
+  -namespace-weight=<namespace>=<weight>
+    Specifies the weight of a namespace for fair-share dequeuing, between 1 and
+    1000. Namespaces without a weight have a weight of 1. This flag may be
+    repeated, and replaces all of the current weights. Pass an empty value to
+    remove all weights.
 `
 	return strings.TrimSpace(helpText)
 }
+
+// parseNodeScorers parses the values of the -node-score flag. A single empty
+// value returns an empty set of node scorers.
+func parseNodeScorers(specs []string) ([]*api.NodeScorer, error) {
+	scorers := []*api.NodeScorer{}
+	for _, spec := range specs {
+		if spec == "" {
+			if len(specs) > 1 {
+				return nil, fmt.Errorf("empty value can't be combined with other values")
+			}
+			break
+		}
+
+		scorer := &api.NodeScorer{}
+		for _, field := range strings.Split(spec, ",") {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, fmt.Errorf("%q is not in the form key=value", field)
+			}
+
+			switch key = strings.TrimSpace(key); key {
+			case "name":
+				scorer.Name = value
+			case "expression":
+				scorer.Expression = value
+			case "weight":
+				weight, err := strconv.ParseInt(value, 10, 8)
+				if err != nil {
+					return nil, fmt.Errorf("invalid weight %q: %v", value, err)
+				}
+				scorer.Weight = int8(weight)
+			case "min", "max":
+				f, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s %q: %v", key, value, err)
+				}
+				if key == "min" {
+					scorer.Min = f
+				} else {
+					scorer.Max = f
+				}
+			default:
+				return nil, fmt.Errorf("unknown key %q", key)
+			}
+		}
+		scorers = append(scorers, scorer)
+	}
+	return scorers, nil
+}
+
+// parseNamespaceWeights parses the values of the -namespace-weight flag. A
+// single empty value returns an empty set of weights.
+func parseNamespaceWeights(specs []string) (map[string]int, error) {
+	weights := map[string]int{}
+	for _, spec := range specs {
+		if spec == "" {
+			if len(specs) > 1 {
+				return nil, fmt.Errorf("empty value can't be combined with other values")
+			}
+			break
+		}
+
+		namespace, value, ok := strings.Cut(spec, "=")
+		if !ok || namespace == "" {
+			return nil, fmt.Errorf("%q is not in the form <namespace>=<weight>", spec)
+		}
+		weight, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight %q: %v", value, err)
+		}
+		weights[namespace] = weight
+	}
+	return weights, nil
+}
@@ -97,6 +97,88 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 	require.Contains(t, ui.OutputWriter.String(), "Scheduler configuration updated!")
 	ui.ErrorWriter.Reset()
 	ui.OutputWriter.Reset()
+
+	// Set the node scorers.
+	require.EqualValues(t, 0, c.Run([]string{
+		"-address=" + addr,
+		"-node-score=name=cost,expression=${meta.cost_per_hour},weight=-50,min=0,max=10",
+		"-node-score=name=freq,expression=${attr.cpu.frequency},weight=20,min=1000,max=4000",
+	}))
+	require.Contains(t, ui.OutputWriter.String(), "Scheduler configuration updated!")
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
+	scorersConfig, _, err := srv.Client().Operator().SchedulerGetConfiguration(nil)
+	require.NoError(t, err)
+	require.Equal(t, []*api.NodeScorer{
+		{Name: "cost", Expression: "${meta.cost_per_hour}", Weight: -50, Min: 0, Max: 10},
+		{Name: "freq", Expression: "${attr.cpu.frequency}", Weight: 20, Min: 1000, Max: 4000},
+	}, scorersConfig.SchedulerConfig.NodeScorers)
+
+	// Invalid node scorers are rejected by the server.
+	require.EqualValues(t, 1, c.Run([]string{
+		"-address=" + addr,
+		"-node-score=name=cost,expression=${meta.cost_per_hour},weight=-50,min=10,max=0",
+	}))
+	require.Contains(t, ui.ErrorWriter.String(), "max (0) must be greater than min (10)")
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
+	// Malformed node scorers are rejected by the command.
+	require.EqualValues(t, 1, c.Run([]string{
+		"-address=" + addr,
+		"-node-score=name=cost,ratio=2",
+	}))
+	require.Contains(t, ui.ErrorWriter.String(), `unknown key "ratio"`)
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
+	// Remove the node scorers.
+	require.EqualValues(t, 0, c.Run([]string{"-address=" + addr, "-node-score="}))
+	require.Contains(t, ui.OutputWriter.String(), "Scheduler configuration updated!")
+
+	clearedConfig, _, err := srv.Client().Operator().SchedulerGetConfiguration(nil)
+	require.NoError(t, err)
+	require.Empty(t, clearedConfig.SchedulerConfig.NodeScorers)
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
+	// Enable fair-share dequeuing with namespace weights.
+	require.EqualValues(t, 0, c.Run([]string{
+		"-address=" + addr,
+		"-eval-broker-fair-share=true",
+		"-namespace-weight=prod=4",
+		"-namespace-weight=dev=1",
+	}))
+	require.Contains(t, ui.OutputWriter.String(), "Scheduler configuration updated!")
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
+	fairShareConfig, _, err := srv.Client().Operator().SchedulerGetConfiguration(nil)
+	require.NoError(t, err)
+	require.Equal(t, &api.EvalBrokerFairShare{
+		Enabled: true,
+		Weights: map[string]int{"prod": 4, "dev": 1},
+	}, fairShareConfig.SchedulerConfig.EvalBrokerFairShare)
+
+	// Invalid weights are rejected by the server.
+	require.EqualValues(t, 1, c.Run([]string{"-address=" + addr, "-namespace-weight=prod=0"}))
+	require.Contains(t, ui.ErrorWriter.String(), `weight 0 of namespace "prod" must be between 1 and 1000`)
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
+	// Malformed weights are rejected by the command.
+	require.EqualValues(t, 1, c.Run([]string{"-address=" + addr, "-namespace-weight=prod"}))
+	require.Contains(t, ui.ErrorWriter.String(), `"prod" is not in the form <namespace>=<weight>`)
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
+	// Disabling fair-share dequeuing keeps the weights.
+	require.EqualValues(t, 0, c.Run([]string{"-address=" + addr, "-eval-broker-fair-share=false"}))
+	disabledConfig, _, err := srv.Client().Operator().SchedulerGetConfiguration(nil)
+	require.NoError(t, err)
+	require.False(t, disabledConfig.SchedulerConfig.EvalBrokerFairShare.Enabled)
+	require.Len(t, disabledConfig.SchedulerConfig.EvalBrokerFairShare.Weights, 2)
 }
 
 func schedulerConfigEquals(t *testing.T, expected, actual *api.SchedulerConfiguration) {
@@ -105,4 +187,5 @@ func schedulerConfigEquals(t *testing.T, expected, actual *api.SchedulerConfigur
 	require.Equal(t, expected.MemoryOversubscriptionEnabled, actual.MemoryOversubscriptionEnabled)
 	require.Equal(t, expected.PauseEvalBroker, actual.PauseEvalBroker)
 	require.Equal(t, expected.PreemptionConfig, actual.PreemptionConfig)
+	require.Equal(t, expected.NodeScorers, actual.NodeScorers)
 }
@@ -0,0 +1,432 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/command/agent"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/hashicorp/nomad/helper/raftutil"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler/simulator"
+	"github.com/posener/complete"
+)
+
+type OperatorSimulateCommand struct {
+	Meta
+	JobGetter
+}
+
+func (c *OperatorSimulateCommand) Help() string {
+	helpText := `
+Usage: nomad operator simulate [options] <snapshot> [<job file>...]
+
+  Simulates scheduling against the cluster state in a snapshot file, such as
+  one written by "nomad operator snapshot save". The simulation applies
+  synthetic node changes, registers the given jobs, and then runs the
+  schedulers against the resulting state to report the placements, placement
+  failures and utilization of the cluster.
+
+  The simulation runs entirely offline and makes no changes to the cluster.
+
+  To simulate adding 20 nodes like the existing nodes of class "large",
+  draining the nodes in rack "r1", and then submitting two jobs:
+
+    $ nomad operator simulate \
+        -add-nodes="class=large,count=20" \
+        -drain='Meta.rack == "r1"' \
+        backup.snap api.nomad.hcl cache.nomad.hcl
+
+Simulate Options:
+
+  -add-nodes=<spec>
+    Adds synthetic nodes copied from an existing node in the snapshot. The
+    spec is a comma separated list of key=value pairs. The "count" key sets
+    the number of nodes to add. The "class" key copies a ready node of the
+    given node class, and the "node" key copies the node with the given ID or
+    ID prefix. One of "class" or "node" must be set. May be specified
+    multiple times.
+
+  -drain=<filter>
+    Drains the nodes matching the filter expression, for example
+    'Meta.rack == "r1"'. The allocations on the drained nodes are all
+    migrated at once. May be specified multiple times.
+
+  -var 'key=value'
+    Variable for template, can be used multiple times.
+
+  -var-file=path
+    Path to HCL2 file containing user variables.
+
+  -json
+    Output the simulation report in its JSON format.
+
+  -t
+    Format and display the simulation report using a Go template.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorSimulateCommand) Synopsis() string {
+	return "Simulate scheduling against a snapshot of the cluster state"
+}
+
+func (c *OperatorSimulateCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-add-nodes": complete.PredictAnything,
+		"-drain":     complete.PredictAnything,
+		"-var":       complete.PredictAnything,
+		"-var-file":  complete.PredictFiles("*.var"),
+		"-json":      complete.PredictNothing,
+		"-t":         complete.PredictAnything,
+		"-verbose":   complete.PredictNothing,
+	}
+}
+
+func (c *OperatorSimulateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("*")
+}
+
+func (c *OperatorSimulateCommand) Name() string { return "operator simulate" }
+
+func (c *OperatorSimulateCommand) Run(args []string) int {
+	var json, verbose bool
+	var tmpl string
+	var addNodes, drains flaghelper.StringFlag
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetNone)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.Var(&addNodes, "add-nodes", "")
+	flags.Var(&drains, "drain", "")
+	flags.Var(&c.JobGetter.Vars, "var", "")
+	flags.Var(&c.JobGetter.VarFiles, "var-file", "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) < 1 {
+		c.Ui.Error("This command takes at least one argument: <snapshot> [<job file>...]")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	nodeSpecs, err := parseSimulateAddNodes(addNodes)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid -add-nodes value: %s", err))
+		return 1
+	}
+	drainFilters := make([]*bexpr.Evaluator, len(drains))
+	for i, expr := range drains {
+		drainFilters[i], err = bexpr.CreateEvaluator(expr)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Invalid -drain filter %q: %s", expr, err))
+			return 1
+		}
+	}
+
+	// Parse the jobs before loading the snapshot, which may be slow
+	jobs := make([]*structs.Job, 0, len(args)-1)
+	for _, path := range args[1:] {
+		job, err := c.simulatedJob(path)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error parsing job file %s: %s", path, err))
+			return 1
+		}
+		jobs = append(jobs, job)
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error opening snapshot file: %s", err))
+		return 1
+	}
+	defer f.Close()
+
+	store, _, err := raftutil.RestoreFromArchive(f, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to read snapshot file: %s", err))
+		return 1
+	}
+
+	sim, err := simulator.New(hclog.NewNullLogger(), store)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error starting simulation: %s", err))
+		return 1
+	}
+
+	// Find the nodes to copy and drain before changing the state
+	templates := make([]*structs.Node, len(nodeSpecs))
+	for i, spec := range nodeSpecs {
+		templates[i], err = simulateTemplateNode(store, spec)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error adding nodes: %s", err))
+			return 1
+		}
+	}
+	drainIDs, err := simulateDrainNodes(store, drainFilters)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error draining nodes: %s", err))
+		return 1
+	}
+
+	for i, spec := range nodeSpecs {
+		if _, err := sim.AddNodes(templates[i], spec.count); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error adding nodes: %s", err))
+			return 1
+		}
+	}
+	for _, nodeID := range drainIDs {
+		if err := sim.DrainNode(nodeID); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error draining node %s: %s", nodeID, err))
+			return 1
+		}
+	}
+	for _, job := range jobs {
+		if err := sim.RegisterJob(job); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error registering job: %s", err))
+			return 1
+		}
+	}
+
+	report, err := sim.Run()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error running simulation: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, report)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output(c.formatReport(report, verbose))
+	return 0
+}
+
+// simulatedJob parses, canonicalizes and validates the job file.
+func (c *OperatorSimulateCommand) simulatedJob(path string) (*structs.Job, error) {
+	apiJob, err := c.JobGetter.Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	job := agent.ApiJobToStructJob(apiJob)
+	job.Canonicalize()
+	if err := job.Validate(); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (c *OperatorSimulateCommand) formatReport(report *simulator.Report, verbose bool) string {
+	out := c.Colorize().Color("[bold]Simulation[reset]\n")
+	out += formatKV([]string{
+		fmt.Sprintf("Nodes Added|%d", report.NodesAdded),
+		fmt.Sprintf("Nodes Drained|%d", report.NodesDrained),
+		fmt.Sprintf("Jobs Registered|%d", report.JobsRegistered),
+		fmt.Sprintf("Evaluations|%d", report.Evaluations),
+	})
+
+	placements := []string{"Namespace|Job ID|Task Group|Placed|Stopped|Preempted|Failed"}
+	var failures []string
+	for _, job := range report.Jobs {
+		tgs := make([]string, 0, len(job.TaskGroups))
+		for name := range job.TaskGroups {
+			tgs = append(tgs, name)
+		}
+		sort.Strings(tgs)
+
+		for _, name := range tgs {
+			tg := job.TaskGroups[name]
+			placements = append(placements, fmt.Sprintf("%s|%s|%s|%d|%d|%d|%d",
+				job.Namespace, job.JobID, name, tg.Placed, tg.Stopped, tg.Preempted, tg.Failed))
+
+			if tg.Metrics != nil {
+				noun := "allocation"
+				if tg.Failed > 1 {
+					noun += "s"
+				}
+				failures = append(failures, fmt.Sprintf(
+					"Job %q Task Group %q (failed to place %d %s):\n%s",
+					job.JobID, name, tg.Failed, noun,
+					formatAllocMetrics(simulateAllocMetric(tg.Metrics), verbose, "  ")))
+			}
+		}
+	}
+	if len(placements) > 1 {
+		out += c.Colorize().Color("\n\n[bold]Placements[reset]\n")
+		out += formatList(placements)
+	}
+	if len(failures) > 0 {
+		out += c.Colorize().Color("\n\n[bold]Placement Failures[reset]\n")
+		out += strings.Join(failures, "\n")
+	}
+
+	out += c.Colorize().Color("\n\n[bold]Utilization[reset]\n")
+	out += formatList([]string{
+		"Metric|Before|After",
+		fmt.Sprintf("Nodes|%d|%d", report.Before.Nodes, report.After.Nodes),
+		fmt.Sprintf("CPU|%s|%s",
+			formatSimulateUsage(report.Before.CPUAllocated, report.Before.CPU, "MHz"),
+			formatSimulateUsage(report.After.CPUAllocated, report.After.CPU, "MHz")),
+		fmt.Sprintf("Memory|%s|%s",
+			formatSimulateUsage(report.Before.MemoryAllocatedMB, report.Before.MemoryMB, "MiB"),
+			formatSimulateUsage(report.After.MemoryAllocatedMB, report.After.MemoryMB, "MiB")),
+	})
+	return out
+}
+
+// simulateNodeSpec is the parsed value of an -add-nodes flag.
+type simulateNodeSpec struct {
+	count  int
+	class  string
+	nodeID string
+}
+
+// parseSimulateAddNodes parses the values of the -add-nodes flag, which are
+// in the form "class=large,count=20".
+func parseSimulateAddNodes(specs []string) ([]*simulateNodeSpec, error) {
+	var result []*simulateNodeSpec
+	for _, spec := range specs {
+		parsed := &simulateNodeSpec{}
+		for _, field := range strings.Split(spec, ",") {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, fmt.Errorf("%q is not in the form key=value", field)
+			}
+
+			switch key = strings.TrimSpace(key); key {
+			case "count":
+				count, err := strconv.Atoi(value)
+				if err != nil || count < 1 {
+					return nil, fmt.Errorf("invalid count %q", value)
+				}
+				parsed.count = count
+			case "class":
+				parsed.class = value
+			case "node":
+				parsed.nodeID = value
+			default:
+				return nil, fmt.Errorf("unknown key %q", key)
+			}
+		}
+
+		switch {
+		case parsed.count == 0:
+			return nil, fmt.Errorf("%q is missing a count", spec)
+		case parsed.class == "" && parsed.nodeID == "":
+			return nil, fmt.Errorf("%q must set one of class or node", spec)
+		case parsed.class != "" && parsed.nodeID != "":
+			return nil, fmt.Errorf("%q can't set both class and node", spec)
+		}
+		result = append(result, parsed)
+	}
+	return result, nil
+}
+
+// simulateTemplateNode returns the node to copy for the -add-nodes spec.
+func simulateTemplateNode(store *state.StateStore, spec *simulateNodeSpec) (*structs.Node, error) {
+	if spec.nodeID != "" {
+		iter, err := store.NodesByIDPrefix(nil, spec.nodeID)
+		if err != nil {
+			return nil, err
+		}
+		var nodes []*structs.Node
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			nodes = append(nodes, raw.(*structs.Node))
+		}
+		switch len(nodes) {
+		case 0:
+			return nil, fmt.Errorf("no node with prefix %q found", spec.nodeID)
+		case 1:
+			return nodes[0], nil
+		default:
+			return nil, fmt.Errorf("prefix %q matched multiple nodes", spec.nodeID)
+		}
+	}
+
+	iter, err := store.Nodes(nil)
+	if err != nil {
+		return nil, err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if node.NodeClass == spec.class && node.Status == structs.NodeStatusReady {
+			return node, nil
+		}
+	}
+	return nil, fmt.Errorf("no ready node of class %q found", spec.class)
+}
+
+// simulateDrainNodes returns the IDs of the nodes matching any of the -drain
+// filters.
+func simulateDrainNodes(store *state.StateStore, filters []*bexpr.Evaluator) ([]string, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	iter, err := store.Nodes(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodeIDs []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		for _, filter := range filters {
+			match, err := filter.Evaluate(node)
+			if err != nil {
+				return nil, err
+			}
+			if match {
+				nodeIDs = append(nodeIDs, node.ID)
+				break
+			}
+		}
+	}
+	return nodeIDs, nil
+}
+
+// simulateAllocMetric converts the placement metrics of the simulation so
+// they can be displayed like the metrics of a real evaluation.
+func simulateAllocMetric(m *structs.AllocMetric) *api.AllocationMetric {
+	return &api.AllocationMetric{
+		NodesEvaluated:     m.NodesEvaluated,
+		NodesFiltered:      m.NodesFiltered,
+		NodesAvailable:     m.NodesAvailable,
+		ClassFiltered:      m.ClassFiltered,
+		ConstraintFiltered: m.ConstraintFiltered,
+		NodesExhausted:     m.NodesExhausted,
+		ClassExhausted:     m.ClassExhausted,
+		DimensionExhausted: m.DimensionExhausted,
+		QuotaExhausted:     m.QuotaExhausted,
+	}
+}
+
+func formatSimulateUsage(used, total int64, unit string) string {
+	percent := 0.0
+	if total > 0 {
+		percent = float64(used) / float64(total) * 100
+	}
+	return fmt.Sprintf("%d/%d %s (%.1f%%)", used, total, unit, percent)
+}
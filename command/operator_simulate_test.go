@@ -0,0 +1,147 @@
+package command
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler/simulator"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+const simulateJobHCL = `
+job "simulated" {
+  datacenters = ["dc1"]
+
+  group "web" {
+    count = 3
+
+    task "web" {
+      driver = "exec"
+
+      config {
+        command = "/bin/date"
+      }
+
+      resources {
+        cpu    = 500
+        memory = 256
+      }
+    }
+  }
+}
+`
+
+func TestOperatorSimulateCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorSimulateCommand{}
+}
+
+func TestOperatorSimulateCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	node := mock.Node()
+	snapPath := generateSnapshotFile(t, func(srv *agent.TestAgent, _ *api.Client, _ string) {
+		state := srv.Agent.Server().State()
+		require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+	})
+
+	jobPath := filepath.Join(t.TempDir(), "simulated.nomad.hcl")
+	require.NoError(t, os.WriteFile(jobPath, []byte(simulateJobHCL), 0600))
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorSimulateCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{
+		"-add-nodes", "class=linux-medium-pci,count=2",
+		"-drain", `ID == "` + node.ID + `"`,
+		snapPath, jobPath,
+	})
+	require.Zero(t, code, ui.ErrorWriter.String())
+
+	out := ui.OutputWriter.String()
+	require.Regexp(t, `Nodes Added\s+= 2`, out)
+	require.Regexp(t, `Nodes Drained\s+= 1`, out)
+	require.Regexp(t, `Jobs Registered\s+= 1`, out)
+	require.Regexp(t, `default\s+simulated\s+web\s+3\s+0\s+0\s+0`, out)
+	require.Regexp(t, `Nodes\s+1\s+2`, out)
+
+	// The report can be output as JSON
+	ui = cli.NewMockUi()
+	cmd = &OperatorSimulateCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-json", "-drain", `ID == "` + node.ID + `"`, snapPath, jobPath})
+	require.Zero(t, code, ui.ErrorWriter.String())
+
+	var report simulator.Report
+	require.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &report))
+	require.Equal(t, 1, report.NodesDrained)
+	require.Len(t, report.Jobs, 1)
+	require.Equal(t, 3, report.Jobs[0].TaskGroups["web"].Failed)
+	require.NotNil(t, report.Jobs[0].TaskGroups["web"].Metrics)
+}
+
+func TestOperatorSimulateCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		args   []string
+		expect string
+	}{
+		{
+			name:   "no snapshot",
+			args:   []string{},
+			expect: "This command takes at least one argument",
+		},
+		{
+			name:   "missing count",
+			args:   []string{"-add-nodes", "class=large", "backup.snap"},
+			expect: `"class=large" is missing a count`,
+		},
+		{
+			name:   "missing template",
+			args:   []string{"-add-nodes", "count=2", "backup.snap"},
+			expect: "must set one of class or node",
+		},
+		{
+			name:   "class and node",
+			args:   []string{"-add-nodes", "count=2,class=large,node=abc", "backup.snap"},
+			expect: "can't set both class and node",
+		},
+		{
+			name:   "invalid count",
+			args:   []string{"-add-nodes", "count=-1,class=large", "backup.snap"},
+			expect: `invalid count "-1"`,
+		},
+		{
+			name:   "unknown key",
+			args:   []string{"-add-nodes", "count=1,rack=r1", "backup.snap"},
+			expect: `unknown key "rack"`,
+		},
+		{
+			name:   "invalid drain filter",
+			args:   []string{"-drain", "Meta.rack ==", "backup.snap"},
+			expect: "Invalid -drain filter",
+		},
+		{
+			name:   "missing snapshot file",
+			args:   []string{filepath.Join(t.TempDir(), "backup.snap")},
+			expect: "no such file",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &OperatorSimulateCommand{Meta: Meta{Ui: ui}}
+			require.Equal(t, 1, cmd.Run(tc.args))
+			require.Contains(t, ui.ErrorWriter.String(), tc.expect)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type SystemRebalanceCommand struct {
+	Meta
+}
+
+func (c *SystemRebalanceCommand) Help() string {
+	helpText := `
+Usage: nomad system rebalance [options]
+
+  Create a rebalance evaluation for every running service job in the cluster.
+  The scheduler migrates the allocations of each job that would score
+  meaningfully better on another node, respecting the migrate block of each
+  task group. The IDs of the evaluations created are printed to the screen.
+  See the "nomad job rebalance" command for details.
+
+  If ACLs are enabled, this option requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Rebalance Options:
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *SystemRebalanceCommand) Synopsis() string {
+	return "Migrate allocations of all service jobs to better scoring nodes"
+}
+
+func (c *SystemRebalanceCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *SystemRebalanceCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *SystemRebalanceCommand) Name() string { return "system rebalance" }
+
+func (c *SystemRebalanceCommand) Run(args []string) int {
+	var verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if args = flags.Args(); len(args) > 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	evalIDs, _, err := client.System().Rebalance(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error rebalancing jobs: %s", err))
+		return 1
+	}
+
+	if len(evalIDs) == 0 {
+		c.Ui.Output("No service jobs to rebalance")
+		return 0
+	}
+
+	c.Ui.Output(fmt.Sprintf("Created %d rebalance evaluations:", len(evalIDs)))
+	for _, evalID := range evalIDs {
+		c.Ui.Output(fmt.Sprintf("  %s", limit(evalID, length)))
+	}
+	return 0
+}
@@ -0,0 +1,38 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemRebalanceCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &SystemRebalanceCommand{}
+}
+
+func TestSystemRebalanceCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &SystemRebalanceCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "No service jobs to rebalance")
+	ui.OutputWriter.Reset()
+
+	job := mock.Job()
+	require.NoError(t, srv.Agent.Server().State().UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	code = cmd.Run([]string{"-address=" + url, "-verbose"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Created 1 rebalance evaluations")
+}
@@ -41,6 +41,18 @@ Usage: nomad var <subcommand> [options] [args]
 
       $ nomad var list <prefix>
 
+  Display the tracked versions of a secure variable:
+
+      $ nomad var history <path>
+
+  Restore a secure variable to a prior version:
+
+      $ nomad var restore -version <version> <path>
+
+  Run a process while holding the lock on a secure variable:
+
+      $ nomad var lock <path> <child command>
+
   Please see the individual subcommand help for detailed usage information.
 `
 
@@ -0,0 +1,126 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type VarHistoryCommand struct {
+	Meta
+}
+
+func (c *VarHistoryCommand) Help() string {
+	helpText := `
+Usage: nomad var history [options] <path>
+
+  History is used to display the tracked versions of a secure variable. Nomad
+  retains a bounded number of prior versions of each secure variable, which
+  can be restored with the "nomad var restore" command.
+
+  If ACLs are enabled, this command requires a token with the ` + "`read`" + `
+  capability for the secure variable path.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+History Options:
+
+  -json
+    Output the secure variable versions in JSON format.
+
+  -t
+    Format and display the secure variable versions using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarHistoryCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-t":    complete.PredictAnything,
+		},
+	)
+}
+
+func (c *VarHistoryCommand) AutocompleteArgs() complete.Predictor {
+	return SecureVariablePathPredictor(c.Meta.Client)
+}
+
+func (c *VarHistoryCommand) Synopsis() string {
+	return "Display the tracked versions of a secure variable"
+}
+
+func (c *VarHistoryCommand) Name() string { return "var history" }
+
+func (c *VarHistoryCommand) Run(args []string) int {
+	var json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	path := args[0]
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	versions, _, err := client.SecureVariables().History(path, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving secure variable history: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, versions)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output(formatVarVersions(versions))
+	return 0
+}
+
+func formatVarVersions(versions []*api.SecureVariableMetadata) string {
+	if len(versions) == 0 {
+		return msgSecureVariableNotFound
+	}
+
+	rows := make([]string, len(versions)+1)
+	rows[0] = "Version|Modify Index|Last Updated"
+	for i, v := range versions {
+		rows[i+1] = fmt.Sprintf("%d|%d|%s",
+			v.Version,
+			v.ModifyIndex,
+			time.Unix(0, v.ModifyTime),
+		)
+	}
+	return formatList(rows)
+}
@@ -0,0 +1,121 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type VarLockCommand struct {
+	Meta
+}
+
+func (c *VarLockCommand) Help() string {
+	helpText := `
+Usage: nomad var lock [options] <path> <child command> [args]
+
+  Lock is used to acquire the lock on a secure variable and run a child
+  process while holding it. The lock is renewed in the background for as long
+  as the child process runs, and released once it exits. If the lock is held
+  by another holder, the command waits until it becomes available. If the lock
+  is lost, the child process is terminated.
+
+  The secure variable is created if it does not exist. Locked variables can
+  only be modified or deleted by the lock holder.
+
+  If ACLs are enabled, this command requires a token with the ` + "`write`" + `
+  capability for the secure variable path.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Lock Options:
+
+  -ttl
+    The TTL of the lock. The lock is released if it is not renewed within the
+    TTL. Defaults to the server's default lock TTL of 15s.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarLockCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-ttl": complete.PredictAnything,
+		},
+	)
+}
+
+func (c *VarLockCommand) AutocompleteArgs() complete.Predictor {
+	return SecureVariablePathPredictor(c.Meta.Client)
+}
+
+func (c *VarLockCommand) Synopsis() string {
+	return "Run a process while holding the lock on a secure variable"
+}
+
+func (c *VarLockCommand) Name() string { return "var lock" }
+
+func (c *VarLockCommand) Run(args []string) int {
+	var ttl time.Duration
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.DurationVar(&ttl, "ttl", 0, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got a path and a child command
+	args = flags.Args()
+	if len(args) < 2 {
+		c.Ui.Error("This command takes at least two arguments: <path> <child command>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	path, childArgs := args[0], args[1:]
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	locker := client.SecureVariables().NewLocker(path, ttl, nil)
+
+	exitCode := 0
+	err = locker.Hold(context.Background(), func(ctx context.Context) error {
+		cmd := exec.CommandContext(ctx, childArgs[0], childArgs[1:]...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		err := cmd.Run()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && ctx.Err() == nil {
+			exitCode = exitErr.ExitCode()
+			return nil
+		}
+		return err
+	})
+
+	switch {
+	case errors.Is(err, api.ErrLockLost):
+		c.Ui.Error(fmt.Sprintf("Lock on secure variable %q was lost; child process terminated", path))
+		return 1
+	case err != nil:
+		c.Ui.Error(fmt.Sprintf("Error running child process with lock held: %s", err))
+		return 1
+	}
+	return exitCode
+}
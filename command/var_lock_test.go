@@ -0,0 +1,58 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestVarLockCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarLockCommand{}
+}
+
+func TestVarLockCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &VarLockCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on missing child command
+	code := cmd.Run([]string{"some/path"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes at least two arguments")
+	ui.ErrorWriter.Reset()
+
+	// Fails on an invalid TTL
+	code = cmd.Run([]string{"-ttl", "forever", "some/path", "true"})
+	must.One(t, code)
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	code = cmd.Run([]string{"-address=nope", "some/path", "true"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error running child process")
+}
+
+func TestVarLockCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &VarLockCommand{Meta: Meta{Ui: ui}}
+
+	// The exit code of the child process is returned
+	code := cmd.Run([]string{"-address=" + url, "some/path", "sh", "-c", "exit 3"})
+	must.Eq(t, 3, code)
+
+	// The lock is released once the child exits, but the variable is kept
+	sv, _, err := client.SecureVariables().Read("some/path", nil)
+	must.NoError(t, err)
+	must.Nil(t, sv.Lock)
+
+	// A successful child process exits cleanly
+	code = cmd.Run([]string{"-address=" + url, "-ttl=10s", "some/path", "true"})
+	must.Zero(t, code)
+}
@@ -0,0 +1,125 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type VarRestoreCommand struct {
+	Meta
+}
+
+func (c *VarRestoreCommand) Help() string {
+	helpText := `
+Usage: nomad var restore [options] -version <version> <path>
+
+  Restore is used to restore the contents of a secure variable to a prior
+  version. The restored contents are written as a new version of the secure
+  variable, so the restore itself can be undone. Use the "nomad var history"
+  command to list the versions which can be restored.
+
+  If ACLs are enabled, this command requires a token with the ` + "`read`" + `
+  and ` + "`write`" + ` capabilities for the secure variable path.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Restore Options:
+
+  -version
+    The version of the secure variable to restore. Required.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarRestoreCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-version": complete.PredictAnything,
+		},
+	)
+}
+
+func (c *VarRestoreCommand) AutocompleteArgs() complete.Predictor {
+	return SecureVariablePathPredictor(c.Meta.Client)
+}
+
+func (c *VarRestoreCommand) Synopsis() string {
+	return "Restore a secure variable to a prior version"
+}
+
+func (c *VarRestoreCommand) Name() string { return "var restore" }
+
+func (c *VarRestoreCommand) Run(args []string) int {
+	var version int64
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.Int64Var(&version, "version", -1, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	path := args[0]
+
+	if version < 0 {
+		c.Ui.Error("A version to restore must be provided with -version")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	prior, _, err := client.SecureVariables().ReadVersion(path, uint64(version), nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving version %d of secure variable: %s", version, err))
+		return 1
+	}
+
+	current, _, err := client.SecureVariables().Read(path, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving secure variable: %s", err))
+		return 1
+	}
+
+	// Write the prior contents over the current version, checking that the
+	// variable hasn't been modified since it was read.
+	sv := &api.SecureVariable{
+		Namespace:   current.Namespace,
+		Path:        current.Path,
+		ModifyIndex: current.ModifyIndex,
+		Items:       prior.Items,
+	}
+	out, _, err := client.SecureVariables().CheckedUpdate(sv, nil)
+	if err != nil {
+		var casErr api.ErrCASConflict
+		if errors.As(err, &casErr) && casErr.Conflict.Lock != nil {
+			c.Ui.Error(fmt.Sprintf("Error restoring secure variable: %q is locked", path))
+			return 1
+		}
+		c.Ui.Error(fmt.Sprintf("Error restoring secure variable: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Restored secure variable %q to version %d as version %d",
+		path, version, out.Version))
+	return 0
+}
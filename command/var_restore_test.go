@@ -0,0 +1,78 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestVarRestoreCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarRestoreCommand{}
+	var _ cli.Command = &VarHistoryCommand{}
+}
+
+func TestVarRestoreCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &VarRestoreCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on missing path
+	code := cmd.Run([]string{"-version=1"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes one argument")
+	ui.ErrorWriter.Reset()
+
+	// Fails on missing version
+	code = cmd.Run([]string{"some/path"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "A version to restore must be provided")
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	code = cmd.Run([]string{"-address=nope", "-version=1", "some/path"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error retrieving version 1 of secure variable")
+}
+
+func TestVarRestoreCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	// Write two versions of a variable
+	sv := api.NewSecureVariable("some/path")
+	sv.Items["value"] = "first"
+	_, _, err := client.SecureVariables().Create(sv, nil)
+	must.NoError(t, err)
+	sv.Items["value"] = "second"
+	_, _, err = client.SecureVariables().Update(sv, nil)
+	must.NoError(t, err)
+
+	// Both versions are listed in the history
+	ui := cli.NewMockUi()
+	history := &VarHistoryCommand{Meta: Meta{Ui: ui}}
+	code := history.Run([]string{"-address=" + url, "some/path"})
+	must.Zero(t, code)
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "Version")
+	must.StrContains(t, out, "Modify Index")
+
+	versions, _, err := client.SecureVariables().History("some/path", nil)
+	must.NoError(t, err)
+	must.Len(t, 2, versions)
+
+	// Restore the first version, which is written as a new version
+	cmd := &VarRestoreCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-version=0", "some/path"})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), `Restored secure variable "some/path" to version 0 as version 2`)
+
+	restored, _, err := client.SecureVariables().Read("some/path", nil)
+	must.NoError(t, err)
+	must.Eq(t, "first", restored.Items["value"])
+	must.Eq(t, uint64(2), restored.Version)
+}
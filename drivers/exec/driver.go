@@ -12,10 +12,13 @@ import (
 	"github.com/hashicorp/consul-template/signals"
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/lib/cgutil"
+	"github.com/hashicorp/nomad/client/lib/ociimage"
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
 	"github.com/hashicorp/nomad/drivers/shared/eventer"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
 	"github.com/hashicorp/nomad/drivers/shared/resolvconf"
+	"github.com/hashicorp/nomad/helper/escapingfs"
+	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/plugins/base"
@@ -78,17 +81,37 @@ var (
 			hclspec.NewAttr("allow_caps", "list(string)", false),
 			hclspec.NewLiteral(capabilities.HCLSpecLiteral),
 		),
+		"allow_ulimits": hclspec.NewDefault(
+			hclspec.NewAttr("allow_ulimits", "list(string)", false),
+			hclspec.NewLiteral(executor.UlimitsHCLSpecLiteral),
+		),
+		"allow_sysctls": hclspec.NewDefault(
+			hclspec.NewAttr("allow_sysctls", "list(string)", false),
+			hclspec.NewLiteral(executor.SysctlsHCLSpecLiteral),
+		),
+		"default_seccomp_profile": hclspec.NewAttr("default_seccomp_profile", "string", false),
+		"default_unveil":          hclspec.NewAttr("default_unveil", "list(string)", false),
+		"user_namespaces": hclspec.NewDefault(
+			hclspec.NewAttr("user_namespaces", "bool", false),
+			hclspec.NewLiteral("false"),
+		),
 	})
 
 	// taskConfigSpec is the hcl specification for the driver config section of
 	// a task within a job. It is returned in the TaskConfigSchema RPC
 	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
-		"command":  hclspec.NewAttr("command", "string", true),
-		"args":     hclspec.NewAttr("args", "list(string)", false),
-		"pid_mode": hclspec.NewAttr("pid_mode", "string", false),
-		"ipc_mode": hclspec.NewAttr("ipc_mode", "string", false),
-		"cap_add":  hclspec.NewAttr("cap_add", "list(string)", false),
-		"cap_drop": hclspec.NewAttr("cap_drop", "list(string)", false),
+		"command":         hclspec.NewAttr("command", "string", true),
+		"args":            hclspec.NewAttr("args", "list(string)", false),
+		"pid_mode":        hclspec.NewAttr("pid_mode", "string", false),
+		"ipc_mode":        hclspec.NewAttr("ipc_mode", "string", false),
+		"cap_add":         hclspec.NewAttr("cap_add", "list(string)", false),
+		"cap_drop":        hclspec.NewAttr("cap_drop", "list(string)", false),
+		"seccomp_profile": hclspec.NewAttr("seccomp_profile", "string", false),
+		"unveil":          hclspec.NewAttr("unveil", "list(string)", false),
+		"image":           hclspec.NewAttr("image", "string", false),
+		"ulimit":          hclspec.NewAttr("ulimit", "list(map(string))", false),
+		"sysctl":          hclspec.NewAttr("sysctl", "list(map(string))", false),
+		"readonly_rootfs": hclspec.NewAttr("readonly_rootfs", "bool", false),
 	})
 
 	// driverCapabilities represents the RPC response for what features are
@@ -132,6 +155,10 @@ type Driver struct {
 	// whether it has been successful
 	fingerprintSuccess *bool
 	fingerprintLock    sync.Mutex
+
+	// checkpoint is whether CRIU is available to checkpoint tasks, unset
+	// until first checked
+	checkpoint *bool
 }
 
 // Config is the driver configuration set by the SetConfig RPC call
@@ -151,6 +178,28 @@ type Config struct {
 	// AllowCaps configures which Linux Capabilities are enabled for tasks
 	// running on this node.
 	AllowCaps []string `codec:"allow_caps"`
+
+	// AllowUlimits configures which ulimits tasks running on this node may
+	// set. Defaults to executor.UlimitsHCLSpecLiteral.
+	AllowUlimits []string `codec:"allow_ulimits"`
+
+	// AllowSysctls configures which sysctls tasks running on this node may
+	// set. Entries ending in "*" allow every sysctl with that prefix.
+	// Defaults to executor.SysctlsHCLSpecLiteral.
+	AllowSysctls []string `codec:"allow_sysctls"`
+
+	// DefaultSeccompProfile is the path on the host of a Docker compatible
+	// seccomp profile applied to tasks which do not set their own.
+	DefaultSeccompProfile string `codec:"default_seccomp_profile"`
+
+	// DefaultUnveil is a list of "mode:path" rules granting filesystem access
+	// to every task. When set, tasks are restricted with landlock to these
+	// paths and those of their own unveil rules.
+	DefaultUnveil []string `codec:"default_unveil"`
+
+	// UserNamespaces opts the tasks of this driver into the user namespaces
+	// allocated by the client when it is configured with subordinate IDs.
+	UserNamespaces bool `codec:"user_namespaces"`
 }
 
 func (c *Config) validate() error {
@@ -171,6 +220,10 @@ func (c *Config) validate() error {
 		return fmt.Errorf("allow_caps configured with capabilities not supported by system: %s", badCaps)
 	}
 
+	if err := executor.ValidateUnveil(c.DefaultUnveil); err != nil {
+		return fmt.Errorf("default_unveil: %v", err)
+	}
+
 	return nil
 }
 
@@ -195,6 +248,29 @@ type TaskConfig struct {
 
 	// CapDrop is a set of linux capabilities to disable.
 	CapDrop []string `codec:"cap_drop"`
+
+	// SeccompProfile is the path of a Docker compatible seccomp profile
+	// within the task directory.
+	SeccompProfile string `codec:"seccomp_profile"`
+
+	// Unveil is a list of "mode:path" rules granting filesystem access to
+	// the task, which is otherwise denied once any rule is set.
+	Unveil []string `codec:"unveil"`
+
+	// Image is the path of an OCI image layout within the task directory,
+	// either a directory or a tarball, used as the root filesystem of the
+	// task instead of its chroot.
+	Image string `codec:"image"`
+
+	// Ulimit maps resources to the "soft:hard" limits set for the task.
+	Ulimit hclutils.MapStrStr `codec:"ulimit"`
+
+	// Sysctl is a set of namespaced kernel parameters to set for the task.
+	Sysctl hclutils.MapStrStr `codec:"sysctl"`
+
+	// ReadonlyRootfs mounts the root filesystem of the task read-only,
+	// leaving only the task directories writable.
+	ReadonlyRootfs bool `codec:"readonly_rootfs"`
 }
 
 func (tc *TaskConfig) validate() error {
@@ -220,6 +296,10 @@ func (tc *TaskConfig) validate() error {
 		return fmt.Errorf("cap_drop configured with capabilities not supported by system: %s", badDrops)
 	}
 
+	if err := executor.ValidateUnveil(tc.Unveil); err != nil {
+		return fmt.Errorf("unveil: %v", err)
+	}
+
 	return nil
 }
 
@@ -300,7 +380,21 @@ func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
 // Capabilities is returned by the Capabilities RPC and indicates what
 // optional features this driver supports
 func (d *Driver) Capabilities() (*drivers.Capabilities, error) {
-	return driverCapabilities, nil
+	caps := *driverCapabilities
+	caps.Checkpoint = d.checkpointSupported()
+	caps.UserNamespaces = d.config.UserNamespaces
+	return &caps, nil
+}
+
+// checkpointSupported returns whether tasks can be checkpointed, as of the
+// latest fingerprint.
+func (d *Driver) checkpointSupported() bool {
+	d.fingerprintLock.Lock()
+	defer d.fingerprintLock.Unlock()
+	if d.checkpoint == nil {
+		d.checkpoint = pointer.Of(executor.CheckpointSupported())
+	}
+	return *d.checkpoint
 }
 
 func (d *Driver) Fingerprint(ctx context.Context) (<-chan *drivers.Fingerprint, error) {
@@ -366,6 +460,19 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 	}
 
 	fp.Attributes["driver.exec"] = pstructs.NewBoolAttribute(true)
+	fp.Attributes["driver.exec.seccomp"] = pstructs.NewBoolAttribute(executor.SeccompSupported())
+	if abi := executor.LandlockABI(); abi > 0 {
+		fp.Attributes["driver.exec.landlock"] = pstructs.NewBoolAttribute(true)
+		fp.Attributes["driver.exec.landlock.abi"] = pstructs.NewIntAttribute(int64(abi), "")
+	} else {
+		fp.Attributes["driver.exec.landlock"] = pstructs.NewBoolAttribute(false)
+	}
+
+	checkpoint := executor.CheckpointSupported()
+	d.fingerprintLock.Lock()
+	d.checkpoint = pointer.Of(checkpoint)
+	d.fingerprintLock.Unlock()
+	fp.Attributes["driver.exec.checkpoint"] = pstructs.NewBoolAttribute(checkpoint)
 	d.setFingerprintSuccess()
 	return fp
 }
@@ -436,6 +543,46 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
 	}
 
+	seccompProfile, err := d.seccompProfile(cfg.TaskDir().Dir, driverConfig.SeccompProfile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ulimits, err := executor.ParseUlimits(driverConfig.Ulimit, d.config.AllowUlimits)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := executor.ValidateSysctls(driverConfig.Sysctl, d.config.AllowSysctls); err != nil {
+		return nil, nil, err
+	}
+	modeIPC := executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC)
+	if err := executor.ValidateSysctlNamespaces(driverConfig.Sysctl, cfg.NetworkIsolation != nil, modeIPC); err != nil {
+		return nil, nil, err
+	}
+
+	if cfg.DNS != nil {
+		dnsMount, err := resolvconf.GenerateDNSMount(cfg.TaskDir().Dir, cfg.DNS)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to build mount for resolv.conf: %v", err)
+		}
+		cfg.Mounts = append(cfg.Mounts, dnsMount)
+	}
+
+	caps, err := capabilities.Calculate(
+		capabilities.NomadDefaults(), d.config.AllowCaps, driverConfig.CapAdd, driverConfig.CapDrop,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	d.logger.Debug("task capabilities", "capabilities", caps)
+
+	// The image is mounted after everything that can fail before the
+	// executor is launched, so that failures don't leave it mounted
+	rootfs, err := d.mountImage(cfg, driverConfig.Image)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	d.logger.Info("starting task", "driver_cfg", hclog.Fmt("%+v", driverConfig))
 	handle := drivers.NewTaskHandle(taskHandleVersion)
 	handle.Config = cfg
@@ -451,6 +598,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		d.logger.With("task_name", handle.Config.Name, "alloc_id", handle.Config.AllocID),
 		d.nomadConfig, executorConfig)
 	if err != nil {
+		d.unmountImage(cfg)
 		return nil, nil, fmt.Errorf("failed to create executor: %v", err)
 	}
 
@@ -459,22 +607,6 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		user = "nobody"
 	}
 
-	if cfg.DNS != nil {
-		dnsMount, err := resolvconf.GenerateDNSMount(cfg.TaskDir().Dir, cfg.DNS)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to build mount for resolv.conf: %v", err)
-		}
-		cfg.Mounts = append(cfg.Mounts, dnsMount)
-	}
-
-	caps, err := capabilities.Calculate(
-		capabilities.NomadDefaults(), d.config.AllowCaps, driverConfig.CapAdd, driverConfig.CapDrop,
-	)
-	if err != nil {
-		return nil, nil, err
-	}
-	d.logger.Debug("task capabilities", "capabilities", caps)
-
 	execCmd := &executor.ExecCommand{
 		Cmd:              driverConfig.Command,
 		Args:             driverConfig.Args,
@@ -489,14 +621,25 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		Mounts:           cfg.Mounts,
 		Devices:          cfg.Devices,
 		NetworkIsolation: cfg.NetworkIsolation,
+		UserNamespace:    cfg.UserNamespace,
 		ModePID:          executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
-		ModeIPC:          executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC),
+		ModeIPC:          modeIPC,
 		Capabilities:     caps,
+		SeccompProfile:   seccompProfile,
+		Unveil:           d.unveil(driverConfig.Unveil),
+		Rootfs:           rootfs,
+		Ulimits:          ulimits,
+		Sysctls:          driverConfig.Sysctl,
+		ReadonlyRootfs:   driverConfig.ReadonlyRootfs,
+	}
+	if cfg.Restore {
+		execCmd.RestoreDir = cfg.TaskDir().CheckpointDir
 	}
 
 	ps, err := exec.Launch(execCmd)
 	if err != nil {
 		pluginClient.Kill()
+		d.unmountImage(cfg)
 		return nil, nil, fmt.Errorf("failed to launch command with executor: %v", err)
 	}
 
@@ -521,6 +664,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		d.logger.Error("failed to start task, error setting driver state", "error", err)
 		_ = exec.Shutdown("", 0)
 		pluginClient.Kill()
+		d.unmountImage(cfg)
 		return nil, nil, fmt.Errorf("failed to set driver state: %v", err)
 	}
 
@@ -529,6 +673,87 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	return handle, nil, nil
 }
 
+// seccompProfile returns the contents of the seccomp profile configured for a
+// task, falling back to the plugin's default profile.
+func (d *Driver) seccompProfile(taskDir, path string) (string, error) {
+	if path != "" {
+		return executor.ReadSeccompProfile(taskDir, path)
+	}
+	return executor.ReadSeccompProfile("", d.config.DefaultSeccompProfile)
+}
+
+// unveil returns the plugin's default unveil rules followed by those
+// configured for a task.
+func (d *Driver) unveil(rules []string) []string {
+	if len(d.config.DefaultUnveil) == 0 && len(rules) == 0 {
+		return nil
+	}
+	unveil := make([]string, 0, len(d.config.DefaultUnveil)+len(rules))
+	unveil = append(unveil, d.config.DefaultUnveil...)
+	return append(unveil, rules...)
+}
+
+// imageCache returns the layer cache of the client running the task.
+func imageCache(logger hclog.Logger, cfg *drivers.TaskConfig) *ociimage.Cache {
+	return ociimage.NewCache(logger, ociimage.CacheDir(filepath.Dir(cfg.AllocDir)))
+}
+
+// imageDir returns the directory within the task directory holding the
+// root filesystem mounted from the task's image and the changes made to it.
+func imageDir(cfg *drivers.TaskConfig) string {
+	return filepath.Join(cfg.TaskDir().Dir, ".image")
+}
+
+// mountImage unpacks the OCI image layout at path within the task directory
+// and mounts it as the root filesystem of the task, returning its path. No
+// root filesystem is returned if the task doesn't use an image.
+func (d *Driver) mountImage(cfg *drivers.TaskConfig, path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	taskDir := cfg.TaskDir().Dir
+	escapes, err := escapingfs.PathEscapesAllocDir(taskDir, "", path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve image %q: %v", path, err)
+	}
+	if escapes {
+		return "", fmt.Errorf("image %q escapes the task directory", path)
+	}
+
+	// the image is owned by the root user of the task's user namespace
+	var shift ociimage.IDShift
+	if ns := cfg.UserNamespace; ns != nil {
+		shift = ociimage.IDShift{UID: ns.HostUID, GID: ns.HostGID}
+	}
+
+	cache := imageCache(d.logger, cfg)
+	img, err := cache.Unpack(cfg.ID, taskDir, filepath.Join(taskDir, path), shift)
+	if err != nil {
+		_ = cache.Release(cfg.ID)
+		return "", fmt.Errorf("failed to unpack image: %v", err)
+	}
+	rootfs, err := img.Mount(imageDir(cfg))
+	if err != nil {
+		_ = cache.Release(cfg.ID)
+		return "", err
+	}
+	d.logger.Debug("mounted task image", "image", img.Digest, "rootfs", rootfs)
+	return rootfs, nil
+}
+
+// unmountImage unmounts the root filesystem of the task's image, if any, and
+// releases its layers so they can be garbage collected.
+func (d *Driver) unmountImage(cfg *drivers.TaskConfig) {
+	if err := ociimage.Unmount(imageDir(cfg)); err != nil {
+		d.logger.Warn("failed to unmount task image", "task_id", cfg.ID, "error", err)
+		return
+	}
+	if err := imageCache(d.logger, cfg).Release(cfg.ID); err != nil {
+		d.logger.Warn("failed to release task image", "task_id", cfg.ID, "error", err)
+	}
+}
+
 func (d *Driver) WaitTask(ctx context.Context, taskID string) (<-chan *drivers.ExitResult, error) {
 	handle, ok := d.tasks.Get(taskID)
 	if !ok {
@@ -571,6 +796,18 @@ func (d *Driver) StopTask(taskID string, timeout time.Duration, signal string) e
 		return drivers.ErrTaskNotFound
 	}
 
+	// the executor stops the task once checkpointed, and if it fails to
+	// checkpoint the task is stopped with its kill signal
+	if killSignal, ok := drivers.ParseCheckpointSignal(signal); ok {
+		signal = killSignal
+		dir := handle.taskConfig.TaskDir().CheckpointDir
+		if err := handle.exec.Checkpoint(dir); err != nil {
+			d.logger.Warn("failed to checkpoint task, stopping it", "task_id", taskID, "error", err)
+		} else {
+			d.logger.Debug("checkpointed task", "task_id", taskID, "checkpoint", dir)
+		}
+	}
+
 	if err := handle.exec.Shutdown(signal, timeout); err != nil {
 		if handle.pluginClient.Exited() {
 			return nil
@@ -621,6 +858,8 @@ func (d *Driver) DestroyTask(taskID string, force bool) error {
 	// workaround for the case where DestroyTask was issued on task restart
 	d.resetCgroup(handle)
 
+	d.unmountImage(handle.taskConfig)
+
 	d.tasks.Delete(taskID)
 	return nil
 }
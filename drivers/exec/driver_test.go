@@ -110,6 +110,9 @@ func TestExecDriver_Fingerprint(t *testing.T) {
 	case finger := <-fingerCh:
 		require.Equal(drivers.HealthStateHealthy, finger.Health)
 		require.True(finger.Attributes["driver.exec"].GetBool())
+		checkpoint, ok := finger.Attributes["driver.exec.checkpoint"].GetBool()
+		require.True(ok)
+		require.Equal(executor.CheckpointSupported(), checkpoint)
 	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
 		require.Fail("timeout receiving fingerprint")
 	}
@@ -516,6 +519,104 @@ func TestExecDriver_Start_Wait_AllocDir(t *testing.T) {
 	require.NoError(t, harness.DestroyTask(task.ID, true))
 }
 
+func TestExecDriver_Capabilities_UserNamespaces(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	// tasks are only remapped when the plugin opts into user namespaces
+	caps, err := harness.Capabilities()
+	require.NoError(t, err)
+	require.False(t, caps.UserNamespaces)
+
+	var data []byte
+	config := &Config{
+		DefaultModePID: executor.IsolationModePrivate,
+		DefaultModeIPC: executor.IsolationModePrivate,
+		UserNamespaces: true,
+	}
+	require.NoError(t, basePlug.MsgPackEncode(&data, config))
+	require.NoError(t, harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	caps, err = harness.Capabilities()
+	require.NoError(t, err)
+	require.True(t, caps.UserNamespaces)
+}
+
+func TestExecDriver_UserNamespace(t *testing.T) {
+	ci.Parallel(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	// user namespaces require private pid and ipc namespaces
+	config := &Config{
+		DefaultModePID: executor.IsolationModePrivate,
+		DefaultModeIPC: executor.IsolationModePrivate,
+		UserNamespaces: true,
+	}
+	var data []byte
+	require.NoError(t, basePlug.MsgPackEncode(&data, config))
+	require.NoError(t, harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	allocID := uuid.Generate()
+	task := &drivers.TaskConfig{
+		AllocID:       allocID,
+		ID:            uuid.Generate(),
+		Name:          "userns",
+		User:          "root",
+		Resources:     testResources(allocID, "userns"),
+		UserNamespace: &drivers.UserNamespace{HostUID: 200000, HostGID: 300000, Size: 65536},
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	// like the client's alloc dir, the root of the task must be traversable
+	// by the users of the namespace
+	require.NoError(t, os.Chmod(filepath.Dir(task.AllocDir), 0711))
+
+	tc := &TaskConfig{
+		Command: "/bin/bash",
+		Args:    []string{"-c", "cat /proc/self/uid_map > /alloc/uid_map"},
+	}
+	require.NoError(t, task.EncodeConcreteDriverConfig(&tc))
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(t, err)
+	require.NotNil(t, handle)
+
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(t, err)
+	select {
+	case res := <-waitCh:
+		require.True(t, res.Successful(), "task should have exited successfully: %v", res)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail(t, "timeout waiting for task")
+	}
+
+	// The root user of the task is mapped to the start of the range
+	outputFile := filepath.Join(task.TaskDir().SharedAllocDir, "uid_map")
+	act, err := ioutil.ReadFile(outputFile)
+	require.NoError(t, err)
+	require.Equal(t, []string{"0", "200000", "65536"}, strings.Fields(string(act)))
+
+	fi, err := os.Stat(outputFile)
+	require.NoError(t, err)
+	stat := fi.Sys().(*syscall.Stat_t)
+	require.Equal(t, uint32(200000), stat.Uid)
+	require.Equal(t, uint32(300000), stat.Gid)
+
+	require.NoError(t, harness.DestroyTask(task.ID, true))
+}
+
 func TestExecDriver_User(t *testing.T) {
 	ci.Parallel(t)
 	ctestutils.ExecCompatible(t)
@@ -719,11 +820,27 @@ func TestConfig_ParseAllHCL(t *testing.T) {
 config {
   command = "/bin/bash"
   args = ["-c", "echo hello"]
+  seccomp_profile = "local/seccomp.json"
+  unveil = ["r:/etc", "rx:/bin"]
+  image = "local/image.tar"
+  ulimit {
+    nofile = "2048:4096"
+  }
+  sysctl {
+    net.core.somaxconn = "16384"
+  }
+  readonly_rootfs = true
 }`
 
 	expected := &TaskConfig{
-		Command: "/bin/bash",
-		Args:    []string{"-c", "echo hello"},
+		Command:        "/bin/bash",
+		Args:           []string{"-c", "echo hello"},
+		SeccompProfile: "local/seccomp.json",
+		Unveil:         []string{"r:/etc", "rx:/bin"},
+		Image:          "local/image.tar",
+		Ulimit:         map[string]string{"nofile": "2048:4096"},
+		Sysctl:         map[string]string{"net.core.somaxconn": "16384"},
+		ReadonlyRootfs: true,
 	}
 
 	var tc *TaskConfig
@@ -774,6 +891,273 @@ func TestExecDriver_NoPivotRoot(t *testing.T) {
 	require.NoError(t, harness.DestroyTask(task.ID, true))
 }
 
+func TestExecDriver_ImageEscapesTaskDir(t *testing.T) {
+	ci.Parallel(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	allocID := uuid.Generate()
+	task := &drivers.TaskConfig{
+		AllocID:   allocID,
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources(allocID, "test"),
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command: "/bin/sh",
+		Image:   "../../../image",
+	}
+	require.NoError(t, task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := harness.StartTask(task)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `image "../../../image" escapes the task directory`)
+}
+
+func TestExecDriver_UlimitsSysctlsReadonlyRootfs(t *testing.T) {
+	ci.Parallel(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	config := &Config{
+		DefaultModePID: executor.IsolationModePrivate,
+		DefaultModeIPC: executor.IsolationModePrivate,
+		AllowUlimits:   []string{"nofile"},
+		AllowSysctls:   []string{"kernel.shmmni", "net.*"},
+	}
+	var data []byte
+	require.NoError(t, basePlug.MsgPackEncode(&data, config))
+	require.NoError(t, harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	newTask := func(tc *TaskConfig) *drivers.TaskConfig {
+		allocID := uuid.Generate()
+		task := &drivers.TaskConfig{
+			AllocID:   allocID,
+			ID:        uuid.Generate(),
+			Name:      "limits",
+			Resources: testResources(allocID, "limits"),
+		}
+		require.NoError(t, task.EncodeConcreteDriverConfig(&tc))
+		return task
+	}
+
+	t.Run("ulimit not allowed", func(t *testing.T) {
+		task := newTask(&TaskConfig{
+			Command: "/bin/true",
+			Ulimit:  map[string]string{"nproc": "10"},
+		})
+		_, _, err := harness.StartTask(task)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `ulimit "nproc" is not allowed by the driver configuration`)
+	})
+
+	t.Run("sysctl not allowed", func(t *testing.T) {
+		task := newTask(&TaskConfig{
+			Command: "/bin/true",
+			Sysctl:  map[string]string{"kernel.msgmax": "16384"},
+		})
+		_, _, err := harness.StartTask(task)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `sysctl "kernel.msgmax" is not allowed by the driver configuration`)
+	})
+
+	t.Run("network sysctl on host network", func(t *testing.T) {
+		task := newTask(&TaskConfig{
+			Command: "/bin/true",
+			Sysctl:  map[string]string{"net.core.somaxconn": "16384"},
+		})
+		_, _, err := harness.StartTask(task)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `sysctl "net.core.somaxconn" requires a network namespace, but the task uses the host network`)
+	})
+
+	t.Run("ipc sysctl on host ipc", func(t *testing.T) {
+		task := newTask(&TaskConfig{
+			Command: "/bin/true",
+			ModeIPC: executor.IsolationModeHost,
+			Sysctl:  map[string]string{"kernel.shmmni": "1024"},
+		})
+		_, _, err := harness.StartTask(task)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `sysctl "kernel.shmmni" requires a private IPC namespace, but ipc_mode is "host"`)
+	})
+
+	// cleaning up the alloc dir stops the harness, so this must run last
+	t.Run("allowed", func(t *testing.T) {
+		task := newTask(&TaskConfig{
+			Command:        "/bin/bash",
+			Args:           []string{"-c", "echo $(ulimit -Sn) $(ulimit -Hn) $(cat /proc/sys/kernel/shmmni) > /alloc/out"},
+			Ulimit:         map[string]string{"nofile": "512:1024"},
+			Sysctl:         map[string]string{"kernel.shmmni": "1024"},
+			ReadonlyRootfs: true,
+		})
+		cleanup := harness.MkAllocDir(task, false)
+		defer cleanup()
+
+		_, _, err := harness.StartTask(task)
+		require.NoError(t, err)
+
+		waitCh, err := harness.WaitTask(context.Background(), task.ID)
+		require.NoError(t, err)
+		select {
+		case res := <-waitCh:
+			require.True(t, res.Successful(), "task should have exited successfully: %v", res)
+		case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+			require.Fail(t, "timeout waiting for task")
+		}
+
+		act, err := ioutil.ReadFile(filepath.Join(task.TaskDir().SharedAllocDir, "out"))
+		require.NoError(t, err)
+		require.Equal(t, []string{"512", "1024", "1024"}, strings.Fields(string(act)))
+		require.NoError(t, harness.DestroyTask(task.ID, true))
+	})
+}
+
+func TestExecDriver_CheckpointRestore(t *testing.T) {
+	ci.Parallel(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	// the capability follows the availability of CRIU
+	caps, err := harness.Capabilities()
+	require.NoError(t, err)
+	require.Equal(t, executor.CheckpointSupported(), caps.Checkpoint)
+	if !caps.Checkpoint {
+		t.Skip("criu is not available")
+	}
+
+	allocID := uuid.Generate()
+	task := &drivers.TaskConfig{
+		AllocID:   allocID,
+		ID:        uuid.Generate(),
+		Name:      "checkpoint",
+		Resources: testResources(allocID, "checkpoint"),
+	}
+
+	// the counter only lives in the memory of the task, so a task started
+	// afresh counts from 1 again
+	tc := &TaskConfig{
+		Command: "/bin/bash",
+		Args:    []string{"-c", "i=0; while true; do i=$((i+1)); echo $i > /alloc/count.tmp; mv /alloc/count.tmp /alloc/count; sleep 0.1; done"},
+	}
+	require.NoError(t, task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	count := func() int {
+		b, err := ioutil.ReadFile(filepath.Join(task.TaskDir().SharedAllocDir, "count"))
+		if err != nil {
+			return 0
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(string(b)))
+		return n
+	}
+
+	_, _, err = harness.StartTask(task)
+	require.NoError(t, err)
+	testutil.WaitForResult(func() (bool, error) {
+		if n := count(); n < 10 {
+			return false, fmt.Errorf("expected count of at least 10 but found %d", n)
+		}
+		return true, nil
+	}, func(err error) { require.NoError(t, err) })
+
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(t, err)
+	require.NoError(t, harness.StopTask(task.ID, 2*time.Second, drivers.CheckpointSignal))
+	select {
+	case <-waitCh:
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail(t, "timeout waiting for task to stop")
+	}
+	require.NoError(t, harness.DestroyTask(task.ID, true))
+	require.FileExists(t, filepath.Join(task.TaskDir().CheckpointDir, "inventory.img"))
+	checkpointed := count()
+
+	// the restored task carries on counting from the checkpoint
+	task.ID = uuid.Generate()
+	task.Restore = true
+	_, _, err = harness.StartTask(task)
+	require.NoError(t, err)
+	defer harness.DestroyTask(task.ID, true)
+
+	testutil.WaitForResult(func() (bool, error) {
+		if n := count(); n == checkpointed {
+			return false, fmt.Errorf("expected count to change from %d", checkpointed)
+		}
+		return true, nil
+	}, func(err error) { require.NoError(t, err) })
+	require.Greater(t, count(), checkpointed)
+}
+
+func TestExecDriver_CheckpointFailure_KillSignal(t *testing.T) {
+	ci.Parallel(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	allocID := uuid.Generate()
+	task := &drivers.TaskConfig{
+		AllocID:   allocID,
+		ID:        uuid.Generate(),
+		Name:      "checkpoint",
+		Resources: testResources(allocID, "checkpoint"),
+	}
+
+	// the task only exits with code 3 when it receives its kill signal
+	tc := &TaskConfig{
+		Command: "/bin/bash",
+		Args:    []string{"-c", "trap 'exit 3' USR1; touch /alloc/ready; while true; do sleep 0.1; done"},
+	}
+	require.NoError(t, task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	// a file in place of the checkpoint directory fails the checkpoint
+	require.NoError(t, os.WriteFile(task.TaskDir().CheckpointDir, []byte{}, 0600))
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(t, err)
+	defer harness.DestroyTask(task.ID, true)
+	testutil.WaitForResult(func() (bool, error) {
+		_, err := os.Stat(filepath.Join(task.TaskDir().SharedAllocDir, "ready"))
+		return err == nil, err
+	}, func(err error) { require.NoError(t, err) })
+
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(t, err)
+	require.NoError(t, harness.StopTask(task.ID, 5*time.Second, drivers.CheckpointKillSignal("SIGUSR1")))
+	select {
+	case result := <-waitCh:
+		require.Equal(t, 3, result.ExitCode)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail(t, "timeout waiting for task to stop")
+	}
+}
+
 func TestDriver_Config_validate(t *testing.T) {
 	ci.Parallel(t)
 	t.Run("pid/ipc", func(t *testing.T) {
@@ -813,6 +1197,19 @@ func TestDriver_Config_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("default_unveil", func(t *testing.T) {
+		require.NoError(t, (&Config{
+			DefaultModePID: "private",
+			DefaultModeIPC: "private",
+			DefaultUnveil:  []string{"r:/etc", "rx:/usr"},
+		}).validate())
+		require.EqualError(t, (&Config{
+			DefaultModePID: "private",
+			DefaultModeIPC: "private",
+			DefaultUnveil:  []string{"r:/etc", "rx"},
+		}).validate(), `default_unveil: unveil rule "rx" must be of the form mode:path`)
+	})
 }
 
 func TestDriver_TaskConfig_validate(t *testing.T) {
@@ -872,4 +1269,22 @@ func TestDriver_TaskConfig_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("unveil", func(t *testing.T) {
+		for _, tc := range []struct {
+			rules []string
+			exp   error
+		}{
+			{rules: nil, exp: nil},
+			{rules: []string{"r:/etc", "rwc:/tmp", "rx:/usr/bin"}, exp: nil},
+			{rules: []string{"/etc"}, exp: errors.New(`unveil: unveil rule "/etc" must be of the form mode:path`)},
+			{rules: []string{":/etc"}, exp: errors.New(`unveil: unveil rule ":/etc" must specify a mode`)},
+			{rules: []string{"rz:/etc"}, exp: errors.New(`unveil: unveil rule "rz:/etc" has invalid mode 'z', must be a combination of r, w, x and c`)},
+			{rules: []string{"r:etc"}, exp: errors.New(`unveil: unveil rule "r:etc" must use an absolute path`)},
+		} {
+			require.Equal(t, tc.exp, (&TaskConfig{
+				Unveil: tc.rules,
+			}).validate())
+		}
+	})
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/nomad/drivers/shared/eventer"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
 	"github.com/hashicorp/nomad/drivers/shared/resolvconf"
+	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/plugins/base"
 	"github.com/hashicorp/nomad/plugins/drivers"
@@ -78,6 +79,16 @@ var (
 			hclspec.NewAttr("allow_caps", "list(string)", false),
 			hclspec.NewLiteral(capabilities.HCLSpecLiteral),
 		),
+		"allow_ulimits": hclspec.NewDefault(
+			hclspec.NewAttr("allow_ulimits", "list(string)", false),
+			hclspec.NewLiteral(executor.UlimitsHCLSpecLiteral),
+		),
+		"allow_sysctls": hclspec.NewDefault(
+			hclspec.NewAttr("allow_sysctls", "list(string)", false),
+			hclspec.NewLiteral(executor.SysctlsHCLSpecLiteral),
+		),
+		"default_seccomp_profile": hclspec.NewAttr("default_seccomp_profile", "string", false),
+		"default_unveil":          hclspec.NewAttr("default_unveil", "list(string)", false),
 	})
 
 	// taskConfigSpec is the hcl specification for the driver config section of
@@ -86,15 +97,20 @@ var (
 		// It's required for either `class` or `jar_path` to be set,
 		// but that's not expressable in hclspec.  Marking both as optional
 		// and setting checking explicitly later
-		"class":       hclspec.NewAttr("class", "string", false),
-		"class_path":  hclspec.NewAttr("class_path", "string", false),
-		"jar_path":    hclspec.NewAttr("jar_path", "string", false),
-		"jvm_options": hclspec.NewAttr("jvm_options", "list(string)", false),
-		"args":        hclspec.NewAttr("args", "list(string)", false),
-		"pid_mode":    hclspec.NewAttr("pid_mode", "string", false),
-		"ipc_mode":    hclspec.NewAttr("ipc_mode", "string", false),
-		"cap_add":     hclspec.NewAttr("cap_add", "list(string)", false),
-		"cap_drop":    hclspec.NewAttr("cap_drop", "list(string)", false),
+		"class":           hclspec.NewAttr("class", "string", false),
+		"class_path":      hclspec.NewAttr("class_path", "string", false),
+		"jar_path":        hclspec.NewAttr("jar_path", "string", false),
+		"jvm_options":     hclspec.NewAttr("jvm_options", "list(string)", false),
+		"args":            hclspec.NewAttr("args", "list(string)", false),
+		"pid_mode":        hclspec.NewAttr("pid_mode", "string", false),
+		"ipc_mode":        hclspec.NewAttr("ipc_mode", "string", false),
+		"cap_add":         hclspec.NewAttr("cap_add", "list(string)", false),
+		"cap_drop":        hclspec.NewAttr("cap_drop", "list(string)", false),
+		"seccomp_profile": hclspec.NewAttr("seccomp_profile", "string", false),
+		"unveil":          hclspec.NewAttr("unveil", "list(string)", false),
+		"ulimit":          hclspec.NewAttr("ulimit", "list(map(string))", false),
+		"sysctl":          hclspec.NewAttr("sysctl", "list(map(string))", false),
+		"readonly_rootfs": hclspec.NewAttr("readonly_rootfs", "bool", false),
 	})
 
 	// driverCapabilities is returned by the Capabilities RPC and indicates what
@@ -133,6 +149,24 @@ type Config struct {
 	// AllowCaps configures which Linux Capabilities are enabled for tasks
 	// running on this node.
 	AllowCaps []string `codec:"allow_caps"`
+
+	// AllowUlimits configures which ulimits tasks running on this node may
+	// set. Defaults to executor.UlimitsHCLSpecLiteral.
+	AllowUlimits []string `codec:"allow_ulimits"`
+
+	// AllowSysctls configures which sysctls tasks running on this node may
+	// set. Entries ending in "*" allow every sysctl with that prefix.
+	// Defaults to executor.SysctlsHCLSpecLiteral.
+	AllowSysctls []string `codec:"allow_sysctls"`
+
+	// DefaultSeccompProfile is the path on the host of a Docker compatible
+	// seccomp profile applied to tasks which do not set their own.
+	DefaultSeccompProfile string `codec:"default_seccomp_profile"`
+
+	// DefaultUnveil is a list of "mode:path" rules granting filesystem access
+	// to every task. When set, tasks are restricted with landlock to these
+	// paths and those of their own unveil rules.
+	DefaultUnveil []string `codec:"default_unveil"`
 }
 
 func (c *Config) validate() error {
@@ -153,6 +187,10 @@ func (c *Config) validate() error {
 		return fmt.Errorf("allow_caps configured with capabilities not supported by system: %s", badCaps)
 	}
 
+	if err := executor.ValidateUnveil(c.DefaultUnveil); err != nil {
+		return fmt.Errorf("default_unveil: %v", err)
+	}
+
 	return nil
 }
 
@@ -186,6 +224,24 @@ type TaskConfig struct {
 
 	// CapDrop is a set of linux capabilities to disable.
 	CapDrop []string `codec:"cap_drop"`
+
+	// SeccompProfile is the path of a Docker compatible seccomp profile
+	// within the task directory.
+	SeccompProfile string `codec:"seccomp_profile"`
+
+	// Unveil is a list of "mode:path" rules granting filesystem access to
+	// the task, which is otherwise denied once any rule is set.
+	Unveil []string `codec:"unveil"`
+
+	// Ulimit maps resources to the "soft:hard" limits set for the task.
+	Ulimit hclutils.MapStrStr `codec:"ulimit"`
+
+	// Sysctl is a set of namespaced kernel parameters to set for the task.
+	Sysctl hclutils.MapStrStr `codec:"sysctl"`
+
+	// ReadonlyRootfs mounts the root filesystem of the task read-only,
+	// leaving only the task directories writable.
+	ReadonlyRootfs bool `codec:"readonly_rootfs"`
 }
 
 func (tc *TaskConfig) validate() error {
@@ -212,6 +268,10 @@ func (tc *TaskConfig) validate() error {
 		return fmt.Errorf("cap_drop configured with capabilities not supported by system: %s", badDrops)
 	}
 
+	if err := executor.ValidateUnveil(tc.Unveil); err != nil {
+		return fmt.Errorf("unveil: %v", err)
+	}
+
 	return nil
 }
 
@@ -369,6 +429,13 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 	fp.Attributes[driverVersionAttr] = pstructs.NewStringAttribute(version)
 	fp.Attributes["driver.java.runtime"] = pstructs.NewStringAttribute(jdkJRE)
 	fp.Attributes["driver.java.vm"] = pstructs.NewStringAttribute(vm)
+	fp.Attributes["driver.java.seccomp"] = pstructs.NewBoolAttribute(executor.SeccompSupported())
+	if abi := executor.LandlockABI(); abi > 0 {
+		fp.Attributes["driver.java.landlock"] = pstructs.NewBoolAttribute(true)
+		fp.Attributes["driver.java.landlock.abi"] = pstructs.NewIntAttribute(int64(abi), "")
+	} else {
+		fp.Attributes["driver.java.landlock"] = pstructs.NewBoolAttribute(false)
+	}
 
 	return fp
 }
@@ -441,6 +508,23 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		return nil, nil, fmt.Errorf("jar_path or class must be specified")
 	}
 
+	seccompProfile, err := d.seccompProfile(cfg.TaskDir().Dir, driverConfig.SeccompProfile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ulimits, err := executor.ParseUlimits(driverConfig.Ulimit, d.config.AllowUlimits)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := executor.ValidateSysctls(driverConfig.Sysctl, d.config.AllowSysctls); err != nil {
+		return nil, nil, err
+	}
+	modeIPC := executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC)
+	if err := executor.ValidateSysctlNamespaces(driverConfig.Sysctl, cfg.NetworkIsolation != nil, modeIPC); err != nil {
+		return nil, nil, err
+	}
+
 	absPath, err := GetAbsolutePath("java")
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to find java binary: %s", err)
@@ -502,8 +586,13 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		Devices:          cfg.Devices,
 		NetworkIsolation: cfg.NetworkIsolation,
 		ModePID:          executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
-		ModeIPC:          executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC),
+		ModeIPC:          modeIPC,
 		Capabilities:     caps,
+		SeccompProfile:   seccompProfile,
+		Unveil:           d.unveil(driverConfig.Unveil),
+		Ulimits:          ulimits,
+		Sysctls:          driverConfig.Sysctl,
+		ReadonlyRootfs:   driverConfig.ReadonlyRootfs,
 	}
 
 	ps, err := exec.Launch(execCmd)
@@ -572,6 +661,26 @@ func javaCmdArgs(driverConfig TaskConfig) []string {
 	return args
 }
 
+// seccompProfile returns the contents of the seccomp profile configured for a
+// task, falling back to the plugin's default profile.
+func (d *Driver) seccompProfile(taskDir, path string) (string, error) {
+	if path != "" {
+		return executor.ReadSeccompProfile(taskDir, path)
+	}
+	return executor.ReadSeccompProfile("", d.config.DefaultSeccompProfile)
+}
+
+// unveil returns the plugin's default unveil rules followed by those
+// configured for a task.
+func (d *Driver) unveil(rules []string) []string {
+	if len(d.config.DefaultUnveil) == 0 && len(rules) == 0 {
+		return nil
+	}
+	unveil := make([]string, 0, len(d.config.DefaultUnveil)+len(rules))
+	unveil = append(unveil, d.config.DefaultUnveil...)
+	return append(unveil, rules...)
+}
+
 func (d *Driver) WaitTask(ctx context.Context, taskID string) (<-chan *drivers.ExitResult, error) {
 	handle, ok := d.tasks.Get(taskID)
 	if !ok {
@@ -340,14 +340,24 @@ config {
   jar_path = "/tmp/jar.jar"
   jvm_options = ["-Xmx600"]
   args = ["arg1", "arg2"]
+  ulimit {
+    nofile = "2048:4096"
+  }
+  sysctl {
+    net.core.somaxconn = "16384"
+  }
+  readonly_rootfs = true
 }`
 
 	expected := &TaskConfig{
-		Class:     "java.main",
-		ClassPath: "/tmp/cp",
-		JarPath:   "/tmp/jar.jar",
-		JvmOpts:   []string{"-Xmx600"},
-		Args:      []string{"arg1", "arg2"},
+		Class:          "java.main",
+		ClassPath:      "/tmp/cp",
+		JarPath:        "/tmp/jar.jar",
+		JvmOpts:        []string{"-Xmx600"},
+		Args:           []string{"arg1", "arg2"},
+		Ulimit:         map[string]string{"nofile": "2048:4096"},
+		Sysctl:         map[string]string{"net.core.somaxconn": "16384"},
+		ReadonlyRootfs: true,
 	}
 
 	var tc *TaskConfig
@@ -452,6 +462,19 @@ func TestDriver_Config_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("default_unveil", func(t *testing.T) {
+		require.NoError(t, (&Config{
+			DefaultModePID: "private",
+			DefaultModeIPC: "private",
+			DefaultUnveil:  []string{"r:/etc", "rx:/usr"},
+		}).validate())
+		require.EqualError(t, (&Config{
+			DefaultModePID: "private",
+			DefaultModeIPC: "private",
+			DefaultUnveil:  []string{"r:/etc", "rx"},
+		}).validate(), `default_unveil: unveil rule "rx" must be of the form mode:path`)
+	})
 }
 
 func TestDriver_TaskConfig_validate(t *testing.T) {
@@ -512,4 +535,22 @@ func TestDriver_TaskConfig_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("unveil", func(t *testing.T) {
+		for _, tc := range []struct {
+			rules []string
+			exp   error
+		}{
+			{rules: nil, exp: nil},
+			{rules: []string{"r:/etc", "rwc:/tmp", "rx:/usr/bin"}, exp: nil},
+			{rules: []string{"/etc"}, exp: errors.New(`unveil: unveil rule "/etc" must be of the form mode:path`)},
+			{rules: []string{":/etc"}, exp: errors.New(`unveil: unveil rule ":/etc" must specify a mode`)},
+			{rules: []string{"rz:/etc"}, exp: errors.New(`unveil: unveil rule "rz:/etc" has invalid mode 'z', must be a combination of r, w, x and c`)},
+			{rules: []string{"r:etc"}, exp: errors.New(`unveil: unveil rule "r:etc" must use an absolute path`)},
+		} {
+			require.Equal(t, tc.exp, (&TaskConfig{
+				Unveil: tc.rules,
+			}).validate())
+		}
+	})
 }
@@ -82,6 +82,11 @@ type Executor interface {
 	// Signal sends the given signal to the user process
 	Signal(os.Signal) error
 
+	// Checkpoint dumps the state of the user process to dir so that it can
+	// be restored by launching a command with RestoreDir set. The process
+	// is stopped once checkpointed.
+	Checkpoint(dir string) error
+
 	// Exec executes the given command and args inside the executor context
 	// and returns the output and exit code.
 	Exec(deadline time.Time, cmd string, args []string) ([]byte, int, error)
@@ -154,6 +159,37 @@ type ExecCommand struct {
 
 	// Capabilities are the linux capabilities to be enabled by the task driver.
 	Capabilities []string
+
+	// SeccompProfile is the contents of a Docker compatible seccomp profile
+	// applied to the task process.
+	SeccompProfile string
+
+	// Unveil is the list of "mode:path" rules restricting the filesystem
+	// access of the task process with landlock.
+	Unveil []string
+
+	// Rootfs is the host path of the root filesystem of the task when it
+	// runs from an image rather than from its chroot in TaskDir.
+	Rootfs string
+
+	// UserNamespace maps the user and group IDs of the user namespace the
+	// task runs in, if any.
+	UserNamespace *drivers.UserNamespace
+
+	// Ulimits are the resource limits of the task process.
+	Ulimits []*Ulimit
+
+	// Sysctls are the kernel parameters set in the namespaces of the task.
+	Sysctls map[string]string
+
+	// ReadonlyRootfs mounts the root filesystem of the task read-only,
+	// leaving only the task directories writable.
+	ReadonlyRootfs bool
+
+	// RestoreDir is the directory of a checkpoint to restore the process
+	// from instead of starting it. The process is started from scratch if
+	// restoring it fails.
+	RestoreDir string
 }
 
 // SetWriters sets the writer for the process stdout and stderr. This should
@@ -283,6 +319,24 @@ func (e *UniversalExecutor) Version() (*ExecutorVersion, error) {
 func (e *UniversalExecutor) Launch(command *ExecCommand) (*ProcessState, error) {
 	e.logger.Trace("preparing to launch command", "command", command.Cmd, "args", strings.Join(command.Args, " "))
 
+	// seccomp, landlock, images, user namespaces and limits are only
+	// supported by the libcontainer executor
+	if command.SeccompProfile != "" || len(command.Unveil) > 0 {
+		return nil, fmt.Errorf("seccomp profiles and unveil rules require filesystem isolation")
+	}
+	if command.Rootfs != "" {
+		return nil, fmt.Errorf("images require filesystem isolation")
+	}
+	if command.UserNamespace != nil {
+		return nil, fmt.Errorf("user namespaces require filesystem isolation")
+	}
+	if len(command.Ulimits) > 0 || len(command.Sysctls) > 0 || command.ReadonlyRootfs {
+		return nil, fmt.Errorf("ulimits, sysctls and read-only root filesystems require filesystem isolation")
+	}
+	if command.RestoreDir != "" {
+		e.logger.Warn("checkpoints require filesystem isolation, starting task", "checkpoint", command.RestoreDir)
+	}
+
 	e.commandCfg = command
 
 	// setting the user of the process
@@ -595,6 +649,11 @@ func (e *UniversalExecutor) Shutdown(signal string, grace time.Duration) error {
 	return nil
 }
 
+// Checkpoint is only supported by the libcontainer executor
+func (e *UniversalExecutor) Checkpoint(dir string) error {
+	return fmt.Errorf("checkpoints require filesystem isolation")
+}
+
 // Signal sends the passed signal to the task
 func (e *UniversalExecutor) Signal(s os.Signal) error {
 	if e.childCmd.Process == nil {
@@ -16,6 +16,12 @@ func NewExecutorWithIsolation(logger hclog.Logger) Executor {
 	return NewExecutor(logger)
 }
 
+// CheckpointSupported returns whether tasks can be checkpointed, which is
+// never the case outside of Linux.
+func CheckpointSupported() bool {
+	return false
+}
+
 func (e *UniversalExecutor) configureResourceContainer(_ int) error { return nil }
 
 func (e *UniversalExecutor) getAllPids() (resources.PIDs, error) {
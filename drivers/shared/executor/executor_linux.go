@@ -11,11 +11,13 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/armon/circbuf"
+	securejoin "github.com/cyphar/filepath-securejoin"
 	"github.com/hashicorp/consul-template/signals"
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/allocdir"
@@ -34,6 +36,7 @@ import (
 	"github.com/opencontainers/runc/libcontainer/devices"
 	ldevices "github.com/opencontainers/runc/libcontainer/devices"
 	"github.com/opencontainers/runc/libcontainer/specconv"
+	luser "github.com/opencontainers/runc/libcontainer/user"
 	lutils "github.com/opencontainers/runc/libcontainer/utils"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"golang.org/x/sys/unix"
@@ -101,7 +104,7 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 		// note that os.Args[0] refers to the executor shim typically
 		// and first args arguments is ignored now due
 		// until https://github.com/opencontainers/runc/pull/1888 is merged
-		libcontainer.InitArgs(os.Args[0], "libcontainer-shim"),
+		libcontainer.InitArgs(unveilInitArgs(command.Unveil)...),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create factory: %v", err)
@@ -113,11 +116,9 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 		return nil, fmt.Errorf("failed to configure container(%s): %v", l.id, err)
 	}
 
-	container, err := factory.Create(l.id, containerCfg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create container(%s): %v", l.id, err)
+	if err := l.create(factory, containerCfg); err != nil {
+		return nil, err
 	}
-	l.container = container
 
 	// Look up the binary path and make it executable
 	absPath, err := lookupTaskBin(command)
@@ -126,16 +127,20 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 		return nil, err
 	}
 
-	if err := makeExecutable(absPath); err != nil {
-		return nil, err
+	// binaries of images are left untouched
+	if containerBase(command, absPath) == command.TaskDir {
+		if err := makeExecutable(absPath); err != nil {
+			return nil, err
+		}
 	}
 
 	path := absPath
 
 	// Ensure that the path is contained in the chroot, and find it relative to the container
-	rel, err := filepath.Rel(command.TaskDir, path)
+	base := containerBase(command, path)
+	rel, err := filepath.Rel(base, path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to determine relative path base=%q target=%q: %v", command.TaskDir, path, err)
+		return nil, fmt.Errorf("failed to determine relative path base=%q target=%q: %v", base, path, err)
 	}
 
 	// Turn relative-to-chroot path into absolute path to avoid
@@ -169,21 +174,32 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	if command.User != "" {
 		process.User = command.User
 	}
+
+	// unveiled tasks join their container once it is set up, see run
+	if len(command.Unveil) > 0 {
+		process.Init = false
+		if command.User != "" {
+			process.User, process.AdditionalGroups, err = resolveUser(command)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
 	l.userProc = process
 
 	l.totalCpuStats = stats.NewCpuStats()
 	l.userCpuStats = stats.NewCpuStats()
 	l.systemCpuStats = stats.NewCpuStats()
 
-	// Starts the task
-	if err := container.Run(process); err != nil {
-		container.Destroy()
+	// Starts the task, restoring it from its checkpoint if there is one
+	if err := l.start(factory, containerCfg, process, command.RestoreDir); err != nil {
+		l.container.Destroy()
 		return nil, err
 	}
 
 	pid, err := process.Pid()
 	if err != nil {
-		container.Destroy()
+		l.container.Destroy()
 		return nil, err
 	}
 
@@ -200,6 +216,79 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	}, nil
 }
 
+// start runs the process in the container. When restoreDir is set the
+// process is restored from the checkpoint in it instead, falling back to
+// running it in a new container if restoring fails.
+func (l *LibcontainerExecutor) start(factory libcontainer.Factory, cfg *lconfigs.Config,
+	process *libcontainer.Process, restoreDir string) error {
+	if restoreDir == "" {
+		return l.run(cfg, process)
+	}
+	if err := checkpointable(l.command); err != nil {
+		l.logger.Warn("not restoring checkpoint, starting task", "checkpoint", restoreDir, "error", err)
+		return l.run(cfg, process)
+	}
+
+	err := l.container.Restore(process, criuOpts(restoreDir))
+	if err == nil {
+		l.logger.Debug("restored checkpoint", "checkpoint", restoreDir)
+		return nil
+	}
+	l.logger.Warn("failed to restore checkpoint, starting task", "checkpoint", restoreDir, "error", err)
+
+	// a failed restore may leave the container in any state, so the process
+	// is started in a new one
+	if err := l.container.Destroy(); err != nil {
+		return fmt.Errorf("failed to destroy container(%s): %v", l.id, err)
+	}
+	if err := l.create(factory, cfg); err != nil {
+		return err
+	}
+	return l.run(cfg, process)
+}
+
+// create creates the container of the task. The initial process of the
+// container of an unveiled task only holds its namespaces, and is started
+// without the seccomp profile of the task.
+func (l *LibcontainerExecutor) create(factory libcontainer.Factory, cfg *lconfigs.Config) error {
+	if len(l.command.Unveil) > 0 {
+		holderCfg := *cfg
+		holderCfg.Seccomp = nil
+		cfg = &holderCfg
+	}
+
+	container, err := factory.Create(l.id, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create container(%s): %v", l.id, err)
+	}
+	l.container = container
+	return nil
+}
+
+// run runs the process in the container. Landlock prevents the initial process
+// of a container from setting up its root filesystem, so an unveiled task
+// instead joins its container, where the libcontainer init process applies the
+// unveil rules before exec. The initial process is left waiting to exec,
+// holding the namespaces of the container.
+func (l *LibcontainerExecutor) run(cfg *lconfigs.Config, process *libcontainer.Process) error {
+	if process.Init {
+		return l.container.Run(process)
+	}
+
+	holder := &libcontainer.Process{
+		Args: process.Args[:1],
+		Env:  process.Env,
+		Init: true,
+	}
+	if err := l.container.Start(holder); err != nil {
+		return err
+	}
+	if err := l.container.Set(*cfg); err != nil {
+		return fmt.Errorf("failed to configure container(%s): %v", l.id, err)
+	}
+	return l.container.Run(process)
+}
+
 func (l *LibcontainerExecutor) getAllPids() (resources.PIDs, error) {
 	pids, err := l.container.Processes()
 	if err != nil {
@@ -277,6 +366,13 @@ func (l *LibcontainerExecutor) Shutdown(signal string, grace time.Duration) erro
 		return nil
 	}
 
+	// the container of an unveiled task outlives it
+	select {
+	case <-l.userProcExited:
+		return nil
+	default:
+	}
+
 	if grace > 0 {
 		if signal == "" {
 			signal = "SIGINT"
@@ -287,9 +383,9 @@ func (l *LibcontainerExecutor) Shutdown(signal string, grace time.Duration) erro
 			return fmt.Errorf("error unknown signal given for shutdown: %s", signal)
 		}
 
-		// Signal initial container processes only during graceful
-		// shutdown; hence `false` arg.
-		err = l.container.Signal(sig, false)
+		// Signal the task process only during graceful shutdown, which
+		// is the initial container process unless the task is unveiled.
+		err = l.userProc.Signal(sig)
 		if err != nil {
 			return err
 		}
@@ -426,6 +522,56 @@ func (l *LibcontainerExecutor) Signal(s os.Signal) error {
 	return l.userProc.Signal(s)
 }
 
+// Checkpoint dumps the state of the processes of the container to dir with
+// CRIU, which stops them once dumped. A failed checkpoint is removed.
+func (l *LibcontainerExecutor) Checkpoint(dir string) error {
+	if l.container == nil {
+		return fmt.Errorf("no container to checkpoint")
+	}
+	if err := checkpointable(l.command); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create checkpoint dir: %v", err)
+	}
+	if err := l.container.Checkpoint(criuOpts(dir)); err != nil {
+		os.RemoveAll(dir)
+		return fmt.Errorf("failed to checkpoint container(%s): %v", l.id, err)
+	}
+	return nil
+}
+
+// checkpointable returns an error if the task of the command can't be
+// checkpointed and restored. Tasks in user namespaces are restored into
+// allocations remapped to other IDs, and CRIU can't restore the Landlock
+// restrictions of unveiled tasks.
+func checkpointable(command *ExecCommand) error {
+	if command.UserNamespace != nil {
+		return fmt.Errorf("tasks in user namespaces can't be checkpointed")
+	}
+	if len(command.Unveil) > 0 {
+		return fmt.Errorf("tasks with unveil rules can't be checkpointed")
+	}
+	return nil
+}
+
+// CheckpointSupported returns whether tasks can be checkpointed, which
+// requires a CRIU binary whose checks of the running kernel pass.
+func CheckpointSupported() bool {
+	return exec.Command("criu", "check").Run() == nil
+}
+
+// criuOpts returns the options used to checkpoint containers to dir and
+// restore them from it. Established TCP connections can't be restored on
+// another node, so CRIU refuses to checkpoint processes holding them.
+func criuOpts(dir string) *libcontainer.CriuOpts {
+	return &libcontainer.CriuOpts{
+		ImagesDirectory: dir,
+		FileLocks:       true,
+	}
+}
+
 // Exec starts an additional process inside the container
 func (l *LibcontainerExecutor) Exec(deadline time.Time, cmd string, args []string) ([]byte, int, error) {
 	combined := append([]string{cmd}, args...)
@@ -571,7 +717,7 @@ func configureIsolation(cfg *lconfigs.Config, command *ExecCommand) error {
 	defaultMountFlags := syscall.MS_NOEXEC | syscall.MS_NOSUID | syscall.MS_NODEV
 
 	// set the new root directory for the container
-	cfg.Rootfs = command.TaskDir
+	cfg.Rootfs = taskRoot(command)
 
 	// disable pivot_root if set in the driver's configuration
 	cfg.NoPivotRoot = command.NoPivotRoot
@@ -586,6 +732,18 @@ func configureIsolation(cfg *lconfigs.Config, command *ExecCommand) error {
 		})
 	}
 
+	// remap the users of the task to the unprivileged range of host IDs
+	// allocated to it by the client; proc and mqueue can only be mounted
+	// from namespaces owned by the user namespace
+	if ns := command.UserNamespace; ns != nil {
+		if command.ModePID != IsolationModePrivate || command.ModeIPC != IsolationModePrivate {
+			return fmt.Errorf("user namespaces require private pid and ipc namespaces")
+		}
+		cfg.Namespaces = append(cfg.Namespaces, lconfigs.Namespace{Type: lconfigs.NEWUSER})
+		cfg.UidMappings = []lconfigs.IDMap{{ContainerID: 0, HostID: int(ns.HostUID), Size: int(ns.Size)}}
+		cfg.GidMappings = []lconfigs.IDMap{{ContainerID: 0, HostID: int(ns.HostGID), Size: int(ns.Size)}}
+	}
+
 	// paths to mask using a bind mount to /dev/null to prevent reading
 	cfg.MaskPaths = []string{
 		"/proc/kcore",
@@ -648,6 +806,35 @@ func configureIsolation(cfg *lconfigs.Config, command *ExecCommand) error {
 		},
 	}
 
+	// sysfs can't be mounted from a user namespace which doesn't own the
+	// network namespace, so bind mount the one of the host instead
+	if command.UserNamespace != nil {
+		cfg.Mounts[len(cfg.Mounts)-1] = &lconfigs.Mount{
+			Source:      "/sys",
+			Destination: "/sys",
+			Device:      "bind",
+			Flags:       defaultMountFlags | syscall.MS_RDONLY | syscall.MS_BIND | syscall.MS_REC,
+		}
+	}
+
+	// tasks running from an image only see the shared task directories
+	if command.Rootfs != "" {
+		cfg.Mounts = append(cfg.Mounts, taskDirMounts(command.TaskDir)...)
+	}
+
+	// a read-only root filesystem leaves the task directories writable
+	if command.ReadonlyRootfs {
+		cfg.Readonlyfs = true
+		cfg.Mounts = append(cfg.Mounts, writableTaskDirMounts(command)...)
+	}
+
+	rlimits, err := configureRlimits(command.Ulimits)
+	if err != nil {
+		return err
+	}
+	cfg.Rlimits = rlimits
+	cfg.Sysctl = command.Sysctls
+
 	if len(command.Mounts) > 0 {
 		cfg.Mounts = append(cfg.Mounts, cmdMounts(command.Mounts)...)
 	}
@@ -655,6 +842,30 @@ func configureIsolation(cfg *lconfigs.Config, command *ExecCommand) error {
 	return nil
 }
 
+// configureSandbox validates the kernel supports the seccomp profile and
+// unveil rules requested by the task, and sets up the seccomp filter of the
+// container.
+func configureSandbox(cfg *lconfigs.Config, command *ExecCommand) error {
+	if len(command.Unveil) > 0 && LandlockABI() == 0 {
+		return fmt.Errorf("unveil rules require landlock, which is not supported on this client")
+	}
+
+	if command.SeccompProfile == "" {
+		return nil
+	}
+
+	if !SeccompSupported() {
+		return fmt.Errorf("seccomp profiles are not supported on this client")
+	}
+
+	seccomp, err := seccompConfig(command, cfg.Capabilities.Bounding)
+	if err != nil {
+		return fmt.Errorf("failed to load seccomp profile: %v", err)
+	}
+	cfg.Seccomp = seccomp
+	return nil
+}
+
 func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 	// If resources are not limited then manually create cgroups needed
 	if !command.ResourceLimits {
@@ -735,6 +946,10 @@ func newLibcontainerConfig(command *ExecCommand) (*lconfigs.Config, error) {
 
 	configureCapabilities(cfg, command)
 
+	if err := configureSandbox(cfg, command); err != nil {
+		return nil, err
+	}
+
 	// children should not inherit Nomad agent oom_score_adj value
 	oomScoreAdj := 0
 	cfg.OomScoreAdj = &oomScoreAdj
@@ -805,10 +1020,122 @@ func cmdMounts(mounts []*drivers.MountConfig) []*lconfigs.Mount {
 	return r
 }
 
-// lookupTaskBin finds the file `bin` in taskDir/local, taskDir in that order, then performs
-// a PATH search inside taskDir. It returns an absolute path. See also executor.lookupBin
+// taskDirMounts returns the bind mounts exposing the alloc, local and secrets
+// directories of the task directory inside a root filesystem from an image.
+func taskDirMounts(taskDir string) []*lconfigs.Mount {
+	return bindTaskDirs(taskDir, allocdir.SharedAllocName, allocdir.TaskLocal, allocdir.TaskSecrets)
+}
+
+// writableTaskDirMounts returns the mounts keeping the task directories
+// writable when the root filesystem is read-only. The shared alloc and
+// secrets directories are always mounted, and so is the local directory of
+// tasks running from an image.
+func writableTaskDirMounts(command *ExecCommand) []*lconfigs.Mount {
+	if command.Rootfs != "" {
+		return bindTaskDirs(command.TaskDir, allocdir.TmpDirName)
+	}
+	return bindTaskDirs(command.TaskDir, allocdir.TaskLocal, allocdir.TmpDirName)
+}
+
+// bindTaskDirs returns bind mounts of the given directories of the task
+// directory at the same path inside the container.
+func bindTaskDirs(taskDir string, dirs ...string) []*lconfigs.Mount {
+	mounts := make([]*lconfigs.Mount, len(dirs))
+	for i, dir := range dirs {
+		mounts[i] = &lconfigs.Mount{
+			Source:           filepath.Join(taskDir, dir),
+			Destination:      "/" + dir,
+			Device:           "bind",
+			Flags:            unix.MS_BIND | unix.MS_REC,
+			PropagationFlags: []int{unix.MS_PRIVATE | unix.MS_REC},
+		}
+	}
+	return mounts
+}
+
+// rlimitTypes maps the names of ulimits to their resources.
+var rlimitTypes = map[string]int{
+	"core":       unix.RLIMIT_CORE,
+	"cpu":        unix.RLIMIT_CPU,
+	"data":       unix.RLIMIT_DATA,
+	"fsize":      unix.RLIMIT_FSIZE,
+	"locks":      unix.RLIMIT_LOCKS,
+	"memlock":    unix.RLIMIT_MEMLOCK,
+	"msgqueue":   unix.RLIMIT_MSGQUEUE,
+	"nice":       unix.RLIMIT_NICE,
+	"nofile":     unix.RLIMIT_NOFILE,
+	"nproc":      unix.RLIMIT_NPROC,
+	"rss":        unix.RLIMIT_RSS,
+	"rtprio":     unix.RLIMIT_RTPRIO,
+	"rttime":     unix.RLIMIT_RTTIME,
+	"sigpending": unix.RLIMIT_SIGPENDING,
+	"stack":      unix.RLIMIT_STACK,
+}
+
+// configureRlimits converts ulimits to the resource limits of the container.
+func configureRlimits(ulimits []*Ulimit) ([]lconfigs.Rlimit, error) {
+	var rlimits []lconfigs.Rlimit
+	for _, u := range ulimits {
+		t, ok := rlimitTypes[u.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown ulimit %q", u.Name)
+		}
+		rlimits = append(rlimits, lconfigs.Rlimit{Type: t, Soft: u.Soft, Hard: u.Hard})
+	}
+	return rlimits, nil
+}
+
+// taskRoot returns the host path of the root filesystem of the task, which
+// is either the image root filesystem or the task directory.
+func taskRoot(command *ExecCommand) string {
+	if command.Rootfs != "" {
+		return command.Rootfs
+	}
+	return command.TaskDir
+}
+
+// resolveUser resolves the user of the task against the passwd and group files
+// of its root filesystem, which an unveiled task may not be allowed to read
+// once libcontainer sets up its user.
+func resolveUser(command *ExecCommand) (string, []string, error) {
+	root := taskRoot(command)
+	passwdPath, err := securejoin.SecureJoin(root, "/etc/passwd")
+	if err != nil {
+		return "", nil, err
+	}
+	groupPath, err := securejoin.SecureJoin(root, "/etc/group")
+	if err != nil {
+		return "", nil, err
+	}
+
+	u, err := luser.GetExecUserPath(command.User, nil, passwdPath, groupPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve user %q: %v", command.User, err)
+	}
+
+	groups := make([]string, 0, len(u.Sgids))
+	for _, gid := range u.Sgids {
+		groups = append(groups, strconv.Itoa(gid))
+	}
+	return fmt.Sprintf("%d:%d", u.Uid, u.Gid), groups, nil
+}
+
+// containerBase returns the host directory which the given path of the task
+// command is relative to inside the container. Binaries in the local directory
+// are mounted into image root filesystems at the same location.
+func containerBase(command *ExecCommand, path string) string {
+	local := filepath.Join(command.TaskDir, allocdir.TaskLocal) + string(filepath.Separator)
+	if command.Rootfs != "" && !strings.HasPrefix(path, local) {
+		return command.Rootfs
+	}
+	return command.TaskDir
+}
+
+// lookupTaskBin finds the file `bin` in taskDir/local, the task root in that order, then performs
+// a PATH search inside the task root. It returns an absolute path. See also executor.lookupBin
 func lookupTaskBin(command *ExecCommand) (string, error) {
 	taskDir := command.TaskDir
+	taskRoot := taskRoot(command)
 	bin := command.Cmd
 
 	// Check in the local directory
@@ -819,22 +1146,42 @@ func lookupTaskBin(command *ExecCommand) (string, error) {
 	}
 
 	// Check at the root of the task's directory
-	root := filepath.Join(taskDir, bin)
-	if _, err := os.Stat(root); err == nil {
+	root := filepath.Join(taskRoot, bin)
+	if _, err := statTaskRoot(command, root); err == nil {
 		return root, nil
 	}
 
 	if strings.Contains(bin, "/") {
-		return "", fmt.Errorf("file %s not found under path %s", bin, taskDir)
+		return "", fmt.Errorf("file %s not found under path %s", bin, taskRoot)
 	}
 
 	path := "/usr/local/bin:/usr/bin:/bin"
 
-	return lookPathIn(path, taskDir, bin)
+	return lookPathIn(path, taskRoot, bin, func(path string) (os.FileInfo, error) {
+		return statTaskRoot(command, path)
+	})
+}
+
+// statTaskRoot returns the file info of a path within the task root. Symlinks
+// in image root filesystems are resolved within the image, as they would be
+// inside the container, so they can't refer to files on the host.
+func statTaskRoot(command *ExecCommand, path string) (os.FileInfo, error) {
+	if command.Rootfs == "" {
+		return os.Stat(path)
+	}
+	rel, err := filepath.Rel(command.Rootfs, path)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := securejoin.SecureJoin(command.Rootfs, rel)
+	if err != nil {
+		return nil, err
+	}
+	return os.Stat(resolved)
 }
 
 // lookPathIn looks for a file with PATH inside the directory root. Like exec.LookPath
-func lookPathIn(path string, root string, bin string) (string, error) {
+func lookPathIn(path string, root string, bin string, stat func(string) (os.FileInfo, error)) (string, error) {
 	// exec.LookPath(file string)
 	for _, dir := range filepath.SplitList(path) {
 		if dir == "" {
@@ -842,7 +1189,7 @@ func lookPathIn(path string, root string, bin string) (string, error) {
 			dir = "."
 		}
 		path := filepath.Join(root, dir, bin)
-		f, err := os.Stat(path)
+		f, err := stat(path)
 		if err != nil {
 			continue
 		}
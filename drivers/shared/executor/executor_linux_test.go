@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -169,6 +171,167 @@ func TestExecutor_Isolation_PID_and_IPC_hostMode(t *testing.T) {
 	executor.Wait(context.Background())
 }
 
+func TestExecutor_UserNamespace(t *testing.T) {
+	ci.Parallel(t)
+	r := require.New(t)
+	testutil.ExecCompatible(t)
+
+	testExecCmd := testExecutorCommandWithChroot(t)
+	execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+	execCmd.Cmd = "/bin/cat"
+	execCmd.Args = []string{"/proc/self/uid_map", "/proc/self/gid_map"}
+	defer allocDir.Destroy()
+
+	execCmd.User = "root"
+	execCmd.ResourceLimits = true
+	execCmd.ModePID = IsolationModePrivate
+	execCmd.ModeIPC = IsolationModePrivate
+	execCmd.UserNamespace = &drivers.UserNamespace{HostUID: 200000, HostGID: 300000, Size: 65536}
+
+	executor := NewExecutorWithIsolation(testlog.HCLogger(t))
+	defer executor.Shutdown("SIGKILL", 0)
+
+	ps, err := executor.Launch(execCmd)
+	r.NoError(err)
+	r.NotZero(ps.Pid)
+
+	estate, err := executor.Wait(context.Background())
+	r.NoError(err)
+	r.Zero(estate.ExitCode)
+
+	tu.WaitForResult(func() (bool, error) {
+		fields := strings.Fields(testExecCmd.stdout.String())
+		expected := []string{"0", "200000", "65536", "0", "300000", "65536"}
+		if !reflect.DeepEqual(expected, fields) {
+			return false, fmt.Errorf("expected id maps %v but found %v", expected, fields)
+		}
+		return true, nil
+	}, func(err error) { t.Error(err) })
+
+	// the namespace can't own the namespaces of the host
+	execCmd.ModePID = "host"
+	_, err = NewExecutorWithIsolation(testlog.HCLogger(t)).Launch(execCmd)
+	r.Error(err)
+	r.Contains(err.Error(), "user namespaces require private pid and ipc namespaces")
+}
+
+func TestExecutor_UlimitsSysctlsReadonlyRootfs(t *testing.T) {
+	ci.Parallel(t)
+	r := require.New(t)
+	testutil.ExecCompatible(t)
+
+	testExecCmd := testExecutorCommandWithChroot(t)
+	execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+	execCmd.Cmd = "/bin/bash"
+	execCmd.Args = []string{"-c", strings.Join([]string{
+		"ulimit -Sn", "ulimit -Hn", "cat /proc/sys/kernel/shmmni",
+		"(echo > /etc/x) 2>/dev/null || echo readonly",
+		"echo > /local/x && echo > /tmp/x && echo > /alloc/x && echo writable",
+	}, "; ")}
+	defer allocDir.Destroy()
+
+	execCmd.ResourceLimits = true
+	execCmd.ModeIPC = IsolationModePrivate
+	execCmd.Ulimits = []*Ulimit{{Name: "nofile", Soft: 512, Hard: 1024}}
+	execCmd.Sysctls = map[string]string{"kernel.shmmni": "1024"}
+	execCmd.ReadonlyRootfs = true
+
+	executor := NewExecutorWithIsolation(testlog.HCLogger(t))
+	defer executor.Shutdown("SIGKILL", 0)
+
+	_, err := executor.Launch(execCmd)
+	r.NoError(err)
+
+	estate, err := executor.Wait(context.Background())
+	r.NoError(err)
+	r.Zero(estate.ExitCode)
+
+	tu.WaitForResult(func() (bool, error) {
+		expected := []string{"512", "1024", "1024", "readonly", "writable"}
+		fields := strings.Fields(testExecCmd.stdout.String())
+		if !reflect.DeepEqual(expected, fields) {
+			return false, fmt.Errorf("expected %v but found %v", expected, fields)
+		}
+		return true, nil
+	}, func(err error) { t.Error(err) })
+}
+
+func TestExecutor_CheckpointRestoreFallback(t *testing.T) {
+	ci.Parallel(t)
+	r := require.New(t)
+	testutil.ExecCompatible(t)
+
+	testExecCmd := testExecutorCommandWithChroot(t)
+	execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+	execCmd.Cmd = "/bin/bash"
+	execCmd.Args = []string{"-c", "echo started; sleep 1000"}
+	defer allocDir.Destroy()
+
+	// an empty checkpoint can't be restored, so the task is started instead
+	execCmd.ResourceLimits = true
+	execCmd.RestoreDir = t.TempDir()
+
+	executor := NewExecutorWithIsolation(testlog.HCLogger(t))
+	defer executor.Shutdown("SIGKILL", 0)
+
+	_, err := executor.Launch(execCmd)
+	r.NoError(err)
+
+	tu.WaitForResult(func() (bool, error) {
+		if out := strings.TrimSpace(testExecCmd.stdout.String()); out != "started" {
+			return false, fmt.Errorf("expected started but found %q", out)
+		}
+		return true, nil
+	}, func(err error) { t.Error(err) })
+
+	if _, err := exec.LookPath("criu"); err == nil {
+		t.Skip("criu is installed")
+	}
+
+	// failing to checkpoint removes the checkpoint and leaves the task running
+	dir := filepath.Join(t.TempDir(), "checkpoint")
+	r.Error(executor.Checkpoint(dir))
+	r.NoDirExists(dir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = executor.Wait(ctx)
+	r.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestExecutor_checkpointable(t *testing.T) {
+	ci.Parallel(t)
+
+	require.NoError(t, checkpointable(&ExecCommand{}))
+
+	// checkpoints of remapped tasks would be restored with other IDs
+	err := checkpointable(&ExecCommand{UserNamespace: &drivers.UserNamespace{HostUID: 200000, HostGID: 200000, Size: 65536}})
+	require.EqualError(t, err, "tasks in user namespaces can't be checkpointed")
+
+	err = checkpointable(&ExecCommand{Unveil: []string{"r:/etc"}})
+	require.EqualError(t, err, "tasks with unveil rules can't be checkpointed")
+}
+
+func TestExecutor_configureRlimits(t *testing.T) {
+	ci.Parallel(t)
+
+	rlimits, err := configureRlimits([]*Ulimit{{Name: "core", Soft: 0, Hard: 0}, {Name: "nofile", Soft: 10, Hard: 20}})
+	require.NoError(t, err)
+	require.Equal(t, []lconfigs.Rlimit{
+		{Type: unix.RLIMIT_CORE, Soft: 0, Hard: 0},
+		{Type: unix.RLIMIT_NOFILE, Soft: 10, Hard: 20},
+	}, rlimits)
+
+	// every ulimit accepted by the drivers can be configured
+	for name := range ulimitNames {
+		_, ok := rlimitTypes[name]
+		require.True(t, ok, "missing rlimit for %q", name)
+	}
+
+	_, err = configureRlimits([]*Ulimit{{Name: "bogus"}})
+	require.EqualError(t, err, `unknown ulimit "bogus"`)
+}
+
 func TestExecutor_IsolationAndConstraints(t *testing.T) {
 	ci.Parallel(t)
 	testutil.ExecCompatible(t)
@@ -449,6 +612,48 @@ func TestUniversalExecutor_LookupTaskBin(t *testing.T) {
 	require.Error(err)
 }
 
+func TestUniversalExecutor_LookupTaskBin_Rootfs(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	taskDir := t.TempDir()
+	rootfs := t.TempDir()
+	cmd := &ExecCommand{Env: []string{"PATH=/bin"}, TaskDir: taskDir, Rootfs: rootfs}
+
+	require.NoError(os.MkdirAll(filepath.Join(rootfs, "bin"), 0755))
+	require.NoError(ioutil.WriteFile(filepath.Join(rootfs, "bin", "app"), []byte{1, 2}, 0755))
+	require.NoError(os.Symlink("app", filepath.Join(rootfs, "bin", "multicall")))
+
+	// symlinks pointing outside of the image must not resolve to the host
+	require.NoError(os.Symlink("/bin/sh", filepath.Join(rootfs, "bin", "sh")))
+
+	require.NoError(os.MkdirAll(filepath.Join(taskDir, "local"), 0755))
+	require.NoError(ioutil.WriteFile(filepath.Join(taskDir, "local", "tool"), []byte{1, 2}, 0755))
+
+	// Binaries are looked up within the image
+	cmd.Cmd = "/bin/app"
+	path, err := lookupTaskBin(cmd)
+	require.NoError(err)
+	require.Equal(filepath.Join(rootfs, "bin", "app"), path)
+
+	// Symlinks are kept, for binaries which behave based on their name
+	cmd.Cmd = "multicall"
+	path, err = lookupTaskBin(cmd)
+	require.NoError(err)
+	require.Equal(filepath.Join(rootfs, "bin", "multicall"), path)
+
+	cmd.Cmd = "sh"
+	_, err = lookupTaskBin(cmd)
+	require.Error(err)
+
+	// Binaries in the local dir of the task are still found
+	cmd.Cmd = "tool"
+	path, err = lookupTaskBin(cmd)
+	require.NoError(err)
+	require.Equal(filepath.Join(taskDir, "local", "tool"), path)
+	require.Equal(taskDir, containerBase(cmd, path))
+}
+
 // Exec Launch looks for the binary only inside the chroot
 func TestExecutor_EscapeContainer(t *testing.T) {
 	ci.Parallel(t)
@@ -780,3 +985,117 @@ func TestUniversalExecutor_NoCgroup(t *testing.T) {
 	})
 
 }
+
+func TestExecutor_seccompConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	profile := `{
+  "defaultAction": "SCMP_ACT_ERRNO",
+  "syscalls": [
+    {"names": ["read", "write"], "action": "SCMP_ACT_ALLOW"},
+    {"names": ["chown"], "action": "SCMP_ACT_ALLOW", "includes": {"caps": ["CAP_CHOWN"]}}
+  ]
+}`
+
+	t.Run("capabilities", func(t *testing.T) {
+		cfg, err := seccompConfig(&ExecCommand{SeccompProfile: profile}, []string{"CAP_KILL"})
+		require.NoError(t, err)
+		require.Equal(t, lconfigs.Errno, cfg.DefaultAction)
+		require.Len(t, cfg.Syscalls, 2)
+		require.Equal(t, "read", cfg.Syscalls[0].Name)
+		require.Equal(t, "write", cfg.Syscalls[1].Name)
+
+		cfg, err = seccompConfig(&ExecCommand{SeccompProfile: profile}, []string{"CAP_CHOWN"})
+		require.NoError(t, err)
+		require.Len(t, cfg.Syscalls, 3)
+		require.Equal(t, "chown", cfg.Syscalls[2].Name)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := seccompConfig(&ExecCommand{SeccompProfile: `{"defaultAction": "SCMP_ACT_NOPE"}`}, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestExecutor_unveilInitArgs(t *testing.T) {
+	ci.Parallel(t)
+
+	args := unveilInitArgs([]string{"r:/etc", "rx:/bin"})
+	require.Equal(t, []string{os.Args[0], "libcontainer-shim", "r:/etc", "rx:/bin"}, args)
+
+	args = unveilInitArgs(nil)
+	require.Equal(t, []string{os.Args[0], "libcontainer-shim"}, args)
+}
+
+func TestExecutor_unveilAccess(t *testing.T) {
+	ci.Parallel(t)
+
+	require.Equal(t, uint64(unix.LANDLOCK_ACCESS_FS_READ_FILE|unix.LANDLOCK_ACCESS_FS_READ_DIR), unveilAccess("r"))
+	require.Equal(t, uint64(unix.LANDLOCK_ACCESS_FS_READ_FILE|unix.LANDLOCK_ACCESS_FS_READ_DIR|
+		unix.LANDLOCK_ACCESS_FS_EXECUTE), unveilAccess("rx"))
+	require.Zero(t, unveilAccess("c")&landlockAccessFile)
+
+	// file rename and link rights are only handled from landlock ABI v2
+	require.Zero(t, landlockHandledAccess(1)&unix.LANDLOCK_ACCESS_FS_REFER)
+	require.NotZero(t, landlockHandledAccess(2)&unix.LANDLOCK_ACCESS_FS_REFER)
+}
+
+func TestExecutor_Unveil(t *testing.T) {
+	ci.Parallel(t)
+	testutil.ExecCompatible(t)
+	if LandlockABI() == 0 {
+		t.Skip("landlock is not supported")
+	}
+
+	testExecCmd := testExecutorCommandWithChroot(t)
+	execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+	defer allocDir.Destroy()
+
+	execCmd.User = "nobody"
+	execCmd.ResourceLimits = true
+	execCmd.Cmd = "/bin/bash"
+	execCmd.Args = []string{"-c", "cat /etc/passwd >/dev/null || echo denied; echo hello > /local/out; cat /local/out; echo $UID"}
+	execCmd.Unveil = []string{
+		"rx:/bin", "rx:/lib", "rx:/lib64", "rx:/usr/lib",
+		"r:/etc/ld.so.cache", "rw:/dev/null", "rwc:/local",
+	}
+
+	// the rules are applied before the seccomp profile, so it doesn't need to
+	// allow the landlock syscalls
+	if SeccompSupported() {
+		execCmd.SeccompProfile = `{
+  "defaultAction": "SCMP_ACT_ALLOW",
+  "syscalls": [{
+    "names": ["landlock_create_ruleset", "landlock_add_rule", "landlock_restrict_self"],
+    "action": "SCMP_ACT_ERRNO"
+  }]
+}`
+	}
+
+	executor := NewExecutorWithIsolation(testlog.HCLogger(t))
+	defer executor.Shutdown("SIGKILL", 0)
+
+	_, err := executor.Launch(execCmd)
+	require.NoError(t, err)
+
+	ps, err := executor.Wait(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, ps.ExitCode)
+
+	tu.WaitForResult(func() (bool, error) {
+		output := testExecCmd.stdout.String()
+		if output != "denied\nhello\n65534\n" {
+			return false, fmt.Errorf("unexpected output: %q, stderr: %q", output, testExecCmd.stderr.String())
+		}
+		return true, nil
+	}, func(err error) { require.NoError(t, err) })
+
+	// commands executed in the container are unveiled like the task
+	_, code, err := executor.Exec(time.Now().Add(10*time.Second), "/bin/cat", []string{"/etc/passwd"})
+	require.NoError(t, err)
+	require.NotZero(t, code)
+
+	_, code, err = executor.Exec(time.Now().Add(10*time.Second), "/bin/cat", []string{"/local/out"})
+	require.NoError(t, err)
+	require.Zero(t, code)
+}
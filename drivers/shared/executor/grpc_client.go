@@ -50,6 +50,14 @@ func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 		DefaultPidMode:     cmd.ModePID,
 		DefaultIpcMode:     cmd.ModeIPC,
 		Capabilities:       cmd.Capabilities,
+		SeccompProfile:     cmd.SeccompProfile,
+		Unveil:             cmd.Unveil,
+		Rootfs:             cmd.Rootfs,
+		UserNamespace:      drivers.UserNamespaceToProto(cmd.UserNamespace),
+		Ulimits:            ulimitsToProto(cmd.Ulimits),
+		Sysctls:            cmd.Sysctls,
+		ReadonlyRootfs:     cmd.ReadonlyRootfs,
+		RestoreDir:         cmd.RestoreDir,
 	}
 	resp, err := c.client.Launch(ctx, req)
 	if err != nil {
@@ -175,6 +183,18 @@ func (c *grpcExecutorClient) Signal(s os.Signal) error {
 	return nil
 }
 
+func (c *grpcExecutorClient) Checkpoint(dir string) error {
+	ctx := context.Background()
+	req := &proto.CheckpointRequest{
+		Dir: dir,
+	}
+	if _, err := c.client.Checkpoint(ctx, req); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (c *grpcExecutorClient) Exec(deadline time.Time, cmd string, args []string) ([]byte, int, error) {
 	ctx := context.Background()
 	pbDeadline, err := ptypes.TimestampProto(deadline)
@@ -39,6 +39,14 @@ func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchReques
 		ModePID:            req.DefaultPidMode,
 		ModeIPC:            req.DefaultIpcMode,
 		Capabilities:       req.Capabilities,
+		SeccompProfile:     req.SeccompProfile,
+		Unveil:             req.Unveil,
+		Rootfs:             req.Rootfs,
+		UserNamespace:      drivers.UserNamespaceFromProto(req.UserNamespace),
+		Ulimits:            ulimitsFromProto(req.Ulimits),
+		Sysctls:            req.Sysctls,
+		ReadonlyRootfs:     req.ReadonlyRootfs,
+		RestoreDir:         req.RestoreDir,
 	})
 
 	if err != nil {
@@ -145,6 +153,13 @@ func (s *grpcExecutorServer) Signal(ctx context.Context, req *proto.SignalReques
 	return &proto.SignalResponse{}, nil
 }
 
+func (s *grpcExecutorServer) Checkpoint(ctx context.Context, req *proto.CheckpointRequest) (*proto.CheckpointResponse, error) {
+	if err := s.impl.Checkpoint(req.Dir); err != nil {
+		return nil, err
+	}
+	return &proto.CheckpointResponse{}, nil
+}
+
 func (s *grpcExecutorServer) Exec(ctx context.Context, req *proto.ExecRequest) (*proto.ExecResponse, error) {
 	deadline, err := ptypes.Timestamp(req.Deadline)
 	if err != nil {
@@ -19,6 +19,10 @@ func init() {
 	if len(os.Args) > 1 && os.Args[1] == "libcontainer-shim" {
 		runtime.GOMAXPROCS(1)
 		runtime.LockOSThread()
+		if err := unveilInit(os.Args[2:]); err != nil {
+			hclog.L().Error("failed to apply unveil rules", "error", err)
+			os.Exit(1)
+		}
 		factory, _ := libcontainer.New("")
 		if err := factory.StartInitialization(); err != nil {
 			hclog.L().Error("failed to initialize libcontainer-shim", "error", err)
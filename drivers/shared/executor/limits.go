@@ -0,0 +1,115 @@
+package executor
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// UlimitsHCLSpecLiteral is the default list of ulimits tasks may set,
+	// expressed as a literal HCL string for use in HCL config parsing.
+	UlimitsHCLSpecLiteral = `["core","memlock","nofile","nproc","stack"]`
+
+	// SysctlsHCLSpecLiteral is the default list of sysctls tasks may set,
+	// expressed as a literal HCL string for use in HCL config parsing. These
+	// are namespaced, so they only affect the IPC and network namespaces of
+	// the task.
+	SysctlsHCLSpecLiteral = `["kernel.msgmax","kernel.msgmnb","kernel.msgmni","kernel.sem","kernel.shmall","kernel.shmmax","kernel.shmmni","kernel.shm_rmid_forced","fs.mqueue.*","net.*"]`
+)
+
+// ulimitNames is the set of resources a ulimit may be set for, named as by
+// the Docker driver.
+var ulimitNames = map[string]struct{}{
+	"core": {}, "cpu": {}, "data": {}, "fsize": {}, "locks": {}, "memlock": {},
+	"msgqueue": {}, "nice": {}, "nofile": {}, "nproc": {}, "rss": {},
+	"rtprio": {}, "rttime": {}, "sigpending": {}, "stack": {},
+}
+
+// Ulimit is a resource limit of the task process.
+type Ulimit struct {
+	Name string
+	Soft uint64
+	Hard uint64
+}
+
+// ParseUlimits parses ulimits given as a map of resource names to limits of
+// the form "soft:hard", or a single value used for both, only allowing the
+// resources in allowed. The ulimits are sorted by name.
+func ParseUlimits(ulimits map[string]string, allowed []string) ([]*Ulimit, error) {
+	parsed := make([]*Ulimit, 0, len(ulimits))
+	for name, limit := range ulimits {
+		if _, ok := ulimitNames[name]; !ok {
+			return nil, fmt.Errorf("ulimit %q is not a known resource", name)
+		}
+		if !allowedKey(name, allowed) {
+			return nil, fmt.Errorf("ulimit %q is not allowed by the driver configuration", name)
+		}
+
+		soft, hard, ok := strings.Cut(limit, ":")
+		if !ok {
+			hard = soft
+		}
+		s, err := strconv.ParseUint(soft, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("ulimit %q has malformed soft limit %q", name, soft)
+		}
+		h, err := strconv.ParseUint(hard, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("ulimit %q has malformed hard limit %q", name, hard)
+		}
+		if s > h {
+			return nil, fmt.Errorf("ulimit %q has soft limit %d greater than hard limit %d", name, s, h)
+		}
+		parsed = append(parsed, &Ulimit{Name: name, Soft: s, Hard: h})
+	}
+	sort.Slice(parsed, func(i, j int) bool { return parsed[i].Name < parsed[j].Name })
+	return parsed, nil
+}
+
+// ValidateSysctls ensures each of the given sysctls is in allowed.
+func ValidateSysctls(sysctls map[string]string, allowed []string) error {
+	for key := range sysctls {
+		if !allowedKey(key, allowed) {
+			return fmt.Errorf("sysctl %q is not allowed by the driver configuration", key)
+		}
+	}
+	return nil
+}
+
+// ipcSysctls are the sysctls scoped to the IPC namespace of the task.
+var ipcSysctls = []string{
+	"kernel.msgmax", "kernel.msgmnb", "kernel.msgmni", "kernel.sem",
+	"kernel.shmall", "kernel.shmmax", "kernel.shmmni", "kernel.shm_rmid_forced",
+	"fs.mqueue.*",
+}
+
+// ValidateSysctlNamespaces ensures the task has the namespaces the given
+// sysctls are scoped to, so they aren't set on the host.
+func ValidateSysctlNamespaces(sysctls map[string]string, netIsolated bool, modeIPC string) error {
+	for key := range sysctls {
+		if strings.HasPrefix(key, "net.") && !netIsolated {
+			return fmt.Errorf("sysctl %q requires a network namespace, but the task uses the host network", key)
+		}
+		if allowedKey(key, ipcSysctls) && modeIPC == IsolationModeHost {
+			return fmt.Errorf("sysctl %q requires a private IPC namespace, but ipc_mode is %q", key, modeIPC)
+		}
+	}
+	return nil
+}
+
+// allowedKey returns whether key matches one of the patterns in allowed,
+// which are either exact keys or prefixes ending in "*".
+func allowedKey(key string, allowed []string) bool {
+	for _, pattern := range allowed {
+		if strings.HasSuffix(pattern, "*") {
+			if strings.HasPrefix(key, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+		} else if key == pattern {
+			return true
+		}
+	}
+	return false
+}
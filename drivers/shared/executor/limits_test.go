@@ -0,0 +1,75 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimits_ParseUlimits(t *testing.T) {
+	ci.Parallel(t)
+
+	allowed := []string{"nofile", "core"}
+
+	ulimits, err := ParseUlimits(map[string]string{"nofile": "512:1024", "core": "0"}, allowed)
+	require.NoError(t, err)
+	require.Equal(t, []*Ulimit{
+		{Name: "core", Soft: 0, Hard: 0},
+		{Name: "nofile", Soft: 512, Hard: 1024},
+	}, ulimits)
+
+	cases := map[string]struct {
+		ulimits map[string]string
+		err     string
+	}{
+		"unknown":     {map[string]string{"bogus": "1"}, `ulimit "bogus" is not a known resource`},
+		"not allowed": {map[string]string{"nproc": "1"}, `ulimit "nproc" is not allowed by the driver configuration`},
+		"bad soft":    {map[string]string{"nofile": "a:1"}, `ulimit "nofile" has malformed soft limit "a"`},
+		"bad hard":    {map[string]string{"nofile": "1:"}, `ulimit "nofile" has malformed hard limit ""`},
+		"soft > hard": {map[string]string{"nofile": "2:1"}, `ulimit "nofile" has soft limit 2 greater than hard limit 1`},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseUlimits(tc.ulimits, allowed)
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestLimits_ValidateSysctls(t *testing.T) {
+	ci.Parallel(t)
+
+	allowed := []string{"kernel.shmmax", "net.*"}
+
+	require.NoError(t, ValidateSysctls(map[string]string{
+		"kernel.shmmax":            "1024",
+		"net.ipv4.ip_forward":      "1",
+		"net.core.somaxconn":       "4096",
+		"net.ipv4.tcp_fin_timeout": "10",
+	}, allowed))
+	require.NoError(t, ValidateSysctls(nil, nil))
+
+	err := ValidateSysctls(map[string]string{"kernel.shmmni": "1"}, allowed)
+	require.EqualError(t, err, `sysctl "kernel.shmmni" is not allowed by the driver configuration`)
+
+	// prefixes only match at the start of the key
+	err = ValidateSysctls(map[string]string{"kernel.net.x": "1"}, allowed)
+	require.Error(t, err)
+}
+
+func TestLimits_ValidateSysctlNamespaces(t *testing.T) {
+	ci.Parallel(t)
+
+	sysctls := map[string]string{"net.core.somaxconn": "4096", "kernel.shmmax": "1024"}
+	require.NoError(t, ValidateSysctlNamespaces(sysctls, true, IsolationModePrivate))
+	require.NoError(t, ValidateSysctlNamespaces(nil, false, IsolationModeHost))
+
+	// network sysctls would be set on the host without a network namespace
+	err := ValidateSysctlNamespaces(map[string]string{"net.core.somaxconn": "4096"}, false, IsolationModePrivate)
+	require.EqualError(t, err, `sysctl "net.core.somaxconn" requires a network namespace, but the task uses the host network`)
+
+	// as would IPC sysctls in the IPC namespace of the host
+	err = ValidateSysctlNamespaces(map[string]string{"fs.mqueue.msg_max": "20"}, true, IsolationModeHost)
+	require.EqualError(t, err, `sysctl "fs.mqueue.msg_max" requires a private IPC namespace, but ipc_mode is "host"`)
+}
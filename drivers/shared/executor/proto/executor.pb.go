@@ -46,6 +46,14 @@ type LaunchRequest struct {
 	CpusetCgroup         string                       `protobuf:"bytes,17,opt,name=cpuset_cgroup,json=cpusetCgroup,proto3" json:"cpuset_cgroup,omitempty"`
 	AllowCaps            []string                     `protobuf:"bytes,18,rep,name=allow_caps,json=allowCaps,proto3" json:"allow_caps,omitempty"`
 	Capabilities         []string                     `protobuf:"bytes,19,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	SeccompProfile       string                       `protobuf:"bytes,20,opt,name=seccomp_profile,json=seccompProfile,proto3" json:"seccomp_profile,omitempty"`
+	Unveil               []string                     `protobuf:"bytes,21,rep,name=unveil,proto3" json:"unveil,omitempty"`
+	Rootfs               string                       `protobuf:"bytes,22,opt,name=rootfs,proto3" json:"rootfs,omitempty"`
+	UserNamespace        *proto1.UserNamespace        `protobuf:"bytes,23,opt,name=user_namespace,json=userNamespace,proto3" json:"user_namespace,omitempty"`
+	Ulimits              []*Ulimit                    `protobuf:"bytes,24,rep,name=ulimits,proto3" json:"ulimits,omitempty"`
+	Sysctls              map[string]string            `protobuf:"bytes,25,rep,name=sysctls,proto3" json:"sysctls,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ReadonlyRootfs       bool                         `protobuf:"varint,26,opt,name=readonly_rootfs,json=readonlyRootfs,proto3" json:"readonly_rootfs,omitempty"`
+	RestoreDir           string                       `protobuf:"bytes,27,opt,name=restore_dir,json=restoreDir,proto3" json:"restore_dir,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
 	XXX_unrecognized     []byte                       `json:"-"`
 	XXX_sizecache        int32                        `json:"-"`
@@ -209,6 +217,117 @@ func (m *LaunchRequest) GetCapabilities() []string {
 	return nil
 }
 
+func (m *LaunchRequest) GetSeccompProfile() string {
+	if m != nil {
+		return m.SeccompProfile
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetUnveil() []string {
+	if m != nil {
+		return m.Unveil
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetRootfs() string {
+	if m != nil {
+		return m.Rootfs
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetUserNamespace() *proto1.UserNamespace {
+	if m != nil {
+		return m.UserNamespace
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetUlimits() []*Ulimit {
+	if m != nil {
+		return m.Ulimits
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetSysctls() map[string]string {
+	if m != nil {
+		return m.Sysctls
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetReadonlyRootfs() bool {
+	if m != nil {
+		return m.ReadonlyRootfs
+	}
+	return false
+}
+
+func (m *LaunchRequest) GetRestoreDir() string {
+	if m != nil {
+		return m.RestoreDir
+	}
+	return ""
+}
+
+type Ulimit struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Soft                 uint64   `protobuf:"varint,2,opt,name=soft,proto3" json:"soft,omitempty"`
+	Hard                 uint64   `protobuf:"varint,3,opt,name=hard,proto3" json:"hard,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Ulimit) Reset()         { *m = Ulimit{} }
+func (m *Ulimit) String() string { return proto.CompactTextString(m) }
+func (*Ulimit) ProtoMessage()    {}
+func (*Ulimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{1}
+}
+
+func (m *Ulimit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ulimit.Unmarshal(m, b)
+}
+func (m *Ulimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ulimit.Marshal(b, m, deterministic)
+}
+func (m *Ulimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ulimit.Merge(m, src)
+}
+func (m *Ulimit) XXX_Size() int {
+	return xxx_messageInfo_Ulimit.Size(m)
+}
+func (m *Ulimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ulimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ulimit proto.InternalMessageInfo
+
+func (m *Ulimit) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Ulimit) GetSoft() uint64 {
+	if m != nil {
+		return m.Soft
+	}
+	return 0
+}
+
+func (m *Ulimit) GetHard() uint64 {
+	if m != nil {
+		return m.Hard
+	}
+	return 0
+}
+
 type LaunchResponse struct {
 	Process              *ProcessState `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -220,7 +339,7 @@ func (m *LaunchResponse) Reset()         { *m = LaunchResponse{} }
 func (m *LaunchResponse) String() string { return proto.CompactTextString(m) }
 func (*LaunchResponse) ProtoMessage()    {}
 func (*LaunchResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{1}
+	return fileDescriptor_66b85426380683f3, []int{2}
 }
 
 func (m *LaunchResponse) XXX_Unmarshal(b []byte) error {
@@ -258,7 +377,7 @@ func (m *WaitRequest) Reset()         { *m = WaitRequest{} }
 func (m *WaitRequest) String() string { return proto.CompactTextString(m) }
 func (*WaitRequest) ProtoMessage()    {}
 func (*WaitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{2}
+	return fileDescriptor_66b85426380683f3, []int{3}
 }
 
 func (m *WaitRequest) XXX_Unmarshal(b []byte) error {
@@ -290,7 +409,7 @@ func (m *WaitResponse) Reset()         { *m = WaitResponse{} }
 func (m *WaitResponse) String() string { return proto.CompactTextString(m) }
 func (*WaitResponse) ProtoMessage()    {}
 func (*WaitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{3}
+	return fileDescriptor_66b85426380683f3, []int{4}
 }
 
 func (m *WaitResponse) XXX_Unmarshal(b []byte) error {
@@ -330,7 +449,7 @@ func (m *ShutdownRequest) Reset()         { *m = ShutdownRequest{} }
 func (m *ShutdownRequest) String() string { return proto.CompactTextString(m) }
 func (*ShutdownRequest) ProtoMessage()    {}
 func (*ShutdownRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{4}
+	return fileDescriptor_66b85426380683f3, []int{5}
 }
 
 func (m *ShutdownRequest) XXX_Unmarshal(b []byte) error {
@@ -375,7 +494,7 @@ func (m *ShutdownResponse) Reset()         { *m = ShutdownResponse{} }
 func (m *ShutdownResponse) String() string { return proto.CompactTextString(m) }
 func (*ShutdownResponse) ProtoMessage()    {}
 func (*ShutdownResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{5}
+	return fileDescriptor_66b85426380683f3, []int{6}
 }
 
 func (m *ShutdownResponse) XXX_Unmarshal(b []byte) error {
@@ -407,7 +526,7 @@ func (m *UpdateResourcesRequest) Reset()         { *m = UpdateResourcesRequest{}
 func (m *UpdateResourcesRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateResourcesRequest) ProtoMessage()    {}
 func (*UpdateResourcesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{6}
+	return fileDescriptor_66b85426380683f3, []int{7}
 }
 
 func (m *UpdateResourcesRequest) XXX_Unmarshal(b []byte) error {
@@ -445,7 +564,7 @@ func (m *UpdateResourcesResponse) Reset()         { *m = UpdateResourcesResponse
 func (m *UpdateResourcesResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateResourcesResponse) ProtoMessage()    {}
 func (*UpdateResourcesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{7}
+	return fileDescriptor_66b85426380683f3, []int{8}
 }
 
 func (m *UpdateResourcesResponse) XXX_Unmarshal(b []byte) error {
@@ -476,7 +595,7 @@ func (m *VersionRequest) Reset()         { *m = VersionRequest{} }
 func (m *VersionRequest) String() string { return proto.CompactTextString(m) }
 func (*VersionRequest) ProtoMessage()    {}
 func (*VersionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{8}
+	return fileDescriptor_66b85426380683f3, []int{9}
 }
 
 func (m *VersionRequest) XXX_Unmarshal(b []byte) error {
@@ -508,7 +627,7 @@ func (m *VersionResponse) Reset()         { *m = VersionResponse{} }
 func (m *VersionResponse) String() string { return proto.CompactTextString(m) }
 func (*VersionResponse) ProtoMessage()    {}
 func (*VersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{9}
+	return fileDescriptor_66b85426380683f3, []int{10}
 }
 
 func (m *VersionResponse) XXX_Unmarshal(b []byte) error {
@@ -547,7 +666,7 @@ func (m *StatsRequest) Reset()         { *m = StatsRequest{} }
 func (m *StatsRequest) String() string { return proto.CompactTextString(m) }
 func (*StatsRequest) ProtoMessage()    {}
 func (*StatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{10}
+	return fileDescriptor_66b85426380683f3, []int{11}
 }
 
 func (m *StatsRequest) XXX_Unmarshal(b []byte) error {
@@ -586,7 +705,7 @@ func (m *StatsResponse) Reset()         { *m = StatsResponse{} }
 func (m *StatsResponse) String() string { return proto.CompactTextString(m) }
 func (*StatsResponse) ProtoMessage()    {}
 func (*StatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{11}
+	return fileDescriptor_66b85426380683f3, []int{12}
 }
 
 func (m *StatsResponse) XXX_Unmarshal(b []byte) error {
@@ -625,7 +744,7 @@ func (m *SignalRequest) Reset()         { *m = SignalRequest{} }
 func (m *SignalRequest) String() string { return proto.CompactTextString(m) }
 func (*SignalRequest) ProtoMessage()    {}
 func (*SignalRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{12}
+	return fileDescriptor_66b85426380683f3, []int{13}
 }
 
 func (m *SignalRequest) XXX_Unmarshal(b []byte) error {
@@ -663,7 +782,7 @@ func (m *SignalResponse) Reset()         { *m = SignalResponse{} }
 func (m *SignalResponse) String() string { return proto.CompactTextString(m) }
 func (*SignalResponse) ProtoMessage()    {}
 func (*SignalResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{13}
+	return fileDescriptor_66b85426380683f3, []int{14}
 }
 
 func (m *SignalResponse) XXX_Unmarshal(b []byte) error {
@@ -684,6 +803,76 @@ func (m *SignalResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_SignalResponse proto.InternalMessageInfo
 
+type CheckpointRequest struct {
+	Dir                  string   `protobuf:"bytes,1,opt,name=dir,proto3" json:"dir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointRequest) Reset()         { *m = CheckpointRequest{} }
+func (m *CheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckpointRequest) ProtoMessage()    {}
+func (*CheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{15}
+}
+
+func (m *CheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointRequest.Unmarshal(m, b)
+}
+func (m *CheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointRequest.Merge(m, src)
+}
+func (m *CheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckpointRequest.Size(m)
+}
+func (m *CheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointRequest proto.InternalMessageInfo
+
+func (m *CheckpointRequest) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+type CheckpointResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointResponse) Reset()         { *m = CheckpointResponse{} }
+func (m *CheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckpointResponse) ProtoMessage()    {}
+func (*CheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{16}
+}
+
+func (m *CheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointResponse.Unmarshal(m, b)
+}
+func (m *CheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointResponse.Merge(m, src)
+}
+func (m *CheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckpointResponse.Size(m)
+}
+func (m *CheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointResponse proto.InternalMessageInfo
+
 type ExecRequest struct {
 	Deadline             *timestamp.Timestamp `protobuf:"bytes,1,opt,name=deadline,proto3" json:"deadline,omitempty"`
 	Cmd                  string               `protobuf:"bytes,2,opt,name=cmd,proto3" json:"cmd,omitempty"`
@@ -697,7 +886,7 @@ func (m *ExecRequest) Reset()         { *m = ExecRequest{} }
 func (m *ExecRequest) String() string { return proto.CompactTextString(m) }
 func (*ExecRequest) ProtoMessage()    {}
 func (*ExecRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{14}
+	return fileDescriptor_66b85426380683f3, []int{17}
 }
 
 func (m *ExecRequest) XXX_Unmarshal(b []byte) error {
@@ -751,7 +940,7 @@ func (m *ExecResponse) Reset()         { *m = ExecResponse{} }
 func (m *ExecResponse) String() string { return proto.CompactTextString(m) }
 func (*ExecResponse) ProtoMessage()    {}
 func (*ExecResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{15}
+	return fileDescriptor_66b85426380683f3, []int{18}
 }
 
 func (m *ExecResponse) XXX_Unmarshal(b []byte) error {
@@ -800,7 +989,7 @@ func (m *ProcessState) Reset()         { *m = ProcessState{} }
 func (m *ProcessState) String() string { return proto.CompactTextString(m) }
 func (*ProcessState) ProtoMessage()    {}
 func (*ProcessState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{16}
+	return fileDescriptor_66b85426380683f3, []int{19}
 }
 
 func (m *ProcessState) XXX_Unmarshal(b []byte) error {
@@ -851,6 +1040,8 @@ func (m *ProcessState) GetTime() *timestamp.Timestamp {
 
 func init() {
 	proto.RegisterType((*LaunchRequest)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchRequest")
+	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchRequest.SysctlsEntry")
+	proto.RegisterType((*Ulimit)(nil), "hashicorp.nomad.plugins.executor.proto.Ulimit")
 	proto.RegisterType((*LaunchResponse)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchResponse")
 	proto.RegisterType((*WaitRequest)(nil), "hashicorp.nomad.plugins.executor.proto.WaitRequest")
 	proto.RegisterType((*WaitResponse)(nil), "hashicorp.nomad.plugins.executor.proto.WaitResponse")
@@ -864,6 +1055,8 @@ func init() {
 	proto.RegisterType((*StatsResponse)(nil), "hashicorp.nomad.plugins.executor.proto.StatsResponse")
 	proto.RegisterType((*SignalRequest)(nil), "hashicorp.nomad.plugins.executor.proto.SignalRequest")
 	proto.RegisterType((*SignalResponse)(nil), "hashicorp.nomad.plugins.executor.proto.SignalResponse")
+	proto.RegisterType((*CheckpointRequest)(nil), "hashicorp.nomad.plugins.executor.proto.CheckpointRequest")
+	proto.RegisterType((*CheckpointResponse)(nil), "hashicorp.nomad.plugins.executor.proto.CheckpointResponse")
 	proto.RegisterType((*ExecRequest)(nil), "hashicorp.nomad.plugins.executor.proto.ExecRequest")
 	proto.RegisterType((*ExecResponse)(nil), "hashicorp.nomad.plugins.executor.proto.ExecResponse")
 	proto.RegisterType((*ProcessState)(nil), "hashicorp.nomad.plugins.executor.proto.ProcessState")
@@ -874,74 +1067,89 @@ func init() {
 }
 
 var fileDescriptor_66b85426380683f3 = []byte{
-	// 1058 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0x6d, 0x6f, 0x1b, 0x45,
-	0x10, 0xee, 0xc5, 0x89, 0x5f, 0xc6, 0x76, 0xe2, 0x2e, 0xa8, 0x5c, 0x8d, 0x50, 0xcd, 0x21, 0x51,
-	0x0b, 0xca, 0x25, 0x4a, 0xdf, 0x90, 0x90, 0x28, 0x22, 0x2d, 0xa8, 0x52, 0x1a, 0x45, 0x97, 0x42,
-	0x25, 0x3e, 0x70, 0x6c, 0xee, 0xb6, 0xf6, 0x2a, 0xf6, 0xed, 0xb2, 0xbb, 0xe7, 0x04, 0x09, 0x89,
-	0x4f, 0xfc, 0x03, 0x90, 0xf8, 0x31, 0xfc, 0x38, 0xb4, 0x6f, 0x17, 0x3b, 0x2d, 0xd5, 0xb9, 0x88,
-	0x4f, 0xbe, 0x1d, 0x3f, 0xcf, 0xcc, 0xec, 0xce, 0xcc, 0x33, 0x70, 0x27, 0x17, 0x74, 0x41, 0x84,
-	0xdc, 0x95, 0x53, 0x2c, 0x48, 0xbe, 0x4b, 0x2e, 0x48, 0x56, 0x2a, 0x26, 0x76, 0xb9, 0x60, 0x8a,
-	0x55, 0xc7, 0xd8, 0x1c, 0xd1, 0xc7, 0x53, 0x2c, 0xa7, 0x34, 0x63, 0x82, 0xc7, 0x05, 0x9b, 0xe3,
-	0x3c, 0xe6, 0xb3, 0x72, 0x42, 0x0b, 0x19, 0xaf, 0xe2, 0x86, 0xb7, 0x26, 0x8c, 0x4d, 0x66, 0xc4,
-	0x3a, 0x39, 0x2d, 0x5f, 0xee, 0x2a, 0x3a, 0x27, 0x52, 0xe1, 0x39, 0x77, 0x80, 0xc8, 0x11, 0x77,
-	0x7d, 0x78, 0x1b, 0xce, 0x9e, 0x2c, 0x26, 0xfa, 0xbb, 0x09, 0xfd, 0x43, 0x5c, 0x16, 0xd9, 0x34,
-	0x21, 0x3f, 0x97, 0x44, 0x2a, 0x34, 0x80, 0x46, 0x36, 0xcf, 0xc3, 0x60, 0x14, 0x8c, 0x3b, 0x89,
-	0xfe, 0x44, 0x08, 0x36, 0xb1, 0x98, 0xc8, 0x70, 0x63, 0xd4, 0x18, 0x77, 0x12, 0xf3, 0x8d, 0x8e,
-	0xa0, 0x23, 0x88, 0x64, 0xa5, 0xc8, 0x88, 0x0c, 0x1b, 0xa3, 0x60, 0xdc, 0xdd, 0xdf, 0x8b, 0xff,
-	0x2d, 0x71, 0x17, 0xdf, 0x86, 0x8c, 0x13, 0xcf, 0x4b, 0x2e, 0x5d, 0xa0, 0x5b, 0xd0, 0x95, 0x2a,
-	0x67, 0xa5, 0x4a, 0x39, 0x56, 0xd3, 0x70, 0xd3, 0x44, 0x07, 0x6b, 0x3a, 0xc6, 0x6a, 0xea, 0x00,
-	0x44, 0x08, 0x0b, 0xd8, 0xaa, 0x00, 0x44, 0x08, 0x03, 0x18, 0x40, 0x83, 0x14, 0x8b, 0xb0, 0x69,
-	0x92, 0xd4, 0x9f, 0x3a, 0xef, 0x52, 0x12, 0x11, 0xb6, 0x0c, 0xd6, 0x7c, 0xa3, 0x9b, 0xd0, 0x56,
-	0x58, 0x9e, 0xa5, 0x39, 0x15, 0x61, 0xdb, 0xd8, 0x5b, 0xfa, 0xfc, 0x98, 0x0a, 0x74, 0x1b, 0x76,
-	0x7c, 0x3e, 0xe9, 0x8c, 0xce, 0xa9, 0x92, 0x61, 0x67, 0x14, 0x8c, 0xdb, 0xc9, 0xb6, 0x37, 0x1f,
-	0x1a, 0x2b, 0xda, 0x83, 0x77, 0x4f, 0xb1, 0xa4, 0x59, 0xca, 0x05, 0xcb, 0x88, 0x94, 0x69, 0x36,
-	0x11, 0xac, 0xe4, 0x21, 0x18, 0x34, 0x32, 0xff, 0x1d, 0xdb, 0xbf, 0x0e, 0xcc, 0x3f, 0xe8, 0x31,
-	0x34, 0xe7, 0xac, 0x2c, 0x94, 0x0c, 0xbb, 0xa3, 0xc6, 0xb8, 0xbb, 0x7f, 0xa7, 0xe6, 0x53, 0x3d,
-	0xd3, 0xa4, 0xc4, 0x71, 0xd1, 0xb7, 0xd0, 0xca, 0xc9, 0x82, 0xea, 0x17, 0xef, 0x19, 0x37, 0x9f,
-	0xd5, 0x74, 0xf3, 0xd8, 0xb0, 0x12, 0xcf, 0x46, 0x53, 0xb8, 0x5e, 0x10, 0x75, 0xce, 0xc4, 0x59,
-	0x4a, 0x25, 0x9b, 0x61, 0x45, 0x59, 0x11, 0xf6, 0x4d, 0x11, 0xbf, 0xa8, 0xe9, 0xf2, 0xc8, 0xf2,
-	0x9f, 0x7a, 0xfa, 0x09, 0x27, 0x59, 0x32, 0x28, 0xae, 0x58, 0x51, 0x04, 0xfd, 0x82, 0xa5, 0x9c,
-	0x2e, 0x98, 0x4a, 0x05, 0x63, 0x2a, 0xdc, 0x36, 0x6f, 0xd4, 0x2d, 0xd8, 0xb1, 0xb6, 0x25, 0x8c,
-	0x29, 0x34, 0x86, 0x41, 0x4e, 0x5e, 0xe2, 0x72, 0xa6, 0x52, 0x4e, 0xf3, 0x74, 0xce, 0x72, 0x12,
-	0xee, 0x98, 0xd2, 0x6c, 0x3b, 0xfb, 0x31, 0xcd, 0x9f, 0xb1, 0x9c, 0x2c, 0x23, 0x29, 0xcf, 0x2c,
-	0x72, 0xb0, 0x82, 0x7c, 0xca, 0x33, 0x83, 0xfc, 0x08, 0xfa, 0x19, 0x2f, 0x25, 0x51, 0xbe, 0x36,
-	0xd7, 0x0d, 0xac, 0x67, 0x8d, 0xae, 0x2a, 0x1f, 0x00, 0xe0, 0xd9, 0x8c, 0x9d, 0xa7, 0x19, 0xe6,
-	0x32, 0x44, 0xa6, 0x71, 0x3a, 0xc6, 0x72, 0x80, 0xb9, 0x44, 0x11, 0xf4, 0x32, 0xcc, 0xf1, 0x29,
-	0x9d, 0x51, 0x45, 0x89, 0x0c, 0xdf, 0x31, 0x80, 0x15, 0x5b, 0xf4, 0x13, 0x6c, 0xfb, 0xe9, 0x91,
-	0x9c, 0x15, 0x92, 0xa0, 0x23, 0x68, 0xb9, 0xb6, 0x30, 0x23, 0xd4, 0xdd, 0xbf, 0x17, 0xd7, 0x9b,
-	0xe7, 0xd8, 0xb5, 0xcc, 0x89, 0xc2, 0x8a, 0x24, 0xde, 0x49, 0xd4, 0x87, 0xee, 0x0b, 0x4c, 0x95,
-	0x9b, 0xce, 0xe8, 0x47, 0xe8, 0xd9, 0xe3, 0xff, 0x14, 0xee, 0x10, 0x76, 0x4e, 0xa6, 0xa5, 0xca,
-	0xd9, 0x79, 0xe1, 0x05, 0xe1, 0x06, 0x34, 0x25, 0x9d, 0x14, 0x78, 0xe6, 0x34, 0xc1, 0x9d, 0xd0,
-	0x87, 0xd0, 0x9b, 0x08, 0x9c, 0x91, 0x94, 0x13, 0x41, 0x59, 0x1e, 0x6e, 0x8c, 0x82, 0x71, 0x23,
-	0xe9, 0x1a, 0xdb, 0xb1, 0x31, 0x45, 0x08, 0x06, 0x97, 0xde, 0x6c, 0xc6, 0xd1, 0x14, 0x6e, 0x7c,
-	0xc7, 0x73, 0x1d, 0xb4, 0xd2, 0x01, 0x17, 0x68, 0x45, 0x53, 0x82, 0xff, 0xac, 0x29, 0xd1, 0x4d,
-	0x78, 0xef, 0x95, 0x48, 0x2e, 0x89, 0x01, 0x6c, 0x7f, 0x4f, 0x84, 0xa4, 0xcc, 0xdf, 0x32, 0xfa,
-	0x14, 0x76, 0x2a, 0x8b, 0x7b, 0xdb, 0x10, 0x5a, 0x0b, 0x6b, 0x72, 0x37, 0xf7, 0xc7, 0xe8, 0x13,
-	0xe8, 0xe9, 0x77, 0xab, 0x32, 0x1f, 0x42, 0x9b, 0x16, 0x8a, 0x88, 0x85, 0x7b, 0xa4, 0x46, 0x52,
-	0x9d, 0xa3, 0x17, 0xd0, 0x77, 0x58, 0xe7, 0xf6, 0x1b, 0xd8, 0x92, 0xda, 0xb0, 0xe6, 0x15, 0x9f,
-	0x63, 0x79, 0x66, 0x1d, 0x59, 0x7a, 0x74, 0x1b, 0xfa, 0x27, 0xa6, 0x12, 0xaf, 0x2f, 0xd4, 0x96,
-	0x2f, 0x94, 0xbe, 0xac, 0x07, 0xba, 0xeb, 0x9f, 0x41, 0xf7, 0xc9, 0x05, 0xc9, 0x3c, 0xf1, 0x01,
-	0xb4, 0x73, 0x82, 0xf3, 0x19, 0x2d, 0x88, 0x4b, 0x6a, 0x18, 0xdb, 0xe5, 0x12, 0xfb, 0xe5, 0x12,
-	0x3f, 0xf7, 0xcb, 0x25, 0xa9, 0xb0, 0x7e, 0x55, 0x6c, 0xbc, 0xba, 0x2a, 0x1a, 0x97, 0xab, 0x22,
-	0x3a, 0x80, 0x9e, 0x0d, 0xe6, 0xee, 0x7f, 0x03, 0x9a, 0xac, 0x54, 0xbc, 0x54, 0x26, 0x56, 0x2f,
-	0x71, 0x27, 0xf4, 0x3e, 0x74, 0xc8, 0x05, 0x55, 0x69, 0xa6, 0xc7, 0x7a, 0xc3, 0xdc, 0xa0, 0xad,
-	0x0d, 0x07, 0x2c, 0x27, 0xd1, 0xef, 0x01, 0xf4, 0x96, 0x3b, 0x56, 0xc7, 0xe6, 0x34, 0x77, 0x37,
-	0xd5, 0x9f, 0x6f, 0xe4, 0x2f, 0xbd, 0x4d, 0x63, 0xf9, 0x6d, 0x50, 0x0c, 0x9b, 0x7a, 0x6d, 0x9a,
-	0x85, 0xf3, 0xe6, 0x6b, 0x1b, 0xdc, 0xfe, 0x9f, 0x1d, 0x68, 0x3f, 0x71, 0x83, 0x84, 0x7e, 0x81,
-	0xa6, 0x9d, 0x7e, 0x74, 0xbf, 0xee, 0xd4, 0xad, 0xec, 0xda, 0xe1, 0x83, 0x75, 0x69, 0xae, 0x7e,
-	0xd7, 0x90, 0x84, 0x4d, 0xad, 0x03, 0xe8, 0x6e, 0x5d, 0x0f, 0x4b, 0x22, 0x32, 0xbc, 0xb7, 0x1e,
-	0xa9, 0x0a, 0xfa, 0x1b, 0xb4, 0xfd, 0x38, 0xa3, 0x87, 0x75, 0x7d, 0x5c, 0x91, 0x93, 0xe1, 0xe7,
-	0xeb, 0x13, 0xab, 0x04, 0xfe, 0x08, 0x60, 0xe7, 0xca, 0x48, 0xa3, 0x2f, 0xeb, 0xfa, 0x7b, 0xbd,
-	0xea, 0x0c, 0x1f, 0xbd, 0x35, 0xbf, 0x4a, 0xeb, 0x57, 0x68, 0x39, 0xed, 0x40, 0xb5, 0x2b, 0xba,
-	0x2a, 0x3f, 0xc3, 0x87, 0x6b, 0xf3, 0xaa, 0xe8, 0x17, 0xb0, 0x65, 0x74, 0x01, 0xd5, 0x2e, 0xeb,
-	0xb2, 0x76, 0x0d, 0xef, 0xaf, 0xc9, 0xf2, 0x71, 0xf7, 0x02, 0xdd, 0xff, 0x56, 0x58, 0xea, 0xf7,
-	0xff, 0x8a, 0x62, 0xd5, 0xef, 0xff, 0x2b, 0xfa, 0x65, 0xfa, 0x5f, 0x8f, 0x61, 0xfd, 0xfe, 0x5f,
-	0xd2, 0xbb, 0xfa, 0xfd, 0xbf, 0xac, 0x5b, 0xd1, 0x35, 0xf4, 0x57, 0x00, 0x7d, 0x6d, 0x3a, 0x51,
-	0x82, 0xe0, 0x39, 0x2d, 0x26, 0xe8, 0x51, 0x4d, 0xf1, 0xd6, 0x2c, 0x2b, 0xe0, 0x8e, 0xe9, 0x53,
-	0xf9, 0xea, 0xed, 0x1d, 0xf8, 0xb4, 0xc6, 0xc1, 0x5e, 0xf0, 0x75, 0xeb, 0x87, 0x2d, 0xab, 0x59,
-	0x4d, 0xf3, 0x73, 0xf7, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x2e, 0x63, 0xc4, 0xd3, 0x74, 0x0c,
-	0x00, 0x00,
+	// 1308 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xdf, 0x6f, 0x1b, 0x45,
+	0x10, 0xe6, 0xe2, 0xc4, 0x76, 0xc6, 0x76, 0x92, 0x2e, 0x21, 0xbd, 0x5e, 0x85, 0x1a, 0x0e, 0x41,
+	0x23, 0x28, 0x97, 0xa8, 0xbf, 0x29, 0x12, 0x45, 0x4d, 0x0a, 0x54, 0x6a, 0xa3, 0xe8, 0xd2, 0x52,
+	0x09, 0x10, 0xc7, 0xf6, 0x6e, 0x63, 0xaf, 0x7c, 0xbe, 0x3d, 0x76, 0xf7, 0xdc, 0x44, 0x42, 0xe2,
+	0xa9, 0xff, 0x01, 0x0f, 0xbc, 0x20, 0xf1, 0xa7, 0xa2, 0xfd, 0xe5, 0xd8, 0x69, 0xa1, 0xe7, 0x20,
+	0x9e, 0x7c, 0xf3, 0x79, 0xbe, 0x99, 0xd9, 0x99, 0xdd, 0x6f, 0x17, 0xae, 0x65, 0x9c, 0x8e, 0x09,
+	0x17, 0xdb, 0x62, 0x80, 0x39, 0xc9, 0xb6, 0xc9, 0x31, 0x49, 0x2b, 0xc9, 0xf8, 0x76, 0xc9, 0x99,
+	0x64, 0x13, 0x33, 0xd2, 0x26, 0xfa, 0x78, 0x80, 0xc5, 0x80, 0xa6, 0x8c, 0x97, 0x51, 0xc1, 0x46,
+	0x38, 0x8b, 0xca, 0xbc, 0xea, 0xd3, 0x42, 0x44, 0xb3, 0x7e, 0xc1, 0x95, 0x3e, 0x63, 0xfd, 0x9c,
+	0x98, 0x20, 0x2f, 0xaa, 0xa3, 0x6d, 0x49, 0x47, 0x44, 0x48, 0x3c, 0x2a, 0xad, 0x43, 0x68, 0x89,
+	0xdb, 0x2e, 0xbd, 0x49, 0x67, 0x2c, 0xe3, 0x13, 0xfe, 0x05, 0xd0, 0x7b, 0x8c, 0xab, 0x22, 0x1d,
+	0xc4, 0xe4, 0x97, 0x8a, 0x08, 0x89, 0xd6, 0xa0, 0x91, 0x8e, 0x32, 0xdf, 0xdb, 0xf4, 0xb6, 0x96,
+	0x63, 0xf5, 0x89, 0x10, 0x2c, 0x62, 0xde, 0x17, 0xfe, 0xc2, 0x66, 0x63, 0x6b, 0x39, 0xd6, 0xdf,
+	0x68, 0x1f, 0x96, 0x39, 0x11, 0xac, 0xe2, 0x29, 0x11, 0x7e, 0x63, 0xd3, 0xdb, 0xea, 0x5c, 0xdf,
+	0x89, 0xfe, 0xa9, 0x70, 0x9b, 0xdf, 0xa4, 0x8c, 0x62, 0xc7, 0x8b, 0x4f, 0x43, 0xa0, 0x2b, 0xd0,
+	0x11, 0x32, 0x63, 0x95, 0x4c, 0x4a, 0x2c, 0x07, 0xfe, 0xa2, 0xce, 0x0e, 0x06, 0x3a, 0xc0, 0x72,
+	0x60, 0x1d, 0x08, 0xe7, 0xc6, 0x61, 0x69, 0xe2, 0x40, 0x38, 0xd7, 0x0e, 0x6b, 0xd0, 0x20, 0xc5,
+	0xd8, 0x6f, 0xea, 0x22, 0xd5, 0xa7, 0xaa, 0xbb, 0x12, 0x84, 0xfb, 0x2d, 0xed, 0xab, 0xbf, 0xd1,
+	0x25, 0x68, 0x4b, 0x2c, 0x86, 0x49, 0x46, 0xb9, 0xdf, 0xd6, 0x78, 0x4b, 0xd9, 0x7b, 0x94, 0xa3,
+	0xab, 0xb0, 0xea, 0xea, 0x49, 0x72, 0x3a, 0xa2, 0x52, 0xf8, 0xcb, 0x9b, 0xde, 0x56, 0x3b, 0x5e,
+	0x71, 0xf0, 0x63, 0x8d, 0xa2, 0x1d, 0x58, 0x7f, 0x81, 0x05, 0x4d, 0x93, 0x92, 0xb3, 0x94, 0x08,
+	0x91, 0xa4, 0x7d, 0xce, 0xaa, 0xd2, 0x07, 0xed, 0x8d, 0xf4, 0x7f, 0x07, 0xe6, 0xaf, 0x5d, 0xfd,
+	0x0f, 0xda, 0x83, 0xe6, 0x88, 0x55, 0x85, 0x14, 0x7e, 0x67, 0xb3, 0xb1, 0xd5, 0xb9, 0x7e, 0xad,
+	0x66, 0xab, 0x9e, 0x28, 0x52, 0x6c, 0xb9, 0xe8, 0x1b, 0x68, 0x65, 0x64, 0x4c, 0x55, 0xc7, 0xbb,
+	0x3a, 0xcc, 0x67, 0x35, 0xc3, 0xec, 0x69, 0x56, 0xec, 0xd8, 0x68, 0x00, 0x17, 0x0a, 0x22, 0x5f,
+	0x32, 0x3e, 0x4c, 0xa8, 0x60, 0x39, 0x96, 0x94, 0x15, 0x7e, 0x4f, 0x0f, 0xf1, 0x8b, 0x9a, 0x21,
+	0xf7, 0x0d, 0xff, 0x91, 0xa3, 0x1f, 0x96, 0x24, 0x8d, 0xd7, 0x8a, 0x33, 0x28, 0x0a, 0xa1, 0x57,
+	0xb0, 0xa4, 0xa4, 0x63, 0x26, 0x13, 0xce, 0x98, 0xf4, 0x57, 0x74, 0x8f, 0x3a, 0x05, 0x3b, 0x50,
+	0x58, 0xcc, 0x98, 0x44, 0x5b, 0xb0, 0x96, 0x91, 0x23, 0x5c, 0xe5, 0x32, 0x29, 0x69, 0x96, 0x8c,
+	0x58, 0x46, 0xfc, 0x55, 0x3d, 0x9a, 0x15, 0x8b, 0x1f, 0xd0, 0xec, 0x09, 0xcb, 0xc8, 0xb4, 0x27,
+	0x2d, 0x53, 0xe3, 0xb9, 0x36, 0xe3, 0xf9, 0xa8, 0x4c, 0xb5, 0xe7, 0x87, 0xd0, 0x4b, 0xcb, 0x4a,
+	0x10, 0xe9, 0x66, 0x73, 0x41, 0xbb, 0x75, 0x0d, 0x68, 0xa7, 0xf2, 0x3e, 0x00, 0xce, 0x73, 0xf6,
+	0x32, 0x49, 0x71, 0x29, 0x7c, 0xa4, 0x37, 0xce, 0xb2, 0x46, 0x76, 0x71, 0x29, 0x50, 0x08, 0xdd,
+	0x14, 0x97, 0xf8, 0x05, 0xcd, 0xa9, 0xa4, 0x44, 0xf8, 0xef, 0x6a, 0x87, 0x19, 0x4c, 0xed, 0x19,
+	0x41, 0xd2, 0x94, 0x8d, 0x4a, 0xb5, 0x19, 0x8e, 0x68, 0x4e, 0xfc, 0x75, 0x53, 0x90, 0x85, 0x0f,
+	0x0c, 0x8a, 0x36, 0xa0, 0x59, 0x15, 0x63, 0x42, 0x73, 0xff, 0x3d, 0x1d, 0xc6, 0x5a, 0x0a, 0x57,
+	0x7d, 0x39, 0x12, 0xfe, 0x86, 0xe6, 0x59, 0x0b, 0xfd, 0x00, 0x2b, 0x6a, 0xbf, 0x26, 0x05, 0x1e,
+	0x11, 0x51, 0xe2, 0x94, 0xf8, 0x17, 0xf5, 0x7c, 0x6e, 0xd6, 0x9c, 0xcf, 0x33, 0x41, 0xf8, 0xbe,
+	0xe3, 0xc6, 0xbd, 0x6a, 0xda, 0x44, 0xdf, 0x42, 0xab, 0xb2, 0x3b, 0xdc, 0xd7, 0x1b, 0x29, 0x8a,
+	0xea, 0x69, 0x4e, 0xf4, 0x4c, 0xd3, 0x62, 0x47, 0x47, 0x3f, 0x42, 0x4b, 0x9c, 0x88, 0x54, 0xe6,
+	0xc2, 0xbf, 0xa4, 0x23, 0x3d, 0xa8, 0x1b, 0x69, 0x46, 0x74, 0xa2, 0x43, 0x13, 0xe4, 0x61, 0x21,
+	0xf9, 0x49, 0xec, 0x42, 0x9a, 0x13, 0x89, 0x33, 0x56, 0xe4, 0x27, 0x89, 0xed, 0x52, 0xe0, 0x4e,
+	0xa4, 0x81, 0x63, 0xd3, 0xad, 0x2b, 0xd0, 0xe1, 0x44, 0x48, 0xc6, 0x89, 0x3e, 0xd8, 0x97, 0x8d,
+	0x38, 0x58, 0x68, 0x8f, 0xf2, 0xe0, 0x1e, 0x74, 0xa7, 0x53, 0x28, 0xb1, 0x18, 0x92, 0x13, 0x27,
+	0x72, 0x43, 0x72, 0x82, 0xd6, 0x61, 0x69, 0x8c, 0xf3, 0x8a, 0xf8, 0x0b, 0x1a, 0x33, 0xc6, 0xbd,
+	0x85, 0xbb, 0x5e, 0xb8, 0x07, 0x4d, 0xb3, 0x6c, 0x25, 0x28, 0x6a, 0x1e, 0x96, 0xa6, 0xbf, 0x15,
+	0x26, 0xd8, 0x91, 0xd4, 0xb4, 0xc5, 0x58, 0x7f, 0x2b, 0x6c, 0x80, 0x79, 0xa6, 0x75, 0x71, 0x31,
+	0xd6, 0xdf, 0xe1, 0xcf, 0xb0, 0xe2, 0x96, 0x2c, 0x4a, 0x56, 0x08, 0x82, 0xf6, 0xa1, 0x65, 0x05,
+	0xc4, 0xf7, 0xde, 0x32, 0xdb, 0x33, 0xbd, 0xb3, 0xe2, 0x72, 0x28, 0xb1, 0x24, 0xb1, 0x0b, 0x12,
+	0xf6, 0xa0, 0xf3, 0x1c, 0x53, 0x69, 0x5b, 0x1a, 0xfe, 0x04, 0x5d, 0x63, 0xfe, 0x4f, 0xe9, 0x1e,
+	0xc3, 0xea, 0xe1, 0xa0, 0x92, 0x19, 0x7b, 0x59, 0xb8, 0xab, 0x63, 0x03, 0x9a, 0x82, 0xf6, 0x0b,
+	0x9c, 0xdb, 0x0e, 0x59, 0x0b, 0x7d, 0x00, 0xdd, 0x3e, 0xc7, 0x29, 0x49, 0x4a, 0xc2, 0x29, 0xcb,
+	0x74, 0xaf, 0x1a, 0x71, 0x47, 0x63, 0x07, 0x1a, 0x0a, 0x11, 0xac, 0x9d, 0x46, 0x33, 0x15, 0x87,
+	0x03, 0xd8, 0x78, 0x56, 0x66, 0x2a, 0xe9, 0xe4, 0xc6, 0xb0, 0x89, 0x66, 0x6e, 0x1f, 0xef, 0x3f,
+	0xdf, 0x3e, 0xe1, 0x25, 0xb8, 0xf8, 0x5a, 0x26, 0x5b, 0xc4, 0x1a, 0xac, 0x7c, 0x47, 0xb8, 0xa0,
+	0xcc, 0xad, 0x32, 0xfc, 0x14, 0x56, 0x27, 0x88, 0xed, 0xad, 0x0f, 0xad, 0xb1, 0x81, 0xec, 0xca,
+	0x9d, 0x19, 0x7e, 0x02, 0x5d, 0xd5, 0xb7, 0x49, 0xe5, 0x01, 0xb4, 0x69, 0x21, 0x09, 0x1f, 0xdb,
+	0x26, 0x35, 0xe2, 0x89, 0x1d, 0x3e, 0x87, 0x9e, 0xf5, 0xb5, 0x61, 0xbf, 0x86, 0x25, 0xa1, 0x80,
+	0x39, 0x97, 0xf8, 0x14, 0x8b, 0xa1, 0x09, 0x64, 0xe8, 0xe1, 0x55, 0xe8, 0x1d, 0xea, 0x49, 0xbc,
+	0x79, 0x50, 0x4b, 0x6e, 0x50, 0x6a, 0xb1, 0xce, 0xd1, 0x2e, 0xff, 0x23, 0xb8, 0xb0, 0x3b, 0x20,
+	0xe9, 0xb0, 0x64, 0xb4, 0x90, 0x53, 0x4f, 0x04, 0x75, 0xcc, 0xec, 0xe9, 0xc9, 0x28, 0x0f, 0xd7,
+	0x01, 0x4d, 0xbb, 0x59, 0xf2, 0x10, 0x3a, 0x0f, 0x8f, 0x49, 0xea, 0x68, 0xb7, 0xa1, 0x9d, 0x11,
+	0x9c, 0xe5, 0xb4, 0x20, 0x76, 0x45, 0x41, 0x64, 0xde, 0x30, 0x91, 0x7b, 0xc3, 0x44, 0x4f, 0xdd,
+	0x1b, 0x26, 0x9e, 0xf8, 0xba, 0x17, 0xc9, 0xc2, 0xeb, 0x2f, 0x92, 0xc6, 0xe9, 0x8b, 0x24, 0xdc,
+	0x85, 0xae, 0x49, 0x66, 0x9b, 0xb7, 0x01, 0x4d, 0x56, 0xc9, 0xb2, 0x92, 0x3a, 0x57, 0x37, 0xb6,
+	0x16, 0xba, 0x0c, 0xcb, 0xe4, 0x98, 0xca, 0x24, 0x55, 0xb7, 0xc7, 0x82, 0x5e, 0x7e, 0x5b, 0x01,
+	0xbb, 0x2c, 0x23, 0xe1, 0x2b, 0x0f, 0xba, 0xd3, 0xdb, 0x5d, 0xe5, 0x2e, 0x69, 0x66, 0xdb, 0xa4,
+	0x3e, 0xff, 0x95, 0x3f, 0xd5, 0xd8, 0xc6, 0x74, 0x63, 0x51, 0x04, 0x8b, 0xea, 0x75, 0xe6, 0x2f,
+	0xbe, 0x75, 0xd9, 0xda, 0xef, 0xfa, 0x9f, 0x00, 0xed, 0x87, 0xf6, 0x14, 0xa2, 0x13, 0x68, 0x1a,
+	0xe9, 0x40, 0xb7, 0xce, 0xa5, 0xae, 0xc1, 0xed, 0x79, 0x69, 0x76, 0x7e, 0xef, 0x20, 0x01, 0x8b,
+	0x4a, 0x44, 0xd0, 0x8d, 0xba, 0x11, 0xa6, 0x14, 0x28, 0xb8, 0x39, 0x1f, 0x69, 0x92, 0xf4, 0x37,
+	0x68, 0x3b, 0x2d, 0x40, 0x77, 0xea, 0xc6, 0x38, 0xa3, 0x45, 0xc1, 0xdd, 0xf9, 0x89, 0x93, 0x02,
+	0x7e, 0xf7, 0x60, 0xf5, 0x8c, 0x1e, 0xa0, 0x2f, 0x6b, 0x5f, 0x91, 0x6f, 0x94, 0xac, 0xe0, 0xfe,
+	0xb9, 0xf9, 0x93, 0xb2, 0x7e, 0x85, 0x96, 0x15, 0x1e, 0x54, 0x7b, 0xa2, 0xb3, 0xda, 0x15, 0xdc,
+	0x99, 0x9b, 0x37, 0xc9, 0x7e, 0x0c, 0x4b, 0x5a, 0x54, 0x50, 0xed, 0xb1, 0x4e, 0x0b, 0x5f, 0x70,
+	0x6b, 0x4e, 0x96, 0xcb, 0xbb, 0xe3, 0xa9, 0xfd, 0x6f, 0x54, 0xa9, 0xfe, 0xfe, 0x9f, 0x91, 0xbb,
+	0xe0, 0xf6, 0xbc, 0xb4, 0xc9, 0xa2, 0x5f, 0x79, 0x00, 0xa7, 0xc2, 0x86, 0x3e, 0xaf, 0x1b, 0xe8,
+	0x35, 0xcd, 0x0c, 0xee, 0x9d, 0x87, 0x3a, 0x7d, 0x0e, 0x95, 0x1c, 0xd4, 0x3f, 0x87, 0x53, 0xba,
+	0x1b, 0xdc, 0x9c, 0x8f, 0x34, 0x49, 0xfa, 0x87, 0x07, 0x3d, 0x05, 0x1d, 0x4a, 0x4e, 0xf0, 0x88,
+	0x16, 0x7d, 0x74, 0xbf, 0xe6, 0x0d, 0xa4, 0x58, 0xe6, 0x16, 0xb2, 0x4c, 0x57, 0xca, 0x57, 0xe7,
+	0x0f, 0xe0, 0xca, 0xda, 0xf2, 0x76, 0xbc, 0x07, 0xad, 0xef, 0x97, 0x8c, 0x76, 0x36, 0xf5, 0xcf,
+	0x8d, 0xbf, 0x07, 0x00, 0x22, 0x98, 0xe7, 0x8e, 0x63, 0x0f, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -963,6 +1171,7 @@ type ExecutorClient interface {
 	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
 	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (Executor_StatsClient, error)
 	Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (*SignalResponse, error)
+	Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error)
 	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(ctx context.Context, opts ...grpc.CallOption) (Executor_ExecStreamingClient, error)
@@ -1062,6 +1271,15 @@ func (c *executorClient) Signal(ctx context.Context, in *SignalRequest, opts ...
 	return out, nil
 }
 
+func (c *executorClient) Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error) {
+	out := new(CheckpointResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.executor.proto.Executor/Checkpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *executorClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error) {
 	out := new(ExecResponse)
 	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.executor.proto.Executor/Exec", in, out, opts...)
@@ -1111,6 +1329,7 @@ type ExecutorServer interface {
 	Version(context.Context, *VersionRequest) (*VersionResponse, error)
 	Stats(*StatsRequest, Executor_StatsServer) error
 	Signal(context.Context, *SignalRequest) (*SignalResponse, error)
+	Checkpoint(context.Context, *CheckpointRequest) (*CheckpointResponse, error)
 	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(Executor_ExecStreamingServer) error
@@ -1141,6 +1360,9 @@ func (*UnimplementedExecutorServer) Stats(req *StatsRequest, srv Executor_StatsS
 func (*UnimplementedExecutorServer) Signal(ctx context.Context, req *SignalRequest) (*SignalResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Signal not implemented")
 }
+func (*UnimplementedExecutorServer) Checkpoint(ctx context.Context, req *CheckpointRequest) (*CheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Checkpoint not implemented")
+}
 func (*UnimplementedExecutorServer) Exec(ctx context.Context, req *ExecRequest) (*ExecResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
 }
@@ -1281,6 +1503,24 @@ func _Executor_Signal_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Executor_Checkpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).Checkpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.executor.proto.Executor/Checkpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).Checkpoint(ctx, req.(*CheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Executor_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ExecRequest)
 	if err := dec(in); err != nil {
@@ -1353,6 +1593,10 @@ var _Executor_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Signal",
 			Handler:    _Executor_Signal_Handler,
 		},
+		{
+			MethodName: "Checkpoint",
+			Handler:    _Executor_Checkpoint_Handler,
+		},
 		{
 			MethodName: "Exec",
 			Handler:    _Executor_Exec_Handler,
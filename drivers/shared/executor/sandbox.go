@@ -0,0 +1,97 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/hashicorp/nomad/helper/escapingfs"
+)
+
+const (
+	// UnveilModeRead grants read access to files and directory listings
+	UnveilModeRead = 'r'
+
+	// UnveilModeWrite grants write access to existing files
+	UnveilModeWrite = 'w'
+
+	// UnveilModeExecute grants permission to execute files
+	UnveilModeExecute = 'x'
+
+	// UnveilModeCreate grants permission to create and remove files,
+	// directories and other filesystem nodes
+	UnveilModeCreate = 'c'
+)
+
+// ParseUnveil parses an unveil rule of the form "mode:path", where mode is
+// any combination of "r", "w", "x" and "c" and path is an absolute path as
+// seen from inside the task's filesystem.
+func ParseUnveil(rule string) (string, string, error) {
+	mode, path, ok := strings.Cut(rule, ":")
+	if !ok {
+		return "", "", fmt.Errorf("unveil rule %q must be of the form mode:path", rule)
+	}
+	if mode == "" {
+		return "", "", fmt.Errorf("unveil rule %q must specify a mode", rule)
+	}
+	for _, m := range mode {
+		switch m {
+		case UnveilModeRead, UnveilModeWrite, UnveilModeExecute, UnveilModeCreate:
+		default:
+			return "", "", fmt.Errorf("unveil rule %q has invalid mode %q, must be a combination of r, w, x and c", rule, m)
+		}
+	}
+	if !filepath.IsAbs(path) {
+		return "", "", fmt.Errorf("unveil rule %q must use an absolute path", rule)
+	}
+	return mode, filepath.Clean(path), nil
+}
+
+// ValidateUnveil ensures each of the given unveil rules is well formed.
+func ValidateUnveil(rules []string) error {
+	for _, rule := range rules {
+		if _, _, err := ParseUnveil(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadSeccompProfile reads a Docker compatible seccomp profile from path. If
+// taskDir is set, path and any symlinks in it are resolved within the task
+// directory and must not escape it.
+func ReadSeccompProfile(taskDir, path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	if taskDir != "" {
+		escapes, err := escapingfs.PathEscapesAllocDir(taskDir, "", path)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve seccomp profile %q: %v", path, err)
+		}
+		if escapes {
+			return "", fmt.Errorf("seccomp profile %q escapes the task directory", path)
+		}
+
+		// symlinks are resolved within the task directory, so a link can't
+		// point the profile read outside of it
+		resolved, err := securejoin.SecureJoin(taskDir, path)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve seccomp profile %q: %v", path, err)
+		}
+		path = resolved
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read seccomp profile: %v", err)
+	}
+	if !json.Valid(b) {
+		return "", fmt.Errorf("seccomp profile %q is not valid JSON", path)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package executor
+
+// LandlockABI returns the landlock ABI version supported by the running
+// kernel, which is always 0 as landlock is only available on Linux.
+func LandlockABI() int {
+	return 0
+}
+
+// SeccompSupported returns whether seccomp filters can be applied to tasks,
+// which is never the case outside of Linux.
+func SeccompSupported() bool {
+	return false
+}
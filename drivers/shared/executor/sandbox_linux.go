@@ -0,0 +1,213 @@
+//go:build linux
+
+package executor
+
+import (
+	"fmt"
+	"os"
+	"unsafe"
+
+	dseccomp "github.com/docker/docker/profiles/seccomp"
+	lconfigs "github.com/opencontainers/runc/libcontainer/configs"
+	lseccomp "github.com/opencontainers/runc/libcontainer/seccomp"
+	"github.com/opencontainers/runc/libcontainer/specconv"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// landlockAccessFile is the set of landlock rights that may be granted
+	// on a regular file rather than a directory
+	landlockAccessFile = unix.LANDLOCK_ACCESS_FS_EXECUTE |
+		unix.LANDLOCK_ACCESS_FS_WRITE_FILE |
+		unix.LANDLOCK_ACCESS_FS_READ_FILE
+)
+
+// landlockInitRules are granted to processes joining a container with unveil
+// rules, in addition to the rules of the task, since libcontainer reads them
+// once the process is restricted. They only expose the process itself.
+var landlockInitRules = []string{
+	"r:/proc/self/fd",
+	"r:/proc/self/setgroups",
+	"r:/proc/self/status",
+}
+
+// unveilInitArgs returns the arguments of the libcontainer init process of a
+// task, which carry its unveil rules to the processes joining its container.
+func unveilInitArgs(rules []string) []string {
+	args := make([]string, 0, len(rules)+2)
+	args = append(args, os.Args[0], "libcontainer-shim")
+	return append(args, rules...)
+}
+
+// unveilInit applies the unveil rules given to a libcontainer init process
+// joining the container of a task. Landlock prevents a sandboxed process from
+// changing its mount topology, so the rules can't be applied to the initial
+// process of a container, which sets up its root filesystem. Processes joining
+// it are already in its namespaces, and are restricted before libcontainer
+// drops their privileges, applies the seccomp profile and execs the command.
+func unveilInit(args []string) error {
+	if len(args) == 0 || os.Getenv("_LIBCONTAINER_INITTYPE") != "setns" {
+		return nil
+	}
+	return applyLandlock(append(args, landlockInitRules...))
+}
+
+// LandlockABI returns the landlock ABI version supported by the running
+// kernel, or 0 if landlock is unavailable.
+func LandlockABI() int {
+	abi, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, 0, 0, unix.LANDLOCK_CREATE_RULESET_VERSION)
+	if errno != 0 {
+		return 0
+	}
+	return int(abi)
+}
+
+// landlockHandledAccess returns the filesystem rights enforced by a ruleset
+// for the given landlock ABI version.
+func landlockHandledAccess(abi int) uint64 {
+	access := uint64(unix.LANDLOCK_ACCESS_FS_EXECUTE |
+		unix.LANDLOCK_ACCESS_FS_WRITE_FILE |
+		unix.LANDLOCK_ACCESS_FS_READ_FILE |
+		unix.LANDLOCK_ACCESS_FS_READ_DIR |
+		unix.LANDLOCK_ACCESS_FS_REMOVE_DIR |
+		unix.LANDLOCK_ACCESS_FS_REMOVE_FILE |
+		unix.LANDLOCK_ACCESS_FS_MAKE_CHAR |
+		unix.LANDLOCK_ACCESS_FS_MAKE_DIR |
+		unix.LANDLOCK_ACCESS_FS_MAKE_REG |
+		unix.LANDLOCK_ACCESS_FS_MAKE_SOCK |
+		unix.LANDLOCK_ACCESS_FS_MAKE_FIFO |
+		unix.LANDLOCK_ACCESS_FS_MAKE_BLOCK |
+		unix.LANDLOCK_ACCESS_FS_MAKE_SYM)
+	if abi >= 2 {
+		access |= unix.LANDLOCK_ACCESS_FS_REFER
+	}
+	return access
+}
+
+// unveilAccess converts an unveil mode into landlock filesystem rights.
+func unveilAccess(mode string) uint64 {
+	var access uint64
+	for _, m := range mode {
+		switch m {
+		case UnveilModeRead:
+			access |= unix.LANDLOCK_ACCESS_FS_READ_FILE | unix.LANDLOCK_ACCESS_FS_READ_DIR
+		case UnveilModeWrite:
+			access |= unix.LANDLOCK_ACCESS_FS_WRITE_FILE
+		case UnveilModeExecute:
+			access |= unix.LANDLOCK_ACCESS_FS_EXECUTE
+		case UnveilModeCreate:
+			access |= unix.LANDLOCK_ACCESS_FS_REMOVE_DIR |
+				unix.LANDLOCK_ACCESS_FS_REMOVE_FILE |
+				unix.LANDLOCK_ACCESS_FS_MAKE_CHAR |
+				unix.LANDLOCK_ACCESS_FS_MAKE_DIR |
+				unix.LANDLOCK_ACCESS_FS_MAKE_REG |
+				unix.LANDLOCK_ACCESS_FS_MAKE_SOCK |
+				unix.LANDLOCK_ACCESS_FS_MAKE_FIFO |
+				unix.LANDLOCK_ACCESS_FS_MAKE_BLOCK |
+				unix.LANDLOCK_ACCESS_FS_MAKE_SYM |
+				unix.LANDLOCK_ACCESS_FS_REFER
+		}
+	}
+	return access
+}
+
+// applyLandlock restricts the calling thread to the filesystem access granted
+// by the given unveil rules. Any path not covered by a rule is inaccessible
+// once the ruleset is enforced.
+func applyLandlock(rules []string) error {
+	abi := LandlockABI()
+	if abi == 0 {
+		return fmt.Errorf("landlock is not supported by this kernel")
+	}
+	handled := landlockHandledAccess(abi)
+
+	attr := unix.LandlockRulesetAttr{Access_fs: handled}
+	fd, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, uintptr(unsafe.Pointer(&attr)), unsafe.Sizeof(attr), 0)
+	if errno != 0 {
+		return fmt.Errorf("failed to create landlock ruleset: %v", errno)
+	}
+	defer unix.Close(int(fd))
+
+	for _, rule := range rules {
+		mode, path, err := ParseUnveil(rule)
+		if err != nil {
+			return err
+		}
+		if err := addLandlockRule(int(fd), path, unveilAccess(mode)&handled); err != nil {
+			return err
+		}
+	}
+
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return fmt.Errorf("failed to set no_new_privs: %v", err)
+	}
+	if _, _, errno := unix.Syscall(unix.SYS_LANDLOCK_RESTRICT_SELF, fd, 0, 0); errno != 0 {
+		return fmt.Errorf("failed to enforce landlock ruleset: %v", errno)
+	}
+	return nil
+}
+
+// addLandlockRule grants access to path and everything beneath it.
+func addLandlockRule(rulesetFd int, path string, access uint64) error {
+	fd, err := unix.Open(path, unix.O_PATH|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open unveil path %q: %v", path, err)
+	}
+	defer unix.Close(fd)
+
+	var st unix.Stat_t
+	if err := unix.Fstat(fd, &st); err != nil {
+		return fmt.Errorf("failed to stat unveil path %q: %v", path, err)
+	}
+	if st.Mode&unix.S_IFMT != unix.S_IFDIR {
+		access &= landlockAccessFile
+	}
+	if access == 0 {
+		return nil
+	}
+
+	attr := unix.LandlockPathBeneathAttr{
+		Allowed_access: access,
+		Parent_fd:      int32(fd),
+	}
+	_, _, errno := unix.Syscall6(unix.SYS_LANDLOCK_ADD_RULE, uintptr(rulesetFd),
+		unix.LANDLOCK_RULE_PATH_BENEATH, uintptr(unsafe.Pointer(&attr)), 0, 0, 0)
+	if errno != 0 {
+		return fmt.Errorf("failed to unveil path %q: %v", path, errno)
+	}
+	return nil
+}
+
+// SeccompSupported returns whether seccomp filters can be applied to tasks,
+// which requires both kernel support and an executor built with libseccomp.
+func SeccompSupported() bool {
+	if major, _, _ := lseccomp.Version(); major == 0 {
+		return false
+	}
+
+	// probing with a nil filter fails with EFAULT rather than EINVAL when
+	// the kernel supports seccomp filter mode
+	err := unix.Prctl(unix.PR_SET_SECCOMP, unix.SECCOMP_MODE_FILTER, 0, 0, 0)
+	return err == unix.EFAULT
+}
+
+// seccompConfig converts the Docker compatible seccomp profile of the
+// command into its libcontainer representation. Syscall rules conditioned on
+// capabilities are evaluated against the given bounding set.
+func seccompConfig(command *ExecCommand, caps []string) (*lconfigs.Seccomp, error) {
+	spec := &specs.Spec{
+		Process: &specs.Process{
+			Capabilities: &specs.LinuxCapabilities{Bounding: caps},
+		},
+	}
+	profile, err := dseccomp.LoadProfile(command.SeccompProfile, spec)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, nil
+	}
+
+	return specconv.SetupSeccomp(profile)
+}
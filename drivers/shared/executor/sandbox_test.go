@@ -0,0 +1,73 @@
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSandbox_ParseUnveil(t *testing.T) {
+	ci.Parallel(t)
+
+	mode, path, err := ParseUnveil("rwc:/tmp/../var/tmp/")
+	require.NoError(t, err)
+	require.Equal(t, "rwc", mode)
+	require.Equal(t, "/var/tmp", path)
+
+	_, _, err = ParseUnveil("r:local")
+	require.EqualError(t, err, `unveil rule "r:local" must use an absolute path`)
+}
+
+func TestSandbox_ReadSeccompProfile(t *testing.T) {
+	ci.Parallel(t)
+
+	taskDir := t.TempDir()
+	profile := `{"defaultAction": "SCMP_ACT_ALLOW"}`
+	require.NoError(t, os.MkdirAll(filepath.Join(taskDir, "local"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(taskDir, "local", "seccomp.json"), []byte(profile), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(taskDir, "local", "bad.json"), []byte("{"), 0644))
+
+	t.Run("task", func(t *testing.T) {
+		result, err := ReadSeccompProfile(taskDir, "local/seccomp.json")
+		require.NoError(t, err)
+		require.Equal(t, profile, result)
+
+		// absolute paths are resolved inside the task directory
+		result, err = ReadSeccompProfile(taskDir, "/local/seccomp.json")
+		require.NoError(t, err)
+		require.Equal(t, profile, result)
+	})
+
+	t.Run("host", func(t *testing.T) {
+		result, err := ReadSeccompProfile("", filepath.Join(taskDir, "local", "seccomp.json"))
+		require.NoError(t, err)
+		require.Equal(t, profile, result)
+	})
+
+	t.Run("escape", func(t *testing.T) {
+		_, err := ReadSeccompProfile(taskDir, "../../etc/passwd")
+		require.EqualError(t, err, `seccomp profile "../../etc/passwd" escapes the task directory`)
+
+		// symlinks are resolved inside the task directory
+		host := filepath.Join(t.TempDir(), "seccomp.json")
+		require.NoError(t, os.WriteFile(host, []byte(profile), 0644))
+		require.NoError(t, os.Symlink(host, filepath.Join(taskDir, "local", "link.json")))
+
+		_, err = ReadSeccompProfile(taskDir, "local/link.json")
+		require.ErrorContains(t, err, "failed to read seccomp profile")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ReadSeccompProfile(taskDir, "local/bad.json")
+		require.Error(t, err)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		result, err := ReadSeccompProfile(taskDir, "")
+		require.NoError(t, err)
+		require.Empty(t, result)
+	})
+}
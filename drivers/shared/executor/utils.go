@@ -127,6 +127,28 @@ func processStateFromProto(pb *proto.ProcessState) (*ProcessState, error) {
 	}, nil
 }
 
+func ulimitsToProto(ulimits []*Ulimit) []*proto.Ulimit {
+	if len(ulimits) == 0 {
+		return nil
+	}
+	pb := make([]*proto.Ulimit, len(ulimits))
+	for i, u := range ulimits {
+		pb[i] = &proto.Ulimit{Name: u.Name, Soft: u.Soft, Hard: u.Hard}
+	}
+	return pb
+}
+
+func ulimitsFromProto(pb []*proto.Ulimit) []*Ulimit {
+	if len(pb) == 0 {
+		return nil
+	}
+	ulimits := make([]*Ulimit, len(pb))
+	for i, u := range pb {
+		ulimits[i] = &Ulimit{Name: u.Name, Soft: u.Soft, Hard: u.Hard}
+	}
+	return ulimits
+}
+
 // IsolationMode returns the namespace isolation mode as determined from agent
 // plugin configuration and task driver configuration. The task configuration
 // takes precedence, if it is configured.
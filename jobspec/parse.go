@@ -305,6 +305,7 @@ func parseSpread(result *[]*api.Spread, list *ast.ObjectList) error {
 			"attribute",
 			"weight",
 			"target",
+			"max_skew",
 		}
 		if err := checkHCLKeys(o.Val, valid); err != nil {
 			return err
@@ -467,6 +468,7 @@ func parseMigrate(result **api.MigrateStrategy, list *ast.ObjectList) error {
 		"health_check",
 		"min_healthy_time",
 		"healthy_deadline",
+		"checkpoint",
 	}
 	if err := checkHCLKeys(o.Val, valid); err != nil {
 		return err
@@ -58,6 +58,9 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			"scaling",
 			"stop_after_client_disconnect",
 			"max_client_disconnect",
+			"gang",
+			"preemption",
+			"window",
 		}
 		if err := checkHCLKeys(listVal, valid); err != nil {
 			return multierror.Prefix(err, fmt.Sprintf("'%s' ->", n))
@@ -83,6 +86,9 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 		delete(m, "service")
 		delete(m, "volume")
 		delete(m, "scaling")
+		delete(m, "gang")
+		delete(m, "preemption")
+		delete(m, "window")
 
 		// Build the group with the basic decode
 		var g api.TaskGroup
@@ -172,6 +178,27 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			}
 		}
 
+		// Parse the gang strategy
+		if o := listVal.Filter("gang"); len(o.Items) > 0 {
+			if err := parseGang(&g.Gang, o); err != nil {
+				return multierror.Prefix(err, "gang ->")
+			}
+		}
+
+		// Parse the preemption policy
+		if o := listVal.Filter("preemption"); len(o.Items) > 0 {
+			if err := parsePreemption(&g.Preemption, o); err != nil {
+				return multierror.Prefix(err, "preemption ->")
+			}
+		}
+
+		// Parse the scheduling window
+		if o := listVal.Filter("window"); len(o.Items) > 0 {
+			if err := parseWindow(&g.Window, o); err != nil {
+				return multierror.Prefix(err, "window ->")
+			}
+		}
+
 		// Parse out meta fields. These are in HCL as a list so we need
 		// to iterate over them and merge them.
 		if metaO := listVal.Filter("meta"); len(metaO.Items) > 0 {
@@ -302,6 +329,104 @@ func parseEphemeralDisk(result **api.EphemeralDisk, list *ast.ObjectList) error
 	return nil
 }
 
+func parseGang(result **api.GangStrategy, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return fmt.Errorf("only one 'gang' block allowed")
+	}
+
+	// Get our gang object
+	obj := list.Items[0]
+
+	// Check for invalid keys
+	valid := []string{
+		"scope",
+	}
+	if err := checkHCLKeys(obj.Val, valid); err != nil {
+		return err
+	}
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, obj.Val); err != nil {
+		return err
+	}
+
+	var gang api.GangStrategy
+	if err := mapstructure.WeakDecode(m, &gang); err != nil {
+		return err
+	}
+	*result = &gang
+
+	return nil
+}
+
+func parsePreemption(result **api.PreemptionPolicy, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return fmt.Errorf("only one 'preemption' block allowed")
+	}
+
+	// Get our preemption object
+	obj := list.Items[0]
+
+	// Check for invalid keys
+	valid := []string{
+		"max_preemptions",
+		"protected_priority",
+		"protected",
+	}
+	if err := checkHCLKeys(obj.Val, valid); err != nil {
+		return err
+	}
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, obj.Val); err != nil {
+		return err
+	}
+
+	var preemption api.PreemptionPolicy
+	if err := mapstructure.WeakDecode(m, &preemption); err != nil {
+		return err
+	}
+	*result = &preemption
+
+	return nil
+}
+
+func parseWindow(result **api.SchedulingWindow, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return fmt.Errorf("only one 'window' block allowed")
+	}
+
+	// Get our window object
+	obj := list.Items[0]
+
+	// Check for invalid keys
+	valid := []string{
+		"start",
+		"end",
+		"time_zone",
+		"days",
+	}
+	if err := checkHCLKeys(obj.Val, valid); err != nil {
+		return err
+	}
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, obj.Val); err != nil {
+		return err
+	}
+
+	var window api.SchedulingWindow
+	if err := mapstructure.WeakDecode(m, &window); err != nil {
+		return err
+	}
+	*result = &window
+
+	return nil
+}
+
 func parseRestartPolicy(final **api.RestartPolicy, list *ast.ObjectList) error {
 	list = list.Elem()
 	if len(list.Items) > 1 {
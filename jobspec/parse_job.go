@@ -38,6 +38,8 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 	delete(m, "vault")
 	delete(m, "spread")
 	delete(m, "multiregion")
+	delete(m, "preemption")
+	delete(m, "window")
 
 	// Set the ID and name to the object key
 	result.ID = stringToPtr(obj.Keys[0].Token.Value().(string))
@@ -69,6 +71,7 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		"migrate",
 		"name",
 		"namespace",
+		"node_pool",
 		"parameterized",
 		"periodic",
 		"priority",
@@ -81,6 +84,8 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		"vault_token",
 		"consul_token",
 		"multiregion",
+		"preemption",
+		"window",
 	}
 	if err := checkHCLKeys(listVal, valid); err != nil {
 		return multierror.Prefix(err, "job:")
@@ -142,6 +147,20 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		}
 	}
 
+	// If we have a preemption block, then parse that
+	if o := listVal.Filter("preemption"); len(o.Items) > 0 {
+		if err := parsePreemption(&result.Preemption, o); err != nil {
+			return multierror.Prefix(err, "preemption ->")
+		}
+	}
+
+	// If we have a window block, then parse that
+	if o := listVal.Filter("window"); len(o.Items) > 0 {
+		if err := parseWindow(&result.Window, o); err != nil {
+			return multierror.Prefix(err, "window ->")
+		}
+	}
+
 	// If we have a multiregion block, then parse that
 	if o := listVal.Filter("multiregion"); len(o.Items) > 0 {
 		var mr api.Multiregion
@@ -606,6 +606,7 @@ func parseResources(result *api.Resources, list *ast.ObjectList) error {
 		"network",
 		"device",
 		"cores",
+		"numa",
 	}
 	if err := checkHCLKeys(listVal, valid); err != nil {
 		return multierror.Prefix(err, "resources ->")
@@ -617,6 +618,7 @@ func parseResources(result *api.Resources, list *ast.ObjectList) error {
 	}
 	delete(m, "network")
 	delete(m, "device")
+	delete(m, "numa")
 
 	if err := mapstructure.WeakDecode(m, result); err != nil {
 		return err
@@ -695,6 +697,27 @@ func parseResources(result *api.Resources, list *ast.ObjectList) error {
 		}
 	}
 
+	// Parse the NUMA affinity
+	if o := listVal.Filter("numa"); len(o.Items) > 0 {
+		if len(o.Items) > 1 {
+			return fmt.Errorf("only one 'numa' block allowed per resources")
+		}
+		no := o.Items[0]
+		if err := checkHCLKeys(no.Val, []string{"affinity"}); err != nil {
+			return multierror.Prefix(err, "resources, numa ->")
+		}
+
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, no.Val); err != nil {
+			return err
+		}
+		var numa api.NUMAResource
+		if err := mapstructure.WeakDecode(m, &numa); err != nil {
+			return err
+		}
+		result.NUMA = &numa
+	}
+
 	return nil
 }
 
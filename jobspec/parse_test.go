@@ -1108,6 +1108,7 @@ func TestParse(t *testing.T) {
 							HealthCheck:     stringToPtr("checks"),
 							MinHealthyTime:  timeToPtr(1 * time.Second),
 							HealthyDeadline: timeToPtr(1 * time.Minute),
+							Checkpoint:      boolToPtr(true),
 						},
 						Tasks: []*api.Task{
 							{
@@ -1815,6 +1816,142 @@ func TestParse(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"resources-numa.hcl",
+			&api.Job{
+				ID:   stringToPtr("numa-test"),
+				Name: stringToPtr("numa-test"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("group"),
+						Tasks: []*api.Task{
+							{
+								Name:   "task",
+								Driver: "docker",
+								Resources: &api.Resources{
+									Cores:    intToPtr(4),
+									MemoryMB: intToPtr(128),
+									NUMA: &api.NUMAResource{
+										Affinity: "require",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"group-gang.hcl",
+			&api.Job{
+				ID:   stringToPtr("gang-test"),
+				Name: stringToPtr("gang-test"),
+				Type: stringToPtr("batch"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name:  stringToPtr("group"),
+						Count: intToPtr(4),
+						Gang: &api.GangStrategy{
+							Scope: stringToPtr("job"),
+						},
+						Tasks: []*api.Task{
+							{
+								Name:   "task",
+								Driver: "docker",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"preemption.hcl",
+			&api.Job{
+				ID:       stringToPtr("preemption-test"),
+				Name:     stringToPtr("preemption-test"),
+				Priority: intToPtr(80),
+				Preemption: &api.PreemptionPolicy{
+					MaxPreemptions:    intToPtr(5),
+					ProtectedPriority: intToPtr(60),
+				},
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("web"),
+						Preemption: &api.PreemptionPolicy{
+							Protected: boolToPtr(true),
+						},
+						Tasks: []*api.Task{
+							{
+								Name:   "server",
+								Driver: "docker",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"spread-max-skew.hcl",
+			&api.Job{
+				ID:   stringToPtr("spread-max-skew"),
+				Name: stringToPtr("spread-max-skew"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("web"),
+						Spreads: []*api.Spread{
+							{
+								Attribute: "${meta.zone}",
+								MaxSkew:   intToPtr(1),
+							},
+							{
+								Attribute: "${meta.rack}",
+								Weight:    int8ToPtr(50),
+								MaxSkew:   intToPtr(2),
+							},
+						},
+						Tasks: []*api.Task{
+							{
+								Name:   "server",
+								Driver: "docker",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"window.hcl",
+			&api.Job{
+				ID:   stringToPtr("window-test"),
+				Name: stringToPtr("window-test"),
+				Window: &api.SchedulingWindow{
+					Start:    stringToPtr("22:00"),
+					End:      stringToPtr("06:00"),
+					TimeZone: stringToPtr("Europe/Berlin"),
+					Days:     []string{"sat", "sun"},
+				},
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("web"),
+						Window: &api.SchedulingWindow{
+							Start: stringToPtr("02:00"),
+							End:   stringToPtr("04:00"),
+						},
+						Tasks: []*api.Task{
+							{
+								Name:   "server",
+								Driver: "docker",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
 		{
 			"service-provider.hcl",
 			&api.Job{
@@ -0,0 +1,15 @@
+job "gang-test" {
+  type = "batch"
+
+  group "group" {
+    count = 4
+
+    gang {
+      scope = "job"
+    }
+
+    task "task" {
+      driver = "docker"
+    }
+  }
+}
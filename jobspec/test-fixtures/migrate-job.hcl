@@ -26,6 +26,7 @@ job "foo" {
       health_check     = "checks"
       min_healthy_time = "1s"
       healthy_deadline = "1m"
+      checkpoint       = true
     }
   }
 }
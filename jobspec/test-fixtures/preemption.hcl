@@ -0,0 +1,18 @@
+job "preemption-test" {
+  priority = 80
+
+  preemption {
+    max_preemptions    = 5
+    protected_priority = 60
+  }
+
+  group "web" {
+    preemption {
+      protected = true
+    }
+
+    task "server" {
+      driver = "docker"
+    }
+  }
+}
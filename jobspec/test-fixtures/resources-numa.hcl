@@ -0,0 +1,16 @@
+job "numa-test" {
+  group "group" {
+    task "task" {
+      driver = "docker"
+
+      resources {
+        cores  = 4
+        memory = 128
+
+        numa {
+          affinity = "require"
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,18 @@
+job "spread-max-skew" {
+  group "web" {
+    spread {
+      attribute = "${meta.zone}"
+      max_skew  = 1
+    }
+
+    spread {
+      attribute = "${meta.rack}"
+      weight    = 50
+      max_skew  = 2
+    }
+
+    task "server" {
+      driver = "docker"
+    }
+  }
+}
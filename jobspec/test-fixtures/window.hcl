@@ -0,0 +1,19 @@
+job "window-test" {
+  window {
+    start     = "22:00"
+    end       = "06:00"
+    time_zone = "Europe/Berlin"
+    days      = ["sat", "sun"]
+  }
+
+  group "web" {
+    window {
+      start = "02:00"
+      end   = "04:00"
+    }
+
+    task "server" {
+      driver = "docker"
+    }
+  }
+}
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// bindNameInterpolationRegex matches the "${value.<key>}" variables which
+// can be used within a binding rule bind name.
+var bindNameInterpolationRegex = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// BinderStateStore is the subset of state store methods used by the binder.
+type BinderStateStore interface {
+	GetACLBindingRulesByAuthMethod(ws memdb.WatchSet, authMethod string) (memdb.ResultIterator, error)
+	GetACLRoleByName(ws memdb.WatchSet, roleName string) (*structs.ACLRole, error)
+	ACLPolicyByName(ws memdb.WatchSet, name string) (*structs.ACLPolicy, error)
+}
+
+// Binder is responsible for collecting the ACL roles and policies to be
+// assigned to a token generated as a result of "logging in" via an auth
+// method.
+type Binder struct {
+	store BinderStateStore
+}
+
+// NewBinder creates a Binder with the given state store.
+func NewBinder(store BinderStateStore) *Binder {
+	return &Binder{store: store}
+}
+
+// Bindings contains the ACL roles and policies to be assigned to the created
+// token.
+type Bindings struct {
+	Management bool
+	Roles      []*structs.ACLTokenRoleLink
+	Policies   []string
+}
+
+// None indicates that the resulting bindings would not give the created
+// token access to any resources.
+func (b *Bindings) None() bool {
+	if b == nil {
+		return true
+	}
+	return !b.Management && len(b.Policies) == 0 && len(b.Roles) == 0
+}
+
+// Bind collects the ACL roles and policies to be assigned to the created
+// token, by evaluating the binding rules of the auth method against the
+// verified identity. Roles and policies which do not exist are ignored.
+func (b *Binder) Bind(authMethod *structs.ACLAuthMethod, identity *Identity) (*Bindings, error) {
+	var bindings Bindings
+
+	// Load the auth method's binding rules.
+	rulesIterator, err := b.store.GetACLBindingRulesByAuthMethod(nil, authMethod.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the rules with selectors that match the identity's fields.
+	var matchingRules []*structs.ACLBindingRule
+	for raw := rulesIterator.Next(); raw != nil; raw = rulesIterator.Next() {
+		rule := raw.(*structs.ACLBindingRule)
+		if doesSelectorMatch(rule.Selector, identity.Claims) {
+			matchingRules = append(matchingRules, rule)
+		}
+	}
+	if len(matchingRules) == 0 {
+		return &bindings, nil
+	}
+
+	// Compute role or policy names by interpolating the identity's claim
+	// mappings into the rule BindName templates.
+	for _, rule := range matchingRules {
+		bindName, valid, err := computeBindName(rule.BindType, rule.BindName, identity.ClaimMappings)
+		switch {
+		case err != nil:
+			return nil, fmt.Errorf("cannot compute %q bind name for bind target: %w", rule.BindType, err)
+		case !valid:
+			return nil, fmt.Errorf("computed %q bind name for bind target is invalid: %q", rule.BindType, bindName)
+		}
+
+		switch rule.BindType {
+		case structs.ACLBindingRuleBindTypeRole:
+			role, err := b.store.GetACLRoleByName(nil, bindName)
+			if err != nil {
+				return nil, err
+			}
+
+			if role != nil {
+				bindings.Roles = append(bindings.Roles, &structs.ACLTokenRoleLink{
+					ID: role.ID,
+				})
+			}
+		case structs.ACLBindingRuleBindTypePolicy:
+			policy, err := b.store.ACLPolicyByName(nil, bindName)
+			if err != nil {
+				return nil, err
+			}
+
+			if policy != nil {
+				bindings.Policies = append(bindings.Policies, policy.Name)
+			}
+		case structs.ACLBindingRuleBindTypeManagement:
+			bindings.Management = true
+		}
+	}
+
+	return &bindings, nil
+}
+
+// computeBindName interpolates the claim mappings into the bind name of a
+// rule. When the bind type is management, the bind name is not used and is
+// therefore always valid.
+//
+// - If the bind name references an unknown variable ("", false, AN_ERROR) is returned.
+// - If the computed name is empty ("", false, nil) is returned.
+// - If the computed name is valid ("VALID_NAME", true, nil) is returned.
+func computeBindName(bindType, bindName string, claimMappings map[string]string) (string, bool, error) {
+	if bindType == structs.ACLBindingRuleBindTypeManagement {
+		return "", true, nil
+	}
+
+	var interpErr error
+	result := bindNameInterpolationRegex.ReplaceAllStringFunc(bindName, func(match string) string {
+		variable := bindNameInterpolationRegex.FindStringSubmatch(match)[1]
+
+		const prefix = "value."
+		if len(variable) <= len(prefix) || variable[:len(prefix)] != prefix {
+			interpErr = fmt.Errorf("unknown variable %q", variable)
+			return ""
+		}
+		val, ok := claimMappings[variable[len(prefix):]]
+		if !ok {
+			interpErr = fmt.Errorf("unknown variable %q", variable)
+			return ""
+		}
+		return val
+	})
+	if interpErr != nil {
+		return "", false, interpErr
+	}
+
+	return result, result != "", nil
+}
+
+// doesSelectorMatch checks that a single selector matches the provided vars.
+// Evaluation errors, such as referencing a field which does not exist, are
+// treated as a failure to match.
+func doesSelectorMatch(selector string, selectableVars interface{}) bool {
+	if selector == "" {
+		return true // catch-all
+	}
+
+	eval, err := bexpr.CreateEvaluator(selector)
+	if err != nil {
+		return false // fails to match if selector is invalid
+	}
+
+	result, err := eval.Evaluate(selectableVars)
+	if err != nil {
+		return false // fails to match if evaluation fails
+	}
+
+	return result
+}
@@ -0,0 +1,277 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinder_Bind(t *testing.T) {
+	ci.Parallel(t)
+
+	testStore := state.TestStateStore(t)
+	testBind := NewBinder(testStore)
+
+	// create an authMethod method and insert into the state store
+	authMethod := mock.ACLAuthMethod()
+	require.NoError(t, testStore.UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 0, []*structs.ACLAuthMethod{authMethod}))
+
+	// create some roles and insert into the state store
+	targetRole := &structs.ACLRole{
+		ID:       "vu8rf8vc-yvn7-fl6p-2yoj-i5vn6upspsiz",
+		Name:     "vu8rf8vc",
+		Policies: []*structs.ACLRolePolicyLink{{Name: "test-policy"}},
+	}
+	otherRole := &structs.ACLRole{
+		ID:       "7x9l9mbi-bu7d-d4n9-8hzi-rnjuzlt6y5w3",
+		Name:     "7x9l9mbi",
+		Policies: []*structs.ACLRolePolicyLink{{Name: "test-policy"}},
+	}
+	require.NoError(t, testStore.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 0, []*structs.ACLRole{targetRole, otherRole}, true))
+
+	// create a policy which the binding rules can target
+	targetPolicy := mock.ACLPolicy()
+	targetPolicy.Name = "engineering-policy"
+	require.NoError(t, testStore.UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 0, []*structs.ACLPolicy{targetPolicy}))
+
+	// create binding rules and insert into the state store
+	bindingRules := []*structs.ACLBindingRule{
+		{
+			ID:         "4c8c8ac5-7e40-4e43-a9b1-f0c0a2a9ba3a",
+			Selector:   "value.role==engineer",
+			BindType:   structs.ACLBindingRuleBindTypeRole,
+			BindName:   "${value.editor}",
+			AuthMethod: authMethod.Name,
+		},
+		{
+			ID:         "2c5a7e84-1234-4a2f-8a3e-8c3bb5f2a1d2",
+			Selector:   "engineering in list.groups",
+			BindType:   structs.ACLBindingRuleBindTypePolicy,
+			BindName:   "engineering-policy",
+			AuthMethod: authMethod.Name,
+		},
+		{
+			ID:         "d1d8a4f5-5f2e-4b0d-9c5a-1c0f6b7e3a9f",
+			Selector:   "admin in list.groups",
+			BindType:   structs.ACLBindingRuleBindTypeManagement,
+			AuthMethod: authMethod.Name,
+		},
+	}
+	require.NoError(t, testStore.UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 0, bindingRules, true))
+
+	tests := []struct {
+		name       string
+		authMethod *structs.ACLAuthMethod
+		identity   *Identity
+		want       *Bindings
+		wantErr    bool
+	}{
+		{
+			name:       "empty identity",
+			authMethod: authMethod,
+			identity:   &Identity{},
+			want:       &Bindings{},
+			wantErr:    false,
+		},
+		{
+			name:       "role",
+			authMethod: authMethod,
+			identity: &Identity{
+				Claims: &SelectorData{
+					Value: map[string]string{"role": "engineer"},
+				},
+				ClaimMappings: map[string]string{
+					"editor": "vu8rf8vc",
+				},
+			},
+			want:    &Bindings{Roles: []*structs.ACLTokenRoleLink{{ID: targetRole.ID}}},
+			wantErr: false,
+		},
+		{
+			name:       "unknown bind name variable",
+			authMethod: authMethod,
+			identity: &Identity{
+				Claims: &SelectorData{
+					Value: map[string]string{"role": "engineer"},
+				},
+				ClaimMappings: map[string]string{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:       "policy",
+			authMethod: authMethod,
+			identity: &Identity{
+				Claims: &SelectorData{
+					List: map[string][]string{"groups": {"engineering"}},
+				},
+			},
+			want:    &Bindings{Policies: []string{"engineering-policy"}},
+			wantErr: false,
+		},
+		{
+			name:       "management",
+			authMethod: authMethod,
+			identity: &Identity{
+				Claims: &SelectorData{
+					List: map[string][]string{"groups": {"admin"}},
+				},
+			},
+			want:    &Bindings{Management: true},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testBind.Bind(tt.authMethod, tt.identity)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBindings_None(t *testing.T) {
+	ci.Parallel(t)
+
+	var nilBindings *Bindings
+	require.True(t, nilBindings.None())
+	require.True(t, (&Bindings{}).None())
+	require.False(t, (&Bindings{Management: true}).None())
+	require.False(t, (&Bindings{Policies: []string{"policy"}}).None())
+	require.False(t, (&Bindings{Roles: []*structs.ACLTokenRoleLink{{ID: "role"}}}).None())
+}
+
+func Test_computeBindName(t *testing.T) {
+	ci.Parallel(t)
+
+	tests := []struct {
+		name          string
+		bindType      string
+		bindName      string
+		claimMappings map[string]string
+		wantName      string
+		wantTrue      bool
+		wantErr       bool
+	}{
+		{
+			name:          "valid bind name and type",
+			bindType:      structs.ACLBindingRuleBindTypeRole,
+			bindName:      "cluster-admin",
+			claimMappings: map[string]string{"cluster-admin": "root"},
+			wantName:      "cluster-admin",
+			wantTrue:      true,
+			wantErr:       false,
+		},
+		{
+			name:          "interpolated bind name",
+			bindType:      structs.ACLBindingRuleBindTypePolicy,
+			bindName:      "team-${value.team}",
+			claimMappings: map[string]string{"team": "web"},
+			wantName:      "team-web",
+			wantTrue:      true,
+			wantErr:       false,
+		},
+		{
+			name:          "invalid type",
+			bindType:      "amazing",
+			bindName:      "cluster-admin",
+			claimMappings: map[string]string{"cluster-admin": "root"},
+			wantName:      "cluster-admin",
+			wantTrue:      true,
+			wantErr:       false,
+		},
+		{
+			name:          "empty computed name",
+			bindType:      structs.ACLBindingRuleBindTypeRole,
+			bindName:      "${value.team}",
+			claimMappings: map[string]string{"team": ""},
+			wantName:      "",
+			wantTrue:      false,
+			wantErr:       false,
+		},
+		{
+			name:          "unknown variable",
+			bindType:      structs.ACLBindingRuleBindTypeRole,
+			bindName:      "${team}",
+			claimMappings: map[string]string{"team": "web"},
+			wantName:      "",
+			wantTrue:      false,
+			wantErr:       true,
+		},
+		{
+			name:          "management bind type",
+			bindType:      structs.ACLBindingRuleBindTypeManagement,
+			bindName:      "",
+			claimMappings: nil,
+			wantName:      "",
+			wantTrue:      true,
+			wantErr:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1, err := computeBindName(tt.bindType, tt.bindName, tt.claimMappings)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantName, got)
+			require.Equal(t, tt.wantTrue, got1)
+		})
+	}
+}
+
+func Test_doesSelectorMatch(t *testing.T) {
+	ci.Parallel(t)
+
+	tests := []struct {
+		name           string
+		selector       string
+		selectableVars interface{}
+		want           bool
+	}{
+		{
+			name:           "catch-all",
+			selector:       "",
+			selectableVars: nil,
+			want:           true,
+		},
+		{
+			name:           "valid selector but no selectable vars",
+			selector:       "nomad_engineering_team in Groups",
+			selectableVars: "",
+			want:           false,
+		},
+		{
+			name:           "valid selector and successful evaluation",
+			selector:       "nomad_engineering_team in Groups",
+			selectableVars: map[string][]string{"Groups": {"nomad_sales_team", "nomad_engineering_team"}},
+			want:           true,
+		},
+		{
+			name:           "invalid selector",
+			selector:       "this is not == valid",
+			selectableVars: map[string][]string{"Groups": {"nomad_engineering_team"}},
+			want:           false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, doesSelectorMatch(tt.selector, tt.selectableVars))
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// SelectorData is the data made available to binding rule selectors. Scalar
+// claims mapped using ClaimMappings are available under the "value" prefix and
+// list claims mapped using ListClaimMappings under the "list" prefix.
+type SelectorData struct {
+	Value map[string]string   `bexpr:"value"`
+	List  map[string][]string `bexpr:"list"`
+}
+
+// Identity is the verified identity of a user which is logging in, derived
+// from the claims of their identity token.
+type Identity struct {
+
+	// Claims is the data that binding rule selectors are evaluated against.
+	Claims *SelectorData
+
+	// ClaimMappings contains the scalar mapped claims which can be
+	// interpolated into binding rule bind names using the "value" prefix.
+	ClaimMappings map[string]string
+}
+
+// NewIdentity builds the identity of a user from the verified claims of their
+// token, using the claim mappings of the auth method config. Every mapped key
+// is present within the result, even when the claim was not found, so that
+// selectors can always reference it.
+func NewIdentity(config *structs.ACLAuthMethodConfig, claims map[string]interface{}) (*Identity, error) {
+
+	data := &SelectorData{
+		Value: make(map[string]string, len(config.ClaimMappings)),
+		List:  make(map[string][]string, len(config.ListClaimMappings)),
+	}
+
+	for claim, key := range config.ClaimMappings {
+		data.Value[key] = ""
+
+		raw, ok := getClaim(claims, claim)
+		if !ok {
+			continue
+		}
+		val, err := stringifyClaim(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map claim %q: %v", claim, err)
+		}
+		data.Value[key] = val
+	}
+
+	for claim, key := range config.ListClaimMappings {
+		data.List[key] = []string{}
+
+		raw, ok := getClaim(claims, claim)
+		if !ok {
+			continue
+		}
+
+		// Treat a single scalar value as a list containing one element, as
+		// some providers do not return a list when there is only one item.
+		items, ok := raw.([]interface{})
+		if !ok {
+			items = []interface{}{raw}
+		}
+		for _, item := range items {
+			val, err := stringifyClaim(item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to map list claim %q: %v", claim, err)
+			}
+			data.List[key] = append(data.List[key], val)
+		}
+	}
+
+	return &Identity{
+		Claims:        data,
+		ClaimMappings: data.Value,
+	}, nil
+}
+
+// getClaim returns the claim identified by name. Nested claims can be
+// referenced using a JSON pointer style name such as "/groups/primary".
+func getClaim(claims map[string]interface{}, name string) (interface{}, bool) {
+	if !strings.HasPrefix(name, "/") {
+		val, ok := claims[name]
+		return val, ok
+	}
+
+	var current interface{} = claims
+	for _, part := range strings.Split(strings.TrimPrefix(name, "/"), "/") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// stringifyClaim converts a scalar claim value into a string. Lists and
+// objects cannot be converted and return an error.
+func stringifyClaim(raw interface{}) (string, error) {
+	switch v := raw.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case json.Number:
+		return v.String(), nil
+	default:
+		return "", fmt.Errorf("unsupported claim value type %T", raw)
+	}
+}
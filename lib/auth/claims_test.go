@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIdentity(t *testing.T) {
+	ci.Parallel(t)
+
+	config := &structs.ACLAuthMethodConfig{
+		ClaimMappings: map[string]string{
+			"sub":            "subject",
+			"email_verified": "verified",
+			"/org/team":      "team",
+			"missing":        "missing",
+		},
+		ListClaimMappings: map[string]string{
+			"groups": "groups",
+			"role":   "roles",
+		},
+	}
+
+	claims := map[string]interface{}{
+		"sub":            "alice",
+		"email_verified": true,
+		"org":            map[string]interface{}{"team": "web"},
+		"groups":         []interface{}{"engineering", "ops"},
+		"role":           "admin",
+	}
+
+	identity, err := NewIdentity(config, claims)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"subject":  "alice",
+		"verified": "true",
+		"team":     "web",
+		"missing":  "",
+	}, identity.Claims.Value)
+	require.Equal(t, map[string][]string{
+		"groups": {"engineering", "ops"},
+		"roles":  {"admin"},
+	}, identity.Claims.List)
+	require.Equal(t, identity.Claims.Value, identity.ClaimMappings)
+
+	// Mapping an object claim to a value should fail.
+	claims["sub"] = map[string]interface{}{"name": "alice"}
+	_, err = NewIdentity(config, claims)
+	require.ErrorContains(t, err, "unsupported claim value type")
+}
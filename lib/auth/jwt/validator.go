@@ -0,0 +1,106 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/hashicorp/nomad/lib/auth"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Validator verifies JWTs which have been signed by a third party, such as a
+// CI runner or workload identity provider, and configured within a JWT auth
+// method. Unlike OIDC, no discovery is performed; the signing keys are sourced
+// from either a JWKS endpoint or a static list of public keys.
+type Validator struct {
+	verifier *auth.Verifier
+}
+
+// NewValidator returns a Validator built from the passed auth method config.
+func NewValidator(config *structs.ACLAuthMethodConfig) (*Validator, error) {
+	if config == nil {
+		return nil, errors.New("missing auth method config")
+	}
+
+	var keySet auth.KeySet
+
+	switch {
+	case config.JWKSURL != "":
+		var caPEMs []string
+		if config.JWKSCACert != "" {
+			caPEMs = []string{config.JWKSCACert}
+		}
+		client, err := auth.NewHTTPClient(caPEMs)
+		if err != nil {
+			return nil, err
+		}
+		keySet = auth.NewRemoteKeySet(config.JWKSURL, client)
+	case len(config.JWTValidationPubKeys) > 0:
+		staticKeySet, err := auth.NewStaticKeySet(config.JWTValidationPubKeys)
+		if err != nil {
+			return nil, err
+		}
+		keySet = staticKeySet
+	default:
+		return nil, errors.New("one of JWKSURL or JWTValidationPubKeys must be set")
+	}
+
+	return &Validator{
+		verifier: &auth.Verifier{
+			KeySet:               keySet,
+			SupportedSigningAlgs: config.SigningAlgs,
+			Issuer:               config.BoundIssuer,
+			Audiences:            config.BoundAudiences,
+			ClockSkewLeeway:      config.ClockSkewLeeway,
+		},
+	}, nil
+}
+
+// Validate verifies the signature and standard claims of the passed JWT,
+// returning all of its claims on success.
+func (v *Validator) Validate(ctx context.Context, rawToken string) (map[string]interface{}, error) {
+	return v.verifier.Verify(ctx, rawToken)
+}
+
+// ValidatorCache caches the validators of JWT auth methods, so that remote
+// key sets do not need to be fetched on every login. Validators are keyed by
+// auth method name and recreated when the auth method is modified.
+type ValidatorCache struct {
+	validators map[string]*cachedValidator
+	lock       sync.Mutex
+}
+
+type cachedValidator struct {
+	modifyIndex uint64
+	validator   *Validator
+}
+
+// NewValidatorCache returns an empty ValidatorCache.
+func NewValidatorCache() *ValidatorCache {
+	return &ValidatorCache{
+		validators: make(map[string]*cachedValidator),
+	}
+}
+
+// Get returns the validator for the passed auth method, creating it if it
+// does not exist or the auth method has been modified since it was cached.
+func (c *ValidatorCache) Get(authMethod *structs.ACLAuthMethod) (*Validator, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if cached, ok := c.validators[authMethod.Name]; ok && cached.modifyIndex == authMethod.ModifyIndex {
+		return cached.validator, nil
+	}
+
+	validator, err := NewValidator(authMethod.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	c.validators[authMethod.Name] = &cachedValidator{
+		modifyIndex: authMethod.ModifyIndex,
+		validator:   validator,
+	}
+	return validator, nil
+}
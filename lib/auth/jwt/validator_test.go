@@ -0,0 +1,193 @@
+package jwt
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/lib/auth/oidc"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+	josejwt "gopkg.in/square/go-jose.v2/jwt"
+)
+
+// testKey generates an RSA key and returns it along with its PEM encoded
+// public key.
+func testKey(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// signToken returns a JWT signed by the passed key. The expiry and issued at
+// claims are set if not present within the passed claims.
+func signToken(t *testing.T, key *rsa.PrivateKey, claims map[string]interface{}) string {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	require.NoError(t, err)
+
+	now := time.Now()
+	all := map[string]interface{}{
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	for k, v := range claims {
+		all[k] = v
+	}
+
+	raw, err := josejwt.Signed(signer).Claims(all).CompactSerialize()
+	require.NoError(t, err)
+	return raw
+}
+
+func TestValidator_Validate_StaticKeys(t *testing.T) {
+	ci.Parallel(t)
+
+	key, pubPEM := testKey(t)
+	otherKey, _ := testKey(t)
+
+	validator, err := NewValidator(&structs.ACLAuthMethodConfig{
+		JWTValidationPubKeys: []string{pubPEM},
+		BoundIssuer:          "https://ci.example.com",
+		BoundAudiences:       []string{"nomad"},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name                  string
+		token                 string
+		expectedErrorContains string
+	}{
+		{
+			name: "valid",
+			token: signToken(t, key, map[string]interface{}{
+				"iss": "https://ci.example.com",
+				"aud": "nomad",
+				"sub": "deploy",
+			}),
+		},
+		{
+			name: "issuer mismatch",
+			token: signToken(t, key, map[string]interface{}{
+				"iss": "https://other.example.com",
+				"aud": "nomad",
+			}),
+			expectedErrorContains: "failed to validate token claims",
+		},
+		{
+			name: "audience mismatch",
+			token: signToken(t, key, map[string]interface{}{
+				"iss": "https://ci.example.com",
+				"aud": "not-nomad",
+			}),
+			expectedErrorContains: "audience does not match",
+		},
+		{
+			name: "expired",
+			token: signToken(t, key, map[string]interface{}{
+				"iss": "https://ci.example.com",
+				"aud": "nomad",
+				"exp": time.Now().Add(-time.Hour).Unix(),
+			}),
+			expectedErrorContains: "failed to validate token claims",
+		},
+		{
+			name: "unknown key",
+			token: signToken(t, otherKey, map[string]interface{}{
+				"iss": "https://ci.example.com",
+				"aud": "nomad",
+			}),
+			expectedErrorContains: "failed to verify token signature",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims, err := validator.Validate(context.Background(), tc.token)
+			if tc.expectedErrorContains != "" {
+				require.ErrorContains(t, err, tc.expectedErrorContains)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "deploy", claims["sub"])
+		})
+	}
+}
+
+func TestValidator_Validate_JWKS(t *testing.T) {
+	ci.Parallel(t)
+
+	testProvider := oidc.NewTestProvider(t)
+
+	validator, err := NewValidator(&structs.ACLAuthMethodConfig{
+		JWKSURL:     testProvider.URL() + "/keys",
+		BoundIssuer: testProvider.URL(),
+	})
+	require.NoError(t, err)
+
+	token := testProvider.SignIDToken(t, map[string]interface{}{"sub": "deploy"})
+	claims, err := validator.Validate(context.Background(), token)
+	require.NoError(t, err)
+	require.Equal(t, "deploy", claims["sub"])
+}
+
+func TestNewValidator_Errors(t *testing.T) {
+	ci.Parallel(t)
+
+	_, err := NewValidator(nil)
+	require.ErrorContains(t, err, "missing auth method config")
+
+	_, err = NewValidator(&structs.ACLAuthMethodConfig{})
+	require.ErrorContains(t, err, "one of JWKSURL or JWTValidationPubKeys must be set")
+
+	_, err = NewValidator(&structs.ACLAuthMethodConfig{
+		JWTValidationPubKeys: []string{"not-a-pem"},
+	})
+	require.ErrorContains(t, err, "failed to decode PEM encoded public key")
+
+	_, err = NewValidator(&structs.ACLAuthMethodConfig{
+		JWKSURL:    "https://example.com/keys",
+		JWKSCACert: "not-a-pem",
+	})
+	require.ErrorContains(t, err, "failed to parse CA certificate")
+}
+
+func TestValidatorCache_Get(t *testing.T) {
+	ci.Parallel(t)
+
+	_, pubPEM := testKey(t)
+	cache := NewValidatorCache()
+
+	authMethod := &structs.ACLAuthMethod{
+		Name: "test-method",
+		Type: structs.ACLAuthMethodTypeJWT,
+		Config: &structs.ACLAuthMethodConfig{
+			JWTValidationPubKeys: []string{pubPEM},
+		},
+		ModifyIndex: 10,
+	}
+
+	// The validator should be cached until the auth method is modified.
+	validator1, err := cache.Get(authMethod)
+	require.NoError(t, err)
+	validator2, err := cache.Get(authMethod)
+	require.NoError(t, err)
+	require.Same(t, validator1, validator2)
+
+	authMethod.ModifyIndex = 20
+	validator3, err := cache.Get(authMethod)
+	require.NoError(t, err)
+	require.NotSame(t, validator1, validator3)
+}
@@ -0,0 +1,137 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/nomad/lib/auth"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// discoveryPath is the path, relative to the discovery URL, of the OpenID
+// provider configuration document.
+const discoveryPath = "/.well-known/openid-configuration"
+
+// discoveryDocument is the subset of the OpenID provider configuration that
+// Nomad uses to verify ID tokens.
+type discoveryDocument struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// Provider verifies ID tokens issued by an OIDC provider which has been
+// configured within an auth method.
+type Provider struct {
+	verifier *auth.Verifier
+}
+
+// NewProvider performs OIDC discovery using the passed auth method config and
+// returns a Provider which can verify ID tokens issued by it.
+func NewProvider(ctx context.Context, config *structs.ACLAuthMethodConfig) (*Provider, error) {
+	if config == nil {
+		return nil, errors.New("missing auth method config")
+	}
+
+	client, err := auth.NewHTTPClient(config.DiscoveryCaPem)
+	if err != nil {
+		return nil, err
+	}
+
+	issuer := strings.TrimSuffix(config.OIDCDiscoveryURL, "/")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+discoveryPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery request: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query OIDC discovery URL: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to query OIDC discovery URL: unexpected status code %d", resp.StatusCode)
+	}
+
+	var doc discoveryDocument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode OIDC discovery document: %v", err)
+	}
+
+	// The OIDC discovery specification requires the issuer to match the URL
+	// used to retrieve the configuration.
+	if doc.Issuer != issuer {
+		return nil, fmt.Errorf("OIDC issuer %q does not match discovery URL %q", doc.Issuer, issuer)
+	}
+	if doc.JWKSURI == "" {
+		return nil, errors.New("OIDC discovery document is missing jwks_uri")
+	}
+
+	audiences := config.BoundAudiences
+	if len(audiences) == 0 {
+		audiences = []string{config.OIDCClientID}
+	}
+
+	return &Provider{
+		verifier: &auth.Verifier{
+			KeySet:               auth.NewRemoteKeySet(doc.JWKSURI, client),
+			SupportedSigningAlgs: config.SigningAlgs,
+			Issuer:               doc.Issuer,
+			Audiences:            audiences,
+		},
+	}, nil
+}
+
+// VerifyIDToken verifies the signature and standard claims of the passed ID
+// token, returning all of its claims on success.
+func (p *Provider) VerifyIDToken(ctx context.Context, rawToken string) (map[string]interface{}, error) {
+	return p.verifier.Verify(ctx, rawToken)
+}
+
+// ProviderCache caches the OIDC providers of auth methods, so that discovery
+// and key set fetching does not need to be performed on every login.
+// Providers are keyed by auth method name and recreated when the auth method
+// is modified.
+type ProviderCache struct {
+	providers map[string]*cachedProvider
+	lock      sync.Mutex
+}
+
+type cachedProvider struct {
+	modifyIndex uint64
+	provider    *Provider
+}
+
+// NewProviderCache returns an empty ProviderCache.
+func NewProviderCache() *ProviderCache {
+	return &ProviderCache{
+		providers: make(map[string]*cachedProvider),
+	}
+}
+
+// Get returns the provider for the passed auth method, creating it if it does
+// not exist or the auth method has been modified since it was cached.
+func (c *ProviderCache) Get(ctx context.Context, authMethod *structs.ACLAuthMethod) (*Provider, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if cached, ok := c.providers[authMethod.Name]; ok && cached.modifyIndex == authMethod.ModifyIndex {
+		return cached.provider, nil
+	}
+
+	provider, err := NewProvider(ctx, authMethod.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	c.providers[authMethod.Name] = &cachedProvider{
+		modifyIndex: authMethod.ModifyIndex,
+		provider:    provider,
+	}
+	return provider, nil
+}
@@ -0,0 +1,156 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider_VerifyIDToken(t *testing.T) {
+	ci.Parallel(t)
+
+	testProvider := NewTestProvider(t)
+	otherProvider := NewTestProvider(t)
+
+	config := &structs.ACLAuthMethodConfig{
+		OIDCDiscoveryURL: testProvider.URL(),
+		OIDCClientID:     "nomad",
+	}
+
+	provider, err := NewProvider(context.Background(), config)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name                  string
+		token                 string
+		expectedErrorContains string
+	}{
+		{
+			name: "valid",
+			token: testProvider.SignIDToken(t, map[string]interface{}{
+				"aud": "nomad",
+				"sub": "alice",
+			}),
+		},
+		{
+			name: "audience mismatch",
+			token: testProvider.SignIDToken(t, map[string]interface{}{
+				"aud": "not-nomad",
+			}),
+			expectedErrorContains: "audience does not match",
+		},
+		{
+			name: "expired",
+			token: testProvider.SignIDToken(t, map[string]interface{}{
+				"aud": "nomad",
+				"exp": time.Now().Add(-time.Hour).Unix(),
+			}),
+			expectedErrorContains: "failed to validate token claims",
+		},
+		{
+			name: "issuer mismatch",
+			token: testProvider.SignIDToken(t, map[string]interface{}{
+				"aud": "nomad",
+				"iss": "https://example.com",
+			}),
+			expectedErrorContains: "failed to validate token claims",
+		},
+		{
+			name: "signed by another provider",
+			token: otherProvider.SignIDToken(t, map[string]interface{}{
+				"aud": "nomad",
+				"iss": testProvider.URL(),
+			}),
+			expectedErrorContains: "failed to verify token signature",
+		},
+		{
+			name:                  "malformed",
+			token:                 "not-a-token",
+			expectedErrorContains: "failed to parse token",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims, err := provider.VerifyIDToken(context.Background(), tc.token)
+			if tc.expectedErrorContains != "" {
+				require.ErrorContains(t, err, tc.expectedErrorContains)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "alice", claims["sub"])
+		})
+	}
+}
+
+func TestProvider_VerifyIDToken_SigningAlgs(t *testing.T) {
+	ci.Parallel(t)
+
+	testProvider := NewTestProvider(t)
+
+	// Only allow an algorithm the test provider does not use.
+	config := &structs.ACLAuthMethodConfig{
+		OIDCDiscoveryURL: testProvider.URL(),
+		OIDCClientID:     "nomad",
+		SigningAlgs:      []string{"ES256"},
+	}
+
+	provider, err := NewProvider(context.Background(), config)
+	require.NoError(t, err)
+
+	token := testProvider.SignIDToken(t, map[string]interface{}{"aud": "nomad"})
+	_, err = provider.VerifyIDToken(context.Background(), token)
+	require.ErrorContains(t, err, "unsupported token signing algorithm")
+}
+
+func TestNewProvider_Discovery(t *testing.T) {
+	ci.Parallel(t)
+
+	// A discovery URL which does not match the issuer should be rejected.
+	testProvider := NewTestProvider(t)
+	_, err := NewProvider(context.Background(), &structs.ACLAuthMethodConfig{
+		OIDCDiscoveryURL: testProvider.URL() + "/other",
+		OIDCClientID:     "nomad",
+	})
+	require.Error(t, err)
+
+	// An invalid CA certificate should be rejected.
+	_, err = NewProvider(context.Background(), &structs.ACLAuthMethodConfig{
+		OIDCDiscoveryURL: testProvider.URL(),
+		OIDCClientID:     "nomad",
+		DiscoveryCaPem:   []string{"not-a-pem"},
+	})
+	require.ErrorContains(t, err, "failed to parse CA certificate")
+}
+
+func TestProviderCache_Get(t *testing.T) {
+	ci.Parallel(t)
+
+	testProvider := NewTestProvider(t)
+	cache := NewProviderCache()
+
+	authMethod := &structs.ACLAuthMethod{
+		Name: "test-method",
+		Config: &structs.ACLAuthMethodConfig{
+			OIDCDiscoveryURL: testProvider.URL(),
+			OIDCClientID:     "nomad",
+		},
+		ModifyIndex: 10,
+	}
+
+	// The provider should be cached until the auth method is modified.
+	provider1, err := cache.Get(context.Background(), authMethod)
+	require.NoError(t, err)
+	provider2, err := cache.Get(context.Background(), authMethod)
+	require.NoError(t, err)
+	require.Same(t, provider1, provider2)
+
+	authMethod.ModifyIndex = 20
+	provider3, err := cache.Get(context.Background(), authMethod)
+	require.NoError(t, err)
+	require.NotSame(t, provider1, provider3)
+}
@@ -0,0 +1,415 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/nomad/helper"
+	"golang.org/x/sync/singleflight"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const (
+	// DefaultSigningAlg is the signing algorithm accepted when a verifier
+	// has not been configured with any supported algorithms. It is the
+	// algorithm all OIDC providers are required to support.
+	DefaultSigningAlg = "RS256"
+
+	// DefaultClockSkewLeeway is the leeway used when validating the time
+	// based claims of a token, to account for clock skew between Nomad and
+	// the token issuer.
+	DefaultClockSkewLeeway = jwt.DefaultLeeway
+)
+
+// KeySet provides the public keys used to verify the signature of a JWT.
+type KeySet interface {
+
+	// Keys returns the candidate public keys which may have signed a token
+	// with the passed key ID. The key ID may be empty, in which case all
+	// known keys should be returned.
+	Keys(ctx context.Context, keyID string) ([]interface{}, error)
+}
+
+// Verifier validates the signature and standard claims of a JWT. It is
+// independent of how the signing keys are sourced, so it can be used for any
+// auth method type which accepts signed tokens.
+type Verifier struct {
+
+	// KeySet is used to lookup the keys used to verify token signatures.
+	KeySet KeySet
+
+	// SupportedSigningAlgs is the list of accepted signing algorithms. If
+	// empty, DefaultSigningAlg is used.
+	SupportedSigningAlgs []string
+
+	// Issuer, if set, must match the "iss" claim of the token.
+	Issuer string
+
+	// Audiences, if set, must contain at least one of the values found
+	// within the "aud" claim of the token.
+	Audiences []string
+
+	// ClockSkewLeeway is the leeway used when validating the "exp", "nbf",
+	// and "iat" claims. If zero, DefaultClockSkewLeeway is used.
+	ClockSkewLeeway time.Duration
+
+	// now is used to override the current time within tests.
+	now func() time.Time
+}
+
+// Verify validates the passed raw token and returns all of its claims on
+// success.
+func (v *Verifier) Verify(ctx context.Context, rawToken string) (map[string]interface{}, error) {
+
+	tok, err := jwt.ParseSigned(rawToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %v", err)
+	}
+	if len(tok.Headers) != 1 {
+		return nil, errors.New("token must contain exactly one signature")
+	}
+	header := tok.Headers[0]
+
+	supportedAlgs := v.SupportedSigningAlgs
+	if len(supportedAlgs) == 0 {
+		supportedAlgs = []string{DefaultSigningAlg}
+	}
+	if !helper.SliceStringContains(supportedAlgs, header.Algorithm) {
+		return nil, fmt.Errorf("unsupported token signing algorithm %q", header.Algorithm)
+	}
+
+	keys, err := v.KeySet.Keys(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try each candidate key in turn, as a token without a key ID could have
+	// been signed by any of them.
+	var (
+		stdClaims jwt.Claims
+		allClaims map[string]interface{}
+		verified  bool
+	)
+	for _, key := range keys {
+		if err := tok.Claims(key, &stdClaims, &allClaims); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errors.New("failed to verify token signature")
+	}
+
+	now := time.Now
+	if v.now != nil {
+		now = v.now
+	}
+	leeway := v.ClockSkewLeeway
+	if leeway == 0 {
+		leeway = DefaultClockSkewLeeway
+	}
+
+	if stdClaims.Expiry == nil {
+		return nil, errors.New("token is missing expiration time")
+	}
+	if err := stdClaims.ValidateWithLeeway(jwt.Expected{Issuer: v.Issuer, Time: now()}, leeway); err != nil {
+		return nil, fmt.Errorf("failed to validate token claims: %v", err)
+	}
+
+	if len(v.Audiences) > 0 {
+		var found bool
+		for _, aud := range v.Audiences {
+			if stdClaims.Audience.Contains(aud) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.New("token audience does not match any bound audience")
+		}
+	}
+
+	return allClaims, nil
+}
+
+// RemoteKeySet is a KeySet which fetches keys from a remote JSON Web Key Set
+// endpoint. Keys are cached and only refetched when a token references a key
+// ID which is not known, which handles the provider rotating its keys. As
+// login requests are unauthenticated, refetches are limited to one per
+// keySetMinRefreshInterval and unknown key IDs are remembered for
+// keySetMissingKeyTTL, so tokens with made up key IDs can't be used to make
+// Nomad flood the provider with requests.
+type RemoteKeySet struct {
+	url    string
+	client *http.Client
+
+	// keys is the cached key set, and lastFetch the time it was last
+	// fetched, successfully or not.
+	keys      []jose.JSONWebKey
+	lastFetch time.Time
+
+	// missing tracks the key IDs which were not found in the key set after
+	// a refetch, and when they were last looked up.
+	missing map[string]time.Time
+
+	lock  sync.Mutex
+	group singleflight.Group
+
+	// now is used to override the current time within tests.
+	now func() time.Time
+}
+
+const (
+	// keySetMinRefreshInterval is the minimum time between fetches of a
+	// remote key set.
+	keySetMinRefreshInterval = 10 * time.Second
+
+	// keySetMissingKeyTTL is how long a key ID which was not found in a
+	// remote key set is remembered, during which it does not trigger a
+	// refetch.
+	keySetMissingKeyTTL = 5 * time.Minute
+
+	// keySetMaxMissingKeys limits the number of unknown key IDs remembered.
+	keySetMaxMissingKeys = 1024
+
+	// keySetFetchTimeout is the timeout of a remote key set fetch. Fetches
+	// are shared by concurrent lookups, so are not bound to the context of
+	// any one of them.
+	keySetFetchTimeout = 30 * time.Second
+)
+
+// NewRemoteKeySet returns a RemoteKeySet which fetches keys from the passed
+// JWKS URL using the passed HTTP client.
+func NewRemoteKeySet(url string, client *http.Client) *RemoteKeySet {
+	return &RemoteKeySet{
+		url:     url,
+		client:  client,
+		missing: make(map[string]time.Time),
+		now:     time.Now,
+	}
+}
+
+// Keys satisfies the Keys function of the KeySet interface.
+func (r *RemoteKeySet) Keys(ctx context.Context, keyID string) ([]interface{}, error) {
+	keys, refresh := r.cachedKeys(keyID)
+	if len(keys) > 0 {
+		return keys, nil
+	}
+	if !refresh {
+		return nil, fmt.Errorf("failed to find signing key %q", keyID)
+	}
+
+	// The key was not found in our cache, so refresh the key set in case the
+	// provider has rotated its keys. Concurrent lookups share the fetch,
+	// which is performed without holding the lock so that lookups of known
+	// keys are not blocked.
+	ch := r.group.DoChan(r.url, func() (interface{}, error) {
+		return nil, r.refresh()
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if keys := filterKeys(r.keys, keyID); len(keys) > 0 {
+		return keys, nil
+	}
+	r.addMissing(keyID)
+	return nil, fmt.Errorf("failed to find signing key %q", keyID)
+}
+
+// cachedKeys returns the cached keys matching the key ID and, when there are
+// none, whether the key set may be refetched to look for them.
+func (r *RemoteKeySet) cachedKeys(keyID string) ([]interface{}, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if keys := filterKeys(r.keys, keyID); len(keys) > 0 {
+		return keys, false
+	}
+
+	now := r.now()
+	if seen, ok := r.missing[keyID]; ok && now.Sub(seen) < keySetMissingKeyTTL {
+		return nil, false
+	}
+	if !r.lastFetch.IsZero() && now.Sub(r.lastFetch) < keySetMinRefreshInterval {
+		r.addMissing(keyID)
+		return nil, false
+	}
+	return nil, true
+}
+
+// refresh fetches the key set and replaces the cached keys with it, unless
+// it was fetched within the refresh interval by a lookup which raced with
+// this one. Failed fetches also count towards the refresh interval, so an
+// unavailable provider is not retried on every lookup.
+func (r *RemoteKeySet) refresh() error {
+	r.lock.Lock()
+	recent := !r.lastFetch.IsZero() && r.now().Sub(r.lastFetch) < keySetMinRefreshInterval
+	r.lock.Unlock()
+	if recent {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), keySetFetchTimeout)
+	defer cancel()
+
+	keySet, err := r.fetch(ctx)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.lastFetch = r.now()
+	if err != nil {
+		return err
+	}
+	r.keys = keySet.Keys
+	r.missing = make(map[string]time.Time)
+	return nil
+}
+
+// addMissing remembers a key ID which was not found in the key set. Once the
+// limit of remembered IDs is reached, expired ones are dropped and new ones
+// are only added if there is room. The lock must be held by the caller.
+func (r *RemoteKeySet) addMissing(keyID string) {
+	now := r.now()
+	if len(r.missing) >= keySetMaxMissingKeys {
+		for id, seen := range r.missing {
+			if now.Sub(seen) >= keySetMissingKeyTTL {
+				delete(r.missing, id)
+			}
+		}
+		if len(r.missing) >= keySetMaxMissingKeys {
+			return
+		}
+	}
+	r.missing[keyID] = now
+}
+
+func (r *RemoteKeySet) fetch(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key set request: %v", err)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch key set: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch key set: unexpected status code %d", resp.StatusCode)
+	}
+
+	var keySet jose.JSONWebKeySet
+	if err := json.NewDecoder(resp.Body).Decode(&keySet); err != nil {
+		return nil, fmt.Errorf("failed to decode key set: %v", err)
+	}
+	return &keySet, nil
+}
+
+// StaticKeySet is a KeySet backed by a fixed list of public keys, such as
+// those configured directly on an auth method. As the keys have no IDs, all
+// keys are candidates for every token.
+type StaticKeySet struct {
+	keys []interface{}
+}
+
+// NewStaticKeySet parses the passed PEM encoded public keys or certificates
+// and returns a StaticKeySet containing them.
+func NewStaticKeySet(pemKeys []string) (*StaticKeySet, error) {
+	if len(pemKeys) == 0 {
+		return nil, errors.New("no public keys provided")
+	}
+
+	keys := make([]interface{}, 0, len(pemKeys))
+	for _, pemKey := range pemKeys {
+		key, err := ParsePublicKeyPEM([]byte(pemKey))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return &StaticKeySet{keys: keys}, nil
+}
+
+// Keys satisfies the Keys function of the KeySet interface.
+func (s *StaticKeySet) Keys(_ context.Context, _ string) ([]interface{}, error) {
+	return s.keys, nil
+}
+
+// ParsePublicKeyPEM parses a PEM encoded public key. The PEM block may
+// contain a PKIX or PKCS1 public key, or an x509 certificate in which case
+// the certificate public key is returned.
+func ParsePublicKeyPEM(data []byte) (interface{}, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM encoded public key")
+	}
+
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+		return cert.PublicKey, nil
+	}
+	return nil, errors.New("failed to parse public key: unsupported key or certificate type")
+}
+
+// NewHTTPClient returns an HTTP client suitable for fetching keys and
+// provider metadata, which trusts the passed PEM encoded CA certificates. If
+// no certificates are passed, the system roots are used.
+func NewHTTPClient(caPEMs []string) (*http.Client, error) {
+	client := cleanhttp.DefaultClient()
+	if len(caPEMs) == 0 {
+		return client, nil
+	}
+
+	pool := x509.NewCertPool()
+	for _, caPEM := range caPEMs {
+		if !pool.AppendCertsFromPEM([]byte(caPEM)) {
+			return nil, errors.New("failed to parse CA certificate")
+		}
+	}
+
+	transport := client.Transport.(*http.Transport)
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	return client, nil
+}
+
+// filterKeys returns the public keys from the passed JWKs which match the key
+// ID. If the key ID is empty, all public keys are returned.
+func filterKeys(jwks []jose.JSONWebKey, keyID string) []interface{} {
+	var out []interface{}
+	for _, jwk := range jwks {
+		if keyID != "" && jwk.KeyID != keyID {
+			continue
+		}
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		out = append(out, jwk.Key)
+	}
+	return out
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+)
+
+func TestRemoteKeySet_Keys(t *testing.T) {
+	ci.Parallel(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var (
+		fetches int32
+		keyIDs  = []string{"key-1"}
+		lock    sync.Mutex
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		lock.Lock()
+		defer lock.Unlock()
+		var keySet jose.JSONWebKeySet
+		for _, id := range keyIDs {
+			keySet.Keys = append(keySet.Keys, jose.JSONWebKey{Key: &key.PublicKey, KeyID: id, Use: "sig"})
+		}
+		_ = json.NewEncoder(w).Encode(keySet)
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	keySet := NewRemoteKeySet(srv.URL, srv.Client())
+	keySet.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// Concurrent lookups share a single fetch.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			keys, err := keySet.Keys(ctx, "key-1")
+			require.NoError(t, err)
+			require.Len(t, keys, 1)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+
+	// Unknown key IDs do not trigger a refetch within the refresh interval.
+	for _, id := range []string{"bogus-1", "bogus-2", "bogus-3"} {
+		_, err := keySet.Keys(ctx, id)
+		require.ErrorContains(t, err, "failed to find signing key")
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+
+	// Once the interval has passed, a new unknown key ID refetches the key
+	// set, picking up rotated keys.
+	lock.Lock()
+	keyIDs = append(keyIDs, "key-2")
+	lock.Unlock()
+	now = now.Add(keySetMinRefreshInterval)
+
+	keys, err := keySet.Keys(ctx, "key-2")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+
+	// A key ID which is still unknown after a refetch is remembered, and does
+	// not trigger another one until it expires.
+	now = now.Add(keySetMinRefreshInterval)
+	_, err = keySet.Keys(ctx, "bogus-1")
+	require.Error(t, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(&fetches))
+
+	now = now.Add(keySetMinRefreshInterval)
+	_, err = keySet.Keys(ctx, "bogus-1")
+	require.Error(t, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(&fetches))
+
+	now = now.Add(keySetMissingKeyTTL)
+	_, err = keySet.Keys(ctx, "bogus-1")
+	require.Error(t, err)
+	require.Equal(t, int32(4), atomic.LoadInt32(&fetches))
+}
@@ -101,6 +101,9 @@ func resolveTokenFromSnapshotCache(snap *state.StateSnapshot, cache *lru.TwoQueu
 		if token == nil {
 			return nil, structs.ErrTokenNotFound
 		}
+		if token.IsExpired(time.Now().UTC()) {
+			return nil, structs.ErrTokenExpired
+		}
 	}
 
 	// Check if this is a management token
@@ -108,9 +111,16 @@ func resolveTokenFromSnapshotCache(snap *state.StateSnapshot, cache *lru.TwoQueu
 		return acl.ManagementACL, nil
 	}
 
+	// Identify all the policies the token is linked to, either directly or
+	// via its roles.
+	policyNames, err := resolveTokenPolicyNames(snap, token)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get all associated policies
-	policies := make([]*structs.ACLPolicy, 0, len(token.Policies))
-	for _, policyName := range token.Policies {
+	policies := make([]*structs.ACLPolicy, 0, len(policyNames))
+	for _, policyName := range policyNames {
 		policy, err := snap.ACLPolicyByName(nil, policyName)
 		if err != nil {
 			return nil, err
@@ -132,6 +142,45 @@ func resolveTokenFromSnapshotCache(snap *state.StateSnapshot, cache *lru.TwoQueu
 	return aclObj, nil
 }
 
+// resolveTokenPolicyNames returns the unique set of policy names that the
+// token grants, which includes the policies linked directly to the token and
+// those linked through its ACL roles. Roles which no longer exist are ignored,
+// since they don't grant any privilege.
+func resolveTokenPolicyNames(snap *state.StateSnapshot, token *structs.ACLToken) ([]string, error) {
+	if len(token.Roles) == 0 {
+		return token.Policies, nil
+	}
+
+	seen := make(map[string]struct{}, len(token.Policies))
+	policyNames := make([]string, 0, len(token.Policies))
+
+	addPolicyName := func(name string) {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			policyNames = append(policyNames, name)
+		}
+	}
+
+	for _, policyName := range token.Policies {
+		addPolicyName(policyName)
+	}
+
+	for _, roleLink := range token.Roles {
+		role, err := snap.GetACLRoleByID(nil, roleLink.ID)
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			continue
+		}
+		for _, policyLink := range role.Policies {
+			addPolicyName(policyLink.Name)
+		}
+	}
+
+	return policyNames, nil
+}
+
 // ResolveSecretToken is used to translate an ACL Token Secret ID into
 // an ACLToken object, nil if ACLs are disabled, or an error.
 func (s *Server) ResolveSecretToken(secretID string) (*structs.ACLToken, error) {
@@ -161,6 +210,9 @@ func (s *Server) ResolveSecretToken(secretID string) (*structs.ACLToken, error)
 		if token == nil {
 			return nil, structs.ErrTokenNotFound
 		}
+		if token.IsExpired(time.Now().UTC()) {
+			return nil, structs.ErrTokenExpired
+		}
 	}
 
 	return token, nil
@@ -1,6 +1,7 @@
 package nomad
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -15,9 +16,13 @@ import (
 	policy "github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/lib/auth"
+	"github.com/hashicorp/nomad/lib/auth/jwt"
+	"github.com/hashicorp/nomad/lib/auth/oidc"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/state/paginator"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"golang.org/x/exp/slices"
 )
 
 var (
@@ -29,6 +34,10 @@ const (
 	// aclBootstrapReset is the file name to create in the data dir. It's only contents
 	// should be the reset index
 	aclBootstrapReset = "acl-bootstrap-reset"
+
+	// aclLoginProviderTimeout is the maximum time allowed for performing OIDC
+	// discovery and verifying a login token with the auth method provider.
+	aclLoginProviderTimeout = 30 * time.Second
 )
 
 // ACL endpoint is used for manipulating ACL tokens and policies
@@ -148,8 +157,13 @@ func (a *ACL) ListPolicies(args *structs.ACLPolicyListRequest, reply *structs.AC
 			return structs.ErrTokenNotFound
 		}
 
-		policies = make(map[string]struct{}, len(token.Policies))
-		for _, p := range token.Policies {
+		policyNames, err := a.policyNamesFromToken(token)
+		if err != nil {
+			return err
+		}
+
+		policies = make(map[string]struct{}, len(policyNames))
+		for _, p := range policyNames {
 			policies[p] = struct{}{}
 		}
 	}
@@ -232,15 +246,12 @@ func (a *ACL) GetPolicy(args *structs.ACLPolicySpecificRequest, reply *structs.S
 			return structs.ErrTokenNotFound
 		}
 
-		found := false
-		for _, p := range token.Policies {
-			if p == args.Name {
-				found = true
-				break
-			}
+		policyNames, err := a.policyNamesFromToken(token)
+		if err != nil {
+			return err
 		}
 
-		if !found {
+		if !slices.Contains(policyNames, args.Name) {
 			return structs.ErrPermissionDenied
 		}
 	}
@@ -289,7 +300,24 @@ func (a *ACL) requestACLToken(secretID string) (*structs.ACLToken, error) {
 		return nil, err
 	}
 
-	return snap.ACLTokenBySecretID(nil, secretID)
+	token, err := snap.ACLTokenBySecretID(nil, secretID)
+	if err != nil {
+		return nil, err
+	}
+	if token != nil && token.IsExpired(time.Now().UTC()) {
+		return nil, structs.ErrTokenExpired
+	}
+	return token, nil
+}
+
+// policyNamesFromToken returns the names of all the policies the token is
+// linked to, either directly or via its ACL roles.
+func (a *ACL) policyNamesFromToken(token *structs.ACLToken) ([]string, error) {
+	snap, err := a.srv.fsm.State().Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return resolveTokenPolicyNames(snap, token)
 }
 
 // GetPolicies is used to get a set of policies
@@ -313,8 +341,14 @@ func (a *ACL) GetPolicies(args *structs.ACLPolicySetRequest, reply *structs.ACLP
 	if token == nil {
 		return structs.ErrTokenNotFound
 	}
-	if token.Type != structs.ACLManagementToken && !token.PolicySubset(args.Names) {
-		return structs.ErrPermissionDenied
+	if token.Type != structs.ACLManagementToken {
+		policyNames, err := a.policyNamesFromToken(token)
+		if err != nil {
+			return err
+		}
+		if subset, _ := helper.SliceStringIsSubset(policyNames, args.Names); !subset {
+			return structs.ErrPermissionDenied
+		}
 	}
 
 	// Setup the blocking query
@@ -513,32 +547,50 @@ func (a *ACL) UpsertTokens(args *structs.ACLTokenUpsertRequest, reply *structs.A
 
 	// Validate each token
 	for idx, token := range args.Tokens {
-		if err := token.Validate(); err != nil {
-			return structs.NewErrRPCCodedf(400, "token %d invalid: %v", idx, err)
-		}
 
-		// Generate an accessor and secret ID if new
-		if token.AccessorID == "" {
-			token.AccessorID = uuid.Generate()
-			token.SecretID = uuid.Generate()
-			token.CreateTime = time.Now().UTC()
+		// Store any existing token found, so we can perform the correct update
+		// validation.
+		var existing *structs.ACLToken
 
-		} else {
-			// Verify the token exists
-			out, err := state.ACLTokenByAccessorID(nil, token.AccessorID)
+		isUpdate := token.AccessorID != ""
+		if isUpdate {
+			existing, err = state.ACLTokenByAccessorID(nil, token.AccessorID)
 			if err != nil {
 				return structs.NewErrRPCCodedf(400, "token lookup failed: %v", err)
 			}
-			if out == nil {
+		}
+
+		// Generate an accessor and secret ID if new, along with the
+		// expiration time if the token has a TTL.
+		token.Canonicalize()
+
+		if err := token.Validate(a.srv.config.ACLTokenMinExpirationTTL,
+			a.srv.config.ACLTokenMaxExpirationTTL, existing); err != nil {
+			return structs.NewErrRPCCodedf(400, "token %d invalid: %v", idx, err)
+		}
+
+		if isUpdate {
+			// Verify the token exists
+			if existing == nil {
 				return structs.NewErrRPCCodedf(404, "cannot find token %s", token.AccessorID)
 			}
 
 			// Cannot toggle the "Global" mode
-			if token.Global != out.Global {
+			if token.Global != existing.Global {
 				return structs.NewErrRPCCodedf(400, "cannot toggle global mode of %s", token.AccessorID)
 			}
 		}
 
+		// Ensure any role links reference roles which exist, and populate
+		// both the ID and name of each link.
+		if len(token.Roles) > 0 {
+			roleLinks, err := resolveTokenRoleLinks(state, token.Roles)
+			if err != nil {
+				return structs.NewErrRPCCodedf(400, "token %d invalid: %v", idx, err)
+			}
+			token.Roles = roleLinks
+		}
+
 		// Compute the token hash
 		token.SetHash()
 	}
@@ -568,6 +620,45 @@ func (a *ACL) UpsertTokens(args *structs.ACLTokenUpsertRequest, reply *structs.A
 	return nil
 }
 
+// resolveTokenRoleLinks looks up each of the passed role links, which may be
+// identified by either ID or name, and returns a deduplicated set of links
+// with both fields populated. An error is returned if any linked role does not
+// exist.
+func resolveTokenRoleLinks(snap *state.StateSnapshot, links []*structs.ACLTokenRoleLink) ([]*structs.ACLTokenRoleLink, error) {
+	resolved := make([]*structs.ACLTokenRoleLink, 0, len(links))
+	seen := make(map[string]struct{}, len(links))
+
+	for _, link := range links {
+		var (
+			role *structs.ACLRole
+			err  error
+		)
+
+		switch {
+		case link.ID != "":
+			role, err = snap.GetACLRoleByID(nil, link.ID)
+		case link.Name != "":
+			role, err = snap.GetACLRoleByName(nil, link.Name)
+		default:
+			return nil, fmt.Errorf("role link must specify an ID or name")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("role lookup failed: %v", err)
+		}
+		if role == nil {
+			return nil, fmt.Errorf("cannot find role %s", link.ID+link.Name)
+		}
+
+		if _, ok := seen[role.ID]; ok {
+			continue
+		}
+		seen[role.ID] = struct{}{}
+		resolved = append(resolved, &structs.ACLTokenRoleLink{ID: role.ID, Name: role.Name})
+	}
+
+	return resolved, nil
+}
+
 // DeleteTokens is used to delete tokens
 func (a *ACL) DeleteTokens(args *structs.ACLTokenDeleteRequest, reply *structs.GenericResponse) error {
 	// Ensure ACLs are enabled, and always flow modification requests to the authoritative region
@@ -1024,3 +1115,1308 @@ func (a *ACL) ExpireOneTimeTokens(args *structs.OneTimeTokenExpireRequest, reply
 	reply.Index = index
 	return nil
 }
+
+// UpsertRoles creates or updates ACL roles held within Nomad.
+func (a *ACL) UpsertRoles(
+	args *structs.ACLRolesUpsertRequest,
+	reply *structs.ACLRolesUpsertResponse) error {
+
+	// Only allow operators to upsert ACL roles when ACLs are enabled.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	// This endpoint always forwards to the authoritative region as ACL roles
+	// are global.
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward(structs.ACLUpsertRolesRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "upsert_roles"}, time.Now())
+
+	// Only tokens with management level permissions can create ACL roles.
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Snapshot the state so we can perform lookups against the ID and policy
+	// links if needed. Do it here, so we only need to do this once no matter
+	// how many roles we are upserting.
+	stateSnapshot, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Validate each role.
+	for idx, role := range args.ACLRoles {
+
+		// Perform all the static validation of the ACL role object. Use the
+		// array index as we cannot be sure the error was caused by a missing
+		// name.
+		if err := role.Validate(); err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "role %d invalid: %v", idx, err)
+		}
+
+		// Ensure the policies linked to this role exist within state.
+		for _, policyLink := range role.Policies {
+			policy, err := stateSnapshot.ACLPolicyByName(nil, policyLink.Name)
+			if err != nil {
+				return err
+			}
+			if policy == nil {
+				return structs.NewErrRPCCodedf(
+					http.StatusBadRequest, "cannot find policy %s", policyLink.Name)
+			}
+		}
+
+		// If the caller has passed a role ID, this call is considered an
+		// update to an existing role. We should therefore ensure it is found
+		// within state.
+		if role.ID != "" {
+			existingRole, err := stateSnapshot.GetACLRoleByID(nil, role.ID)
+			if err != nil {
+				return structs.NewErrRPCCodedf(http.StatusInternalServerError, "role lookup failed: %v", err)
+			}
+			if existingRole == nil {
+				return structs.NewErrRPCCodedf(http.StatusBadRequest, "cannot find role %s", role.ID)
+			}
+		}
+
+		// Enforce uniqueness of the role name. An existing role with the same
+		// name may only be the role being updated.
+		existingRole, err := stateSnapshot.GetACLRoleByName(nil, role.Name)
+		if err != nil {
+			return structs.NewErrRPCCodedf(http.StatusInternalServerError, "role lookup failed: %v", err)
+		}
+		if existingRole != nil && role.ID != existingRole.ID {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "role with name %s already exists", role.Name)
+		}
+
+		// Ensure the role has an ID and hash set.
+		role.Canonicalize()
+		role.SetHash()
+	}
+
+	// Update via Raft.
+	out, index, err := a.srv.raftApply(structs.ACLRolesUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the FSM response, which is an interface, contains an error.
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	// Populate the response. We do a lookup against the state to pick up the
+	// proper create / modify indexes.
+	stateSnapshot, err = a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, role := range args.ACLRoles {
+		lookupACLRole, err := stateSnapshot.GetACLRoleByID(nil, role.ID)
+		if err != nil {
+			return structs.NewErrRPCCodedf(http.StatusInternalServerError, "ACL role lookup failed: %v", err)
+		}
+		reply.ACLRoles = append(reply.ACLRoles, lookupACLRole)
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// DeleteRoles is used to batch delete ACL roles using the ID as the deletion
+// key.
+func (a *ACL) DeleteRoles(
+	args *structs.ACLRolesDeleteRequest,
+	reply *structs.ACLRolesDeleteResponse) error {
+
+	// Only allow operators to delete ACL roles when ACLs are enabled.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	// This endpoint always forwards to the authoritative region as ACL roles
+	// are global.
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward(structs.ACLDeleteRolesRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "delete_roles"}, time.Now())
+
+	// Only tokens with management level permissions can delete ACL roles.
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of roles
+	if len(args.ACLRoleIDs) == 0 {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "must specify as least one role")
+	}
+
+	// Update via Raft.
+	out, index, err := a.srv.raftApply(structs.ACLRolesDeleteByIDRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the FSM response, which is an interface, contains an error.
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// ListRoles is used to list ACL roles within state. If no prefix is supplied,
+// all ACL roles are listed, otherwise a prefix search is performed on the ACL
+// role ID.
+func (a *ACL) ListRoles(
+	args *structs.ACLRolesListRequest,
+	reply *structs.ACLRolesListResponse) error {
+
+	// Only allow operators to list ACL roles when ACLs are enabled.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward(structs.ACLListRolesRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "list_roles"}, time.Now())
+
+	// Resolve the token and ensure it has some form of permissions.
+	acl, err := a.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if acl == nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// If the token is not a management token, determine the roles which it
+	// is linked to, as these are the only roles it is allowed to list.
+	mgt := acl.IsManagement()
+	var roles map[string]struct{}
+	if !mgt {
+		token, err := a.requestACLToken(args.AuthToken)
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			return structs.ErrTokenNotFound
+		}
+
+		roles = make(map[string]struct{}, len(token.Roles))
+		for _, roleLink := range token.Roles {
+			roles[roleLink.ID] = struct{}{}
+		}
+	}
+
+	// Set up and return the blocking query.
+	return a.srv.blockingRPC(&blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+
+			var (
+				err  error
+				iter memdb.ResultIterator
+			)
+
+			// If the operator supplied a prefix, perform a prefix search.
+			// Otherwise, list all ACL roles in state.
+			switch prefix := args.QueryOptions.Prefix; prefix {
+			case "":
+				iter, err = stateStore.GetACLRoles(ws)
+			default:
+				iter, err = stateStore.GetACLRoleByIDPrefix(ws, prefix)
+			}
+			if err != nil {
+				return err
+			}
+
+			// Iterate all the results returned from the state query. Once
+			// these have been exhausted, we move onto updating the query
+			// metadata.
+			var stubs []*structs.ACLRoleListStub
+			for {
+				raw := iter.Next()
+				if raw == nil {
+					break
+				}
+				role := raw.(*structs.ACLRole)
+				if _, ok := roles[role.ID]; ok || mgt {
+					stubs = append(stubs, role.Stub())
+				}
+			}
+
+			// Populate the response using the role stubs.
+			reply.ACLRoles = stubs
+
+			// Use the index table to populate the query meta as we have no way
+			// of tracking the max index on deletes.
+			return a.srv.setReplyQueryMeta(stateStore, state.TableACLRoles, &reply.QueryMeta)
+		},
+	})
+}
+
+// GetRolesByID is used to get a set of ACL Roles as defined by their ID. This
+// endpoint is used by the replication process and Nomad agent client token
+// resolution.
+func (a *ACL) GetRolesByID(args *structs.ACLRolesByIDRequest, reply *structs.ACLRolesByIDResponse) error {
+
+	// This endpoint is only used by the replication process which is only
+	// running on ACL enabled clusters, so this check should never be
+	// triggered.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward(structs.ACLGetRolesByIDRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_roles_id"}, time.Now())
+
+	// For client typed tokens, allow them to query any roles associated with
+	// that token. This is used by Nomad agents in client mode which are
+	// resolving the roles to enforce.
+	token, err := a.requestACLToken(args.AuthToken)
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return structs.ErrTokenNotFound
+	}
+	if token.Type != structs.ACLManagementToken && !tokenHasRoles(token, args.ACLRoleIDs) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Set up and return the blocking query.
+	return a.srv.blockingRPC(&blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+
+			// Instantiate the output map to the correct maximum length.
+			reply.ACLRoles = make(map[string]*structs.ACLRole, len(args.ACLRoleIDs))
+
+			// Look for the ACL role and add this to our mapping if we have
+			// found it.
+			for _, roleID := range args.ACLRoleIDs {
+				out, err := stateStore.GetACLRoleByID(ws, roleID)
+				if err != nil {
+					return err
+				}
+				if out != nil {
+					reply.ACLRoles[out.ID] = out
+				}
+			}
+
+			// Use the index table to populate the query meta as we have no way
+			// of tracking the max index on deletes.
+			return a.srv.setReplyQueryMeta(stateStore, state.TableACLRoles, &reply.QueryMeta)
+		},
+	})
+}
+
+// tokenHasRoles returns whether each of the passed role IDs are linked to the
+// token.
+func tokenHasRoles(token *structs.ACLToken, roleIDs []string) bool {
+	linked := make(map[string]struct{}, len(token.Roles))
+	for _, roleLink := range token.Roles {
+		linked[roleLink.ID] = struct{}{}
+	}
+	for _, roleID := range roleIDs {
+		if _, ok := linked[roleID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// GetRoleByID is used to look up an individual ACL role using its ID.
+func (a *ACL) GetRoleByID(
+	args *structs.ACLRoleByIDRequest,
+	reply *structs.ACLRoleByIDResponse) error {
+
+	// Only allow operators to read an ACL role when ACLs are enabled.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward(structs.ACLGetRoleByIDRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_role_id"}, time.Now())
+
+	// Resolve the token and ensure it has some form of permissions.
+	acl, err := a.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if acl == nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// If the resolved token is not a management token, ensure the requested
+	// role is linked to it.
+	if !acl.IsManagement() {
+		token, err := a.requestACLToken(args.AuthToken)
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			return structs.ErrTokenNotFound
+		}
+		if !tokenHasRoles(token, []string{args.RoleID}) {
+			return structs.ErrPermissionDenied
+		}
+	}
+
+	// Set up and return the blocking query.
+	return a.srv.blockingRPC(&blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+
+			// Perform a lookup for the ACL role.
+			out, err := stateStore.GetACLRoleByID(ws, args.RoleID)
+			if err != nil {
+				return err
+			}
+
+			// Set the index correctly depending on whether the ACL role was
+			// found.
+			switch out {
+			case nil:
+				index, err := stateStore.Index(state.TableACLRoles)
+				if err != nil {
+					return err
+				}
+				reply.Index = index
+			default:
+				reply.Index = out.ModifyIndex
+			}
+
+			// We didn't encounter an error looking up the index; set the ACL
+			// role on the reply and exit successfully.
+			reply.ACLRole = out
+			return nil
+		},
+	})
+}
+
+// GetRoleByName is used to look up an individual ACL role using its name.
+func (a *ACL) GetRoleByName(
+	args *structs.ACLRoleByNameRequest,
+	reply *structs.ACLRoleByNameResponse) error {
+
+	// Only allow operators to read an ACL role when ACLs are enabled.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward(structs.ACLGetRoleByNameRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_role_name"}, time.Now())
+
+	// Resolve the token and ensure it has some form of permissions.
+	acl, err := a.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if acl == nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// If the resolved token is not a management token, ensure the requested
+	// role is linked to it. Role links always carry the name once stored.
+	if !acl.IsManagement() {
+		token, err := a.requestACLToken(args.AuthToken)
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			return structs.ErrTokenNotFound
+		}
+
+		found := false
+		for _, roleLink := range token.Roles {
+			if roleLink.Name == args.RoleName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return structs.ErrPermissionDenied
+		}
+	}
+
+	// Set up and return the blocking query.
+	return a.srv.blockingRPC(&blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+
+			// Perform a lookup for the ACL role.
+			out, err := stateStore.GetACLRoleByName(ws, args.RoleName)
+			if err != nil {
+				return err
+			}
+
+			// Set the index correctly depending on whether the ACL role was
+			// found.
+			switch out {
+			case nil:
+				index, err := stateStore.Index(state.TableACLRoles)
+				if err != nil {
+					return err
+				}
+				reply.Index = index
+			default:
+				reply.Index = out.ModifyIndex
+			}
+
+			// We didn't encounter an error looking up the index; set the ACL
+			// role on the reply and exit successfully.
+			reply.ACLRole = out
+			return nil
+		},
+	})
+}
+
+// UpsertAuthMethods is used to create or update a set of auth methods
+func (a *ACL) UpsertAuthMethods(
+	args *structs.ACLAuthMethodsUpsertRequest,
+	reply *structs.ACLAuthMethodsUpsertResponse) error {
+
+	// Only allow operators to upsert ACL auth methods when ACLs are enabled.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	// This endpoint always forwards to the authoritative region as ACL auth
+	// methods are global.
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward(structs.ACLUpsertAuthMethodsRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "upsert_auth_methods"}, time.Now())
+
+	// Only tokens with management level permissions can create ACL auth
+	// methods.
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of auth methods
+	if len(args.AuthMethods) == 0 {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "must specify as least one auth method")
+	}
+
+	// Snapshot the state so we can perform lookups against the existing
+	// default auth method.
+	stateSnapshot, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Track the name of the method within the request which is marked as the
+	// default, so we can ensure there is only one.
+	var defaultMethodName string
+
+	// Validate each auth method.
+	for idx, authMethod := range args.AuthMethods {
+
+		// Perform all the static validation of the ACL auth method object.
+		// Use the array index as we cannot be sure the error was caused by a
+		// missing name.
+		if err := authMethod.Validate(
+			a.srv.config.ACLTokenMinExpirationTTL,
+			a.srv.config.ACLTokenMaxExpirationTTL); err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "auth method %d invalid: %v", idx, err)
+		}
+
+		// Only a single auth method can be marked as the default. This
+		// includes methods already in state, other than the one being
+		// updated.
+		if authMethod.Default {
+			if defaultMethodName != "" {
+				return structs.NewErrRPCCodedf(http.StatusBadRequest,
+					"default auth method already exists: %s", defaultMethodName)
+			}
+			defaultMethodName = authMethod.Name
+
+			existingDefault, err := stateSnapshot.GetDefaultACLAuthMethod(nil)
+			if err != nil {
+				return structs.NewErrRPCCodedf(http.StatusInternalServerError,
+					"auth method lookup failed: %v", err)
+			}
+			if existingDefault != nil && existingDefault.Name != authMethod.Name {
+				return structs.NewErrRPCCodedf(http.StatusBadRequest,
+					"default auth method already exists: %s", existingDefault.Name)
+			}
+		}
+
+		// Ensure the auth method has its times and hash set.
+		authMethod.Canonicalize()
+		authMethod.SetHash()
+	}
+
+	// Update via Raft.
+	out, index, err := a.srv.raftApply(structs.ACLAuthMethodsUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the FSM response, which is an interface, contains an error.
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	// Populate the response. We do a lookup against the state to pick up the
+	// proper create / modify indexes.
+	stateSnapshot, err = a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, method := range args.AuthMethods {
+		lookupAuthMethod, err := stateSnapshot.GetACLAuthMethodByName(nil, method.Name)
+		if err != nil {
+			return structs.NewErrRPCCodedf(http.StatusInternalServerError,
+				"ACL auth method lookup failed: %v", err)
+		}
+		reply.AuthMethods = append(reply.AuthMethods, lookupAuthMethod)
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// DeleteAuthMethods is used to delete auth methods. Any binding rules which
+// reference a deleted auth method are also deleted.
+func (a *ACL) DeleteAuthMethods(
+	args *structs.ACLAuthMethodsDeleteRequest,
+	reply *structs.ACLAuthMethodsDeleteResponse) error {
+
+	// Only allow operators to delete ACL auth methods when ACLs are enabled.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	// This endpoint always forwards to the authoritative region as ACL auth
+	// methods are global.
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward(structs.ACLDeleteAuthMethodsRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "delete_auth_methods"}, time.Now())
+
+	// Only tokens with management level permissions can delete ACL auth
+	// methods.
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of auth methods
+	if len(args.Names) == 0 {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "must specify as least one auth method")
+	}
+
+	// Update via Raft.
+	out, index, err := a.srv.raftApply(structs.ACLAuthMethodsDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the FSM response, which is an interface, contains an error.
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// ListAuthMethods returns a list of ACL auth methods. Any token, including
+// the anonymous token, is able to list auth methods, as operators need to
+// discover them before logging in. Only the stub object is returned, which
+// does not contain any of the method configuration.
+func (a *ACL) ListAuthMethods(
+	args *structs.ACLAuthMethodListRequest,
+	reply *structs.ACLAuthMethodListResponse) error {
+
+	// Only allow operators to list ACL auth methods when ACLs are enabled.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward(structs.ACLListAuthMethodsRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "list_auth_methods"}, time.Now())
+
+	// Resolve the token and ensure it is valid.
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// Set up and return the blocking query.
+	return a.srv.blockingRPC(&blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+
+			// Get all the auth methods from state.
+			iter, err := stateStore.GetACLAuthMethods(ws)
+			if err != nil {
+				return err
+			}
+
+			// Iterate all the results returned from the state query. Once
+			// these have been exhausted, we move onto updating the query
+			// metadata.
+			var stubs []*structs.ACLAuthMethodStub
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				method := raw.(*structs.ACLAuthMethod)
+				stubs = append(stubs, method.Stub())
+			}
+
+			// Populate the response using the auth method stubs.
+			reply.AuthMethods = stubs
+
+			// Use the index table to populate the query meta as we have no way
+			// of tracking the max index on deletes.
+			return a.srv.setReplyQueryMeta(stateStore, state.TableACLAuthMethods, &reply.QueryMeta)
+		},
+	})
+}
+
+// GetAuthMethod is used to get a single auth method using its name.
+func (a *ACL) GetAuthMethod(
+	args *structs.ACLAuthMethodGetRequest,
+	reply *structs.ACLAuthMethodGetResponse) error {
+
+	// Only allow operators to read an ACL auth method when ACLs are enabled.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward(structs.ACLGetAuthMethodRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_auth_method_name"}, time.Now())
+
+	// Only tokens with management level permissions can read the full
+	// configuration of an ACL auth method.
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Set up and return the blocking query.
+	return a.srv.blockingRPC(&blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+
+			// Perform a lookup for the ACL auth method.
+			out, err := stateStore.GetACLAuthMethodByName(ws, args.MethodName)
+			if err != nil {
+				return err
+			}
+
+			// Set the index correctly depending on whether the ACL auth
+			// method was found.
+			switch out {
+			case nil:
+				index, err := stateStore.Index(state.TableACLAuthMethods)
+				if err != nil {
+					return err
+				}
+				reply.Index = index
+			default:
+				reply.Index = out.ModifyIndex
+			}
+
+			// We didn't encounter an error looking up the index; set the auth
+			// method on the reply and exit successfully.
+			reply.AuthMethod = out
+			return nil
+		},
+	})
+}
+
+// GetAuthMethods is used to get a set of auth methods using their names. This
+// endpoint is used by the replication process.
+func (a *ACL) GetAuthMethods(
+	args *structs.ACLAuthMethodsGetRequest,
+	reply *structs.ACLAuthMethodsGetResponse) error {
+
+	// This endpoint is only used by the replication process which is only
+	// running on ACL enabled clusters, so this check should never be
+	// triggered.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward(structs.ACLGetAuthMethodsRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_auth_methods"}, time.Now())
+
+	// Only tokens with management level permissions can read the full
+	// configuration of ACL auth methods.
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Set up and return the blocking query.
+	return a.srv.blockingRPC(&blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+
+			// Instantiate the output map to the correct maximum length.
+			reply.AuthMethods = make(map[string]*structs.ACLAuthMethod, len(args.Names))
+
+			// Look for the ACL auth method and add this to our mapping if we
+			// have found it.
+			for _, methodName := range args.Names {
+				out, err := stateStore.GetACLAuthMethodByName(ws, methodName)
+				if err != nil {
+					return err
+				}
+				if out != nil {
+					reply.AuthMethods[out.Name] = out
+				}
+			}
+
+			// Use the index table to populate the query meta as we have no way
+			// of tracking the max index on deletes.
+			return a.srv.setReplyQueryMeta(stateStore, state.TableACLAuthMethods, &reply.QueryMeta)
+		},
+	})
+}
+
+// UpsertBindingRules creates or updates ACL binding rules held within Nomad.
+func (a *ACL) UpsertBindingRules(
+	args *structs.ACLBindingRulesUpsertRequest,
+	reply *structs.ACLBindingRulesUpsertResponse) error {
+
+	// Only allow operators to upsert ACL binding rules when ACLs are enabled.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	// This endpoint always forwards to the authoritative region as ACL
+	// binding rules are global.
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward(structs.ACLUpsertBindingRulesRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "upsert_binding_rules"}, time.Now())
+
+	// Only tokens with management level permissions can create ACL binding
+	// rules.
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of binding rules.
+	if len(args.ACLBindingRules) == 0 {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "must specify as least one binding rule")
+	}
+
+	// Snapshot the state so we can perform lookups against the auth method
+	// and existing rules.
+	stateSnapshot, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Validate each binding rule.
+	for idx, bindingRule := range args.ACLBindingRules {
+
+		// If the caller has passed a rule ID, this call is considered an
+		// update to an existing rule. We should therefore ensure it is found
+		// within state.
+		if bindingRule.ID != "" {
+			existingRule, err := stateSnapshot.GetACLBindingRule(nil, bindingRule.ID)
+			if err != nil {
+				return structs.NewErrRPCCodedf(http.StatusInternalServerError,
+					"binding rule lookup failed: %v", err)
+			}
+			if existingRule == nil {
+				return structs.NewErrRPCCodedf(http.StatusBadRequest,
+					"cannot find binding rule %s", bindingRule.ID)
+			}
+
+			// The auth method of a binding rule cannot be changed, as the
+			// selector and bind name are specific to its claims.
+			if existingRule.AuthMethod != bindingRule.AuthMethod {
+				return structs.NewErrRPCCodedf(http.StatusBadRequest,
+					"cannot update auth method for binding rule %s", bindingRule.ID)
+			}
+		}
+
+		// Perform all the static validation of the ACL binding rule object.
+		// Use the array index as we cannot be sure the error was caused by a
+		// missing ID.
+		if err := bindingRule.Validate(); err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "binding rule %d invalid: %v", idx, err)
+		}
+
+		// Ensure the auth method linked to this binding rule exists within
+		// state.
+		method, err := stateSnapshot.GetACLAuthMethodByName(nil, bindingRule.AuthMethod)
+		if err != nil {
+			return structs.NewErrRPCCodedf(http.StatusInternalServerError,
+				"auth method lookup failed: %v", err)
+		}
+		if method == nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest,
+				"ACL auth method %s not found", bindingRule.AuthMethod)
+		}
+
+		// Ensure the binding rule has an ID, times and hash set.
+		bindingRule.Canonicalize()
+		bindingRule.SetHash()
+	}
+
+	// Update via Raft.
+	out, index, err := a.srv.raftApply(structs.ACLBindingRulesUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the FSM response, which is an interface, contains an error.
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	// Populate the response. We do a lookup against the state to pick up the
+	// proper create / modify indexes.
+	stateSnapshot, err = a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, bindingRule := range args.ACLBindingRules {
+		lookupBindingRule, err := stateSnapshot.GetACLBindingRule(nil, bindingRule.ID)
+		if err != nil {
+			return structs.NewErrRPCCodedf(http.StatusInternalServerError,
+				"ACL binding rule lookup failed: %v", err)
+		}
+		reply.ACLBindingRules = append(reply.ACLBindingRules, lookupBindingRule)
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// DeleteBindingRules is used to batch delete ACL binding rules using the ID
+// as the deletion key.
+func (a *ACL) DeleteBindingRules(
+	args *structs.ACLBindingRulesDeleteRequest,
+	reply *structs.ACLBindingRulesDeleteResponse) error {
+
+	// Only allow operators to delete ACL binding rules when ACLs are enabled.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	// This endpoint always forwards to the authoritative region as ACL
+	// binding rules are global.
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward(structs.ACLDeleteBindingRulesRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "delete_binding_rules"}, time.Now())
+
+	// Only tokens with management level permissions can delete ACL binding
+	// rules.
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of binding rules.
+	if len(args.ACLBindingRuleIDs) == 0 {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "must specify as least one binding rule")
+	}
+
+	// Update via Raft.
+	out, index, err := a.srv.raftApply(structs.ACLBindingRulesDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the FSM response, which is an interface, contains an error.
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// ListBindingRules returns a stub list of ACL binding rules.
+func (a *ACL) ListBindingRules(
+	args *structs.ACLBindingRulesListRequest,
+	reply *structs.ACLBindingRulesListResponse) error {
+
+	// Only allow operators to list ACL binding rules when ACLs are enabled.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward(structs.ACLListBindingRulesRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "list_binding_rules"}, time.Now())
+
+	// Only tokens with management level permissions can list ACL binding
+	// rules.
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Set up and return the blocking query.
+	return a.srv.blockingRPC(&blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+
+			// Get all the binding rules from state.
+			iter, err := stateStore.GetACLBindingRules(ws)
+			if err != nil {
+				return err
+			}
+
+			// Iterate all the results returned from the state query. Once
+			// these have been exhausted, we move onto updating the query
+			// metadata.
+			var stubs []*structs.ACLBindingRuleListStub
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				rule := raw.(*structs.ACLBindingRule)
+				stubs = append(stubs, rule.Stub())
+			}
+
+			// Populate the response using the binding rule stubs.
+			reply.ACLBindingRules = stubs
+
+			// Use the index table to populate the query meta as we have no way
+			// of tracking the max index on deletes.
+			return a.srv.setReplyQueryMeta(stateStore, state.TableACLBindingRules, &reply.QueryMeta)
+		},
+	})
+}
+
+// GetBindingRules is used to query for a set of ACL binding rules. This
+// endpoint is used by the replication process.
+func (a *ACL) GetBindingRules(
+	args *structs.ACLBindingRulesRequest,
+	reply *structs.ACLBindingRulesResponse) error {
+
+	// This endpoint is only used by the replication process which is only
+	// running on ACL enabled clusters, so this check should never be
+	// triggered.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward(structs.ACLGetBindingRulesRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_binding_rules"}, time.Now())
+
+	// Only tokens with management level permissions can read ACL binding
+	// rules.
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Set up and return the blocking query.
+	return a.srv.blockingRPC(&blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+
+			// Instantiate the output map to the correct maximum length.
+			reply.ACLBindingRules = make(map[string]*structs.ACLBindingRule, len(args.ACLBindingRuleIDs))
+
+			// Look for the ACL binding rule and add this to our mapping if we
+			// have found it.
+			for _, ruleID := range args.ACLBindingRuleIDs {
+				out, err := stateStore.GetACLBindingRule(ws, ruleID)
+				if err != nil {
+					return err
+				}
+				if out != nil {
+					reply.ACLBindingRules[out.ID] = out
+				}
+			}
+
+			// Use the index table to populate the query meta as we have no way
+			// of tracking the max index on deletes.
+			return a.srv.setReplyQueryMeta(stateStore, state.TableACLBindingRules, &reply.QueryMeta)
+		},
+	})
+}
+
+// GetBindingRule is used to retrieve a single ACL binding rule as defined by
+// its ID.
+func (a *ACL) GetBindingRule(
+	args *structs.ACLBindingRuleRequest,
+	reply *structs.ACLBindingRuleResponse) error {
+
+	// Only allow operators to read an ACL binding rule when ACLs are enabled.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	if done, err := a.srv.forward(structs.ACLGetBindingRuleRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_binding_rule"}, time.Now())
+
+	// Only tokens with management level permissions can read ACL binding
+	// rules.
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Set up and return the blocking query.
+	return a.srv.blockingRPC(&blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+
+			// Perform a lookup for the ACL binding rule.
+			out, err := stateStore.GetACLBindingRule(ws, args.ACLBindingRuleID)
+			if err != nil {
+				return err
+			}
+
+			// Set the index correctly depending on whether the ACL binding
+			// rule was found.
+			switch out {
+			case nil:
+				index, err := stateStore.Index(state.TableACLBindingRules)
+				if err != nil {
+					return err
+				}
+				reply.Index = index
+			default:
+				reply.Index = out.ModifyIndex
+			}
+
+			// We didn't encounter an error looking up the index; set the ACL
+			// binding rule on the reply and exit successfully.
+			reply.ACLBindingRule = out
+			return nil
+		},
+	})
+}
+
+// Login exchanges an identity token issued by the provider configured within
+// an auth method for a Nomad ACL token. The binding rules of the auth method
+// are evaluated against the claims of the identity token to determine the
+// roles and policies granted to the generated token. The endpoint does not
+// require an ACL token, as the identity token is the proof of identity.
+func (a *ACL) Login(args *structs.ACLLoginRequest, reply *structs.ACLLoginResponse) error {
+
+	// Only allow operators to login when ACLs are enabled.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	// Validate the request arguments before performing any lookups.
+	if err := args.Validate(); err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid login request: %v", err)
+	}
+
+	// Auth methods are replicated to all regions, so look up the method
+	// locally to determine whether the generated token is global. Global
+	// tokens must be created within the authoritative region.
+	authMethod, err := a.srv.State().GetACLAuthMethodByName(nil, args.AuthMethodName)
+	if err != nil {
+		return structs.NewErrRPCCodedf(http.StatusInternalServerError, "auth method lookup failed: %v", err)
+	}
+	if authMethod == nil {
+		return structs.NewErrRPCCodedf(http.StatusNotFound, "auth method %s not found", args.AuthMethodName)
+	}
+	if authMethod.TokenLocalityIsGlobal() {
+		args.Region = a.srv.config.AuthoritativeRegion
+	}
+
+	if done, err := a.srv.forward(structs.ACLLoginRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "login"}, time.Now())
+
+	// Snapshot the state, so the auth method and binding rules are read
+	// consistently. The request may have been forwarded, so the auth method
+	// is looked up again.
+	stateSnapshot, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	authMethod, err = stateSnapshot.GetACLAuthMethodByName(nil, args.AuthMethodName)
+	if err != nil {
+		return structs.NewErrRPCCodedf(http.StatusInternalServerError, "auth method lookup failed: %v", err)
+	}
+	if authMethod == nil {
+		return structs.NewErrRPCCodedf(http.StatusNotFound, "auth method %s not found", args.AuthMethodName)
+	}
+
+	// Verify the login token using the provider or keys configured within
+	// the auth method.
+	claims, err := a.verifyLoginToken(authMethod, args.LoginToken)
+	if err != nil {
+		return err
+	}
+
+	// Map the claims to the identity and evaluate the binding rules to
+	// determine the roles and policies of the token.
+	identity, err := auth.NewIdentity(authMethod.Config, claims)
+	if err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "failed to map claims: %v", err)
+	}
+	bindings, err := auth.NewBinder(stateSnapshot).Bind(authMethod, identity)
+	if err != nil {
+		return structs.NewErrRPCCodedf(http.StatusInternalServerError, "failed to apply binding rules: %v", err)
+	}
+	if bindings.None() {
+		return structs.NewErrRPCCoded(http.StatusForbidden, "no role or policy bindings matched")
+	}
+
+	// Build the ACL token. The token expires after the max TTL configured on
+	// the auth method.
+	token := &structs.ACLToken{
+		Name:          fmt.Sprintf("%s-%s", authMethod.Type, authMethod.Name),
+		Global:        authMethod.TokenLocalityIsGlobal(),
+		ExpirationTTL: authMethod.MaxTokenTTL,
+	}
+	if bindings.Management {
+		token.Type = structs.ACLManagementToken
+	} else {
+		token.Type = structs.ACLClientToken
+		token.Policies = bindings.Policies
+		if len(bindings.Roles) > 0 {
+			roleLinks, err := resolveTokenRoleLinks(stateSnapshot, bindings.Roles)
+			if err != nil {
+				return structs.NewErrRPCCodedf(http.StatusInternalServerError,
+					"failed to resolve role bindings: %v", err)
+			}
+			token.Roles = roleLinks
+		}
+	}
+
+	token.Canonicalize()
+	token.SetHash()
+
+	tokenUpsertRequest := structs.ACLTokenUpsertRequest{
+		Tokens: []*structs.ACLToken{token},
+	}
+
+	// Update via Raft.
+	out, index, err := a.srv.raftApply(structs.ACLTokenUpsertRequestType, &tokenUpsertRequest)
+	if err != nil {
+		return err
+	}
+
+	// Check if the FSM response, which is an interface, contains an error.
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	// Populate the response. We do a lookup against the state to pick up the
+	// proper create / modify indexes.
+	stateSnapshot, err = a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	reply.ACLToken, err = stateSnapshot.ACLTokenByAccessorID(nil, token.AccessorID)
+	if err != nil {
+		return structs.NewErrRPCCodedf(http.StatusInternalServerError, "ACL token lookup failed: %v", err)
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// verifyLoginToken verifies the signature and standard claims of the login
+// token according to the type of the auth method, returning all of its claims
+// on success. The returned error is an RPC coded error.
+func (a *ACL) verifyLoginToken(authMethod *structs.ACLAuthMethod, loginToken string) (map[string]interface{}, error) {
+
+	ctx, cancel := context.WithTimeout(a.srv.shutdownCtx, aclLoginProviderTimeout)
+	defer cancel()
+
+	var (
+		claims map[string]interface{}
+		err    error
+	)
+
+	switch authMethod.Type {
+	case structs.ACLAuthMethodTypeOIDC:
+		var provider *oidc.Provider
+		if provider, err = a.srv.oidcProviderCache.Get(ctx, authMethod); err != nil {
+			return nil, structs.NewErrRPCCodedf(http.StatusInternalServerError,
+				"failed to create OIDC provider for auth method %s: %v", authMethod.Name, err)
+		}
+		claims, err = provider.VerifyIDToken(ctx, loginToken)
+	case structs.ACLAuthMethodTypeJWT:
+		var validator *jwt.Validator
+		if validator, err = a.srv.jwtValidatorCache.Get(authMethod); err != nil {
+			return nil, structs.NewErrRPCCodedf(http.StatusInternalServerError,
+				"failed to create JWT validator for auth method %s: %v", authMethod.Name, err)
+		}
+		claims, err = validator.Validate(ctx, loginToken)
+	default:
+		return nil, structs.NewErrRPCCodedf(http.StatusInternalServerError,
+			"unsupported auth method type %q", authMethod.Type)
+	}
+
+	if err != nil {
+		return nil, structs.NewErrRPCCodedf(http.StatusUnauthorized, "failed to verify login token: %v", err)
+	}
+	return claims, nil
+}
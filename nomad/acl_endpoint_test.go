@@ -10,7 +10,9 @@ import (
 
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/lib/auth/oidc"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
@@ -265,6 +267,38 @@ func TestACLEndpoint_GetPolicies_TokenSubset(t *testing.T) {
 	}
 }
 
+func TestACLEndpoint_GetPolicies_ExpiredToken(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	policy := mock.ACLPolicy()
+	require.NoError(t, s1.fsm.State().UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 1000, []*structs.ACLPolicy{policy}))
+
+	// An expired token can't read the policies it's linked to
+	token := mock.ACLToken()
+	token.Policies = []string{policy.Name}
+	token.ExpirationTime = pointer.Of(time.Now().UTC().Add(-time.Minute))
+	require.NoError(t, s1.fsm.State().UpsertACLTokens(
+		structs.MsgTypeTestSetup, 1001, []*structs.ACLToken{token}))
+
+	get := &structs.ACLPolicySetRequest{
+		Names: []string{policy.Name},
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: token.SecretID,
+		},
+	}
+	var resp structs.ACLPolicySetResponse
+	err := msgpackrpc.CallWithCodec(codec, "ACL.GetPolicies", get, &resp)
+	require.EqualError(t, err, structs.ErrTokenExpired.Error())
+	require.Empty(t, resp.Policies)
+}
+
 func TestACLEndpoint_GetPolicies_Blocking(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1535,6 +1569,57 @@ func TestACLEndpoint_UpsertTokens_Invalid(t *testing.T) {
 	}
 }
 
+func TestACLEndpoint_UpsertTokens_Expiration(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create a token with a TTL, which should have its expiration time set.
+	p1 := mock.ACLToken()
+	p1.AccessorID = ""
+	p1.ExpirationTTL = time.Hour
+
+	req := &structs.ACLTokenUpsertRequest{
+		Tokens: []*structs.ACLToken{p1},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLTokenUpsertResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp))
+	require.Len(t, resp.Tokens, 1)
+
+	created := resp.Tokens[0]
+	require.NotNil(t, created.ExpirationTime)
+	require.Equal(t, created.CreateTime.Add(time.Hour), *created.ExpirationTime)
+
+	// Attempting to modify the expiration time should fail.
+	updated := created.Copy()
+	updated.ExpirationTime = pointer.Of(created.ExpirationTime.Add(time.Hour))
+	req.Tokens = []*structs.ACLToken{updated}
+	err := msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp)
+	require.ErrorContains(t, err, "cannot update expiration time")
+
+	// A TTL outside the configured bounds should fail.
+	p2 := mock.ACLToken()
+	p2.AccessorID = ""
+	p2.ExpirationTTL = s1.config.ACLTokenMaxExpirationTTL + time.Hour
+	req.Tokens = []*structs.ACLToken{p2}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp)
+	require.ErrorContains(t, err, "expiration time cannot be more than")
+
+	p3 := mock.ACLToken()
+	p3.AccessorID = ""
+	p3.ExpirationTTL = time.Second
+	req.Tokens = []*structs.ACLToken{p3}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp)
+	require.ErrorContains(t, err, "expiration time cannot be less than")
+}
+
 func TestACLEndpoint_ResolveToken(t *testing.T) {
 	ci.Parallel(t)
 	s1, _, cleanupS1 := TestACLServer(t, nil)
@@ -1685,3 +1770,910 @@ func TestACLEndpoint_OneTimeToken(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, ott)
 }
+
+func TestACLEndpoint_UpsertTokens_WithRoles(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the policies our ACL role wants to link to and the role itself.
+	policy1 := mock.ACLPolicy()
+	policy1.Name = "mocked-test-policy-1"
+	policy2 := mock.ACLPolicy()
+	policy2.Name = "mocked-test-policy-2"
+	require.NoError(t, s1.fsm.State().UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLPolicy{policy1, policy2}))
+
+	aclRole := mock.ACLRole()
+	require.NoError(t, s1.fsm.State().UpsertACLRoles(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLRole{aclRole}, false))
+
+	// Create a token which links to the role by name only.
+	token := mock.ACLToken()
+	token.AccessorID = ""
+	token.Policies = nil
+	token.Roles = []*structs.ACLTokenRoleLink{{Name: aclRole.Name}}
+
+	req := &structs.ACLTokenUpsertRequest{
+		Tokens: []*structs.ACLToken{token},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLTokenUpsertResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp))
+	require.Len(t, resp.Tokens, 1)
+	require.Equal(t, []*structs.ACLTokenRoleLink{{ID: aclRole.ID, Name: aclRole.Name}}, resp.Tokens[0].Roles)
+
+	// Resolving the token should grant the permissions of the role policies.
+	aclObj, err := s1.ResolveToken(resp.Tokens[0].SecretID)
+	require.NoError(t, err)
+	require.True(t, aclObj.AllowNamespace("default"))
+
+	// Linking a token to a role that does not exist should fail.
+	token = mock.ACLToken()
+	token.AccessorID = ""
+	token.Policies = nil
+	token.Roles = []*structs.ACLTokenRoleLink{{Name: "not-a-role"}}
+	req.Tokens = []*structs.ACLToken{token}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp)
+	require.ErrorContains(t, err, "cannot find role not-a-role")
+}
+
+func TestACL_UpsertRoles(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, rootACLToken, testServerCleanupFn := TestACLServer(t, nil)
+	defer testServerCleanupFn()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	// Try upserting a role without a token.
+	aclRoleReq1 := &structs.ACLRolesUpsertRequest{
+		ACLRoles: []*structs.ACLRole{mock.ACLRole()},
+		WriteRequest: structs.WriteRequest{
+			Region: DefaultRegion,
+		},
+	}
+	var aclRoleResp1 structs.ACLRolesUpsertResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLUpsertRolesRPCMethod, aclRoleReq1, &aclRoleResp1)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Try upserting a role that links to policies that do not exist.
+	aclRoleReq2 := &structs.ACLRolesUpsertRequest{
+		ACLRoles: []*structs.ACLRole{mock.ACLRole()},
+		WriteRequest: structs.WriteRequest{
+			Region:    DefaultRegion,
+			AuthToken: rootACLToken.SecretID,
+		},
+	}
+	var aclRoleResp2 structs.ACLRolesUpsertResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertRolesRPCMethod, aclRoleReq2, &aclRoleResp2)
+	require.ErrorContains(t, err, "cannot find policy")
+
+	// Create the policies our ACL roles wants to link to.
+	policy1 := mock.ACLPolicy()
+	policy1.Name = "mocked-test-policy-1"
+	policy2 := mock.ACLPolicy()
+	policy2.Name = "mocked-test-policy-2"
+	require.NoError(t, testServer.fsm.State().UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLPolicy{policy1, policy2}))
+
+	// Try upserting the role now the policies exist. The ID is not set, so it
+	// should be generated by the endpoint.
+	aclRole := mock.ACLRole()
+	aclRole.ID = ""
+	aclRoleReq3 := &structs.ACLRolesUpsertRequest{
+		ACLRoles: []*structs.ACLRole{aclRole},
+		WriteRequest: structs.WriteRequest{
+			Region:    DefaultRegion,
+			AuthToken: rootACLToken.SecretID,
+		},
+	}
+	var aclRoleResp3 structs.ACLRolesUpsertResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertRolesRPCMethod, aclRoleReq3, &aclRoleResp3)
+	require.NoError(t, err)
+	require.Len(t, aclRoleResp3.ACLRoles, 1)
+	require.NotEmpty(t, aclRoleResp3.ACLRoles[0].ID)
+	require.Equal(t, aclRole.Name, aclRoleResp3.ACLRoles[0].Name)
+
+	// Try creating a second role with the same name, which should fail.
+	duplicateRole := mock.ACLRole()
+	duplicateRole.ID = ""
+	duplicateRole.Name = aclRole.Name
+	aclRoleReq4 := &structs.ACLRolesUpsertRequest{
+		ACLRoles: []*structs.ACLRole{duplicateRole},
+		WriteRequest: structs.WriteRequest{
+			Region:    DefaultRegion,
+			AuthToken: rootACLToken.SecretID,
+		},
+	}
+	var aclRoleResp4 structs.ACLRolesUpsertResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertRolesRPCMethod, aclRoleReq4, &aclRoleResp4)
+	require.ErrorContains(t, err, "already exists")
+
+	// Try updating a role using an ID which does not exist.
+	missingRole := mock.ACLRole()
+	aclRoleReq5 := &structs.ACLRolesUpsertRequest{
+		ACLRoles: []*structs.ACLRole{missingRole},
+		WriteRequest: structs.WriteRequest{
+			Region:    DefaultRegion,
+			AuthToken: rootACLToken.SecretID,
+		},
+	}
+	var aclRoleResp5 structs.ACLRolesUpsertResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertRolesRPCMethod, aclRoleReq5, &aclRoleResp5)
+	require.ErrorContains(t, err, "cannot find role")
+
+	// Update the existing role name.
+	updatedRole := aclRoleResp3.ACLRoles[0].Copy()
+	updatedRole.Name = "updated-role-name"
+	aclRoleReq6 := &structs.ACLRolesUpsertRequest{
+		ACLRoles: []*structs.ACLRole{updatedRole},
+		WriteRequest: structs.WriteRequest{
+			Region:    DefaultRegion,
+			AuthToken: rootACLToken.SecretID,
+		},
+	}
+	var aclRoleResp6 structs.ACLRolesUpsertResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertRolesRPCMethod, aclRoleReq6, &aclRoleResp6)
+	require.NoError(t, err)
+	require.Len(t, aclRoleResp6.ACLRoles, 1)
+	require.Equal(t, updatedRole.ID, aclRoleResp6.ACLRoles[0].ID)
+	require.Equal(t, "updated-role-name", aclRoleResp6.ACLRoles[0].Name)
+	require.Greater(t, aclRoleResp6.ACLRoles[0].ModifyIndex, aclRoleResp6.ACLRoles[0].CreateIndex)
+}
+
+func TestACL_DeleteRoles(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, rootACLToken, testServerCleanupFn := TestACLServer(t, nil)
+	defer testServerCleanupFn()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	// Create some ACL roles directly in state.
+	aclRoles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	require.NoError(t, testServer.fsm.State().UpsertACLRoles(structs.MsgTypeTestSetup, 10, aclRoles, true))
+
+	// Try deleting a role without a token.
+	aclRoleReq1 := &structs.ACLRolesDeleteRequest{
+		ACLRoleIDs: []string{aclRoles[0].ID},
+		WriteRequest: structs.WriteRequest{
+			Region: DefaultRegion,
+		},
+	}
+	var aclRoleResp1 structs.ACLRolesDeleteResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLDeleteRolesRPCMethod, aclRoleReq1, &aclRoleResp1)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Delete a role using the management token.
+	aclRoleReq2 := &structs.ACLRolesDeleteRequest{
+		ACLRoleIDs: []string{aclRoles[0].ID},
+		WriteRequest: structs.WriteRequest{
+			Region:    DefaultRegion,
+			AuthToken: rootACLToken.SecretID,
+		},
+	}
+	var aclRoleResp2 structs.ACLRolesDeleteResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLDeleteRolesRPCMethod, aclRoleReq2, &aclRoleResp2)
+	require.NoError(t, err)
+	require.NotZero(t, aclRoleResp2.Index)
+
+	// Try deleting a role that does not exist.
+	aclRoleReq3 := &structs.ACLRolesDeleteRequest{
+		ACLRoleIDs: []string{aclRoles[0].ID},
+		WriteRequest: structs.WriteRequest{
+			Region:    DefaultRegion,
+			AuthToken: rootACLToken.SecretID,
+		},
+	}
+	var aclRoleResp3 structs.ACLRolesDeleteResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLDeleteRolesRPCMethod, aclRoleReq3, &aclRoleResp3)
+	require.ErrorContains(t, err, "ACL role not found")
+
+	// Only the second role should remain within state.
+	out, err := testServer.fsm.State().GetACLRoleByID(nil, aclRoles[1].ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
+func TestACL_ListRoles(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, rootACLToken, testServerCleanupFn := TestACLServer(t, nil)
+	defer testServerCleanupFn()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	// Create some ACL roles directly in state, along with a client token
+	// linked to the first role.
+	aclRoles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	aclRoles[0].ID = "prefix-" + aclRoles[0].ID
+	require.NoError(t, testServer.fsm.State().UpsertACLRoles(structs.MsgTypeTestSetup, 10, aclRoles, true))
+
+	token := mock.ACLToken()
+	token.Policies = nil
+	token.Roles = []*structs.ACLTokenRoleLink{{ID: aclRoles[0].ID, Name: aclRoles[0].Name}}
+	require.NoError(t, testServer.fsm.State().UpsertACLTokens(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLToken{token}))
+
+	// The management token should be able to list all roles.
+	aclRoleReq1 := &structs.ACLRolesListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    DefaultRegion,
+			AuthToken: rootACLToken.SecretID,
+		},
+	}
+	var aclRoleResp1 structs.ACLRolesListResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLListRolesRPCMethod, aclRoleReq1, &aclRoleResp1)
+	require.NoError(t, err)
+	require.Len(t, aclRoleResp1.ACLRoles, 2)
+	require.Equal(t, uint64(10), aclRoleResp1.Index)
+
+	// Use a prefix to filter the results.
+	aclRoleReq2 := &structs.ACLRolesListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    DefaultRegion,
+			AuthToken: rootACLToken.SecretID,
+			Prefix:    "prefix-",
+		},
+	}
+	var aclRoleResp2 structs.ACLRolesListResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLListRolesRPCMethod, aclRoleReq2, &aclRoleResp2)
+	require.NoError(t, err)
+	require.Len(t, aclRoleResp2.ACLRoles, 1)
+	require.Equal(t, aclRoles[0].ID, aclRoleResp2.ACLRoles[0].ID)
+
+	// The client token should only be able to list its linked role.
+	aclRoleReq3 := &structs.ACLRolesListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    DefaultRegion,
+			AuthToken: token.SecretID,
+		},
+	}
+	var aclRoleResp3 structs.ACLRolesListResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLListRolesRPCMethod, aclRoleReq3, &aclRoleResp3)
+	require.NoError(t, err)
+	require.Len(t, aclRoleResp3.ACLRoles, 1)
+	require.Equal(t, aclRoles[0].ID, aclRoleResp3.ACLRoles[0].ID)
+}
+
+func TestACL_GetRolesByID(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, rootACLToken, testServerCleanupFn := TestACLServer(t, nil)
+	defer testServerCleanupFn()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	// Create some ACL roles directly in state, along with a client token
+	// linked to the first role.
+	aclRoles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	require.NoError(t, testServer.fsm.State().UpsertACLRoles(structs.MsgTypeTestSetup, 10, aclRoles, true))
+
+	token := mock.ACLToken()
+	token.Policies = nil
+	token.Roles = []*structs.ACLTokenRoleLink{{ID: aclRoles[0].ID, Name: aclRoles[0].Name}}
+	require.NoError(t, testServer.fsm.State().UpsertACLTokens(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLToken{token}))
+
+	// The management token can read both roles.
+	aclRoleReq1 := &structs.ACLRolesByIDRequest{
+		ACLRoleIDs: []string{aclRoles[0].ID, aclRoles[1].ID},
+		QueryOptions: structs.QueryOptions{
+			Region:    DefaultRegion,
+			AuthToken: rootACLToken.SecretID,
+		},
+	}
+	var aclRoleResp1 structs.ACLRolesByIDResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLGetRolesByIDRPCMethod, aclRoleReq1, &aclRoleResp1)
+	require.NoError(t, err)
+	require.Len(t, aclRoleResp1.ACLRoles, 2)
+
+	// The client token can only read its linked role.
+	aclRoleReq1.AuthToken = token.SecretID
+	var aclRoleResp2 structs.ACLRolesByIDResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLGetRolesByIDRPCMethod, aclRoleReq1, &aclRoleResp2)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	aclRoleReq1.ACLRoleIDs = []string{aclRoles[0].ID}
+	var aclRoleResp3 structs.ACLRolesByIDResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLGetRolesByIDRPCMethod, aclRoleReq1, &aclRoleResp3)
+	require.NoError(t, err)
+	require.Len(t, aclRoleResp3.ACLRoles, 1)
+	require.Equal(t, aclRoles[0], aclRoleResp3.ACLRoles[aclRoles[0].ID])
+}
+
+func TestACL_GetRoleByIDAndName(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, rootACLToken, testServerCleanupFn := TestACLServer(t, nil)
+	defer testServerCleanupFn()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	// Create some ACL roles directly in state, along with a client token
+	// linked to the first role.
+	aclRoles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	require.NoError(t, testServer.fsm.State().UpsertACLRoles(structs.MsgTypeTestSetup, 10, aclRoles, true))
+
+	token := mock.ACLToken()
+	token.Policies = nil
+	token.Roles = []*structs.ACLTokenRoleLink{{ID: aclRoles[0].ID, Name: aclRoles[0].Name}}
+	require.NoError(t, testServer.fsm.State().UpsertACLTokens(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLToken{token}))
+
+	// Read both roles by their ID and name using the management token.
+	for _, aclRole := range aclRoles {
+		aclRoleReq1 := &structs.ACLRoleByIDRequest{
+			RoleID: aclRole.ID,
+			QueryOptions: structs.QueryOptions{
+				Region:    DefaultRegion,
+				AuthToken: rootACLToken.SecretID,
+			},
+		}
+		var aclRoleResp1 structs.ACLRoleByIDResponse
+		err := msgpackrpc.CallWithCodec(codec, structs.ACLGetRoleByIDRPCMethod, aclRoleReq1, &aclRoleResp1)
+		require.NoError(t, err)
+		require.Equal(t, aclRole, aclRoleResp1.ACLRole)
+
+		aclRoleReq2 := &structs.ACLRoleByNameRequest{
+			RoleName: aclRole.Name,
+			QueryOptions: structs.QueryOptions{
+				Region:    DefaultRegion,
+				AuthToken: rootACLToken.SecretID,
+			},
+		}
+		var aclRoleResp2 structs.ACLRoleByNameResponse
+		err = msgpackrpc.CallWithCodec(codec, structs.ACLGetRoleByNameRPCMethod, aclRoleReq2, &aclRoleResp2)
+		require.NoError(t, err)
+		require.Equal(t, aclRole, aclRoleResp2.ACLRole)
+	}
+
+	// The client token can read its linked role, but not the other.
+	aclRoleReq3 := &structs.ACLRoleByIDRequest{
+		RoleID: aclRoles[0].ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    DefaultRegion,
+			AuthToken: token.SecretID,
+		},
+	}
+	var aclRoleResp3 structs.ACLRoleByIDResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLGetRoleByIDRPCMethod, aclRoleReq3, &aclRoleResp3)
+	require.NoError(t, err)
+	require.Equal(t, aclRoles[0], aclRoleResp3.ACLRole)
+
+	aclRoleReq4 := &structs.ACLRoleByNameRequest{
+		RoleName: aclRoles[1].Name,
+		QueryOptions: structs.QueryOptions{
+			Region:    DefaultRegion,
+			AuthToken: token.SecretID,
+		},
+	}
+	var aclRoleResp4 structs.ACLRoleByNameResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLGetRoleByNameRPCMethod, aclRoleReq4, &aclRoleResp4)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+}
+
+func TestACL_UpsertAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, rootACLToken, testServerCleanupFn := TestACLServer(t, nil)
+	defer testServerCleanupFn()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	// Create a mock auth method and try to upsert it without a token.
+	mockedAuthMethod := mock.ACLAuthMethod()
+
+	authMethodReq1 := &structs.ACLAuthMethodsUpsertRequest{
+		AuthMethods: []*structs.ACLAuthMethod{mockedAuthMethod},
+		WriteRequest: structs.WriteRequest{
+			Region: DefaultRegion,
+		},
+	}
+	var authMethodResp1 structs.ACLAuthMethodsUpsertResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLUpsertAuthMethodsRPCMethod, authMethodReq1, &authMethodResp1)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Upsert the auth method using the management token.
+	authMethodReq1.AuthToken = rootACLToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertAuthMethodsRPCMethod, authMethodReq1, &authMethodResp1)
+	require.NoError(t, err)
+	require.Len(t, authMethodResp1.AuthMethods, 1)
+	require.Equal(t, mockedAuthMethod.Name, authMethodResp1.AuthMethods[0].Name)
+	require.NotZero(t, authMethodResp1.AuthMethods[0].CreateIndex)
+
+	// Try upserting an invalid auth method.
+	invalidAuthMethod := mock.ACLAuthMethod()
+	invalidAuthMethod.MaxTokenTTL = 100 * time.Hour
+
+	authMethodReq2 := &structs.ACLAuthMethodsUpsertRequest{
+		AuthMethods: []*structs.ACLAuthMethod{invalidAuthMethod},
+		WriteRequest: structs.WriteRequest{
+			Region:    DefaultRegion,
+			AuthToken: rootACLToken.SecretID,
+		},
+	}
+	var authMethodResp2 structs.ACLAuthMethodsUpsertResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertAuthMethodsRPCMethod, authMethodReq2, &authMethodResp2)
+	require.ErrorContains(t, err, "invalid MaxTokenTTL value")
+
+	// Mark the first method as the default, then try and create a second
+	// default method which should fail.
+	mockedAuthMethod.Default = true
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertAuthMethodsRPCMethod, authMethodReq1, &authMethodResp1)
+	require.NoError(t, err)
+
+	secondDefaultAuthMethod := mock.ACLAuthMethod()
+	secondDefaultAuthMethod.Default = true
+
+	authMethodReq3 := &structs.ACLAuthMethodsUpsertRequest{
+		AuthMethods: []*structs.ACLAuthMethod{secondDefaultAuthMethod},
+		WriteRequest: structs.WriteRequest{
+			Region:    DefaultRegion,
+			AuthToken: rootACLToken.SecretID,
+		},
+	}
+	var authMethodResp3 structs.ACLAuthMethodsUpsertResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertAuthMethodsRPCMethod, authMethodReq3, &authMethodResp3)
+	require.ErrorContains(t, err, "default auth method already exists")
+}
+
+func TestACL_DeleteAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, rootACLToken, testServerCleanupFn := TestACLServer(t, nil)
+	defer testServerCleanupFn()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	// Create some auth methods directly in state.
+	authMethods := []*structs.ACLAuthMethod{mock.ACLAuthMethod(), mock.ACLAuthMethod()}
+	require.NoError(t, testServer.fsm.State().UpsertACLAuthMethods(structs.MsgTypeTestSetup, 10, authMethods))
+
+	// Try deleting an auth method without a token.
+	authMethodReq1 := &structs.ACLAuthMethodsDeleteRequest{
+		Names: []string{authMethods[0].Name},
+		WriteRequest: structs.WriteRequest{
+			Region: DefaultRegion,
+		},
+	}
+	var authMethodResp1 structs.ACLAuthMethodsDeleteResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLDeleteAuthMethodsRPCMethod, authMethodReq1, &authMethodResp1)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Delete an auth method using the management token.
+	authMethodReq1.AuthToken = rootACLToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLDeleteAuthMethodsRPCMethod, authMethodReq1, &authMethodResp1)
+	require.NoError(t, err)
+	require.NotZero(t, authMethodResp1.Index)
+
+	// Try deleting an auth method that does not exist.
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLDeleteAuthMethodsRPCMethod, authMethodReq1, &authMethodResp1)
+	require.ErrorContains(t, err, "ACL auth method not found")
+
+	// Only the second auth method should remain within state.
+	out, err := testServer.fsm.State().GetACLAuthMethodByName(nil, authMethods[1].Name)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
+func TestACL_ListAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, _, testServerCleanupFn := TestACLServer(t, nil)
+	defer testServerCleanupFn()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	// Create some auth methods directly in state.
+	authMethods := []*structs.ACLAuthMethod{mock.ACLAuthMethod(), mock.ACLAuthMethod()}
+	require.NoError(t, testServer.fsm.State().UpsertACLAuthMethods(structs.MsgTypeTestSetup, 10, authMethods))
+
+	// Listing auth methods does not require a token, so operators can find
+	// a method to login with.
+	authMethodReq := &structs.ACLAuthMethodListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: DefaultRegion,
+		},
+	}
+	var authMethodResp structs.ACLAuthMethodListResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLListAuthMethodsRPCMethod, authMethodReq, &authMethodResp)
+	require.NoError(t, err)
+	require.Len(t, authMethodResp.AuthMethods, 2)
+	require.Equal(t, uint64(10), authMethodResp.Index)
+
+	// Perform a blocking query, which should return once an auth method is
+	// deleted.
+	time.AfterFunc(100*time.Millisecond, func() {
+		require.NoError(t, testServer.fsm.State().DeleteACLAuthMethods(
+			structs.MsgTypeTestSetup, 20, []string{authMethods[0].Name}))
+	})
+
+	authMethodReq.MinQueryIndex = 10
+	authMethodReq.MaxQueryTime = 2 * time.Second
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLListAuthMethodsRPCMethod, authMethodReq, &authMethodResp)
+	require.NoError(t, err)
+	require.Len(t, authMethodResp.AuthMethods, 1)
+	require.Equal(t, uint64(20), authMethodResp.Index)
+}
+
+func TestACL_GetAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, rootACLToken, testServerCleanupFn := TestACLServer(t, nil)
+	defer testServerCleanupFn()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	// Create some auth methods directly in state.
+	authMethods := []*structs.ACLAuthMethod{mock.ACLAuthMethod(), mock.ACLAuthMethod()}
+	require.NoError(t, testServer.fsm.State().UpsertACLAuthMethods(structs.MsgTypeTestSetup, 10, authMethods))
+
+	// Try reading an auth method without a token.
+	authMethodReq1 := &structs.ACLAuthMethodGetRequest{
+		MethodName: authMethods[0].Name,
+		QueryOptions: structs.QueryOptions{
+			Region: DefaultRegion,
+		},
+	}
+	var authMethodResp1 structs.ACLAuthMethodGetResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLGetAuthMethodRPCMethod, authMethodReq1, &authMethodResp1)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Read the auth method using the management token.
+	authMethodReq1.AuthToken = rootACLToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLGetAuthMethodRPCMethod, authMethodReq1, &authMethodResp1)
+	require.NoError(t, err)
+	require.Equal(t, authMethods[0].Hash, authMethodResp1.AuthMethod.Hash)
+
+	// Read a missing auth method, which should not error.
+	authMethodReq1.MethodName = "missing"
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLGetAuthMethodRPCMethod, authMethodReq1, &authMethodResp1)
+	require.NoError(t, err)
+	require.Nil(t, authMethodResp1.AuthMethod)
+
+	// Read both auth methods using the batch endpoint used by replication.
+	authMethodReq2 := &structs.ACLAuthMethodsGetRequest{
+		Names: []string{authMethods[0].Name, authMethods[1].Name, "missing"},
+		QueryOptions: structs.QueryOptions{
+			Region:    DefaultRegion,
+			AuthToken: rootACLToken.SecretID,
+		},
+	}
+	var authMethodResp2 structs.ACLAuthMethodsGetResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLGetAuthMethodsRPCMethod, authMethodReq2, &authMethodResp2)
+	require.NoError(t, err)
+	require.Len(t, authMethodResp2.AuthMethods, 2)
+	require.Contains(t, authMethodResp2.AuthMethods, authMethods[0].Name)
+	require.Contains(t, authMethodResp2.AuthMethods, authMethods[1].Name)
+}
+
+func TestACL_UpsertBindingRules(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, rootACLToken, testServerCleanupFn := TestACLServer(t, nil)
+	defer testServerCleanupFn()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	// Create a mock binding rule and try to upsert it without a token.
+	mockedBindingRule := mock.ACLBindingRule()
+	mockedBindingRule.ID = ""
+
+	bindingRuleReq1 := &structs.ACLBindingRulesUpsertRequest{
+		ACLBindingRules: []*structs.ACLBindingRule{mockedBindingRule},
+		WriteRequest: structs.WriteRequest{
+			Region: DefaultRegion,
+		},
+	}
+	var bindingRuleResp1 structs.ACLBindingRulesUpsertResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLUpsertBindingRulesRPCMethod, bindingRuleReq1, &bindingRuleResp1)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Upsert the binding rule using the management token. This should fail,
+	// as the auth method does not exist.
+	bindingRuleReq1.AuthToken = rootACLToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertBindingRulesRPCMethod, bindingRuleReq1, &bindingRuleResp1)
+	require.ErrorContains(t, err, "not found")
+
+	// Create the auth method and try again.
+	authMethod := mock.ACLAuthMethod()
+	authMethod.Name = mockedBindingRule.AuthMethod
+	require.NoError(t, testServer.fsm.State().UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod}))
+
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertBindingRulesRPCMethod, bindingRuleReq1, &bindingRuleResp1)
+	require.NoError(t, err)
+	require.Len(t, bindingRuleResp1.ACLBindingRules, 1)
+	require.NotEmpty(t, bindingRuleResp1.ACLBindingRules[0].ID)
+	require.NotZero(t, bindingRuleResp1.ACLBindingRules[0].CreateIndex)
+
+	// Try updating a binding rule which does not exist.
+	missingBindingRule := mock.ACLBindingRule()
+
+	bindingRuleReq2 := &structs.ACLBindingRulesUpsertRequest{
+		ACLBindingRules: []*structs.ACLBindingRule{missingBindingRule},
+		WriteRequest: structs.WriteRequest{
+			Region:    DefaultRegion,
+			AuthToken: rootACLToken.SecretID,
+		},
+	}
+	var bindingRuleResp2 structs.ACLBindingRulesUpsertResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertBindingRulesRPCMethod, bindingRuleReq2, &bindingRuleResp2)
+	require.ErrorContains(t, err, "cannot find binding rule")
+
+	// Try upserting an invalid binding rule.
+	invalidBindingRule := mock.ACLBindingRule()
+	invalidBindingRule.ID = ""
+	invalidBindingRule.BindType = "service"
+
+	bindingRuleReq3 := &structs.ACLBindingRulesUpsertRequest{
+		ACLBindingRules: []*structs.ACLBindingRule{invalidBindingRule},
+		WriteRequest: structs.WriteRequest{
+			Region:    DefaultRegion,
+			AuthToken: rootACLToken.SecretID,
+		},
+	}
+	var bindingRuleResp3 structs.ACLBindingRulesUpsertResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertBindingRulesRPCMethod, bindingRuleReq3, &bindingRuleResp3)
+	require.ErrorContains(t, err, "unsupported bind type")
+}
+
+func TestACL_DeleteBindingRules(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, rootACLToken, testServerCleanupFn := TestACLServer(t, nil)
+	defer testServerCleanupFn()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	// Create some binding rules directly in state.
+	bindingRules := []*structs.ACLBindingRule{mock.ACLBindingRule(), mock.ACLBindingRule()}
+	require.NoError(t, testServer.fsm.State().UpsertACLBindingRules(structs.MsgTypeTestSetup, 10, bindingRules, true))
+
+	// Try deleting a binding rule without a token.
+	bindingRuleReq1 := &structs.ACLBindingRulesDeleteRequest{
+		ACLBindingRuleIDs: []string{bindingRules[0].ID},
+		WriteRequest: structs.WriteRequest{
+			Region: DefaultRegion,
+		},
+	}
+	var bindingRuleResp1 structs.ACLBindingRulesDeleteResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLDeleteBindingRulesRPCMethod, bindingRuleReq1, &bindingRuleResp1)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Delete a binding rule using the management token.
+	bindingRuleReq1.AuthToken = rootACLToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLDeleteBindingRulesRPCMethod, bindingRuleReq1, &bindingRuleResp1)
+	require.NoError(t, err)
+	require.NotZero(t, bindingRuleResp1.Index)
+
+	// Try deleting a binding rule that does not exist.
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLDeleteBindingRulesRPCMethod, bindingRuleReq1, &bindingRuleResp1)
+	require.ErrorContains(t, err, "ACL binding rule not found")
+
+	// Only the second binding rule should remain within state.
+	out, err := testServer.fsm.State().GetACLBindingRule(nil, bindingRules[1].ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
+func TestACL_ListAndGetBindingRules(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, rootACLToken, testServerCleanupFn := TestACLServer(t, nil)
+	defer testServerCleanupFn()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	// Create some binding rules directly in state.
+	bindingRules := []*structs.ACLBindingRule{mock.ACLBindingRule(), mock.ACLBindingRule()}
+	require.NoError(t, testServer.fsm.State().UpsertACLBindingRules(structs.MsgTypeTestSetup, 10, bindingRules, true))
+
+	// Try listing binding rules without a token.
+	listReq := &structs.ACLBindingRulesListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: DefaultRegion,
+		},
+	}
+	var listResp structs.ACLBindingRulesListResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLListBindingRulesRPCMethod, listReq, &listResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// List binding rules using the management token.
+	listReq.AuthToken = rootACLToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLListBindingRulesRPCMethod, listReq, &listResp)
+	require.NoError(t, err)
+	require.Len(t, listResp.ACLBindingRules, 2)
+	require.Equal(t, uint64(10), listResp.Index)
+
+	// Read a single binding rule.
+	getReq := &structs.ACLBindingRuleRequest{
+		ACLBindingRuleID: bindingRules[0].ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    DefaultRegion,
+			AuthToken: rootACLToken.SecretID,
+		},
+	}
+	var getResp structs.ACLBindingRuleResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLGetBindingRuleRPCMethod, getReq, &getResp)
+	require.NoError(t, err)
+	require.Equal(t, bindingRules[0].Hash, getResp.ACLBindingRule.Hash)
+
+	// Read both binding rules using the batch endpoint used by replication.
+	batchReq := &structs.ACLBindingRulesRequest{
+		ACLBindingRuleIDs: []string{bindingRules[0].ID, bindingRules[1].ID},
+		QueryOptions: structs.QueryOptions{
+			Region:    DefaultRegion,
+			AuthToken: rootACLToken.SecretID,
+		},
+	}
+	var batchResp structs.ACLBindingRulesResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLGetBindingRulesRPCMethod, batchReq, &batchResp)
+	require.NoError(t, err)
+	require.Len(t, batchResp.ACLBindingRules, 2)
+}
+
+func TestACL_Login(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, _, testServerCleanupFn := TestACLServer(t, nil)
+	defer testServerCleanupFn()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	// Start an OIDC provider and create an auth method which uses it.
+	oidcProvider := oidc.NewTestProvider(t)
+
+	authMethod := mock.ACLAuthMethod()
+	authMethod.Config = &structs.ACLAuthMethodConfig{
+		OIDCDiscoveryURL:  oidcProvider.URL(),
+		OIDCClientID:      "nomad",
+		ClaimMappings:     map[string]string{"team": "team"},
+		ListClaimMappings: map[string]string{"groups": "groups"},
+	}
+	authMethod.SetHash()
+	require.NoError(t, testServer.fsm.State().UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod}))
+
+	// Create a policy and a binding rule which grants it to members of the
+	// engineering group.
+	policy := mock.ACLPolicy()
+	policy.Name = "engineering-web"
+	require.NoError(t, testServer.fsm.State().UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLPolicy{policy}))
+
+	bindingRule := &structs.ACLBindingRule{
+		ID:         uuid.Generate(),
+		AuthMethod: authMethod.Name,
+		Selector:   "engineering in list.groups",
+		BindType:   structs.ACLBindingRuleBindTypePolicy,
+		BindName:   "engineering-${value.team}",
+	}
+	require.NoError(t, testServer.fsm.State().UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 30, []*structs.ACLBindingRule{bindingRule}, false))
+
+	// Try logging in with an invalid login token.
+	loginReq := &structs.ACLLoginRequest{
+		AuthMethodName: authMethod.Name,
+		LoginToken:     "not-a-token",
+		WriteRequest: structs.WriteRequest{
+			Region: DefaultRegion,
+		},
+	}
+	var loginResp structs.ACLLoginResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLLoginRPCMethod, loginReq, &loginResp)
+	require.ErrorContains(t, err, "failed to verify login token")
+
+	// Try logging in using an auth method which does not exist.
+	loginReq.AuthMethodName = "missing"
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLLoginRPCMethod, loginReq, &loginResp)
+	require.ErrorContains(t, err, "not found")
+
+	// Login with a token whose claims do not match any binding rule.
+	loginReq.AuthMethodName = authMethod.Name
+	loginReq.LoginToken = oidcProvider.SignIDToken(t, map[string]interface{}{
+		"aud":    "nomad",
+		"team":   "web",
+		"groups": []string{"sales"},
+	})
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLLoginRPCMethod, loginReq, &loginResp)
+	require.ErrorContains(t, err, "no role or policy bindings matched")
+
+	// Login with a token whose claims match the binding rule.
+	loginReq.LoginToken = oidcProvider.SignIDToken(t, map[string]interface{}{
+		"aud":    "nomad",
+		"team":   "web",
+		"groups": []string{"engineering"},
+	})
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLLoginRPCMethod, loginReq, &loginResp)
+	require.NoError(t, err)
+	require.NotNil(t, loginResp.ACLToken)
+	require.Equal(t, structs.ACLClientToken, loginResp.ACLToken.Type)
+	require.Equal(t, []string{"engineering-web"}, loginResp.ACLToken.Policies)
+	require.Equal(t, "OIDC-"+authMethod.Name, loginResp.ACLToken.Name)
+	require.False(t, loginResp.ACLToken.Global)
+	require.True(t, loginResp.ACLToken.HasExpirationTime())
+	require.Equal(t, loginResp.ACLToken.CreateTime.Add(authMethod.MaxTokenTTL), *loginResp.ACLToken.ExpirationTime)
+
+	// The generated token should be usable.
+	aclObj, err := testServer.ResolveToken(loginResp.ACLToken.SecretID)
+	require.NoError(t, err)
+	require.NotNil(t, aclObj)
+	require.False(t, aclObj.IsManagement())
+}
+
+func TestACL_Login_JWT(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, _, testServerCleanupFn := TestACLServer(t, nil)
+	defer testServerCleanupFn()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	// Use the key set of an OIDC test provider to sign and validate JWTs,
+	// without performing any discovery.
+	oidcProvider := oidc.NewTestProvider(t)
+
+	authMethod := mock.ACLAuthMethod()
+	authMethod.Type = structs.ACLAuthMethodTypeJWT
+	authMethod.Config = &structs.ACLAuthMethodConfig{
+		JWKSURL:        oidcProvider.URL() + "/keys",
+		BoundIssuer:    oidcProvider.URL(),
+		BoundAudiences: []string{"nomad"},
+		ClaimMappings:  map[string]string{"project": "project"},
+	}
+	authMethod.SetHash()
+	require.NoError(t, testServer.fsm.State().UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod}))
+
+	policy := mock.ACLPolicy()
+	policy.Name = "deploy-web"
+	require.NoError(t, testServer.fsm.State().UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLPolicy{policy}))
+
+	bindingRule := &structs.ACLBindingRule{
+		ID:         uuid.Generate(),
+		AuthMethod: authMethod.Name,
+		Selector:   "value.project==web",
+		BindType:   structs.ACLBindingRuleBindTypePolicy,
+		BindName:   "deploy-${value.project}",
+	}
+	require.NoError(t, testServer.fsm.State().UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 30, []*structs.ACLBindingRule{bindingRule}, false))
+
+	// Try logging in with a JWT which has an incorrect audience.
+	loginReq := &structs.ACLLoginRequest{
+		AuthMethodName: authMethod.Name,
+		LoginToken: oidcProvider.SignIDToken(t, map[string]interface{}{
+			"aud":     "not-nomad",
+			"project": "web",
+		}),
+		WriteRequest: structs.WriteRequest{
+			Region: DefaultRegion,
+		},
+	}
+	var loginResp structs.ACLLoginResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLLoginRPCMethod, loginReq, &loginResp)
+	require.ErrorContains(t, err, "failed to verify login token")
+
+	// Login with a JWT whose claims match the binding rule.
+	loginReq.LoginToken = oidcProvider.SignIDToken(t, map[string]interface{}{
+		"aud":     "nomad",
+		"project": "web",
+	})
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLLoginRPCMethod, loginReq, &loginResp)
+	require.NoError(t, err)
+	require.NotNil(t, loginResp.ACLToken)
+	require.Equal(t, structs.ACLClientToken, loginResp.ACLToken.Type)
+	require.Equal(t, []string{"deploy-web"}, loginResp.ACLToken.Policies)
+	require.Equal(t, "JWT-"+authMethod.Name, loginResp.ACLToken.Name)
+	require.True(t, loginResp.ACLToken.HasExpirationTime())
+}
@@ -2,6 +2,7 @@ package nomad
 
 import (
 	"testing"
+	"time"
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/shoenig/test/must"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/state"
@@ -95,6 +97,35 @@ func TestResolveACLToken(t *testing.T) {
 	}
 }
 
+func TestResolveACLToken_Expired(t *testing.T) {
+	ci.Parallel(t)
+
+	state := state.TestStateStore(t)
+	cache, err := lru.New2Q(16)
+	must.NoError(t, err)
+
+	// Create an expired and an unexpired token.
+	expiredToken := mock.ACLToken()
+	expiredToken.ExpirationTime = pointer.Of(time.Now().UTC().Add(-1 * time.Hour))
+	unexpiredToken := mock.ACLToken()
+	unexpiredToken.ExpirationTime = pointer.Of(time.Now().UTC().Add(time.Hour))
+	err = state.UpsertACLTokens(structs.MsgTypeTestSetup, 110, []*structs.ACLToken{expiredToken, unexpiredToken})
+	must.NoError(t, err)
+
+	snap, err := state.Snapshot()
+	must.NoError(t, err)
+
+	// Attempt resolution of the expired token. Should fail.
+	aclObj, err := resolveTokenFromSnapshotCache(snap, cache, expiredToken.SecretID)
+	must.EqError(t, err, structs.ErrTokenExpired.Error())
+	must.Nil(t, aclObj)
+
+	// Attempt resolution of the unexpired token. Should succeed.
+	aclObj, err = resolveTokenFromSnapshotCache(snap, cache, unexpiredToken.SecretID)
+	must.NoError(t, err)
+	must.NotNil(t, aclObj)
+}
+
 func TestResolveACLToken_LeaderToken(t *testing.T) {
 	ci.Parallel(t)
 	assert := assert.New(t)
@@ -193,6 +193,14 @@ type Config struct {
 	// one-time tokens.
 	OneTimeTokenGCInterval time.Duration
 
+	// ACLTokenExpirationGCInterval is how often we dispatch a job to GC
+	// expired ACL tokens.
+	ACLTokenExpirationGCInterval time.Duration
+
+	// ACLTokenExpirationGCThreshold controls how "old" an expired ACL token
+	// must be to be collected by GC.
+	ACLTokenExpirationGCThreshold time.Duration
+
 	// RootKeyGCInterval is how often we dispatch a job to GC
 	// encryption key metadata
 	RootKeyGCInterval time.Duration
@@ -201,9 +209,27 @@ type Config struct {
 	// to be eligible for GC.
 	RootKeyGCThreshold time.Duration
 
-	// RootKeyRotationThreshold is how "old" an active key can be
-	// before it's rotated
-	RootKeyRotationThreshold time.Duration
+	// RootKeyRotationInterval is how "old" an active key can be before the
+	// leader rotates it. A zero value disables automatic rotation.
+	RootKeyRotationInterval time.Duration
+
+	// RootKeyRekeyOnRotation controls whether variables encrypted with the
+	// previous root key are re-encrypted with the new key after an automatic
+	// rotation.
+	RootKeyRekeyOnRotation bool
+
+	// OIDCIssuer is the issuer of workload identities, which must be the
+	// URL at which the OIDC discovery document is served to third parties.
+	// If unset, the OIDC discovery document is not served.
+	OIDCIssuer string
+
+	// OIDCAudience is the audience (aud claim) of workload identities, which
+	// third parties verifying them must expect.
+	OIDCAudience []string
+
+	// KeyringKEK configures the key encryption key provider used to wrap
+	// root keys before they are written to the on-disk keystore.
+	KeyringKEK *config.KEKConfig
 
 	// SecureVariablesRekeyInterval is how often we dispatch a job to
 	// rekey any variables associated with a key in the Rekeying state
@@ -294,6 +320,14 @@ type Config struct {
 	// ACLEnabled controls if ACL enforcement and management is enabled.
 	ACLEnabled bool
 
+	// ACLTokenMinExpirationTTL is used to enforce the lowest acceptable value
+	// for ACL token expiration.
+	ACLTokenMinExpirationTTL time.Duration
+
+	// ACLTokenMaxExpirationTTL is used to enforce the highest acceptable value
+	// for ACL token expiration.
+	ACLTokenMaxExpirationTTL time.Duration
+
 	// ReplicationBackoff is how much we backoff when replication errors.
 	// This is a tunable knob for testing primarily.
 	ReplicationBackoff time.Duration
@@ -439,9 +473,15 @@ func DefaultConfig() *Config {
 		CSIVolumeClaimGCInterval:         5 * time.Minute,
 		CSIVolumeClaimGCThreshold:        5 * time.Minute,
 		OneTimeTokenGCInterval:           10 * time.Minute,
+		ACLTokenExpirationGCInterval:     5 * time.Minute,
+		ACLTokenExpirationGCThreshold:    1 * time.Hour,
+		ACLTokenMinExpirationTTL:         1 * time.Minute,
+		ACLTokenMaxExpirationTTL:         24 * time.Hour,
 		RootKeyGCInterval:                10 * time.Minute,
 		RootKeyGCThreshold:               1 * time.Hour,
-		RootKeyRotationThreshold:         720 * time.Hour, // 30 days
+		RootKeyRotationInterval:          720 * time.Hour, // 30 days
+		RootKeyRekeyOnRotation:           true,
+		OIDCAudience:                     []string{structs.DefaultWorkloadIdentityAudience},
 		SecureVariablesRekeyInterval:     10 * time.Minute,
 		EvalNackTimeout:                  60 * time.Second,
 		EvalDeliveryLimit:                3,
@@ -2,7 +2,6 @@ package nomad
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"math"
 	"strings"
@@ -11,6 +10,7 @@ import (
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
 	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -18,6 +18,10 @@ import (
 	"golang.org/x/time/rate"
 )
 
+// secureVariablesRekeyBatchSize is the number of secure variable versions
+// re-encrypted by each rekey RPC.
+const secureVariablesRekeyBatchSize = 100
+
 // CoreScheduler is a special "scheduler" that is registered
 // as "_core". It is used to run various administrative work
 // across the cluster.
@@ -55,8 +59,12 @@ func (c *CoreScheduler) Process(eval *structs.Evaluation) error {
 		return c.csiPluginGC(eval)
 	case structs.CoreJobOneTimeTokenGC:
 		return c.expiredOneTimeTokenGC(eval)
-	case structs.CoreJobRootKeyRotateOrGC:
-		return c.rootKeyRotateOrGC(eval)
+	case structs.CoreJobLocalTokenExpiredGC:
+		return c.expiredACLTokenGC(eval, false)
+	case structs.CoreJobGlobalTokenExpiredGC:
+		return c.expiredACLTokenGC(eval, true)
+	case structs.CoreJobRootKeyGC:
+		return c.rootKeyGC(eval)
 	case structs.CoreJobSecureVariablesRekey:
 		return c.secureVariablesRekey(eval)
 	case structs.CoreJobForceGC:
@@ -86,7 +94,13 @@ func (c *CoreScheduler) forceGC(eval *structs.Evaluation) error {
 	if err := c.expiredOneTimeTokenGC(eval); err != nil {
 		return err
 	}
-	if err := c.rootKeyRotateOrGC(eval); err != nil {
+	if err := c.expiredACLTokenGC(eval, false); err != nil {
+		return err
+	}
+	if err := c.expiredACLTokenGC(eval, true); err != nil {
+		return err
+	}
+	if err := c.rootKeyGC(eval); err != nil {
 		return err
 	}
 	// Node GC must occur after the others to ensure the allocations are
@@ -784,25 +798,110 @@ func (c *CoreScheduler) expiredOneTimeTokenGC(eval *structs.Evaluation) error {
 	return c.srv.RPC("ACL.ExpireOneTimeTokens", req, &structs.GenericResponse{})
 }
 
-// rootKeyRotateOrGC is used to rotate or garbage collect root keys
-func (c *CoreScheduler) rootKeyRotateOrGC(eval *structs.Evaluation) error {
+// expiredACLTokenGC handles running the garbage collector for expired ACL
+// tokens. It can be used for both local and global tokens and includes
+// behaviour to account for periodic and user actioned garbage collection
+// invocations.
+func (c *CoreScheduler) expiredACLTokenGC(eval *structs.Evaluation, global bool) error {
+
+	// If ACLs are not enabled, we do not need to continue and should exit
+	// early. This is not an error condition as callers can blindly call this
+	// function without checking the configuration. If the caller wants this
+	// to be an error, they should check this config value themselves.
+	if !c.srv.config.ACLEnabled {
+		return nil
+	}
+
+	// If the function has been triggered for global tokens, but we are not the
+	// authoritative region, we should exit. This is not an error condition as
+	// callers can blindly call this function without checking the
+	// configuration. If the caller wants this to be an error, they should
+	// check this config value themselves.
+	if global && c.srv.config.AuthoritativeRegion != c.srv.Region() {
+		return nil
+	}
+
+	// The object name is logged within the getThreshold function, therefore
+	// we want to be clear what token type this trigger is for.
+	tokenScope := "local"
+	if global {
+		tokenScope = "global"
+	}
+
+	expiryThresholdIdx := c.getThreshold(eval, tokenScope+" expired ACL tokens",
+		"acl_token_expiration_gc_threshold", c.srv.config.ACLTokenExpirationGCThreshold)
 
-	// a rotation will be sent to the leader so our view of state
-	// is no longer valid. we ack this core job and will pick up
-	// the GC work on the next interval
-	wasRotated, err := c.rootKeyRotation(eval)
+	expiredIter, err := c.snap.ACLTokensByExpired(global)
 	if err != nil {
 		return err
 	}
-	if wasRotated {
+
+	var (
+		expiredAccessorIDs []string
+		num                int
+	)
+
+	// The memdb iterator contains all tokens which include an expiration time,
+	// however, as the caller, we do not know at which point in the array the
+	// tokens are no longer expired. This time therefore forms the basis at
+	// which we draw the line in the iteration loop and find the final expired
+	// token that is eligible for deletion.
+	now := time.Now().UTC()
+
+	for raw := expiredIter.Next(); raw != nil; raw = expiredIter.Next() {
+		token := raw.(*structs.ACLToken)
+
+		// The iteration order of the indexes mean if we come across an
+		// unexpired token, we can exit as we have found all currently expired
+		// tokens.
+		if !token.IsExpired(now) {
+			break
+		}
+
+		// Check if the token is recent enough to skip, otherwise we'll delete
+		// it.
+		if token.CreateIndex > expiryThresholdIdx {
+			continue
+		}
+
+		expiredAccessorIDs = append(expiredAccessorIDs, token.AccessorID)
+
+		num++
+		if num >= structs.ACLMaxExpiredBatchSize {
+			break
+		}
+	}
+
+	// There is no need to call the RPC endpoint if we do not have any tokens
+	// to delete.
+	if len(expiredAccessorIDs) < 1 {
 		return nil
 	}
 
-	// we can't GC any key older than the oldest live allocation
-	// because it might have signed that allocation's workload
-	// identity; this is conservative so that we don't have to iterate
-	// over all the allocations and find out which keys signed their
-	// identity, which will be expensive on large clusters
+	c.logger.Debug("expired ACL token GC found eligible tokens",
+		"num", len(expiredAccessorIDs), "global", global)
+
+	// Set up and make the RPC request which will return any error performing
+	// the deletion.
+	req := structs.ACLTokenDeleteRequest{
+		AccessorIDs: expiredAccessorIDs,
+		WriteRequest: structs.WriteRequest{
+			Region:    c.srv.Region(),
+			AuthToken: eval.LeaderACL,
+		},
+	}
+	return c.srv.RPC("ACL.DeleteTokens", &req, &structs.GenericResponse{})
+}
+
+// rootKeyGC is used to garbage collect root keys which are no longer in
+// use. Root keys are rotated by the leader; see Server.rotateRootKeys.
+func (c *CoreScheduler) rootKeyGC(eval *structs.Evaluation) error {
+
+	// we can't GC any key that was active while the oldest live allocation
+	// was created, because it might have signed that allocation's workload
+	// identity; this is conservative so that we don't have to decode the
+	// identity of every allocation and find out which keys signed them,
+	// which will be expensive on large clusters
 	allocOldThreshold, err := c.getOldestAllocationIndex()
 	if err != nil {
 		return err
@@ -829,7 +928,10 @@ func (c *CoreScheduler) rootKeyRotateOrGC(eval *structs.Evaluation) error {
 		if keyMeta.CreateIndex > oldThreshold {
 			continue // don't GC recent keys
 		}
-		if keyMeta.CreateIndex > allocOldThreshold {
+		if keyMeta.ModifyIndex >= allocOldThreshold {
+			// the key was last modified when it was deactivated or
+			// deprecated, so it can't have signed the identity of an
+			// allocation created after that
 			continue // don't GC keys possibly used to sign live allocations
 		}
 		varIter, err := c.snap.GetSecureVariablesByKeyID(ws, keyMeta.KeyID)
@@ -839,6 +941,13 @@ func (c *CoreScheduler) rootKeyRotateOrGC(eval *structs.Evaluation) error {
 		if varIter.Next() != nil {
 			continue // key is still in use
 		}
+		versionIter, err := c.snap.GetSecureVariableVersionsByKeyID(ws, keyMeta.KeyID)
+		if err != nil {
+			return err
+		}
+		if versionIter.Next() != nil {
+			continue // key is still in use by a prior version
+		}
 
 		req := &structs.KeyringDeleteRootKeyRequest{
 			KeyID: keyMeta.KeyID,
@@ -857,40 +966,6 @@ func (c *CoreScheduler) rootKeyRotateOrGC(eval *structs.Evaluation) error {
 	return nil
 }
 
-// rootKeyRotation checks if the active key is old enough that we need
-// to kick off a rotation. Returns true if the key was rotated.
-func (c *CoreScheduler) rootKeyRotation(eval *structs.Evaluation) (bool, error) {
-
-	rotationThreshold := c.getThreshold(eval, "root key",
-		"root_key_rotation_threshold", c.srv.config.RootKeyRotationThreshold)
-
-	ws := memdb.NewWatchSet()
-	activeKey, err := c.snap.GetActiveRootKeyMeta(ws)
-	if err != nil {
-		return false, err
-	}
-	if activeKey == nil {
-		return false, nil // no active key
-	}
-	if activeKey.CreateIndex >= rotationThreshold {
-		return false, nil // key is too new
-	}
-
-	req := &structs.KeyringRotateRootKeyRequest{
-		WriteRequest: structs.WriteRequest{
-			Region:    c.srv.config.Region,
-			AuthToken: eval.LeaderACL,
-		},
-	}
-	if err := c.srv.RPC("Keyring.Rotate",
-		req, &structs.KeyringRotateRootKeyResponse{}); err != nil {
-		c.logger.Error("root key rotation failed", "error", err)
-		return false, err
-	}
-
-	return true, nil
-}
-
 // secureVariablesReKey is optionally run after rotating the active
 // root key. It iterates over all the variables for the keys in the
 // re-keying state, decrypts them, and re-encrypts them in batches
@@ -913,13 +988,13 @@ func (c *CoreScheduler) secureVariablesRekey(eval *structs.Evaluation) error {
 		if !keyMeta.Rekeying() {
 			continue
 		}
-		varIter, err := c.snap.GetSecureVariablesByKeyID(ws, keyMeta.KeyID)
+		done, err := c.rekeyVariables(keyMeta.KeyID, eval)
 		if err != nil {
 			return err
 		}
-		err = c.rotateVariables(varIter, eval)
-		if err != nil {
-			return err
+		if !done {
+			// the new eval will finish rekeying this key's variables
+			return nil
 		}
 
 		// we've now rotated all this key's variables, so set its state
@@ -950,39 +1025,55 @@ func (c *CoreScheduler) secureVariablesRekey(eval *structs.Evaluation) error {
 	return nil
 }
 
-// rotateVariables runs over an iterator of secure variables and decrypts them,
-// and then sends them back to be re-encrypted with the currently active key,
-// checking for conflicts
-func (c *CoreScheduler) rotateVariables(iter memdb.ResultIterator, eval *structs.Evaluation) error {
+// rekeyVariables re-encrypts all the tracked versions of secure variables
+// which were encrypted with the key, with the currently active key. It
+// returns false if it ran out of time before completing, in which case it
+// has emitted a new eval to continue rekeying.
+func (c *CoreScheduler) rekeyVariables(keyID string, eval *structs.Evaluation) (bool, error) {
 
-	args := &structs.SecureVariablesApplyRequest{
-		Op: structs.SVOpCAS,
-		WriteRequest: structs.WriteRequest{
-			Region:    c.srv.config.Region,
-			AuthToken: eval.LeaderACL,
-		},
+	ws := memdb.NewWatchSet()
+	varIter, err := c.snap.GetSecureVariablesByKeyID(ws, keyID)
+	if err != nil {
+		return false, err
+	}
+	versionIter, err := c.snap.GetSecureVariableVersionsByKeyID(ws, keyID)
+	if err != nil {
+		return false, err
 	}
 
-	// We may have to work on a very large number of variables. There's no
-	// BatchApply RPC because it makes for an awkward API around conflict
-	// detection, and even if we did, we'd be blocking this scheduler goroutine
-	// for a very long time using the same snapshot. This would increase the
-	// risk that any given batch hits a conflict because of a concurrent change
-	// and make it more likely that we fail the eval. For large sets, this would
-	// likely mean the eval would run out of retries.
-	//
-	// Instead, we'll rate limit RPC requests and have a timeout. If we still
+	// The current version of a variable is usually also a tracked version,
+	// so only rekey it once.
+	type versionID struct {
+		namespace, path string
+		version         uint64
+	}
+	seen := make(map[versionID]struct{})
+	var versions []*structs.SecureVariableMetadata
+	for _, iter := range []memdb.ResultIterator{varIter, versionIter} {
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			ev := raw.(*structs.SecureVariableEncrypted)
+			id := versionID{ev.Namespace, ev.Path, ev.Version}
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			versions = append(versions, &structs.SecureVariableMetadata{
+				Namespace: ev.Namespace,
+				Path:      ev.Path,
+				Version:   ev.Version,
+			})
+		}
+	}
+
+	// We may have to work on a very large number of variables, so we rekey
+	// them in batches and rate limit RPC requests, so as not to block this
+	// scheduler goroutine for a very long time or flood raft. If we still
 	// haven't finished the set by the timeout, emit a new eval.
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
 	defer cancel()
-	limiter := rate.NewLimiter(rate.Limit(100), 100)
-
-	for {
-		raw := iter.Next()
-		if raw == nil {
-			break
-		}
+	limiter := rate.NewLimiter(rate.Limit(10), 10)
 
+	for len(versions) > 0 {
 		select {
 		case <-ctx.Done():
 			newEval := &structs.Evaluation{
@@ -995,7 +1086,7 @@ func (c *CoreScheduler) rotateVariables(iter memdb.ResultIterator, eval *structs
 				Status:      structs.EvalStatusPending,
 				LeaderACL:   eval.LeaderACL,
 			}
-			return c.srv.RPC("Eval.Create", &structs.EvalUpdateRequest{
+			return false, c.srv.RPC("Eval.Create", &structs.EvalUpdateRequest{
 				Evals:     []*structs.Evaluation{newEval},
 				EvalToken: uuid.Generate(),
 				WriteRequest: structs.WriteRequest{
@@ -1007,39 +1098,30 @@ func (c *CoreScheduler) rotateVariables(iter memdb.ResultIterator, eval *structs
 		default:
 		}
 
-		ev := raw.(*structs.SecureVariableEncrypted)
-		cleartext, err := c.srv.encrypter.Decrypt(ev.Data, ev.KeyID)
-		if err != nil {
-			return err
-		}
-		dv := &structs.SecureVariableDecrypted{
-			SecureVariableMetadata: ev.SecureVariableMetadata,
-		}
-		dv.Items = make(map[string]string)
-		err = json.Unmarshal(cleartext, &dv.Items)
-		if err != nil {
-			return err
-		}
-		args.Var = dv
-		reply := &structs.SecureVariablesApplyResponse{}
+		batch := versions[:helper.Min(len(versions), secureVariablesRekeyBatchSize)]
+		versions = versions[len(batch):]
 
 		if err := limiter.Wait(ctx); err != nil {
-			return err
+			return false, err
 		}
 
-		err = c.srv.RPC("SecureVariables.Apply", args, reply)
-		if err != nil {
-			return err
+		// Versions written or deleted since we took this evaluation's
+		// snapshot are skipped, as new writes are made with the active key.
+		req := &structs.SecureVariablesRekeyRequest{
+			KeyID:    keyID,
+			Versions: batch,
+			WriteRequest: structs.WriteRequest{
+				Region:    c.srv.config.Region,
+				AuthToken: eval.LeaderACL,
+			},
 		}
-		if reply.IsConflict() {
-			// we've already rotated the key by the time we took this
-			// evaluation's snapshot, so any conflict is going to be on a write
-			// made with the new key, so there's nothing for us to do here
-			continue
+		if err := c.srv.RPC(structs.SecureVariablesRekeyRPCMethod,
+			req, &structs.SecureVariablesRekeyResponse{}); err != nil {
+			return false, err
 		}
 	}
 
-	return nil
+	return true, nil
 }
 
 // getThreshold returns the index threshold for determining whether an
@@ -1067,22 +1149,27 @@ func (c *CoreScheduler) getThreshold(eval *structs.Evaluation, objectName, confi
 }
 
 // getOldestAllocationIndex returns the CreateIndex of the oldest
-// non-terminal allocation in the state store
+// non-terminal allocation in the state store, or math.MaxUint64 if there
+// are no non-terminal allocations
 func (c *CoreScheduler) getOldestAllocationIndex() (uint64, error) {
 	ws := memdb.NewWatchSet()
 	allocs, err := c.snap.Allocs(ws, state.SortDefault)
 	if err != nil {
 		return 0, err
 	}
+
+	// allocations are sorted by ID, so we need to check all of them. If
+	// there are no live allocations, no key is in use to sign identities.
+	var oldest uint64 = math.MaxUint64
 	for {
 		raw := allocs.Next()
 		if raw == nil {
 			break
 		}
 		alloc := raw.(*structs.Allocation)
-		if !alloc.TerminalStatus() {
-			return alloc.CreateIndex, nil
+		if !alloc.TerminalStatus() && alloc.CreateIndex < oldest {
+			oldest = alloc.CreateIndex
 		}
 	}
-	return 0, nil
+	return oldest, nil
 }
@@ -8,6 +8,7 @@ import (
 	memdb "github.com/hashicorp/go-memdb"
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/state"
@@ -2489,6 +2490,30 @@ func TestCoreScheduler_RootKeyGC(t *testing.T) {
 	})
 	require.NoError(t, setResp.Error)
 
+	// insert an "old" and inactive key with a prior version of a variable
+	// that's using it
+	key5 := structs.NewRootKeyMeta()
+	key5.SetInactive()
+	require.NoError(t, store.UpsertRootKeyMeta(610, key5, false))
+
+	variable2 := mock.SecureVariableEncrypted()
+	variable2.KeyID = key5.KeyID
+	setResp = store.SVESet(611, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpSet,
+		Var: variable2,
+	})
+	require.NoError(t, setResp.Error)
+
+	rekeyed := variable2.Copy()
+	variable2 = &rekeyed
+	variable2.KeyID = key0.KeyID
+	variable2.Data = []byte("rekeyed")
+	setResp = store.SVESet(612, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpSet,
+		Var: variable2,
+	})
+	require.NoError(t, setResp.Error)
+
 	// insert an allocation
 	alloc := mock.Alloc()
 	alloc.ClientStatus = structs.AllocClientStatusRunning
@@ -2513,9 +2538,9 @@ func TestCoreScheduler_RootKeyGC(t *testing.T) {
 	snap, err := store.Snapshot()
 	require.NoError(t, err)
 	core := NewCoreScheduler(srv, snap)
-	eval := srv.coreJobEval(structs.CoreJobRootKeyRotateOrGC, 2000)
+	eval := srv.coreJobEval(structs.CoreJobRootKeyGC, 2000)
 	c := core.(*CoreScheduler)
-	require.NoError(t, c.rootKeyRotateOrGC(eval))
+	require.NoError(t, c.rootKeyGC(eval))
 
 	ws := memdb.NewWatchSet()
 	key, err := store.RootKeyMetaByID(ws, key0.KeyID)
@@ -2537,6 +2562,26 @@ func TestCoreScheduler_RootKeyGC(t *testing.T) {
 	key, err = store.RootKeyMetaByID(ws, key4.KeyID)
 	require.NoError(t, err)
 	require.NotNil(t, key, "new key should not have been GCd")
+
+	key, err = store.RootKeyMetaByID(ws, key5.KeyID)
+	require.NoError(t, err)
+	require.NotNil(t, key, "old key should not have been GCd if still in use by a prior version")
+
+	// once the allocation is terminal, keys newer than it can be GCd
+	alloc = alloc.Copy()
+	alloc.DesiredStatus = structs.AllocDesiredStatusStop
+	require.NoError(t, store.UpsertAllocs(
+		structs.MsgTypeTestSetup, 2100, []*structs.Allocation{alloc}))
+
+	snap, err = store.Snapshot()
+	require.NoError(t, err)
+	core = NewCoreScheduler(srv, snap)
+	c = core.(*CoreScheduler)
+	require.NoError(t, c.rootKeyGC(eval))
+
+	key, err = store.RootKeyMetaByID(ws, key3.KeyID)
+	require.NoError(t, err)
+	require.Nil(t, key, "old key should have been GCd after alloc is terminal")
 }
 
 // TestCoreScheduler_SecureVariablesRekey exercises secure variables rekeying
@@ -2614,6 +2659,35 @@ func TestCoreScheduler_SecureVariablesRekey(t *testing.T) {
 			require.True(t, keyMeta.Deprecated())
 		}
 	}
+
+	// rekeying re-encrypts the tracked versions of each variable without
+	// creating new versions
+	iter, err = store.SecureVariables(memdb.NewWatchSet())
+	require.NoError(t, err)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		variable := raw.(*structs.SecureVariableEncrypted)
+		require.Equal(t, uint64(0), variable.Version)
+
+		versions, err := store.GetSecureVariableVersions(nil, variable.Namespace, variable.Path)
+		require.NoError(t, err)
+		require.Len(t, versions, 1)
+		require.Equal(t, newKeyID, versions[0].KeyID)
+	}
+
+	// the deprecated keys are no longer in use, so they can be GC'd
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+	core := NewCoreScheduler(srv, snap)
+	eval := srv.coreJobEval(structs.CoreJobForceGC, 2000)
+	require.NoError(t, core.(*CoreScheduler).rootKeyGC(eval))
+
+	iter, err = store.RootKeyMetas(memdb.NewWatchSet())
+	require.NoError(t, err)
+	var keyIDs []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		keyIDs = append(keyIDs, raw.(*structs.RootKeyMeta).KeyID)
+	}
+	require.Equal(t, []string{newKeyID}, keyIDs, "deprecated keys should have been GCd")
 }
 
 func TestCoreScheduler_FailLoop(t *testing.T) {
@@ -2675,3 +2749,66 @@ func TestCoreScheduler_FailLoop(t *testing.T) {
 			out.TriggeredBy)
 	}
 }
+
+func TestCoreScheduler_ExpiredACLTokenGC(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, rootACLToken, testServerShutdown := TestACLServer(t, nil)
+	defer testServerShutdown()
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	now := time.Now().UTC()
+
+	// Craft some specific local and global tokens. For each type, one is
+	// expired, one is not.
+	expiredGlobal := mock.ACLToken()
+	expiredGlobal.Global = true
+	expiredGlobal.ExpirationTime = pointer.Of(now.Add(-2 * time.Hour))
+
+	unexpiredGlobal := mock.ACLToken()
+	unexpiredGlobal.Global = true
+	unexpiredGlobal.ExpirationTime = pointer.Of(now.Add(2 * time.Hour))
+
+	expiredLocal := mock.ACLToken()
+	expiredLocal.ExpirationTime = pointer.Of(now.Add(-2 * time.Hour))
+
+	unexpiredLocal := mock.ACLToken()
+	unexpiredLocal.ExpirationTime = pointer.Of(now.Add(2 * time.Hour))
+
+	// Upsert these into state.
+	err := testServer.State().UpsertACLTokens(structs.MsgTypeTestSetup, 10, []*structs.ACLToken{
+		expiredGlobal, unexpiredGlobal, expiredLocal, unexpiredLocal,
+	})
+	require.NoError(t, err)
+
+	// Reset the timetable, so we can witness an index at a timestamp in the
+	// past which marks the tokens as old enough to be collected.
+	testServer.fsm.timetable.table = make([]TimeTableEntry, 1, 10)
+	testServer.fsm.TimeTable().Witness(20, now.Add(-1*testServer.config.ACLTokenExpirationGCThreshold))
+
+	// Generate the core scheduler.
+	snap, err := testServer.State().Snapshot()
+	require.NoError(t, err)
+	coreScheduler := NewCoreScheduler(testServer, snap)
+
+	// Trigger global and local periodic garbage collection runs.
+	index, err := testServer.State().LatestIndex()
+	require.NoError(t, err)
+	index++
+
+	globalGCEval := testServer.coreJobEval(structs.CoreJobGlobalTokenExpiredGC, index)
+	require.NoError(t, coreScheduler.Process(globalGCEval))
+
+	localGCEval := testServer.coreJobEval(structs.CoreJobLocalTokenExpiredGC, index)
+	require.NoError(t, coreScheduler.Process(localGCEval))
+
+	// Ensure the ACL tokens stored within state are as expected.
+	iter, err := testServer.State().ACLTokens(nil, state.SortDefault)
+	require.NoError(t, err)
+
+	var tokens []*structs.ACLToken
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		tokens = append(tokens, raw.(*structs.ACLToken))
+	}
+	require.ElementsMatch(t, []*structs.ACLToken{rootACLToken, unexpiredGlobal, unexpiredLocal}, tokens)
+}
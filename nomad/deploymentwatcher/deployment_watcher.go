@@ -439,6 +439,16 @@ FAIL:
 			// This is the successful case, and we stop the loop
 			return
 		case <-deadlineTimer.C:
+			// The progress deadline doesn't apply while task groups wait for
+			// their scheduling window, since the scheduler defers their
+			// placements until it opens. Push the deadline out instead.
+			if next := w.getWindowProgressCutoff(w.getDeployment()); !next.IsZero() {
+				w.logger.Debug("deferring deadline until scheduling window opens", "deadline", next)
+				currentDeadline = next
+				deadlineTimer.Reset(time.Until(next))
+				continue
+			}
+
 			// We have hit the progress deadline, so fail the deployment
 			// unless we're waiting for manual promotion. We need to determine
 			// whether we should roll back the job by inspecting which allocs
@@ -722,6 +732,36 @@ func (w *deploymentWatcher) getDeploymentProgressCutoff(d *structs.Deployment) t
 	return next
 }
 
+// getWindowProgressCutoff returns the progress cutoff for the given
+// deployment while any of its task groups that aren't done wait for their
+// scheduling window to open. The progress deadline of such a group starts
+// again once its window opens. A zero time is returned if no group is waiting.
+func (w *deploymentWatcher) getWindowProgressCutoff(d *structs.Deployment) time.Time {
+	if d == nil {
+		return time.Time{}
+	}
+
+	var next time.Time
+	now := time.Now()
+	doneTGs := w.doneGroups(d)
+	for name, dstate := range d.TaskGroups {
+		if doneTGs[name] || dstate.ProgressDeadline == 0 {
+			continue
+		}
+
+		window := w.j.LookupSchedulingWindow(name)
+		if window.Open(now) {
+			continue
+		}
+
+		cutoff := window.Next(now).Add(dstate.ProgressDeadline)
+		if next.IsZero() || cutoff.Before(next) {
+			next = cutoff
+		}
+	}
+	return next
+}
+
 // doneGroups returns a map of task group to whether the deployment appears to
 // be done for the group. A true value doesn't mean no more action will be taken
 // in the life time of the deployment because there could always be node
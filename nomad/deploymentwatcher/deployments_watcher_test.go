@@ -1234,6 +1234,70 @@ func TestDeploymentWatcher_Watch_ProgressDeadline(t *testing.T) {
 	})
 }
 
+// Test that the progress deadline isn't hit while the job waits for its
+// scheduling window
+func TestDeploymentWatcher_Watch_ProgressDeadline_Window(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	w, m := testDeploymentWatcher(t, 1000.0, 1*time.Millisecond)
+
+	// Create a job with a window that opens in an hour, an alloc, and a
+	// deployment
+	opens := time.Now().UTC().Add(time.Hour).Truncate(time.Minute)
+	j := mock.Job()
+	j.Window = &structs.SchedulingWindow{
+		Start: opens.Format("15:04"),
+		End:   opens.Add(time.Hour).Format("15:04"),
+	}
+	j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	j.TaskGroups[0].Update.ProgressDeadline = 100 * time.Millisecond
+	j.Stable = true
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.StatusDescription = structs.DeploymentStatusDescriptionWaitingForWindow
+	d.TaskGroups["web"].ProgressDeadline = 100 * time.Millisecond
+	a := mock.Alloc()
+	now := time.Now()
+	a.CreateTime = now.UnixNano()
+	a.ModifyTime = now.UnixNano()
+	a.DeploymentID = d.ID
+	require.Nil(m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), j), "UpsertJob")
+	require.Nil(m.state.UpsertDeployment(m.nextIndex(), d), "UpsertDeployment")
+	require.Nil(m.state.UpsertAllocs(structs.MsgTypeTestSetup, m.nextIndex(), []*structs.Allocation{a}), "UpsertAllocs")
+
+	// The unhealthy alloc may be replaced
+	m.On("UpdateAllocDesiredTransition", mocker.MatchedBy(func(args *structs.AllocUpdateDesiredTransitionRequest) bool {
+		return true
+	})).Return(nil).Maybe()
+
+	w.SetEnabled(true, m.state)
+	testutil.WaitForResult(func() (bool, error) { return 1 == watchersCount(w), nil },
+		func(err error) { require.Equal(1, watchersCount(w), "Should have 1 deployment") })
+
+	// The deadline restarts once the window opens
+	watcher, err := w.getOrCreateWatcher(d.ID)
+	require.NoError(err)
+	d1, err := m.state.DeploymentByID(nil, d.ID)
+	require.NoError(err)
+	cutoff := watcher.getWindowProgressCutoff(d1)
+	require.True(opens.Add(100*time.Millisecond).Equal(cutoff), "expected %v, got %v", opens, cutoff)
+
+	// Update the alloc to be unhealthy and require that the deployment isn't
+	// failed once the deadline passes
+	a2 := a.Copy()
+	a2.DeploymentStatus = &structs.AllocDeploymentStatus{
+		Healthy:   pointer.Of(false),
+		Timestamp: now,
+	}
+	require.Nil(m.state.UpdateAllocsFromClient(structs.MsgTypeTestSetup, m.nextIndex(), []*structs.Allocation{a2}))
+
+	time.Sleep(500 * time.Millisecond)
+	d2, err := m.state.DeploymentByID(nil, d.ID)
+	require.NoError(err)
+	require.Equal(structs.DeploymentStatusRunning, d2.Status)
+	m.AssertNotCalled(t, "UpdateDeploymentStatus", mocker.Anything)
+}
+
 // Test that progress deadline handling works when there are multiple groups
 func TestDeploymentWatcher_ProgressCutoff(t *testing.T) {
 	ci.Parallel(t)
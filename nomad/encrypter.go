@@ -1,7 +1,6 @@
 package nomad
 
 import (
-	"bytes"
 	"context"
 	"crypto/aes"
 	"crypto/cipher"
@@ -22,7 +21,6 @@ import (
 
 	jwt "github.com/golang-jwt/jwt/v4"
 	log "github.com/hashicorp/go-hclog"
-	"github.com/hashicorp/go-msgpack/codec"
 	"golang.org/x/time/rate"
 
 	"github.com/hashicorp/nomad/helper"
@@ -36,6 +34,10 @@ type Encrypter struct {
 	srv          *Server
 	keystorePath string
 
+	// kek wraps the key material before it's written to the keystore. If
+	// nil, the key material is written in plaintext.
+	kek kekProvider
+
 	keyring map[string]*keyset
 	lock    sync.RWMutex
 }
@@ -47,13 +49,14 @@ type keyset struct {
 }
 
 // NewEncrypter loads or creates a new local keystore and returns an
-// encryption keyring with the keys it finds.
-func NewEncrypter(srv *Server, keystorePath string) (*Encrypter, error) {
+// encryption keyring with the keys it finds. If kek is non-nil, it's used
+// to wrap and unwrap the key material in the keystore.
+func NewEncrypter(srv *Server, keystorePath string, kek kekProvider) (*Encrypter, error) {
 	err := os.MkdirAll(keystorePath, 0700)
 	if err != nil {
 		return nil, err
 	}
-	encrypter, err := encrypterFromKeystore(keystorePath)
+	encrypter, err := encrypterFromKeystore(keystorePath, kek)
 	if err != nil {
 		return nil, err
 	}
@@ -61,11 +64,12 @@ func NewEncrypter(srv *Server, keystorePath string) (*Encrypter, error) {
 	return encrypter, nil
 }
 
-func encrypterFromKeystore(keystoreDirectory string) (*Encrypter, error) {
+func encrypterFromKeystore(keystoreDirectory string, kek kekProvider) (*Encrypter, error) {
 
 	encrypter := &Encrypter{
 		keyring:      make(map[string]*keyset),
 		keystorePath: keystoreDirectory,
+		kek:          kek,
 	}
 
 	err := filepath.Walk(keystoreDirectory, func(path string, info fs.FileInfo, err error) error {
@@ -87,7 +91,11 @@ func encrypterFromKeystore(keystoreDirectory string) (*Encrypter, error) {
 			return nil
 		}
 
-		key, err := encrypter.loadKeyFromStore(path)
+		storedKey, err := readKeyFromStore(path)
+		if err != nil {
+			return fmt.Errorf("could not load key file %s from keystore: %v", path, err)
+		}
+		key, err := encrypter.unwrapStoredKey(storedKey)
 		if err != nil {
 			return fmt.Errorf("could not load key file %s from keystore: %v", path, err)
 		}
@@ -95,10 +103,20 @@ func encrypterFromKeystore(keystoreDirectory string) (*Encrypter, error) {
 			return fmt.Errorf("root key ID %s must match key file %s", key.Meta.KeyID, path)
 		}
 
-		err = encrypter.AddKey(key)
+		err = encrypter.addCipher(key)
 		if err != nil {
 			return fmt.Errorf("could not add key file %s to keystore: %v", path, err)
 		}
+
+		// keys written before a KEK provider was configured are written back
+		// to the keystore so that they get wrapped. Keys already wrapped by
+		// the provider are left as is, to avoid a round-trip to the provider
+		// for each key on every startup.
+		if encrypter.kek != nil && storedKey.KEKProvider == "" {
+			if err := encrypter.saveKeyToStore(key); err != nil {
+				return fmt.Errorf("could not wrap key file %s: %v", path, err)
+			}
+		}
 		return nil
 	})
 	if err != nil {
@@ -280,6 +298,25 @@ func (e *Encrypter) GetKey(keyID string) ([]byte, error) {
 	return keyset.rootKey.Key, nil
 }
 
+// GetPublicKey returns the public key used to verify workload identities
+// signed by the key with the given ID
+func (e *Encrypter) GetPublicKey(keyID string) (*structs.KeyringPublicKey, error) {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	keyset, err := e.keysetByIDLocked(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return &structs.KeyringPublicKey{
+		KeyID:      keyID,
+		PublicKey:  keyset.privateKey.Public().(ed25519.PublicKey),
+		Algorithm:  structs.PubKeyAlgEdDSA,
+		Use:        structs.PubKeyUseSig,
+		CreateTime: keyset.rootKey.Meta.CreateTime,
+	}, nil
+}
+
 // activeKeySetLocked returns the keyset that belongs to the key marked as
 // active in the state store (so that it's consistent with raft). The
 // called must read-lock the keyring
@@ -314,16 +351,38 @@ func (e *Encrypter) RemoveKey(keyID string) error {
 	return nil
 }
 
+// storedRootKey is the on-disk representation of a root key. The key
+// material is stored in Key in plaintext, or in WrappedKey if it was wrapped
+// by a KEK provider.
+type storedRootKey struct {
+	Meta        *structs.RootKeyMetaStub
+	Key         string `json:",omitempty"`
+	WrappedKey  string `json:",omitempty"`
+	KEKProvider string `json:",omitempty"`
+}
+
 // saveKeyToStore serializes a root key to the on-disk keystore.
 func (e *Encrypter) saveKeyToStore(rootKey *structs.RootKey) error {
-	var buf bytes.Buffer
-	enc := codec.NewEncoder(&buf, structs.JsonHandleWithExtensions)
-	err := enc.Encode(rootKey)
+	storedKey := &storedRootKey{
+		Meta: rootKey.Meta.Stub(),
+	}
+	if e.kek != nil {
+		wrapped, err := e.kek.Wrap(rootKey.Meta.KeyID, rootKey.Key)
+		if err != nil {
+			return err
+		}
+		storedKey.WrappedKey = base64.StdEncoding.EncodeToString(wrapped)
+		storedKey.KEKProvider = e.kek.Name()
+	} else {
+		storedKey.Key = base64.StdEncoding.EncodeToString(rootKey.Key)
+	}
+
+	buf, err := json.Marshal(storedKey)
 	if err != nil {
 		return err
 	}
 	path := filepath.Join(e.keystorePath, rootKey.Meta.KeyID+nomadKeystoreExtension)
-	err = os.WriteFile(path, buf.Bytes(), 0600)
+	err = os.WriteFile(path, buf, 0600)
 	if err != nil {
 		return err
 	}
@@ -332,21 +391,34 @@ func (e *Encrypter) saveKeyToStore(rootKey *structs.RootKey) error {
 
 // loadKeyFromStore deserializes a root key from disk.
 func (e *Encrypter) loadKeyFromStore(path string) (*structs.RootKey, error) {
+	storedKey, err := readKeyFromStore(path)
+	if err != nil {
+		return nil, err
+	}
+	return e.unwrapStoredKey(storedKey)
+}
 
+// readKeyFromStore reads the on-disk representation of a root key.
+func readKeyFromStore(path string) (*storedRootKey, error) {
 	raw, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
-	storedKey := &struct {
-		Meta *structs.RootKeyMetaStub
-		Key  string
-	}{}
-
+	storedKey := &storedRootKey{}
 	if err := json.Unmarshal(raw, storedKey); err != nil {
 		return nil, err
 	}
+	if storedKey.Meta == nil {
+		return nil, fmt.Errorf("missing metadata")
+	}
+	return storedKey, nil
+}
 
+// unwrapStoredKey returns the root key from its on-disk representation,
+// unwrapping the key material with the KEK provider if it was wrapped.
+func (e *Encrypter) unwrapStoredKey(storedKey *storedRootKey) (*structs.RootKey, error) {
+	var err error
 	meta := &structs.RootKeyMeta{
 		State:      storedKey.Meta.State,
 		KeyID:      storedKey.Meta.KeyID,
@@ -357,9 +429,29 @@ func (e *Encrypter) loadKeyFromStore(path string) (*structs.RootKey, error) {
 		return nil, err
 	}
 
-	key, err := base64.StdEncoding.DecodeString(storedKey.Key)
-	if err != nil {
-		return nil, fmt.Errorf("could not decode key: %v", err)
+	var key []byte
+	if storedKey.KEKProvider != "" {
+		if e.kek == nil {
+			return nil, fmt.Errorf("key was wrapped by KEK provider %q but no KEK provider is configured",
+				storedKey.KEKProvider)
+		}
+		if storedKey.KEKProvider != e.kek.Name() {
+			return nil, fmt.Errorf("key was wrapped by KEK provider %q but KEK provider %q is configured",
+				storedKey.KEKProvider, e.kek.Name())
+		}
+		wrapped, err := base64.StdEncoding.DecodeString(storedKey.WrappedKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode wrapped key: %v", err)
+		}
+		key, err = e.kek.Unwrap(meta.KeyID, wrapped)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		key, err = base64.StdEncoding.DecodeString(storedKey.Key)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode key: %v", err)
+		}
 	}
 
 	return &structs.RootKey{
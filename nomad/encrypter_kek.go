@@ -0,0 +1,214 @@
+package nomad
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	// note: this is aliased so that it's more noticeable if someone
+	// accidentally swaps it out for math/rand via running goimports
+	cryptorand "crypto/rand"
+
+	vapi "github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+// kekProvider wraps and unwraps root key material with a key encryption key
+// (KEK), so that the root keys are never written to the on-disk keystore in
+// plaintext.
+type kekProvider interface {
+	// Name returns the name of the provider. It is written alongside the
+	// wrapped key so that we can detect a change of provider.
+	Name() string
+
+	// Wrap encrypts the key material for the root key with the given ID.
+	Wrap(keyID string, key []byte) ([]byte, error)
+
+	// Unwrap decrypts key material previously returned by Wrap for the root
+	// key with the given ID.
+	Unwrap(keyID string, wrapped []byte) ([]byte, error)
+}
+
+// newKEKProvider returns the KEK provider for the configuration, or nil if
+// no provider is configured.
+func newKEKProvider(cfg *config.KEKConfig) (kekProvider, error) {
+	if cfg == nil || cfg.Provider == "" {
+		return nil, nil
+	}
+
+	switch cfg.Provider {
+	case config.KEKProviderAEAD:
+		return newAEADKEKProvider(cfg)
+	case config.KEKProviderVaultTransit:
+		return newVaultTransitKEKProvider(cfg)
+	default:
+		return nil, fmt.Errorf("invalid KEK provider %q", cfg.Provider)
+	}
+}
+
+// aeadKEKProvider wraps root keys with a local AES-256-GCM key.
+type aeadKEKProvider struct {
+	cipher cipher.AEAD
+}
+
+func newAEADKEKProvider(cfg *config.KEKConfig) (*aeadKEKProvider, error) {
+	if cfg.KeyFile == "" {
+		return nil, fmt.Errorf("KEK provider %q requires a key_file", cfg.Provider)
+	}
+
+	raw, err := os.ReadFile(cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read KEK file: %v", err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode KEK file: %v", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("KEK must be 32 bytes, got %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("could not create KEK cipher: %v", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("could not create KEK cipher: %v", err)
+	}
+	return &aeadKEKProvider{cipher: aead}, nil
+}
+
+func (p *aeadKEKProvider) Name() string { return config.KEKProviderAEAD }
+
+func (p *aeadKEKProvider) Wrap(keyID string, key []byte) ([]byte, error) {
+	nonceSize := p.cipher.NonceSize()
+	nonce := make([]byte, nonceSize)
+	n, err := cryptorand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+	if n < nonceSize {
+		return nil, fmt.Errorf("failed to wrap key: entropy exhausted")
+	}
+
+	// the key ID is bound as AEAD additional data, so it's authenticated but
+	// not encrypted and wrapped keys can't be swapped between key files
+	return p.cipher.Seal(nonce, nonce, key, []byte(keyID)), nil
+}
+
+func (p *aeadKEKProvider) Unwrap(keyID string, wrapped []byte) ([]byte, error) {
+	nonceSize := p.cipher.NonceSize()
+	if len(wrapped) < nonceSize {
+		return nil, fmt.Errorf("failed to unwrap key: wrapped key is too short")
+	}
+	key, err := p.cipher.Open(nil, wrapped[:nonceSize], wrapped[nonceSize:], []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap key: %v", err)
+	}
+	return key, nil
+}
+
+// vaultTransitKEKProvider wraps root keys with a key held by the Vault
+// Transit secrets engine, so that the KEK never leaves Vault.
+type vaultTransitKEKProvider struct {
+	client    *vapi.Client
+	mountPath string
+	keyName   string
+}
+
+func newVaultTransitKEKProvider(cfg *config.KEKConfig) (*vaultTransitKEKProvider, error) {
+	if cfg.KeyName == "" {
+		return nil, fmt.Errorf("KEK provider %q requires a key_name", cfg.Provider)
+	}
+
+	vaultConfig := vapi.DefaultConfig()
+	if vaultConfig.Error != nil {
+		return nil, fmt.Errorf("could not configure Vault client: %v", vaultConfig.Error)
+	}
+	if cfg.Address != "" {
+		vaultConfig.Address = cfg.Address
+	}
+	client, err := vapi.NewClient(vaultConfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not create Vault client: %v", err)
+	}
+	if cfg.Token != "" {
+		client.SetToken(cfg.Token)
+	}
+	if cfg.Namespace != "" {
+		client.SetNamespace(cfg.Namespace)
+	}
+
+	mountPath := strings.Trim(cfg.MountPath, "/")
+	if mountPath == "" {
+		mountPath = config.DefaultKEKVaultTransitMountPath
+	}
+
+	return &vaultTransitKEKProvider{
+		client:    client,
+		mountPath: mountPath,
+		keyName:   cfg.KeyName,
+	}, nil
+}
+
+func (p *vaultTransitKEKProvider) Name() string { return config.KEKProviderVaultTransit }
+
+func (p *vaultTransitKEKProvider) Wrap(keyID string, key []byte) ([]byte, error) {
+	path := fmt.Sprintf("%s/encrypt/%s", p.mountPath, p.keyName)
+	secret, err := p.client.Logical().Write(path, map[string]interface{}{
+		"plaintext":       base64.StdEncoding.EncodeToString(key),
+		"associated_data": transitAssociatedData(keyID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap key with Vault: %v", err)
+	}
+	ciphertext, err := transitSecretField(secret, "ciphertext")
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap key with Vault: %v", err)
+	}
+	return []byte(ciphertext), nil
+}
+
+func (p *vaultTransitKEKProvider) Unwrap(keyID string, wrapped []byte) ([]byte, error) {
+	path := fmt.Sprintf("%s/decrypt/%s", p.mountPath, p.keyName)
+	secret, err := p.client.Logical().Write(path, map[string]interface{}{
+		"ciphertext":      string(wrapped),
+		"associated_data": transitAssociatedData(keyID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap key with Vault: %v", err)
+	}
+	plaintext, err := transitSecretField(secret, "plaintext")
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap key with Vault: %v", err)
+	}
+	key, err := base64.StdEncoding.DecodeString(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap key with Vault: %v", err)
+	}
+	return key, nil
+}
+
+// transitAssociatedData binds a wrapped key to its key ID, so that the
+// wrapped key material of one key can't be swapped into another key file.
+func transitAssociatedData(keyID string) string {
+	return base64.StdEncoding.EncodeToString([]byte(keyID))
+}
+
+// transitSecretField returns a string field from the data of a Vault
+// Transit response.
+func transitSecretField(secret *vapi.Secret, field string) (string, error) {
+	if secret == nil || secret.Data == nil {
+		return "", fmt.Errorf("empty response")
+	}
+	value, ok := secret.Data[field].(string)
+	if !ok || value == "" {
+		return "", fmt.Errorf("response is missing %s", field)
+	}
+	return value, nil
+}
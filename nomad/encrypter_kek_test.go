@@ -0,0 +1,323 @@
+package nomad
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+// testAEADKEKFile writes a random KEK to a file and returns its path
+func testAEADKEKFile(t *testing.T) string {
+	t.Helper()
+	key, err := structs.NewRootKey(structs.EncryptionAlgorithmAES256GCM)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "kek")
+	require.NoError(t, os.WriteFile(path,
+		[]byte(base64.StdEncoding.EncodeToString(key.Key)+"\n"), 0600))
+	return path
+}
+
+// testVaultTransitServer is a stand-in for the Vault Transit secrets engine
+// which "encrypts" by storing the plaintext and associated data and
+// returning a handle to them.
+func testVaultTransitServer(t *testing.T, mountPath, keyName string) *httptest.Server {
+	t.Helper()
+
+	type entry struct {
+		plaintext      string
+		associatedData string
+	}
+
+	var lock sync.Mutex
+	store := map[string]entry{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"errors":["permission denied"]}`)
+			return
+		}
+
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+
+		var data map[string]string
+		switch r.URL.Path {
+		case fmt.Sprintf("/v1/%s/encrypt/%s", mountPath, keyName):
+			ciphertext := "vault:v1:" + uuid.Generate()
+			store[ciphertext] = entry{body["plaintext"], body["associated_data"]}
+			data = map[string]string{"ciphertext": ciphertext}
+		case fmt.Sprintf("/v1/%s/decrypt/%s", mountPath, keyName):
+			e, ok := store[body["ciphertext"]]
+			if !ok || e.associatedData != body["associated_data"] {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"errors":["cipher: message authentication failed"]}`)
+				return
+			}
+			data = map[string]string{"plaintext": e.plaintext}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestNewKEKProvider(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		cfg         *config.KEKConfig
+		expectName  string
+		expectedErr string
+	}{
+		{
+			name: "nil config",
+		},
+		{
+			name: "no provider",
+			cfg:  &config.KEKConfig{},
+		},
+		{
+			name:        "invalid provider",
+			cfg:         &config.KEKConfig{Provider: "hsm"},
+			expectedErr: `invalid KEK provider "hsm"`,
+		},
+		{
+			name:        "aead missing key file",
+			cfg:         &config.KEKConfig{Provider: config.KEKProviderAEAD},
+			expectedErr: "requires a key_file",
+		},
+		{
+			name: "aead",
+			cfg: &config.KEKConfig{
+				Provider: config.KEKProviderAEAD,
+				KeyFile:  testAEADKEKFile(t),
+			},
+			expectName: config.KEKProviderAEAD,
+		},
+		{
+			name:        "vault transit missing key name",
+			cfg:         &config.KEKConfig{Provider: config.KEKProviderVaultTransit},
+			expectedErr: "requires a key_name",
+		},
+		{
+			name: "vault transit",
+			cfg: &config.KEKConfig{
+				Provider: config.KEKProviderVaultTransit,
+				KeyName:  "nomad-keyring",
+			},
+			expectName: config.KEKProviderVaultTransit,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kek, err := newKEKProvider(tc.cfg)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectName == "" {
+				require.Nil(t, kek)
+			} else {
+				require.Equal(t, tc.expectName, kek.Name())
+			}
+		})
+	}
+
+	t.Run("aead invalid key", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "kek")
+		require.NoError(t, os.WriteFile(path,
+			[]byte(base64.StdEncoding.EncodeToString([]byte("too short"))), 0600))
+		_, err := newKEKProvider(&config.KEKConfig{
+			Provider: config.KEKProviderAEAD,
+			KeyFile:  path,
+		})
+		require.EqualError(t, err, "KEK must be 32 bytes, got 9")
+	})
+}
+
+// TestEncrypter_LoadSave_KEK exercises round-tripping wrapped keys to disk
+func TestEncrypter_LoadSave_KEK(t *testing.T) {
+	ci.Parallel(t)
+
+	transit := testVaultTransitServer(t, "nomad-transit", "nomad-keyring")
+
+	testCases := []struct {
+		name string
+		cfg  *config.KEKConfig
+	}{
+		{
+			name: config.KEKProviderAEAD,
+			cfg: &config.KEKConfig{
+				Provider: config.KEKProviderAEAD,
+				KeyFile:  testAEADKEKFile(t),
+			},
+		},
+		{
+			name: config.KEKProviderVaultTransit,
+			cfg: &config.KEKConfig{
+				Provider:  config.KEKProviderVaultTransit,
+				Address:   transit.URL,
+				Token:     "root",
+				MountPath: "/nomad-transit/",
+				KeyName:   "nomad-keyring",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kek, err := newKEKProvider(tc.cfg)
+			require.NoError(t, err)
+
+			tmpDir := t.TempDir()
+			encrypter, err := NewEncrypter(nil, tmpDir, kek)
+			require.NoError(t, err)
+
+			key, err := structs.NewRootKey(structs.EncryptionAlgorithmAES256GCM)
+			require.NoError(t, err)
+			require.NoError(t, encrypter.saveKeyToStore(key))
+
+			// the key material must not be written in plaintext
+			path := filepath.Join(tmpDir, key.Meta.KeyID+nomadKeystoreExtension)
+			raw, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.NotContains(t, string(raw),
+				base64.StdEncoding.EncodeToString(key.Key))
+			require.Contains(t, string(raw), tc.cfg.Provider)
+
+			gotKey, err := encrypter.loadKeyFromStore(path)
+			require.NoError(t, err)
+			require.Equal(t, key.Key, gotKey.Key)
+			require.Equal(t, key.Meta.KeyID, gotKey.Meta.KeyID)
+			require.NoError(t, encrypter.addCipher(gotKey))
+
+			// reloading the keystore doesn't wrap the key again
+			_, err = NewEncrypter(nil, tmpDir, kek)
+			require.NoError(t, err)
+			reloaded, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, raw, reloaded)
+
+			// the wrapped key is bound to its key ID, so it can't be
+			// swapped into the key file of another key
+			otherKey, err := structs.NewRootKey(structs.EncryptionAlgorithmAES256GCM)
+			require.NoError(t, err)
+			require.NoError(t, encrypter.saveKeyToStore(otherKey))
+			otherPath := filepath.Join(tmpDir, otherKey.Meta.KeyID+nomadKeystoreExtension)
+
+			stored, err := readKeyFromStore(otherPath)
+			require.NoError(t, err)
+			original, err := readKeyFromStore(path)
+			require.NoError(t, err)
+			stored.WrappedKey = original.WrappedKey
+			swapped, err := json.Marshal(stored)
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(otherPath, swapped, 0600))
+
+			_, err = encrypter.loadKeyFromStore(otherPath)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "failed to unwrap key")
+			require.NoError(t, os.Remove(otherPath))
+
+			// a wrapped key can't be loaded without the KEK provider
+			encrypter.kek = nil
+			_, err = encrypter.loadKeyFromStore(path)
+			require.EqualError(t, err, fmt.Sprintf(
+				"key was wrapped by KEK provider %q but no KEK provider is configured",
+				tc.cfg.Provider))
+		})
+	}
+}
+
+// TestEncrypter_LoadSave_KEKInvalid verifies that keys wrapped by a
+// different KEK can't be unwrapped
+func TestEncrypter_LoadSave_KEKInvalid(t *testing.T) {
+	ci.Parallel(t)
+
+	tmpDir := t.TempDir()
+	kek, err := newKEKProvider(&config.KEKConfig{
+		Provider: config.KEKProviderAEAD,
+		KeyFile:  testAEADKEKFile(t),
+	})
+	require.NoError(t, err)
+	encrypter, err := NewEncrypter(nil, tmpDir, kek)
+	require.NoError(t, err)
+
+	key, err := structs.NewRootKey(structs.EncryptionAlgorithmAES256GCM)
+	require.NoError(t, err)
+	require.NoError(t, encrypter.saveKeyToStore(key))
+
+	otherKEK, err := newKEKProvider(&config.KEKConfig{
+		Provider: config.KEKProviderAEAD,
+		KeyFile:  testAEADKEKFile(t),
+	})
+	require.NoError(t, err)
+	_, err = NewEncrypter(nil, tmpDir, otherKEK)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to unwrap key")
+}
+
+// TestEncrypter_KEKMigration verifies that keys written in plaintext are
+// wrapped when the keystore is loaded with a KEK provider
+func TestEncrypter_KEKMigration(t *testing.T) {
+	ci.Parallel(t)
+
+	tmpDir := t.TempDir()
+	encrypter, err := NewEncrypter(nil, tmpDir, nil)
+	require.NoError(t, err)
+
+	key, err := structs.NewRootKey(structs.EncryptionAlgorithmAES256GCM)
+	require.NoError(t, err)
+	require.NoError(t, encrypter.AddKey(key))
+
+	path := filepath.Join(tmpDir, key.Meta.KeyID+nomadKeystoreExtension)
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(raw), base64.StdEncoding.EncodeToString(key.Key))
+
+	kek, err := newKEKProvider(&config.KEKConfig{
+		Provider: config.KEKProviderAEAD,
+		KeyFile:  testAEADKEKFile(t),
+	})
+	require.NoError(t, err)
+	encrypter, err = NewEncrypter(nil, tmpDir, kek)
+	require.NoError(t, err)
+
+	gotKey, err := encrypter.GetKey(key.Meta.KeyID)
+	require.NoError(t, err)
+	require.Equal(t, key.Key, gotKey)
+
+	raw, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(raw),
+		base64.StdEncoding.EncodeToString(key.Key), "key should have been wrapped")
+}
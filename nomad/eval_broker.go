@@ -43,6 +43,11 @@ var (
 // to only dequeue work they know how to handle. The broker is designed to be entirely
 // in-memory and is managed by the leader node.
 //
+// If fair-share dequeuing is enabled, the ready evaluations of a scheduler
+// are dequeued fairly across namespaces in proportion to their weights, so
+// that a namespace with many evaluations can't starve the others. Priority
+// then only orders the evaluations within a namespace.
+//
 // The broker must provide at-least-once delivery semantics. It relies on explicit
 // Ack/Nack messages to handle this. If a delivery is not Ack'd in a sufficient time
 // span, it will be assumed Nack'd.
@@ -66,8 +71,24 @@ type EvalBroker struct {
 	// blocked tracks the blocked evaluations by JobID in a priority queue
 	blocked map[structs.NamespacedID]PendingEvaluations
 
-	// ready tracks the ready jobs by scheduler in a priority queue
-	ready map[string]PendingEvaluations
+	// ready tracks the ready jobs by scheduler and namespace in priority
+	// queues
+	ready map[string]map[string]PendingEvaluations
+
+	// fairShare is the fair-share configuration, or nil if fair-share
+	// dequeuing is disabled
+	fairShare *structs.EvalBrokerFairShare
+
+	// usage tracks the number of evaluations dequeued per namespace divided
+	// by the weight of the namespace. The namespace with the least usage is
+	// dequeued next when fair-share dequeuing is enabled.
+	usage map[string]float64
+
+	// readyOrder tracks the order in which the ready evaluations were
+	// enqueued by ID, to dequeue evaluations of different namespaces but the
+	// same priority and create index in FIFO order
+	readyOrder   map[string]uint64
+	readyCounter uint64
 
 	// unack is a map of evalID to an un-acknowledged evaluation
 	unack map[string]*unackEval
@@ -140,7 +161,9 @@ func NewEvalBroker(timeout, initialNackDelay, subsequentNackDelay time.Duration,
 		evals:                make(map[string]int),
 		jobEvals:             make(map[structs.NamespacedID]string),
 		blocked:              make(map[structs.NamespacedID]PendingEvaluations),
-		ready:                make(map[string]PendingEvaluations),
+		ready:                make(map[string]map[string]PendingEvaluations),
+		usage:                make(map[string]float64),
+		readyOrder:           make(map[string]uint64),
 		unack:                make(map[string]*unackEval),
 		waiting:              make(map[string]chan struct{}),
 		requeue:              make(map[string]*structs.Evaluation),
@@ -151,6 +174,7 @@ func NewEvalBroker(timeout, initialNackDelay, subsequentNackDelay time.Duration,
 		delayedEvalsUpdateCh: make(chan struct{}, 1),
 	}
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.stats.DelayedEvals = make(map[string]*structs.Evaluation)
 
 	return b, nil
@@ -186,6 +210,26 @@ func (b *EvalBroker) SetEnabled(enabled bool) {
 	b.enabledNotifier.Notify("eval broker enabled status changed to " + strconv.FormatBool(enabled))
 }
 
+// SetFairShare is used to configure fair-share dequeuing of evaluations
+// across namespaces. Fair-share dequeuing is disabled if the configuration is
+// nil or not enabled.
+func (b *EvalBroker) SetFairShare(fairShare *structs.EvalBrokerFairShare) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	if fairShare == nil || !fairShare.Enabled {
+		b.fairShare = nil
+		return
+	}
+
+	// Start from a clean slate when fair-share dequeuing is enabled, so
+	// that the namespaces aren't charged for evaluations dequeued before
+	if b.fairShare == nil {
+		b.usage = make(map[string]float64)
+	}
+	b.fairShare = fairShare.Copy()
+}
+
 // Enqueue is used to enqueue a new evaluation
 func (b *EvalBroker) Enqueue(eval *structs.Evaluation) {
 	b.l.Lock()
@@ -303,21 +347,37 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, queue string) {
 		heap.Push(&blocked, eval)
 		b.blocked[namespacedID] = blocked
 		b.stats.TotalBlocked += 1
+		b.namespaceStats(eval.Namespace).Blocked += 1
 		return
 	}
 
 	// Find the pending by scheduler class
-	pending, ok := b.ready[queue]
+	ready, ok := b.ready[queue]
 	if !ok {
-		pending = make([]*structs.Evaluation, 0, 16)
+		ready = make(map[string]PendingEvaluations)
+		b.ready[queue] = ready
 		if _, ok := b.waiting[queue]; !ok {
 			b.waiting[queue] = make(chan struct{}, 1)
 		}
 	}
+	pending, ok := ready[eval.Namespace]
+	if !ok {
+		pending = make([]*structs.Evaluation, 0, 16)
+	}
+
+	// A namespace that had no ready evaluations can't have built up credit
+	// while it was idle, otherwise it could monopolize the broker until its
+	// usage catches up with the other namespaces.
+	byNamespace := b.namespaceStats(eval.Namespace)
+	if b.fairShare != nil && byNamespace.Ready == 0 {
+		b.catchUpUsage(eval.Namespace)
+	}
 
 	// Push onto the heap
 	heap.Push(&pending, eval)
-	b.ready[queue] = pending
+	ready[eval.Namespace] = pending
+	b.readyCounter++
+	b.readyOrder[eval.ID] = b.readyCounter
 
 	// Update the stats
 	b.stats.TotalReady += 1
@@ -327,6 +387,7 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, queue string) {
 		b.stats.ByScheduler[queue] = bySched
 	}
 	bySched.Ready += 1
+	byNamespace.Ready += 1
 
 	// Unblock any blocked dequeues
 	select {
@@ -390,14 +451,8 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 	var eligibleSched []string
 	var eligiblePriority int
 	for _, sched := range schedulers {
-		// Get the pending queue
-		pending, ok := b.ready[sched]
-		if !ok {
-			continue
-		}
-
 		// Peek at the next item
-		ready := pending.Peek()
+		_, ready := b.nextReady(sched)
 		if ready == nil {
 			continue
 		}
@@ -437,10 +492,22 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 // This assumes locks are held and that this scheduler has work
 func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string, error) {
 	// Get the pending queue
-	pending := b.ready[sched]
+	namespace, _ := b.nextReady(sched)
+	ready := b.ready[sched]
+	pending := ready[namespace]
 	raw := heap.Pop(&pending)
-	b.ready[sched] = pending
+	if len(pending) > 0 {
+		ready[namespace] = pending
+	} else {
+		delete(ready, namespace)
+	}
 	eval := raw.(*structs.Evaluation)
+	delete(b.readyOrder, eval.ID)
+
+	// Charge the namespace for the dequeue
+	if b.fairShare != nil {
+		b.usage[namespace] += 1 / float64(b.fairShare.Weight(namespace))
+	}
 
 	// Generate a UUID for the token
 	token := uuid.Generate()
@@ -466,10 +533,91 @@ func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string,
 	bySched := b.stats.ByScheduler[sched]
 	bySched.Ready -= 1
 	bySched.Unacked += 1
+	byNamespace := b.namespaceStats(namespace)
+	byNamespace.Ready -= 1
+	byNamespace.Unacked += 1
 
 	return eval, token, nil
 }
 
+// nextReady returns the next ready evaluation of the scheduler and its
+// namespace, or nil if the scheduler has no ready evaluations. This assumes
+// locks are held.
+func (b *EvalBroker) nextReady(sched string) (string, *structs.Evaluation) {
+	var next string
+	var nextEval *structs.Evaluation
+	for namespace, pending := range b.ready[sched] {
+		eval := pending.Peek()
+		if eval == nil {
+			continue
+		}
+		if nextEval == nil || b.readyBefore(namespace, eval, next, nextEval) {
+			next, nextEval = namespace, eval
+		}
+	}
+	return next, nextEval
+}
+
+// readyBefore returns whether the ready evaluation a of namespace aNS should
+// be dequeued before the ready evaluation b of namespace bNS.
+func (b *EvalBroker) readyBefore(aNS string, a *structs.Evaluation, bNS string, other *structs.Evaluation) bool {
+	if b.fairShare != nil && aNS != bNS {
+		if aUsage, bUsage := b.usage[aNS], b.usage[bNS]; aUsage != bUsage {
+			return aUsage < bUsage
+		}
+	}
+	if a.Priority != other.Priority {
+		return a.Priority > other.Priority
+	}
+	if a.CreateIndex != other.CreateIndex {
+		return a.CreateIndex < other.CreateIndex
+	}
+	return b.readyOrder[a.ID] < b.readyOrder[other.ID]
+}
+
+// catchUpUsage raises the usage of a namespace to the least usage of the
+// namespaces with ready evaluations. This assumes locks are held.
+func (b *EvalBroker) catchUpUsage(namespace string) {
+	first := true
+	var least float64
+	for ns, stats := range b.stats.ByNamespace {
+		if ns == namespace || stats.Ready == 0 {
+			continue
+		}
+		if usage := b.usage[ns]; first || usage < least {
+			least = usage
+			first = false
+		}
+	}
+	if !first && b.usage[namespace] < least {
+		b.usage[namespace] = least
+	}
+}
+
+// namespaceStats returns the stats of the namespace, creating them if
+// needed. This assumes locks are held.
+func (b *EvalBroker) namespaceStats(namespace string) *NamespaceStats {
+	byNamespace, ok := b.stats.ByNamespace[namespace]
+	if !ok {
+		byNamespace = &NamespaceStats{}
+		b.stats.ByNamespace[namespace] = byNamespace
+	}
+	return byNamespace
+}
+
+// pruneNamespace drops the stats and usage of the namespace once it has no
+// ready, unacked or blocked evaluations, so that they aren't kept for every
+// namespace ever seen. The usage of the namespace is caught up with the other
+// namespaces when it has ready evaluations again. This assumes locks are held.
+func (b *EvalBroker) pruneNamespace(namespace string) {
+	stats, ok := b.stats.ByNamespace[namespace]
+	if !ok || stats.Ready != 0 || stats.Unacked != 0 || stats.Blocked != 0 {
+		return
+	}
+	delete(b.stats.ByNamespace, namespace)
+	delete(b.usage, namespace)
+}
+
 // waitForSchedulers is used to wait for work on any of the scheduler or until a timeout.
 // Returns if there is work waiting potentially.
 func (b *EvalBroker) waitForSchedulers(schedulers []string, timeoutCh <-chan time.Time) bool {
@@ -573,6 +721,7 @@ func (b *EvalBroker) Ack(evalID, token string) error {
 	}
 	bySched := b.stats.ByScheduler[queue]
 	bySched.Unacked -= 1
+	b.namespaceStats(unack.Eval.Namespace).Unacked -= 1
 
 	// Cleanup
 	delete(b.unack, evalID)
@@ -594,6 +743,7 @@ func (b *EvalBroker) Ack(evalID, token string) error {
 		}
 		eval := raw.(*structs.Evaluation)
 		b.stats.TotalBlocked -= 1
+		b.namespaceStats(eval.Namespace).Blocked -= 1
 		b.enqueueLocked(eval, eval.Type)
 	}
 
@@ -602,6 +752,7 @@ func (b *EvalBroker) Ack(evalID, token string) error {
 		b.processEnqueue(eval, "")
 	}
 
+	b.pruneNamespace(unack.Eval.Namespace)
 	return nil
 }
 
@@ -633,6 +784,7 @@ func (b *EvalBroker) Nack(evalID, token string) error {
 	b.stats.TotalUnacked -= 1
 	bySched := b.stats.ByScheduler[unack.Eval.Type]
 	bySched.Unacked -= 1
+	b.namespaceStats(unack.Eval.Namespace).Unacked -= 1
 
 	// Check if we've hit the delivery limit, and re-enqueue
 	// in the failedQueue
@@ -650,6 +802,7 @@ func (b *EvalBroker) Nack(evalID, token string) error {
 		}
 	}
 
+	b.pruneNamespace(unack.Eval.Namespace)
 	return nil
 }
 
@@ -735,10 +888,13 @@ func (b *EvalBroker) flush() {
 	b.stats.TotalWaiting = 0
 	b.stats.DelayedEvals = make(map[string]*structs.Evaluation)
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.evals = make(map[string]int)
 	b.jobEvals = make(map[structs.NamespacedID]string)
 	b.blocked = make(map[structs.NamespacedID]PendingEvaluations)
-	b.ready = make(map[string]PendingEvaluations)
+	b.ready = make(map[string]map[string]PendingEvaluations)
+	b.usage = make(map[string]float64)
+	b.readyOrder = make(map[string]uint64)
 	b.unack = make(map[string]*unackEval)
 	b.timeWait = make(map[string]*time.Timer)
 	b.delayHeap = delayheap.NewDelayHeap()
@@ -821,6 +977,7 @@ func (b *EvalBroker) Stats() *BrokerStats {
 	stats := new(BrokerStats)
 	stats.DelayedEvals = make(map[string]*structs.Evaluation)
 	stats.ByScheduler = make(map[string]*SchedulerStats)
+	stats.ByNamespace = make(map[string]*NamespaceStats)
 
 	b.l.RLock()
 	defer b.l.RUnlock()
@@ -838,6 +995,11 @@ func (b *EvalBroker) Stats() *BrokerStats {
 		subStatCopy := *subStat
 		stats.ByScheduler[sched] = &subStatCopy
 	}
+	for namespace, subStat := range b.stats.ByNamespace {
+		subStatCopy := *subStat
+		subStatCopy.Usage = b.usage[namespace]
+		stats.ByNamespace[namespace] = &subStatCopy
+	}
 	return stats
 }
 
@@ -869,6 +1031,13 @@ func (b *EvalBroker) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 				metrics.SetGauge([]string{"nomad", "broker", sched, "ready"}, float32(schedStats.Ready))
 				metrics.SetGauge([]string{"nomad", "broker", sched, "unacked"}, float32(schedStats.Unacked))
 			}
+			for namespace, nsStats := range stats.ByNamespace {
+				labels := []metrics.Label{{Name: "namespace", Value: namespace}}
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "ready"}, float32(nsStats.Ready), labels)
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "unacked"}, float32(nsStats.Unacked), labels)
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "blocked"}, float32(nsStats.Blocked), labels)
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "usage"}, float32(nsStats.Usage), labels)
+			}
 
 		case <-stopCh:
 			return
@@ -884,6 +1053,7 @@ type BrokerStats struct {
 	TotalWaiting int
 	DelayedEvals map[string]*structs.Evaluation
 	ByScheduler  map[string]*SchedulerStats
+	ByNamespace  map[string]*NamespaceStats
 }
 
 // SchedulerStats returns the stats per scheduler
@@ -892,6 +1062,17 @@ type SchedulerStats struct {
 	Unacked int
 }
 
+// NamespaceStats returns the stats per namespace
+type NamespaceStats struct {
+	Ready   int
+	Unacked int
+	Blocked int
+
+	// Usage is the number of evaluations dequeued for the namespace divided
+	// by its weight while fair-share dequeuing is enabled
+	Usage float64
+}
+
 // Len is for the sorting interface
 func (p PendingEvaluations) Len() int {
 	return len(p)
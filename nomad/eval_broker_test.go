@@ -1506,3 +1506,170 @@ func TestEvalBroker_NamespacedJobs(t *testing.T) {
 	require.Equal(1, len(b.blocked))
 
 }
+
+// Ensure fairness between namespaces
+func TestEvalBroker_Dequeue_FairShare(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+	b.SetFairShare(&structs.EvalBrokerFairShare{
+		Enabled: true,
+		Weights: map[string]int{"batch": 3},
+	})
+
+	// The evaluations of the "prod" namespace have a higher priority, which
+	// would starve the "batch" namespace without fair-share dequeuing.
+	for i := 0; i < 40; i++ {
+		for _, namespace := range []string{"prod", "batch"} {
+			eval := mock.Eval()
+			eval.Namespace = namespace
+			eval.CreateIndex = uint64(i)
+			if namespace == "prod" {
+				eval.Priority = 80
+			}
+			b.Enqueue(eval)
+		}
+	}
+
+	dequeued := map[string]int{}
+	for i := 0; i < 40; i++ {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		require.NoError(t, err)
+		require.NotNil(t, out)
+		dequeued[out.Namespace]++
+	}
+	require.Equal(t, map[string]int{"prod": 10, "batch": 30}, dequeued)
+
+	stats := b.Stats()
+	require.Equal(t, 30, stats.ByNamespace["prod"].Ready)
+	require.Equal(t, 10, stats.ByNamespace["prod"].Unacked)
+	require.Equal(t, 10, stats.ByNamespace["batch"].Ready)
+	require.Equal(t, 30, stats.ByNamespace["batch"].Unacked)
+	require.Equal(t, 10.0, stats.ByNamespace["prod"].Usage)
+	require.InDelta(t, 10.0, stats.ByNamespace["batch"].Usage, 0.001)
+
+	// Without fair-share dequeuing, priority wins across namespaces.
+	b.SetFairShare(nil)
+	for i := 0; i < 10; i++ {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		require.NoError(t, err)
+		require.Equal(t, "prod", out.Namespace)
+	}
+}
+
+// Ensure a namespace can't build up credit while it is idle
+func TestEvalBroker_Dequeue_FairShare_Idle(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+	b.SetFairShare(&structs.EvalBrokerFairShare{Enabled: true})
+
+	enqueue := func(namespace string, n int) {
+		for i := 0; i < n; i++ {
+			eval := mock.Eval()
+			eval.Namespace = namespace
+			b.Enqueue(eval)
+		}
+	}
+
+	enqueue("busy", 10)
+	for i := 0; i < 5; i++ {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		require.NoError(t, err)
+		require.Equal(t, "busy", out.Namespace)
+	}
+
+	// The idle namespace starts from the usage of the busy namespace, so the
+	// namespaces alternate instead of the idle one catching up first.
+	enqueue("idle", 10)
+	dequeued := map[string]int{}
+	for i := 0; i < 4; i++ {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		require.NoError(t, err)
+		dequeued[out.Namespace]++
+	}
+	require.Equal(t, map[string]int{"busy": 2, "idle": 2}, dequeued)
+}
+
+func TestEvalBroker_Stats_ByNamespace(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	eval1 := mock.Eval()
+	eval1.Namespace = "n1"
+	b.Enqueue(eval1)
+
+	// Blocked behind eval1
+	eval2 := mock.Eval()
+	eval2.Namespace = "n1"
+	eval2.JobID = eval1.JobID
+	b.Enqueue(eval2)
+
+	eval3 := mock.Eval()
+	b.Enqueue(eval3)
+
+	stats := b.Stats()
+	require.Equal(t, &NamespaceStats{Ready: 1, Blocked: 1}, stats.ByNamespace["n1"])
+	require.Equal(t, &NamespaceStats{Ready: 1}, stats.ByNamespace[structs.DefaultNamespace])
+
+	out, token, err := b.Dequeue(defaultSched, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, eval1.ID, out.ID)
+	require.Equal(t, &NamespaceStats{Unacked: 1, Blocked: 1}, b.Stats().ByNamespace["n1"])
+
+	// Acking unblocks eval2
+	require.NoError(t, b.Ack(eval1.ID, token))
+	require.Equal(t, &NamespaceStats{Ready: 1}, b.Stats().ByNamespace["n1"])
+
+	out, token, err = b.Dequeue(defaultSched, time.Second)
+	require.NoError(t, err)
+	require.NoError(t, b.Nack(out.ID, token))
+
+	// The nacked evaluation waits before it is enqueued again, so the
+	// namespace has no evaluations left in the meantime
+	require.NotContains(t, b.Stats().ByNamespace, out.Namespace)
+}
+
+// Ensure the stats and usage of idle namespaces are dropped
+func TestEvalBroker_FairShare_Prune(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+	b.SetFairShare(&structs.EvalBrokerFairShare{Enabled: true})
+
+	for _, namespace := range []string{"n1", "n2"} {
+		eval := mock.Eval()
+		eval.Namespace = namespace
+		b.Enqueue(eval)
+	}
+
+	out, token, err := b.Dequeue(defaultSched, time.Second)
+	require.NoError(t, err)
+	require.NoError(t, b.Ack(out.ID, token))
+
+	b.l.Lock()
+	require.NotContains(t, b.stats.ByNamespace, out.Namespace)
+	require.NotContains(t, b.usage, out.Namespace)
+	require.Len(t, b.stats.ByNamespace, 1)
+	b.l.Unlock()
+
+	// A namespace with a blocked evaluation is kept until it is acked
+	out, token, err = b.Dequeue(defaultSched, time.Second)
+	require.NoError(t, err)
+	blocked := mock.Eval()
+	blocked.Namespace = out.Namespace
+	blocked.JobID = out.JobID
+	b.Enqueue(blocked)
+	require.NoError(t, b.Ack(out.ID, token))
+	require.Equal(t, &NamespaceStats{Ready: 1, Usage: 1}, b.Stats().ByNamespace[out.Namespace])
+
+	out, token, err = b.Dequeue(defaultSched, time.Second)
+	require.NoError(t, err)
+	require.NoError(t, b.Ack(out.ID, token))
+	require.Empty(t, b.Stats().ByNamespace)
+
+	b.l.Lock()
+	require.Empty(t, b.usage)
+	b.l.Unlock()
+}
@@ -58,6 +58,11 @@ const (
 	SecureVariablesSnapshot              SnapshotType = 22
 	SecureVariablesQuotaSnapshot         SnapshotType = 23
 	RootKeyMetaSnapshot                  SnapshotType = 24
+	ACLRoleSnapshot                      SnapshotType = 25
+	ACLAuthMethodSnapshot                SnapshotType = 26
+	ACLBindingRuleSnapshot               SnapshotType = 27
+	NodePoolSnapshot                     SnapshotType = 28
+	SecureVariableVersionSnapshot        SnapshotType = 29
 
 	// Namespace appliers were moved from enterprise and therefore start at 64
 	NamespaceSnapshot SnapshotType = 64
@@ -319,10 +324,28 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyDeleteServiceRegistrationByNodeID(msgType, buf[1:], log.Index)
 	case structs.SVApplyStateRequestType:
 		return n.applySecureVariableOperation(msgType, buf[1:], log.Index)
+	case structs.SVRekeyRequestType:
+		return n.applySecureVariableRekey(msgType, buf[1:], log.Index)
 	case structs.RootKeyMetaUpsertRequestType:
 		return n.applyRootKeyMetaUpsert(msgType, buf[1:], log.Index)
 	case structs.RootKeyMetaDeleteRequestType:
 		return n.applyRootKeyMetaDelete(msgType, buf[1:], log.Index)
+	case structs.ACLRolesUpsertRequestType:
+		return n.applyACLRolesUpsert(msgType, buf[1:], log.Index)
+	case structs.ACLRolesDeleteByIDRequestType:
+		return n.applyACLRolesDeleteByID(msgType, buf[1:], log.Index)
+	case structs.ACLAuthMethodsUpsertRequestType:
+		return n.applyACLAuthMethodsUpsert(msgType, buf[1:], log.Index)
+	case structs.ACLAuthMethodsDeleteRequestType:
+		return n.applyACLAuthMethodsDelete(msgType, buf[1:], log.Index)
+	case structs.ACLBindingRulesUpsertRequestType:
+		return n.applyACLBindingRulesUpsert(msgType, buf[1:], log.Index)
+	case structs.ACLBindingRulesDeleteRequestType:
+		return n.applyACLBindingRulesDelete(msgType, buf[1:], log.Index)
+	case structs.NodePoolUpsertRequestType:
+		return n.applyNodePoolUpsert(msgType, buf[1:], log.Index)
+	case structs.NodePoolDeleteRequestType:
+		return n.applyNodePoolDelete(msgType, buf[1:], log.Index)
 	}
 
 	// Check enterprise only message types.
@@ -1729,6 +1752,16 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 				return err
 			}
 
+		case SecureVariableVersionSnapshot:
+			version := new(structs.SecureVariableEncrypted)
+			if err := dec.Decode(version); err != nil {
+				return err
+			}
+
+			if err := restore.SecureVariableVersionRestore(version); err != nil {
+				return err
+			}
+
 		case SecureVariablesQuotaSnapshot:
 			quota := new(structs.SecureVariablesQuota)
 			if err := dec.Decode(quota); err != nil {
@@ -1749,6 +1782,46 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 				return err
 			}
 
+		case ACLRoleSnapshot:
+			aclRole := new(structs.ACLRole)
+			if err := dec.Decode(aclRole); err != nil {
+				return err
+			}
+
+			if err := restore.ACLRoleRestore(aclRole); err != nil {
+				return err
+			}
+
+		case ACLAuthMethodSnapshot:
+			authMethod := new(structs.ACLAuthMethod)
+			if err := dec.Decode(authMethod); err != nil {
+				return err
+			}
+
+			if err := restore.ACLAuthMethodRestore(authMethod); err != nil {
+				return err
+			}
+
+		case ACLBindingRuleSnapshot:
+			bindingRule := new(structs.ACLBindingRule)
+			if err := dec.Decode(bindingRule); err != nil {
+				return err
+			}
+
+			if err := restore.ACLBindingRuleRestore(bindingRule); err != nil {
+				return err
+			}
+
+		case NodePoolSnapshot:
+			pool := new(structs.NodePool)
+			if err := dec.Decode(pool); err != nil {
+				return err
+			}
+
+			if err := restore.NodePoolRestore(pool); err != nil {
+				return err
+			}
+
 		default:
 			// Check if this is an enterprise only object being restored
 			restorer, ok := n.enterpriseRestorers[snapType]
@@ -2050,6 +2123,10 @@ func (n *nomadFSM) applySecureVariableOperation(msgType structs.MessageType, buf
 		return n.state.SVEDeleteCAS(index, &req)
 	case structs.SVOpCAS:
 		return n.state.SVESetCAS(index, &req)
+	case structs.SVOpLockAcquire:
+		return n.state.SVELockAcquire(index, &req)
+	case structs.SVOpLockRelease:
+		return n.state.SVELockRelease(index, &req)
 	default:
 		err := fmt.Errorf("Invalid SVE operation '%s'", req.Op)
 		n.logger.Warn("Invalid SVE operation", "operation", req.Op)
@@ -2057,6 +2134,21 @@ func (n *nomadFSM) applySecureVariableOperation(msgType structs.MessageType, buf
 	}
 }
 
+func (n *nomadFSM) applySecureVariableRekey(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_sv_rekey"}, time.Now())
+	var req structs.SVRekeyStateRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.SVERekey(index, &req); err != nil {
+		n.logger.Error("SVERekey failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (n *nomadFSM) applyRootKeyMetaUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_root_key_meta_upsert"}, time.Now())
 
@@ -2089,6 +2181,126 @@ func (n *nomadFSM) applyRootKeyMetaDelete(msgType structs.MessageType, buf []byt
 	return nil
 }
 
+func (n *nomadFSM) applyACLRolesUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_role_upsert"}, time.Now())
+	var req structs.ACLRolesUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertACLRoles(msgType, index, req.ACLRoles, req.AllowMissingPolicies); err != nil {
+		n.logger.Error("UpsertACLRoles failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyACLRolesDeleteByID(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_role_delete_by_id"}, time.Now())
+	var req structs.ACLRolesDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteACLRolesByID(msgType, index, req.ACLRoleIDs); err != nil {
+		n.logger.Error("DeleteACLRolesByID failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyACLAuthMethodsUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_auth_method_upsert"}, time.Now())
+	var req structs.ACLAuthMethodsUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertACLAuthMethods(msgType, index, req.AuthMethods); err != nil {
+		n.logger.Error("UpsertACLAuthMethods failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyACLAuthMethodsDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_auth_method_delete"}, time.Now())
+	var req structs.ACLAuthMethodsDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteACLAuthMethods(msgType, index, req.Names); err != nil {
+		n.logger.Error("DeleteACLAuthMethods failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyNodePoolUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_node_pool_upsert"}, time.Now())
+	var req structs.NodePoolUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertNodePools(msgType, index, req.NodePools); err != nil {
+		n.logger.Error("UpsertNodePools failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyNodePoolDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_node_pool_delete"}, time.Now())
+	var req structs.NodePoolDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteNodePools(msgType, index, req.Names); err != nil {
+		n.logger.Error("DeleteNodePools failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyACLBindingRulesUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_binding_rules_upsert"}, time.Now())
+	var req structs.ACLBindingRulesUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertACLBindingRules(msgType, index, req.ACLBindingRules, req.AllowMissingAuthMethods); err != nil {
+		n.logger.Error("UpsertACLBindingRules failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyACLBindingRulesDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_binding_rules_delete"}, time.Now())
+	var req structs.ACLBindingRulesDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteACLBindingRules(msgType, index, req.ACLBindingRuleIDs); err != nil {
+		n.logger.Error("DeleteACLBindingRules failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "persist"}, time.Now())
 	// Register the nodes
@@ -2201,6 +2413,10 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistSecureVariableVersions(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	if err := s.persistSecureVariablesQuotas(sink, encoder); err != nil {
 		sink.Cancel()
 		return err
@@ -2209,6 +2425,22 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistACLRoles(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistACLAuthMethods(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistACLBindingRules(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistNodePools(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	return nil
 }
 
@@ -2790,6 +3022,29 @@ func (s *nomadSnapshot) persistSecureVariables(sink raft.SnapshotSink,
 	return nil
 }
 
+func (s *nomadSnapshot) persistSecureVariableVersions(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	ws := memdb.NewWatchSet()
+	versions, err := s.snap.SecureVariableVersions(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		raw := versions.Next()
+		if raw == nil {
+			break
+		}
+		version := raw.(*structs.SecureVariableEncrypted)
+		sink.Write([]byte{byte(SecureVariableVersionSnapshot)})
+		if err := encoder.Encode(version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *nomadSnapshot) persistSecureVariablesQuotas(sink raft.SnapshotSink,
 	encoder *codec.Encoder) error {
 
@@ -2836,6 +3091,122 @@ func (s *nomadSnapshot) persistRootKeyMeta(sink raft.SnapshotSink,
 	return nil
 }
 
+func (s *nomadSnapshot) persistACLRoles(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	// Get all the ACL roles.
+	ws := memdb.NewWatchSet()
+	aclRolesIter, err := s.snap.GetACLRoles(ws)
+	if err != nil {
+		return err
+	}
+
+	// Iterate all the ACL roles.
+	for {
+		raw := aclRolesIter.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct.
+		role := raw.(*structs.ACLRole)
+
+		// Write out an ACL role snapshot.
+		sink.Write([]byte{byte(ACLRoleSnapshot)})
+		if err := encoder.Encode(role); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistACLAuthMethods(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	// Get all the ACL auth methods.
+	ws := memdb.NewWatchSet()
+	aclAuthMethodsIter, err := s.snap.GetACLAuthMethods(ws)
+	if err != nil {
+		return err
+	}
+
+	// Iterate all the ACL auth methods.
+	for {
+		raw := aclAuthMethodsIter.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct.
+		method := raw.(*structs.ACLAuthMethod)
+
+		// Write out an ACL auth method snapshot.
+		sink.Write([]byte{byte(ACLAuthMethodSnapshot)})
+		if err := encoder.Encode(method); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistACLBindingRules(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	// Get all the ACL binding rules.
+	ws := memdb.NewWatchSet()
+	aclBindingRulesIter, err := s.snap.GetACLBindingRules(ws)
+	if err != nil {
+		return err
+	}
+
+	// Iterate all the ACL binding rules.
+	for {
+		raw := aclBindingRulesIter.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct.
+		rule := raw.(*structs.ACLBindingRule)
+
+		// Write out an ACL binding rule snapshot.
+		sink.Write([]byte{byte(ACLBindingRuleSnapshot)})
+		if err := encoder.Encode(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistNodePools(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	// Get all the node pools.
+	ws := memdb.NewWatchSet()
+	nodePoolsIter, err := s.snap.NodePools(ws)
+	if err != nil {
+		return err
+	}
+
+	// Iterate all the node pools.
+	for {
+		raw := nodePoolsIter.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct.
+		pool := raw.(*structs.NodePool)
+
+		// Write out a node pool snapshot.
+		sink.Write([]byte{byte(NodePoolSnapshot)})
+		if err := encoder.Encode(pool); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Release is a no-op, as we just need to GC the pointer
 // to the state store snapshot. There is nothing to explicitly
 // cleanup.
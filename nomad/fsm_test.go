@@ -2893,6 +2893,90 @@ func TestFSM_SnapshotRestore_ServiceRegistrations(t *testing.T) {
 	require.ElementsMatch(t, restoredRegs, serviceRegs)
 }
 
+func TestFSM_SnapshotRestore_ACLRoles(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create our initial FSM which will be snapshotted.
+	fsm := testFSM(t)
+	testState := fsm.State()
+
+	// Generate and upsert some ACL roles.
+	aclRoles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	require.NoError(t, testState.UpsertACLRoles(structs.MsgTypeTestSetup, 10, aclRoles, true))
+
+	// Perform a snapshot restore.
+	restoredFSM := testSnapshotRestore(t, fsm)
+	restoredState := restoredFSM.State()
+
+	// List the ACL roles from restored state and ensure everything is as
+	// expected.
+	iter, err := restoredState.GetACLRoles(memdb.NewWatchSet())
+	require.NoError(t, err)
+
+	var restoredACLRoles []*structs.ACLRole
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		restoredACLRoles = append(restoredACLRoles, raw.(*structs.ACLRole))
+	}
+	require.ElementsMatch(t, restoredACLRoles, aclRoles)
+}
+
+func TestFSM_SnapshotRestore_ACLAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create our initial FSM which will be snapshotted.
+	fsm := testFSM(t)
+	testState := fsm.State()
+
+	// Generate and upsert some ACL auth methods.
+	authMethods := []*structs.ACLAuthMethod{mock.ACLAuthMethod(), mock.ACLAuthMethod()}
+	require.NoError(t, testState.UpsertACLAuthMethods(structs.MsgTypeTestSetup, 10, authMethods))
+
+	// Perform a snapshot restore.
+	restoredFSM := testSnapshotRestore(t, fsm)
+	restoredState := restoredFSM.State()
+
+	// List the ACL auth methods from restored state and ensure everything is
+	// as expected.
+	iter, err := restoredState.GetACLAuthMethods(memdb.NewWatchSet())
+	require.NoError(t, err)
+
+	var restoredACLAuthMethods []*structs.ACLAuthMethod
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		restoredACLAuthMethods = append(restoredACLAuthMethods, raw.(*structs.ACLAuthMethod))
+	}
+	require.ElementsMatch(t, restoredACLAuthMethods, authMethods)
+}
+
+func TestFSM_SnapshotRestore_ACLBindingRules(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create our initial FSM which will be snapshotted.
+	fsm := testFSM(t)
+	testState := fsm.State()
+
+	// Generate and upsert some ACL binding rules.
+	bindingRules := []*structs.ACLBindingRule{mock.ACLBindingRule(), mock.ACLBindingRule()}
+	require.NoError(t, testState.UpsertACLBindingRules(structs.MsgTypeTestSetup, 10, bindingRules, true))
+
+	// Perform a snapshot restore.
+	restoredFSM := testSnapshotRestore(t, fsm)
+	restoredState := restoredFSM.State()
+
+	// List the ACL binding rules from restored state and ensure everything is
+	// as expected.
+	iter, err := restoredState.GetACLBindingRules(memdb.NewWatchSet())
+	require.NoError(t, err)
+
+	var restoredACLBindingRules []*structs.ACLBindingRule
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		restoredACLBindingRules = append(restoredACLBindingRules, raw.(*structs.ACLBindingRule))
+	}
+	require.ElementsMatch(t, restoredACLBindingRules, bindingRules)
+}
+
 func TestFSM_ReconcileSummaries(t *testing.T) {
 	ci.Parallel(t)
 	// Add some state
@@ -3418,6 +3502,14 @@ func TestFSM_SnapshotRestore_SecureVariables(t *testing.T) {
 		restoredSVs = append(restoredSVs, raw.(*structs.SecureVariableEncrypted))
 	}
 	require.ElementsMatch(t, restoredSVs, svs)
+
+	// The tracked versions of each secure variable are restored as well.
+	for _, sv := range svs {
+		versions, err := restoredState.GetSecureVariableVersions(nil, sv.Namespace, sv.Path)
+		require.NoError(t, err)
+		require.Len(t, versions, 1)
+		require.Equal(t, sv.Data, versions[0].Data)
+	}
 }
 
 func TestFSM_ACLEvents(t *testing.T) {
@@ -3622,3 +3714,246 @@ func TestFSM_EventBroker_JobRegisterFSMEvents(t *testing.T) {
 	require.Len(t, events, 1)
 	require.Equal(t, structs.TypeJobRegistered, events[0].Type)
 }
+
+func TestFSM_ApplyACLRolesUpsert(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	// Create the policies our ACL roles wants to link to.
+	policy1 := mock.ACLPolicy()
+	policy1.Name = "mocked-test-policy-1"
+	policy2 := mock.ACLPolicy()
+	policy2.Name = "mocked-test-policy-2"
+
+	require.NoError(t, fsm.State().UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLPolicy{policy1, policy2}))
+
+	// Generate the upsert request and apply the change.
+	req := structs.ACLRolesUpsertRequest{
+		ACLRoles: []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()},
+	}
+	buf, err := structs.Encode(structs.ACLRolesUpsertRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Read out both ACL roles and perform an equality check using the hash.
+	ws := memdb.NewWatchSet()
+	out, err := fsm.State().GetACLRoleByName(ws, req.ACLRoles[0].Name)
+	require.NoError(t, err)
+	require.Equal(t, req.ACLRoles[0].Hash, out.Hash)
+
+	out, err = fsm.State().GetACLRoleByName(ws, req.ACLRoles[1].Name)
+	require.NoError(t, err)
+	require.Equal(t, req.ACLRoles[1].Hash, out.Hash)
+}
+
+func TestFSM_ApplyACLRolesDeleteByID(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	// Generate and upsert two ACL roles.
+	aclRoles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	require.NoError(t, fsm.State().UpsertACLRoles(structs.MsgTypeTestSetup, 10, aclRoles, true))
+
+	// Build and apply our message.
+	req := structs.ACLRolesDeleteRequest{ACLRoleIDs: []string{aclRoles[0].ID}}
+	buf, err := structs.Encode(structs.ACLRolesDeleteByIDRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Check that the first ACL role has been deleted, whilst the other is
+	// still available.
+	ws := memdb.NewWatchSet()
+	out, err := fsm.State().GetACLRoleByID(ws, aclRoles[0].ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	out, err = fsm.State().GetACLRoleByID(ws, aclRoles[1].ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
+func TestFSM_ApplyACLAuthMethodsUpsert(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	// Generate the upsert request and apply the change.
+	req := structs.ACLAuthMethodsUpsertRequest{
+		AuthMethods: []*structs.ACLAuthMethod{mock.ACLAuthMethod(), mock.ACLAuthMethod()},
+	}
+	buf, err := structs.Encode(structs.ACLAuthMethodsUpsertRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Read out both ACL auth methods and perform an equality check using the
+	// hash.
+	ws := memdb.NewWatchSet()
+	out, err := fsm.State().GetACLAuthMethodByName(ws, req.AuthMethods[0].Name)
+	require.NoError(t, err)
+	require.Equal(t, req.AuthMethods[0].Hash, out.Hash)
+
+	out, err = fsm.State().GetACLAuthMethodByName(ws, req.AuthMethods[1].Name)
+	require.NoError(t, err)
+	require.Equal(t, req.AuthMethods[1].Hash, out.Hash)
+}
+
+func TestFSM_ApplyACLAuthMethodsDelete(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	// Generate and upsert two ACL auth methods.
+	authMethods := []*structs.ACLAuthMethod{mock.ACLAuthMethod(), mock.ACLAuthMethod()}
+	require.NoError(t, fsm.State().UpsertACLAuthMethods(structs.MsgTypeTestSetup, 10, authMethods))
+
+	// Build and apply our message.
+	req := structs.ACLAuthMethodsDeleteRequest{Names: []string{authMethods[0].Name}}
+	buf, err := structs.Encode(structs.ACLAuthMethodsDeleteRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Check that the first ACL auth method has been deleted, whilst the other
+	// is still available.
+	ws := memdb.NewWatchSet()
+	out, err := fsm.State().GetACLAuthMethodByName(ws, authMethods[0].Name)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	out, err = fsm.State().GetACLAuthMethodByName(ws, authMethods[1].Name)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
+func TestFSM_ApplyACLBindingRulesUpsert(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	// Create the auth method our ACL binding rules link to.
+	authMethod := mock.ACLAuthMethod()
+	authMethod.Name = "auth0"
+	require.NoError(t, fsm.State().UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod}))
+
+	// Generate the upsert request and apply the change.
+	req := structs.ACLBindingRulesUpsertRequest{
+		ACLBindingRules: []*structs.ACLBindingRule{mock.ACLBindingRule(), mock.ACLBindingRule()},
+	}
+	buf, err := structs.Encode(structs.ACLBindingRulesUpsertRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Read out both ACL binding rules and perform an equality check using the
+	// hash.
+	ws := memdb.NewWatchSet()
+	out, err := fsm.State().GetACLBindingRule(ws, req.ACLBindingRules[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, req.ACLBindingRules[0].Hash, out.Hash)
+
+	out, err = fsm.State().GetACLBindingRule(ws, req.ACLBindingRules[1].ID)
+	require.NoError(t, err)
+	require.Equal(t, req.ACLBindingRules[1].Hash, out.Hash)
+}
+
+func TestFSM_ApplyACLBindingRulesDelete(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	// Generate and upsert two ACL binding rules.
+	bindingRules := []*structs.ACLBindingRule{mock.ACLBindingRule(), mock.ACLBindingRule()}
+	require.NoError(t, fsm.State().UpsertACLBindingRules(structs.MsgTypeTestSetup, 10, bindingRules, true))
+
+	// Build and apply our message.
+	req := structs.ACLBindingRulesDeleteRequest{ACLBindingRuleIDs: []string{bindingRules[0].ID}}
+	buf, err := structs.Encode(structs.ACLBindingRulesDeleteRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Check that the first ACL binding rule has been deleted, whilst the
+	// other is still available.
+	ws := memdb.NewWatchSet()
+	out, err := fsm.State().GetACLBindingRule(ws, bindingRules[0].ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	out, err = fsm.State().GetACLBindingRule(ws, bindingRules[1].ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
+func TestFSM_ApplyNodePoolUpsert(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	// Generate the upsert request and apply the change.
+	req := structs.NodePoolUpsertRequest{
+		NodePools: []*structs.NodePool{mock.NodePool(), mock.NodePool()},
+	}
+	buf, err := structs.Encode(structs.NodePoolUpsertRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Read out both node pools and perform an equality check using the hash.
+	ws := memdb.NewWatchSet()
+	out, err := fsm.State().NodePoolByName(ws, req.NodePools[0].Name)
+	require.NoError(t, err)
+	require.Equal(t, req.NodePools[0].Hash, out.Hash)
+
+	out, err = fsm.State().NodePoolByName(ws, req.NodePools[1].Name)
+	require.NoError(t, err)
+	require.Equal(t, req.NodePools[1].Hash, out.Hash)
+}
+
+func TestFSM_ApplyNodePoolDelete(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	// Generate and upsert two node pools.
+	pools := []*structs.NodePool{mock.NodePool(), mock.NodePool()}
+	require.NoError(t, fsm.State().UpsertNodePools(structs.MsgTypeTestSetup, 10, pools))
+
+	// Build and apply our message.
+	req := structs.NodePoolDeleteRequest{Names: []string{pools[0].Name}}
+	buf, err := structs.Encode(structs.NodePoolDeleteRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Check that the first node pool has been deleted, whilst the other is
+	// still available.
+	ws := memdb.NewWatchSet()
+	out, err := fsm.State().NodePoolByName(ws, pools[0].Name)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	out, err = fsm.State().NodePoolByName(ws, pools[1].Name)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
+func TestFSM_SnapshotRestore_NodePools(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create our initial FSM which will be snapshotted.
+	fsm := testFSM(t)
+	testState := fsm.State()
+
+	// Generate and upsert some node pools, and modify the default pool.
+	defaultPool, err := testState.NodePoolByName(nil, structs.NodePoolDefault)
+	require.NoError(t, err)
+	defaultPool = defaultPool.Copy()
+	defaultPool.Description = "modified default pool"
+	defaultPool.SetHash()
+
+	pools := []*structs.NodePool{mock.NodePool(), mock.NodePool(), defaultPool}
+	require.NoError(t, testState.UpsertNodePools(structs.MsgTypeTestSetup, 10, pools))
+
+	// Perform a snapshot restore.
+	restoredFSM := testSnapshotRestore(t, fsm)
+	restoredState := restoredFSM.State()
+
+	// Ensure the node pools, including the modified built-in pool, have
+	// been restored.
+	for _, pool := range pools {
+		out, err := restoredState.NodePoolByName(nil, pool.Name)
+		require.NoError(t, err)
+		require.Equal(t, pool, out)
+	}
+}
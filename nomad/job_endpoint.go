@@ -73,6 +73,7 @@ func NewJobEndpoints(s *Server) *Job {
 			jobVaultHook{srv: s},
 			jobNamespaceConstraintCheckHook{srv: s},
 			jobValidate{},
+			&jobNodePoolValidate{srv: s},
 			&memoryOversubscriptionValidate{srv: s},
 		},
 	}
@@ -774,6 +775,87 @@ func (j *Job) Evaluate(args *structs.JobEvaluateRequest, reply *structs.JobRegis
 	return nil
 }
 
+// Rebalance is used to create an evaluation which migrates the running
+// allocations of a service job that would score better on other nodes.
+func (j *Job) Rebalance(args *structs.JobRebalanceRequest, reply *structs.JobRegisterResponse) error {
+	if done, err := j.srv.forward("Job.Rebalance", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "rebalance"}, time.Now())
+
+	// Check for submit-job permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate the arguments
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID for rebalance")
+	}
+
+	// Lookup the job
+	snap, err := j.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	job, err := snap.JobByID(nil, args.RequestNamespace(), args.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return fmt.Errorf("job not found")
+	}
+
+	if job.Type != structs.JobTypeService {
+		return fmt.Errorf("can't rebalance %s job", job.Type)
+	} else if job.IsPeriodic() {
+		return fmt.Errorf("can't rebalance periodic job")
+	} else if job.IsParameterized() {
+		return fmt.Errorf("can't rebalance parameterized job")
+	} else if job.Stopped() {
+		return fmt.Errorf("can't rebalance stopped job")
+	}
+
+	eval := newRebalanceEval(job)
+	update := &structs.EvalUpdateRequest{
+		Evals:        []*structs.Evaluation{eval},
+		WriteRequest: structs.WriteRequest{Region: args.Region},
+	}
+
+	// Commit this evaluation via Raft
+	_, evalIndex, err := j.srv.raftApply(structs.EvalUpdateRequestType, update)
+	if err != nil {
+		j.logger.Error("eval create failed", "error", err, "method", "rebalance")
+		return err
+	}
+
+	// Setup the reply
+	reply.EvalID = eval.ID
+	reply.EvalCreateIndex = evalIndex
+	reply.JobModifyIndex = job.ModifyIndex
+	reply.Index = evalIndex
+	return nil
+}
+
+// newRebalanceEval returns a rebalance evaluation for the job.
+func newRebalanceEval(job *structs.Job) *structs.Evaluation {
+	now := time.Now().UnixNano()
+	return &structs.Evaluation{
+		ID:             uuid.Generate(),
+		Namespace:      job.Namespace,
+		Priority:       job.Priority,
+		Type:           job.Type,
+		TriggeredBy:    structs.EvalTriggerRebalance,
+		JobID:          job.ID,
+		JobModifyIndex: job.ModifyIndex,
+		Status:         structs.EvalStatusPending,
+		CreateTime:     now,
+		ModifyTime:     now,
+	}
+}
+
 // Deregister is used to remove a job the cluster.
 func (j *Job) Deregister(args *structs.JobDeregisterRequest, reply *structs.JobDeregisterResponse) error {
 	if done, err := j.srv.forward("Job.Deregister", args, args, reply); done {
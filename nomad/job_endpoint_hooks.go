@@ -274,6 +274,26 @@ func (jobValidate) Validate(job *structs.Job) (warnings []error, err error) {
 	return warnings, validationErrors.ErrorOrNil()
 }
 
+// jobNodePoolValidate ensures the node pool the job is scoped to exists.
+type jobNodePoolValidate struct {
+	srv *Server
+}
+
+func (*jobNodePoolValidate) Name() string {
+	return "node_pool"
+}
+
+func (v *jobNodePoolValidate) Validate(job *structs.Job) (warnings []error, err error) {
+	pool, err := v.srv.State().NodePoolByName(nil, job.NodePool)
+	if err != nil {
+		return nil, err
+	}
+	if pool == nil {
+		return nil, fmt.Errorf("job %q is in nonexistent node pool %q", job.ID, job.NodePool)
+	}
+	return nil, nil
+}
+
 type memoryOversubscriptionValidate struct {
 	srv *Server
 }
@@ -288,6 +308,13 @@ func (v *memoryOversubscriptionValidate) Validate(job *structs.Job) (warnings []
 		return nil, err
 	}
 
+	// The node pool of the job may override the cluster configuration.
+	pool, err := v.srv.State().NodePoolByName(nil, job.NodePool)
+	if err != nil {
+		return nil, err
+	}
+	c = c.WithNodePool(pool)
+
 	if c != nil && c.MemoryOversubscriptionEnabled {
 		return nil, nil
 	}
@@ -3157,6 +3157,104 @@ func TestJobEndpoint_Evaluate_ACL(t *testing.T) {
 	require.NotZero(eval.ModifyTime)
 }
 
+func TestJobEndpoint_Rebalance(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+	batchJob := mock.BatchJob()
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1001, batchJob))
+	periodicJob := mock.PeriodicJob()
+	periodicJob.Type = structs.JobTypeService
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1002, periodicJob))
+
+	req := &structs.JobRebalanceRequest{
+		JobID: job.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Rebalance", req, &resp))
+	require.NotZero(resp.Index)
+
+	eval, err := state.EvalByID(nil, resp.EvalID)
+	require.NoError(err)
+	require.NotNil(eval)
+	require.Equal(resp.EvalCreateIndex, eval.CreateIndex)
+	require.Equal(structs.EvalTriggerRebalance, eval.TriggeredBy)
+	require.Equal(job.ID, eval.JobID)
+	require.Equal(job.Type, eval.Type)
+	require.Equal(job.Priority, eval.Priority)
+	require.Equal(structs.EvalStatusPending, eval.Status)
+
+	// Only service jobs can be rebalanced
+	req.JobID = batchJob.ID
+	err = msgpackrpc.CallWithCodec(codec, "Job.Rebalance", req, &resp)
+	require.EqualError(err, "can't rebalance batch job")
+
+	req.JobID = periodicJob.ID
+	err = msgpackrpc.CallWithCodec(codec, "Job.Rebalance", req, &resp)
+	require.EqualError(err, "can't rebalance periodic job")
+
+	req.JobID = "unknown"
+	err = msgpackrpc.CallWithCodec(codec, "Job.Rebalance", req, &resp)
+	require.EqualError(err, "job not found")
+}
+
+func TestJobEndpoint_Rebalance_ACL(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 300, job))
+
+	req := &structs.JobRebalanceRequest{
+		JobID: job.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Attempt without a token
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Rebalance", req, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Attempt with a token that can only read the job
+	readToken := mock.CreatePolicyAndToken(t, state, 1003, "test-read",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	req.AuthToken = readToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Job.Rebalance", req, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Rebalance with a token that can submit the job
+	submitToken := mock.CreatePolicyAndToken(t, state, 1005, "test-submit",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+	req.AuthToken = submitToken.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Rebalance", req, &resp))
+	require.NotEmpty(resp.EvalID)
+}
+
 func TestJobEndpoint_Evaluate_Periodic(t *testing.T) {
 	ci.Parallel(t)
 
@@ -320,3 +320,74 @@ func (k *Keyring) Delete(args *structs.KeyringDeleteRootKeyRequest, reply *struc
 	reply.Index = index
 	return nil
 }
+
+// ListPublic lists the public keys used to verify workload identities. It
+// requires no authentication, so that third parties can verify workload
+// identities without a Nomad ACL token.
+func (k *Keyring) ListPublic(args *structs.GenericRequest, reply *structs.KeyringListPublicResponse) error {
+	if done, err := k.srv.forward("Keyring.ListPublic", args, args, reply); done {
+		return err
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "list_public"}, time.Now())
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+
+			// retrieve all the key metadata
+			snap, err := k.srv.fsm.State().Snapshot()
+			if err != nil {
+				return err
+			}
+			iter, err := snap.RootKeyMetas(ws)
+			if err != nil {
+				return err
+			}
+
+			pubKeys := []*structs.KeyringPublicKey{}
+			for {
+				raw := iter.Next()
+				if raw == nil {
+					break
+				}
+				keyMeta := raw.(*structs.RootKeyMeta)
+
+				// inactive and deprecated keys may still have signed the
+				// identity of a live allocation, so they're all included
+				pubKey, err := k.encrypter.GetPublicKey(keyMeta.KeyID)
+				if err != nil {
+					// the key material hasn't been replicated to this
+					// server yet
+					continue
+				}
+				pubKeys = append(pubKeys, pubKey)
+			}
+			reply.PublicKeys = pubKeys
+			return k.srv.replySetIndex(state.TableRootKeyMeta, &reply.QueryMeta)
+		},
+	}
+	return k.srv.blockingRPC(&opts)
+}
+
+// GetOIDCDiscovery returns the OIDC discovery document for workload
+// identities. It requires no authentication, so that third parties can
+// verify workload identities without a Nomad ACL token.
+func (k *Keyring) GetOIDCDiscovery(args *structs.GenericRequest, reply *structs.KeyringGetOIDCDiscoveryResponse) error {
+	if done, err := k.srv.forward("Keyring.GetOIDCDiscovery", args, args, reply); done {
+		return err
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "get_oidc_discovery"}, time.Now())
+
+	if k.srv.config.OIDCIssuer == "" {
+		return structs.NewErrRPCCoded(404,
+			"OIDC discovery is disabled because no oidc_issuer is configured")
+	}
+
+	reply.OIDCDiscovery = structs.NewOIDCDiscoveryConfig(k.srv.config.OIDCIssuer)
+	k.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
@@ -1,14 +1,18 @@
 package nomad
 
 import (
+	"crypto/ed25519"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
 
+	jwt "github.com/golang-jwt/jwt/v4"
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 )
@@ -292,3 +296,159 @@ func TestKeyringEndpoint_Rotate(t *testing.T) {
 	gotKey := getResp.Key
 	require.Len(t, gotKey.Key, 32)
 }
+
+// TestKeyringEndpoint_ListPublic exercises listing the public keys used to
+// verify workload identities
+func TestKeyringEndpoint_ListPublic(t *testing.T) {
+
+	ci.Parallel(t)
+	srv, rootToken, shutdown := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	// Rotate the key so that there's both an active and an inactive key
+
+	rotateReq := &structs.KeyringRotateRootKeyRequest{
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: rootToken.SecretID,
+		},
+	}
+	var rotateResp structs.KeyringRotateRootKeyResponse
+	err := msgpackrpc.CallWithCodec(codec, "Keyring.Rotate", rotateReq, &rotateResp)
+	require.NoError(t, err)
+
+	// List the public keys without a token
+
+	listReq := &structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: "global",
+		},
+	}
+	var listResp structs.KeyringListPublicResponse
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.ListPublic", listReq, &listResp)
+	require.NoError(t, err)
+	require.Equal(t, rotateResp.Index, listResp.Index)
+	require.Len(t, listResp.PublicKeys, 2)
+
+	pubKeys := map[string]*structs.KeyringPublicKey{}
+	for _, pubKey := range listResp.PublicKeys {
+		require.Equal(t, structs.PubKeyAlgEdDSA, pubKey.Algorithm)
+		require.Equal(t, structs.PubKeyUseSig, pubKey.Use)
+		require.Len(t, pubKey.PublicKey, ed25519.PublicKeySize)
+		pubKeys[pubKey.KeyID] = pubKey
+	}
+	require.Contains(t, pubKeys, rotateResp.Key.KeyID)
+
+	// The public key can verify a workload identity signed by the server
+
+	alloc := mock.Alloc()
+	claims := alloc.ToTaskIdentityClaims(alloc.Job, "web")
+	token, err := srv.encrypter.SignClaims(claims)
+	require.NoError(t, err)
+
+	gotClaims := &structs.IdentityClaims{}
+	_, err = jwt.ParseWithClaims(token, gotClaims, func(token *jwt.Token) (interface{}, error) {
+		pubKey, ok := pubKeys[token.Header["kid"].(string)]
+		if !ok {
+			return nil, fmt.Errorf("unknown key")
+		}
+		return ed25519.PublicKey(pubKey.PublicKey), nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, alloc.ID, gotClaims.AllocationID)
+}
+
+// TestKeyringEndpoint_GetOIDCDiscovery exercises fetching the OIDC
+// discovery document
+func TestKeyringEndpoint_GetOIDCDiscovery(t *testing.T) {
+
+	ci.Parallel(t)
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	req := &structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: "global",
+		},
+	}
+	var resp structs.KeyringGetOIDCDiscoveryResponse
+	err := msgpackrpc.CallWithCodec(codec, "Keyring.GetOIDCDiscovery", req, &resp)
+	require.EqualError(t, err,
+		"RPC Error:: 404,OIDC discovery is disabled because no oidc_issuer is configured")
+
+	srv2, shutdown2 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.OIDCIssuer = "https://nomad.example.com/"
+	})
+	defer shutdown2()
+	testutil.WaitForLeader(t, srv2.RPC)
+	codec = rpcClient(t, srv2)
+
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.GetOIDCDiscovery", req, &resp)
+	require.NoError(t, err)
+	require.Equal(t, "https://nomad.example.com/", resp.OIDCDiscovery.Issuer)
+	require.Equal(t, "https://nomad.example.com/.well-known/jwks.json", resp.OIDCDiscovery.JWKS)
+	require.Equal(t, []string{structs.PubKeyAlgEdDSA}, resp.OIDCDiscovery.IDTokenAlgs)
+}
+
+// TestKeyringEndpoint_OIDCDiscovery_VerifyIdentity exercises verifying a
+// signed workload identity the way an OIDC relying party would, using only
+// the discovery document and the public keys
+func TestKeyringEndpoint_OIDCDiscovery_VerifyIdentity(t *testing.T) {
+
+	ci.Parallel(t)
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.OIDCIssuer = "https://nomad.example.com"
+		c.OIDCAudience = []string{"vault.example.com"}
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	req := &structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: "global",
+		},
+	}
+	var discoveryResp structs.KeyringGetOIDCDiscoveryResponse
+	err := msgpackrpc.CallWithCodec(codec, "Keyring.GetOIDCDiscovery", req, &discoveryResp)
+	require.NoError(t, err)
+	discovery := discoveryResp.OIDCDiscovery
+	require.Equal(t, []string{"public"}, discovery.Subjects)
+
+	var listResp structs.KeyringListPublicResponse
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.ListPublic", req, &listResp)
+	require.NoError(t, err)
+
+	alloc := mock.Alloc()
+	require.NoError(t, srv.signAllocIdentities(alloc.Job, []*structs.Allocation{alloc}))
+	token := alloc.SignedIdentities["web"]
+	require.NotEmpty(t, token)
+
+	claims := &jwt.RegisteredClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods(discovery.IDTokenAlgs))
+	_, err = parser.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		for _, pubKey := range listResp.PublicKeys {
+			if pubKey.KeyID == token.Header["kid"] {
+				return ed25519.PublicKey(pubKey.PublicKey), nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key")
+	})
+	require.NoError(t, err)
+
+	require.True(t, claims.VerifyIssuer(discovery.Issuer, true))
+	require.True(t, claims.VerifyAudience("vault.example.com", true))
+	require.Equal(t,
+		fmt.Sprintf("%s:%s:%s:web", alloc.Namespace, alloc.JobID, alloc.TaskGroup),
+		claims.Subject)
+}
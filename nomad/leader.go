@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -36,6 +37,11 @@ const (
 	// possible loss of leadership event if we are unable to get a barrier
 	// while leader.
 	barrierWriteTimeout = 2 * time.Minute
+
+	// rootKeyRotationRetryInterval is how long the leader waits before
+	// checking the keyring again if a rotation fails or the keyring has not
+	// yet been initialized.
+	rootKeyRotationRetryInterval = 1 * time.Minute
 )
 
 var minAutopilotVersion = version.Must(version.NewVersion("0.8.0"))
@@ -365,6 +371,9 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Periodically publish job status metrics
 	go s.publishJobStatusMetrics(stopCh)
 
+	// Periodically rotate the root encryption key
+	go s.rotateRootKeys(stopCh)
+
 	// Setup the heartbeat timers. This is done both when starting up or when
 	// a leader fail over happens. Since the timers are maintained by the leader
 	// node, effectively this means all the timers are renewed at the time of failover.
@@ -379,11 +388,21 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 		return err
 	}
 
+	// Setup the secure variable lock timers, which like the heartbeat timers
+	// are renewed on failover.
+	if err := s.svLocks.initializeTimers(); err != nil {
+		s.logger.Error("secure variable lock timer setup failed", "error", err)
+		return err
+	}
+
 	// Start replication of ACLs and Policies if they are enabled,
 	// and we are not the authoritative region.
 	if s.config.ACLEnabled && s.config.Region != s.config.AuthoritativeRegion {
 		go s.replicateACLPolicies(stopCh)
 		go s.replicateACLTokens(stopCh)
+		go s.replicateACLRoles(stopCh)
+		go s.replicateACLAuthMethods(stopCh)
+		go s.replicateACLBindingRules(stopCh)
 		go s.replicateNamespaces(stopCh)
 	}
 
@@ -767,6 +786,10 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 	defer csiVolumeClaimGC.Stop()
 	oneTimeTokenGC := time.NewTicker(s.config.OneTimeTokenGCInterval)
 	defer oneTimeTokenGC.Stop()
+	localTokenExpiredGC := time.NewTicker(s.config.ACLTokenExpirationGCInterval)
+	defer localTokenExpiredGC.Stop()
+	globalTokenExpiredGC := time.NewTicker(s.config.ACLTokenExpirationGCInterval)
+	defer globalTokenExpiredGC.Stop()
 	rootKeyGC := time.NewTicker(s.config.RootKeyGCInterval)
 	defer rootKeyGC.Stop()
 	secureVariablesRekey := time.NewTicker(s.config.SecureVariablesRekeyInterval)
@@ -819,9 +842,17 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 			if index, ok := getLatest(); ok {
 				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobOneTimeTokenGC, index))
 			}
+		case <-localTokenExpiredGC.C:
+			if index, ok := getLatest(); ok {
+				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobLocalTokenExpiredGC, index))
+			}
+		case <-globalTokenExpiredGC.C:
+			if index, ok := getLatest(); ok {
+				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobGlobalTokenExpiredGC, index))
+			}
 		case <-rootKeyGC.C:
 			if index, ok := getLatest(); ok {
-				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobRootKeyRotateOrGC, index))
+				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobRootKeyGC, index))
 			}
 		case <-secureVariablesRekey.C:
 			if index, ok := getLatest(); ok {
@@ -1163,6 +1194,7 @@ func (s *Server) revokeLeadership() error {
 		s.logger.Error("clearing heartbeat timers failed", "error", err)
 		return err
 	}
+	s.svLocks.clearAllTimers()
 
 	// Unpause our worker if we paused previously
 	s.handlePausableWorkers(false)
@@ -1646,6 +1678,436 @@ func diffACLTokens(store *state.StateStore, minIndex uint64, remoteList []*struc
 	return
 }
 
+// replicateACLRoles is used to replicate ACL roles from the authoritative
+// region to this region.
+func (s *Server) replicateACLRoles(stopCh chan struct{}) {
+	req := structs.ACLRolesListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:     s.config.AuthoritativeRegion,
+			AllowStale: true,
+		},
+	}
+	limiter := rate.NewLimiter(replicationRateLimit, int(replicationRateLimit))
+	s.logger.Debug("starting ACL role replication from authoritative region", "authoritative_region", req.Region)
+
+START:
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+			// Rate limit how often we attempt replication
+			limiter.Wait(context.Background())
+
+			// Fetch the list of roles
+			var resp structs.ACLRolesListResponse
+			req.AuthToken = s.ReplicationToken()
+			err := s.forwardRegion(s.config.AuthoritativeRegion,
+				structs.ACLListRolesRPCMethod, &req, &resp)
+			if err != nil {
+				s.logger.Error("failed to fetch ACL roles from authoritative region", "error", err)
+				goto ERR_WAIT
+			}
+
+			// Perform a two-way diff
+			delete, update := diffACLRoles(s.State(), req.MinQueryIndex, resp.ACLRoles)
+
+			// Delete roles that should not exist
+			if len(delete) > 0 {
+				args := &structs.ACLRolesDeleteRequest{
+					ACLRoleIDs: delete,
+				}
+				_, _, err := s.raftApply(structs.ACLRolesDeleteByIDRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to delete ACL roles", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Fetch any outdated roles
+			var fetched []*structs.ACLRole
+			if len(update) > 0 {
+				req := structs.ACLRolesByIDRequest{
+					ACLRoleIDs: update,
+					QueryOptions: structs.QueryOptions{
+						Region:        s.config.AuthoritativeRegion,
+						AuthToken:     s.ReplicationToken(),
+						AllowStale:    true,
+						MinQueryIndex: resp.Index - 1,
+					},
+				}
+				var reply structs.ACLRolesByIDResponse
+				if err := s.forwardRegion(s.config.AuthoritativeRegion,
+					structs.ACLGetRolesByIDRPCMethod, &req, &reply); err != nil {
+					s.logger.Error("failed to fetch ACL roles from authoritative region", "error", err)
+					goto ERR_WAIT
+				}
+				for _, role := range reply.ACLRoles {
+					fetched = append(fetched, role)
+				}
+			}
+
+			// Update local roles. The policies linked to the roles may not
+			// yet have been replicated, so we skip the policy link check.
+			if len(fetched) > 0 {
+				args := &structs.ACLRolesUpsertRequest{
+					ACLRoles:             fetched,
+					AllowMissingPolicies: true,
+				}
+				_, _, err := s.raftApply(structs.ACLRolesUpsertRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to update ACL roles", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Update the minimum query index, blocks until there
+			// is a change.
+			req.MinQueryIndex = resp.Index
+		}
+	}
+
+ERR_WAIT:
+	select {
+	case <-time.After(s.config.ReplicationBackoff):
+		goto START
+	case <-stopCh:
+		return
+	}
+}
+
+// diffACLRoles is used to perform a two-way diff between the local ACL roles
+// and the remote roles to determine which roles need to be deleted or
+// updated.
+func diffACLRoles(store *state.StateStore, minIndex uint64, remoteList []*structs.ACLRoleListStub) (delete []string, update []string) {
+	// Construct a set of the local and remote roles
+	local := make(map[string][]byte)
+	remote := make(map[string]struct{})
+
+	// Add all the local roles
+	iter, err := store.GetACLRoles(nil)
+	if err != nil {
+		panic("failed to iterate local ACL roles")
+	}
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+		role := raw.(*structs.ACLRole)
+		local[role.ID] = role.Hash
+	}
+
+	// Iterate over the remote roles
+	for _, rr := range remoteList {
+		remote[rr.ID] = struct{}{}
+
+		// Check if the role is missing locally
+		if localHash, ok := local[rr.ID]; !ok {
+			update = append(update, rr.ID)
+
+			// Check if role is newer remotely and there is a hash mis-match.
+		} else if rr.ModifyIndex > minIndex && !bytes.Equal(localHash, rr.Hash) {
+			update = append(update, rr.ID)
+		}
+	}
+
+	// Check if local role should be deleted
+	for lr := range local {
+		if _, ok := remote[lr]; !ok {
+			delete = append(delete, lr)
+		}
+	}
+	return
+}
+
+// replicateACLAuthMethods is used to replicate ACL auth methods from the
+// authoritative region to this region.
+func (s *Server) replicateACLAuthMethods(stopCh chan struct{}) {
+	req := structs.ACLAuthMethodListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:     s.config.AuthoritativeRegion,
+			AllowStale: true,
+		},
+	}
+	limiter := rate.NewLimiter(replicationRateLimit, int(replicationRateLimit))
+	s.logger.Debug("starting ACL auth method replication from authoritative region", "authoritative_region", req.Region)
+
+START:
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+			// Rate limit how often we attempt replication
+			limiter.Wait(context.Background())
+
+			// Fetch the list of auth methods
+			var resp structs.ACLAuthMethodListResponse
+			req.AuthToken = s.ReplicationToken()
+			err := s.forwardRegion(s.config.AuthoritativeRegion,
+				structs.ACLListAuthMethodsRPCMethod, &req, &resp)
+			if err != nil {
+				s.logger.Error("failed to fetch ACL auth methods from authoritative region", "error", err)
+				goto ERR_WAIT
+			}
+
+			// Perform a two-way diff
+			delete, update := diffACLAuthMethods(s.State(), req.MinQueryIndex, resp.AuthMethods)
+
+			// Delete auth methods that should not exist
+			if len(delete) > 0 {
+				args := &structs.ACLAuthMethodsDeleteRequest{
+					Names: delete,
+				}
+				_, _, err := s.raftApply(structs.ACLAuthMethodsDeleteRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to delete ACL auth methods", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Fetch any outdated auth methods
+			var fetched []*structs.ACLAuthMethod
+			if len(update) > 0 {
+				req := structs.ACLAuthMethodsGetRequest{
+					Names: update,
+					QueryOptions: structs.QueryOptions{
+						Region:        s.config.AuthoritativeRegion,
+						AuthToken:     s.ReplicationToken(),
+						AllowStale:    true,
+						MinQueryIndex: resp.Index - 1,
+					},
+				}
+				var reply structs.ACLAuthMethodsGetResponse
+				if err := s.forwardRegion(s.config.AuthoritativeRegion,
+					structs.ACLGetAuthMethodsRPCMethod, &req, &reply); err != nil {
+					s.logger.Error("failed to fetch ACL auth methods from authoritative region", "error", err)
+					goto ERR_WAIT
+				}
+				for _, method := range reply.AuthMethods {
+					fetched = append(fetched, method)
+				}
+			}
+
+			// Update local auth methods
+			if len(fetched) > 0 {
+				args := &structs.ACLAuthMethodsUpsertRequest{
+					AuthMethods: fetched,
+				}
+				_, _, err := s.raftApply(structs.ACLAuthMethodsUpsertRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to update ACL auth methods", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Update the minimum query index, blocks until there
+			// is a change.
+			req.MinQueryIndex = resp.Index
+		}
+	}
+
+ERR_WAIT:
+	select {
+	case <-time.After(s.config.ReplicationBackoff):
+		goto START
+	case <-stopCh:
+		return
+	}
+}
+
+// diffACLAuthMethods is used to perform a two-way diff between the local ACL
+// auth methods and the remote auth methods to determine which need to be
+// deleted or updated.
+func diffACLAuthMethods(store *state.StateStore, minIndex uint64, remoteList []*structs.ACLAuthMethodStub) (delete []string, update []string) {
+	// Construct a set of the local and remote auth methods
+	local := make(map[string][]byte)
+	remote := make(map[string]struct{})
+
+	// Add all the local auth methods
+	iter, err := store.GetACLAuthMethods(nil)
+	if err != nil {
+		panic("failed to iterate local ACL auth methods")
+	}
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+		method := raw.(*structs.ACLAuthMethod)
+		local[method.Name] = method.Hash
+	}
+
+	// Iterate over the remote auth methods
+	for _, rm := range remoteList {
+		remote[rm.Name] = struct{}{}
+
+		// Check if the auth method is missing locally
+		if localHash, ok := local[rm.Name]; !ok {
+			update = append(update, rm.Name)
+
+			// Check if auth method is newer remotely and there is a hash
+			// mis-match.
+		} else if rm.ModifyIndex > minIndex && !bytes.Equal(localHash, rm.Hash) {
+			update = append(update, rm.Name)
+		}
+	}
+
+	// Check if local auth method should be deleted
+	for lm := range local {
+		if _, ok := remote[lm]; !ok {
+			delete = append(delete, lm)
+		}
+	}
+	return
+}
+
+// replicateACLBindingRules is used to replicate ACL binding rules from the
+// authoritative region to this region.
+func (s *Server) replicateACLBindingRules(stopCh chan struct{}) {
+	req := structs.ACLBindingRulesListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:     s.config.AuthoritativeRegion,
+			AllowStale: true,
+		},
+	}
+	limiter := rate.NewLimiter(replicationRateLimit, int(replicationRateLimit))
+	s.logger.Debug("starting ACL binding rule replication from authoritative region", "authoritative_region", req.Region)
+
+START:
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+			// Rate limit how often we attempt replication
+			limiter.Wait(context.Background())
+
+			// Fetch the list of binding rules
+			var resp structs.ACLBindingRulesListResponse
+			req.AuthToken = s.ReplicationToken()
+			err := s.forwardRegion(s.config.AuthoritativeRegion,
+				structs.ACLListBindingRulesRPCMethod, &req, &resp)
+			if err != nil {
+				s.logger.Error("failed to fetch ACL binding rules from authoritative region", "error", err)
+				goto ERR_WAIT
+			}
+
+			// Perform a two-way diff
+			delete, update := diffACLBindingRules(s.State(), req.MinQueryIndex, resp.ACLBindingRules)
+
+			// Delete binding rules that should not exist
+			if len(delete) > 0 {
+				args := &structs.ACLBindingRulesDeleteRequest{
+					ACLBindingRuleIDs: delete,
+				}
+				_, _, err := s.raftApply(structs.ACLBindingRulesDeleteRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to delete ACL binding rules", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Fetch any outdated binding rules
+			var fetched []*structs.ACLBindingRule
+			if len(update) > 0 {
+				req := structs.ACLBindingRulesRequest{
+					ACLBindingRuleIDs: update,
+					QueryOptions: structs.QueryOptions{
+						Region:        s.config.AuthoritativeRegion,
+						AuthToken:     s.ReplicationToken(),
+						AllowStale:    true,
+						MinQueryIndex: resp.Index - 1,
+					},
+				}
+				var reply structs.ACLBindingRulesResponse
+				if err := s.forwardRegion(s.config.AuthoritativeRegion,
+					structs.ACLGetBindingRulesRPCMethod, &req, &reply); err != nil {
+					s.logger.Error("failed to fetch ACL binding rules from authoritative region", "error", err)
+					goto ERR_WAIT
+				}
+				for _, rule := range reply.ACLBindingRules {
+					fetched = append(fetched, rule)
+				}
+			}
+
+			// Update local binding rules. The auth methods linked to the
+			// rules may not yet have been replicated, so we skip the auth
+			// method link check.
+			if len(fetched) > 0 {
+				args := &structs.ACLBindingRulesUpsertRequest{
+					ACLBindingRules:         fetched,
+					AllowMissingAuthMethods: true,
+				}
+				_, _, err := s.raftApply(structs.ACLBindingRulesUpsertRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to update ACL binding rules", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Update the minimum query index, blocks until there
+			// is a change.
+			req.MinQueryIndex = resp.Index
+		}
+	}
+
+ERR_WAIT:
+	select {
+	case <-time.After(s.config.ReplicationBackoff):
+		goto START
+	case <-stopCh:
+		return
+	}
+}
+
+// diffACLBindingRules is used to perform a two-way diff between the local ACL
+// binding rules and the remote binding rules to determine which need to be
+// deleted or updated.
+func diffACLBindingRules(store *state.StateStore, minIndex uint64, remoteList []*structs.ACLBindingRuleListStub) (delete []string, update []string) {
+	// Construct a set of the local and remote binding rules
+	local := make(map[string][]byte)
+	remote := make(map[string]struct{})
+
+	// Add all the local binding rules
+	iter, err := store.GetACLBindingRules(nil)
+	if err != nil {
+		panic("failed to iterate local ACL binding rules")
+	}
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+		rule := raw.(*structs.ACLBindingRule)
+		local[rule.ID] = rule.Hash
+	}
+
+	// Iterate over the remote binding rules
+	for _, rr := range remoteList {
+		remote[rr.ID] = struct{}{}
+
+		// Check if the binding rule is missing locally
+		if localHash, ok := local[rr.ID]; !ok {
+			update = append(update, rr.ID)
+
+			// Check if binding rule is newer remotely and there is a hash
+			// mis-match.
+		} else if rr.ModifyIndex > minIndex && !bytes.Equal(localHash, rr.Hash) {
+			update = append(update, rr.ID)
+		}
+	}
+
+	// Check if local binding rule should be deleted
+	for lr := range local {
+		if _, ok := remote[lr]; !ok {
+			delete = append(delete, lr)
+		}
+	}
+	return
+}
+
 // getOrCreateAutopilotConfig is used to get the autopilot config, initializing it if necessary
 func (s *Server) getOrCreateAutopilotConfig() *structs.AutopilotConfig {
 	state := s.fsm.State()
@@ -1738,6 +2200,70 @@ func (s *Server) initializeKeyring() error {
 	return nil
 }
 
+// rotateRootKeys is a long lived function which rotates the active root key
+// once it is older than the configured rotation interval. When rekeying on
+// rotation is enabled, the variables encrypted with the previous key are
+// re-encrypted in the background by the secure variables rekey core job.
+func (s *Server) rotateRootKeys(stopCh chan struct{}) {
+	interval := s.config.RootKeyRotationInterval
+	if interval <= 0 {
+		return
+	}
+
+	logger := s.logger.Named("keyring")
+	timer, stop := helper.NewSafeTimer(interval)
+	defer stop()
+
+	for {
+		wait, err := s.rotateRootKeyIfExpired(interval)
+		if err != nil {
+			logger.Error("root key rotation failed", "error", err)
+			wait = rootKeyRotationRetryInterval
+		}
+
+		timer.Reset(wait)
+		select {
+		case <-stopCh:
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// rotateRootKeyIfExpired rotates the active root key if it was created more
+// than interval ago. It returns how long to wait before the next check.
+func (s *Server) rotateRootKeyIfExpired(interval time.Duration) (time.Duration, error) {
+	keyMeta, err := s.fsm.State().GetActiveRootKeyMeta(nil)
+	if err != nil {
+		return 0, err
+	}
+	if keyMeta == nil {
+		// the keyring hasn't been initialized yet
+		return rootKeyRotationRetryInterval, nil
+	}
+
+	expiry := time.Unix(0, keyMeta.CreateTime).Add(interval)
+	if wait := time.Until(expiry); wait > 0 {
+		return wait, nil
+	}
+
+	req := &structs.KeyringRotateRootKeyRequest{
+		Full: s.config.RootKeyRekeyOnRotation,
+		WriteRequest: structs.WriteRequest{
+			Region:    s.config.Region,
+			AuthToken: s.getLeaderAcl(),
+		},
+	}
+	var resp structs.KeyringRotateRootKeyResponse
+	if err := s.RPC("Keyring.Rotate", req, &resp); err != nil {
+		return 0, err
+	}
+
+	s.logger.Named("keyring").Info("rotated root key",
+		"previous_id", keyMeta.KeyID, "id", resp.Key.KeyID, "rekey", req.Full)
+	return interval, nil
+}
+
 func (s *Server) generateClusterID() (string, error) {
 	if !ServersMeetMinimumVersion(s.Members(), minClusterIDVersion, false) {
 		s.logger.Named("core").Warn("cannot initialize cluster ID until all servers are above minimum version", "min_version", minClusterIDVersion)
@@ -1796,8 +2322,10 @@ func (s *Server) handleEvalBrokerStateChange(schedConfig *structs.SchedulerConfi
 	switch schedConfig {
 	case nil:
 		enableBrokers = !s.config.DefaultSchedulerConfig.PauseEvalBroker
+		s.evalBroker.SetFairShare(s.config.DefaultSchedulerConfig.EvalBrokerFairShare)
 	default:
 		enableBrokers = !schedConfig.PauseEvalBroker
+		s.evalBroker.SetFairShare(schedConfig.EvalBrokerFairShare)
 	}
 
 	// If the evalBroker status is changing, set the new state.
@@ -1025,6 +1025,118 @@ func TestLeader_DiffACLTokens(t *testing.T) {
 	assert.Equal(t, []string{p3.AccessorID, p4.AccessorID}, update)
 }
 
+func TestLeader_ReplicateACLAuthMethodsAndBindingRules(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.Region = "region1"
+		c.AuthoritativeRegion = "region1"
+		c.ACLEnabled = true
+	})
+	defer cleanupS1()
+	s2, _, cleanupS2 := TestACLServer(t, func(c *Config) {
+		c.Region = "region2"
+		c.AuthoritativeRegion = "region1"
+		c.ACLEnabled = true
+		c.ReplicationBackoff = 20 * time.Millisecond
+		c.ReplicationToken = root.SecretID
+	})
+	defer cleanupS2()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	// Write an auth method and binding rule to the authoritative region
+	authMethod := mock.ACLAuthMethod()
+	require.NoError(t, s1.State().UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 100, []*structs.ACLAuthMethod{authMethod}))
+
+	bindingRule := mock.ACLBindingRule()
+	bindingRule.AuthMethod = authMethod.Name
+	require.NoError(t, s1.State().UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 110, []*structs.ACLBindingRule{bindingRule}, false))
+
+	// Wait for the auth method and binding rule to replicate
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := s2.State().GetACLAuthMethodByName(nil, authMethod.Name)
+		return out != nil, err
+	}, func(err error) {
+		t.Fatalf("should replicate auth method")
+	})
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := s2.State().GetACLBindingRule(nil, bindingRule.ID)
+		return out != nil, err
+	}, func(err error) {
+		t.Fatalf("should replicate binding rule")
+	})
+}
+
+func TestLeader_DiffACLAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+
+	state := state.TestStateStore(t)
+
+	// Populate the local state
+	am1 := mock.ACLAuthMethod()
+	am2 := mock.ACLAuthMethod()
+	am3 := mock.ACLAuthMethod()
+	require.NoError(t, state.UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 100, []*structs.ACLAuthMethod{am1, am2, am3}))
+
+	// Simulate a remote list
+	am2Stub := am2.Stub()
+	am2Stub.ModifyIndex = 50 // Ignored, same index
+	am3Stub := am3.Stub()
+	am3Stub.ModifyIndex = 100 // Updated, higher index
+	am3Stub.Hash = []byte{0, 1, 2, 3}
+	am4 := mock.ACLAuthMethod()
+	remoteList := []*structs.ACLAuthMethodStub{
+		am2Stub,
+		am3Stub,
+		am4.Stub(),
+	}
+	delete, update := diffACLAuthMethods(state, 50, remoteList)
+
+	// am1 does not exist on the remote side, should delete
+	require.Equal(t, []string{am1.Name}, delete)
+
+	// am2 is un-modified - ignore. am3 modified, am4 new.
+	require.Equal(t, []string{am3.Name, am4.Name}, update)
+}
+
+func TestLeader_DiffACLBindingRules(t *testing.T) {
+	ci.Parallel(t)
+
+	state := state.TestStateStore(t)
+
+	// Populate the local state
+	br1 := mock.ACLBindingRule()
+	br2 := mock.ACLBindingRule()
+	br3 := mock.ACLBindingRule()
+	require.NoError(t, state.UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 100, []*structs.ACLBindingRule{br1, br2, br3}, true))
+
+	// Simulate a remote list
+	br2Stub := br2.Stub()
+	br2Stub.ModifyIndex = 50 // Ignored, same index
+	br3Stub := br3.Stub()
+	br3Stub.ModifyIndex = 100 // Updated, higher index
+	br3Stub.Hash = []byte{0, 1, 2, 3}
+	br4 := mock.ACLBindingRule()
+	remoteList := []*structs.ACLBindingRuleListStub{
+		br2Stub,
+		br3Stub,
+		br4.Stub(),
+	}
+	delete, update := diffACLBindingRules(state, 50, remoteList)
+
+	// br1 does not exist on the remote side, should delete
+	require.Equal(t, []string{br1.ID}, delete)
+
+	// br2 is un-modified - ignore. br3 modified, br4 new.
+	require.Equal(t, []string{br3.ID, br4.ID}, update)
+}
+
 func TestLeader_UpgradeRaftVersion(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1750,3 +1862,77 @@ func TestServer_handleEvalBrokerStateChange(t *testing.T) {
 		})
 	}
 }
+
+func TestLeader_RotateRootKeys(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent the rekey job from running
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	key0, err := store.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.NotNil(t, key0, "expected keyring to be bootstrapped")
+
+	// The bootstrapped key is newer than the rotation interval
+	wait, err := s1.rotateRootKeyIfExpired(time.Hour)
+	require.NoError(t, err)
+	require.Greater(t, wait, time.Duration(0))
+	require.LessOrEqual(t, wait, time.Hour)
+
+	key, err := store.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.Equal(t, key0.KeyID, key.KeyID, "key should not have been rotated")
+
+	// The bootstrapped key is older than the rotation interval
+	wait, err = s1.rotateRootKeyIfExpired(time.Nanosecond)
+	require.NoError(t, err)
+	require.Equal(t, time.Nanosecond, wait)
+
+	key, err = store.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.NotEqual(t, key0.KeyID, key.KeyID, "key should have been rotated")
+
+	// The previous key is marked for rekeying
+	key, err = store.RootKeyMetaByID(nil, key0.KeyID)
+	require.NoError(t, err)
+	require.True(t, key.Rekeying(), "previous key should be rekeying")
+}
+
+func TestLeader_RotateRootKeys_Periodic(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.RootKeyRotationInterval = 100 * time.Millisecond
+		c.RootKeyRekeyOnRotation = false
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	key0, err := store.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.NotNil(t, key0, "expected keyring to be bootstrapped")
+
+	testutil.WaitForResult(func() (bool, error) {
+		key, err := store.GetActiveRootKeyMeta(nil)
+		if err != nil {
+			return false, err
+		}
+		if key.KeyID == key0.KeyID {
+			return false, fmt.Errorf("key %s was not rotated", key0.KeyID)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+
+	// Without rekeying the previous key is left inactive
+	key, err := store.RootKeyMetaByID(nil, key0.KeyID)
+	require.NoError(t, err)
+	require.False(t, key.Rekeying(), "previous key should not be rekeying")
+	require.False(t, key.Active(), "previous key should be inactive")
+}
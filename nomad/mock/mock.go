@@ -20,6 +20,7 @@ func Node() *structs.Node {
 		ID:         uuid.Generate(),
 		SecretID:   uuid.Generate(),
 		Datacenter: "dc1",
+		NodePool:   structs.NodePoolDefault,
 		Name:       "foobar",
 		Drivers: map[string]*structs.DriverInfo{
 			"exec": {
@@ -2156,6 +2157,72 @@ func ACLManagementToken() *structs.ACLToken {
 	}
 }
 
+// ACLRole returns a valid ACL role which links to the two policies
+// "mocked-test-policy-1" and "mocked-test-policy-2". These policies are not
+// created within state, so callers must do this if required.
+func ACLRole() *structs.ACLRole {
+	role := structs.ACLRole{
+		ID:          uuid.Generate(),
+		Name:        fmt.Sprintf("acl-role-%s", uuid.Short()),
+		Description: "mocked-test-acl-role",
+		Policies: []*structs.ACLRolePolicyLink{
+			{Name: "mocked-test-policy-1"},
+			{Name: "mocked-test-policy-2"},
+		},
+		CreateIndex: 10,
+		ModifyIndex: 10,
+	}
+	role.SetHash()
+	return &role
+}
+
+// ACLAuthMethod returns a valid OIDC ACL auth method. The discovery URL does
+// not point to a running provider, so callers performing logins must update
+// the config.
+func ACLAuthMethod() *structs.ACLAuthMethod {
+	method := structs.ACLAuthMethod{
+		Name:          fmt.Sprintf("acl-auth-method-%s", uuid.Short()),
+		Type:          structs.ACLAuthMethodTypeOIDC,
+		TokenLocality: structs.ACLAuthMethodTokenLocalityLocal,
+		MaxTokenTTL:   time.Hour,
+		Default:       false,
+		Config: &structs.ACLAuthMethodConfig{
+			OIDCDiscoveryURL:  "http://example.com",
+			OIDCClientID:      "mock",
+			BoundAudiences:    []string{"audience1", "audience2"},
+			DiscoveryCaPem:    []string{"example-pem"},
+			SigningAlgs:       []string{"RS256"},
+			ClaimMappings:     map[string]string{"sub": "subject"},
+			ListClaimMappings: map[string]string{"groups": "groups"},
+		},
+		CreateTime:  time.Now().UTC(),
+		CreateIndex: 10,
+		ModifyIndex: 10,
+	}
+	method.SetHash()
+	return &method
+}
+
+// ACLBindingRule returns a valid ACL binding rule which links to an ACL role
+// named "engineering". The auth method and role are not created within state,
+// so callers must do this if required.
+func ACLBindingRule() *structs.ACLBindingRule {
+	rule := structs.ACLBindingRule{
+		ID:          uuid.Generate(),
+		Description: "mocked-acl-binding-rule",
+		AuthMethod:  "auth0",
+		Selector:    "engineering in list.groups",
+		BindType:    structs.ACLBindingRuleBindTypeRole,
+		BindName:    "engineering",
+		CreateTime:  time.Now().UTC(),
+		ModifyTime:  time.Now().UTC(),
+		CreateIndex: 10,
+		ModifyIndex: 10,
+	}
+	rule.SetHash()
+	return &rule
+}
+
 func ScalingPolicy() *structs.ScalingPolicy {
 	return &structs.ScalingPolicy{
 		ID:   uuid.Generate(),
@@ -2341,6 +2408,17 @@ func Namespace() *structs.Namespace {
 	return ns
 }
 
+// NodePool returns a valid node pool with a unique name.
+func NodePool() *structs.NodePool {
+	pool := &structs.NodePool{
+		Name:        fmt.Sprintf("pool-%s", uuid.Short()),
+		Description: "test node pool",
+		Meta:        map[string]string{"team": "test"},
+	}
+	pool.SetHash()
+	return pool
+}
+
 // ServiceRegistrations generates an array containing two unique service
 // registrations.
 func ServiceRegistrations() []*structs.ServiceRegistration {
@@ -126,6 +126,18 @@ func (n *Node) Register(args *structs.NodeRegisterRequest, reply *structs.NodeUp
 		args.Node.SchedulingEligibility = structs.NodeSchedulingEligible
 	}
 
+	// Default the node pool if none is given. The "all" pool always includes
+	// every node, so nodes cannot register into it directly.
+	if args.Node.NodePool == "" {
+		args.Node.NodePool = structs.NodePoolDefault
+	}
+	if !structs.IsValidNodePoolName(args.Node.NodePool) {
+		return fmt.Errorf("invalid node pool %q for node", args.Node.NodePool)
+	}
+	if args.Node.NodePool == structs.NodePoolAll {
+		return fmt.Errorf("node pool %q is built-in and cannot be used by nodes", args.Node.NodePool)
+	}
+
 	// Set the timestamp when the node is registered
 	args.Node.StatusUpdatedAt = time.Now().Unix()
 
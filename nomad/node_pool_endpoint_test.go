@@ -0,0 +1,246 @@
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodePoolEndpoint_UpsertNodePools(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the register request
+	pool1 := mock.NodePool()
+	pool2 := mock.NodePool()
+	pool2.SchedulerConfiguration = &structs.NodePoolSchedulerConfiguration{
+		SchedulerAlgorithm: structs.SchedulerAlgorithmSpread,
+	}
+
+	req := &structs.NodePoolUpsertRequest{
+		NodePools:    []*structs.NodePool{pool1, pool2},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.GenericResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolUpsertRPCMethod, req, &resp))
+	require.NotZero(t, resp.Index)
+
+	// Check we created the node pools
+	out, err := s1.fsm.State().NodePoolByName(nil, pool1.Name)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.NotEmpty(t, out.Hash)
+
+	out, err = s1.fsm.State().NodePoolByName(nil, pool2.Name)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.Equal(t, structs.SchedulerAlgorithmSpread, out.SchedulerConfiguration.SchedulerAlgorithm)
+
+	// Invalid and built-in node pools should be rejected
+	invalid := mock.NodePool()
+	invalid.Name = "not a valid name"
+	req.NodePools = []*structs.NodePool{invalid}
+	err = msgpackrpc.CallWithCodec(codec, structs.NodePoolUpsertRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "invalid node pool")
+
+	all := mock.NodePool()
+	all.Name = structs.NodePoolAll
+	req.NodePools = []*structs.NodePool{all}
+	err = msgpackrpc.CallWithCodec(codec, structs.NodePoolUpsertRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "cannot be modified")
+}
+
+func TestNodePoolEndpoint_UpsertNodePools_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	pool := mock.NodePool()
+	state := s1.fsm.State()
+
+	// Create a token with node write permissions, which is not sufficient
+	// for managing node pools.
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1001, "test-invalid", mock.NodePolicy("write"))
+
+	req := &structs.NodePoolUpsertRequest{
+		NodePools:    []*structs.NodePool{pool},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+
+	// Upsert the node pool without a token and expect failure
+	var resp structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.NodePoolUpsertRPCMethod, req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Try with an invalid token
+	req.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, structs.NodePoolUpsertRPCMethod, req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Try with a root token
+	req.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolUpsertRPCMethod, req, &resp))
+
+	out, err := state.NodePoolByName(nil, pool.Name)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
+func TestNodePoolEndpoint_GetNodePool(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	pool := mock.NodePool()
+	require.NoError(t, s1.fsm.State().UpsertNodePools(structs.MsgTypeTestSetup, 1000, []*structs.NodePool{pool}))
+
+	// Lookup the node pool
+	get := &structs.NodePoolSpecificRequest{
+		Name:         pool.Name,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.SingleNodePoolResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolGetRPCMethod, get, &resp))
+	require.EqualValues(t, 1000, resp.Index)
+	require.Equal(t, pool, resp.NodePool)
+
+	// Lookup a built-in node pool
+	get.Name = structs.NodePoolDefault
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolGetRPCMethod, get, &resp))
+	require.NotNil(t, resp.NodePool)
+	require.Equal(t, structs.NodePoolDefault, resp.NodePool.Name)
+
+	// Lookup non-existing node pool
+	get.Name = "does-not-exist"
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolGetRPCMethod, get, &resp))
+	require.EqualValues(t, 1000, resp.Index)
+	require.Nil(t, resp.NodePool)
+}
+
+func TestNodePoolEndpoint_List(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	pool1 := mock.NodePool()
+	pool1.Name = "prod-1"
+	pool2 := mock.NodePool()
+	pool2.Name = "dev-1"
+	require.NoError(t, s1.fsm.State().UpsertNodePools(
+		structs.MsgTypeTestSetup, 1000, []*structs.NodePool{pool1, pool2}))
+
+	// Lookup the node pools, which includes the built-in pools
+	get := &structs.NodePoolListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.NodePoolListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolListRPCMethod, get, &resp))
+	require.EqualValues(t, 1000, resp.Index)
+	require.Len(t, resp.NodePools, 4)
+
+	// Lookup the node pools by prefix
+	get.Prefix = "prod"
+	var resp2 structs.NodePoolListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolListRPCMethod, get, &resp2))
+	require.EqualValues(t, 1000, resp2.Index)
+	require.Len(t, resp2.NodePools, 1)
+	require.Equal(t, pool1.Name, resp2.NodePools[0].Name)
+}
+
+func TestNodePoolEndpoint_List_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	state := s1.fsm.State()
+	validToken := mock.CreatePolicyAndToken(t, state, 1001, "test-valid", mock.NodePolicy("read"))
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1002, "test-invalid", mock.AgentPolicy("read"))
+
+	get := &structs.NodePoolListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+
+	// Lookup the node pools without a token and expect failure
+	var resp structs.NodePoolListResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.NodePoolListRPCMethod, get, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Try with an invalid token
+	get.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, structs.NodePoolListRPCMethod, get, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Try with a valid token
+	get.AuthToken = validToken.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolListRPCMethod, get, &resp))
+	require.Len(t, resp.NodePools, 2)
+
+	// Try with a root token
+	get.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolListRPCMethod, get, &resp))
+	require.Len(t, resp.NodePools, 2)
+}
+
+func TestNodePoolEndpoint_DeleteNodePools(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	state := s1.fsm.State()
+
+	// Create a pool which is empty and one which contains a node
+	pool1 := mock.NodePool()
+	pool2 := mock.NodePool()
+	require.NoError(t, state.UpsertNodePools(structs.MsgTypeTestSetup, 1000, []*structs.NodePool{pool1, pool2}))
+
+	node := mock.Node()
+	node.NodePool = pool2.Name
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1001, node))
+
+	// Delete the empty node pool
+	req := &structs.NodePoolDeleteRequest{
+		Names:        []string{pool1.Name},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.GenericResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolDeleteRPCMethod, req, &resp))
+	require.NotZero(t, resp.Index)
+
+	out, err := state.NodePoolByName(nil, pool1.Name)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	// Deleting a node pool which contains nodes should fail
+	req.Names = []string{pool2.Name}
+	err = msgpackrpc.CallWithCodec(codec, structs.NodePoolDeleteRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "has nodes")
+
+	// Deleting a built-in node pool should fail
+	req.Names = []string{structs.NodePoolDefault}
+	err = msgpackrpc.CallWithCodec(codec, structs.NodePoolDeleteRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "built-in")
+}
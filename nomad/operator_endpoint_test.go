@@ -412,6 +412,10 @@ func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 				SystemSchedulerEnabled: false,
 			},
 			PauseEvalBroker: true,
+			EvalBrokerFairShare: &structs.EvalBrokerFairShare{
+				Enabled: true,
+				Weights: map[string]int{"prod": 4},
+			},
 		},
 	}
 	arg.Region = s1.config.Region
@@ -438,6 +442,10 @@ func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 
 	require.False(t, s1.evalBroker.Enabled())
 	require.False(t, s1.blockedEvals.Enabled())
+
+	// The fair-share configuration is applied to the eval broker.
+	require.Equal(t, arg.Config.EvalBrokerFairShare, reply.SchedulerConfig.EvalBrokerFairShare)
+	require.Equal(t, arg.Config.EvalBrokerFairShare, s1.evalBroker.fairShare)
 }
 
 func TestOperator_SchedulerGetConfiguration_ACL(t *testing.T) {
@@ -415,6 +415,8 @@ func (p *planner) signAllocIdentities(job *structs.Job, allocations []*structs.A
 		tg := job.LookupTaskGroup(alloc.TaskGroup)
 		for _, task := range tg.Tasks {
 			claims := alloc.ToTaskIdentityClaims(job, task.Name)
+			claims.Issuer = p.config.OIDCIssuer
+			claims.Audience = p.config.OIDCAudience
 			token, err := encrypter.SignClaims(claims)
 			if err != nil {
 				return err
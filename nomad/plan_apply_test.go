@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/hashicorp/raft"
 	"github.com/stretchr/testify/assert"
@@ -657,6 +658,61 @@ func TestPlanApply_EvalPlan_Partial_AllAtOnce(t *testing.T) {
 	}
 }
 
+// TestPlanApply_EvalPlan_Gang asserts that the plan applier commits none of
+// the placements of a gang when it rejects the node of one gang member
+func TestPlanApply_EvalPlan_Gang(t *testing.T) {
+	ci.Parallel(t)
+
+	// Schedule a gang with one allocation that fits on each node
+	h := scheduler.NewHarness(t)
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	node2 := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node2))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Tasks[0].Resources.CPU = 3000
+	job.TaskGroups[0].Gang = &structs.GangStrategy{Scope: structs.GangScopeGroup}
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	require.NoError(t, h.Process(scheduler.NewServiceScheduler, eval))
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+	require.Len(t, plan.NodeAllocation[node.ID], 1)
+	require.Len(t, plan.NodeAllocation[node2.ID], 1)
+
+	// By the time the plan is applied, the second node has filled up
+	state := testStateStore(t)
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1001, node2))
+	alloc := mock.Alloc()
+	alloc.NodeID = node2.ID
+	alloc.AllocatedResources = structs.NodeResourcesToAllocatedResources(node2.NodeResources)
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1002, alloc.Job))
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1003, []*structs.Allocation{alloc}))
+	snap, err := state.Snapshot()
+	require.NoError(t, err)
+
+	pool := NewEvaluatePool(workerPoolSize, workerPoolBufferSize)
+	defer pool.Shutdown()
+
+	result, err := evaluatePlan(pool, snap, plan, testlog.HCLogger(t))
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Empty(t, result.NodeAllocation)
+	require.NotZero(t, result.RefreshIndex)
+}
+
 func TestPlanApply_EvalNodePlan_Simple(t *testing.T) {
 	ci.Parallel(t)
 	state := testStateStore(t)
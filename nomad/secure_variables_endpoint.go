@@ -13,6 +13,7 @@ import (
 
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/state/paginator"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -51,9 +52,18 @@ func (sv *SecureVariables) Apply(args *structs.SecureVariablesApplyRequest, repl
 		return err
 	}
 
+	// Lock renewals only reset the TTL timer held by the leader, so they
+	// don't need to go through raft.
+	if args.Op == structs.SVOpLockRenew {
+		return sv.renewLock(args, reply, canRead)
+	}
+
 	var ev *structs.SecureVariableEncrypted
 
 	switch args.Op {
+	case structs.SVOpLockAcquire:
+		args.Var.Lock.ID = uuid.Generate()
+		fallthrough
 	case structs.SVOpSet, structs.SVOpCAS:
 		ev, err = sv.encrypt(args.Var)
 		if err != nil {
@@ -68,6 +78,16 @@ func (sv *SecureVariables) Apply(args *structs.SecureVariablesApplyRequest, repl
 				Namespace:   args.Var.Namespace,
 				Path:        args.Var.Path,
 				ModifyIndex: args.Var.ModifyIndex,
+				Lock:        args.Var.Lock.Copy(),
+			},
+		}
+	case structs.SVOpLockRelease:
+		ev = &structs.SecureVariableEncrypted{
+			SecureVariableMetadata: structs.SecureVariableMetadata{
+				Namespace:  args.Var.Namespace,
+				Path:       args.Var.Path,
+				ModifyTime: time.Now().UnixNano(),
+				Lock:       args.Var.Lock.Copy(),
 			},
 		}
 	}
@@ -84,7 +104,20 @@ func (sv *SecureVariables) Apply(args *structs.SecureVariablesApplyRequest, repl
 	if err != nil {
 		return fmt.Errorf("raft apply failed: %w", err)
 	}
-	r, err := sv.makeSecureVariablesApplyResponse(args, out.(*structs.SVApplyStateResponse), canRead)
+	sveResp := out.(*structs.SVApplyStateResponse)
+
+	// Track the expiration of held locks on the leader.
+	if sveResp.IsOk() {
+		switch args.Op {
+		case structs.SVOpLockAcquire:
+			sv.srv.svLocks.resetTimer(args.Var.Namespace, args.Var.Path,
+				args.Var.Lock.ID, args.Var.Lock.TTL)
+		case structs.SVOpLockRelease, structs.SVOpDelete, structs.SVOpDeleteCAS:
+			sv.srv.svLocks.clearTimer(args.Var.Namespace, args.Var.Path)
+		}
+	}
+
+	r, err := sv.makeSecureVariablesApplyResponse(args, sveResp, canRead)
 	if err != nil {
 		return err
 	}
@@ -93,6 +126,127 @@ func (sv *SecureVariables) Apply(args *structs.SecureVariablesApplyRequest, repl
 	return nil
 }
 
+// Rekey re-encrypts the tracked versions of secure variables which were
+// encrypted with the passed key using the active root key. It is used by the
+// leader when rekeying after a root key rotation, and unlike Apply doesn't
+// create new versions of the variables.
+func (sv *SecureVariables) Rekey(args *structs.SecureVariablesRekeyRequest, reply *structs.SecureVariablesRekeyResponse) error {
+	if done, err := sv.srv.forward(structs.SecureVariablesRekeyRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "secure_variables", "rekey"}, time.Now())
+
+	if aclObj, err := sv.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	snap, err := sv.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	rekeyed := make([]*structs.SecureVariableEncrypted, 0, len(args.Versions))
+	for _, meta := range args.Versions {
+		version, err := snap.GetSecureVariableVersion(nil, meta.Namespace, meta.Path, meta.Version)
+		if err != nil {
+			return err
+		}
+		if version == nil {
+			// variables written before versions were tracked only have
+			// their current version
+			_, current, err := snap.SVEGet(nil, meta.Namespace, meta.Path)
+			if err != nil {
+				return err
+			}
+			if current != nil && current.Version == meta.Version {
+				version = current
+			}
+		}
+		if version == nil || version.KeyID != args.KeyID {
+			// the version was deleted or already rekeyed
+			continue
+		}
+
+		cleartext, err := sv.encrypter.Decrypt(version.Data, version.KeyID)
+		if err != nil {
+			return fmt.Errorf("secure variable error: decrypt: %w", err)
+		}
+		ev := &structs.SecureVariableEncrypted{
+			SecureVariableMetadata: structs.SecureVariableMetadata{
+				Namespace: version.Namespace,
+				Path:      version.Path,
+				Version:   version.Version,
+			},
+		}
+		ev.Data, ev.KeyID, err = sv.encrypter.Encrypt(cleartext)
+		if err != nil {
+			return fmt.Errorf("secure variable error: encrypt: %w", err)
+		}
+		rekeyed = append(rekeyed, ev)
+	}
+	if len(rekeyed) == 0 {
+		return nil
+	}
+
+	out, index, err := sv.srv.raftApply(structs.SVRekeyRequestType, &structs.SVRekeyStateRequest{
+		KeyID:        args.KeyID,
+		Versions:     rekeyed,
+		WriteRequest: args.WriteRequest,
+	})
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("raft apply failed: %w", err)
+	}
+	reply.Index = index
+	return nil
+}
+
+// renewLock resets the TTL of a held secure variable lock. The lock must be
+// held by the caller, otherwise a conflict is returned.
+func (sv *SecureVariables) renewLock(args *structs.SecureVariablesApplyRequest,
+	reply *structs.SecureVariablesApplyResponse, canRead bool) error {
+
+	existing, err := sv.srv.fsm.State().GetSecureVariable(nil, args.Var.Namespace, args.Var.Path)
+	if err != nil {
+		return err
+	}
+
+	sveArgs := structs.SVApplyStateRequest{
+		Op:           args.Op,
+		WriteRequest: args.WriteRequest,
+	}
+
+	var sveResp *structs.SVApplyStateResponse
+	switch {
+	case existing == nil:
+		zeroVal := &structs.SecureVariableEncrypted{
+			SecureVariableMetadata: structs.SecureVariableMetadata{
+				Namespace: args.Var.Namespace,
+				Path:      args.Var.Path,
+			},
+		}
+		sveResp = sveArgs.ConflictResponse(0, zeroVal)
+	case !existing.IsLockHolder(args.Var.Lock):
+		sveResp = sveArgs.ConflictResponse(existing.ModifyIndex, existing)
+	default:
+		sv.srv.svLocks.resetTimer(existing.Namespace, existing.Path,
+			existing.Lock.ID, existing.Lock.TTL)
+		sveResp = sveArgs.SuccessResponse(existing.ModifyIndex, existing.SecureVariableMetadata.Copy())
+	}
+
+	r, err := sv.makeSecureVariablesApplyResponse(args, sveResp, canRead)
+	if err != nil {
+		return err
+	}
+	*reply = *r
+	reply.Index = sveResp.Index
+	return nil
+}
+
 func svePreApply(sv *SecureVariables, args *structs.SecureVariablesApplyRequest, vd *structs.SecureVariableDecrypted) (canRead bool, err error) {
 
 	canRead = false
@@ -109,7 +263,8 @@ func svePreApply(sv *SecureVariables, args *structs.SecureVariablesApplyRequest,
 		canRead = hasPerm(acl.SecureVariablesCapabilityRead)
 
 		switch args.Op {
-		case structs.SVOpSet, structs.SVOpCAS:
+		case structs.SVOpSet, structs.SVOpCAS,
+			structs.SVOpLockAcquire, structs.SVOpLockRenew, structs.SVOpLockRelease:
 			if !hasPerm(acl.SecureVariablesCapabilityWrite) {
 				err = structs.ErrPermissionDenied
 				return
@@ -140,6 +295,31 @@ func svePreApply(sv *SecureVariables, args *structs.SecureVariablesApplyRequest,
 			err = fmt.Errorf("delete requires a Path")
 			return
 		}
+
+	case structs.SVOpLockAcquire:
+		if args.Var.Lock == nil {
+			args.Var.Lock = &structs.SecureVariableLock{}
+		}
+		if args.Var.Lock.TTL == 0 {
+			args.Var.Lock.TTL = structs.DefaultSecureVariableLockTTL
+		}
+		args.Var.Canonicalize()
+		if err = args.Var.Lock.Validate(); err != nil {
+			return
+		}
+		if err = args.Var.Validate(); err != nil {
+			return
+		}
+
+	case structs.SVOpLockRenew, structs.SVOpLockRelease:
+		if args.Var.Path == "" {
+			err = fmt.Errorf("%s requires a Path", args.Op)
+			return
+		}
+		if args.Var.Lock == nil || args.Var.Lock.ID == "" {
+			err = fmt.Errorf("%s requires a lock ID", args.Op)
+			return
+		}
 	}
 
 	return
@@ -173,9 +353,10 @@ func (sv *SecureVariables) makeSecureVariablesApplyResponse(
 	// At this point, the response is necessarily a conflict.
 	// Prime output from the encrypted responses metadata
 	out.Conflict = &structs.SecureVariableDecrypted{
-		SecureVariableMetadata: eResp.Conflict.SecureVariableMetadata,
+		SecureVariableMetadata: *eResp.Conflict.SecureVariableMetadata.Copy(),
 		Items:                  nil,
 	}
+	out.Conflict.RedactLock()
 
 	// If the caller can't read the conflicting value, return the
 	// metadata, but no items and flag it as redacted
@@ -197,6 +378,7 @@ func (sv *SecureVariables) makeSecureVariablesApplyResponse(
 		if err != nil {
 			return nil, err
 		}
+		dv.RedactLock()
 		out.Conflict = dv
 	}
 
@@ -221,7 +403,15 @@ func (sv *SecureVariables) Read(args *structs.SecureVariablesReadRequest, reply
 		queryOpts: &args.QueryOptions,
 		queryMeta: &reply.QueryMeta,
 		run: func(ws memdb.WatchSet, s *state.StateStore) error {
-			out, err := s.GetSecureVariable(ws, args.RequestNamespace(), args.Path)
+			var out *structs.SecureVariableEncrypted
+			var err error
+			table := state.TableSecureVariables
+			if args.Version != nil {
+				out, err = s.GetSecureVariableVersion(ws, args.RequestNamespace(), args.Path, *args.Version)
+				table = state.TableSecureVariableVersions
+			} else {
+				out, err = s.GetSecureVariable(ws, args.RequestNamespace(), args.Path)
+			}
 			if err != nil {
 				return err
 			}
@@ -233,11 +423,54 @@ func (sv *SecureVariables) Read(args *structs.SecureVariablesReadRequest, reply
 				if err != nil {
 					return err
 				}
+				// The lock ID is only returned to the lock holder
 				ov := dv.Copy()
+				ov.RedactLock()
 				reply.Data = &ov
 				reply.Index = out.ModifyIndex
 			} else {
-				sv.srv.replySetIndex(state.TableSecureVariables, &reply.QueryMeta)
+				sv.srv.replySetIndex(table, &reply.QueryMeta)
+			}
+			return nil
+		}}
+	return sv.srv.blockingRPC(&opts)
+}
+
+// History is used to list the tracked versions of a secure variable. Only
+// the metadata of each version is returned; the contents of a version can be
+// read by passing its version to Read.
+func (sv *SecureVariables) History(args *structs.SecureVariablesHistoryRequest, reply *structs.SecureVariablesHistoryResponse) error {
+	if done, err := sv.srv.forward(structs.SecureVariablesHistoryRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "secure_variables", "history"}, time.Now())
+
+	_, err := sv.handleMixedAuthEndpoint(args.QueryOptions,
+		acl.PolicyRead, args.Path)
+	if err != nil {
+		return err
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			out, err := s.GetSecureVariableVersions(ws, args.RequestNamespace(), args.Path)
+			if err != nil {
+				return err
+			}
+
+			// Setup the output
+			reply.Versions = nil
+			if len(out) != 0 {
+				reply.Versions = make([]*structs.SecureVariableMetadata, 0, len(out))
+				for _, version := range out {
+					reply.Versions = append(reply.Versions, version.SecureVariableMetadata.Copy())
+				}
+				reply.Index = out[0].ModifyIndex
+			} else {
+				sv.srv.replySetIndex(state.TableSecureVariableVersions, &reply.QueryMeta)
 			}
 			return nil
 		}}
@@ -310,8 +543,9 @@ func (sv *SecureVariables) List(
 			paginatorImpl, err := paginator.NewPaginator(iter, tokenizer, filters, args.QueryOptions,
 				func(raw interface{}) error {
 					sv := raw.(*structs.SecureVariableEncrypted)
-					svStub := sv.SecureVariableMetadata
-					svs = append(svs, &svStub)
+					svStub := sv.SecureVariableMetadata.Copy()
+					svStub.RedactLock()
+					svs = append(svs, svStub)
 					return nil
 				})
 			if err != nil {
@@ -409,8 +643,9 @@ func (s *SecureVariables) listAllSecureVariables(
 			paginatorImpl, err := paginator.NewPaginator(iter, tokenizer, filters, args.QueryOptions,
 				func(raw interface{}) error {
 					sv := raw.(*structs.SecureVariableEncrypted)
-					svStub := sv.SecureVariableMetadata
-					svs = append(svs, &svStub)
+					svStub := sv.SecureVariableMetadata.Copy()
+					svStub.RedactLock()
+					svs = append(svs, svStub)
 					return nil
 				})
 			if err != nil {
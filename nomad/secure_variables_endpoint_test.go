@@ -706,3 +706,186 @@ func writeVar(t *testing.T, s *Server, idx uint64, ns, path string) {
 	})
 	must.NoError(t, resp.Error)
 }
+
+func TestSecureVariablesEndpoint_Apply_Locks(t *testing.T) {
+	ci.Parallel(t)
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	sv := &structs.SecureVariableDecrypted{
+		SecureVariableMetadata: structs.SecureVariableMetadata{
+			Namespace: structs.DefaultNamespace,
+			Path:      "locks/leader",
+		},
+	}
+	apply := func(op structs.SVOp, v structs.SecureVariableDecrypted) *structs.SecureVariablesApplyResponse {
+		t.Helper()
+		req := &structs.SecureVariablesApplyRequest{
+			Op:           op,
+			Var:          &v,
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.SecureVariablesApplyResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.SecureVariablesApplyRPCMethod, req, &resp))
+		return &resp
+	}
+
+	// Acquire the lock, which creates the variable with the default TTL
+	resp := apply(structs.SVOpLockAcquire, sv.Copy())
+	must.Eq(t, structs.SVOpResultOk, resp.Result)
+	must.NotNil(t, resp.Output.Lock)
+	must.NotEq(t, "", resp.Output.Lock.ID)
+	must.Eq(t, structs.DefaultSecureVariableLockTTL, resp.Output.Lock.TTL)
+	lock := resp.Output.Lock.Copy()
+
+	// The leader tracks the lock TTL
+	key := svLockKey{namespace: sv.Namespace, path: sv.Path}
+	srv.svLocks.timersLock.Lock()
+	must.MapContainsKeys(t, srv.svLocks.timers, []svLockKey{key})
+	srv.svLocks.timersLock.Unlock()
+
+	// Reads don't expose the lock ID
+	readReq := &structs.SecureVariablesReadRequest{
+		Path: sv.Path,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: sv.Namespace,
+		},
+	}
+	var readResp structs.SecureVariablesReadResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.SecureVariablesReadRPCMethod, readReq, &readResp))
+	must.NotNil(t, readResp.Data.Lock)
+	must.Eq(t, "", readResp.Data.Lock.ID)
+
+	// A second acquire conflicts without exposing the lock ID
+	resp = apply(structs.SVOpLockAcquire, sv.Copy())
+	must.Eq(t, structs.SVOpResultConflict, resp.Result)
+	must.Eq(t, "", resp.Conflict.Lock.ID)
+
+	// Writes without the lock ID conflict, writes by the lock holder succeed
+	write := sv.Copy()
+	write.Items = structs.SecureVariableItems{"leader": "a"}
+	resp = apply(structs.SVOpSet, write.Copy())
+	must.Eq(t, structs.SVOpResultConflict, resp.Result)
+
+	write.Lock = lock.Copy()
+	resp = apply(structs.SVOpSet, write.Copy())
+	must.Eq(t, structs.SVOpResultOk, resp.Result)
+
+	// Renew requires the lock ID
+	badLock := sv.Copy()
+	badLock.Lock = &structs.SecureVariableLock{ID: "not-the-lock-holder"}
+	resp = apply(structs.SVOpLockRenew, badLock.Copy())
+	must.Eq(t, structs.SVOpResultConflict, resp.Result)
+
+	held := sv.Copy()
+	held.Lock = lock.Copy()
+	resp = apply(structs.SVOpLockRenew, held.Copy())
+	must.Eq(t, structs.SVOpResultOk, resp.Result)
+	must.Eq(t, lock.ID, resp.Output.Lock.ID)
+
+	// Release the lock, which retains the variable
+	resp = apply(structs.SVOpLockRelease, held.Copy())
+	must.Eq(t, structs.SVOpResultOk, resp.Result)
+
+	out, err := srv.fsm.State().GetSecureVariable(nil, sv.Namespace, sv.Path)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+	must.False(t, out.IsLocked())
+
+	srv.svLocks.timersLock.Lock()
+	_, ok := srv.svLocks.timers[key]
+	must.False(t, ok)
+	srv.svLocks.timersLock.Unlock()
+
+	// Expired locks are released by the leader
+	resp = apply(structs.SVOpLockAcquire, sv.Copy())
+	must.Eq(t, structs.SVOpResultOk, resp.Result)
+	srv.svLocks.expireLock(key, resp.Output.Lock.ID)
+
+	out, err = srv.fsm.State().GetSecureVariable(nil, sv.Namespace, sv.Path)
+	must.NoError(t, err)
+	must.False(t, out.IsLocked())
+
+	// Invalid TTLs are rejected
+	invalid := sv.Copy()
+	invalid.Lock = &structs.SecureVariableLock{TTL: time.Second}
+	req := &structs.SecureVariablesApplyRequest{
+		Op:           structs.SVOpLockAcquire,
+		Var:          &invalid,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var applyResp structs.SecureVariablesApplyResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.SecureVariablesApplyRPCMethod, req, &applyResp)
+	must.Error(t, err)
+	must.StrContains(t, err.Error(), "lock TTL must be between")
+}
+
+func TestSecureVariablesEndpoint_History(t *testing.T) {
+	ci.Parallel(t)
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	// Write two versions of the variable
+	for _, value := range []string{"a", "b"} {
+		req := &structs.SecureVariablesApplyRequest{
+			Op: structs.SVOpSet,
+			Var: &structs.SecureVariableDecrypted{
+				SecureVariableMetadata: structs.SecureVariableMetadata{
+					Namespace: structs.DefaultNamespace,
+					Path:      "history/foo",
+				},
+				Items: structs.SecureVariableItems{"value": value},
+			},
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.SecureVariablesApplyResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.SecureVariablesApplyRPCMethod, req, &resp))
+		must.Eq(t, structs.SVOpResultOk, resp.Result)
+	}
+
+	// The history lists both versions from the newest
+	historyReq := &structs.SecureVariablesHistoryRequest{
+		Path: "history/foo",
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var historyResp structs.SecureVariablesHistoryResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.SecureVariablesHistoryRPCMethod, historyReq, &historyResp))
+	must.Len(t, 2, historyResp.Versions)
+	must.Eq(t, uint64(1), historyResp.Versions[0].Version)
+	must.Eq(t, uint64(0), historyResp.Versions[1].Version)
+	must.Eq(t, historyResp.Versions[0].ModifyIndex, historyResp.Index)
+
+	// A prior version can be read
+	version := uint64(0)
+	readReq := &structs.SecureVariablesReadRequest{
+		Path:    "history/foo",
+		Version: &version,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var readResp structs.SecureVariablesReadResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.SecureVariablesReadRPCMethod, readReq, &readResp))
+	must.NotNil(t, readResp.Data)
+	must.Eq(t, "a", readResp.Data.Items["value"])
+	must.Eq(t, uint64(0), readResp.Data.Version)
+
+	// Untracked versions are not found
+	version = 5
+	readResp = structs.SecureVariablesReadResponse{}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.SecureVariablesReadRPCMethod, readReq, &readResp))
+	must.Nil(t, readResp.Data)
+}
@@ -0,0 +1,172 @@
+package nomad
+
+import (
+	"sync"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// svLockKey identifies a locked secure variable.
+type svLockKey struct {
+	namespace string
+	path      string
+}
+
+// svLockTimer tracks the expiration of a single secure variable lock.
+type svLockTimer struct {
+	lockID string
+	timer  *time.Timer
+}
+
+// secureVariableLocks is used by the leader to track expiration times of
+// secure variable locks. If a lock is not renewed before its TTL expires, it
+// is released.
+type secureVariableLocks struct {
+	srv    *Server
+	logger log.Logger
+
+	timers     map[svLockKey]*svLockTimer
+	timersLock sync.Mutex
+}
+
+// newSecureVariableLocks returns a new lock tracker used to expire secure
+// variable locks which are not renewed.
+func newSecureVariableLocks(s *Server) *secureVariableLocks {
+	return &secureVariableLocks{
+		srv:    s,
+		logger: s.logger.Named("secure_variable_locks"),
+	}
+}
+
+// initializeTimers is used when a leader is newly elected to reset the
+// timers of all the locks held in state. As with node heartbeats, this
+// effectively renews all locks on failover; the TTL contract is that a lock
+// will not be expired before its TTL.
+func (l *secureVariableLocks) initializeTimers() error {
+	snap, err := l.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	iter, err := snap.SecureVariables(memdb.NewWatchSet())
+	if err != nil {
+		return err
+	}
+
+	l.timersLock.Lock()
+	defer l.timersLock.Unlock()
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		sv := raw.(*structs.SecureVariableEncrypted)
+		if !sv.IsLocked() {
+			continue
+		}
+		l.resetTimerLocked(sv.Namespace, sv.Path, sv.Lock.ID, sv.Lock.TTL)
+	}
+	return nil
+}
+
+// resetTimer is used to create or renew the expiration timer of a lock.
+func (l *secureVariableLocks) resetTimer(namespace, path, lockID string, ttl time.Duration) {
+	l.timersLock.Lock()
+	defer l.timersLock.Unlock()
+
+	// Do not create a timer since we are not the leader. This avoids the race
+	// in which leadership is lost while servicing an RPC.
+	if !l.srv.IsLeader() {
+		l.logger.Debug("ignoring resetting lock TTL since this server is not the leader",
+			"namespace", namespace, "path", path)
+		return
+	}
+	l.resetTimerLocked(namespace, path, lockID, ttl)
+}
+
+// resetTimerLocked is used to reset a lock timer assuming the timersLock is
+// already held.
+func (l *secureVariableLocks) resetTimerLocked(namespace, path, lockID string, ttl time.Duration) {
+	if l.timers == nil {
+		l.timers = make(map[svLockKey]*svLockTimer)
+	}
+
+	key := svLockKey{namespace: namespace, path: path}
+	if t, ok := l.timers[key]; ok {
+		t.timer.Stop()
+	}
+
+	l.timers[key] = &svLockTimer{
+		lockID: lockID,
+		timer: time.AfterFunc(ttl, func() {
+			l.expireLock(key, lockID)
+		}),
+	}
+}
+
+// expireLock is invoked when a lock TTL is reached and the lock needs to be
+// released.
+func (l *secureVariableLocks) expireLock(key svLockKey, lockID string) {
+	defer metrics.MeasureSince([]string{"nomad", "secure_variables", "lock_expire"}, time.Now())
+
+	l.timersLock.Lock()
+	if t, ok := l.timers[key]; ok && t.lockID == lockID {
+		delete(l.timers, key)
+	}
+	l.timersLock.Unlock()
+
+	if !l.srv.IsLeader() {
+		l.logger.Debug("ignoring lock TTL since this server is not the leader",
+			"namespace", key.namespace, "path", key.path)
+		return
+	}
+
+	l.logger.Debug("secure variable lock TTL expired",
+		"namespace", key.namespace, "path", key.path)
+
+	req := structs.SVApplyStateRequest{
+		Op: structs.SVOpLockRelease,
+		Var: &structs.SecureVariableEncrypted{
+			SecureVariableMetadata: structs.SecureVariableMetadata{
+				Namespace:  key.namespace,
+				Path:       key.path,
+				ModifyTime: time.Now().UnixNano(),
+				Lock:       &structs.SecureVariableLock{ID: lockID},
+			},
+		},
+		WriteRequest: structs.WriteRequest{
+			Region:    l.srv.config.Region,
+			Namespace: key.namespace,
+		},
+	}
+	if _, _, err := l.srv.raftApply(structs.SVApplyStateRequestType, req); err != nil {
+		l.logger.Error("failed to release expired secure variable lock",
+			"namespace", key.namespace, "path", key.path, "error", err)
+	}
+}
+
+// clearTimer is used to stop tracking a lock which has been released.
+func (l *secureVariableLocks) clearTimer(namespace, path string) {
+	l.timersLock.Lock()
+	defer l.timersLock.Unlock()
+
+	key := svLockKey{namespace: namespace, path: path}
+	if t, ok := l.timers[key]; ok {
+		t.timer.Stop()
+		delete(l.timers, key)
+	}
+}
+
+// clearAllTimers is used when a leader is stepping down and we no longer
+// need to track any lock timers.
+func (l *secureVariableLocks) clearAllTimers() {
+	l.timersLock.Lock()
+	defer l.timersLock.Unlock()
+
+	for _, t := range l.timers {
+		t.timer.Stop()
+	}
+	l.timers = nil
+}
@@ -31,6 +31,8 @@ import (
 	"github.com/hashicorp/nomad/helper/pool"
 	"github.com/hashicorp/nomad/helper/stats"
 	"github.com/hashicorp/nomad/helper/tlsutil"
+	"github.com/hashicorp/nomad/lib/auth/jwt"
+	"github.com/hashicorp/nomad/lib/auth/oidc"
 	"github.com/hashicorp/nomad/nomad/deploymentwatcher"
 	"github.com/hashicorp/nomad/nomad/drainer"
 	"github.com/hashicorp/nomad/nomad/state"
@@ -222,6 +224,14 @@ type Server struct {
 	// encrypter is the keyring for secure variables
 	encrypter *Encrypter
 
+	// oidcProviderCache caches the OIDC providers of ACL auth methods, so
+	// that discovery is not performed on every login.
+	oidcProviderCache *oidc.ProviderCache
+
+	// jwtValidatorCache caches the validators of JWT ACL auth methods, so
+	// that remote key sets are not fetched on every login.
+	jwtValidatorCache *jwt.ValidatorCache
+
 	// periodicDispatcher is used to track and create evaluations for periodic jobs.
 	periodicDispatcher *PeriodicDispatch
 
@@ -233,6 +243,10 @@ type Server struct {
 	// detects an expired node, the node status is updated to be 'down'.
 	*nodeHeartbeater
 
+	// svLocks is used to track expiration times of secure variable locks. If
+	// a lock is not renewed before its TTL, the lock is released.
+	svLocks *secureVariableLocks
+
 	// consulCatalog is used for discovering other Nomad Servers via Consul
 	consulCatalog consul.CatalogAPI
 
@@ -297,6 +311,7 @@ type endpoints struct {
 	Enterprise          *EnterpriseEndpoints
 	Event               *Event
 	Namespace           *Namespace
+	NodePool            *NodePool
 	SecureVariables     *SecureVariables
 	Keyring             *Keyring
 	ServiceRegistration *ServiceRegistration
@@ -364,6 +379,8 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigEntr
 		rpcTLS:                  incomingTLS,
 		aclCache:                aclCache,
 		workersEventCh:          make(chan interface{}, 1),
+		oidcProviderCache:       oidc.NewProviderCache(),
+		jwtValidatorCache:       jwt.NewValidatorCache(),
 	}
 
 	s.shutdownCtx, s.shutdownCancel = context.WithCancel(context.Background())
@@ -382,6 +399,9 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigEntr
 	// Create the node heartbeater
 	s.nodeHeartbeater = newNodeHeartbeater(s)
 
+	// Create the secure variable lock tracker
+	s.svLocks = newSecureVariableLocks(s)
+
 	// Create the periodic dispatcher for launching periodic jobs.
 	s.periodicDispatcher = NewPeriodicDispatch(s.logger, s)
 
@@ -406,7 +426,11 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigEntr
 			return nil, fmt.Errorf("Failed to create keystore tempdir")
 		}
 	}
-	encrypter, err := NewEncrypter(s, keystorePath)
+	kek, err := newKEKProvider(s.config.KeyringKEK)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to configure keyring KEK provider: %v", err)
+	}
+	encrypter, err := NewEncrypter(s, keystorePath, kek)
 	if err != nil {
 		return nil, err
 	}
@@ -1211,6 +1235,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) error {
 		s.staticEndpoints.System = &System{srv: s, logger: s.logger.Named("system")}
 		s.staticEndpoints.Search = &Search{srv: s, logger: s.logger.Named("search")}
 		s.staticEndpoints.Namespace = &Namespace{srv: s}
+		s.staticEndpoints.NodePool = &NodePool{srv: s}
 		s.staticEndpoints.SecureVariables = &SecureVariables{srv: s, logger: s.logger.Named("secure_variables"), encrypter: s.encrypter}
 		s.staticEndpoints.Keyring = &Keyring{srv: s, logger: s.logger.Named("keyring"), encrypter: s.encrypter}
 
@@ -1261,6 +1286,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) error {
 	server.Register(s.staticEndpoints.FileSystem)
 	server.Register(s.staticEndpoints.Agent)
 	server.Register(s.staticEndpoints.Namespace)
+	server.Register(s.staticEndpoints.NodePool)
 	server.Register(s.staticEndpoints.SecureVariables)
 
 	// Create new dynamic endpoints and add them to the RPC server.
@@ -1,8 +1,10 @@
 package state
 
 import (
+	"encoding/binary"
 	"fmt"
 	"sync"
+	"time"
 
 	memdb "github.com/hashicorp/go-memdb"
 
@@ -12,11 +14,16 @@ import (
 const (
 	tableIndex = "index"
 
-	TableNamespaces            = "namespaces"
-	TableServiceRegistrations  = "service_registrations"
-	TableSecureVariables       = "secure_variables"
-	TableSecureVariablesQuotas = "secure_variables_quota"
-	TableRootKeyMeta           = "secure_variables_root_key_meta"
+	TableNamespaces             = "namespaces"
+	TableServiceRegistrations   = "service_registrations"
+	TableSecureVariables        = "secure_variables"
+	TableSecureVariablesQuotas  = "secure_variables_quota"
+	TableSecureVariableVersions = "secure_variables_versions"
+	TableRootKeyMeta            = "secure_variables_root_key_meta"
+	TableACLRoles               = "acl_roles"
+	TableACLAuthMethods         = "acl_auth_methods"
+	TableACLBindingRules        = "acl_binding_rules"
+	TableNodePools              = "node_pools"
 )
 
 const (
@@ -27,6 +34,11 @@ const (
 	indexServiceName = "service_name"
 	indexKeyID       = "key_id"
 	indexPath        = "path"
+	indexName        = "name"
+	indexAuthMethod  = "auth_method"
+
+	indexExpiresGlobal = "expires-global"
+	indexExpiresLocal  = "expires-local"
 )
 
 var (
@@ -77,7 +89,12 @@ func init() {
 		serviceRegistrationsTableSchema,
 		secureVariablesTableSchema,
 		secureVariablesQuotasTableSchema,
+		secureVariableVersionsTableSchema,
 		secureVariablesRootKeyMetaSchema,
+		aclRolesTableSchema,
+		aclAuthMethodsTableSchema,
+		aclBindingRulesTableSchema,
+		nodePoolsTableSchema,
 	}...)
 }
 
@@ -894,10 +911,70 @@ func aclTokenTableSchema() *memdb.TableSchema {
 					Field: "Global",
 				},
 			},
+			indexExpiresGlobal: {
+				Name:         indexExpiresGlobal,
+				AllowMissing: true,
+				Unique:       false,
+				Indexer: &TokenExpirationIndex{
+					LocalOnly: false,
+				},
+			},
+			indexExpiresLocal: {
+				Name:         indexExpiresLocal,
+				AllowMissing: true,
+				Unique:       false,
+				Indexer: &TokenExpirationIndex{
+					LocalOnly: true,
+				},
+			},
 		},
 	}
 }
 
+// TokenExpirationIndex is used to index ACL tokens by their expiration time.
+// Tokens without an expiration time are not indexed. The LocalOnly field
+// controls whether the index is built using region local or global tokens.
+type TokenExpirationIndex struct {
+	LocalOnly bool
+}
+
+// FromObject is used to extract an index value from an
+// object or to indicate that the index value is missing.
+func (t *TokenExpirationIndex) FromObject(obj interface{}) (bool, []byte, error) {
+	token, ok := obj.(*structs.ACLToken)
+	if !ok {
+		return false, nil, fmt.Errorf("object %#v is not an ACLToken", obj)
+	}
+
+	// Only index tokens of the requested locality, which have an expiry set.
+	if t.LocalOnly == token.Global || !token.HasExpirationTime() {
+		return false, nil, nil
+	}
+
+	return true, encodeTokenExpiration(*token.ExpirationTime), nil
+}
+
+// FromArgs is used to build an exact index lookup based on arguments
+func (t *TokenExpirationIndex) FromArgs(args ...interface{}) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("must provide only a single argument")
+	}
+	arg, ok := args[0].(time.Time)
+	if !ok {
+		return nil, fmt.Errorf("argument must be a time.Time: %#v", args[0])
+	}
+	return encodeTokenExpiration(arg), nil
+}
+
+// encodeTokenExpiration encodes the passed time as a big-endian Unix
+// timestamp, so that lexicographic ordering within the index matches time
+// ordering.
+func encodeTokenExpiration(t time.Time) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(t.Unix()))
+	return buf
+}
+
 // oneTimeTokenTableSchema returns the MemDB schema for the tokens table.
 // This table is used to store one-time tokens for ACL tokens
 func oneTimeTokenTableSchema() *memdb.TableSchema {
@@ -1319,6 +1396,42 @@ func secureVariablesTableSchema() *memdb.TableSchema {
 	}
 }
 
+// secureVariableVersionsTableSchema returns the MemDB schema for the
+// tracked versions of Nomad secure variables.
+func secureVariableVersionsTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableSecureVariableVersions,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+
+				// Use a compound index so the tuple of (Namespace, Path,
+				// Version) is uniquely identifying
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "Path",
+						},
+						&memdb.UintFieldIndex{
+							Field: "Version",
+						},
+					},
+				},
+			},
+			indexKeyID: {
+				Name:         indexKeyID,
+				AllowMissing: false,
+				Indexer:      &secureVariableKeyIDFieldIndexer{},
+			},
+		},
+	}
+}
+
 type secureVariableKeyIDFieldIndexer struct{}
 
 // FromArgs implements go-memdb/Indexer and is used to build an exact
@@ -1408,3 +1521,95 @@ func secureVariablesRootKeyMetaSchema() *memdb.TableSchema {
 		},
 	}
 }
+
+// aclRolesTableSchema returns the MemDB schema for the ACL roles table. This
+// table is used to store ACL roles which group ACL policies together and can
+// be linked to ACL tokens.
+func aclRolesTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableACLRoles,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "ID",
+				},
+			},
+			indexName: {
+				Name:         indexName,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// aclAuthMethodsTableSchema returns the MemDB schema for the ACL auth methods
+// table. This table is used to store the configuration of the external
+// identity providers which can be used to login to Nomad.
+func aclAuthMethodsTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableACLAuthMethods,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// aclBindingRulesTableSchema returns the MemDB schema for the ACL binding
+// rules table. This table is used to store the rules which map identity
+// provider claims to Nomad ACL roles and policies at login time.
+func aclBindingRulesTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableACLBindingRules,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "ID",
+				},
+			},
+			indexAuthMethod: {
+				Name:         indexAuthMethod,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "AuthMethod",
+				},
+			},
+		},
+	}
+}
+
+// nodePoolsTableSchema returns the MemDB schema for the node pools table.
+// This table is used to store the pools which partition the nodes of the
+// cluster.
+func nodePoolsTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableNodePools,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
@@ -153,6 +153,11 @@ func NewStateStore(config *StateStoreConfig) (*StateStore, error) {
 		return nil, fmt.Errorf("enterprise state store initialization failed: %v", err)
 	}
 
+	// Initialize the state store with the built-in node pools.
+	if err := s.nodePoolInit(); err != nil {
+		return nil, fmt.Errorf("state store initialization failed: %v", err)
+	}
+
 	return s, nil
 }
 
@@ -944,6 +949,9 @@ func upsertNodeTxn(txn *txn, index uint64, node *structs.Node) error {
 	if err := upsertCSIPluginsForNode(txn, node, index); err != nil {
 		return fmt.Errorf("csi plugin update failed: %v", err)
 	}
+	if err := upsertNodePoolForNodeTxn(txn, index, node.NodePool); err != nil {
+		return fmt.Errorf("node pool update failed: %v", err)
+	}
 
 	return nil
 }
@@ -5675,7 +5683,7 @@ func (s *StateStore) ACLTokenByAccessorID(ws memdb.WatchSet, id string) (*struct
 	ws.Add(watchCh)
 
 	if existing != nil {
-		return existing.(*structs.ACLToken), nil
+		return s.fixTokenRoleLinks(txn, existing.(*structs.ACLToken))
 	}
 	return nil, nil
 }
@@ -5695,7 +5703,7 @@ func (s *StateStore) ACLTokenBySecretID(ws memdb.WatchSet, secretID string) (*st
 	ws.Add(watchCh)
 
 	if existing != nil {
-		return existing.(*structs.ACLToken), nil
+		return s.fixTokenRoleLinks(txn, existing.(*structs.ACLToken))
 	}
 	return nil, nil
 }
@@ -5764,6 +5772,26 @@ func (s *StateStore) ACLTokensByGlobal(ws memdb.WatchSet, globalVal bool, sort S
 	return iter, nil
 }
 
+// ACLTokensByExpired returns an iterator over all ACL tokens which have an
+// expiration time set, ordered by that time with the earliest first. Tokens
+// without an expiration time are not included. It is the responsibility of
+// the caller to stop iterating once an unexpired token is found.
+//
+// The function handles global and local tokens independently as determined by
+// the global boolean argument.
+func (s *StateStore) ACLTokensByExpired(global bool) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	indexName := indexExpiresLocal
+	if global {
+		indexName = indexExpiresGlobal
+	}
+
+	// Walk the index from the start of Unix time, which orders the tokens by
+	// their expiration time, earliest first.
+	return txn.LowerBound("acl_token", indexName, time.Unix(0, 0))
+}
+
 // CanBootstrapACLToken checks if bootstrapping is possible and returns the reset index
 func (s *StateStore) CanBootstrapACLToken() (bool, uint64, error) {
 	txn := s.db.ReadTxn()
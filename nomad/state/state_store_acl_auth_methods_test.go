@@ -0,0 +1,149 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateStore_UpsertACLAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	// Create mock auth methods
+	mockedACLAuthMethods := []*structs.ACLAuthMethod{mock.ACLAuthMethod(), mock.ACLAuthMethod()}
+
+	require.NoError(t, testState.UpsertACLAuthMethods(structs.MsgTypeTestSetup, 10, mockedACLAuthMethods))
+
+	// Check that the index for the table was modified as expected.
+	initialIndex, err := testState.Index(TableACLAuthMethods)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), initialIndex)
+
+	// List all the auth methods in the table, so we can perform a number of
+	// tests on the return array.
+	ws := memdb.NewWatchSet()
+	iter, err := testState.GetACLAuthMethods(ws)
+	require.NoError(t, err)
+
+	var aclAuthMethods []*structs.ACLAuthMethod
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		aclAuthMethods = append(aclAuthMethods, raw.(*structs.ACLAuthMethod))
+	}
+	require.Len(t, aclAuthMethods, 2)
+	require.Equal(t, uint64(10), aclAuthMethods[0].CreateIndex)
+	require.Equal(t, uint64(10), aclAuthMethods[0].ModifyIndex)
+
+	// Try writing the same auth methods to state which should not result in
+	// an update to the table index.
+	require.NoError(t, testState.UpsertACLAuthMethods(structs.MsgTypeTestSetup, 20, mockedACLAuthMethods))
+	reInsertActualIndex, err := testState.Index(TableACLAuthMethods)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), reInsertActualIndex, "index should not have changed")
+
+	// Make a change to one of the methods and ensure this update is accepted
+	// and the table index is updated.
+	updatedMethod := mockedACLAuthMethods[0].Copy()
+	updatedMethod.Type = "new type"
+	updatedMethod.SetHash()
+	require.NoError(t, testState.UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLAuthMethod{updatedMethod}))
+
+	updateActualIndex, err := testState.Index(TableACLAuthMethods)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), updateActualIndex, "index should have changed")
+
+	aclAuthMethod, err := testState.GetACLAuthMethodByName(ws, updatedMethod.Name)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), aclAuthMethod.CreateIndex)
+	require.Equal(t, uint64(20), aclAuthMethod.ModifyIndex)
+	require.Equal(t, "new type", aclAuthMethod.Type)
+}
+
+func TestStateStore_DeleteACLAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	// Generate some mocked auth methods, along with a binding rule for each,
+	// and upsert these straight into state.
+	mockedACLAuthMethods := []*structs.ACLAuthMethod{mock.ACLAuthMethod(), mock.ACLAuthMethod()}
+	require.NoError(t, testState.UpsertACLAuthMethods(structs.MsgTypeTestSetup, 10, mockedACLAuthMethods))
+
+	mockedACLBindingRules := []*structs.ACLBindingRule{mock.ACLBindingRule(), mock.ACLBindingRule()}
+	mockedACLBindingRules[0].AuthMethod = mockedACLAuthMethods[0].Name
+	mockedACLBindingRules[1].AuthMethod = mockedACLAuthMethods[1].Name
+	require.NoError(t, testState.UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 10, mockedACLBindingRules, false))
+
+	// Try and delete a method using a name that doesn't exist. This should
+	// return an error and not update the table index.
+	err := testState.DeleteACLAuthMethods(structs.MsgTypeTestSetup, 20, []string{"not-a-method"})
+	require.EqualError(t, err, "ACL auth method not found")
+
+	tableIndex, err := testState.Index(TableACLAuthMethods)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), tableIndex)
+
+	// Delete one of the previously upserted auth methods. This should succeed
+	// and modify the table index.
+	require.NoError(t, testState.DeleteACLAuthMethods(
+		structs.MsgTypeTestSetup, 20, []string{mockedACLAuthMethods[0].Name}))
+
+	tableIndex, err = testState.Index(TableACLAuthMethods)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), tableIndex)
+
+	ws := memdb.NewWatchSet()
+	iter, err := testState.GetACLAuthMethods(ws)
+	require.NoError(t, err)
+
+	var aclAuthMethods []*structs.ACLAuthMethod
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		aclAuthMethods = append(aclAuthMethods, raw.(*structs.ACLAuthMethod))
+	}
+	require.Len(t, aclAuthMethods, 1)
+	require.Equal(t, mockedACLAuthMethods[1].Name, aclAuthMethods[0].Name)
+
+	// The binding rule which referenced the deleted method should have been
+	// deleted, and the binding rules table index updated.
+	rule, err := testState.GetACLBindingRule(ws, mockedACLBindingRules[0].ID)
+	require.NoError(t, err)
+	require.Nil(t, rule)
+
+	rule, err = testState.GetACLBindingRule(ws, mockedACLBindingRules[1].ID)
+	require.NoError(t, err)
+	require.NotNil(t, rule)
+
+	rulesIndex, err := testState.Index(TableACLBindingRules)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), rulesIndex)
+}
+
+func TestStateStore_GetDefaultACLAuthMethod(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	// No default method should be found when none are marked as such.
+	mockedACLAuthMethods := []*structs.ACLAuthMethod{mock.ACLAuthMethod(), mock.ACLAuthMethod()}
+	require.NoError(t, testState.UpsertACLAuthMethods(structs.MsgTypeTestSetup, 10, mockedACLAuthMethods))
+
+	out, err := testState.GetDefaultACLAuthMethod(nil)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	// Mark one method as the default and ensure it is returned.
+	defaultMethod := mockedACLAuthMethods[1].Copy()
+	defaultMethod.Default = true
+	defaultMethod.SetHash()
+	require.NoError(t, testState.UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLAuthMethod{defaultMethod}))
+
+	out, err = testState.GetDefaultACLAuthMethod(nil)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.Equal(t, defaultMethod.Name, out.Name)
+}
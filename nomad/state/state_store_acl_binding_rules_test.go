@@ -0,0 +1,147 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateStore_UpsertACLBindingRules(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	// Generate a mocked ACL binding rule for testing and attempt to upsert
+	// this straight into state. It should fail because the auth method does
+	// not exist.
+	mockedACLBindingRules := []*structs.ACLBindingRule{mock.ACLBindingRule()}
+	err := testState.UpsertACLBindingRules(structs.MsgTypeTestSetup, 10, mockedACLBindingRules, false)
+	require.ErrorContains(t, err, "ACL auth method not found")
+
+	// Create the auth method and try the upsert again, which should succeed.
+	authMethod := mock.ACLAuthMethod()
+	authMethod.Name = mockedACLBindingRules[0].AuthMethod
+	require.NoError(t, testState.UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod}))
+	require.NoError(t, testState.UpsertACLBindingRules(structs.MsgTypeTestSetup, 20, mockedACLBindingRules, false))
+
+	// Check that the index for the table was modified as expected.
+	initialIndex, err := testState.Index(TableACLBindingRules)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), initialIndex)
+
+	// List all the ACL binding rules in the table, so we can perform a number
+	// of tests on the return array.
+	ws := memdb.NewWatchSet()
+	iter, err := testState.GetACLBindingRules(ws)
+	require.NoError(t, err)
+
+	var aclBindingRules []*structs.ACLBindingRule
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		aclBindingRules = append(aclBindingRules, raw.(*structs.ACLBindingRule))
+	}
+	require.Len(t, aclBindingRules, 1)
+	require.Equal(t, uint64(20), aclBindingRules[0].CreateIndex)
+	require.Equal(t, uint64(20), aclBindingRules[0].ModifyIndex)
+
+	// Try writing the same ACL binding rule to state which should not result
+	// in an update to the table index.
+	require.NoError(t, testState.UpsertACLBindingRules(structs.MsgTypeTestSetup, 30, mockedACLBindingRules, false))
+	reInsertActualIndex, err := testState.Index(TableACLBindingRules)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), reInsertActualIndex, "index should not have changed")
+
+	// Make a change to the binding rule and ensure this update is accepted
+	// and the table index is updated.
+	updatedMockedBindingRule := mockedACLBindingRules[0].Copy()
+	updatedMockedBindingRule.Description = "updated description"
+	updatedMockedBindingRule.SetHash()
+	require.NoError(t, testState.UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 30, []*structs.ACLBindingRule{updatedMockedBindingRule}, false))
+
+	updateActualIndex, err := testState.Index(TableACLBindingRules)
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), updateActualIndex, "index should have changed")
+
+	aclBindingRule, err := testState.GetACLBindingRule(ws, updatedMockedBindingRule.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), aclBindingRule.CreateIndex)
+	require.Equal(t, uint64(30), aclBindingRule.ModifyIndex)
+	require.Equal(t, "updated description", aclBindingRule.Description)
+
+	// A binding rule linking to a missing auth method should be accepted when
+	// this is explicitly allowed, as performed by the replication process.
+	missingMethodRule := mock.ACLBindingRule()
+	missingMethodRule.AuthMethod = "missing-auth-method"
+	require.NoError(t, testState.UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 40, []*structs.ACLBindingRule{missingMethodRule}, true))
+}
+
+func TestStateStore_DeleteACLBindingRules(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	// Generate some mocked ACL binding rules for testing and upsert these
+	// straight into state.
+	mockedACLBindingRules := []*structs.ACLBindingRule{mock.ACLBindingRule(), mock.ACLBindingRule()}
+	require.NoError(t, testState.UpsertACLBindingRules(structs.MsgTypeTestSetup, 10, mockedACLBindingRules, true))
+
+	// Try and delete a binding rule using an ID that doesn't exist. This
+	// should return an error and not update the table index.
+	err := testState.DeleteACLBindingRules(structs.MsgTypeTestSetup, 20, []string{"not-a-rule"})
+	require.EqualError(t, err, "ACL binding rule not found")
+
+	tableIndex, err := testState.Index(TableACLBindingRules)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), tableIndex)
+
+	// Delete one of the previously upserted ACL binding rules. This should
+	// succeed and modify the table index.
+	require.NoError(t, testState.DeleteACLBindingRules(
+		structs.MsgTypeTestSetup, 20, []string{mockedACLBindingRules[0].ID}))
+
+	tableIndex, err = testState.Index(TableACLBindingRules)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), tableIndex)
+
+	ws := memdb.NewWatchSet()
+	iter, err := testState.GetACLBindingRules(ws)
+	require.NoError(t, err)
+
+	var aclBindingRules []*structs.ACLBindingRule
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		aclBindingRules = append(aclBindingRules, raw.(*structs.ACLBindingRule))
+	}
+	require.Len(t, aclBindingRules, 1)
+	require.Equal(t, mockedACLBindingRules[1].ID, aclBindingRules[0].ID)
+}
+
+func TestStateStore_GetACLBindingRulesByAuthMethod(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	// Generate some mocked ACL binding rules for testing, linked to two
+	// different auth methods, and upsert these straight into state.
+	mockedACLBindingRules := []*structs.ACLBindingRule{
+		mock.ACLBindingRule(), mock.ACLBindingRule(), mock.ACLBindingRule()}
+	mockedACLBindingRules[2].AuthMethod = "other-auth-method"
+	require.NoError(t, testState.UpsertACLBindingRules(structs.MsgTypeTestSetup, 10, mockedACLBindingRules, true))
+
+	ws := memdb.NewWatchSet()
+	iter, err := testState.GetACLBindingRulesByAuthMethod(ws, mockedACLBindingRules[0].AuthMethod)
+	require.NoError(t, err)
+
+	var ids []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ids = append(ids, raw.(*structs.ACLBindingRule).ID)
+	}
+	require.ElementsMatch(t, []string{mockedACLBindingRules[0].ID, mockedACLBindingRules[1].ID}, ids)
+
+	// A lookup for an auth method with no rules should return nothing.
+	iter, err = testState.GetACLBindingRulesByAuthMethod(ws, "not-an-auth-method")
+	require.NoError(t, err)
+	require.Nil(t, iter.Next())
+}
@@ -0,0 +1,250 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateStore_UpsertACLRoles(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	// Mock the policies our test roles will link to and upsert these.
+	policy1 := mock.ACLPolicy()
+	policy1.Name = "mocked-test-policy-1"
+	policy2 := mock.ACLPolicy()
+	policy2.Name = "mocked-test-policy-2"
+
+	require.NoError(t, testState.UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLPolicy{policy1, policy2}))
+
+	// Generate a mocked ACL role for testing and attempt to upsert this
+	// straight into state. It should fail because the ACL policies do not
+	// exist.
+	mockedACLRoles := []*structs.ACLRole{mock.ACLRole()}
+	mockedACLRoles[0].Policies = append(mockedACLRoles[0].Policies,
+		&structs.ACLRolePolicyLink{Name: "missing-policy"})
+	err := testState.UpsertACLRoles(structs.MsgTypeTestSetup, 20, mockedACLRoles, false)
+	require.ErrorContains(t, err, "ACL policy not found")
+
+	// Remove the link to the missing policy, and the upsert should succeed.
+	mockedACLRoles[0].Policies = mockedACLRoles[0].Policies[:2]
+	mockedACLRoles[0].SetHash()
+	require.NoError(t, testState.UpsertACLRoles(structs.MsgTypeTestSetup, 20, mockedACLRoles, false))
+
+	// Check that the index for the table was modified as expected.
+	initialIndex, err := testState.Index(TableACLRoles)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), initialIndex)
+
+	// List all the ACL roles in the table, so we can perform a number of tests
+	// on the return array.
+	ws := memdb.NewWatchSet()
+	iter, err := testState.GetACLRoles(ws)
+	require.NoError(t, err)
+
+	var aclRoles []*structs.ACLRole
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		aclRoles = append(aclRoles, raw.(*structs.ACLRole))
+	}
+	require.Len(t, aclRoles, 1)
+	require.Equal(t, uint64(20), aclRoles[0].CreateIndex)
+	require.Equal(t, uint64(20), aclRoles[0].ModifyIndex)
+
+	// Try writing the same ACL role to state which should not result in an
+	// update to the table index.
+	require.NoError(t, testState.UpsertACLRoles(structs.MsgTypeTestSetup, 30, mockedACLRoles, false))
+	reInsertActualIndex, err := testState.Index(TableACLRoles)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), reInsertActualIndex, "index should not have changed")
+
+	// Make a change to the role and ensure this update is accepted and the
+	// table index is updated.
+	updatedMockedRole := mockedACLRoles[0].Copy()
+	updatedMockedRole.Policies = []*structs.ACLRolePolicyLink{{Name: "mocked-test-policy-1"}}
+	updatedMockedRole.SetHash()
+	require.NoError(t, testState.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 30, []*structs.ACLRole{updatedMockedRole}, false))
+
+	updateActualIndex, err := testState.Index(TableACLRoles)
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), updateActualIndex, "index should have changed")
+
+	aclRole, err := testState.GetACLRoleByID(ws, updatedMockedRole.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), aclRole.CreateIndex)
+	require.Equal(t, uint64(30), aclRole.ModifyIndex)
+	require.Len(t, aclRole.Policies, 1)
+
+	// Try adding a new ACL role with the same name as the existing role,
+	// which should fail.
+	duplicateNameRole := mock.ACLRole()
+	duplicateNameRole.Name = updatedMockedRole.Name
+	err = testState.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 40, []*structs.ACLRole{duplicateNameRole}, false)
+	require.ErrorContains(t, err, "already exists")
+
+	// A role linking to missing policies should be accepted when this is
+	// explicitly allowed, as performed by the replication process.
+	missingPolicyRole := mock.ACLRole()
+	missingPolicyRole.Policies = []*structs.ACLRolePolicyLink{{Name: "missing-policy"}}
+	missingPolicyRole.SetHash()
+	require.NoError(t, testState.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 40, []*structs.ACLRole{missingPolicyRole}, true))
+}
+
+func TestStateStore_DeleteACLRolesByID(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	// Generate some mocked ACL roles for testing and upsert these straight
+	// into state.
+	mockedACLRoles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	require.NoError(t, testState.UpsertACLRoles(structs.MsgTypeTestSetup, 10, mockedACLRoles, true))
+
+	// Try and delete a role using an ID that doesn't exist. This should
+	// return an error and not update the table index.
+	err := testState.DeleteACLRolesByID(structs.MsgTypeTestSetup, 20, []string{"not-a-role"})
+	require.EqualError(t, err, "ACL role not found")
+
+	tableIndex, err := testState.Index(TableACLRoles)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), tableIndex)
+
+	// Delete one of the previously upserted ACL roles. This should succeed
+	// and modify the table index.
+	require.NoError(t, testState.DeleteACLRolesByID(
+		structs.MsgTypeTestSetup, 20, []string{mockedACLRoles[0].ID}))
+
+	tableIndex, err = testState.Index(TableACLRoles)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), tableIndex)
+
+	ws := memdb.NewWatchSet()
+	iter, err := testState.GetACLRoles(ws)
+	require.NoError(t, err)
+
+	var aclRoles []*structs.ACLRole
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		aclRoles = append(aclRoles, raw.(*structs.ACLRole))
+	}
+	require.Len(t, aclRoles, 1)
+	require.Equal(t, mockedACLRoles[1].ID, aclRoles[0].ID)
+}
+
+func TestStateStore_GetACLRoleByName(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	mockedACLRoles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	require.NoError(t, testState.UpsertACLRoles(structs.MsgTypeTestSetup, 10, mockedACLRoles, true))
+
+	ws := memdb.NewWatchSet()
+
+	// Try reading an ACL role that does not exist.
+	aclRole, err := testState.GetACLRoleByName(ws, "not-a-role")
+	require.NoError(t, err)
+	require.Nil(t, aclRole)
+
+	// Read the two ACL roles that we should find.
+	for _, role := range mockedACLRoles {
+		aclRole, err = testState.GetACLRoleByName(ws, role.Name)
+		require.NoError(t, err)
+		require.Equal(t, role, aclRole)
+	}
+}
+
+func TestStateStore_GetACLRoleByIDPrefix(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	// Generate a some mocked ACL roles for testing and upsert these straight
+	// into state. Set the ID to something with a prefix we know so it is easy
+	// to test.
+	mockedACLRoles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	mockedACLRoles[0].ID = "test-prefix-" + mockedACLRoles[0].ID
+	mockedACLRoles[1].ID = "test-prefix-" + mockedACLRoles[1].ID
+	require.NoError(t, testState.UpsertACLRoles(structs.MsgTypeTestSetup, 10, mockedACLRoles, true))
+
+	ws := memdb.NewWatchSet()
+
+	// Try using a prefix that doesn't match any entries.
+	iter, err := testState.GetACLRoleByIDPrefix(ws, "nope")
+	require.NoError(t, err)
+	require.Nil(t, iter.Next())
+
+	// Use a prefix which should match two entries in state.
+	iter, err = testState.GetACLRoleByIDPrefix(ws, "test-prefix-")
+	require.NoError(t, err)
+
+	var aclRoles []*structs.ACLRole
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		aclRoles = append(aclRoles, raw.(*structs.ACLRole))
+	}
+	require.Len(t, aclRoles, 2)
+}
+
+func TestStateStore_fixTokenRoleLinks(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	// Create two ACL roles and a token linked to both of them.
+	mockedACLRoles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	require.NoError(t, testState.UpsertACLRoles(structs.MsgTypeTestSetup, 10, mockedACLRoles, true))
+
+	token := mock.ACLToken()
+	token.Policies = nil
+	token.Roles = []*structs.ACLTokenRoleLink{
+		{ID: mockedACLRoles[0].ID, Name: mockedACLRoles[0].Name},
+		{ID: mockedACLRoles[1].ID, Name: mockedACLRoles[1].Name},
+	}
+	require.NoError(t, testState.UpsertACLTokens(structs.MsgTypeTestSetup, 20, []*structs.ACLToken{token}))
+
+	// With no changes to the roles, the token should be returned unmodified.
+	ws := memdb.NewWatchSet()
+	out, err := testState.ACLTokenByAccessorID(ws, token.AccessorID)
+	require.NoError(t, err)
+	require.Equal(t, token.Roles, out.Roles)
+
+	// Rename the first role and ensure the token link reflects the new name.
+	renamedRole := mockedACLRoles[0].Copy()
+	renamedRole.Name = "renamed-role"
+	renamedRole.SetHash()
+	require.NoError(t, testState.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 30, []*structs.ACLRole{renamedRole}, true))
+
+	out, err = testState.ACLTokenByAccessorID(ws, token.AccessorID)
+	require.NoError(t, err)
+	require.Len(t, out.Roles, 2)
+	require.Equal(t, "renamed-role", out.Roles[0].Name)
+	require.Equal(t, mockedACLRoles[1].Name, out.Roles[1].Name)
+
+	// The rename should have been written to the token in state, so that
+	// listings which do not fix links also reflect the new name.
+	iter, err := testState.ACLTokens(ws, SortDefault)
+	require.NoError(t, err)
+	listed := iter.Next().(*structs.ACLToken)
+	require.Equal(t, "renamed-role", listed.Roles[0].Name)
+	require.Equal(t, uint64(30), listed.ModifyIndex)
+
+	tokenIndex, err := testState.Index("acl_token")
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), tokenIndex)
+
+	// Delete the second role and ensure the token link is removed.
+	require.NoError(t, testState.DeleteACLRolesByID(
+		structs.MsgTypeTestSetup, 40, []string{mockedACLRoles[1].ID}))
+
+	out, err = testState.ACLTokenBySecretID(ws, token.SecretID)
+	require.NoError(t, err)
+	require.Equal(t, []*structs.ACLTokenRoleLink{{ID: renamedRole.ID, Name: "renamed-role"}}, out.Roles)
+
+	// The token within state should not have been modified.
+	require.Len(t, token.Roles, 2)
+	require.Equal(t, mockedACLRoles[0].Name, token.Roles[0].Name)
+}
@@ -0,0 +1,238 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// nodePoolInit creates the built-in node pools. This is safe to do every time
+// we create the state store, as any persisted pools will be overridden by the
+// restore code path.
+func (s *StateStore) nodePoolInit() error {
+	if err := s.UpsertNodePools(structs.MsgTypeTestSetup, 1, structs.BuiltInNodePools()); err != nil {
+		return fmt.Errorf("inserting built-in node pools failed: %v", err)
+	}
+	return nil
+}
+
+// UpsertNodePools is used to insert a number of node pools into the state
+// store. It uses a single write transaction for efficiency, however, any
+// error means no entries will be committed.
+func (s *StateStore) UpsertNodePools(
+	msgType structs.MessageType, index uint64, pools []*structs.NodePool) error {
+
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	// updated tracks whether any inserts have been made. This allows us to
+	// skip updating the index table if we do not need to.
+	var updated bool
+
+	for _, pool := range pools {
+		poolUpdated, err := s.upsertNodePoolTxn(index, txn, pool)
+		if err != nil {
+			return err
+		}
+		updated = updated || poolUpdated
+	}
+
+	// If we did not perform any inserts, exit early.
+	if !updated {
+		return nil
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableNodePools, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// upsertNodePoolTxn inserts a single node pool into the state store using the
+// provided write transaction. It is the responsibility of the caller to
+// update the index table.
+func (s *StateStore) upsertNodePoolTxn(index uint64, txn *txn, pool *structs.NodePool) (bool, error) {
+
+	// Ensure the pool hash is not zero to provide defense in depth. This
+	// should be done outside the state store for performance reasons, so we
+	// do not spend time within the Raft apply.
+	if len(pool.Hash) == 0 {
+		pool.SetHash()
+	}
+
+	existingRaw, err := txn.First(TableNodePools, indexID, pool.Name)
+	if err != nil {
+		return false, fmt.Errorf("node pool lookup failed: %v", err)
+	}
+
+	if existingRaw != nil {
+		existing := existingRaw.(*structs.NodePool)
+
+		// If the pool already exists, check whether the update contains any
+		// difference. If it doesn't, we can avoid a state update as well as
+		// updates to any blocking queries.
+		if bytes.Equal(existing.Hash, pool.Hash) {
+			return false, nil
+		}
+
+		pool.CreateIndex = existing.CreateIndex
+		pool.ModifyIndex = index
+	} else {
+		pool.CreateIndex = index
+		pool.ModifyIndex = index
+	}
+
+	if err := txn.Insert(TableNodePools, pool); err != nil {
+		return false, fmt.Errorf("node pool insert failed: %v", err)
+	}
+	return true, nil
+}
+
+// upsertNodePoolForNodeTxn creates the node pool of a registering node if it
+// does not already exist, so that operators do not have to create pools
+// before clients can join them. It is the responsibility of the caller to
+// pass a node pool name which has been validated.
+func upsertNodePoolForNodeTxn(txn *txn, index uint64, poolName string) error {
+	if poolName == "" || poolName == structs.NodePoolDefault {
+		return nil
+	}
+
+	existing, err := txn.First(TableNodePools, indexID, poolName)
+	if err != nil {
+		return fmt.Errorf("node pool lookup failed: %v", err)
+	}
+	if existing != nil {
+		return nil
+	}
+
+	pool := &structs.NodePool{
+		Name:        poolName,
+		CreateIndex: index,
+		ModifyIndex: index,
+	}
+	pool.SetHash()
+
+	if err := txn.Insert(TableNodePools, pool); err != nil {
+		return fmt.Errorf("node pool insert failed: %v", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableNodePools, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// DeleteNodePools is responsible for batch deleting node pools. It uses a
+// single write transaction for efficiency, however, any error means no
+// entries will be committed. Built-in pools, and pools which still contain
+// nodes or jobs, cannot be deleted.
+func (s *StateStore) DeleteNodePools(msgType structs.MessageType, index uint64, names []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, name := range names {
+		if err := s.deleteNodePoolTxn(txn, name); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableNodePools, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// deleteNodePoolTxn deletes a single node pool from the state store using the
+// provided write transaction. It is the responsibility of the caller to
+// update the index table.
+func (s *StateStore) deleteNodePoolTxn(txn *txn, name string) error {
+
+	existing, err := txn.First(TableNodePools, indexID, name)
+	if err != nil {
+		return fmt.Errorf("node pool lookup failed: %v", err)
+	}
+	if existing == nil {
+		return errors.New("node pool not found")
+	}
+
+	pool := existing.(*structs.NodePool)
+	if pool.IsBuiltIn() {
+		return fmt.Errorf("node pool %q is built-in and cannot be deleted", name)
+	}
+
+	// Deleting a pool which is in use would leave nodes or jobs referencing a
+	// pool that does not exist.
+	nodeIter, err := txn.Get("nodes", "id")
+	if err != nil {
+		return fmt.Errorf("node lookup failed: %v", err)
+	}
+	for raw := nodeIter.Next(); raw != nil; raw = nodeIter.Next() {
+		if raw.(*structs.Node).NodePool == name {
+			return fmt.Errorf("node pool %q has nodes", name)
+		}
+	}
+
+	jobIter, err := txn.Get("jobs", "id")
+	if err != nil {
+		return fmt.Errorf("job lookup failed: %v", err)
+	}
+	for raw := jobIter.Next(); raw != nil; raw = jobIter.Next() {
+		if raw.(*structs.Job).NodePool == name {
+			return fmt.Errorf("node pool %q has jobs", name)
+		}
+	}
+
+	if err := txn.Delete(TableNodePools, pool); err != nil {
+		return fmt.Errorf("node pool deletion failed: %v", err)
+	}
+	return nil
+}
+
+// NodePools returns an iterator over all the node pools stored within state.
+func (s *StateStore) NodePools(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableNodePools, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("node pool lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// NodePoolsByNamePrefix returns an iterator over all the node pools whose
+// name matches the passed prefix.
+func (s *StateStore) NodePoolsByNamePrefix(ws memdb.WatchSet, prefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableNodePools, indexID+"_prefix", prefix)
+	if err != nil {
+		return nil, fmt.Errorf("node pool lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// NodePoolByName returns a single node pool specified by the input name. The
+// pool object will be nil, if no matching entry was found; it is the
+// responsibility of the caller to check for this.
+func (s *StateStore) NodePoolByName(ws memdb.WatchSet, name string) (*structs.NodePool, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableNodePools, indexID, name)
+	if err != nil {
+		return nil, fmt.Errorf("node pool lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.NodePool), nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,128 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateStore_NodePoolInit(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	// The built-in node pools should always exist.
+	for _, name := range []string{structs.NodePoolAll, structs.NodePoolDefault} {
+		pool, err := testState.NodePoolByName(nil, name)
+		require.NoError(t, err)
+		require.NotNil(t, pool)
+		require.True(t, pool.IsBuiltIn())
+	}
+}
+
+func TestStateStore_UpsertNodePools(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	pool1, pool2 := mock.NodePool(), mock.NodePool()
+	require.NoError(t, testState.UpsertNodePools(structs.MsgTypeTestSetup, 10, []*structs.NodePool{pool1, pool2}))
+
+	// Check that the index for the table was modified as expected.
+	initialIndex, err := testState.Index(TableNodePools)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), initialIndex)
+
+	ws := memdb.NewWatchSet()
+	out, err := testState.NodePoolByName(ws, pool1.Name)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), out.CreateIndex)
+	require.Equal(t, uint64(10), out.ModifyIndex)
+
+	// Upserting the same pool again should not cause an update.
+	require.NoError(t, testState.UpsertNodePools(structs.MsgTypeTestSetup, 20, []*structs.NodePool{pool1.Copy()}))
+	unchangedIndex, err := testState.Index(TableNodePools)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), unchangedIndex)
+	require.False(t, watchFired(ws))
+
+	// Modify the pool and ensure the indexes are updated.
+	updated := pool1.Copy()
+	updated.Description = "updated"
+	updated.SetHash()
+	require.NoError(t, testState.UpsertNodePools(structs.MsgTypeTestSetup, 30, []*structs.NodePool{updated}))
+	require.True(t, watchFired(ws))
+
+	out, err = testState.NodePoolByName(nil, pool1.Name)
+	require.NoError(t, err)
+	require.Equal(t, "updated", out.Description)
+	require.Equal(t, uint64(10), out.CreateIndex)
+	require.Equal(t, uint64(30), out.ModifyIndex)
+
+	// Check the prefix lookup.
+	iter, err := testState.NodePoolsByNamePrefix(nil, "pool-")
+	require.NoError(t, err)
+
+	var pools []*structs.NodePool
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		pools = append(pools, raw.(*structs.NodePool))
+	}
+	require.Len(t, pools, 2)
+}
+
+func TestStateStore_UpsertNode_CreatesNodePool(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	node := mock.Node()
+	node.NodePool = "new-pool"
+	require.NoError(t, testState.UpsertNode(structs.MsgTypeTestSetup, 10, node))
+
+	pool, err := testState.NodePoolByName(nil, "new-pool")
+	require.NoError(t, err)
+	require.NotNil(t, pool)
+	require.Equal(t, uint64(10), pool.CreateIndex)
+}
+
+func TestStateStore_DeleteNodePools(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	pool1, pool2, pool3 := mock.NodePool(), mock.NodePool(), mock.NodePool()
+	require.NoError(t, testState.UpsertNodePools(
+		structs.MsgTypeTestSetup, 10, []*structs.NodePool{pool1, pool2, pool3}))
+
+	node := mock.Node()
+	node.NodePool = pool2.Name
+	require.NoError(t, testState.UpsertNode(structs.MsgTypeTestSetup, 20, node))
+
+	job := mock.Job()
+	job.NodePool = pool3.Name
+	require.NoError(t, testState.UpsertJob(structs.MsgTypeTestSetup, 30, job))
+
+	// Pools which are in use or built-in cannot be deleted.
+	err := testState.DeleteNodePools(structs.MsgTypeTestSetup, 40, []string{pool2.Name})
+	require.ErrorContains(t, err, "has nodes")
+
+	err = testState.DeleteNodePools(structs.MsgTypeTestSetup, 40, []string{pool3.Name})
+	require.ErrorContains(t, err, "has jobs")
+
+	err = testState.DeleteNodePools(structs.MsgTypeTestSetup, 40, []string{structs.NodePoolDefault})
+	require.ErrorContains(t, err, "built-in")
+
+	err = testState.DeleteNodePools(structs.MsgTypeTestSetup, 40, []string{"does-not-exist"})
+	require.ErrorContains(t, err, "not found")
+
+	// Delete the unused pool.
+	require.NoError(t, testState.DeleteNodePools(structs.MsgTypeTestSetup, 50, []string{pool1.Name}))
+
+	out, err := testState.NodePoolByName(nil, pool1.Name)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	index, err := testState.Index(TableNodePools)
+	require.NoError(t, err)
+	require.Equal(t, uint64(50), index)
+}
@@ -207,6 +207,15 @@ func (r *StateRestore) SecureVariablesRestore(variable *structs.SecureVariableEn
 	return nil
 }
 
+// SecureVariableVersionRestore is used to restore a single tracked secure
+// variable version into the secure_variables_versions table.
+func (r *StateRestore) SecureVariableVersionRestore(version *structs.SecureVariableEncrypted) error {
+	if err := r.txn.Insert(TableSecureVariableVersions, version); err != nil {
+		return fmt.Errorf("secure variable version insert failed: %v", err)
+	}
+	return nil
+}
+
 // SecureVariablesQuotaRestore is used to restore a single secure variable quota
 // into the secure_variables_quota table.
 func (r *StateRestore) SecureVariablesQuotaRestore(quota *structs.SecureVariablesQuota) error {
@@ -224,3 +233,39 @@ func (r *StateRestore) RootKeyMetaRestore(quota *structs.RootKeyMeta) error {
 	}
 	return nil
 }
+
+// ACLRoleRestore is used to restore a single ACL role into the acl_roles
+// table.
+func (r *StateRestore) ACLRoleRestore(aclRole *structs.ACLRole) error {
+	if err := r.txn.Insert(TableACLRoles, aclRole); err != nil {
+		return fmt.Errorf("ACL role insert failed: %v", err)
+	}
+	return nil
+}
+
+// ACLAuthMethodRestore is used to restore a single ACL auth method into the
+// acl_auth_methods table.
+func (r *StateRestore) ACLAuthMethodRestore(aclAuthMethod *structs.ACLAuthMethod) error {
+	if err := r.txn.Insert(TableACLAuthMethods, aclAuthMethod); err != nil {
+		return fmt.Errorf("ACL auth method insert failed: %v", err)
+	}
+	return nil
+}
+
+// ACLBindingRuleRestore is used to restore a single ACL binding rule into the
+// acl_binding_rules table.
+func (r *StateRestore) ACLBindingRuleRestore(aclBindingRule *structs.ACLBindingRule) error {
+	if err := r.txn.Insert(TableACLBindingRules, aclBindingRule); err != nil {
+		return fmt.Errorf("ACL binding rule insert failed: %v", err)
+	}
+	return nil
+}
+
+// NodePoolRestore is used to restore a single node pool into the node_pools
+// table.
+func (r *StateRestore) NodePoolRestore(pool *structs.NodePool) error {
+	if err := r.txn.Insert(TableNodePools, pool); err != nil {
+		return fmt.Errorf("node pool insert failed: %v", err)
+	}
+	return nil
+}
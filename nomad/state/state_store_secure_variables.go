@@ -3,6 +3,7 @@ package state
 import (
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/hashicorp/go-memdb"
 
@@ -194,6 +195,20 @@ func (s *StateStore) svSetTxn(tx WriteTxn, idx uint64, req *structs.SVApplyState
 	}
 	existing, _ := existingRaw.(*structs.SecureVariableEncrypted)
 
+	// A locked variable can only be modified by the lock holder, and writes
+	// always retain the existing lock. Only a lock acquire is allowed to set
+	// the lock of a variable.
+	if req.Op != structs.SVOpLockAcquire {
+		if existing != nil && existing.IsLocked() {
+			if !existing.IsLockHolder(sv.Lock) {
+				return req.ConflictResponse(idx, existing)
+			}
+			sv.Lock = existing.Lock.Copy()
+		} else {
+			sv.Lock = nil
+		}
+	}
+
 	existingQuota, err := tx.First(TableSecureVariablesQuotas, indexID, sv.Namespace)
 	if err != nil {
 		return req.ErrorResponse(idx, fmt.Errorf("secure variable quota lookup failed: %v", err))
@@ -201,10 +216,11 @@ func (s *StateStore) svSetTxn(tx WriteTxn, idx uint64, req *structs.SVApplyState
 
 	var quotaChange int64
 
-	// Set the CreateIndex and CreateTime
+	// Set the CreateIndex, CreateTime and Version
 	if existing != nil {
 		sv.CreateIndex = existing.CreateIndex
 		sv.CreateTime = existing.CreateTime
+		sv.Version = existing.Version
 
 		if existing.Equals(*sv) {
 			// Skip further writing in the state store if the entry is not actually
@@ -215,16 +231,23 @@ func (s *StateStore) svSetTxn(tx WriteTxn, idx uint64, req *structs.SVApplyState
 			return req.SuccessResponse(idx, nil)
 		}
 		sv.ModifyIndex = idx
+		if !existing.SecureVariableData.Equals(sv.SecureVariableData) {
+			sv.Version = existing.Version + 1
+		}
 		quotaChange = int64(len(sv.Data) - len(existing.Data))
 	} else {
 		sv.CreateIndex = idx
 		sv.ModifyIndex = idx
+		sv.Version = 0
 		quotaChange = int64(len(sv.Data))
 	}
 
 	if err := tx.Insert(TableSecureVariables, sv); err != nil {
 		return req.ErrorResponse(idx, fmt.Errorf("failed inserting secure variable: %s", err))
 	}
+	if err := s.svUpsertVersionTxn(tx, idx, sv); err != nil {
+		return req.ErrorResponse(idx, err)
+	}
 
 	// Track quota usage
 	var quotaUsed *structs.SecureVariablesQuota
@@ -402,6 +425,11 @@ func (s *StateStore) svDeleteTxn(tx WriteTxn, idx uint64, req *structs.SVApplySt
 
 	sv := existingRaw.(*structs.SecureVariableEncrypted)
 
+	// A locked variable can only be deleted by the lock holder.
+	if sv.IsLocked() && !sv.IsLockHolder(req.Var.Lock) {
+		return req.ConflictResponse(idx, sv)
+	}
+
 	// Track quota usage
 	if existingQuota != nil {
 		quotaUsed := existingQuota.(*structs.SecureVariablesQuota)
@@ -418,6 +446,12 @@ func (s *StateStore) svDeleteTxn(tx WriteTxn, idx uint64, req *structs.SVApplySt
 		return req.ErrorResponse(idx, fmt.Errorf("failed deleting secure variable entry: %s", err))
 	}
 
+	// Deleting a secure variable also deletes its tracked versions, so that
+	// no secret material is retained once it has been deleted.
+	if err := s.svDeleteVersionsTxn(tx, idx, sv.Namespace, sv.Path); err != nil {
+		return req.ErrorResponse(idx, err)
+	}
+
 	if err := tx.Insert(tableIndex, &IndexEntry{TableSecureVariables, idx}); err != nil {
 		return req.ErrorResponse(idx, fmt.Errorf("failed updating secure variable index: %s", err))
 	}
@@ -425,6 +459,297 @@ func (s *StateStore) svDeleteTxn(tx WriteTxn, idx uint64, req *structs.SVApplySt
 	return req.SuccessResponse(idx, nil)
 }
 
+// SVELockAcquire is used to acquire the lock on a secure variable. If the
+// variable does not exist, it is created with the provided items, otherwise
+// only its lock is modified. A conflict is returned if the variable is
+// already locked.
+func (s *StateStore) SVELockAcquire(idx uint64, req *structs.SVApplyStateRequest) *structs.SVApplyStateResponse {
+	tx := s.db.WriteTxn(idx)
+	defer tx.Abort()
+
+	sv := req.Var
+	raw, err := tx.First(TableSecureVariables, indexID, sv.Namespace, sv.Path)
+	if err != nil {
+		return req.ErrorResponse(idx, fmt.Errorf("failed secure variable lookup: %s", err))
+	}
+
+	var resp *structs.SVApplyStateResponse
+	if raw == nil {
+		resp = s.svSetTxn(tx, idx, req)
+	} else {
+		existing := raw.(*structs.SecureVariableEncrypted)
+		if existing.IsLocked() {
+			return req.ConflictResponse(idx, existing)
+		}
+
+		updated := existing.Copy()
+		updated.Lock = sv.Lock.Copy()
+		resp = s.svUpdateLockTxn(tx, idx, req, &updated)
+	}
+	if !resp.IsOk() {
+		return resp
+	}
+
+	if err := tx.Commit(); err != nil {
+		return req.ErrorResponse(idx, err)
+	}
+	return resp
+}
+
+// SVELockRelease is used to release the lock on a secure variable. The
+// variable itself is retained. A conflict is returned if the variable does
+// not exist or the request does not identify the current lock holder.
+func (s *StateStore) SVELockRelease(idx uint64, req *structs.SVApplyStateRequest) *structs.SVApplyStateResponse {
+	tx := s.db.WriteTxn(idx)
+	defer tx.Abort()
+
+	sv := req.Var
+	raw, err := tx.First(TableSecureVariables, indexID, sv.Namespace, sv.Path)
+	if err != nil {
+		return req.ErrorResponse(idx, fmt.Errorf("failed secure variable lookup: %s", err))
+	}
+	if raw == nil {
+		zeroVal := &structs.SecureVariableEncrypted{
+			SecureVariableMetadata: structs.SecureVariableMetadata{
+				Namespace: sv.Namespace,
+				Path:      sv.Path,
+			},
+		}
+		return req.ConflictResponse(idx, zeroVal)
+	}
+
+	existing := raw.(*structs.SecureVariableEncrypted)
+	if !existing.IsLockHolder(sv.Lock) {
+		return req.ConflictResponse(idx, existing)
+	}
+
+	updated := existing.Copy()
+	updated.Lock = nil
+	resp := s.svUpdateLockTxn(tx, idx, req, &updated)
+	if !resp.IsOk() {
+		return resp
+	}
+
+	if err := tx.Commit(); err != nil {
+		return req.ErrorResponse(idx, err)
+	}
+	return resp
+}
+
+// svUpdateLockTxn writes a copy of an existing secure variable whose lock
+// has been modified. The encrypted data is unchanged, so the quota usage
+// does not need to be updated.
+func (s *StateStore) svUpdateLockTxn(tx WriteTxn, idx uint64,
+	req *structs.SVApplyStateRequest, updated *structs.SecureVariableEncrypted) *structs.SVApplyStateResponse {
+
+	updated.ModifyIndex = idx
+	updated.ModifyTime = req.Var.ModifyTime
+
+	if err := tx.Insert(TableSecureVariables, updated); err != nil {
+		return req.ErrorResponse(idx, fmt.Errorf("failed inserting secure variable: %s", err))
+	}
+	if err := tx.Insert(tableIndex,
+		&IndexEntry{TableSecureVariables, idx}); err != nil {
+		return req.ErrorResponse(idx, fmt.Errorf("failed updating secure variable index: %s", err))
+	}
+
+	return req.SuccessResponse(idx, &updated.SecureVariableMetadata)
+}
+
+// SVERekey replaces the encrypted data of tracked versions of secure
+// variables which were re-encrypted with a new root key. The current version
+// of each variable is also replaced if it is the re-encrypted version. Only
+// versions which are still encrypted with the key the request was made for
+// are replaced, so versions written or rekeyed concurrently are left as is.
+// The plaintext of a version never changes, so the version and indexes of the
+// variables are kept, and so is their quota usage as the size of the
+// ciphertext only depends on the size of the plaintext.
+func (s *StateStore) SVERekey(idx uint64, req *structs.SVRekeyStateRequest) error {
+	tx := s.db.WriteTxn(idx)
+	defer tx.Abort()
+
+	var updated, versionsUpdated bool
+	for _, rekeyed := range req.Versions {
+		raw, err := tx.First(TableSecureVariables, indexID, rekeyed.Namespace, rekeyed.Path)
+		if err != nil {
+			return fmt.Errorf("failed sve lookup: %s", err)
+		}
+		if existing, ok := raw.(*structs.SecureVariableEncrypted); ok &&
+			existing.Version == rekeyed.Version && existing.KeyID == req.KeyID {
+			sv := existing.Copy()
+			sv.SecureVariableData = rekeyed.SecureVariableData.Copy()
+			if err := tx.Insert(TableSecureVariables, &sv); err != nil {
+				return fmt.Errorf("failed inserting secure variable: %s", err)
+			}
+			updated = true
+		}
+
+		raw, err = tx.First(TableSecureVariableVersions, indexID,
+			rekeyed.Namespace, rekeyed.Path, rekeyed.Version)
+		if err != nil {
+			return fmt.Errorf("secure variable version lookup failed: %v", err)
+		}
+		if existing, ok := raw.(*structs.SecureVariableEncrypted); ok && existing.KeyID == req.KeyID {
+			version := existing.Copy()
+			version.SecureVariableData = rekeyed.SecureVariableData.Copy()
+			if err := tx.Insert(TableSecureVariableVersions, &version); err != nil {
+				return fmt.Errorf("failed inserting secure variable version: %s", err)
+			}
+			versionsUpdated = true
+		}
+	}
+
+	if updated {
+		if err := tx.Insert(tableIndex, &IndexEntry{TableSecureVariables, idx}); err != nil {
+			return fmt.Errorf("failed updating secure variable index: %s", err)
+		}
+	}
+	if versionsUpdated {
+		if err := tx.Insert(tableIndex,
+			&IndexEntry{TableSecureVariableVersions, idx}); err != nil {
+			return fmt.Errorf("failed updating secure variable version index: %s", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// svUpsertVersionTxn tracks the version of a secure variable which has just
+// been written, and deletes the oldest tracked version if more than
+// SecureVariableTrackedVersions versions are held.
+func (s *StateStore) svUpsertVersionTxn(tx WriteTxn, idx uint64, sv *structs.SecureVariableEncrypted) error {
+
+	// Tracked versions are never locked; the lock only applies to the
+	// current version of the variable.
+	version := sv.Copy()
+	version.Lock = nil
+
+	if err := tx.Insert(TableSecureVariableVersions, &version); err != nil {
+		return fmt.Errorf("failed inserting secure variable version: %s", err)
+	}
+	if err := tx.Insert(tableIndex,
+		&IndexEntry{TableSecureVariableVersions, idx}); err != nil {
+		return fmt.Errorf("failed updating secure variable version index: %s", err)
+	}
+
+	all, err := svVersionsTxn(tx, nil, sv.Namespace, sv.Path)
+	if err != nil {
+		return err
+	}
+
+	// Delete the versions outside of the set being kept
+	for _, old := range all[helper.Min(len(all), structs.SecureVariableTrackedVersions):] {
+		if err := tx.Delete(TableSecureVariableVersions, old); err != nil {
+			return fmt.Errorf("failed deleting secure variable version %d: %s", old.Version, err)
+		}
+	}
+	return nil
+}
+
+// svDeleteVersionsTxn deletes all the tracked versions of a secure variable.
+func (s *StateStore) svDeleteVersionsTxn(tx WriteTxn, idx uint64, namespace, path string) error {
+	all, err := svVersionsTxn(tx, nil, namespace, path)
+	if err != nil {
+		return err
+	}
+	if len(all) == 0 {
+		return nil
+	}
+
+	for _, version := range all {
+		if err := tx.Delete(TableSecureVariableVersions, version); err != nil {
+			return fmt.Errorf("failed deleting secure variable version %d: %s", version.Version, err)
+		}
+	}
+	if err := tx.Insert(tableIndex,
+		&IndexEntry{TableSecureVariableVersions, idx}); err != nil {
+		return fmt.Errorf("failed updating secure variable version index: %s", err)
+	}
+	return nil
+}
+
+// SecureVariableVersions queries all the tracked secure variable versions and
+// is used only for snapshot/restore and key rotation
+func (s *StateStore) SecureVariableVersions(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableSecureVariableVersions, indexID)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// GetSecureVariableVersions returns the tracked versions of a secure variable
+// at a given namespace and path, ordered from the newest version.
+func (s *StateStore) GetSecureVariableVersions(
+	ws memdb.WatchSet, namespace, path string) ([]*structs.SecureVariableEncrypted, error) {
+	txn := s.db.ReadTxn()
+	return svVersionsTxn(txn, ws, namespace, path)
+}
+
+// svVersionsTxn is the inner method that gets the tracked versions of a
+// secure variable inside an existing transaction.
+func svVersionsTxn(tx ReadTxn, ws memdb.WatchSet,
+	namespace, path string) ([]*structs.SecureVariableEncrypted, error) {
+
+	iter, err := tx.Get(TableSecureVariableVersions, indexID+"_prefix", namespace, path)
+	if err != nil {
+		return nil, fmt.Errorf("secure variable version lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	var all []*structs.SecureVariableEncrypted
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+
+		// Ensure the path is an exact match
+		sv := raw.(*structs.SecureVariableEncrypted)
+		if sv.Path != path {
+			continue
+		}
+		all = append(all, sv)
+	}
+
+	// Sort in reverse order so that the highest version is first
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Version > all[j].Version
+	})
+	return all, nil
+}
+
+// GetSecureVariableVersion returns a single tracked version of a secure
+// variable at a given namespace and path.
+func (s *StateStore) GetSecureVariableVersion(ws memdb.WatchSet,
+	namespace, path string, version uint64) (*structs.SecureVariableEncrypted, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, raw, err := txn.FirstWatch(TableSecureVariableVersions, indexID, namespace, path, version)
+	if err != nil {
+		return nil, fmt.Errorf("secure variable version lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+	if raw == nil {
+		return nil, nil
+	}
+	return raw.(*structs.SecureVariableEncrypted), nil
+}
+
+// GetSecureVariableVersionsByKeyID returns an iterator that contains all
+// tracked secure variable versions that were encrypted with a particular key
+func (s *StateStore) GetSecureVariableVersionsByKeyID(
+	ws memdb.WatchSet, keyID string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableSecureVariableVersions, indexKeyID, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("secure variable version lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
 // This extra indirection is to facilitate the tombstone case if it matters.
 func svMaxIndex(tx ReadTxn) uint64 {
 	return maxIndexTxn(tx, TableSecureVariables)
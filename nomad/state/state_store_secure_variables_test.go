@@ -2,6 +2,7 @@ package state
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 	"strings"
 	"testing"
@@ -643,3 +644,229 @@ func printSecureVariables(tsvs []*structs.SecureVariableEncrypted) string {
 	}
 	return out.String()
 }
+
+func TestStateStore_SecureVariableLocks(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	sv := mock.SecureVariableEncrypted()
+	sv.Lock = &structs.SecureVariableLock{ID: uuid.Generate(), TTL: structs.DefaultSecureVariableLockTTL}
+
+	// Acquiring the lock on a missing variable creates it
+	acquire := sv.Copy()
+	resp := testState.SVELockAcquire(10, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpLockAcquire,
+		Var: &acquire,
+	})
+	require.True(t, resp.IsOk(), "unexpected result: %v", resp.Result)
+	require.True(t, resp.WrittenSVMeta.IsLockHolder(sv.Lock))
+
+	got, err := testState.GetSecureVariable(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.Equal(t, sv.Data, got.Data)
+	require.True(t, got.IsLockHolder(sv.Lock))
+
+	// Acquiring a held lock is a conflict
+	other := sv.Copy()
+	other.Lock = &structs.SecureVariableLock{ID: uuid.Generate(), TTL: structs.DefaultSecureVariableLockTTL}
+	resp = testState.SVELockAcquire(11, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpLockAcquire,
+		Var: &other,
+	})
+	require.Equal(t, structs.SVOpResultConflict, resp.Result)
+
+	// Only the lock holder can modify or delete the variable
+	other.Data = []byte("not the lock holder")
+	resp = testState.SVESet(12, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpSet,
+		Var: &other,
+	})
+	require.Equal(t, structs.SVOpResultConflict, resp.Result)
+	resp = testState.SVEDelete(12, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpDelete,
+		Var: &other,
+	})
+	require.Equal(t, structs.SVOpResultConflict, resp.Result)
+
+	holder := sv.Copy()
+	holder.Data = []byte("lock holder")
+	resp = testState.SVESet(13, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpSet,
+		Var: &holder,
+	})
+	require.True(t, resp.IsOk(), "unexpected result: %v", resp.Result)
+
+	got, err = testState.GetSecureVariable(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.Equal(t, holder.Data, got.Data)
+	require.True(t, got.IsLockHolder(sv.Lock), "write should retain the lock")
+
+	// Releasing the lock requires the lock ID and retains the variable
+	resp = testState.SVELockRelease(14, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpLockRelease,
+		Var: &other,
+	})
+	require.Equal(t, structs.SVOpResultConflict, resp.Result)
+
+	resp = testState.SVELockRelease(15, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpLockRelease,
+		Var: sv,
+	})
+	require.True(t, resp.IsOk(), "unexpected result: %v", resp.Result)
+
+	got, err = testState.GetSecureVariable(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.False(t, got.IsLocked())
+	require.Equal(t, holder.Data, got.Data)
+	require.Equal(t, uint64(15), got.ModifyIndex)
+
+	// Writes to an unlocked variable can't set the lock
+	resp = testState.SVESet(16, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpSet,
+		Var: &other,
+	})
+	require.True(t, resp.IsOk(), "unexpected result: %v", resp.Result)
+	got, err = testState.GetSecureVariable(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.False(t, got.IsLocked())
+}
+
+func TestStateStore_SecureVariableVersions(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	sv := mock.SecureVariableEncrypted()
+	sv.Path = "versions/foo"
+
+	// A variable with a path sharing the prefix must not be mixed in
+	other := mock.SecureVariableEncrypted()
+	other.Namespace = sv.Namespace
+	other.Path = "versions/foobar"
+	resp := testState.SVESet(10, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpSet,
+		Var: other,
+	})
+	require.True(t, resp.IsOk(), "unexpected result: %v", resp.Result)
+
+	// Write more versions than are tracked
+	index := uint64(10)
+	for i := 0; i < structs.SecureVariableTrackedVersions+2; i++ {
+		index++
+		update := sv.Copy()
+		update.Data = []byte(fmt.Sprintf("version %d", i))
+		resp := testState.SVESet(index, &structs.SVApplyStateRequest{
+			Op:  structs.SVOpSet,
+			Var: &update,
+		})
+		require.True(t, resp.IsOk(), "unexpected result: %v", resp.Result)
+	}
+
+	got, err := testState.GetSecureVariable(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.Equal(t, uint64(structs.SecureVariableTrackedVersions+1), got.Version)
+
+	// Only the newest versions are retained, ordered from the newest
+	versions, err := testState.GetSecureVariableVersions(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.Len(t, versions, structs.SecureVariableTrackedVersions)
+	require.Equal(t, got.Version, versions[0].Version)
+	require.Equal(t, got.Data, versions[0].Data)
+	require.Equal(t, uint64(2), versions[len(versions)-1].Version)
+
+	prior, err := testState.GetSecureVariableVersion(nil, sv.Namespace, sv.Path, 3)
+	require.NoError(t, err)
+	require.Equal(t, []byte("version 3"), prior.Data)
+
+	pruned, err := testState.GetSecureVariableVersion(nil, sv.Namespace, sv.Path, 1)
+	require.NoError(t, err)
+	require.Nil(t, pruned)
+
+	// Versions are tracked by key ID for the keyring GC
+	iter, err := testState.GetSecureVariableVersionsByKeyID(nil, sv.KeyID)
+	require.NoError(t, err)
+	require.NotNil(t, iter.Next())
+
+	// Deleting the variable deletes its versions, but not those of other
+	// variables
+	index++
+	resp = testState.SVEDelete(index, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpDelete,
+		Var: sv,
+	})
+	require.True(t, resp.IsOk(), "unexpected result: %v", resp.Result)
+
+	versions, err = testState.GetSecureVariableVersions(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.Empty(t, versions)
+
+	versions, err = testState.GetSecureVariableVersions(nil, other.Namespace, other.Path)
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+}
+
+func TestStateStore_SVERekey(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	sv := mock.SecureVariableEncrypted()
+	oldKeyID := sv.KeyID
+	for i, index := range []uint64{10, 20} {
+		update := sv.Copy()
+		update.Data = []byte(fmt.Sprintf("version %d", i))
+		resp := testState.SVESet(index, &structs.SVApplyStateRequest{
+			Op:  structs.SVOpSet,
+			Var: &update,
+		})
+		require.True(t, resp.IsOk(), "unexpected result: %v", resp.Result)
+	}
+
+	rekeyed := func(version uint64) *structs.SecureVariableEncrypted {
+		return &structs.SecureVariableEncrypted{
+			SecureVariableMetadata: structs.SecureVariableMetadata{
+				Namespace: sv.Namespace,
+				Path:      sv.Path,
+				Version:   version,
+			},
+			SecureVariableData: structs.SecureVariableData{
+				Data:  []byte(fmt.Sprintf("rekeyed %d", version)),
+				KeyID: "new-key",
+			},
+		}
+	}
+
+	// Versions encrypted with another key are left as is
+	require.NoError(t, testState.SVERekey(30, &structs.SVRekeyStateRequest{
+		KeyID:    "other-key",
+		Versions: []*structs.SecureVariableEncrypted{rekeyed(0), rekeyed(1)},
+	}))
+	versions, err := testState.GetSecureVariableVersions(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.Equal(t, oldKeyID, versions[0].KeyID)
+	require.Equal(t, oldKeyID, versions[1].KeyID)
+
+	// Rekeying replaces the data of the current and prior versions, without
+	// creating a new version or changing the indexes
+	require.NoError(t, testState.SVERekey(40, &structs.SVRekeyStateRequest{
+		KeyID:    oldKeyID,
+		Versions: []*structs.SecureVariableEncrypted{rekeyed(0), rekeyed(1)},
+	}))
+
+	got, err := testState.GetSecureVariable(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), got.Version)
+	require.Equal(t, uint64(20), got.ModifyIndex)
+	require.Equal(t, "new-key", got.KeyID)
+	require.Equal(t, []byte("rekeyed 1"), got.Data)
+
+	versions, err = testState.GetSecureVariableVersions(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	for _, version := range versions {
+		require.Equal(t, "new-key", version.KeyID)
+		require.Equal(t, []byte(fmt.Sprintf("rekeyed %d", version.Version)), version.Data)
+	}
+
+	iter, err := testState.GetSecureVariableVersionsByKeyID(nil, oldKeyID)
+	require.NoError(t, err)
+	require.Nil(t, iter.Next())
+}
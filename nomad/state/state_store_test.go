@@ -8904,6 +8904,70 @@ func TestStateStore_ACLTokensByGlobal(t *testing.T) {
 	})
 }
 
+func TestStateStore_ACLTokensByExpired(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	now := time.Now().UTC()
+
+	// Generate local tokens, some of which expire in the past and others in
+	// the future, along with a token that never expires.
+	localExpiredLater := mock.ACLToken()
+	localExpiredLater.ExpirationTime = pointer.Of(now.Add(-1 * time.Minute))
+
+	localExpiredEarlier := mock.ACLToken()
+	localExpiredEarlier.ExpirationTime = pointer.Of(now.Add(-1 * time.Hour))
+
+	localUnexpired := mock.ACLToken()
+	localUnexpired.ExpirationTime = pointer.Of(now.Add(time.Hour))
+
+	localNoExpiry := mock.ACLToken()
+
+	// Generate global tokens in the same manner.
+	globalExpired := mock.ACLToken()
+	globalExpired.Global = true
+	globalExpired.ExpirationTime = pointer.Of(now.Add(-1 * time.Hour))
+
+	globalNoExpiry := mock.ACLToken()
+	globalNoExpiry.Global = true
+
+	err := state.UpsertACLTokens(structs.MsgTypeTestSetup, 1000, []*structs.ACLToken{
+		localExpiredLater, localExpiredEarlier, localUnexpired, localNoExpiry,
+		globalExpired, globalNoExpiry,
+	})
+	require.NoError(t, err)
+
+	gatherTokens := func(iter memdb.ResultIterator) []*structs.ACLToken {
+		var tokens []*structs.ACLToken
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			tokens = append(tokens, raw.(*structs.ACLToken))
+		}
+		return tokens
+	}
+
+	t.Run("local tokens", func(t *testing.T) {
+		iter, err := state.ACLTokensByExpired(false)
+		require.NoError(t, err)
+
+		// Tokens without an expiry are not indexed and the returned tokens are
+		// ordered by their expiration time.
+		got := gatherTokens(iter)
+		require.Len(t, got, 3)
+		require.Equal(t, localExpiredEarlier.AccessorID, got[0].AccessorID)
+		require.Equal(t, localExpiredLater.AccessorID, got[1].AccessorID)
+		require.Equal(t, localUnexpired.AccessorID, got[2].AccessorID)
+	})
+
+	t.Run("global tokens", func(t *testing.T) {
+		iter, err := state.ACLTokensByExpired(true)
+		require.NoError(t, err)
+
+		got := gatherTokens(iter)
+		require.Len(t, got, 1)
+		require.Equal(t, globalExpired.AccessorID, got[0].AccessorID)
+	})
+}
+
 func TestStateStore_OneTimeTokens(t *testing.T) {
 	ci.Parallel(t)
 	index := uint64(100)
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/go-memdb"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
 
 	"github.com/hashicorp/go-hclog"
@@ -98,9 +99,10 @@ func (e *EventBroker) Publish(events *structs.Events) {
 	}
 
 	// Notify the broker to check running subscriptions against potentially
-	// updated ACL Token or Policy
+	// updated ACL Token, Policy, or Role
 	for _, event := range events.Events {
-		if event.Topic == structs.TopicACLToken || event.Topic == structs.TopicACLPolicy {
+		switch event.Topic {
+		case structs.TopicACLToken, structs.TopicACLPolicy, structs.TopicACLRole:
 			e.aclCh <- event
 		}
 	}
@@ -213,9 +215,9 @@ func (e *EventBroker) handleACLUpdates(ctx context.Context) {
 					return !aclAllowsSubscription(aclObj, sub.req)
 				})
 
-			case *structs.ACLPolicyEvent:
+			case *structs.ACLPolicyEvent, *structs.ACLRoleStreamEvent:
 				// Re-evaluate each subscriptions permissions since a policy
-				// change may or may not affect the subscription
+				// or role change may or may not affect the subscription
 				e.checkSubscriptionsAgainstPolicyChange()
 			}
 		}
@@ -272,8 +274,26 @@ func aclObjFromSnapshotForTokenSecretID(aclSnapshot ACLTokenProvider, aclCache *
 		return acl.ManagementACL, nil
 	}
 
-	aclPolicies := make([]*structs.ACLPolicy, 0, len(aclToken.Policies))
-	for _, policyName := range aclToken.Policies {
+	policyNames := make([]string, 0, len(aclToken.Policies))
+	policyNames = append(policyNames, aclToken.Policies...)
+
+	// Add the policies which are linked via the token's roles. Roles which
+	// no longer exist are ignored, since they don't grant any privilege.
+	for _, roleLink := range aclToken.Roles {
+		role, err := aclSnapshot.GetACLRoleByID(nil, roleLink.ID)
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			continue
+		}
+		for _, policyLink := range role.Policies {
+			policyNames = append(policyNames, policyLink.Name)
+		}
+	}
+
+	aclPolicies := make([]*structs.ACLPolicy, 0, len(policyNames))
+	for _, policyName := range helper.SetToSliceString(helper.SliceStringToSet(policyNames)) {
 		policy, err := aclSnapshot.ACLPolicyByName(nil, policyName)
 		if err != nil || policy == nil {
 			return nil, errors.New("error finding acl policy")
@@ -287,6 +307,7 @@ func aclObjFromSnapshotForTokenSecretID(aclSnapshot ACLTokenProvider, aclCache *
 type ACLTokenProvider interface {
 	ACLTokenBySecretID(ws memdb.WatchSet, secretID string) (*structs.ACLToken, error)
 	ACLPolicyByName(ws memdb.WatchSet, policyName string) (*structs.ACLPolicy, error)
+	GetACLRoleByID(ws memdb.WatchSet, roleID string) (*structs.ACLRole, error)
 }
 
 type ACLDelegate interface {
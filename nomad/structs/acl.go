@@ -0,0 +1,1197 @@
+package structs
+
+import (
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// ACLUpsertRolesRPCMethod is the RPC method for batch creating or
+	// modifying ACL roles.
+	//
+	// Args: ACLRolesUpsertRequest
+	// Reply: ACLRolesUpsertResponse
+	ACLUpsertRolesRPCMethod = "ACL.UpsertRoles"
+
+	// ACLDeleteRolesRPCMethod the RPC method for batch deleting ACL roles by
+	// their ID.
+	//
+	// Args: ACLRolesDeleteRequest
+	// Reply: ACLRolesDeleteResponse
+	ACLDeleteRolesRPCMethod = "ACL.DeleteRoles"
+
+	// ACLListRolesRPCMethod is the RPC method for listing ACL roles.
+	//
+	// Args: ACLRolesListRequest
+	// Reply: ACLRolesListResponse
+	ACLListRolesRPCMethod = "ACL.ListRoles"
+
+	// ACLGetRolesByIDRPCMethod is the RPC method for detailing a number of ACL
+	// roles using their ID. This is an internal only RPC endpoint and used by
+	// the ACL Role replication process and client token resolution.
+	//
+	// Args: ACLRolesByIDRequest
+	// Reply: ACLRolesByIDResponse
+	ACLGetRolesByIDRPCMethod = "ACL.GetRolesByID"
+
+	// ACLGetRoleByIDRPCMethod is the RPC method for detailing an individual
+	// ACL role using its ID.
+	//
+	// Args: ACLRoleByIDRequest
+	// Reply: ACLRoleByIDResponse
+	ACLGetRoleByIDRPCMethod = "ACL.GetRoleByID"
+
+	// ACLGetRoleByNameRPCMethod is the RPC method for detailing an individual
+	// ACL role using its name.
+	//
+	// Args: ACLRoleByNameRequest
+	// Reply: ACLRoleByNameResponse
+	ACLGetRoleByNameRPCMethod = "ACL.GetRoleByName"
+
+	// ACLUpsertAuthMethodsRPCMethod is the RPC method for batch creating or
+	// modifying auth methods.
+	//
+	// Args: ACLAuthMethodsUpsertRequest
+	// Reply: ACLAuthMethodsUpsertResponse
+	ACLUpsertAuthMethodsRPCMethod = "ACL.UpsertAuthMethods"
+
+	// ACLDeleteAuthMethodsRPCMethod is the RPC method for batch deleting auth
+	// methods.
+	//
+	// Args: ACLAuthMethodsDeleteRequest
+	// Reply: ACLAuthMethodsDeleteResponse
+	ACLDeleteAuthMethodsRPCMethod = "ACL.DeleteAuthMethods"
+
+	// ACLListAuthMethodsRPCMethod is the RPC method for listing auth methods.
+	//
+	// Args: ACLAuthMethodListRequest
+	// Reply: ACLAuthMethodListResponse
+	ACLListAuthMethodsRPCMethod = "ACL.ListAuthMethods"
+
+	// ACLGetAuthMethodRPCMethod is the RPC method for detailing an individual
+	// auth method using its name.
+	//
+	// Args: ACLAuthMethodGetRequest
+	// Reply: ACLAuthMethodGetResponse
+	ACLGetAuthMethodRPCMethod = "ACL.GetAuthMethod"
+
+	// ACLGetAuthMethodsRPCMethod is the RPC method for getting multiple auth
+	// methods using their names. This is an internal only RPC endpoint and
+	// used by the ACL auth method replication process.
+	//
+	// Args: ACLAuthMethodsGetRequest
+	// Reply: ACLAuthMethodsGetResponse
+	ACLGetAuthMethodsRPCMethod = "ACL.GetAuthMethods"
+
+	// ACLUpsertBindingRulesRPCMethod is the RPC method for batch creating or
+	// modifying binding rules.
+	//
+	// Args: ACLBindingRulesUpsertRequest
+	// Reply: ACLBindingRulesUpsertResponse
+	ACLUpsertBindingRulesRPCMethod = "ACL.UpsertBindingRules"
+
+	// ACLDeleteBindingRulesRPCMethod is the RPC method for batch deleting
+	// binding rules.
+	//
+	// Args: ACLBindingRulesDeleteRequest
+	// Reply: ACLBindingRulesDeleteResponse
+	ACLDeleteBindingRulesRPCMethod = "ACL.DeleteBindingRules"
+
+	// ACLListBindingRulesRPCMethod is the RPC method listing binding rules.
+	//
+	// Args: ACLBindingRulesListRequest
+	// Reply: ACLBindingRulesListResponse
+	ACLListBindingRulesRPCMethod = "ACL.ListBindingRules"
+
+	// ACLGetBindingRulesRPCMethod is the RPC method for getting multiple
+	// binding rules using their IDs. This is an internal only RPC endpoint
+	// and used by the ACL binding rule replication process.
+	//
+	// Args: ACLBindingRulesRequest
+	// Reply: ACLBindingRulesResponse
+	ACLGetBindingRulesRPCMethod = "ACL.GetBindingRules"
+
+	// ACLGetBindingRuleRPCMethod is the RPC method for detailing an individual
+	// binding rule using its ID.
+	//
+	// Args: ACLBindingRuleRequest
+	// Reply: ACLBindingRuleResponse
+	ACLGetBindingRuleRPCMethod = "ACL.GetBindingRule"
+
+	// ACLLoginRPCMethod is the RPC method for exchanging an identity token
+	// issued by an external identity provider for a Nomad ACL token.
+	//
+	// Args: ACLLoginRequest
+	// Reply: ACLLoginResponse
+	ACLLoginRPCMethod = "ACL.Login"
+)
+
+const (
+	// ACLMaxExpiredBatchSize is the maximum number of expired ACL tokens that
+	// will be garbage collected in a single trigger. This number helps limit
+	// the replication pressure due to expired token deletion. If there are a
+	// large number of expired tokens pending garbage collection, this value is
+	// a potential limiting factor.
+	ACLMaxExpiredBatchSize = 4096
+
+	// maxACLRoleDescriptionLength limits an ACL roles description length.
+	maxACLRoleDescriptionLength = 256
+
+	// maxACLBindingRuleDescriptionLength limits an ACL binding rules
+	// description length.
+	maxACLBindingRuleDescriptionLength = 256
+)
+
+const (
+	// ACLAuthMethodTokenLocalityLocal is the ACLAuthMethod.TokenLocality that
+	// will generate ACL tokens which can only be used on the local cluster the
+	// request was made.
+	ACLAuthMethodTokenLocalityLocal = "local"
+
+	// ACLAuthMethodTokenLocalityGlobal is the ACLAuthMethod.TokenLocality that
+	// will generate ACL tokens which can be used on all federated clusters.
+	ACLAuthMethodTokenLocalityGlobal = "global"
+
+	// ACLAuthMethodTypeOIDC the ACLAuthMethod.Type and represents an
+	// auth-method which uses the OIDC protocol.
+	ACLAuthMethodTypeOIDC = "OIDC"
+
+	// ACLAuthMethodTypeJWT the ACLAuthMethod.Type and represents an
+	// auth-method which validates JWTs signed by a third party, such as a CI
+	// runner or another cluster, using a JWKS endpoint or static public keys.
+	ACLAuthMethodTypeJWT = "JWT"
+)
+
+const (
+	// ACLBindingRuleBindTypeRole is the ACL binding rule bind type that only
+	// allows the binding rule to function if a role exists at login-time. The
+	// role will be specified within the ACLBindingRule.BindName parameter, and
+	// will identify whether this is an ID or Name.
+	ACLBindingRuleBindTypeRole = "role"
+
+	// ACLBindingRuleBindTypePolicy is the ACL binding rule bind type that
+	// assigns a policy to the generate ACL token. The role will be specified
+	// within the ACLBindingRule.BindName parameter, and will be the policy
+	// name.
+	ACLBindingRuleBindTypePolicy = "policy"
+
+	// ACLBindingRuleBindTypeManagement is the ACL binding rule bind type that
+	// will generate management ACL tokens when matched. The
+	// ACLBindingRule.BindName parameter is not used and should be empty.
+	ACLBindingRuleBindTypeManagement = "management"
+)
+
+var (
+	// validACLRoleName is used to validate an ACL role name.
+	validACLRoleName = regexp.MustCompile("^[a-zA-Z0-9-]{1,128}$")
+
+	// validACLAuthMethodName is used to validate an ACL auth method name.
+	validACLAuthMethodName = regexp.MustCompile("^[a-zA-Z0-9-]{1,128}$")
+)
+
+// ACLTokenRoleLink is used to link an ACL token to an ACL role. The ACL token
+// can therefore inherit all the ACL policy permissions that the ACL role
+// contains.
+type ACLTokenRoleLink struct {
+
+	// ID is the ACLRole.ID UUID. This field is immutable and represents the
+	// absolute truth for the link.
+	ID string
+
+	// Name is the human friendly identifier for the ACL role and is a
+	// convenience field for operators. Links may be created using only the
+	// name, which is resolved to the ID before the token is stored in state.
+	// Because operators can rename an ACL role, the name of the links is
+	// updated along with the role.
+	Name string
+}
+
+// ACLRole is an abstraction for the ACL system which allows the grouping of
+// ACL policies into a single object. ACL tokens can be created and linked to
+// a role; the token then inherits all the permissions granted by the
+// policies.
+type ACLRole struct {
+
+	// ID is an internally generated UUID for this role and is controlled by
+	// Nomad.
+	ID string
+
+	// Name is unique across the entire set of federated clusters and is
+	// supplied by the operator on role creation. The name can be modified by
+	// updating the role and including the Nomad generated ID. This update will
+	// not affect tokens created and linked to this role. This is a required
+	// field.
+	Name string
+
+	// Description is a human-readable, operator set description that can
+	// provide additional context about the role. This is an operational field.
+	Description string
+
+	// Policies is an array of ACL policy links. Although currently policies
+	// can only be linked using their name, in the future we will want to add
+	// IDs also and thus allow operators to specify either a name, an ID, or
+	// both.
+	Policies []*ACLRolePolicyLink
+
+	// Hash is the hashed value of the role and is generated using all fields
+	// above this point.
+	Hash []byte
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLRolePolicyLink is used to link a policy to an ACL role. We use a struct
+// rather than a list of strings as in the future we will want to add IDs to
+// policies and then link via these.
+type ACLRolePolicyLink struct {
+
+	// Name is the ACLPolicy.Name value which will be linked to the ACL role.
+	Name string
+}
+
+// SetHash is used to compute and set the hash of the ACL role. This should be
+// called every time a user specified field on the role is changed
+// before updating the Nomad state store.
+func (a *ACLRole) SetHash() []byte {
+
+	// Initialize a 256bit Blake2 hash (32 bytes).
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// Write all the user set fields.
+	_, _ = hash.Write([]byte(a.Name))
+	_, _ = hash.Write([]byte(a.Description))
+
+	for _, policyLink := range a.Policies {
+		_, _ = hash.Write([]byte(policyLink.Name))
+	}
+
+	// Finalize the hash.
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash.
+	a.Hash = hashVal
+	return hashVal
+}
+
+// Validate ensure the ACL role contains valid information which meets Nomad's
+// internal requirements. This does not include any state calls, such as
+// ensuring the linked policies exist.
+func (a *ACLRole) Validate() error {
+
+	var mErr multierror.Error
+
+	if !validACLRoleName.MatchString(a.Name) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid name '%s'", a.Name))
+	}
+
+	if len(a.Description) > maxACLRoleDescriptionLength {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("description longer than %d", maxACLRoleDescriptionLength))
+	}
+
+	if len(a.Policies) < 1 {
+		mErr.Errors = append(mErr.Errors, errors.New("at least one policy should be specified"))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Canonicalize performs basic canonicalization on the ACL role object. It is
+// important for callers to understand certain fields such as ID are set if it
+// is empty, so copies should be taken if needed before calling this function.
+func (a *ACLRole) Canonicalize() {
+	if a.ID == "" {
+		a.ID = uuid.Generate()
+	}
+}
+
+// Equals performs an equality check on the two ACL roles. It
+// handles nil objects.
+func (a *ACLRole) Equals(o *ACLRole) bool {
+	if a == nil || o == nil {
+		return a == o
+	}
+	if len(a.Hash) == 0 {
+		a.SetHash()
+	}
+	if len(o.Hash) == 0 {
+		o.SetHash()
+	}
+	return string(a.Hash) == string(o.Hash)
+}
+
+// Copy creates a deep copy of the ACL role. This copy can then be safely
+// modified. It handles nil objects.
+func (a *ACLRole) Copy() *ACLRole {
+	if a == nil {
+		return nil
+	}
+
+	c := new(ACLRole)
+	*c = *a
+
+	c.Policies = make([]*ACLRolePolicyLink, len(a.Policies))
+	for i, policyLink := range a.Policies {
+		c.Policies[i] = &ACLRolePolicyLink{Name: policyLink.Name}
+	}
+	c.Hash = make([]byte, len(a.Hash))
+	copy(c.Hash, a.Hash)
+
+	return c
+}
+
+// Stub converts the ACLRole object into a ACLRoleListStub object.
+func (a *ACLRole) Stub() *ACLRoleListStub {
+	return &ACLRoleListStub{
+		ID:          a.ID,
+		Name:        a.Name,
+		Description: a.Description,
+		Policies:    a.Policies,
+		Hash:        a.Hash,
+		CreateIndex: a.CreateIndex,
+		ModifyIndex: a.ModifyIndex,
+	}
+}
+
+// ACLRoleListStub is the stub object returned when performing a listing of
+// ACL roles. While it might not currently be different to the full response
+// object, it allows us to future-proof the RPC in the event the ACLRole object
+// grows over time.
+type ACLRoleListStub struct {
+
+	// ID is an internally generated UUID for this role and is controlled by
+	// Nomad.
+	ID string
+
+	// Name is unique across the entire set of federated clusters and is
+	// supplied by the operator on role creation.
+	Name string
+
+	// Description is a human-readable, operator set description that can
+	// provide additional context about the role.
+	Description string
+
+	// Policies is an array of ACL policy links.
+	Policies []*ACLRolePolicyLink
+
+	// Hash is the hashed value of the role and is generated using all fields
+	// above this point.
+	Hash []byte
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLRolesUpsertRequest is the request object used to upsert one or more ACL
+// roles.
+type ACLRolesUpsertRequest struct {
+	ACLRoles []*ACLRole
+
+	// AllowMissingPolicies skips the ACL Role policy link verification and is
+	// used by the replication process. The replication cannot ensure policies
+	// are present before ACL Roles are replicated.
+	AllowMissingPolicies bool
+
+	WriteRequest
+}
+
+// ACLRolesUpsertResponse is the response object when one or more ACL roles
+// have been successfully upserted into state.
+type ACLRolesUpsertResponse struct {
+	ACLRoles []*ACLRole
+	WriteMeta
+}
+
+// ACLRolesDeleteRequest is the request object used to delete one or more ACL
+// roles using the role ID.
+type ACLRolesDeleteRequest struct {
+	ACLRoleIDs []string
+	WriteRequest
+}
+
+// ACLRolesDeleteResponse is the response object when performing a deletion
+// of one or more ACL roles using the role ID.
+type ACLRolesDeleteResponse struct {
+	WriteMeta
+}
+
+// ACLRolesListRequest is the request object when performing ACL role
+// listings.
+type ACLRolesListRequest struct {
+	QueryOptions
+}
+
+// ACLRolesListResponse is the response object when performing ACL role
+// listings.
+type ACLRolesListResponse struct {
+	ACLRoles []*ACLRoleListStub
+	QueryMeta
+}
+
+// ACLRolesByIDRequest is the request object when performing a lookup of
+// multiple roles by the ID.
+type ACLRolesByIDRequest struct {
+	ACLRoleIDs []string
+	QueryOptions
+}
+
+// ACLRolesByIDResponse is the response object when performing a lookup of
+// multiple roles by their IDs.
+type ACLRolesByIDResponse struct {
+	ACLRoles map[string]*ACLRole
+	QueryMeta
+}
+
+// ACLRoleByIDRequest is the request object to perform a lookup of an ACL
+// role using a specific ID.
+type ACLRoleByIDRequest struct {
+	RoleID string
+	QueryOptions
+}
+
+// ACLRoleByIDResponse is the response object when performing a lookup of an
+// ACL role matching a specific ID.
+type ACLRoleByIDResponse struct {
+	ACLRole *ACLRole
+	QueryMeta
+}
+
+// ACLRoleByNameRequest is the request object to perform a lookup of an ACL
+// role using a specific name.
+type ACLRoleByNameRequest struct {
+	RoleName string
+	QueryOptions
+}
+
+// ACLRoleByNameResponse is the response object when performing a lookup of an
+// ACL role matching a specific name.
+type ACLRoleByNameResponse struct {
+	ACLRole *ACLRole
+	QueryMeta
+}
+
+// ACLAuthMethod is used to capture the properties of an authentication method
+// used for single sing-on.
+type ACLAuthMethod struct {
+
+	// Name is the identifier for this auth method and is unique across the
+	// entire set of federated clusters.
+	Name string
+
+	// Type is the SSO identifier this auth method is. Supported values are
+	// "OIDC" and "JWT".
+	Type string
+
+	// TokenLocality defines whether the ACL tokens created when using this
+	// auth method are local or global. Valid values are "local" or "global".
+	TokenLocality string
+
+	// MaxTokenTTL is the maximum life of a token created by this method.
+	MaxTokenTTL time.Duration
+
+	// Default identifies whether this is the default auth method to use when
+	// an operator logs in without specifying a method. Only one auth method
+	// can be the default.
+	Default bool
+
+	// Config contains the detailed configuration which is specific to the
+	// auth method type.
+	Config *ACLAuthMethodConfig
+
+	// Hash is the hashed value of the auth method and is generated using all
+	// fields from the above.
+	Hash []byte
+
+	CreateTime  time.Time
+	ModifyTime  time.Time
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// SetHash is used to compute and set the hash of the ACL auth method. This
+// should be called every time a user specified field on the method is changed
+// before updating the Nomad state store.
+func (a *ACLAuthMethod) SetHash() []byte {
+
+	// Initialize a 256bit Blake2 hash (32 bytes).
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	_, _ = hash.Write([]byte(a.Name))
+	_, _ = hash.Write([]byte(a.Type))
+	_, _ = hash.Write([]byte(a.TokenLocality))
+	_, _ = hash.Write([]byte(a.MaxTokenTTL.String()))
+	_, _ = hash.Write([]byte(strconv.FormatBool(a.Default)))
+
+	if a.Config != nil {
+		_, _ = hash.Write([]byte(a.Config.OIDCDiscoveryURL))
+		_, _ = hash.Write([]byte(a.Config.OIDCClientID))
+		for _, ba := range a.Config.BoundAudiences {
+			_, _ = hash.Write([]byte(ba))
+		}
+		for _, pem := range a.Config.DiscoveryCaPem {
+			_, _ = hash.Write([]byte(pem))
+		}
+		for _, sa := range a.Config.SigningAlgs {
+			_, _ = hash.Write([]byte(sa))
+		}
+		writeSortedStringMap(hash, a.Config.ClaimMappings)
+		writeSortedStringMap(hash, a.Config.ListClaimMappings)
+		_, _ = hash.Write([]byte(a.Config.JWKSURL))
+		_, _ = hash.Write([]byte(a.Config.JWKSCACert))
+		for _, key := range a.Config.JWTValidationPubKeys {
+			_, _ = hash.Write([]byte(key))
+		}
+		_, _ = hash.Write([]byte(a.Config.BoundIssuer))
+		_, _ = hash.Write([]byte(a.Config.ClockSkewLeeway.String()))
+	}
+
+	// Finalize the hash.
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash.
+	a.Hash = hashVal
+	return hashVal
+}
+
+// writeSortedStringMap writes the keys and values of the map to the writer in
+// key order. Map iteration order is random, so this is required for hashes
+// to be deterministic.
+func writeSortedStringMap(w io.Writer, m map[string]string) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		_, _ = w.Write([]byte(k))
+		_, _ = w.Write([]byte(m[k]))
+	}
+}
+
+// Stub converts the ACLAuthMethod object into a ACLAuthMethodStub object.
+func (a *ACLAuthMethod) Stub() *ACLAuthMethodStub {
+	return &ACLAuthMethodStub{
+		Name:        a.Name,
+		Type:        a.Type,
+		Default:     a.Default,
+		Hash:        a.Hash,
+		CreateIndex: a.CreateIndex,
+		ModifyIndex: a.ModifyIndex,
+	}
+}
+
+// Equals performs an equality check on the two ACL auth methods. It handles
+// nil objects.
+func (a *ACLAuthMethod) Equals(other *ACLAuthMethod) bool {
+	if a == nil || other == nil {
+		return a == other
+	}
+	if len(a.Hash) == 0 {
+		a.SetHash()
+	}
+	if len(other.Hash) == 0 {
+		other.SetHash()
+	}
+	return string(a.Hash) == string(other.Hash)
+}
+
+// Copy creates a deep copy of the ACL auth method. This copy can then be
+// safely modified. It handles nil objects.
+func (a *ACLAuthMethod) Copy() *ACLAuthMethod {
+	if a == nil {
+		return nil
+	}
+
+	c := new(ACLAuthMethod)
+	*c = *a
+
+	c.Hash = make([]byte, len(a.Hash))
+	copy(c.Hash, a.Hash)
+	c.Config = a.Config.Copy()
+
+	return c
+}
+
+// Canonicalize performs basic canonicalization on the ACL auth method object.
+func (a *ACLAuthMethod) Canonicalize() {
+	t := time.Now().UTC()
+
+	if a.CreateTime.IsZero() {
+		a.CreateTime = t
+	}
+	a.ModifyTime = t
+}
+
+// Validate returns an error if the ACL auth method is invalid. The minimum
+// and maximum TTL bound the token TTL configured on the method, as these are
+// the bounds enforced on all expiring ACL tokens.
+func (a *ACLAuthMethod) Validate(minTTL, maxTTL time.Duration) error {
+	var mErr multierror.Error
+
+	if !validACLAuthMethodName.MatchString(a.Name) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid name '%s'", a.Name))
+	}
+
+	switch a.TokenLocality {
+	case ACLAuthMethodTokenLocalityLocal, ACLAuthMethodTokenLocalityGlobal:
+	default:
+		mErr.Errors = append(
+			mErr.Errors, fmt.Errorf("invalid token locality '%s'", a.TokenLocality))
+	}
+
+	switch a.Type {
+	case ACLAuthMethodTypeOIDC, ACLAuthMethodTypeJWT:
+	default:
+		mErr.Errors = append(
+			mErr.Errors, fmt.Errorf("invalid token type '%s'", a.Type))
+	}
+
+	if minTTL > a.MaxTokenTTL || a.MaxTokenTTL > maxTTL {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf(
+			"invalid MaxTokenTTL value '%s' (should be between %s and %s)",
+			a.MaxTokenTTL.String(), minTTL.String(), maxTTL.String()))
+	}
+
+	if a.Config == nil {
+		mErr.Errors = append(mErr.Errors, errors.New("missing auth method config"))
+		return mErr.ErrorOrNil()
+	}
+
+	switch a.Type {
+	case ACLAuthMethodTypeOIDC:
+		if a.Config.OIDCDiscoveryURL == "" {
+			mErr.Errors = append(mErr.Errors, errors.New("missing OIDC discovery URL"))
+		}
+		if a.Config.OIDCClientID == "" {
+			mErr.Errors = append(mErr.Errors, errors.New("missing OIDC client ID"))
+		}
+	case ACLAuthMethodTypeJWT:
+		if err := a.Config.validateJWT(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// TokenLocalityIsGlobal returns whether the auth method creates global ACL
+// tokens or not.
+func (a *ACLAuthMethod) TokenLocalityIsGlobal() bool {
+	return a.TokenLocality == ACLAuthMethodTokenLocalityGlobal
+}
+
+// ACLAuthMethodConfig is used to store configuration of an auth method.
+type ACLAuthMethodConfig struct {
+
+	// OIDCDiscoveryURL is the OIDC provider's discovery URL. The provider
+	// metadata and signing keys are fetched from this location and the
+	// issuer of identity tokens must match it.
+	OIDCDiscoveryURL string
+
+	// OIDCClientID is the OAuth client ID configured with the OIDC provider.
+	// Identity tokens must include this value as an audience, unless bound
+	// audiences are configured.
+	OIDCClientID string
+
+	// BoundAudiences is the list of audience values which are accepted within
+	// an identity token. If empty, the OIDC client ID is used.
+	BoundAudiences []string
+
+	// DiscoveryCaPem is a list of PEM encoded CA certificates used to verify
+	// the OIDC provider's TLS certificate. If empty, the system's trusted
+	// certificates are used.
+	DiscoveryCaPem []string
+
+	// SigningAlgs is the list of accepted identity token signing algorithms.
+	// If empty, RS256 is the only accepted algorithm.
+	SigningAlgs []string
+
+	// ClaimMappings maps scalar claim values from the identity token to
+	// metadata keys, which can be used within binding rule selectors and bind
+	// names using the "value" prefix.
+	ClaimMappings map[string]string
+
+	// ListClaimMappings maps list claim values from the identity token to
+	// metadata keys, which can be used within binding rule selectors using
+	// the "list" prefix.
+	ListClaimMappings map[string]string
+
+	// JWKSURL is the JSON Web Key Set endpoint used to fetch the keys which
+	// verify JWT signatures. Only used by JWT auth methods and mutually
+	// exclusive with JWTValidationPubKeys.
+	JWKSURL string
+
+	// JWKSCACert is a PEM encoded CA certificate used to verify the TLS
+	// certificate of the JWKS endpoint. If empty, the system's trusted
+	// certificates are used.
+	JWKSCACert string
+
+	// JWTValidationPubKeys is a list of PEM encoded public keys used to
+	// verify JWT signatures. Only used by JWT auth methods and mutually
+	// exclusive with JWKSURL.
+	JWTValidationPubKeys []string
+
+	// BoundIssuer, if set, must match the "iss" claim of a JWT. Only used by
+	// JWT auth methods, as the issuer of OIDC tokens is always verified
+	// against the discovery URL.
+	BoundIssuer string
+
+	// ClockSkewLeeway is the leeway used when validating the time based
+	// claims of a JWT, to account for clock skew between Nomad and the
+	// issuer. If zero, a default of one minute is used.
+	ClockSkewLeeway time.Duration
+}
+
+// validateJWT performs validation of the config fields used by JWT auth
+// methods.
+func (a *ACLAuthMethodConfig) validateJWT() error {
+	var mErr multierror.Error
+
+	switch {
+	case a.JWKSURL == "" && len(a.JWTValidationPubKeys) == 0:
+		mErr.Errors = append(mErr.Errors,
+			errors.New("one of JWKSURL or JWTValidationPubKeys must be set"))
+	case a.JWKSURL != "" && len(a.JWTValidationPubKeys) > 0:
+		mErr.Errors = append(mErr.Errors,
+			errors.New("JWKSURL and JWTValidationPubKeys are mutually exclusive"))
+	}
+
+	if a.JWKSCACert != "" && a.JWKSURL == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("JWKSCACert requires JWKSURL to be set"))
+	}
+
+	for i, key := range a.JWTValidationPubKeys {
+		if block, _ := pem.Decode([]byte(key)); block == nil {
+			mErr.Errors = append(mErr.Errors,
+				fmt.Errorf("JWTValidationPubKeys[%d] is not a PEM encoded key", i))
+		}
+	}
+
+	if a.ClockSkewLeeway < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("ClockSkewLeeway must not be negative"))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Copy creates a deep copy of the ACL auth method config. It handles nil
+// objects.
+func (a *ACLAuthMethodConfig) Copy() *ACLAuthMethodConfig {
+	if a == nil {
+		return nil
+	}
+
+	c := new(ACLAuthMethodConfig)
+	*c = *a
+
+	c.BoundAudiences = helper.CopySliceString(a.BoundAudiences)
+	c.DiscoveryCaPem = helper.CopySliceString(a.DiscoveryCaPem)
+	c.SigningAlgs = helper.CopySliceString(a.SigningAlgs)
+	c.ClaimMappings = helper.CopyMapStringString(a.ClaimMappings)
+	c.ListClaimMappings = helper.CopyMapStringString(a.ListClaimMappings)
+	c.JWTValidationPubKeys = helper.CopySliceString(a.JWTValidationPubKeys)
+
+	return c
+}
+
+// ACLAuthMethodStub is used for listing ACL auth methods
+type ACLAuthMethodStub struct {
+	Name    string
+	Type    string
+	Default bool
+
+	// Hash is the hashed value of the auth method and is generated using all
+	// fields from the full object except the create and modify times and
+	// indexes.
+	Hash []byte
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLAuthMethodsUpsertRequest is used to upsert a set of auth methods
+type ACLAuthMethodsUpsertRequest struct {
+	AuthMethods []*ACLAuthMethod
+	WriteRequest
+}
+
+// ACLAuthMethodsUpsertResponse is a response of the upsert ACL auth methods
+// operation
+type ACLAuthMethodsUpsertResponse struct {
+	AuthMethods []*ACLAuthMethod
+	WriteMeta
+}
+
+// ACLAuthMethodsDeleteRequest is used to delete a set of auth methods by their
+// name
+type ACLAuthMethodsDeleteRequest struct {
+	Names []string
+	WriteRequest
+}
+
+// ACLAuthMethodsDeleteResponse is a response of the delete ACL auth methods
+// operation
+type ACLAuthMethodsDeleteResponse struct {
+	WriteMeta
+}
+
+// ACLAuthMethodListRequest is used to list auth methods
+type ACLAuthMethodListRequest struct {
+	QueryOptions
+}
+
+// ACLAuthMethodListResponse is used to list auth methods
+type ACLAuthMethodListResponse struct {
+	AuthMethods []*ACLAuthMethodStub
+	QueryMeta
+}
+
+// ACLAuthMethodGetRequest is used to query a specific auth method
+type ACLAuthMethodGetRequest struct {
+	MethodName string
+	QueryOptions
+}
+
+// ACLAuthMethodGetResponse is used to return a single auth method
+type ACLAuthMethodGetResponse struct {
+	AuthMethod *ACLAuthMethod
+	QueryMeta
+}
+
+// ACLAuthMethodsGetRequest is used to query a set of auth methods
+type ACLAuthMethodsGetRequest struct {
+	Names []string
+	QueryOptions
+}
+
+// ACLAuthMethodsGetResponse is used to return a set of auth methods
+type ACLAuthMethodsGetResponse struct {
+	AuthMethods map[string]*ACLAuthMethod
+	QueryMeta
+}
+
+// ACLBindingRule contains a direct relation to an ACLAuthMethod and represents
+// a rule to apply when logging in via the named AuthMethod. This allows the
+// transformation of OIDC provider claims, to Nomad based ACL concepts such as
+// ACL Roles and Policies.
+type ACLBindingRule struct {
+
+	// ID is an internally generated UUID for this rule and is controlled by
+	// Nomad.
+	ID string
+
+	// Description is a human-readable, operator set description that can
+	// provide additional context about the binding rule. This is an
+	// operational field.
+	Description string
+
+	// AuthMethod is the name of the auth method for which this rule applies
+	// to. This is required and the method must exist within state before the
+	// cluster administrator can create the rule.
+	AuthMethod string
+
+	// Selector is an expression that matches against verified identity
+	// attributes returned from the auth method during login. This is optional
+	// and when not set, provides a catch-all rule.
+	Selector string
+
+	// BindType adjusts how this binding rule is applied at login time. The
+	// valid values are ACLBindingRuleBindTypeRole,
+	// ACLBindingRuleBindTypePolicy, and ACLBindingRuleBindTypeManagement.
+	BindType string
+
+	// BindName is the target of the binding. Can be lightly templated using
+	// HIL ${foo} syntax from available field names. How it is used depends
+	// upon the BindType.
+	BindName string
+
+	// Hash is the hashed value of the binding rule and is generated using all
+	// fields from the above.
+	Hash []byte
+
+	CreateTime  time.Time
+	ModifyTime  time.Time
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// SetHash is used to compute and set the hash of the ACL binding rule. This
+// should be called every time a user specified field on the method is changed
+// before updating the Nomad state store.
+func (a *ACLBindingRule) SetHash() []byte {
+
+	// Initialize a 256bit Blake2 hash (32 bytes).
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	_, _ = hash.Write([]byte(a.ID))
+	_, _ = hash.Write([]byte(a.Description))
+	_, _ = hash.Write([]byte(a.AuthMethod))
+	_, _ = hash.Write([]byte(a.Selector))
+	_, _ = hash.Write([]byte(a.BindType))
+	_, _ = hash.Write([]byte(a.BindName))
+
+	// Finalize the hash.
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash.
+	a.Hash = hashVal
+	return hashVal
+}
+
+// Copy creates a deep copy of the ACL binding rule. This copy can then be
+// safely modified. It handles nil objects.
+func (a *ACLBindingRule) Copy() *ACLBindingRule {
+	if a == nil {
+		return nil
+	}
+
+	c := new(ACLBindingRule)
+	*c = *a
+	c.Hash = make([]byte, len(a.Hash))
+	copy(c.Hash, a.Hash)
+
+	return c
+}
+
+// Equals performs an equality check on the two ACL binding rules. It handles
+// nil objects.
+func (a *ACLBindingRule) Equals(other *ACLBindingRule) bool {
+	if a == nil || other == nil {
+		return a == other
+	}
+	if len(a.Hash) == 0 {
+		a.SetHash()
+	}
+	if len(other.Hash) == 0 {
+		other.SetHash()
+	}
+	return string(a.Hash) == string(other.Hash)
+}
+
+// Canonicalize performs basic canonicalization on the ACL binding rule
+// object. It is important for callers to understand certain fields such as ID
+// are set if it is empty, so copies should be taken if needed before calling
+// this function.
+func (a *ACLBindingRule) Canonicalize() {
+	t := time.Now().UTC()
+
+	if a.ID == "" {
+		a.ID = uuid.Generate()
+		a.CreateTime = t
+	}
+	a.ModifyTime = t
+}
+
+// Validate ensures the ACL binding rule contains valid information which
+// meets Nomad's internal requirements. This does not include any state calls,
+// such as ensuring the auth method exists.
+func (a *ACLBindingRule) Validate() error {
+
+	var mErr multierror.Error
+
+	if a.AuthMethod == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("auth method is missing"))
+	}
+	if len(a.Description) > maxACLBindingRuleDescriptionLength {
+		mErr.Errors = append(mErr.Errors,
+			fmt.Errorf("description longer than %d", maxACLBindingRuleDescriptionLength))
+	}
+
+	// Depending on the bind type, we have some specific validation. Catching
+	// the empty string also provides easier to understand feedback to the
+	// user.
+	switch a.BindType {
+	case "":
+		mErr.Errors = append(mErr.Errors, errors.New("bind type is missing"))
+	case ACLBindingRuleBindTypeRole, ACLBindingRuleBindTypePolicy:
+		if a.BindName == "" {
+			mErr.Errors = append(mErr.Errors, errors.New("bind name is missing"))
+		}
+	case ACLBindingRuleBindTypeManagement:
+		if a.BindName != "" {
+			mErr.Errors = append(mErr.Errors, errors.New("bind name should be empty"))
+		}
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("unsupported bind type: %q", a.BindType))
+	}
+
+	// Ensure the selector, if set, is a valid expression.
+	if a.Selector != "" {
+		if _, err := bexpr.CreateEvaluator(a.Selector); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("selector is invalid: %v", err))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Stub converts the ACLBindingRule object into a ACLBindingRuleListStub
+// object.
+func (a *ACLBindingRule) Stub() *ACLBindingRuleListStub {
+	return &ACLBindingRuleListStub{
+		ID:          a.ID,
+		Description: a.Description,
+		AuthMethod:  a.AuthMethod,
+		Hash:        a.Hash,
+		CreateIndex: a.CreateIndex,
+		ModifyIndex: a.ModifyIndex,
+	}
+}
+
+// ACLBindingRuleListStub is the stub object returned when performing a listing
+// of ACL binding rules.
+type ACLBindingRuleListStub struct {
+
+	// ID is an internally generated UUID for this role and is controlled by
+	// Nomad.
+	ID string
+
+	// Description is a human-readable, operator set description that can
+	// provide additional context about the binding role. This is an
+	// operational field.
+	Description string
+
+	// AuthMethod is the name of the auth method for which this rule applies
+	// to. This is required and the method must exist within state before the
+	// cluster administrator can create the rule.
+	AuthMethod string
+
+	// Hash is the hashed value of the binding rule and is generated using all
+	// fields from the full object except the create and modify times and
+	// indexes.
+	Hash []byte
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLBindingRulesUpsertRequest is used to upsert a set of ACL binding rules.
+type ACLBindingRulesUpsertRequest struct {
+	ACLBindingRules []*ACLBindingRule
+
+	// AllowMissingAuthMethods skips the ACL binding rule auth method link
+	// verification and is used by the replication process. The replication
+	// cannot ensure auth methods are present before ACL binding rules are
+	// replicated.
+	AllowMissingAuthMethods bool
+
+	WriteRequest
+}
+
+// ACLBindingRulesUpsertResponse is a response of the upsert ACL binding rules
+// operation.
+type ACLBindingRulesUpsertResponse struct {
+	ACLBindingRules []*ACLBindingRule
+	WriteMeta
+}
+
+// ACLBindingRulesDeleteRequest is used to delete a set of ACL binding rules
+// by their IDs.
+type ACLBindingRulesDeleteRequest struct {
+	ACLBindingRuleIDs []string
+	WriteRequest
+}
+
+// ACLBindingRulesDeleteResponse is a response of the delete ACL binding rules
+// operation.
+type ACLBindingRulesDeleteResponse struct {
+	WriteMeta
+}
+
+// ACLBindingRulesListRequest is the request object when performing ACL
+// binding rules listings.
+type ACLBindingRulesListRequest struct {
+	QueryOptions
+}
+
+// ACLBindingRulesListResponse is the response object when performing ACL
+// binding rule listings.
+type ACLBindingRulesListResponse struct {
+	ACLBindingRules []*ACLBindingRuleListStub
+	QueryMeta
+}
+
+// ACLBindingRulesRequest is the request object when performing a lookup of
+// multiple binding rules by the ID.
+type ACLBindingRulesRequest struct {
+	ACLBindingRuleIDs []string
+	QueryOptions
+}
+
+// ACLBindingRulesResponse is the response object when performing a lookup of
+// multiple binding rules by their IDs.
+type ACLBindingRulesResponse struct {
+	ACLBindingRules map[string]*ACLBindingRule
+	QueryMeta
+}
+
+// ACLBindingRuleRequest is the request object to perform a lookup of an ACL
+// binding rule using a specific ID.
+type ACLBindingRuleRequest struct {
+	ACLBindingRuleID string
+	QueryOptions
+}
+
+// ACLBindingRuleResponse is the response object when performing a lookup of an
+// ACL binding rule matching a specific ID.
+type ACLBindingRuleResponse struct {
+	ACLBindingRule *ACLBindingRule
+	QueryMeta
+}
+
+// ACLLoginRequest is the request object used to exchange an identity token
+// issued by an external identity provider for a Nomad ACL token.
+type ACLLoginRequest struct {
+
+	// AuthMethodName is the name of the auth method being used to login. This
+	// is a required parameter.
+	AuthMethodName string
+
+	// LoginToken is the identity token issued by the provider configured
+	// within the auth method. This is a required parameter.
+	LoginToken string
+
+	WriteRequest
+}
+
+// Validate ensures the request object contains all the required fields in
+// order to login.
+func (a *ACLLoginRequest) Validate() error {
+	var mErr multierror.Error
+	if a.AuthMethodName == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing auth method name"))
+	}
+	if a.LoginToken == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing login token"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// ACLLoginResponse is the response object when performing a successful login.
+// It contains the ACL token which was generated for the identity.
+type ACLLoginResponse struct {
+	ACLToken *ACLToken
+	WriteMeta
+}
@@ -0,0 +1,681 @@
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLRole_SetHash(t *testing.T) {
+	ci.Parallel(t)
+
+	aclRole := &ACLRole{
+		Name:        "acl-role-" + uuid.Short(),
+		Description: "mocked-test-acl-role",
+		Policies: []*ACLRolePolicyLink{
+			{Name: "mocked-test-policy-1"},
+			{Name: "mocked-test-policy-2"},
+		},
+		CreateIndex: 10,
+		ModifyIndex: 10,
+	}
+	out1 := aclRole.SetHash()
+	require.NotNil(t, out1)
+	require.NotNil(t, aclRole.Hash)
+	require.Equal(t, out1, aclRole.Hash)
+
+	aclRole.Policies = []*ACLRolePolicyLink{{Name: "mocked-test-policy-1"}}
+	out2 := aclRole.SetHash()
+	require.NotNil(t, out2)
+	require.NotNil(t, aclRole.Hash)
+	require.Equal(t, out2, aclRole.Hash)
+	require.NotEqual(t, out1, out2)
+}
+
+func TestACLRole_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name                  string
+		inputACLRole          *ACLRole
+		expectedErrorContains string
+	}{
+		{
+			name:                  "role name too long",
+			inputACLRole:          &ACLRole{Name: uuid.Generate() + uuid.Generate() + uuid.Generate() + uuid.Generate()},
+			expectedErrorContains: "invalid name",
+		},
+		{
+			name:                  "role name too short",
+			inputACLRole:          &ACLRole{Name: ""},
+			expectedErrorContains: "invalid name",
+		},
+		{
+			name:                  "role name with invalid characters",
+			inputACLRole:          &ACLRole{Name: "--#$%$^%_%%_?>"},
+			expectedErrorContains: "invalid name",
+		},
+		{
+			name: "description too long",
+			inputACLRole: &ACLRole{
+				Name:        "acl-role",
+				Description: uuid.Generate() + uuid.Generate() + uuid.Generate() + uuid.Generate() + uuid.Generate() + uuid.Generate() + uuid.Generate() + uuid.Generate()},
+			expectedErrorContains: "description longer than",
+		},
+		{
+			name: "no policies",
+			inputACLRole: &ACLRole{
+				Name:        "acl-role",
+				Description: "some description",
+			},
+			expectedErrorContains: "at least one policy should be specified",
+		},
+		{
+			name: "valid",
+			inputACLRole: &ACLRole{
+				Name:        "acl-role",
+				Description: "some description",
+				Policies: []*ACLRolePolicyLink{
+					{Name: "policy-1"},
+				},
+			},
+			expectedErrorContains: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.inputACLRole.Validate()
+			if tc.expectedErrorContains != "" {
+				require.ErrorContains(t, err, tc.expectedErrorContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestACLRole_Canonicalize(t *testing.T) {
+	ci.Parallel(t)
+
+	// An empty ID should be generated.
+	aclRole := &ACLRole{}
+	aclRole.Canonicalize()
+	require.NotEmpty(t, aclRole.ID)
+
+	// An existing ID should be left untouched.
+	existingID := uuid.Generate()
+	aclRole = &ACLRole{ID: existingID}
+	aclRole.Canonicalize()
+	require.Equal(t, existingID, aclRole.ID)
+}
+
+func TestACLRole_Equals(t *testing.T) {
+	ci.Parallel(t)
+
+	aclRole1 := &ACLRole{
+		ID:       uuid.Generate(),
+		Name:     "acl-role-1",
+		Policies: []*ACLRolePolicyLink{{Name: "policy-1"}},
+	}
+	aclRole1.SetHash()
+
+	aclRole2 := aclRole1.Copy()
+	require.True(t, aclRole1.Equals(aclRole2))
+
+	aclRole2.Description = "changed"
+	aclRole2.SetHash()
+	require.False(t, aclRole1.Equals(aclRole2))
+
+	require.False(t, aclRole1.Equals(nil))
+	require.True(t, (*ACLRole)(nil).Equals(nil))
+}
+
+func TestACLRole_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	aclRole := &ACLRole{
+		ID:       uuid.Generate(),
+		Name:     "acl-role-1",
+		Policies: []*ACLRolePolicyLink{{Name: "policy-1"}},
+	}
+	aclRole.SetHash()
+
+	aclRoleCopy := aclRole.Copy()
+	require.Equal(t, aclRole, aclRoleCopy)
+
+	aclRoleCopy.Policies[0].Name = "policy-2"
+	require.Equal(t, "policy-1", aclRole.Policies[0].Name)
+
+	require.Nil(t, (*ACLRole)(nil).Copy())
+}
+
+func TestACLToken_Canonicalize(t *testing.T) {
+	ci.Parallel(t)
+
+	// A new token without a TTL should not gain an expiration time.
+	token := &ACLToken{Type: ACLClientToken, Policies: []string{"foo"}}
+	token.Canonicalize()
+	require.NotEmpty(t, token.AccessorID)
+	require.NotEmpty(t, token.SecretID)
+	require.False(t, token.CreateTime.IsZero())
+	require.Nil(t, token.ExpirationTime)
+
+	// A new token with a TTL should have the expiration time calculated.
+	token = &ACLToken{Type: ACLClientToken, Policies: []string{"foo"}, ExpirationTTL: time.Hour}
+	token.Canonicalize()
+	require.NotNil(t, token.ExpirationTime)
+	require.Equal(t, token.CreateTime.Add(time.Hour), *token.ExpirationTime)
+
+	// An existing token should not be modified.
+	existing := token.Copy()
+	token.ExpirationTTL = 2 * time.Hour
+	token.Canonicalize()
+	require.Equal(t, existing.AccessorID, token.AccessorID)
+	require.Equal(t, existing.ExpirationTime, token.ExpirationTime)
+}
+
+func TestACLToken_Validate_Expiration(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now().UTC()
+
+	testCases := []struct {
+		name          string
+		token         *ACLToken
+		existing      *ACLToken
+		expectedError string
+	}{
+		{
+			name: "negative TTL",
+			token: &ACLToken{
+				Type:          ACLClientToken,
+				Policies:      []string{"foo"},
+				ExpirationTTL: -1 * time.Hour,
+			},
+			expectedError: "should not be negative",
+		},
+		{
+			name: "expiration before create time",
+			token: &ACLToken{
+				Type:           ACLClientToken,
+				Policies:       []string{"foo"},
+				CreateTime:     now,
+				ExpirationTime: pointer.Of(now.Add(-1 * time.Hour)),
+			},
+			expectedError: "cannot be before create time",
+		},
+		{
+			name: "expiration above max TTL",
+			token: &ACLToken{
+				Type:           ACLClientToken,
+				Policies:       []string{"foo"},
+				CreateTime:     now,
+				ExpirationTime: pointer.Of(now.Add(48 * time.Hour)),
+			},
+			expectedError: "cannot be more than",
+		},
+		{
+			name: "expiration below min TTL",
+			token: &ACLToken{
+				Type:           ACLClientToken,
+				Policies:       []string{"foo"},
+				CreateTime:     now,
+				ExpirationTime: pointer.Of(now.Add(time.Second)),
+			},
+			expectedError: "cannot be less than",
+		},
+		{
+			name: "valid expiration",
+			token: &ACLToken{
+				Type:           ACLClientToken,
+				Policies:       []string{"foo"},
+				CreateTime:     now,
+				ExpirationTime: pointer.Of(now.Add(time.Hour)),
+			},
+		},
+		{
+			name: "update modifies expiration",
+			token: &ACLToken{
+				Type:           ACLClientToken,
+				Policies:       []string{"foo"},
+				CreateTime:     now,
+				ExpirationTime: pointer.Of(now.Add(2 * time.Hour)),
+			},
+			existing: &ACLToken{
+				Type:           ACLClientToken,
+				Policies:       []string{"foo"},
+				CreateTime:     now,
+				ExpirationTime: pointer.Of(now.Add(time.Hour)),
+			},
+			expectedError: "cannot update expiration time",
+		},
+		{
+			name: "update removes expiration",
+			token: &ACLToken{
+				Type:     ACLClientToken,
+				Policies: []string{"foo"},
+			},
+			existing: &ACLToken{
+				Type:           ACLClientToken,
+				Policies:       []string{"foo"},
+				CreateTime:     now,
+				ExpirationTime: pointer.Of(now.Add(time.Hour)),
+			},
+			expectedError: "cannot update expiration time",
+		},
+		{
+			name: "update retains expiration",
+			token: &ACLToken{
+				Type:           ACLClientToken,
+				Policies:       []string{"foo", "bar"},
+				CreateTime:     now,
+				ExpirationTime: pointer.Of(now.Add(time.Hour)),
+			},
+			existing: &ACLToken{
+				Type:           ACLClientToken,
+				Policies:       []string{"foo"},
+				CreateTime:     now,
+				ExpirationTime: pointer.Of(now.Add(time.Hour)),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.token.Validate(time.Minute, 24*time.Hour, tc.existing)
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestACLToken_IsExpired(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now().UTC()
+
+	// A token without an expiration time never expires.
+	token := &ACLToken{}
+	require.False(t, token.HasExpirationTime())
+	require.False(t, token.IsExpired(now))
+
+	// A token with an expiration time in the future is not expired.
+	token.ExpirationTime = pointer.Of(now.Add(time.Hour))
+	require.True(t, token.HasExpirationTime())
+	require.False(t, token.IsExpired(now))
+
+	// A token with an expiration time in the past is expired, regardless of
+	// the location of the passed time.
+	token.ExpirationTime = pointer.Of(now.Add(-1 * time.Hour))
+	require.True(t, token.IsExpired(now))
+	require.True(t, token.IsExpired(now.In(time.FixedZone("test", 3600))))
+}
+
+func TestACLAuthMethod_SetHash(t *testing.T) {
+	ci.Parallel(t)
+
+	am := &ACLAuthMethod{
+		Name:          "auth-method-" + uuid.Short(),
+		Type:          ACLAuthMethodTypeOIDC,
+		TokenLocality: ACLAuthMethodTokenLocalityGlobal,
+		MaxTokenTTL:   time.Hour,
+		Config: &ACLAuthMethodConfig{
+			OIDCDiscoveryURL: "http://example.com",
+			OIDCClientID:     "mock",
+		},
+	}
+	out1 := am.SetHash()
+	require.NotNil(t, out1)
+	require.NotNil(t, am.Hash)
+	require.Equal(t, out1, am.Hash)
+
+	am.Config.OIDCClientID = "updated"
+	out2 := am.SetHash()
+	require.NotNil(t, out2)
+	require.NotNil(t, am.Hash)
+	require.Equal(t, out2, am.Hash)
+	require.NotEqual(t, out1, out2)
+
+	// The hash must be deterministic when the config contains maps.
+	am.Config.ClaimMappings = map[string]string{"a": "1", "b": "2", "c": "3", "d": "4"}
+	out3 := am.SetHash()
+	for i := 0; i < 10; i++ {
+		require.Equal(t, out3, am.SetHash())
+	}
+
+	am.Config.JWTValidationPubKeys = []string{"key"}
+	require.NotEqual(t, out3, am.SetHash())
+}
+
+// testJWTPubKey is a PEM encoded public key used to test the validation of
+// JWT auth methods.
+const testJWTPubKey = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE8Gv3mbYpbuhlgsFj1gFlTxxgzrJm
+8m5k6FVz2OD0f9fjrHQJ8NuEqRjPyIy5z4G5o8eG9QUPVNYfmlNKBrrAuw==
+-----END PUBLIC KEY-----`
+
+func TestACLAuthMethod_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	validConfig := &ACLAuthMethodConfig{
+		OIDCDiscoveryURL: "http://example.com",
+		OIDCClientID:     "mock",
+	}
+
+	testCases := []struct {
+		name                  string
+		inputAuthMethod       *ACLAuthMethod
+		expectedErrorContains string
+	}{
+		{
+			name: "name with invalid characters",
+			inputAuthMethod: &ACLAuthMethod{
+				Name:          "--#$%$^%_%%_?>",
+				Type:          ACLAuthMethodTypeOIDC,
+				TokenLocality: ACLAuthMethodTokenLocalityLocal,
+				MaxTokenTTL:   time.Hour,
+				Config:        validConfig,
+			},
+			expectedErrorContains: "invalid name",
+		},
+		{
+			name: "invalid token locality",
+			inputAuthMethod: &ACLAuthMethod{
+				Name:          "auth-method",
+				Type:          ACLAuthMethodTypeOIDC,
+				TokenLocality: "regional",
+				MaxTokenTTL:   time.Hour,
+				Config:        validConfig,
+			},
+			expectedErrorContains: "invalid token locality",
+		},
+		{
+			name: "invalid type",
+			inputAuthMethod: &ACLAuthMethod{
+				Name:          "auth-method",
+				Type:          "SAML",
+				TokenLocality: ACLAuthMethodTokenLocalityLocal,
+				MaxTokenTTL:   time.Hour,
+				Config:        validConfig,
+			},
+			expectedErrorContains: "invalid token type",
+		},
+		{
+			name: "max token TTL out of bounds",
+			inputAuthMethod: &ACLAuthMethod{
+				Name:          "auth-method",
+				Type:          ACLAuthMethodTypeOIDC,
+				TokenLocality: ACLAuthMethodTokenLocalityLocal,
+				MaxTokenTTL:   48 * time.Hour,
+				Config:        validConfig,
+			},
+			expectedErrorContains: "invalid MaxTokenTTL value",
+		},
+		{
+			name: "missing config",
+			inputAuthMethod: &ACLAuthMethod{
+				Name:          "auth-method",
+				Type:          ACLAuthMethodTypeOIDC,
+				TokenLocality: ACLAuthMethodTokenLocalityLocal,
+				MaxTokenTTL:   time.Hour,
+			},
+			expectedErrorContains: "missing auth method config",
+		},
+		{
+			name: "missing discovery URL",
+			inputAuthMethod: &ACLAuthMethod{
+				Name:          "auth-method",
+				Type:          ACLAuthMethodTypeOIDC,
+				TokenLocality: ACLAuthMethodTokenLocalityLocal,
+				MaxTokenTTL:   time.Hour,
+				Config:        &ACLAuthMethodConfig{OIDCClientID: "mock"},
+			},
+			expectedErrorContains: "missing OIDC discovery URL",
+		},
+		{
+			name: "JWT missing keys",
+			inputAuthMethod: &ACLAuthMethod{
+				Name:          "auth-method",
+				Type:          ACLAuthMethodTypeJWT,
+				TokenLocality: ACLAuthMethodTokenLocalityLocal,
+				MaxTokenTTL:   time.Hour,
+				Config:        &ACLAuthMethodConfig{},
+			},
+			expectedErrorContains: "one of JWKSURL or JWTValidationPubKeys must be set",
+		},
+		{
+			name: "JWT JWKS URL and keys",
+			inputAuthMethod: &ACLAuthMethod{
+				Name:          "auth-method",
+				Type:          ACLAuthMethodTypeJWT,
+				TokenLocality: ACLAuthMethodTokenLocalityLocal,
+				MaxTokenTTL:   time.Hour,
+				Config: &ACLAuthMethodConfig{
+					JWKSURL:              "https://example.com/keys",
+					JWTValidationPubKeys: []string{testJWTPubKey},
+				},
+			},
+			expectedErrorContains: "mutually exclusive",
+		},
+		{
+			name: "JWT CA cert without JWKS URL",
+			inputAuthMethod: &ACLAuthMethod{
+				Name:          "auth-method",
+				Type:          ACLAuthMethodTypeJWT,
+				TokenLocality: ACLAuthMethodTokenLocalityLocal,
+				MaxTokenTTL:   time.Hour,
+				Config: &ACLAuthMethodConfig{
+					JWKSCACert:           "ca",
+					JWTValidationPubKeys: []string{testJWTPubKey},
+				},
+			},
+			expectedErrorContains: "JWKSCACert requires JWKSURL",
+		},
+		{
+			name: "JWT invalid public key",
+			inputAuthMethod: &ACLAuthMethod{
+				Name:          "auth-method",
+				Type:          ACLAuthMethodTypeJWT,
+				TokenLocality: ACLAuthMethodTokenLocalityLocal,
+				MaxTokenTTL:   time.Hour,
+				Config:        &ACLAuthMethodConfig{JWTValidationPubKeys: []string{"not-a-pem"}},
+			},
+			expectedErrorContains: "is not a PEM encoded key",
+		},
+		{
+			name: "JWT negative clock skew leeway",
+			inputAuthMethod: &ACLAuthMethod{
+				Name:          "auth-method",
+				Type:          ACLAuthMethodTypeJWT,
+				TokenLocality: ACLAuthMethodTokenLocalityLocal,
+				MaxTokenTTL:   time.Hour,
+				Config: &ACLAuthMethodConfig{
+					JWKSURL:         "https://example.com/keys",
+					ClockSkewLeeway: -time.Second,
+				},
+			},
+			expectedErrorContains: "ClockSkewLeeway must not be negative",
+		},
+		{
+			name: "JWT valid",
+			inputAuthMethod: &ACLAuthMethod{
+				Name:          "auth-method",
+				Type:          ACLAuthMethodTypeJWT,
+				TokenLocality: ACLAuthMethodTokenLocalityLocal,
+				MaxTokenTTL:   time.Hour,
+				Config:        &ACLAuthMethodConfig{JWTValidationPubKeys: []string{testJWTPubKey}},
+			},
+			expectedErrorContains: "",
+		},
+		{
+			name: "valid",
+			inputAuthMethod: &ACLAuthMethod{
+				Name:          "auth-method",
+				Type:          ACLAuthMethodTypeOIDC,
+				TokenLocality: ACLAuthMethodTokenLocalityGlobal,
+				MaxTokenTTL:   time.Hour,
+				Config:        validConfig,
+			},
+			expectedErrorContains: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.inputAuthMethod.Validate(time.Minute, 24*time.Hour)
+			if tc.expectedErrorContains != "" {
+				require.ErrorContains(t, err, tc.expectedErrorContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestACLAuthMethod_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	am := &ACLAuthMethod{
+		Name:          "auth-method",
+		Type:          ACLAuthMethodTypeOIDC,
+		TokenLocality: ACLAuthMethodTokenLocalityLocal,
+		MaxTokenTTL:   time.Hour,
+		Config: &ACLAuthMethodConfig{
+			OIDCDiscoveryURL: "http://example.com",
+			OIDCClientID:     "mock",
+			BoundAudiences:   []string{"audience1"},
+			ClaimMappings:    map[string]string{"sub": "subject"},
+		},
+	}
+	am.SetHash()
+
+	amCopy := am.Copy()
+	require.True(t, am.Equals(amCopy))
+
+	// Modifying the copy should not affect the original.
+	amCopy.Config.BoundAudiences[0] = "audience2"
+	amCopy.Config.ClaimMappings["sub"] = "user"
+	require.Equal(t, "audience1", am.Config.BoundAudiences[0])
+	require.Equal(t, "subject", am.Config.ClaimMappings["sub"])
+
+	amCopy.SetHash()
+	require.False(t, am.Equals(amCopy))
+	require.Nil(t, (*ACLAuthMethod)(nil).Copy())
+}
+
+func TestACLBindingRule_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name                  string
+		inputBindingRule      *ACLBindingRule
+		expectedErrorContains string
+	}{
+		{
+			name:                  "missing auth method",
+			inputBindingRule:      &ACLBindingRule{BindType: ACLBindingRuleBindTypeManagement},
+			expectedErrorContains: "auth method is missing",
+		},
+		{
+			name: "description too long",
+			inputBindingRule: &ACLBindingRule{
+				AuthMethod:  "auth-method",
+				BindType:    ACLBindingRuleBindTypeManagement,
+				Description: uuid.Generate() + uuid.Generate() + uuid.Generate() + uuid.Generate() + uuid.Generate() + uuid.Generate() + uuid.Generate() + uuid.Generate()},
+			expectedErrorContains: "description longer than",
+		},
+		{
+			name:                  "missing bind type",
+			inputBindingRule:      &ACLBindingRule{AuthMethod: "auth-method"},
+			expectedErrorContains: "bind type is missing",
+		},
+		{
+			name:                  "unsupported bind type",
+			inputBindingRule:      &ACLBindingRule{AuthMethod: "auth-method", BindType: "service"},
+			expectedErrorContains: "unsupported bind type",
+		},
+		{
+			name:                  "role bind type missing bind name",
+			inputBindingRule:      &ACLBindingRule{AuthMethod: "auth-method", BindType: ACLBindingRuleBindTypeRole},
+			expectedErrorContains: "bind name is missing",
+		},
+		{
+			name: "management bind type with bind name",
+			inputBindingRule: &ACLBindingRule{
+				AuthMethod: "auth-method",
+				BindType:   ACLBindingRuleBindTypeManagement,
+				BindName:   "admin",
+			},
+			expectedErrorContains: "bind name should be empty",
+		},
+		{
+			name: "invalid selector",
+			inputBindingRule: &ACLBindingRule{
+				AuthMethod: "auth-method",
+				BindType:   ACLBindingRuleBindTypePolicy,
+				BindName:   "engineering",
+				Selector:   "this is not == valid",
+			},
+			expectedErrorContains: "selector is invalid",
+		},
+		{
+			name: "valid",
+			inputBindingRule: &ACLBindingRule{
+				AuthMethod: "auth-method",
+				BindType:   ACLBindingRuleBindTypePolicy,
+				BindName:   "engineering",
+				Selector:   "engineering in list.groups",
+			},
+			expectedErrorContains: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.inputBindingRule.Validate()
+			if tc.expectedErrorContains != "" {
+				require.ErrorContains(t, err, tc.expectedErrorContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestACLBindingRule_Canonicalize(t *testing.T) {
+	ci.Parallel(t)
+
+	// A new rule should have its ID and times set.
+	newRule := &ACLBindingRule{}
+	newRule.Canonicalize()
+	require.NotEmpty(t, newRule.ID)
+	require.False(t, newRule.CreateTime.IsZero())
+	require.Equal(t, newRule.CreateTime, newRule.ModifyTime)
+
+	// An existing rule should only have its modify time updated.
+	existingRule := &ACLBindingRule{ID: "some-id"}
+	existingRule.Canonicalize()
+	require.Equal(t, "some-id", existingRule.ID)
+	require.True(t, existingRule.CreateTime.IsZero())
+	require.False(t, existingRule.ModifyTime.IsZero())
+}
+
+func TestACLLoginRequest_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	req := &ACLLoginRequest{}
+	err := req.Validate()
+	require.ErrorContains(t, err, "missing auth method name")
+	require.ErrorContains(t, err, "missing login token")
+
+	req = &ACLLoginRequest{AuthMethodName: "auth-method", LoginToken: "token"}
+	require.NoError(t, req.Validate())
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// KEKProviderAEAD wraps root keys with a local AES-256-GCM key read from
+	// a file on the server.
+	KEKProviderAEAD = "aead"
+
+	// KEKProviderVaultTransit wraps root keys with a key held by a Vault
+	// Transit secrets engine.
+	KEKProviderVaultTransit = "vault_transit"
+
+	// DefaultKEKVaultTransitMountPath is the default path at which the
+	// Vault Transit secrets engine is mounted.
+	DefaultKEKVaultTransitMountPath = "transit"
+)
+
+// KEKConfig configures the key encryption key (KEK) provider used to wrap
+// the root keys before they are written to the server's on-disk keystore.
+type KEKConfig struct {
+
+	// Provider is the name of the KEK provider. One of "aead" or
+	// "vault_transit". If unset, root keys are written to the keystore
+	// without being wrapped.
+	Provider string `hcl:"provider"`
+
+	// KeyFile is the path to a file holding the base64 encoded 32-byte key
+	// used by the "aead" provider.
+	KeyFile string `hcl:"key_file"`
+
+	// Address is the address of the Vault server used by the
+	// "vault_transit" provider. Defaults to the VAULT_ADDR environment
+	// variable.
+	Address string `hcl:"address"`
+
+	// Token is the Vault token used by the "vault_transit" provider.
+	// Defaults to the VAULT_TOKEN environment variable.
+	Token string `hcl:"token"`
+
+	// Namespace is the Vault namespace used by the "vault_transit"
+	// provider.
+	Namespace string `hcl:"namespace"`
+
+	// MountPath is the path at which the Vault Transit secrets engine is
+	// mounted. Defaults to "transit".
+	MountPath string `hcl:"mount_path"`
+
+	// KeyName is the name of the Vault Transit key used to wrap root keys.
+	KeyName string `hcl:"key_name"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+// Copy returns a copy of this KEK config.
+func (k *KEKConfig) Copy() *KEKConfig {
+	if k == nil {
+		return nil
+	}
+
+	nk := *k
+	nk.ExtraKeysHCL = slices.Clone(k.ExtraKeysHCL)
+	return &nk
+}
+
+// Merge returns a new KEK configuration by merging another KEK
+// configuration into this one
+func (k *KEKConfig) Merge(b *KEKConfig) *KEKConfig {
+	if k == nil {
+		return b.Copy()
+	}
+
+	result := k.Copy()
+	if b == nil {
+		return result
+	}
+
+	if b.Provider != "" {
+		result.Provider = b.Provider
+	}
+	if b.KeyFile != "" {
+		result.KeyFile = b.KeyFile
+	}
+	if b.Address != "" {
+		result.Address = b.Address
+	}
+	if b.Token != "" {
+		result.Token = b.Token
+	}
+	if b.Namespace != "" {
+		result.Namespace = b.Namespace
+	}
+	if b.MountPath != "" {
+		result.MountPath = b.MountPath
+	}
+	if b.KeyName != "" {
+		result.KeyName = b.KeyName
+	}
+	return result
+}
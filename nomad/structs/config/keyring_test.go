@@ -0,0 +1,71 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKEKConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+
+	fullConfig := &KEKConfig{
+		Provider:  KEKProviderVaultTransit,
+		KeyFile:   "/etc/nomad/kek",
+		Address:   "https://vault.example.com:8200",
+		Token:     "root",
+		Namespace: "ns1",
+		MountPath: "nomad-transit",
+		KeyName:   "nomad-keyring",
+	}
+
+	testCases := []struct {
+		name   string
+		left   *KEKConfig
+		right  *KEKConfig
+		expect *KEKConfig
+	}{
+		{
+			name:   "merge onto empty config",
+			left:   &KEKConfig{},
+			right:  fullConfig,
+			expect: fullConfig,
+		},
+		{
+			name:   "merge onto nil config",
+			left:   nil,
+			right:  fullConfig,
+			expect: fullConfig,
+		},
+		{
+			name:   "merge in a nil config",
+			left:   fullConfig,
+			right:  nil,
+			expect: fullConfig,
+		},
+		{
+			name: "merge onto non-empty config",
+			left: &KEKConfig{
+				Provider: KEKProviderAEAD,
+				KeyFile:  "/etc/nomad/kek",
+			},
+			right: &KEKConfig{
+				Provider: KEKProviderVaultTransit,
+				KeyName:  "nomad-keyring",
+			},
+			expect: &KEKConfig{
+				Provider: KEKProviderVaultTransit,
+				KeyFile:  "/etc/nomad/kek",
+				KeyName:  "nomad-keyring",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := tc.left.Merge(tc.right)
+			require.Equal(t, tc.expect, result)
+		})
+	}
+}
@@ -145,6 +145,16 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 		diff.Objects = append(diff.Objects, mrDiff)
 	}
 
+	// Preemption diff
+	if pDiff := primitiveObjectDiff(j.Preemption, other.Preemption, nil, "Preemption", contextual); pDiff != nil {
+		diff.Objects = append(diff.Objects, pDiff)
+	}
+
+	// Window diff
+	if wDiff := schedulingWindowDiff(j.Window, other.Window, contextual); wDiff != nil {
+		diff.Objects = append(diff.Objects, wDiff)
+	}
+
 	// Check to see if there is a diff. We don't use reflect because we are
 	// filtering quite a few fields that will change on each diff.
 	if diff.Type == DiffTypeNone {
@@ -327,6 +337,21 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		diff.Objects = append(diff.Objects, consulDiff)
 	}
 
+	// Gang diff
+	if gangDiff := primitiveObjectDiff(tg.Gang, other.Gang, nil, "Gang", contextual); gangDiff != nil {
+		diff.Objects = append(diff.Objects, gangDiff)
+	}
+
+	// Preemption diff
+	if pDiff := primitiveObjectDiff(tg.Preemption, other.Preemption, nil, "Preemption", contextual); pDiff != nil {
+		diff.Objects = append(diff.Objects, pDiff)
+	}
+
+	// Window diff
+	if wDiff := schedulingWindowDiff(tg.Window, other.Window, contextual); wDiff != nil {
+		diff.Objects = append(diff.Objects, wDiff)
+	}
+
 	// Update diff
 	// COMPAT: Remove "Stagger" in 0.7.0.
 	if uDiff := primitiveObjectDiff(tg.Update, other.Update, []string{"Stagger"}, "Update", contextual); uDiff != nil {
@@ -1858,6 +1883,40 @@ func parameterizedJobDiff(old, new *ParameterizedJobConfig, contextual bool) *Ob
 	return diff
 }
 
+// schedulingWindowDiff returns the diff of two scheduling windows. If
+// contextual diff is enabled, all fields will be returned, even if no diff
+// occurred.
+func schedulingWindowDiff(old, new *SchedulingWindow, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Window"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &SchedulingWindow{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if new == nil {
+		new = &SchedulingWindow{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	// Days diff
+	if daysDiff := stringSetDiff(old.Days, new.Days, "Days", contextual); daysDiff != nil {
+		diff.Objects = append(diff.Objects, daysDiff)
+	}
+
+	return diff
+}
+
 func multiregionDiff(old, new *Multiregion, contextual bool) *ObjectDiff {
 
 	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Multiregion"}
@@ -2109,6 +2168,11 @@ func (r *Resources) Diff(other *Resources, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, nDiffs...)
 	}
 
+	// NUMA diff
+	if numaDiff := primitiveObjectDiff(r.NUMA, other.NUMA, nil, "NUMA", contextual); numaDiff != nil {
+		diff.Objects = append(diff.Objects, numaDiff)
+	}
+
 	return diff
 }
 
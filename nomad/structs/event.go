@@ -16,15 +16,18 @@ type EventStreamWrapper struct {
 type Topic string
 
 const (
-	TopicDeployment Topic = "Deployment"
-	TopicEvaluation Topic = "Evaluation"
-	TopicAllocation Topic = "Allocation"
-	TopicJob        Topic = "Job"
-	TopicNode       Topic = "Node"
-	TopicACLPolicy  Topic = "ACLPolicy"
-	TopicACLToken   Topic = "ACLToken"
-	TopicService    Topic = "Service"
-	TopicAll        Topic = "*"
+	TopicDeployment     Topic = "Deployment"
+	TopicEvaluation     Topic = "Evaluation"
+	TopicAllocation     Topic = "Allocation"
+	TopicJob            Topic = "Job"
+	TopicNode           Topic = "Node"
+	TopicACLPolicy      Topic = "ACLPolicy"
+	TopicACLToken       Topic = "ACLToken"
+	TopicACLRole        Topic = "ACLRole"
+	TopicACLAuthMethod  Topic = "ACLAuthMethod"
+	TopicACLBindingRule Topic = "ACLBindingRule"
+	TopicService        Topic = "Service"
+	TopicAll            Topic = "*"
 
 	TypeNodeRegistration              = "NodeRegistration"
 	TypeNodeDeregistration            = "NodeDeregistration"
@@ -46,6 +49,12 @@ const (
 	TypeACLTokenUpserted              = "ACLTokenUpserted"
 	TypeACLPolicyDeleted              = "ACLPolicyDeleted"
 	TypeACLPolicyUpserted             = "ACLPolicyUpserted"
+	TypeACLRoleDeleted                = "ACLRoleDeleted"
+	TypeACLRoleUpserted               = "ACLRoleUpserted"
+	TypeACLAuthMethodUpserted         = "ACLAuthMethodUpserted"
+	TypeACLAuthMethodDeleted          = "ACLAuthMethodDeleted"
+	TypeACLBindingRuleUpserted        = "ACLBindingRuleUpserted"
+	TypeACLBindingRuleDeleted         = "ACLBindingRuleDeleted"
 	TypeServiceRegistration           = "ServiceRegistration"
 	TypeServiceDeregistration         = "ServiceDeregistration"
 )
@@ -151,3 +160,21 @@ func (a *ACLTokenEvent) SecretID() string {
 type ACLPolicyEvent struct {
 	ACLPolicy *ACLPolicy
 }
+
+// ACLRoleStreamEvent holds a newly updated or deleted ACL role to be used as an
+// event within the event stream.
+type ACLRoleStreamEvent struct {
+	ACLRole *ACLRole
+}
+
+// ACLAuthMethodEvent holds a newly updated or deleted ACL auth method to be
+// used as an event in the event stream.
+type ACLAuthMethodEvent struct {
+	AuthMethod *ACLAuthMethod
+}
+
+// ACLBindingRuleEvent holds a newly updated or deleted ACL binding rule to be
+// used as an event in the event stream.
+type ACLBindingRuleEvent struct {
+	ACLBindingRule *ACLBindingRule
+}
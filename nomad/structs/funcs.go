@@ -194,10 +194,10 @@ func AllocsFit(node *Node, allocs []*Allocation, netIdx *NetworkIndex, checkDevi
 		return false, "cores", used, nil
 	}
 
-	// Check that the node resources (after subtracting reserved) are a
-	// super set of those that are being allocated
-	available := node.ComparableResources()
-	available.Subtract(node.ComparableReservedResources())
+	// Check that the node resources (after subtracting reserved and applying
+	// the oversubscription ratios) are a super set of those that are being
+	// allocated
+	available := node.ComparableAllocatableResources()
 	if superset, dimension := available.Superset(used); !superset {
 		return false, dimension, used, nil
 	}
@@ -236,17 +236,10 @@ func AllocsFit(node *Node, allocs []*Allocation, netIdx *NetworkIndex, checkDevi
 }
 
 func computeFreePercentage(node *Node, util *ComparableResources) (freePctCpu, freePctRam float64) {
-	// COMPAT(0.11): Remove in 0.11
-	reserved := node.ComparableReservedResources()
-	res := node.ComparableResources()
-
 	// Determine the node availability
-	nodeCpu := float64(res.Flattened.Cpu.CpuShares)
-	nodeMem := float64(res.Flattened.Memory.MemoryMB)
-	if reserved != nil {
-		nodeCpu -= float64(reserved.Flattened.Cpu.CpuShares)
-		nodeMem -= float64(reserved.Flattened.Memory.MemoryMB)
-	}
+	available := node.ComparableAllocatableResources()
+	nodeCpu := float64(available.Flattened.Cpu.CpuShares)
+	nodeMem := float64(available.Flattened.Memory.MemoryMB)
 
 	// Compute the free percentage
 	freePctCpu = 1 - (float64(util.Flattened.Cpu.CpuShares) / nodeCpu)
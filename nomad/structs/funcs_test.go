@@ -626,6 +626,68 @@ func TestAllocsFit_MemoryOversubscription(t *testing.T) {
 	require.EqualValues(t, 12000, used.Flattened.Memory.MemoryMaxMB)
 }
 
+func TestAllocsFit_OversubscriptionRatio(t *testing.T) {
+	ci.Parallel(t)
+
+	n := &Node{
+		NodeResources: &NodeResources{
+			Cpu: NodeCpuResources{
+				CpuShares: 2000,
+			},
+			Memory: NodeMemoryResources{
+				MemoryMB: 2048,
+			},
+			CpuOversubscriptionRatio:    2.0,
+			MemoryOversubscriptionRatio: 1.5,
+		},
+		ReservedResources: &NodeReservedResources{
+			Cpu: NodeReservedCpuResources{
+				CpuShares: 1000,
+			},
+			Memory: NodeReservedMemoryResources{
+				MemoryMB: 1024,
+			},
+		},
+	}
+
+	// The allocatable resources are the unreserved resources scaled by the
+	// oversubscription ratios
+	available := n.ComparableAllocatableResources()
+	require.EqualValues(t, 2000, available.Flattened.Cpu.CpuShares)
+	require.EqualValues(t, 1536, available.Flattened.Memory.MemoryMB)
+
+	a1 := &Allocation{
+		AllocatedResources: &AllocatedResources{
+			Tasks: map[string]*AllocatedTaskResources{
+				"web": {
+					Cpu: AllocatedCpuResources{
+						CpuShares: 1000,
+					},
+					Memory: AllocatedMemoryResources{
+						MemoryMB: 768,
+					},
+				},
+			},
+		},
+	}
+
+	// Should fit two allocations beyond the physical capacity
+	fit, _, used, err := AllocsFit(n, []*Allocation{a1, a1}, nil, false)
+	require.NoError(t, err)
+	require.True(t, fit)
+	require.EqualValues(t, 2000, used.Flattened.Cpu.CpuShares)
+	require.EqualValues(t, 1536, used.Flattened.Memory.MemoryMB)
+
+	// Should not fit a third allocation
+	fit, dim, _, err := AllocsFit(n, []*Allocation{a1, a1, a1}, nil, false)
+	require.NoError(t, err)
+	require.False(t, fit)
+	require.Equal(t, "cpu", dim)
+
+	// Scoring treats the allocatable resources as the capacity of the node
+	require.Equal(t, 18.0, ScoreFitBinPack(n, used))
+}
+
 // COMPAT(0.11): Remove in 0.11
 func TestScoreFitBinPack_Old(t *testing.T) {
 	ci.Parallel(t)
@@ -0,0 +1,65 @@
+package structs
+
+import (
+	"fmt"
+)
+
+const (
+	// GangScopeGroup places all of the allocations of a task group
+	// together, or none of them.
+	GangScopeGroup = "group"
+
+	// GangScopeJob places all of the allocations of every task group of the
+	// job with a job scoped gang together, or none of them.
+	GangScopeJob = "job"
+)
+
+// GangStrategy configures all-or-nothing placement of the allocations of a
+// task group.
+type GangStrategy struct {
+	// Scope is either "group" or "job".
+	Scope string
+}
+
+// Copy returns a copy of the gang strategy.
+func (g *GangStrategy) Copy() *GangStrategy {
+	if g == nil {
+		return nil
+	}
+	ng := *g
+	return &ng
+}
+
+// Canonicalize sets the default scope of the gang strategy.
+func (g *GangStrategy) Canonicalize() {
+	if g != nil && g.Scope == "" {
+		g.Scope = GangScopeGroup
+	}
+}
+
+// Validate returns an error if the gang strategy is invalid.
+func (g *GangStrategy) Validate() error {
+	if g == nil {
+		return nil
+	}
+	switch g.Scope {
+	case "", GangScopeGroup, GangScopeJob:
+		return nil
+	default:
+		return fmt.Errorf("gang scope must be one of %q or %q; got %q",
+			GangScopeGroup, GangScopeJob, g.Scope)
+	}
+}
+
+// GangName returns the name of the gang the task group belongs to, or an
+// empty string if its allocations can be placed independently. All of the
+// task groups of a job with a job scoped gang share the same gang name.
+func (tg *TaskGroup) GangName() string {
+	if tg == nil || tg.Gang == nil {
+		return ""
+	}
+	if tg.Gang.Scope == GangScopeJob {
+		return "job"
+	}
+	return "group:" + tg.Name
+}
@@ -0,0 +1,125 @@
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskGroup_Validate_Gang(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name      string
+		jobType   string
+		gang      *GangStrategy
+		expectErr string
+	}{
+		{
+			name:    "group scope",
+			jobType: JobTypeBatch,
+			gang:    &GangStrategy{Scope: GangScopeGroup},
+		},
+		{
+			name:    "job scope",
+			jobType: JobTypeService,
+			gang:    &GangStrategy{Scope: GangScopeJob},
+		},
+		{
+			name:      "invalid scope",
+			jobType:   JobTypeBatch,
+			gang:      &GangStrategy{Scope: "region"},
+			expectErr: `gang scope must be one of "group" or "job"; got "region"`,
+		},
+		{
+			name:      "system job",
+			jobType:   JobTypeSystem,
+			gang:      &GangStrategy{Scope: GangScopeGroup},
+			expectErr: `Job type "system" does not allow gang block`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := MockJob()
+			job.Type = tc.jobType
+			tg := job.TaskGroups[0]
+			tg.Gang = tc.gang
+			if tc.jobType != JobTypeService {
+				tg.Migrate = nil
+			}
+			if tc.jobType == JobTypeSystem {
+				tg.ReschedulePolicy = nil
+			}
+
+			err := tg.Validate(job)
+			if tc.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestTaskGroup_GangName(t *testing.T) {
+	ci.Parallel(t)
+
+	web := &TaskGroup{Name: "web"}
+	require.Empty(t, web.GangName())
+
+	web.Gang = &GangStrategy{}
+	web.Gang.Canonicalize()
+	require.Equal(t, GangScopeGroup, web.Gang.Scope)
+	require.Equal(t, "group:web", web.GangName())
+
+	// All job scoped groups share a gang
+	web.Gang.Scope = GangScopeJob
+	db := &TaskGroup{Name: "db", Gang: &GangStrategy{Scope: GangScopeJob}}
+	require.Equal(t, web.GangName(), db.GangName())
+}
+
+func TestPlan_RemoveAlloc(t *testing.T) {
+	ci.Parallel(t)
+
+	plan := &Plan{
+		NodeUpdate:      make(map[string][]*Allocation),
+		NodeAllocation:  make(map[string][]*Allocation),
+		NodePreemptions: make(map[string][]*Allocation),
+	}
+
+	nodeID := uuid.Generate()
+	placed, other := MockAlloc(), MockAlloc()
+	placed.NodeID, other.NodeID = nodeID, nodeID
+	plan.AppendAlloc(placed, nil)
+	plan.AppendAlloc(other, nil)
+
+	preempted, otherPreempted := MockAlloc(), MockAlloc()
+	preempted.NodeID, otherPreempted.NodeID = nodeID, nodeID
+	plan.AppendPreemptedAlloc(preempted, placed.ID)
+	plan.AppendPreemptedAlloc(otherPreempted, other.ID)
+
+	stopped, otherStopped := MockAlloc(), MockAlloc()
+	stopped.NodeID, otherStopped.NodeID = nodeID, nodeID
+	plan.AppendStoppedAlloc(stopped, "replaced", "", "")
+	plan.AppendStoppedAlloc(otherStopped, "replaced", "", "")
+
+	plan.RemoveAlloc(placed)
+	require.Len(t, plan.NodeAllocation[nodeID], 1)
+	require.Equal(t, other.ID, plan.NodeAllocation[nodeID][0].ID)
+	require.Len(t, plan.NodePreemptions[nodeID], 1)
+	require.Equal(t, otherPreempted.ID, plan.NodePreemptions[nodeID][0].ID)
+
+	// Updates are removed regardless of their position
+	plan.RemoveUpdate(stopped)
+	require.Len(t, plan.NodeUpdate[nodeID], 1)
+	require.Equal(t, otherStopped.ID, plan.NodeUpdate[nodeID][0].ID)
+
+	plan.RemoveAlloc(other)
+	plan.RemoveUpdate(otherStopped)
+	require.True(t, plan.IsNoOp())
+	require.Empty(t, plan.NodePreemptions)
+}
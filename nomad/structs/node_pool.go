@@ -0,0 +1,251 @@
+package structs
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// NodePoolAll is a built-in node pool which always includes all nodes in
+	// the cluster. It cannot be modified or deleted, and jobs which use it
+	// can be placed onto any node.
+	NodePoolAll = "all"
+
+	// NodePoolDefault is a built-in node pool which contains the nodes which
+	// have not been configured with a node pool, and is used by jobs which do
+	// not specify one. It cannot be deleted.
+	NodePoolDefault = "default"
+
+	// maxNodePoolDescriptionLength limits a node pool description length.
+	maxNodePoolDescriptionLength = 256
+)
+
+const (
+	// NodePoolListRPCMethod is the RPC method for listing node pools.
+	//
+	// Args: NodePoolListRequest
+	// Reply: NodePoolListResponse
+	NodePoolListRPCMethod = "NodePool.List"
+
+	// NodePoolGetRPCMethod is the RPC method for detailing a node pool by its
+	// name.
+	//
+	// Args: NodePoolSpecificRequest
+	// Reply: SingleNodePoolResponse
+	NodePoolGetRPCMethod = "NodePool.GetNodePool"
+
+	// NodePoolUpsertRPCMethod is the RPC method for creating or updating node
+	// pools.
+	//
+	// Args: NodePoolUpsertRequest
+	// Reply: GenericResponse
+	NodePoolUpsertRPCMethod = "NodePool.UpsertNodePools"
+
+	// NodePoolDeleteRPCMethod is the RPC method for deleting node pools by
+	// their name.
+	//
+	// Args: NodePoolDeleteRequest
+	// Reply: GenericResponse
+	NodePoolDeleteRPCMethod = "NodePool.DeleteNodePools"
+)
+
+var (
+	// validNodePoolName is used to validate a node pool name.
+	validNodePoolName = regexp.MustCompile("^[a-zA-Z0-9-_]{1,128}$")
+)
+
+// NodePool allows partitioning the nodes of a cluster, so that jobs can be
+// scoped to a subset of them.
+type NodePool struct {
+	// Name is the unique name of the node pool.
+	Name string
+
+	// Description is a human readable description of the node pool.
+	Description string
+
+	// Meta is the set of metadata key/value pairs attached to the node pool.
+	Meta map[string]string
+
+	// SchedulerConfiguration, if set, overrides the cluster scheduler
+	// configuration for jobs placed within the node pool.
+	SchedulerConfiguration *NodePoolSchedulerConfiguration
+
+	// Hash is the hash of the node pool which is used to efficiently detect
+	// changes.
+	Hash []byte
+
+	// Raft indexes.
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// NodePoolSchedulerConfiguration is the subset of the scheduler configuration
+// which can be overridden per node pool. Fields which are not set use the
+// value from the cluster scheduler configuration.
+type NodePoolSchedulerConfiguration struct {
+	// SchedulerAlgorithm overrides the scheduling algorithm used for jobs
+	// within the node pool.
+	SchedulerAlgorithm SchedulerAlgorithm `hcl:"scheduler_algorithm"`
+
+	// MemoryOversubscriptionEnabled overrides whether memory
+	// oversubscription is enabled for jobs within the node pool.
+	MemoryOversubscriptionEnabled *bool `hcl:"memory_oversubscription_enabled"`
+}
+
+// IsValidNodePoolName returns whether the passed name is a valid node pool
+// name.
+func IsValidNodePoolName(name string) bool {
+	return validNodePoolName.MatchString(name)
+}
+
+// IsBuiltIn returns whether the node pool is one of the pools which are
+// created and managed by Nomad.
+func (n *NodePool) IsBuiltIn() bool {
+	switch n.Name {
+	case NodePoolAll, NodePoolDefault:
+		return true
+	default:
+		return false
+	}
+}
+
+// Validate performs validation of the user supplied node pool fields.
+func (n *NodePool) Validate() error {
+	var mErr multierror.Error
+
+	if !validNodePoolName.MatchString(n.Name) {
+		mErr.Errors = append(mErr.Errors,
+			fmt.Errorf("invalid name %q. Must match regex %s", n.Name, validNodePoolName))
+	}
+	if len(n.Description) > maxNodePoolDescriptionLength {
+		mErr.Errors = append(mErr.Errors,
+			fmt.Errorf("description longer than %d", maxNodePoolDescriptionLength))
+	}
+	if n.SchedulerConfiguration != nil {
+		switch n.SchedulerConfiguration.SchedulerAlgorithm {
+		case "", SchedulerAlgorithmBinpack, SchedulerAlgorithmSpread:
+		default:
+			mErr.Errors = append(mErr.Errors,
+				fmt.Errorf("invalid scheduler algorithm %q", n.SchedulerConfiguration.SchedulerAlgorithm))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// SetHash is used to compute and set the hash of the node pool.
+func (n *NodePool) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes)
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// Write all the user set fields
+	_, _ = hash.Write([]byte(n.Name))
+	_, _ = hash.Write([]byte(n.Description))
+	if n.SchedulerConfiguration != nil {
+		_, _ = hash.Write([]byte(n.SchedulerConfiguration.SchedulerAlgorithm))
+		if n.SchedulerConfiguration.MemoryOversubscriptionEnabled != nil {
+			_, _ = hash.Write([]byte(fmt.Sprint(*n.SchedulerConfiguration.MemoryOversubscriptionEnabled)))
+		}
+	}
+
+	// sort keys to ensure hash stability
+	keys := make([]string, 0, len(n.Meta))
+	for k := range n.Meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		_, _ = hash.Write([]byte(k))
+		_, _ = hash.Write([]byte(n.Meta[k]))
+	}
+
+	// Finalize the hash
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash
+	n.Hash = hashVal
+	return hashVal
+}
+
+// Copy returns a deep copy of the node pool.
+func (n *NodePool) Copy() *NodePool {
+	if n == nil {
+		return nil
+	}
+
+	nc := new(NodePool)
+	*nc = *n
+	nc.Meta = helper.CopyMapStringString(n.Meta)
+	nc.Hash = make([]byte, len(n.Hash))
+	copy(nc.Hash, n.Hash)
+
+	if n.SchedulerConfiguration != nil {
+		sc := *n.SchedulerConfiguration
+		sc.MemoryOversubscriptionEnabled = pointer.Copy(n.SchedulerConfiguration.MemoryOversubscriptionEnabled)
+		nc.SchedulerConfiguration = &sc
+	}
+	return nc
+}
+
+// BuiltInNodePools returns the node pools which are created by Nomad and
+// always exist within state.
+func BuiltInNodePools() []*NodePool {
+	all := &NodePool{
+		Name:        NodePoolAll,
+		Description: "Node pool with all nodes in the cluster.",
+	}
+	all.SetHash()
+
+	defaultPool := &NodePool{
+		Name:        NodePoolDefault,
+		Description: "Default node pool.",
+	}
+	defaultPool.SetHash()
+
+	return []*NodePool{all, defaultPool}
+}
+
+// NodePoolListRequest is used to request a list of node pools.
+type NodePoolListRequest struct {
+	QueryOptions
+}
+
+// NodePoolListResponse is used for a list request.
+type NodePoolListResponse struct {
+	NodePools []*NodePool
+	QueryMeta
+}
+
+// NodePoolSpecificRequest is used to query a specific node pool.
+type NodePoolSpecificRequest struct {
+	Name string
+	QueryOptions
+}
+
+// SingleNodePoolResponse is used to return a single node pool.
+type SingleNodePoolResponse struct {
+	NodePool *NodePool
+	QueryMeta
+}
+
+// NodePoolUpsertRequest is used to upsert a set of node pools.
+type NodePoolUpsertRequest struct {
+	NodePools []*NodePool
+	WriteRequest
+}
+
+// NodePoolDeleteRequest is used to delete a set of node pools.
+type NodePoolDeleteRequest struct {
+	Names []string
+	WriteRequest
+}
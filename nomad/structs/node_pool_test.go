@@ -0,0 +1,117 @@
+package structs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodePool_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		pool        *NodePool
+		expectedErr string
+	}{
+		{
+			name: "valid pool",
+			pool: &NodePool{
+				Name:        "valid",
+				Description: "a valid node pool",
+				SchedulerConfiguration: &NodePoolSchedulerConfiguration{
+					SchedulerAlgorithm: SchedulerAlgorithmSpread,
+				},
+			},
+		},
+		{
+			name:        "invalid name",
+			pool:        &NodePool{Name: "not valid"},
+			expectedErr: "invalid name",
+		},
+		{
+			name: "description too long",
+			pool: &NodePool{
+				Name:        "valid",
+				Description: strings.Repeat("a", maxNodePoolDescriptionLength+1),
+			},
+			expectedErr: "description longer than",
+		},
+		{
+			name: "invalid scheduler algorithm",
+			pool: &NodePool{
+				Name: "valid",
+				SchedulerConfiguration: &NodePoolSchedulerConfiguration{
+					SchedulerAlgorithm: "round-robin",
+				},
+			},
+			expectedErr: "invalid scheduler algorithm",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.pool.Validate()
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestNodePool_SetHash(t *testing.T) {
+	ci.Parallel(t)
+
+	pool := &NodePool{
+		Name: "pool",
+		Meta: map[string]string{"a": "b", "c": "d"},
+	}
+	origHash := pool.SetHash()
+	require.NotEmpty(t, origHash)
+
+	// The hash should be stable across copies.
+	require.Equal(t, origHash, pool.Copy().SetHash())
+
+	// Changing the scheduler configuration should change the hash.
+	pool.SchedulerConfiguration = &NodePoolSchedulerConfiguration{
+		MemoryOversubscriptionEnabled: pointer.Of(true),
+	}
+	require.NotEqual(t, origHash, pool.SetHash())
+}
+
+func TestSchedulerConfiguration_WithNodePool(t *testing.T) {
+	ci.Parallel(t)
+
+	config := &SchedulerConfiguration{
+		SchedulerAlgorithm:            SchedulerAlgorithmBinpack,
+		MemoryOversubscriptionEnabled: false,
+	}
+
+	// Pools without overrides should return the cluster configuration.
+	require.Equal(t, config, config.WithNodePool(nil))
+	require.Equal(t, config, config.WithNodePool(&NodePool{Name: "pool"}))
+
+	// Pool overrides should be applied without modifying the original.
+	pool := &NodePool{
+		Name: "pool",
+		SchedulerConfiguration: &NodePoolSchedulerConfiguration{
+			SchedulerAlgorithm:            SchedulerAlgorithmSpread,
+			MemoryOversubscriptionEnabled: pointer.Of(true),
+		},
+	}
+	out := config.WithNodePool(pool)
+	require.Equal(t, SchedulerAlgorithmSpread, out.SchedulerAlgorithm)
+	require.True(t, out.MemoryOversubscriptionEnabled)
+	require.Equal(t, SchedulerAlgorithmBinpack, config.SchedulerAlgorithm)
+	require.False(t, config.MemoryOversubscriptionEnabled)
+
+	// Unset overrides should keep the cluster value.
+	pool.SchedulerConfiguration.SchedulerAlgorithm = ""
+	out = config.WithNodePool(pool)
+	require.Equal(t, SchedulerAlgorithmBinpack, out.SchedulerAlgorithm)
+}
@@ -0,0 +1,153 @@
+package structs
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// NUMAAffinityNone places the reserved cores of a task without regard
+	// for the NUMA topology of the node.
+	NUMAAffinityNone = "none"
+
+	// NUMAAffinityPrefer places the reserved cores and memory of a task on a
+	// single NUMA node if possible, but allows them to span NUMA nodes
+	// otherwise.
+	NUMAAffinityPrefer = "prefer"
+
+	// NUMAAffinityRequire places the reserved cores and memory of a task on a
+	// single NUMA node, and rejects nodes where that is not possible.
+	NUMAAffinityRequire = "require"
+)
+
+// NUMA is the NUMA placement requested for the resources of a task.
+type NUMA struct {
+	// Affinity is one of "none", "prefer" or "require".
+	Affinity string
+}
+
+// Copy returns a copy of the NUMA request.
+func (n *NUMA) Copy() *NUMA {
+	if n == nil {
+		return nil
+	}
+	nn := *n
+	return &nn
+}
+
+// Equals returns whether the NUMA requests are equal. A nil request is
+// equal to a request with no affinity.
+func (n *NUMA) Equals(o *NUMA) bool {
+	return n.affinity() == o.affinity()
+}
+
+// Validate returns an error if the NUMA request is invalid.
+func (n *NUMA) Validate() error {
+	if n == nil {
+		return nil
+	}
+	switch n.Affinity {
+	case "", NUMAAffinityNone, NUMAAffinityPrefer, NUMAAffinityRequire:
+		return nil
+	default:
+		return fmt.Errorf("numa affinity must be one of %q, %q or %q; got %q",
+			NUMAAffinityNone, NUMAAffinityPrefer, NUMAAffinityRequire, n.Affinity)
+	}
+}
+
+// Requested returns whether the task asked for its resources to be placed
+// on a single NUMA node.
+func (n *NUMA) Requested() bool {
+	affinity := n.affinity()
+	return affinity == NUMAAffinityPrefer || affinity == NUMAAffinityRequire
+}
+
+// Required returns whether the task can only be placed on a node where its
+// resources fit on a single NUMA node.
+func (n *NUMA) Required() bool {
+	return n.affinity() == NUMAAffinityRequire
+}
+
+func (n *NUMA) affinity() string {
+	if n == nil || n.Affinity == "" {
+		return NUMAAffinityNone
+	}
+	return n.Affinity
+}
+
+// NodeNUMAResources is the NUMA topology of a node.
+type NodeNUMAResources struct {
+	// Nodes are the NUMA nodes of the node, sorted by ID.
+	Nodes []*NUMANode
+}
+
+// NUMANode is a single NUMA node.
+type NUMANode struct {
+	// ID is the ID assigned to the NUMA node by the operating system.
+	ID int
+
+	// Cores are the IDs of the CPU cores belonging to the NUMA node.
+	Cores []uint16
+
+	// MemoryMB is the memory local to the NUMA node.
+	MemoryMB int64
+
+	// Distances are the relative distances from this NUMA node to each
+	// NUMA node, in the same order as NodeNUMAResources.Nodes. The distance
+	// from a NUMA node to itself is conventionally 10.
+	Distances []int
+}
+
+// Copy returns a deep copy of the NUMA topology.
+func (n *NodeNUMAResources) Copy() *NodeNUMAResources {
+	if n == nil {
+		return nil
+	}
+	nn := &NodeNUMAResources{}
+	if n.Nodes != nil {
+		nn.Nodes = make([]*NUMANode, len(n.Nodes))
+		for i, node := range n.Nodes {
+			nn.Nodes[i] = node.Copy()
+		}
+	}
+	return nn
+}
+
+// Equals returns whether the NUMA topologies are equal.
+func (n *NodeNUMAResources) Equals(o *NodeNUMAResources) bool {
+	if n == nil || o == nil {
+		return n == o
+	}
+	if len(n.Nodes) != len(o.Nodes) {
+		return false
+	}
+	for i := range n.Nodes {
+		if !n.Nodes[i].Equals(o.Nodes[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Copy returns a deep copy of the NUMA node.
+func (n *NUMANode) Copy() *NUMANode {
+	if n == nil {
+		return nil
+	}
+	nn := *n
+	nn.Cores = slices.Clone(n.Cores)
+	nn.Distances = slices.Clone(n.Distances)
+	return &nn
+}
+
+// Equals returns whether the NUMA nodes are equal.
+func (n *NUMANode) Equals(o *NUMANode) bool {
+	if n == nil || o == nil {
+		return n == o
+	}
+	return n.ID == o.ID &&
+		n.MemoryMB == o.MemoryMB &&
+		slices.Equal(n.Cores, o.Cores) &&
+		slices.Equal(n.Distances, o.Distances)
+}
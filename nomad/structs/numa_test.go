@@ -0,0 +1,79 @@
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResources_Validate_NUMA(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name      string
+		resources *Resources
+		expectErr string
+	}{
+		{
+			name:      "no numa",
+			resources: &Resources{Cores: 2, MemoryMB: 256},
+		},
+		{
+			name:      "require with cores",
+			resources: &Resources{Cores: 2, MemoryMB: 256, NUMA: &NUMA{Affinity: NUMAAffinityRequire}},
+		},
+		{
+			name:      "none with cpu",
+			resources: &Resources{CPU: 100, MemoryMB: 256, NUMA: &NUMA{Affinity: NUMAAffinityNone}},
+		},
+		{
+			name:      "prefer with cpu",
+			resources: &Resources{CPU: 100, MemoryMB: 256, NUMA: &NUMA{Affinity: NUMAAffinityPrefer}},
+			expectErr: "NUMA affinity along with the 'cores' resource",
+		},
+		{
+			name:      "invalid affinity",
+			resources: &Resources{Cores: 2, MemoryMB: 256, NUMA: &NUMA{Affinity: "always"}},
+			expectErr: `numa affinity must be one of "none", "prefer" or "require"; got "always"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.resources.Validate()
+			if tc.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestNUMA_Equals(t *testing.T) {
+	ci.Parallel(t)
+
+	require.True(t, (*NUMA)(nil).Equals(&NUMA{Affinity: NUMAAffinityNone}))
+	require.True(t, (&NUMA{}).Equals(nil))
+	require.False(t, (&NUMA{Affinity: NUMAAffinityPrefer}).Equals(nil))
+	require.False(t, (&NUMA{Affinity: NUMAAffinityPrefer}).Equals(&NUMA{Affinity: NUMAAffinityRequire}))
+}
+
+func TestNodeNUMAResources_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	n := &NodeNUMAResources{
+		Nodes: []*NUMANode{
+			{ID: 0, Cores: []uint16{0, 1}, MemoryMB: 1024, Distances: []int{10, 21}},
+			{ID: 1, Cores: []uint16{2, 3}, MemoryMB: 1024, Distances: []int{21, 10}},
+		},
+	}
+	c := n.Copy()
+	require.True(t, n.Equals(c))
+
+	c.Nodes[0].Cores[0] = 4
+	require.False(t, n.Equals(c))
+	require.Equal(t, uint16(0), n.Nodes[0].Cores[0])
+}
@@ -1,9 +1,13 @@
 package structs
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/raft"
 )
 
@@ -171,6 +175,14 @@ type SchedulerConfiguration struct {
 	// during leadership transitions.
 	PauseEvalBroker bool `hcl:"pause_eval_broker"`
 
+	// NodeScorers are weighted expressions over node attributes and metadata
+	// which are used to score nodes in addition to the built-in scoring.
+	NodeScorers []*NodeScorer `hcl:"node_score"`
+
+	// EvalBrokerFairShare configures weighted fair-share dequeuing of
+	// evaluations across namespaces by the evaluation broker.
+	EvalBrokerFairShare *EvalBrokerFairShare `hcl:"eval_broker_fair_share"`
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -182,9 +194,39 @@ func (s *SchedulerConfiguration) Copy() *SchedulerConfiguration {
 	}
 
 	ns := *s
+	if s.NodeScorers != nil {
+		ns.NodeScorers = make([]*NodeScorer, len(s.NodeScorers))
+		for i, scorer := range s.NodeScorers {
+			ns.NodeScorers[i] = scorer.Copy()
+		}
+	}
+	ns.EvalBrokerFairShare = s.EvalBrokerFairShare.Copy()
 	return &ns
 }
 
+// WithNodePool returns a copy of the scheduler configuration with the
+// overrides of the node pool applied. The receiver is returned unmodified if
+// the node pool has no scheduler configuration.
+func (s *SchedulerConfiguration) WithNodePool(pool *NodePool) *SchedulerConfiguration {
+	if pool == nil || pool.SchedulerConfiguration == nil {
+		return s
+	}
+
+	sc := s.Copy()
+	if sc == nil {
+		sc = &SchedulerConfiguration{}
+	}
+
+	poolConfig := pool.SchedulerConfiguration
+	if poolConfig.SchedulerAlgorithm != "" {
+		sc.SchedulerAlgorithm = poolConfig.SchedulerAlgorithm
+	}
+	if poolConfig.MemoryOversubscriptionEnabled != nil {
+		sc.MemoryOversubscriptionEnabled = *poolConfig.MemoryOversubscriptionEnabled
+	}
+	return sc
+}
+
 func (s *SchedulerConfiguration) EffectiveSchedulerAlgorithm() SchedulerAlgorithm {
 	if s == nil || s.SchedulerAlgorithm == "" {
 		return SchedulerAlgorithmBinpack
@@ -210,9 +252,121 @@ func (s *SchedulerConfiguration) Validate() error {
 		return fmt.Errorf("invalid scheduler algorithm: %v", s.SchedulerAlgorithm)
 	}
 
+	names := make(map[string]struct{}, len(s.NodeScorers))
+	for i, scorer := range s.NodeScorers {
+		if scorer == nil {
+			return fmt.Errorf("invalid node score %d: must not be empty", i+1)
+		}
+		if err := scorer.Validate(); err != nil {
+			return fmt.Errorf("invalid node score %d: %v", i+1, err)
+		}
+		if _, ok := names[scorer.Name]; ok {
+			return fmt.Errorf("invalid node score %d: duplicate name %q", i+1, scorer.Name)
+		}
+		names[scorer.Name] = struct{}{}
+	}
+
+	if err := s.EvalBrokerFairShare.Validate(); err != nil {
+		return fmt.Errorf("invalid eval broker fair share: %v", err)
+	}
+
 	return nil
 }
 
+// EvalBrokerFairShare configures the evaluation broker to dequeue the ready
+// evaluations of each scheduler fairly across namespaces, instead of strictly
+// by priority. Each namespace receives a share of the dequeues proportional
+// to its weight, and evaluations are ordered by priority within a namespace.
+type EvalBrokerFairShare struct {
+	// Enabled enables fair-share dequeuing.
+	Enabled bool `hcl:"enabled"`
+
+	// Weights are the relative weights of namespaces. Namespaces without a
+	// weight have a weight of 1.
+	Weights map[string]int `hcl:"weights"`
+}
+
+func (f *EvalBrokerFairShare) Copy() *EvalBrokerFairShare {
+	if f == nil {
+		return nil
+	}
+	nf := *f
+	nf.Weights = helper.CopyMap(f.Weights)
+	return &nf
+}
+
+// Weight returns the weight of the namespace.
+func (f *EvalBrokerFairShare) Weight(namespace string) int {
+	if f == nil {
+		return 1
+	}
+	if weight, ok := f.Weights[namespace]; ok {
+		return weight
+	}
+	return 1
+}
+
+func (f *EvalBrokerFairShare) Validate() error {
+	if f == nil {
+		return nil
+	}
+	var mErr multierror.Error
+	for namespace, weight := range f.Weights {
+		if weight < 1 || weight > 1000 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("weight %d of namespace %q must be between 1 and 1000", weight, namespace))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// NodeScorer is a weighted expression over the attributes or metadata of a
+// node. The value of the expression is scaled to the range between Min and
+// Max and multiplied by the weight, so that a negative weight prefers nodes
+// with smaller values.
+type NodeScorer struct {
+	// Name identifies the scorer in placement metrics.
+	Name string `hcl:"name"`
+
+	// Expression is interpolated against the node in the same way as
+	// constraint targets, e.g. "${meta.cost_per_hour}", and must resolve to
+	// a number.
+	Expression string `hcl:"expression"`
+
+	// Weight is the weight of the scorer, from -100 to 100.
+	Weight int8 `hcl:"weight"`
+
+	// Min and Max are the values of the expression which are scored as 0
+	// and 1 respectively, before weighting. Values outside of the range are
+	// clamped.
+	Min float64 `hcl:"min"`
+	Max float64 `hcl:"max"`
+}
+
+func (n *NodeScorer) Copy() *NodeScorer {
+	if n == nil {
+		return nil
+	}
+	nn := *n
+	return &nn
+}
+
+func (n *NodeScorer) Validate() error {
+	var mErr multierror.Error
+	if n.Name == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing name"))
+	}
+	if !strings.HasPrefix(n.Expression, "${") || !strings.HasSuffix(n.Expression, "}") {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("expression %q must be an interpolation such as ${meta.key}", n.Expression))
+	}
+	if n.Weight == 0 || n.Weight > 100 || n.Weight < -100 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("weight %d must be non-zero and between -100 and 100", n.Weight))
+	}
+	if n.Max <= n.Min {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("max (%v) must be greater than min (%v)", n.Max, n.Min))
+	}
+	return mErr.ErrorOrNil()
+}
+
 // SchedulerConfigurationResponse is the response object that wraps SchedulerConfiguration
 type SchedulerConfigurationResponse struct {
 	// SchedulerConfig contains scheduler config options
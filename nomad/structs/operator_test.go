@@ -0,0 +1,118 @@
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerConfiguration_Validate_NodeScorers(t *testing.T) {
+	ci.Parallel(t)
+
+	validScorer := func() *NodeScorer {
+		return &NodeScorer{
+			Name:       "cost",
+			Expression: "${meta.cost_per_hour}",
+			Weight:     -50,
+			Min:        0,
+			Max:        10,
+		}
+	}
+
+	cases := []struct {
+		name      string
+		modify    func(*SchedulerConfiguration)
+		expectErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(*SchedulerConfiguration) {},
+		},
+		{
+			name: "missing name",
+			modify: func(c *SchedulerConfiguration) {
+				c.NodeScorers[0].Name = ""
+			},
+			expectErr: "missing name",
+		},
+		{
+			name: "not an interpolation",
+			modify: func(c *SchedulerConfiguration) {
+				c.NodeScorers[0].Expression = "meta.cost_per_hour"
+			},
+			expectErr: `expression "meta.cost_per_hour" must be an interpolation`,
+		},
+		{
+			name: "zero weight",
+			modify: func(c *SchedulerConfiguration) {
+				c.NodeScorers[0].Weight = 0
+			},
+			expectErr: "weight 0 must be non-zero",
+		},
+		{
+			name: "empty range",
+			modify: func(c *SchedulerConfiguration) {
+				c.NodeScorers[0].Max = 0
+			},
+			expectErr: "max (0) must be greater than min (0)",
+		},
+		{
+			name: "duplicate name",
+			modify: func(c *SchedulerConfiguration) {
+				c.NodeScorers = append(c.NodeScorers, validScorer())
+			},
+			expectErr: `invalid node score 2: duplicate name "cost"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &SchedulerConfiguration{
+				NodeScorers: []*NodeScorer{validScorer()},
+			}
+			tc.modify(config)
+
+			err := config.Validate()
+			if tc.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestSchedulerConfiguration_Copy_NodeScorers(t *testing.T) {
+	ci.Parallel(t)
+
+	config := &SchedulerConfiguration{
+		NodeScorers: []*NodeScorer{{Name: "cost", Weight: 10}},
+	}
+	out := config.Copy()
+	out.NodeScorers[0].Weight = 20
+	require.Equal(t, int8(10), config.NodeScorers[0].Weight)
+}
+
+func TestSchedulerConfiguration_EvalBrokerFairShare(t *testing.T) {
+	ci.Parallel(t)
+
+	config := &SchedulerConfiguration{
+		EvalBrokerFairShare: &EvalBrokerFairShare{
+			Enabled: true,
+			Weights: map[string]int{"prod": 4},
+		},
+	}
+	require.NoError(t, config.Validate())
+	require.Equal(t, 4, config.EvalBrokerFairShare.Weight("prod"))
+	require.Equal(t, 1, config.EvalBrokerFairShare.Weight("dev"))
+
+	out := config.Copy()
+	out.EvalBrokerFairShare.Weights["prod"] = 8
+	require.Equal(t, 4, config.EvalBrokerFairShare.Weights["prod"])
+
+	config.EvalBrokerFairShare.Weights["dev"] = 0
+	require.ErrorContains(t, config.Validate(),
+		`weight 0 of namespace "dev" must be between 1 and 1000`)
+}
@@ -0,0 +1,69 @@
+package structs
+
+import (
+	"fmt"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// PreemptionPolicy bounds the preemption performed to place the allocations
+// of a task group, and controls whether the allocations of the task group
+// may be preempted themselves. A policy set on the job applies to every task
+// group that doesn't set its own.
+type PreemptionPolicy struct {
+	// MaxPreemptions is the maximum number of allocations that may be
+	// preempted to place the allocations of the task group in a single
+	// evaluation. Zero means there is no limit.
+	MaxPreemptions int
+
+	// ProtectedPriority prevents the task group from preempting allocations
+	// of jobs with a priority at or above it. Zero means only the priority
+	// of the job limits which allocations may be preempted.
+	ProtectedPriority int
+
+	// Protected prevents the allocations of the task group from being
+	// preempted by any other job.
+	Protected bool
+}
+
+// Copy returns a copy of the preemption policy.
+func (p *PreemptionPolicy) Copy() *PreemptionPolicy {
+	if p == nil {
+		return nil
+	}
+	np := *p
+	return &np
+}
+
+// Validate returns an error if the preemption policy is invalid.
+func (p *PreemptionPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	if p.MaxPreemptions < 0 {
+		mErr.Errors = append(mErr.Errors,
+			fmt.Errorf("max_preemptions must not be negative; got %d", p.MaxPreemptions))
+	}
+	if p.ProtectedPriority != 0 &&
+		(p.ProtectedPriority < JobMinPriority || p.ProtectedPriority > JobMaxPriority) {
+		mErr.Errors = append(mErr.Errors,
+			fmt.Errorf("protected_priority must be between [%d, %d]; got %d",
+				JobMinPriority, JobMaxPriority, p.ProtectedPriority))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// LookupPreemptionPolicy returns the preemption policy of the named task
+// group, falling back to the policy of the job. It returns nil if neither
+// sets a policy.
+func (j *Job) LookupPreemptionPolicy(group string) *PreemptionPolicy {
+	if j == nil {
+		return nil
+	}
+	if tg := j.LookupTaskGroup(group); tg != nil && tg.Preemption != nil {
+		return tg.Preemption
+	}
+	return j.Preemption
+}
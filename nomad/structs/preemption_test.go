@@ -0,0 +1,68 @@
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreemptionPolicy_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name      string
+		policy    *PreemptionPolicy
+		expectErr string
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "valid",
+			policy: &PreemptionPolicy{
+				MaxPreemptions:    10,
+				ProtectedPriority: 70,
+				Protected:         true,
+			},
+		},
+		{
+			name:      "negative max preemptions",
+			policy:    &PreemptionPolicy{MaxPreemptions: -1},
+			expectErr: "max_preemptions must not be negative",
+		},
+		{
+			name:      "protected priority out of range",
+			policy:    &PreemptionPolicy{ProtectedPriority: 101},
+			expectErr: "protected_priority must be between [1, 100]; got 101",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.Validate()
+			if tc.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestJob_LookupPreemptionPolicy(t *testing.T) {
+	ci.Parallel(t)
+
+	job := MockJob()
+	require.Nil(t, job.LookupPreemptionPolicy("web"))
+
+	// The policy of the job applies to every task group
+	job.Preemption = &PreemptionPolicy{MaxPreemptions: 5}
+	require.Equal(t, job.Preemption, job.LookupPreemptionPolicy("web"))
+
+	// The policy of the task group overrides the policy of the job
+	job.TaskGroups[0].Preemption = &PreemptionPolicy{Protected: true}
+	require.Equal(t, job.TaskGroups[0].Preemption, job.LookupPreemptionPolicy("web"))
+	require.Equal(t, job.Preemption, job.LookupPreemptionPolicy("other"))
+}
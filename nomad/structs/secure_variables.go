@@ -39,10 +39,40 @@ const (
 	// Reply: SecureVariablesByNameResponse
 	SecureVariablesReadRPCMethod = "SecureVariables.Read"
 
+	// SecureVariablesHistoryRPCMethod is the RPC method for listing the
+	// tracked versions of a secure variable according to its namespace and
+	// path.
+	//
+	// Args: SecureVariablesHistoryRequest
+	// Reply: SecureVariablesHistoryResponse
+	SecureVariablesHistoryRPCMethod = "SecureVariables.History"
+
+	// SecureVariablesRekeyRPCMethod is the RPC method used by the leader to
+	// re-encrypt tracked versions of secure variables with the active root
+	// key. It requires a management token.
+	//
+	// Args: SecureVariablesRekeyRequest
+	// Reply: SecureVariablesRekeyResponse
+	SecureVariablesRekeyRPCMethod = "SecureVariables.Rekey"
+
+	// SecureVariableTrackedVersions is the number of versions of a secure
+	// variable that are retained, including the current version.
+	SecureVariableTrackedVersions = 6
+
 	// maxVariableSize is the maximum size of the unencrypted contents of
 	// a variable. This size is deliberately set low and is not
 	// configurable, to discourage DoS'ing the cluster
 	maxVariableSize = 16384
+
+	// minSecureVariableLockTTL and maxSecureVariableLockTTL are the bounds
+	// on the TTL of a secure variable lock. The lower bound keeps the renew
+	// load on the leader reasonable.
+	minSecureVariableLockTTL = 10 * time.Second
+	maxSecureVariableLockTTL = 24 * time.Hour
+
+	// DefaultSecureVariableLockTTL is the TTL used for a secure variable lock
+	// when the caller does not provide one.
+	DefaultSecureVariableLockTTL = 15 * time.Second
 )
 
 // SecureVariableMetadata is the metadata envelope for a Secure Variable, it
@@ -55,6 +85,55 @@ type SecureVariableMetadata struct {
 	CreateTime  int64
 	ModifyIndex uint64
 	ModifyTime  int64
+
+	// Version is incremented each time the contents of the secure variable
+	// are modified. Prior versions are retained so that they can be
+	// restored.
+	Version uint64
+
+	// Lock is set when the secure variable is locked. While locked, the
+	// variable can only be modified or deleted by the lock holder.
+	Lock *SecureVariableLock `json:",omitempty"`
+}
+
+// SecureVariableLock is a TTL based lock held on a secure variable, which
+// allows it to be used for leader election between allocations. The lock
+// must be renewed by its holder before the TTL expires, otherwise the leader
+// releases it.
+type SecureVariableLock struct {
+	// ID is generated when the lock is acquired and must be provided by the
+	// holder to renew or release the lock, or to modify the variable. It is
+	// only returned to the lock holder.
+	ID string
+
+	// TTL is the duration the lock is held for without being renewed.
+	TTL time.Duration
+}
+
+// Copy returns a copy of the lock.
+func (l *SecureVariableLock) Copy() *SecureVariableLock {
+	if l == nil {
+		return nil
+	}
+	out := *l
+	return &out
+}
+
+// Equals returns whether the two locks are equal.
+func (l *SecureVariableLock) Equals(o *SecureVariableLock) bool {
+	if l == nil || o == nil {
+		return l == o
+	}
+	return *l == *o
+}
+
+// Validate checks the lock parameters supplied when acquiring a lock.
+func (l *SecureVariableLock) Validate() error {
+	if l.TTL < minSecureVariableLockTTL || l.TTL > maxSecureVariableLockTTL {
+		return fmt.Errorf("lock TTL must be between %s and %s",
+			minSecureVariableLockTTL, maxSecureVariableLockTTL)
+	}
+	return nil
 }
 
 // SecureVariableEncrypted structs are returned from the Encrypter's encrypt
@@ -102,7 +181,33 @@ func (v1 SecureVariableDecrypted) Equals(v2 SecureVariableDecrypted) bool {
 // syntax for metadata and the SecureVariablesData or SecureVariableItems
 // struct
 func (sv SecureVariableMetadata) Equals(sv2 SecureVariableMetadata) bool {
-	return sv == sv2
+	return sv.Namespace == sv2.Namespace &&
+		sv.Path == sv2.Path &&
+		sv.CreateIndex == sv2.CreateIndex &&
+		sv.CreateTime == sv2.CreateTime &&
+		sv.ModifyIndex == sv2.ModifyIndex &&
+		sv.ModifyTime == sv2.ModifyTime &&
+		sv.Version == sv2.Version &&
+		sv.Lock.Equals(sv2.Lock)
+}
+
+// IsLocked returns whether the secure variable is currently locked.
+func (sv SecureVariableMetadata) IsLocked() bool {
+	return sv.Lock != nil
+}
+
+// IsLockHolder returns whether the passed lock identifies the holder of the
+// lock on the secure variable.
+func (sv SecureVariableMetadata) IsLockHolder(lock *SecureVariableLock) bool {
+	return sv.Lock != nil && lock != nil && sv.Lock.ID == lock.ID
+}
+
+// RedactLock removes the lock ID from the metadata, so that it can be
+// returned to callers which do not hold the lock.
+func (sv *SecureVariableMetadata) RedactLock() {
+	if sv.Lock != nil {
+		sv.Lock = &SecureVariableLock{TTL: sv.Lock.TTL}
+	}
 }
 
 // Equals performs deep equality checking on the cleartext items
@@ -127,7 +232,7 @@ func (d1 SecureVariableData) Equals(d2 SecureVariableData) bool {
 
 func (sv SecureVariableDecrypted) Copy() SecureVariableDecrypted {
 	return SecureVariableDecrypted{
-		SecureVariableMetadata: sv.SecureVariableMetadata,
+		SecureVariableMetadata: *sv.SecureVariableMetadata.Copy(),
 		Items:                  sv.Items.Copy(),
 	}
 }
@@ -142,7 +247,7 @@ func (sv SecureVariableItems) Copy() SecureVariableItems {
 
 func (sv SecureVariableEncrypted) Copy() SecureVariableEncrypted {
 	return SecureVariableEncrypted{
-		SecureVariableMetadata: sv.SecureVariableMetadata,
+		SecureVariableMetadata: *sv.SecureVariableMetadata.Copy(),
 		SecureVariableData:     sv.SecureVariableData.Copy(),
 	}
 }
@@ -169,7 +274,9 @@ func (sv SecureVariableDecrypted) Validate() error {
 		return fmt.Errorf("only paths at \"nomad/jobs\" or below are valid paths under the top-level \"nomad\" directory")
 	}
 
-	if len(sv.Items) == 0 {
+	// Locks may be acquired on variables which do not hold any items, so
+	// that they can be used purely for leader election.
+	if len(sv.Items) == 0 && sv.Lock == nil {
 		return errors.New("empty variables are invalid")
 	}
 	if sv.Items.Size() > maxVariableSize {
@@ -190,6 +297,7 @@ func (sv *SecureVariableDecrypted) Canonicalize() {
 // GetNamespace returns the secure variable's namespace. Used for pagination.
 func (sv *SecureVariableMetadata) Copy() *SecureVariableMetadata {
 	var out SecureVariableMetadata = *sv
+	out.Lock = sv.Lock.Copy()
 	return &out
 }
 
@@ -240,8 +348,26 @@ const (
 	SVOpDelete    SVOp = "delete"
 	SVOpDeleteCAS SVOp = "delete-cas"
 	SVOpCAS       SVOp = "cas"
+
+	// SVOpLockAcquire acquires the lock on a secure variable, creating the
+	// variable if it does not exist. SVOpLockRenew extends the TTL of a held
+	// lock and SVOpLockRelease releases it; both require the lock ID.
+	SVOpLockAcquire SVOp = "lock-acquire"
+	SVOpLockRenew   SVOp = "lock-renew"
+	SVOpLockRelease SVOp = "lock-release"
 )
 
+// IsLockOp returns whether the operation acts on the lock of a secure
+// variable.
+func (op SVOp) IsLockOp() bool {
+	switch op {
+	case SVOpLockAcquire, SVOpLockRenew, SVOpLockRelease:
+		return true
+	default:
+		return false
+	}
+}
+
 // SVOpResult constants give possible operations results from a transaction.
 type SVOpResult string
 
@@ -286,6 +412,30 @@ func (r *SecureVariablesApplyResponse) IsRedacted() bool {
 	return r.Result == SVOpResultRedacted
 }
 
+// SecureVariablesRekeyRequest is used by the leader to re-encrypt tracked
+// versions of secure variables which were encrypted with KeyID, using the
+// active root key.
+type SecureVariablesRekeyRequest struct {
+	KeyID    string                    // Key the versions were encrypted with
+	Versions []*SecureVariableMetadata // Namespace, path and version of each
+	WriteRequest
+}
+
+// SecureVariablesRekeyResponse is sent back to the leader once the versions
+// have been re-encrypted.
+type SecureVariablesRekeyResponse struct {
+	WriteMeta
+}
+
+// SVRekeyStateRequest is used by the FSM to replace the encrypted data of
+// tracked versions of secure variables. As re-encrypting doesn't change the
+// contents of a variable, the version and indexes are left unchanged.
+type SVRekeyStateRequest struct {
+	KeyID    string                     // Key the versions were encrypted with
+	Versions []*SecureVariableEncrypted // Re-encrypted versions
+	WriteRequest
+}
+
 // SVApplyStateRequest is used by the FSM to modify the secure variable store
 type SVApplyStateRequest struct {
 	Op  SVOp                     // Which operation are we performing
@@ -361,6 +511,10 @@ type SecureVariablesListResponse struct {
 
 type SecureVariablesReadRequest struct {
 	Path string
+
+	// Version is used to read a prior version of the secure variable. If
+	// nil, the current version is returned.
+	Version *uint64
 	QueryOptions
 }
 
@@ -369,6 +523,20 @@ type SecureVariablesReadResponse struct {
 	QueryMeta
 }
 
+// SecureVariablesHistoryRequest is used to list the tracked versions of a
+// secure variable.
+type SecureVariablesHistoryRequest struct {
+	Path string
+	QueryOptions
+}
+
+// SecureVariablesHistoryResponse contains the metadata of the tracked
+// versions of a secure variable, ordered from the newest version.
+type SecureVariablesHistoryResponse struct {
+	Versions []*SecureVariableMetadata
+	QueryMeta
+}
+
 // ---------------------------------------
 // Keyring state and RPC objects
 
@@ -598,3 +766,60 @@ type KeyringDeleteRootKeyRequest struct {
 type KeyringDeleteRootKeyResponse struct {
 	WriteMeta
 }
+
+const (
+	// PubKeyAlgEdDSA is the JWA algorithm of the keys used to sign
+	// workload identities
+	PubKeyAlgEdDSA = "EdDSA"
+
+	// PubKeyUseSig is the JWK "use" of the keys used to sign workload
+	// identities
+	PubKeyUseSig = "sig"
+)
+
+// KeyringPublicKey is the public half of a root key, which third parties
+// can use to verify workload identities signed by that key.
+type KeyringPublicKey struct {
+	KeyID      string
+	PublicKey  []byte
+	Algorithm  string
+	Use        string
+	CreateTime int64
+}
+
+// KeyringListPublicResponse is the response to the Keyring.ListPublic RPC.
+// The request is a GenericRequest because it requires no authentication.
+type KeyringListPublicResponse struct {
+	PublicKeys []*KeyringPublicKey
+	QueryMeta
+}
+
+// KeyringGetOIDCDiscoveryResponse is the response to the
+// Keyring.GetOIDCDiscovery RPC.
+type KeyringGetOIDCDiscoveryResponse struct {
+	OIDCDiscovery *OIDCDiscoveryConfig
+	QueryMeta
+}
+
+// OIDCDiscoveryConfig is the OpenID Connect discovery document describing
+// how to verify workload identities.
+type OIDCDiscoveryConfig struct {
+	Issuer        string   `json:"issuer"`
+	JWKS          string   `json:"jwks_uri"`
+	IDTokenAlgs   []string `json:"id_token_signing_alg_values_supported"`
+	ResponseTypes []string `json:"response_types_supported"`
+	Subjects      []string `json:"subject_types_supported"`
+}
+
+// NewOIDCDiscoveryConfig returns the OIDC discovery document for the issuer,
+// which must be the URL at which the document is served without the
+// "/.well-known/openid-configuration" suffix.
+func NewOIDCDiscoveryConfig(issuer string) *OIDCDiscoveryConfig {
+	return &OIDCDiscoveryConfig{
+		Issuer:        issuer,
+		JWKS:          strings.TrimSuffix(issuer, "/") + "/.well-known/jwks.json",
+		IDTokenAlgs:   []string{PubKeyAlgEdDSA},
+		ResponseTypes: []string{"code"},
+		Subjects:      []string{"public"},
+	}
+}
@@ -113,6 +113,15 @@ const (
 	SVApplyStateRequestType                      MessageType = 50
 	RootKeyMetaUpsertRequestType                 MessageType = 51
 	RootKeyMetaDeleteRequestType                 MessageType = 52
+	ACLRolesUpsertRequestType                    MessageType = 53
+	ACLRolesDeleteByIDRequestType                MessageType = 54
+	ACLAuthMethodsUpsertRequestType              MessageType = 55
+	ACLAuthMethodsDeleteRequestType              MessageType = 56
+	ACLBindingRulesUpsertRequestType             MessageType = 57
+	ACLBindingRulesDeleteRequestType             MessageType = 58
+	NodePoolUpsertRequestType                    MessageType = 59
+	NodePoolDeleteRequestType                    MessageType = 60
+	SVRekeyRequestType                           MessageType = 61
 
 	// Namespace types were moved from enterprise and therefore start at 64
 	NamespaceUpsertRequestType MessageType = 64
@@ -675,6 +684,18 @@ type EvalOptions struct {
 	ForceReschedule bool
 }
 
+// JobRebalanceRequest is used to create a rebalance evaluation for a job
+type JobRebalanceRequest struct {
+	JobID string
+	WriteRequest
+}
+
+// SystemRebalanceRequest is used to create rebalance evaluations for all of
+// the service jobs in the cluster
+type SystemRebalanceRequest struct {
+	WriteRequest
+}
+
 // JobSpecificRequest is used when we just need to specify a target job
 type JobSpecificRequest struct {
 	JobID string
@@ -1301,6 +1322,12 @@ type JobRegisterResponse struct {
 	QueryMeta
 }
 
+// SystemRebalanceResponse is used to respond to a system rebalance request
+type SystemRebalanceResponse struct {
+	EvalIDs []string
+	WriteMeta
+}
+
 // JobDeregisterResponse is used to respond to a job deregistration
 type JobDeregisterResponse struct {
 	EvalID          string
@@ -1890,6 +1917,9 @@ type Node struct {
 	// Datacenter for this node
 	Datacenter string
 
+	// NodePool is the node pool the node belongs to.
+	NodePool string
+
 	// Node name
 	Name string
 
@@ -2031,6 +2061,11 @@ func (n *Node) Canonicalize() {
 		n.SchedulingEligibility = NodeSchedulingEligible
 	}
 
+	// Ensure the node is in a node pool.
+	if n.NodePool == "" {
+		n.NodePool = NodePoolDefault
+	}
+
 	// COMPAT remove in 1.0
 	// In v0.12.0 we introduced a separate node specific network resource struct
 	// so we need to covert any pre 0.12 clients to the correct struct
@@ -2123,6 +2158,24 @@ func (n *Node) ComparableReservedResources() *ComparableResources {
 	}
 }
 
+// ComparableAllocatableResources returns the resources of the node that can
+// be allocated. These are the resources of the node minus its reserved
+// resources, with the CPU shares and memory scaled by the oversubscription
+// ratios of the node.
+func (n *Node) ComparableAllocatableResources() *ComparableResources {
+	available := n.ComparableResources()
+	available.Subtract(n.ComparableReservedResources())
+
+	cpuRatio, memoryRatio := n.NodeResources.OversubscriptionRatios()
+	if cpuRatio != 1 {
+		available.Flattened.Cpu.CpuShares = int64(float64(available.Flattened.Cpu.CpuShares) * cpuRatio)
+	}
+	if memoryRatio != 1 {
+		available.Flattened.Memory.MemoryMB = int64(float64(available.Flattened.Memory.MemoryMB) * memoryRatio)
+	}
+	return available
+}
+
 // ComparableResources returns the resouces on the node
 // handling upgrade paths. Networking must be handled separately. After 0.11
 // calls to this should be replaced with: node.NodeResources.Comparable()
@@ -2159,6 +2212,7 @@ func (n *Node) Stub(fields *NodeStubFields) *NodeListStub {
 		Address:               addr,
 		ID:                    n.ID,
 		Datacenter:            n.Datacenter,
+		NodePool:              n.NodePool,
 		Name:                  n.Name,
 		NodeClass:             n.NodeClass,
 		Version:               n.Attributes["nomad.version"],
@@ -2197,6 +2251,7 @@ type NodeListStub struct {
 	ID                    string
 	Attributes            map[string]string `json:",omitempty"`
 	Datacenter            string
+	NodePool              string
 	Name                  string
 	NodeClass             string
 	Version               string
@@ -2230,6 +2285,7 @@ type Resources struct {
 	IOPS        int // COMPAT(0.10): Only being used to issue warnings
 	Networks    Networks
 	Devices     ResourceDevices
+	NUMA        *NUMA
 }
 
 const (
@@ -2292,6 +2348,12 @@ func (r *Resources) Validate() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("MemoryMaxMB value (%d) should be larger than MemoryMB value (%d)", r.MemoryMaxMB, r.MemoryMB))
 	}
 
+	if err := r.NUMA.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	} else if r.NUMA.Requested() && r.Cores == 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Task can only ask for NUMA affinity along with the 'cores' resource."))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -2319,6 +2381,9 @@ func (r *Resources) Merge(other *Resources) {
 	if len(other.Devices) != 0 {
 		r.Devices = other.Devices
 	}
+	if other.NUMA != nil {
+		r.NUMA = other.NUMA
+	}
 }
 
 // Equals Resources.
@@ -2338,7 +2403,8 @@ func (r *Resources) Equals(o *Resources) bool {
 		r.DiskMB == o.DiskMB &&
 		r.IOPS == o.IOPS &&
 		r.Networks.Equals(&o.Networks) &&
-		r.Devices.Equals(&o.Devices)
+		r.Devices.Equals(&o.Devices) &&
+		r.NUMA.Equals(o.NUMA)
 }
 
 // ResourceDevices are part of Resources.
@@ -2426,6 +2492,8 @@ func (r *Resources) Copy() *Resources {
 		}
 	}
 
+	newR.NUMA = r.NUMA.Copy()
+
 	return newR
 }
 
@@ -2865,6 +2933,17 @@ type NodeResources struct {
 	// to select dynamic ports from across all networks.
 	MinDynamicPort int
 	MaxDynamicPort int
+
+	// NUMA is the NUMA topology of the node. It is nil if the topology
+	// could not be fingerprinted.
+	NUMA *NodeNUMAResources
+
+	// CpuOversubscriptionRatio and MemoryOversubscriptionRatio are the
+	// factors by which the CPU shares and memory of the node, after
+	// subtracting its reserved resources, may be allocated. Zero means the
+	// resources can't be oversubscribed.
+	CpuOversubscriptionRatio    float64
+	MemoryOversubscriptionRatio float64
 }
 
 func (n *NodeResources) Copy() *NodeResources {
@@ -2876,6 +2955,7 @@ func (n *NodeResources) Copy() *NodeResources {
 	*newN = *n
 	newN.Cpu = n.Cpu.Copy()
 	newN.Networks = n.Networks.Copy()
+	newN.NUMA = n.NUMA.Copy()
 
 	if n.NodeNetworks != nil {
 		newN.NodeNetworks = make([]*NodeNetworkResource, len(n.NodeNetworks))
@@ -2938,6 +3018,18 @@ func (n *NodeResources) Merge(o *NodeResources) {
 		n.Devices = o.Devices
 	}
 
+	if o.NUMA != nil {
+		n.NUMA = o.NUMA
+	}
+
+	if o.CpuOversubscriptionRatio != 0 {
+		n.CpuOversubscriptionRatio = o.CpuOversubscriptionRatio
+	}
+
+	if o.MemoryOversubscriptionRatio != 0 {
+		n.MemoryOversubscriptionRatio = o.MemoryOversubscriptionRatio
+	}
+
 	if len(o.NodeNetworks) != 0 {
 		for _, nw := range o.NodeNetworks {
 			if i, nnw := lookupNetworkByDevice(n.NodeNetworks, nw.Device); nnw != nil {
@@ -2989,9 +3081,34 @@ func (n *NodeResources) Equals(o *NodeResources) bool {
 		return false
 	}
 
+	if !n.NUMA.Equals(o.NUMA) {
+		return false
+	}
+
+	if n.CpuOversubscriptionRatio != o.CpuOversubscriptionRatio ||
+		n.MemoryOversubscriptionRatio != o.MemoryOversubscriptionRatio {
+		return false
+	}
+
 	return true
 }
 
+// OversubscriptionRatios returns the factors by which the CPU shares and
+// memory of the node may be allocated, defaulting to 1.
+func (n *NodeResources) OversubscriptionRatios() (cpu, memory float64) {
+	cpu, memory = 1, 1
+	if n == nil {
+		return
+	}
+	if n.CpuOversubscriptionRatio > 1 {
+		cpu = n.CpuOversubscriptionRatio
+	}
+	if n.MemoryOversubscriptionRatio > 1 {
+		memory = n.MemoryOversubscriptionRatio
+	}
+	return
+}
+
 // Equals equates Networks as a set
 func (ns *Networks) Equals(o *Networks) bool {
 	if ns == o {
@@ -3825,6 +3942,11 @@ func (a *AllocatedCpuResources) Max(other *AllocatedCpuResources) {
 type AllocatedMemoryResources struct {
 	MemoryMB    int64
 	MemoryMaxMB int64
+
+	// NUMANodes are the IDs of the NUMA nodes the memory of the task is
+	// bound to. It is empty if the memory may be allocated from any NUMA
+	// node.
+	NUMANodes []uint16
 }
 
 func (a *AllocatedMemoryResources) Add(delta *AllocatedMemoryResources) {
@@ -4074,6 +4196,10 @@ type Job struct {
 	// Datacenters contains all the datacenters this job is allowed to span
 	Datacenters []string
 
+	// NodePool specifies the node pool this job is allowed to run on. Only
+	// nodes within the pool are considered for placement.
+	NodePool string
+
 	// Constraints can be specified at a job level and apply to
 	// all the task groups and tasks.
 	Constraints []*Constraint
@@ -4096,6 +4222,14 @@ type Job struct {
 
 	Multiregion *Multiregion
 
+	// Preemption bounds the preemption performed to place the task groups
+	// of the job that don't set their own preemption policy.
+	Preemption *PreemptionPolicy
+
+	// Window restricts placements and updates of the task groups of the job
+	// that don't set their own scheduling window.
+	Window *SchedulingWindow
+
 	// Periodic is used to define the interval the job is run at.
 	Periodic *PeriodicConfig
 
@@ -4220,6 +4354,11 @@ func (j *Job) Canonicalize() {
 		j.Namespace = DefaultNamespace
 	}
 
+	// Ensure the job is in a node pool.
+	if j.NodePool == "" {
+		j.NodePool = NodePoolDefault
+	}
+
 	for _, tg := range j.TaskGroups {
 		tg.Canonicalize(j)
 	}
@@ -4249,6 +4388,8 @@ func (j *Job) Copy() *Job {
 	nj.Constraints = CopySliceConstraints(nj.Constraints)
 	nj.Affinities = CopySliceAffinities(nj.Affinities)
 	nj.Multiregion = nj.Multiregion.Copy()
+	nj.Preemption = nj.Preemption.Copy()
+	nj.Window = nj.Window.Copy()
 
 	if j.TaskGroups != nil {
 		tgs := make([]*TaskGroup, len(nj.TaskGroups))
@@ -4286,6 +4427,9 @@ func (j *Job) Validate() error {
 	if j.Namespace == "" {
 		mErr.Errors = append(mErr.Errors, errors.New("Job must be in a namespace"))
 	}
+	if j.NodePool != "" && !IsValidNodePoolName(j.NodePool) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Invalid node pool %q", j.NodePool))
+	}
 	switch j.Type {
 	case JobTypeCore, JobTypeService, JobTypeBatch, JobTypeSystem, JobTypeSysBatch:
 	case "":
@@ -4340,6 +4484,21 @@ func (j *Job) Validate() error {
 		}
 	}
 
+	if err := j.Preemption.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Preemption validation failed: %v", err))
+	}
+
+	if j.Window != nil {
+		switch j.Type {
+		case JobTypeService, JobTypeBatch:
+			if err := j.Window.Validate(); err != nil {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Window validation failed: %v", err))
+			}
+		default:
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Job type %q does not allow window block", j.Type))
+		}
+	}
+
 	// Check for duplicate task groups
 	taskGroups := make(map[string]int)
 	for idx, tg := range j.TaskGroups {
@@ -4515,6 +4674,7 @@ func (j *Job) Stub(summary *JobSummary) *JobListStub {
 		ParentID:          j.ParentID,
 		Name:              j.Name,
 		Datacenters:       j.Datacenters,
+		NodePool:          j.NodePool,
 		Multiregion:       j.Multiregion,
 		Type:              j.Type,
 		Priority:          j.Priority,
@@ -4699,6 +4859,7 @@ type JobListStub struct {
 	Name              string
 	Namespace         string `json:",omitempty"`
 	Datacenters       []string
+	NodePool          string
 	Multiregion       *Multiregion
 	Type              string
 	Priority          int
@@ -6032,6 +6193,11 @@ type MigrateStrategy struct {
 	HealthCheck     string
 	MinHealthyTime  time.Duration
 	HealthyDeadline time.Duration
+
+	// Checkpoint enables checkpointing the tasks of drained allocations, so
+	// that the replacement allocations restore them instead of starting
+	// them from scratch. Only drivers supporting checkpoints honor it.
+	Checkpoint bool
 }
 
 // DefaultMigrateStrategy is used for backwards compat with pre-0.8 Allocations
@@ -6153,6 +6319,18 @@ type TaskGroup struct {
 	// MaxClientDisconnect, if set, configures the client to allow placed
 	// allocations for tasks in this group to attempt to resume running without a restart.
 	MaxClientDisconnect *time.Duration
+
+	// Gang, if set, requires all of the allocations of the task group (or
+	// job) to be placed in the same evaluation, or none of them.
+	Gang *GangStrategy
+
+	// Preemption bounds the preemption performed to place the allocations
+	// of the task group. It overrides the preemption policy of the job.
+	Preemption *PreemptionPolicy
+
+	// Window restricts placements and updates of the allocations of the task
+	// group to a recurring time window. It overrides the window of the job.
+	Window *SchedulingWindow
 }
 
 func (tg *TaskGroup) Copy() *TaskGroup {
@@ -6170,6 +6348,9 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 	ntg.Volumes = CopyMapVolumeRequest(ntg.Volumes)
 	ntg.Scaling = ntg.Scaling.Copy()
 	ntg.Consul = ntg.Consul.Copy()
+	ntg.Gang = ntg.Gang.Copy()
+	ntg.Preemption = ntg.Preemption.Copy()
+	ntg.Window = ntg.Window.Copy()
 
 	// Copy the network objects
 	if tg.Networks != nil {
@@ -6247,6 +6428,8 @@ func (tg *TaskGroup) Canonicalize(job *Job) {
 		tg.Scaling.Canonicalize()
 	}
 
+	tg.Gang.Canonicalize()
+
 	for _, service := range tg.Services {
 		service.Canonicalize(job.Name, tg.Name, "group", job.Namespace)
 	}
@@ -6384,6 +6567,11 @@ func (tg *TaskGroup) Validate(j *Job) error {
 			if err := tg.Migrate.Validate(); err != nil {
 				mErr.Errors = append(mErr.Errors, err)
 			}
+			// checkpoints are shipped to the replacement alloc with the
+			// ephemeral disk
+			if tg.Migrate.Checkpoint && (tg.EphemeralDisk == nil || !tg.EphemeralDisk.Sticky || !tg.EphemeralDisk.Migrate) {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Migrate checkpoint requires a sticky ephemeral disk with migrate enabled"))
+			}
 		}
 	default:
 		if tg.Migrate != nil {
@@ -6391,6 +6579,34 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	// Validate the gang strategy
+	if tg.Gang != nil {
+		switch j.Type {
+		case JobTypeService, JobTypeBatch:
+			if err := tg.Gang.Validate(); err != nil {
+				mErr.Errors = append(mErr.Errors, err)
+			}
+		default:
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Job type %q does not allow gang block", j.Type))
+		}
+	}
+
+	if err := tg.Preemption.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Preemption validation failed: %v", err))
+	}
+
+	// Validate the scheduling window
+	if tg.Window != nil {
+		switch j.Type {
+		case JobTypeService, JobTypeBatch:
+			if err := tg.Window.Validate(); err != nil {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Window validation failed: %v", err))
+			}
+		default:
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Job type %q does not allow window block", j.Type))
+		}
+	}
+
 	// Check that there is only one leader task if any
 	tasks := make(map[string]int)
 	leaderTasks := 0
@@ -8891,6 +9107,12 @@ type Spread struct {
 	// SpreadTarget is used to describe desired percentages for each attribute value
 	SpreadTarget []*SpreadTarget
 
+	// MaxSkew, when positive, makes the spread a hard constraint. Nodes are
+	// infeasible if placing on them would make the number of allocations
+	// with their attribute value exceed the least used value by more than
+	// MaxSkew.
+	MaxSkew int
+
 	// Memoized string representation
 	str string
 }
@@ -8936,6 +9158,9 @@ func (s *Spread) String() string {
 		return s.str
 	}
 	s.str = fmt.Sprintf("%s %s %v", s.Attribute, s.SpreadTarget, s.Weight)
+	if s.MaxSkew > 0 {
+		s.str += fmt.Sprintf(" max_skew=%d", s.MaxSkew)
+	}
 	return s.str
 }
 
@@ -8947,6 +9172,11 @@ func (s *Spread) Validate() error {
 	if s.Weight <= 0 || s.Weight > 100 {
 		mErr.Errors = append(mErr.Errors, errors.New("Spread stanza must have a positive weight from 0 to 100"))
 	}
+	if s.MaxSkew < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Spread max_skew must not be negative; got %d", s.MaxSkew))
+	} else if s.MaxSkew > 0 && len(s.SpreadTarget) != 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Spread stanza with max_skew must not define targets"))
+	}
 	seen := make(map[string]struct{})
 	sumPercent := uint32(0)
 
@@ -9148,6 +9378,7 @@ const (
 	DeploymentStatusDescriptionRunning               = "Deployment is running"
 	DeploymentStatusDescriptionRunningNeedsPromotion = "Deployment is running but requires manual promotion"
 	DeploymentStatusDescriptionRunningAutoPromotion  = "Deployment is running pending automatic promotion"
+	DeploymentStatusDescriptionWaitingForWindow      = "Deployment is running but waiting for scheduling window"
 	DeploymentStatusDescriptionPaused                = "Deployment is paused"
 	DeploymentStatusDescriptionSuccessful            = "Deployment completed successfully"
 	DeploymentStatusDescriptionStoppedJob            = "Cancelled because job is stopped"
@@ -10377,6 +10608,7 @@ func (a *Allocation) ToIdentityClaims(job *Job) *IdentityClaims {
 		JobID:        a.JobID,
 		AllocationID: a.ID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: strings.Join([]string{a.Namespace, a.JobID, a.TaskGroup}, ":"),
 			// TODO: in Nomad 1.5.0 we'll have a refresh loop to
 			// prevent allocation identities from expiring before the
 			// allocation is terminal. Once that's implemented, add an
@@ -10387,6 +10619,7 @@ func (a *Allocation) ToIdentityClaims(job *Job) *IdentityClaims {
 	}
 	if job != nil && job.ParentID != "" {
 		claims.JobID = job.ParentID
+		claims.Subject = strings.Join([]string{a.Namespace, job.ParentID, a.TaskGroup}, ":")
 	}
 	return claims
 }
@@ -10395,12 +10628,18 @@ func (a *Allocation) ToTaskIdentityClaims(job *Job, taskName string) *IdentityCl
 	claims := a.ToIdentityClaims(job)
 	if claims != nil {
 		claims.TaskName = taskName
+		claims.Subject += ":" + taskName
 	}
 	return claims
 }
 
-// IdentityClaims are the input to a JWT identifying a workload. It
-// should never be serialized to msgpack unsigned.
+// DefaultWorkloadIdentityAudience is the audience (aud claim) of workload
+// identities when the server has no oidc_audience configured.
+const DefaultWorkloadIdentityAudience = "nomadproject.io"
+
+// IdentityClaims are the input to a JWT identifying a workload. Its subject
+// (sub claim) is "<namespace>:<job>:<group>:<task>". It should never be
+// serialized to msgpack unsigned.
 type IdentityClaims struct {
 	Namespace    string `json:"nomad_namespace"`
 	JobID        string `json:"nomad_job_id"`
@@ -10539,6 +10778,10 @@ type AllocMetric struct {
 	// This is to prevent creating many failed allocations for a
 	// single task group.
 	CoalescedFailures int
+
+	// GangFailure explains why the placements of a task group that is part
+	// of a gang were withheld.
+	GangFailure string
 }
 
 func (a *AllocMetric) Copy() *AllocMetric {
@@ -10835,6 +11078,8 @@ const (
 	EvalTriggerScaling              = "job-scaling"
 	EvalTriggerMaxDisconnectTimeout = "max-disconnect-timeout"
 	EvalTriggerReconnect            = "reconnect"
+	EvalTriggerRebalance            = "rebalance"
+	EvalTriggerSchedulingWindow     = "scheduling-window"
 )
 
 const (
@@ -10874,9 +11119,20 @@ const (
 	// tokens. We periodically scan for expired tokens and delete them.
 	CoreJobOneTimeTokenGC = "one-time-token-gc"
 
-	// CoreJobRootKeyRotateGC is used for periodic key rotation and
-	// garbage collection of unused encryption keys.
-	CoreJobRootKeyRotateOrGC = "root-key-rotate-gc"
+	// CoreJobLocalTokenExpiredGC is used for the garbage collection of
+	// expired local ACL tokens. We periodically scan for expired tokens and
+	// delete them.
+	CoreJobLocalTokenExpiredGC = "local-token-expired-gc"
+
+	// CoreJobGlobalTokenExpiredGC is used for the garbage collection of
+	// expired global ACL tokens. We periodically scan for expired tokens and
+	// delete them.
+	CoreJobGlobalTokenExpiredGC = "global-token-expired-gc"
+
+	// CoreJobRootKeyGC is used for garbage collection of unused encryption
+	// keys. Keys were previously also rotated by this job, so the value is
+	// kept for compatibility with servers on older versions.
+	CoreJobRootKeyGC = "root-key-rotate-gc"
 
 	// CoreJobSecureVariablesRekey is used to fully rotate the
 	// encryption keys for secure variables by decrypting all secure
@@ -11218,6 +11474,26 @@ func (e *Evaluation) NextRollingEval(wait time.Duration) *Evaluation {
 	}
 }
 
+// NextWindowEval creates an evaluation to followup this eval once the
+// scheduling window of the job opens at the given time.
+func (e *Evaluation) NextWindowEval(waitUntil time.Time) *Evaluation {
+	now := time.Now().UTC().UnixNano()
+	return &Evaluation{
+		ID:             uuid.Generate(),
+		Namespace:      e.Namespace,
+		Priority:       e.Priority,
+		Type:           e.Type,
+		TriggeredBy:    EvalTriggerSchedulingWindow,
+		JobID:          e.JobID,
+		JobModifyIndex: e.JobModifyIndex,
+		Status:         EvalStatusPending,
+		WaitUntil:      waitUntil,
+		PreviousEval:   e.ID,
+		CreateTime:     now,
+		ModifyTime:     now,
+	}
+}
+
 // CreateBlockedEval creates a blocked evaluation to followup this eval to place any
 // failed allocations. It takes the classes marked explicitly eligible or
 // ineligible, whether the job has escaped computed node classes and whether the
@@ -11491,6 +11767,58 @@ func (p *Plan) PopUpdate(alloc *Allocation) {
 	}
 }
 
+// RemoveUpdate removes the update of an allocation from the plan, wherever
+// it is in the list of updates of its node.
+func (p *Plan) RemoveUpdate(alloc *Allocation) {
+	existing := p.NodeUpdate[alloc.NodeID]
+	for i, update := range existing {
+		if update.ID != alloc.ID {
+			continue
+		}
+		existing = append(existing[:i:i], existing[i+1:]...)
+		if len(existing) > 0 {
+			p.NodeUpdate[alloc.NodeID] = existing
+		} else {
+			delete(p.NodeUpdate, alloc.NodeID)
+		}
+		return
+	}
+}
+
+// RemoveAlloc removes a placement from the plan, along with the allocations
+// it preempted.
+func (p *Plan) RemoveAlloc(alloc *Allocation) {
+	existing := p.NodeAllocation[alloc.NodeID]
+	for i, placed := range existing {
+		if placed.ID != alloc.ID {
+			continue
+		}
+		existing = append(existing[:i:i], existing[i+1:]...)
+		if len(existing) > 0 {
+			p.NodeAllocation[alloc.NodeID] = existing
+		} else {
+			delete(p.NodeAllocation, alloc.NodeID)
+		}
+		break
+	}
+
+	preemptions := p.NodePreemptions[alloc.NodeID]
+	if len(preemptions) == 0 {
+		return
+	}
+	kept := make([]*Allocation, 0, len(preemptions))
+	for _, preempted := range preemptions {
+		if preempted.PreemptedByAllocation != alloc.ID {
+			kept = append(kept, preempted)
+		}
+	}
+	if len(kept) > 0 {
+		p.NodePreemptions[alloc.NodeID] = kept
+	} else {
+		delete(p.NodePreemptions, alloc.NodeID)
+	}
+}
+
 // AppendAlloc appends the alloc to the plan allocations.
 // Uses the passed job if explicitly passed, otherwise
 // it is assumed the alloc will use the plan Job version.
@@ -11604,6 +11932,43 @@ type PlanAnnotations struct {
 
 	// PreemptedAllocs is the set of allocations to be preempted to make the placement successful.
 	PreemptedAllocs []*AllocListStub
+
+	// PreemptionReasons explains why each of the preempted allocations was
+	// chosen, keyed by allocation ID.
+	PreemptionReasons map[string]string
+}
+
+// AppendPreemptedAlloc records an allocation preempted by the plan, along
+// with the reason it was chosen if there is one.
+func (a *PlanAnnotations) AppendPreemptedAlloc(alloc *Allocation, reason string) {
+	a.PreemptedAllocs = append(a.PreemptedAllocs, alloc.Stub(nil))
+	if reason == "" {
+		return
+	}
+	if a.PreemptionReasons == nil {
+		a.PreemptionReasons = make(map[string]string)
+	}
+	a.PreemptionReasons[alloc.ID] = reason
+}
+
+// RemovePreemptedAllocs removes the allocations with the given IDs from the
+// preemptions recorded for the plan.
+func (a *PlanAnnotations) RemovePreemptedAllocs(ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+	removed := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		removed[id] = struct{}{}
+		delete(a.PreemptionReasons, id)
+	}
+	kept := a.PreemptedAllocs[:0]
+	for _, stub := range a.PreemptedAllocs {
+		if _, ok := removed[stub.ID]; !ok {
+			kept = append(kept, stub)
+		}
+	}
+	a.PreemptedAllocs = kept
 }
 
 // DesiredUpdates is the set of changes the scheduler would like to make given
@@ -11914,14 +12279,31 @@ type ACLPolicyUpsertRequest struct {
 
 // ACLToken represents a client token which is used to Authenticate
 type ACLToken struct {
-	AccessorID  string   // Public Accessor ID (UUID)
-	SecretID    string   // Secret ID, private (UUID)
-	Name        string   // Human friendly name
-	Type        string   // Client or Management
-	Policies    []string // Policies this token ties to
-	Global      bool     // Global or Region local
-	Hash        []byte
-	CreateTime  time.Time // Time of creation
+	AccessorID string   // Public Accessor ID (UUID)
+	SecretID   string   // Secret ID, private (UUID)
+	Name       string   // Human friendly name
+	Type       string   // Client or Management
+	Policies   []string // Policies this token ties to
+
+	// Roles represents the ACL roles that this token is tied to. The token
+	// will inherit the permissions of all policies detailed within the role.
+	Roles []*ACLTokenRoleLink
+
+	Global     bool // Global or Region local
+	Hash       []byte
+	CreateTime time.Time // Time of creation
+
+	// ExpirationTime represents the point after which a token should be
+	// considered revoked and is eligible for destruction. This time should
+	// always use UTC to account for multi-region global tokens. It is a
+	// pointer, so we can store nil, rather than the zero value of time.Time.
+	ExpirationTime *time.Time
+
+	// ExpirationTTL is a convenience field for helping set ExpirationTime to
+	// a value of CreateTime+ExpirationTTL. This can only be set during token
+	// creation.
+	ExpirationTTL time.Duration
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -11949,9 +12331,21 @@ func (a *ACLToken) Copy() *ACLToken {
 
 	c.Policies = make([]string, len(a.Policies))
 	copy(c.Policies, a.Policies)
+
+	if a.Roles != nil {
+		c.Roles = make([]*ACLTokenRoleLink, len(a.Roles))
+		for i, roleLink := range a.Roles {
+			c.Roles[i] = &ACLTokenRoleLink{ID: roleLink.ID, Name: roleLink.Name}
+		}
+	}
+
 	c.Hash = make([]byte, len(a.Hash))
 	copy(c.Hash, a.Hash)
 
+	if a.ExpirationTime != nil {
+		c.ExpirationTime = pointer.Of(*a.ExpirationTime)
+	}
+
 	return c
 }
 
@@ -11968,15 +12362,17 @@ var (
 )
 
 type ACLTokenListStub struct {
-	AccessorID  string
-	Name        string
-	Type        string
-	Policies    []string
-	Global      bool
-	Hash        []byte
-	CreateTime  time.Time
-	CreateIndex uint64
-	ModifyIndex uint64
+	AccessorID     string
+	Name           string
+	Type           string
+	Policies       []string
+	Roles          []*ACLTokenRoleLink
+	Global         bool
+	Hash           []byte
+	CreateTime     time.Time
+	ExpirationTime *time.Time
+	CreateIndex    uint64
+	ModifyIndex    uint64
 }
 
 // SetHash is used to compute and set the hash of the ACL token
@@ -11993,6 +12389,9 @@ func (a *ACLToken) SetHash() []byte {
 	for _, policyName := range a.Policies {
 		_, _ = hash.Write([]byte(policyName))
 	}
+	for _, roleLink := range a.Roles {
+		_, _ = hash.Write([]byte(roleLink.ID))
+	}
 	if a.Global {
 		_, _ = hash.Write([]byte("global"))
 	} else {
@@ -12009,39 +12408,131 @@ func (a *ACLToken) SetHash() []byte {
 
 func (a *ACLToken) Stub() *ACLTokenListStub {
 	return &ACLTokenListStub{
-		AccessorID:  a.AccessorID,
-		Name:        a.Name,
-		Type:        a.Type,
-		Policies:    a.Policies,
-		Global:      a.Global,
-		Hash:        a.Hash,
-		CreateTime:  a.CreateTime,
-		CreateIndex: a.CreateIndex,
-		ModifyIndex: a.ModifyIndex,
+		AccessorID:     a.AccessorID,
+		Name:           a.Name,
+		Type:           a.Type,
+		Policies:       a.Policies,
+		Roles:          a.Roles,
+		Global:         a.Global,
+		Hash:           a.Hash,
+		CreateTime:     a.CreateTime,
+		ExpirationTime: a.ExpirationTime,
+		CreateIndex:    a.CreateIndex,
+		ModifyIndex:    a.ModifyIndex,
+	}
+}
+
+// Canonicalize performs basic canonicalization on the ACL token object. New
+// tokens, identified by an empty AccessorID, have their identifiers and
+// creation time generated, along with an expiration time if a TTL was
+// supplied. Copies should be taken if needed before calling this function.
+func (a *ACLToken) Canonicalize() {
+	if a.AccessorID != "" {
+		return
+	}
+	a.AccessorID = uuid.Generate()
+	a.SecretID = uuid.Generate()
+	a.CreateTime = time.Now().UTC()
+
+	// If the user has not set the expiration time, but has provided a TTL, we
+	// calculate and populate the former field.
+	if a.ExpirationTime == nil && a.ExpirationTTL != 0 {
+		a.ExpirationTime = pointer.Of(a.CreateTime.Add(a.ExpirationTTL))
 	}
 }
 
-// Validate is used to check a token for reasonableness
-func (a *ACLToken) Validate() error {
+// Validate is used to check a token for reasonableness. The minimum and
+// maximum TTL bound any expiration set on a new token. The existing token, if
+// any, is used to ensure immutable fields are not modified by an update.
+func (a *ACLToken) Validate(minTTL, maxTTL time.Duration, existing *ACLToken) error {
 	var mErr multierror.Error
 	if len(a.Name) > maxTokenNameLength {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("token name too long"))
 	}
 	switch a.Type {
 	case ACLClientToken:
-		if len(a.Policies) == 0 {
-			mErr.Errors = append(mErr.Errors, fmt.Errorf("client token missing policies"))
+		if len(a.Policies) == 0 && len(a.Roles) == 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("client token missing policies or roles"))
 		}
 	case ACLManagementToken:
 		if len(a.Policies) != 0 {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("management token cannot be associated with policies"))
 		}
+		if len(a.Roles) != 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("management token cannot be associated with roles"))
+		}
 	default:
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("token type must be client or management"))
 	}
+
+	// There are different validation rules depending on whether the ACL
+	// token is being created or updated.
+	switch existing {
+	case nil:
+		if a.ExpirationTTL < 0 {
+			mErr.Errors = append(mErr.Errors,
+				fmt.Errorf("token expiration TTL '%s' should not be negative", a.ExpirationTTL))
+		}
+
+		if a.ExpirationTime != nil && !a.ExpirationTime.IsZero() {
+
+			if a.CreateTime.After(*a.ExpirationTime) {
+				mErr.Errors = append(mErr.Errors, errors.New("expiration time cannot be before create time"))
+			}
+
+			// Create a time duration which details the time-til-expiry, so we can
+			// check this against the regions max and min values.
+			expiresIn := a.ExpirationTime.Sub(a.CreateTime)
+			if expiresIn > maxTTL {
+				mErr.Errors = append(mErr.Errors,
+					fmt.Errorf("expiration time cannot be more than %s in the future (was %s)",
+						maxTTL, expiresIn))
+
+			} else if expiresIn < minTTL {
+				mErr.Errors = append(mErr.Errors,
+					fmt.Errorf("expiration time cannot be less than %s in the future (was %s)",
+						minTTL, expiresIn))
+			}
+		}
+	default:
+		if existing.HasExpirationTime() != a.HasExpirationTime() ||
+			(a.HasExpirationTime() && !existing.ExpirationTime.Equal(*a.ExpirationTime)) {
+			mErr.Errors = append(mErr.Errors, errors.New("cannot update expiration time"))
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
+// HasExpirationTime checks whether the ACL token has an expiration time value
+// set.
+func (a *ACLToken) HasExpirationTime() bool {
+	if a == nil || a.ExpirationTime == nil {
+		return false
+	}
+	return !a.ExpirationTime.IsZero()
+}
+
+// IsExpired compares the ACLToken.ExpirationTime against the passed t to
+// identify whether the token is considered expired. The function can be called
+// without checking whether the ACL token has an expiry time.
+func (a *ACLToken) IsExpired(t time.Time) bool {
+
+	// Check the token has an expiration time before potentially modifying the
+	// supplied time. This allows us to avoid extra work, if it isn't needed.
+	if !a.HasExpirationTime() {
+		return false
+	}
+
+	// Check and ensure the time location is set to UTC. This is vital for
+	// consistency with multi-region global tokens.
+	if t.Location() != time.UTC {
+		t = t.UTC()
+	}
+
+	return a.ExpirationTime.Before(t)
+}
+
 // PolicySubset checks if a given set of policies is a subset of the token
 func (a *ACLToken) PolicySubset(policies []string) bool {
 	// Hot-path the management tokens, superset of all policies.
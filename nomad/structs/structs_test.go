@@ -1091,6 +1091,26 @@ func TestTaskGroup_Validate(t *testing.T) {
 	err = tg.Validate(j)
 	require.Error(t, err, "does not allow update block")
 
+	migrate := DefaultMigrateStrategy()
+	migrate.Checkpoint = true
+	tg = &TaskGroup{
+		Name:  "web",
+		Count: 1,
+		Tasks: []*Task{
+			{Name: "web", Leader: true},
+		},
+		Migrate:       migrate,
+		EphemeralDisk: DefaultEphemeralDisk(),
+	}
+	j.Type = JobTypeService
+	err = tg.Validate(j)
+	require.Contains(t, err.Error(), "Migrate checkpoint requires a sticky ephemeral disk with migrate enabled")
+
+	tg.EphemeralDisk.Sticky = true
+	tg.EphemeralDisk.Migrate = true
+	err = tg.Validate(j)
+	require.NotContains(t, err.Error(), "Migrate checkpoint")
+
 	tg = &TaskGroup{
 		Count: -1,
 		RestartPolicy: &RestartPolicy{
@@ -6061,7 +6081,7 @@ func TestACLTokenValidate(t *testing.T) {
 	tk := &ACLToken{}
 
 	// Missing a type
-	err := tk.Validate()
+	err := tk.Validate(0, 0, nil)
 	assert.NotNil(t, err)
 	if !strings.Contains(err.Error(), "client or management") {
 		t.Fatalf("bad: %v", err)
@@ -6069,7 +6089,7 @@ func TestACLTokenValidate(t *testing.T) {
 
 	// Missing policies
 	tk.Type = ACLClientToken
-	err = tk.Validate()
+	err = tk.Validate(0, 0, nil)
 	assert.NotNil(t, err)
 	if !strings.Contains(err.Error(), "missing policies") {
 		t.Fatalf("bad: %v", err)
@@ -6078,19 +6098,29 @@ func TestACLTokenValidate(t *testing.T) {
 	// Invalid policies
 	tk.Type = ACLManagementToken
 	tk.Policies = []string{"foo"}
-	err = tk.Validate()
+	err = tk.Validate(0, 0, nil)
 	assert.NotNil(t, err)
 	if !strings.Contains(err.Error(), "associated with policies") {
 		t.Fatalf("bad: %v", err)
 	}
 
+	// Invalid roles
+	tk.Policies = nil
+	tk.Roles = []*ACLTokenRoleLink{{ID: "foo"}}
+	err = tk.Validate(0, 0, nil)
+	assert.NotNil(t, err)
+	if !strings.Contains(err.Error(), "associated with roles") {
+		t.Fatalf("bad: %v", err)
+	}
+	tk.Roles = nil
+
 	// Name too long policies
 	tk.Name = ""
 	for i := 0; i < 8; i++ {
 		tk.Name += uuid.Generate()
 	}
 	tk.Policies = nil
-	err = tk.Validate()
+	err = tk.Validate(0, 0, nil)
 	assert.NotNil(t, err)
 	if !strings.Contains(err.Error(), "too long") {
 		t.Fatalf("bad: %v", err)
@@ -6098,7 +6128,7 @@ func TestACLTokenValidate(t *testing.T) {
 
 	// Make it valid
 	tk.Name = "foo"
-	err = tk.Validate()
+	err = tk.Validate(0, 0, nil)
 	assert.Nil(t, err)
 }
 
@@ -6674,6 +6704,39 @@ func TestSpread_Validate(t *testing.T) {
 			err:  nil,
 			name: "Valid spread",
 		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				MaxSkew:   -1,
+			},
+			err:  fmt.Errorf("Spread max_skew must not be negative; got -1"),
+			name: "Invalid max skew",
+		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				MaxSkew:   1,
+				SpreadTarget: []*SpreadTarget{
+					{
+						Value:   "dc1",
+						Percent: 25,
+					},
+				},
+			},
+			err:  fmt.Errorf("Spread stanza with max_skew must not define targets"),
+			name: "Max skew with targets",
+		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				MaxSkew:   1,
+			},
+			err:  nil,
+			name: "Valid max skew",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,160 @@
+package structs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+)
+
+// windowTimeLayout is the layout of the start and end times of a scheduling
+// window.
+const windowTimeLayout = "15:04"
+
+// windowDays maps the accepted day names of a scheduling window to their
+// weekday.
+var windowDays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// SchedulingWindow restricts the times at which the allocations of a task
+// group may be placed or updated, such as to a maintenance window or to
+// business hours. Placements and updates outside of the window are deferred
+// until it opens.
+type SchedulingWindow struct {
+	// Start and End are the times of day the window opens and closes, in the
+	// form "15:04". A window that ends before it starts spans midnight.
+	Start string
+	End   string
+
+	// TimeZone is the name of the time zone of Start and End, such as
+	// "America/New_York". Defaults to UTC.
+	TimeZone string
+
+	// Days restricts the window to opening on the given days of the week,
+	// such as "mon". The window opens every day if empty.
+	Days []string
+}
+
+// Copy returns a copy of the scheduling window.
+func (w *SchedulingWindow) Copy() *SchedulingWindow {
+	if w == nil {
+		return nil
+	}
+	nw := *w
+	nw.Days = helper.CopySliceString(w.Days)
+	return &nw
+}
+
+// Validate returns an error if the scheduling window is invalid.
+func (w *SchedulingWindow) Validate() error {
+	if w == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	start, err := time.Parse(windowTimeLayout, w.Start)
+	if err != nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("start must be a time of day in the form HH:MM; got %q", w.Start))
+	}
+	end, err := time.Parse(windowTimeLayout, w.End)
+	if err != nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("end must be a time of day in the form HH:MM; got %q", w.End))
+	}
+	if mErr.ErrorOrNil() == nil && start.Equal(end) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("start and end must be different"))
+	}
+	if _, err := time.LoadLocation(w.TimeZone); err != nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid time_zone %q: %v", w.TimeZone, err))
+	}
+	for _, day := range w.Days {
+		if _, ok := windowDays[strings.ToLower(day)]; !ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid day %q, must be one of sun, mon, tue, wed, thu, fri or sat", day))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// bounds returns the times of day the window opens and closes, along with
+// its time zone. The window must be valid.
+func (w *SchedulingWindow) bounds() (time.Time, time.Time, *time.Location) {
+	start, _ := time.Parse(windowTimeLayout, w.Start)
+	end, _ := time.Parse(windowTimeLayout, w.End)
+	loc, err := time.LoadLocation(w.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return start, end, loc
+}
+
+// opensOn returns whether the window opens on the given day of the week.
+func (w *SchedulingWindow) opensOn(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, name := range w.Days {
+		if windowDays[strings.ToLower(name)] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the time the window is next open at or after t. If the window
+// is open at t, t is returned.
+func (w *SchedulingWindow) Next(t time.Time) time.Time {
+	start, end, loc := w.bounds()
+	year, month, day := t.In(loc).Date()
+
+	// A window that ends before it starts closes the day after it opens
+	closesNextDay := 0
+	if !end.After(start) {
+		closesNextDay = 1
+	}
+
+	// The window may have opened the day before and span midnight. The times
+	// are computed from the wall clock of each day rather than as offsets
+	// from midnight, so that they're right on days with a DST transition.
+	for i := -1; i <= 7; i++ {
+		opens := time.Date(year, month, day+i, start.Hour(), start.Minute(), 0, 0, loc)
+		closes := time.Date(year, month, day+i+closesNextDay, end.Hour(), end.Minute(), 0, 0, loc)
+		if !w.opensOn(opens.Weekday()) {
+			continue
+		}
+		if !t.Before(opens) && t.Before(closes) {
+			return t
+		}
+		if opens.After(t) {
+			return opens
+		}
+	}
+
+	// Unreachable for a valid window, since it opens at least once a week
+	return t
+}
+
+// Open returns whether the window is open at t.
+func (w *SchedulingWindow) Open(t time.Time) bool {
+	return w == nil || w.Next(t).Equal(t)
+}
+
+// LookupSchedulingWindow returns the scheduling window of the named task
+// group, falling back to the window of the job. It returns nil if neither
+// sets a window.
+func (j *Job) LookupSchedulingWindow(group string) *SchedulingWindow {
+	if j == nil {
+		return nil
+	}
+	if tg := j.LookupTaskGroup(group); tg != nil && tg.Window != nil {
+		return tg.Window
+	}
+	return j.Window
+}
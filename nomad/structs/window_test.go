@@ -0,0 +1,209 @@
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulingWindow_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name      string
+		window    *SchedulingWindow
+		expectErr string
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "valid",
+			window: &SchedulingWindow{
+				Start:    "22:00",
+				End:      "06:00",
+				TimeZone: "America/New_York",
+				Days:     []string{"mon", "Fri"},
+			},
+		},
+		{
+			name:      "invalid start",
+			window:    &SchedulingWindow{Start: "25:00", End: "06:00"},
+			expectErr: `start must be a time of day in the form HH:MM; got "25:00"`,
+		},
+		{
+			name:      "missing end",
+			window:    &SchedulingWindow{Start: "22:00"},
+			expectErr: `end must be a time of day in the form HH:MM; got ""`,
+		},
+		{
+			name:      "empty window",
+			window:    &SchedulingWindow{Start: "22:00", End: "22:00"},
+			expectErr: "start and end must be different",
+		},
+		{
+			name:      "invalid time zone",
+			window:    &SchedulingWindow{Start: "22:00", End: "06:00", TimeZone: "Mars/Olympus"},
+			expectErr: `invalid time_zone "Mars/Olympus"`,
+		},
+		{
+			name:      "invalid day",
+			window:    &SchedulingWindow{Start: "22:00", End: "06:00", Days: []string{"someday"}},
+			expectErr: `invalid day "someday"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.window.Validate()
+			if tc.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestSchedulingWindow_Next(t *testing.T) {
+	ci.Parallel(t)
+
+	// 2023-06-07 is a Wednesday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2023, time.June, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	cases := []struct {
+		name   string
+		window *SchedulingWindow
+		now    time.Time
+		next   time.Time
+	}{
+		{
+			name:   "open",
+			window: &SchedulingWindow{Start: "09:00", End: "17:00"},
+			now:    at(7, 12, 0),
+			next:   at(7, 12, 0),
+		},
+		{
+			name:   "before start",
+			window: &SchedulingWindow{Start: "09:00", End: "17:00"},
+			now:    at(7, 8, 30),
+			next:   at(7, 9, 0),
+		},
+		{
+			name:   "at end",
+			window: &SchedulingWindow{Start: "09:00", End: "17:00"},
+			now:    at(7, 17, 0),
+			next:   at(8, 9, 0),
+		},
+		{
+			name:   "spans midnight and opened the day before",
+			window: &SchedulingWindow{Start: "22:00", End: "02:00"},
+			now:    at(8, 1, 0),
+			next:   at(8, 1, 0),
+		},
+		{
+			name:   "spans midnight and closed",
+			window: &SchedulingWindow{Start: "22:00", End: "02:00"},
+			now:    at(8, 3, 0),
+			next:   at(8, 22, 0),
+		},
+		{
+			name:   "days",
+			window: &SchedulingWindow{Start: "09:00", End: "17:00", Days: []string{"sat", "sun"}},
+			now:    at(7, 12, 0),
+			next:   at(10, 9, 0),
+		},
+		{
+			name:   "days span midnight from the day the window opens",
+			window: &SchedulingWindow{Start: "22:00", End: "02:00", Days: []string{"tue"}},
+			now:    at(7, 1, 0),
+			next:   at(7, 1, 0),
+		},
+		{
+			name:   "time zone",
+			window: &SchedulingWindow{Start: "09:00", End: "17:00", TimeZone: "America/New_York"},
+			now:    at(7, 12, 0),
+			next:   at(7, 13, 0),
+		},
+		{
+			// Clocks go forward from 02:00 EST to 03:00 EDT on 2023-03-12
+			name:   "opens on day of spring forward",
+			window: &SchedulingWindow{Start: "09:00", End: "17:00", TimeZone: "America/New_York"},
+			now:    time.Date(2023, time.March, 12, 12, 0, 0, 0, time.UTC),
+			next:   time.Date(2023, time.March, 12, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "closes on day of spring forward",
+			window: &SchedulingWindow{Start: "22:00", End: "06:00", TimeZone: "America/New_York"},
+			now:    time.Date(2023, time.March, 12, 10, 30, 0, 0, time.UTC),
+			next:   time.Date(2023, time.March, 13, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			// Clocks go back from 02:00 EDT to 01:00 EST on 2023-11-05
+			name:   "opens on day of fall back",
+			window: &SchedulingWindow{Start: "09:00", End: "17:00", TimeZone: "America/New_York"},
+			now:    time.Date(2023, time.November, 5, 13, 30, 0, 0, time.UTC),
+			next:   time.Date(2023, time.November, 5, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "open until end on day of fall back",
+			window: &SchedulingWindow{Start: "22:00", End: "06:00", TimeZone: "America/New_York"},
+			now:    time.Date(2023, time.November, 5, 10, 30, 0, 0, time.UTC),
+			next:   time.Date(2023, time.November, 5, 10, 30, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, tc.window.Validate())
+			require.True(t, tc.next.Equal(tc.window.Next(tc.now)),
+				"expected %v, got %v", tc.next, tc.window.Next(tc.now))
+			require.Equal(t, tc.next.Equal(tc.now), tc.window.Open(tc.now))
+		})
+	}
+}
+
+func TestJob_LookupSchedulingWindow(t *testing.T) {
+	ci.Parallel(t)
+
+	job := MockJob()
+	require.Nil(t, job.LookupSchedulingWindow("web"))
+	require.True(t, job.LookupSchedulingWindow("web").Open(time.Now()))
+
+	// The window of the job applies to every task group
+	job.Window = &SchedulingWindow{Start: "22:00", End: "06:00"}
+	require.Equal(t, job.Window, job.LookupSchedulingWindow("web"))
+
+	// The window of the task group overrides the window of the job
+	job.TaskGroups[0].Window = &SchedulingWindow{Start: "02:00", End: "04:00"}
+	require.Equal(t, job.TaskGroups[0].Window, job.LookupSchedulingWindow("web"))
+	require.Equal(t, job.Window, job.LookupSchedulingWindow("other"))
+}
+
+func TestJob_Validate_SchedulingWindow(t *testing.T) {
+	ci.Parallel(t)
+
+	job := MockJob()
+	job.Window = &SchedulingWindow{Start: "22:00", End: "06:00"}
+	job.TaskGroups[0].Window = &SchedulingWindow{Start: "02:00", End: "02:00"}
+	err := job.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Window validation failed")
+	require.Contains(t, err.Error(), "start and end must be different")
+
+	job.TaskGroups[0].Window = nil
+	require.NoError(t, job.Validate())
+
+	// System jobs aren't placed by the scheduler that honours windows
+	job = MockJob()
+	job.Type = JobTypeSystem
+	job.Window = &SchedulingWindow{Start: "22:00", End: "06:00"}
+	err = job.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Job type "system" does not allow window block`)
+}
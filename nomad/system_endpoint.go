@@ -5,9 +5,15 @@ import (
 
 	log "github.com/hashicorp/go-hclog"
 
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
+// rebalanceEvalBatchSize is the number of rebalance evaluations written in a
+// single raft apply, so that rebalancing a large cluster doesn't exceed the
+// raft entry size.
+const rebalanceEvalBatchSize = 100
+
 // System endpoint is used to call invoke system tasks.
 type System struct {
 	srv    *Server
@@ -59,3 +65,58 @@ func (s *System) ReconcileJobSummaries(args *structs.GenericRequest, reply *stru
 	reply.Index = index
 	return nil
 }
+
+// Rebalance creates a rebalance evaluation for every running service job in
+// the cluster, which migrates allocations that would score better on other
+// nodes.
+func (s *System) Rebalance(args *structs.SystemRebalanceRequest, reply *structs.SystemRebalanceResponse) error {
+	if done, err := s.srv.forward("System.Rebalance", args, args, reply); done {
+		return err
+	}
+
+	// Check management level permissions
+	if acl, err := s.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl != nil && !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	iter, err := s.srv.fsm.State().Jobs(nil)
+	if err != nil {
+		return err
+	}
+
+	var evals []*structs.Evaluation
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		job := raw.(*structs.Job)
+		if job.Type != structs.JobTypeService || job.Stopped() ||
+			job.IsPeriodic() || job.IsParameterized() {
+			continue
+		}
+		evals = append(evals, newRebalanceEval(job))
+	}
+
+	if len(evals) == 0 {
+		return nil
+	}
+
+	reply.EvalIDs = make([]string, 0, len(evals))
+	for len(evals) > 0 {
+		batch := evals[:helper.Min(len(evals), rebalanceEvalBatchSize)]
+		evals = evals[len(batch):]
+
+		update := &structs.EvalUpdateRequest{
+			Evals:        batch,
+			WriteRequest: structs.WriteRequest{Region: args.Region},
+		}
+		_, index, err := s.srv.raftApply(structs.EvalUpdateRequestType, update)
+		if err != nil {
+			return fmt.Errorf("failed to create rebalance evaluations: %v", err)
+		}
+		for _, eval := range batch {
+			reply.EvalIDs = append(reply.EvalIDs, eval.ID)
+		}
+		reply.Index = index
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSystemEndpoint_GarbageCollect(t *testing.T) {
@@ -218,3 +219,99 @@ func TestSystemEndpoint_ReconcileJobSummaries_ACL(t *testing.T) {
 		assert.Nil(msgpackrpc.CallWithCodec(codec, "System.ReconcileJobSummaries", req, &resp))
 	}
 }
+
+func TestSystemEndpoint_Rebalance(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	assert := assert.New(t)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Create a service job, a stopped service job and a batch job. Only the
+	// running service job is rebalanced.
+	job := mock.Job()
+	assert.Nil(state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+	stoppedJob := mock.Job()
+	stoppedJob.Stop = true
+	assert.Nil(state.UpsertJob(structs.MsgTypeTestSetup, 1001, stoppedJob))
+	batchJob := mock.BatchJob()
+	assert.Nil(state.UpsertJob(structs.MsgTypeTestSetup, 1002, batchJob))
+
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1003, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+
+	req := &structs.SystemRebalanceRequest{
+		WriteRequest: structs.WriteRequest{
+			Region: "global",
+		},
+	}
+
+	// Try with an invalid token and expect failure
+	{
+		req.AuthToken = invalidToken.SecretID
+		var resp structs.SystemRebalanceResponse
+		err := msgpackrpc.CallWithCodec(codec, "System.Rebalance", req, &resp)
+		assert.NotNil(err)
+		assert.Contains(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+
+	// Try with a management token
+	{
+		req.AuthToken = root.SecretID
+		var resp structs.SystemRebalanceResponse
+		assert.Nil(msgpackrpc.CallWithCodec(codec, "System.Rebalance", req, &resp))
+		assert.NotZero(resp.Index)
+		if assert.Len(resp.EvalIDs, 1) {
+			eval, err := state.EvalByID(nil, resp.EvalIDs[0])
+			assert.Nil(err)
+			assert.Equal(job.ID, eval.JobID)
+			assert.Equal(structs.EvalTriggerRebalance, eval.TriggeredBy)
+		}
+	}
+}
+
+func TestSystemEndpoint_Rebalance_Batches(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Create more jobs than fit in a single batch of evaluations
+	numJobs := rebalanceEvalBatchSize*2 + 1
+	for i := 0; i < numJobs; i++ {
+		require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, uint64(1000+i), mock.Job()))
+	}
+
+	req := &structs.SystemRebalanceRequest{
+		WriteRequest: structs.WriteRequest{
+			Region: "global",
+		},
+	}
+	var resp structs.SystemRebalanceResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "System.Rebalance", req, &resp))
+	require.Len(t, resp.EvalIDs, numJobs)
+
+	// Every evaluation is written, spread across one raft apply per batch
+	indexes := map[uint64]int{}
+	for _, evalID := range resp.EvalIDs {
+		eval, err := state.EvalByID(nil, evalID)
+		require.NoError(t, err)
+		require.NotNil(t, eval)
+		indexes[eval.CreateIndex]++
+	}
+	require.Len(t, indexes, 3)
+	require.Equal(t, 1, indexes[resp.Index])
+	for _, count := range indexes {
+		require.LessOrEqual(t, count, rebalanceEvalBatchSize)
+	}
+}
@@ -74,6 +74,8 @@ func (d *driverPluginClient) Capabilities() (*Capabilities, error) {
 
 		caps.MountConfigs = MountConfigSupport(resp.Capabilities.MountConfigs)
 		caps.RemoteTasks = resp.Capabilities.RemoteTasks
+		caps.UserNamespaces = resp.Capabilities.UserNamespaces
+		caps.Checkpoint = resp.Capabilities.Checkpoint
 	}
 
 	return caps, nil
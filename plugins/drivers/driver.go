@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/nomad/client/allocdir"
@@ -38,8 +39,38 @@ const (
 	// cases like down or drained nodes causing the original allocation to
 	// be terminal.
 	DetachSignal = "DETACH"
+
+	// CheckpointSignal is a special signal sent to drivers supporting
+	// checkpoints when a task should be checkpointed to its task
+	// directory's CheckpointDir before being stopped, so that it can be
+	// restored by a replacement allocation. The kill signal of the task
+	// follows it, separated by a colon, and drivers which fail to checkpoint
+	// the task stop it with that signal. See CheckpointKillSignal.
+	CheckpointSignal = "CHECKPOINT"
 )
 
+// CheckpointKillSignal returns the signal sent to drivers to checkpoint a
+// task whose kill signal is killSignal.
+func CheckpointKillSignal(killSignal string) string {
+	if killSignal == "" {
+		return CheckpointSignal
+	}
+	return CheckpointSignal + ":" + killSignal
+}
+
+// ParseCheckpointSignal returns whether the signal given to stop a task asks
+// for it to be checkpointed, along with the kill signal of the task to stop
+// it with if checkpointing fails.
+func ParseCheckpointSignal(signal string) (string, bool) {
+	if signal == CheckpointSignal {
+		return "", true
+	}
+	if killSignal := strings.TrimPrefix(signal, CheckpointSignal+":"); killSignal != signal {
+		return killSignal, true
+	}
+	return signal, false
+}
+
 // DriverPlugin is the interface with drivers will implement. It is also
 // implemented by a plugin client which proxies the calls to go-plugin. See
 // the proto/driver.proto file for detailed information about each RPC and
@@ -171,6 +202,14 @@ type Capabilities struct {
 	// adjust behavior such as propogating task handles between allocations
 	// to avoid downtime when a client is lost.
 	RemoteTasks bool
+
+	// UserNamespaces indicates the driver runs tasks in the user namespace
+	// given by TaskConfig.UserNamespace, if any.
+	UserNamespaces bool
+
+	// Checkpoint indicates the driver checkpoints tasks stopped with
+	// CheckpointSignal, and restores tasks started with TaskConfig.Restore.
+	Checkpoint bool
 }
 
 func (c *Capabilities) HasNetIsolationMode(m NetIsolationMode) bool {
@@ -229,6 +268,33 @@ const (
 	MountConfigSupportNone
 )
 
+// UserNamespace maps the user and group IDs of a user namespace to ranges of
+// IDs on the host. IDs 0 to Size-1 within the namespace map to HostUID to
+// HostUID+Size-1 and HostGID to HostGID+Size-1 on the host.
+type UserNamespace struct {
+	HostUID uint32
+	HostGID uint32
+	Size    uint32
+}
+
+func (u *UserNamespace) Copy() *UserNamespace {
+	if u == nil {
+		return nil
+	}
+	c := *u
+	return &c
+}
+
+// String describes the mappings of the user namespace.
+func (u *UserNamespace) String() string {
+	if u == nil {
+		return "none"
+	}
+	return fmt.Sprintf("uids 0-%d to %d-%d, gids 0-%d to %d-%d",
+		u.Size-1, u.HostUID, u.HostUID+u.Size-1,
+		u.Size-1, u.HostGID, u.HostGID+u.Size-1)
+}
+
 type TerminalSize struct {
 	Height int
 	Width  int
@@ -284,6 +350,12 @@ type TaskConfig struct {
 	AllocID          string
 	NetworkIsolation *NetworkIsolationSpec
 	DNS              *DNSConfig
+	UserNamespace    *UserNamespace
+
+	// Restore is set when the task should be restored from the checkpoint
+	// in its task directory's CheckpointDir instead of started from
+	// scratch. Drivers fall back to starting the task if restoring fails.
+	Restore bool
 }
 
 func (tc *TaskConfig) Copy() *TaskConfig {
@@ -296,6 +368,7 @@ func (tc *TaskConfig) Copy() *TaskConfig {
 	c.DeviceEnv = helper.CopyMapStringString(c.DeviceEnv)
 	c.Resources = tc.Resources.Copy()
 	c.DNS = tc.DNS.Copy()
+	c.UserNamespace = tc.UserNamespace.Copy()
 
 	if c.Devices != nil {
 		dc := make([]*DeviceConfig, len(c.Devices))
@@ -335,6 +408,7 @@ func (tc *TaskConfig) TaskDir() *allocdir.TaskDir {
 		SharedTaskDir:  filepath.Join(taskDir, allocdir.SharedAllocName),
 		LocalDir:       filepath.Join(taskDir, allocdir.TaskLocal),
 		SecretsDir:     filepath.Join(taskDir, allocdir.TaskSecrets),
+		CheckpointDir:  filepath.Join(tc.AllocDir, allocdir.CheckpointDirName, tc.Name),
 	}
 }
 
@@ -233,7 +233,7 @@ func (x CPUUsage_Fields) String() string {
 }
 
 func (CPUUsage_Fields) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{54, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{55, 0}
 }
 
 type MemoryUsage_Fields int32
@@ -273,7 +273,7 @@ func (x MemoryUsage_Fields) String() string {
 }
 
 func (MemoryUsage_Fields) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{55, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{56, 0}
 }
 
 type TaskConfigSchemaRequest struct {
@@ -1848,7 +1848,13 @@ type DriverCapabilities struct {
 	MountConfigs DriverCapabilities_MountConfigs `protobuf:"varint,6,opt,name=mount_configs,json=mountConfigs,proto3,enum=hashicorp.nomad.plugins.drivers.proto.DriverCapabilities_MountConfigs" json:"mount_configs,omitempty"`
 	// remote_tasks indicates whether the driver executes tasks remotely such
 	// on cloud runtimes like AWS ECS.
-	RemoteTasks          bool     `protobuf:"varint,7,opt,name=remote_tasks,json=remoteTasks,proto3" json:"remote_tasks,omitempty"`
+	RemoteTasks bool `protobuf:"varint,7,opt,name=remote_tasks,json=remoteTasks,proto3" json:"remote_tasks,omitempty"`
+	// user_namespaces indicates whether the driver runs tasks in the user
+	// namespace given in their TaskConfig.
+	UserNamespaces bool `protobuf:"varint,8,opt,name=user_namespaces,json=userNamespaces,proto3" json:"user_namespaces,omitempty"`
+	// checkpoint indicates whether the driver can checkpoint tasks stopped
+	// with the CHECKPOINT signal and restore them when starting tasks.
+	Checkpoint           bool     `protobuf:"varint,9,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1928,6 +1934,20 @@ func (m *DriverCapabilities) GetRemoteTasks() bool {
 	return false
 }
 
+func (m *DriverCapabilities) GetUserNamespaces() bool {
+	if m != nil {
+		return m.UserNamespaces
+	}
+	return false
+}
+
+func (m *DriverCapabilities) GetCheckpoint() bool {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return false
+}
+
 type NetworkIsolationSpec struct {
 	Mode                 NetworkIsolationSpec_NetworkIsolationMode `protobuf:"varint,1,opt,name=mode,proto3,enum=hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec_NetworkIsolationMode" json:"mode,omitempty"`
 	Path                 string                                    `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
@@ -2038,6 +2058,64 @@ func (m *HostsConfig) GetAddress() string {
 	return ""
 }
 
+type UserNamespace struct {
+	// host_uid is the host user ID that uid 0 of the namespace maps to
+	HostUid uint32 `protobuf:"varint,1,opt,name=host_uid,json=hostUid,proto3" json:"host_uid,omitempty"`
+	// host_gid is the host group ID that gid 0 of the namespace maps to
+	HostGid uint32 `protobuf:"varint,2,opt,name=host_gid,json=hostGid,proto3" json:"host_gid,omitempty"`
+	// size is the number of user and group IDs mapped
+	Size                 uint32   `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UserNamespace) Reset()         { *m = UserNamespace{} }
+func (m *UserNamespace) String() string { return proto.CompactTextString(m) }
+func (*UserNamespace) ProtoMessage()    {}
+func (*UserNamespace) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{35}
+}
+
+func (m *UserNamespace) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserNamespace.Unmarshal(m, b)
+}
+func (m *UserNamespace) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserNamespace.Marshal(b, m, deterministic)
+}
+func (m *UserNamespace) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserNamespace.Merge(m, src)
+}
+func (m *UserNamespace) XXX_Size() int {
+	return xxx_messageInfo_UserNamespace.Size(m)
+}
+func (m *UserNamespace) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserNamespace.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserNamespace proto.InternalMessageInfo
+
+func (m *UserNamespace) GetHostUid() uint32 {
+	if m != nil {
+		return m.HostUid
+	}
+	return 0
+}
+
+func (m *UserNamespace) GetHostGid() uint32 {
+	if m != nil {
+		return m.HostGid
+	}
+	return 0
+}
+
+func (m *UserNamespace) GetSize() uint32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
 type DNSConfig struct {
 	Servers              []string `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
 	Searches             []string `protobuf:"bytes,2,rep,name=searches,proto3" json:"searches,omitempty"`
@@ -2051,7 +2129,7 @@ func (m *DNSConfig) Reset()         { *m = DNSConfig{} }
 func (m *DNSConfig) String() string { return proto.CompactTextString(m) }
 func (*DNSConfig) ProtoMessage()    {}
 func (*DNSConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{35}
+	return fileDescriptor_4a8f45747846a74d, []int{36}
 }
 
 func (m *DNSConfig) XXX_Unmarshal(b []byte) error {
@@ -2133,17 +2211,23 @@ type TaskConfig struct {
 	// to use for the task. *Only supported on Linux
 	NetworkIsolationSpec *NetworkIsolationSpec `protobuf:"bytes,16,opt,name=network_isolation_spec,json=networkIsolationSpec,proto3" json:"network_isolation_spec,omitempty"`
 	// DNSConfig is the configuration for task DNS resolvers and other options
-	Dns                  *DNSConfig `protobuf:"bytes,17,opt,name=dns,proto3" json:"dns,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Dns *DNSConfig `protobuf:"bytes,17,opt,name=dns,proto3" json:"dns,omitempty"`
+	// UserNamespace maps the IDs of the user namespace to run the task in.
+	// *Only supported on Linux
+	UserNamespace *UserNamespace `protobuf:"bytes,18,opt,name=user_namespace,json=userNamespace,proto3" json:"user_namespace,omitempty"`
+	// Restore indicates the task should be restored from the checkpoint in
+	// its task directory instead of started from scratch.
+	Restore              bool     `protobuf:"varint,19,opt,name=restore,proto3" json:"restore,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *TaskConfig) Reset()         { *m = TaskConfig{} }
 func (m *TaskConfig) String() string { return proto.CompactTextString(m) }
 func (*TaskConfig) ProtoMessage()    {}
 func (*TaskConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{36}
+	return fileDescriptor_4a8f45747846a74d, []int{37}
 }
 
 func (m *TaskConfig) XXX_Unmarshal(b []byte) error {
@@ -2283,6 +2367,20 @@ func (m *TaskConfig) GetDns() *DNSConfig {
 	return nil
 }
 
+func (m *TaskConfig) GetUserNamespace() *UserNamespace {
+	if m != nil {
+		return m.UserNamespace
+	}
+	return nil
+}
+
+func (m *TaskConfig) GetRestore() bool {
+	if m != nil {
+		return m.Restore
+	}
+	return false
+}
+
 type Resources struct {
 	// AllocatedResources are the resources set for the task
 	AllocatedResources *AllocatedTaskResources `protobuf:"bytes,1,opt,name=allocated_resources,json=allocatedResources,proto3" json:"allocated_resources,omitempty"`
@@ -2300,7 +2398,7 @@ func (m *Resources) Reset()         { *m = Resources{} }
 func (m *Resources) String() string { return proto.CompactTextString(m) }
 func (*Resources) ProtoMessage()    {}
 func (*Resources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{37}
+	return fileDescriptor_4a8f45747846a74d, []int{38}
 }
 
 func (m *Resources) XXX_Unmarshal(b []byte) error {
@@ -2355,7 +2453,7 @@ func (m *AllocatedTaskResources) Reset()         { *m = AllocatedTaskResources{}
 func (m *AllocatedTaskResources) String() string { return proto.CompactTextString(m) }
 func (*AllocatedTaskResources) ProtoMessage()    {}
 func (*AllocatedTaskResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{38}
+	return fileDescriptor_4a8f45747846a74d, []int{39}
 }
 
 func (m *AllocatedTaskResources) XXX_Unmarshal(b []byte) error {
@@ -2408,7 +2506,7 @@ func (m *AllocatedCpuResources) Reset()         { *m = AllocatedCpuResources{} }
 func (m *AllocatedCpuResources) String() string { return proto.CompactTextString(m) }
 func (*AllocatedCpuResources) ProtoMessage()    {}
 func (*AllocatedCpuResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{39}
+	return fileDescriptor_4a8f45747846a74d, []int{40}
 }
 
 func (m *AllocatedCpuResources) XXX_Unmarshal(b []byte) error {
@@ -2448,7 +2546,7 @@ func (m *AllocatedMemoryResources) Reset()         { *m = AllocatedMemoryResourc
 func (m *AllocatedMemoryResources) String() string { return proto.CompactTextString(m) }
 func (*AllocatedMemoryResources) ProtoMessage()    {}
 func (*AllocatedMemoryResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{40}
+	return fileDescriptor_4a8f45747846a74d, []int{41}
 }
 
 func (m *AllocatedMemoryResources) XXX_Unmarshal(b []byte) error {
@@ -2499,7 +2597,7 @@ func (m *NetworkResource) Reset()         { *m = NetworkResource{} }
 func (m *NetworkResource) String() string { return proto.CompactTextString(m) }
 func (*NetworkResource) ProtoMessage()    {}
 func (*NetworkResource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{41}
+	return fileDescriptor_4a8f45747846a74d, []int{42}
 }
 
 func (m *NetworkResource) XXX_Unmarshal(b []byte) error {
@@ -2574,7 +2672,7 @@ func (m *NetworkPort) Reset()         { *m = NetworkPort{} }
 func (m *NetworkPort) String() string { return proto.CompactTextString(m) }
 func (*NetworkPort) ProtoMessage()    {}
 func (*NetworkPort) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{42}
+	return fileDescriptor_4a8f45747846a74d, []int{43}
 }
 
 func (m *NetworkPort) XXX_Unmarshal(b []byte) error {
@@ -2623,7 +2721,7 @@ func (m *PortMapping) Reset()         { *m = PortMapping{} }
 func (m *PortMapping) String() string { return proto.CompactTextString(m) }
 func (*PortMapping) ProtoMessage()    {}
 func (*PortMapping) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{43}
+	return fileDescriptor_4a8f45747846a74d, []int{44}
 }
 
 func (m *PortMapping) XXX_Unmarshal(b []byte) error {
@@ -2700,7 +2798,7 @@ func (m *LinuxResources) Reset()         { *m = LinuxResources{} }
 func (m *LinuxResources) String() string { return proto.CompactTextString(m) }
 func (*LinuxResources) ProtoMessage()    {}
 func (*LinuxResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{44}
+	return fileDescriptor_4a8f45747846a74d, []int{45}
 }
 
 func (m *LinuxResources) XXX_Unmarshal(b []byte) error {
@@ -2793,7 +2891,7 @@ func (m *Mount) Reset()         { *m = Mount{} }
 func (m *Mount) String() string { return proto.CompactTextString(m) }
 func (*Mount) ProtoMessage()    {}
 func (*Mount) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{45}
+	return fileDescriptor_4a8f45747846a74d, []int{46}
 }
 
 func (m *Mount) XXX_Unmarshal(b []byte) error {
@@ -2842,9 +2940,9 @@ type Device struct {
 	HostPath string `protobuf:"bytes,2,opt,name=host_path,json=hostPath,proto3" json:"host_path,omitempty"`
 	// CgroupPermissions defines the Cgroup permissions of the device.
 	// One or more of the following options can be set:
-	//   - r - allows the task to read from the specified device.
-	//   - w - allows the task to write to the specified device.
-	//   - m - allows the task to create device files that do not yet exist.
+	//  * r - allows the task to read from the specified device.
+	//  * w - allows the task to write to the specified device.
+	//  * m - allows the task to create device files that do not yet exist.
 	//
 	// Example: "rw"
 	CgroupPermissions    string   `protobuf:"bytes,3,opt,name=cgroup_permissions,json=cgroupPermissions,proto3" json:"cgroup_permissions,omitempty"`
@@ -2857,7 +2955,7 @@ func (m *Device) Reset()         { *m = Device{} }
 func (m *Device) String() string { return proto.CompactTextString(m) }
 func (*Device) ProtoMessage()    {}
 func (*Device) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{46}
+	return fileDescriptor_4a8f45747846a74d, []int{47}
 }
 
 func (m *Device) XXX_Unmarshal(b []byte) error {
@@ -2919,7 +3017,7 @@ func (m *TaskHandle) Reset()         { *m = TaskHandle{} }
 func (m *TaskHandle) String() string { return proto.CompactTextString(m) }
 func (*TaskHandle) ProtoMessage()    {}
 func (*TaskHandle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{47}
+	return fileDescriptor_4a8f45747846a74d, []int{48}
 }
 
 func (m *TaskHandle) XXX_Unmarshal(b []byte) error {
@@ -2987,7 +3085,7 @@ func (m *NetworkOverride) Reset()         { *m = NetworkOverride{} }
 func (m *NetworkOverride) String() string { return proto.CompactTextString(m) }
 func (*NetworkOverride) ProtoMessage()    {}
 func (*NetworkOverride) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{48}
+	return fileDescriptor_4a8f45747846a74d, []int{49}
 }
 
 func (m *NetworkOverride) XXX_Unmarshal(b []byte) error {
@@ -3046,7 +3144,7 @@ func (m *ExitResult) Reset()         { *m = ExitResult{} }
 func (m *ExitResult) String() string { return proto.CompactTextString(m) }
 func (*ExitResult) ProtoMessage()    {}
 func (*ExitResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{49}
+	return fileDescriptor_4a8f45747846a74d, []int{50}
 }
 
 func (m *ExitResult) XXX_Unmarshal(b []byte) error {
@@ -3110,7 +3208,7 @@ func (m *TaskStatus) Reset()         { *m = TaskStatus{} }
 func (m *TaskStatus) String() string { return proto.CompactTextString(m) }
 func (*TaskStatus) ProtoMessage()    {}
 func (*TaskStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{50}
+	return fileDescriptor_4a8f45747846a74d, []int{51}
 }
 
 func (m *TaskStatus) XXX_Unmarshal(b []byte) error {
@@ -3186,7 +3284,7 @@ func (m *TaskDriverStatus) Reset()         { *m = TaskDriverStatus{} }
 func (m *TaskDriverStatus) String() string { return proto.CompactTextString(m) }
 func (*TaskDriverStatus) ProtoMessage()    {}
 func (*TaskDriverStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{51}
+	return fileDescriptor_4a8f45747846a74d, []int{52}
 }
 
 func (m *TaskDriverStatus) XXX_Unmarshal(b []byte) error {
@@ -3232,7 +3330,7 @@ func (m *TaskStats) Reset()         { *m = TaskStats{} }
 func (m *TaskStats) String() string { return proto.CompactTextString(m) }
 func (*TaskStats) ProtoMessage()    {}
 func (*TaskStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{52}
+	return fileDescriptor_4a8f45747846a74d, []int{53}
 }
 
 func (m *TaskStats) XXX_Unmarshal(b []byte) error {
@@ -3295,7 +3393,7 @@ func (m *TaskResourceUsage) Reset()         { *m = TaskResourceUsage{} }
 func (m *TaskResourceUsage) String() string { return proto.CompactTextString(m) }
 func (*TaskResourceUsage) ProtoMessage()    {}
 func (*TaskResourceUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{53}
+	return fileDescriptor_4a8f45747846a74d, []int{54}
 }
 
 func (m *TaskResourceUsage) XXX_Unmarshal(b []byte) error {
@@ -3348,7 +3446,7 @@ func (m *CPUUsage) Reset()         { *m = CPUUsage{} }
 func (m *CPUUsage) String() string { return proto.CompactTextString(m) }
 func (*CPUUsage) ProtoMessage()    {}
 func (*CPUUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{54}
+	return fileDescriptor_4a8f45747846a74d, []int{55}
 }
 
 func (m *CPUUsage) XXX_Unmarshal(b []byte) error {
@@ -3437,7 +3535,7 @@ func (m *MemoryUsage) Reset()         { *m = MemoryUsage{} }
 func (m *MemoryUsage) String() string { return proto.CompactTextString(m) }
 func (*MemoryUsage) ProtoMessage()    {}
 func (*MemoryUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{55}
+	return fileDescriptor_4a8f45747846a74d, []int{56}
 }
 
 func (m *MemoryUsage) XXX_Unmarshal(b []byte) error {
@@ -3536,7 +3634,7 @@ func (m *DriverTaskEvent) Reset()         { *m = DriverTaskEvent{} }
 func (m *DriverTaskEvent) String() string { return proto.CompactTextString(m) }
 func (*DriverTaskEvent) ProtoMessage()    {}
 func (*DriverTaskEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{56}
+	return fileDescriptor_4a8f45747846a74d, []int{57}
 }
 
 func (m *DriverTaskEvent) XXX_Unmarshal(b []byte) error {
@@ -3647,6 +3745,7 @@ func init() {
 	proto.RegisterType((*NetworkIsolationSpec)(nil), "hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec.LabelsEntry")
 	proto.RegisterType((*HostsConfig)(nil), "hashicorp.nomad.plugins.drivers.proto.HostsConfig")
+	proto.RegisterType((*UserNamespace)(nil), "hashicorp.nomad.plugins.drivers.proto.UserNamespace")
 	proto.RegisterType((*DNSConfig)(nil), "hashicorp.nomad.plugins.drivers.proto.DNSConfig")
 	proto.RegisterType((*TaskConfig)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskConfig")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskConfig.DeviceEnvEntry")
@@ -3682,243 +3781,249 @@ func init() {
 }
 
 var fileDescriptor_4a8f45747846a74d = []byte{
-	// 3776 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0xcd, 0x6f, 0x1b, 0x49,
-	0x76, 0x57, 0xb3, 0xf9, 0xf9, 0x48, 0x51, 0xad, 0xb2, 0x6c, 0xd3, 0x9c, 0x24, 0xe3, 0xed, 0x60,
-	0x02, 0x61, 0x77, 0x86, 0x9e, 0xd5, 0x22, 0xe3, 0xf1, 0xac, 0x67, 0x3d, 0x1c, 0x8a, 0xb6, 0x34,
-	0x96, 0x28, 0xa5, 0x48, 0xc1, 0xeb, 0x38, 0x3b, 0x9d, 0x16, 0xbb, 0x4c, 0xb5, 0xc5, 0xfe, 0x98,
-	0xae, 0xa6, 0x2c, 0x6d, 0x10, 0x24, 0xd8, 0x00, 0xc1, 0x06, 0x48, 0x90, 0x5c, 0x26, 0x7b, 0xc9,
-	0x69, 0x81, 0x9c, 0xf2, 0x0f, 0x04, 0x1b, 0xec, 0x29, 0x87, 0xfc, 0x13, 0xb9, 0xe4, 0x96, 0x63,
-	0x72, 0xca, 0x35, 0xa8, 0x8f, 0x6e, 0x76, 0x93, 0xf4, 0xb8, 0x49, 0xf9, 0xc4, 0x7e, 0xf5, 0xf1,
-	0xab, 0xc7, 0xf7, 0x5e, 0xbd, 0x7a, 0xf5, 0xea, 0x81, 0xee, 0x8f, 0x27, 0x23, 0xdb, 0xa5, 0xf7,
-	0xac, 0xc0, 0xbe, 0x20, 0x01, 0xbd, 0xe7, 0x07, 0x5e, 0xe8, 0x49, 0xaa, 0xc5, 0x09, 0xf4, 0xc1,
-	0x99, 0x49, 0xcf, 0xec, 0xa1, 0x17, 0xf8, 0x2d, 0xd7, 0x73, 0x4c, 0xab, 0x25, 0xe7, 0xb4, 0xe4,
-	0x1c, 0x31, 0xac, 0xf9, 0x7b, 0x23, 0xcf, 0x1b, 0x8d, 0x89, 0x40, 0x38, 0x9d, 0xbc, 0xbc, 0x67,
-	0x4d, 0x02, 0x33, 0xb4, 0x3d, 0x57, 0xf6, 0xbf, 0x3f, 0xdb, 0x1f, 0xda, 0x0e, 0xa1, 0xa1, 0xe9,
-	0xf8, 0x72, 0xc0, 0x07, 0x11, 0x2f, 0xf4, 0xcc, 0x0c, 0x88, 0x75, 0xef, 0x6c, 0x38, 0xa6, 0x3e,
-	0x19, 0xb2, 0x5f, 0x83, 0x7d, 0xc8, 0x61, 0x1f, 0xce, 0x0c, 0xa3, 0x61, 0x30, 0x19, 0x86, 0x11,
-	0xe7, 0x66, 0x18, 0x06, 0xf6, 0xe9, 0x24, 0x24, 0x62, 0xb4, 0x7e, 0x07, 0x6e, 0x0f, 0x4c, 0x7a,
-	0xde, 0xf1, 0xdc, 0x97, 0xf6, 0xa8, 0x3f, 0x3c, 0x23, 0x8e, 0x89, 0xc9, 0x37, 0x13, 0x42, 0x43,
-	0xfd, 0x4f, 0xa0, 0x31, 0xdf, 0x45, 0x7d, 0xcf, 0xa5, 0x04, 0x7d, 0x01, 0x79, 0xb6, 0x64, 0x43,
-	0xb9, 0xab, 0x6c, 0x57, 0x77, 0x3e, 0x6c, 0xbd, 0x49, 0x04, 0x82, 0x87, 0x96, 0x64, 0xb5, 0xd5,
-	0xf7, 0xc9, 0x10, 0xf3, 0x99, 0xfa, 0x4d, 0xb8, 0xd1, 0x31, 0x7d, 0xf3, 0xd4, 0x1e, 0xdb, 0xa1,
-	0x4d, 0x68, 0xb4, 0xe8, 0x04, 0xb6, 0xd2, 0xcd, 0x72, 0xc1, 0x9f, 0x41, 0x6d, 0x98, 0x68, 0x97,
-	0x0b, 0x3f, 0x68, 0x65, 0x92, 0x7d, 0x6b, 0x97, 0x53, 0x29, 0xe0, 0x14, 0x9c, 0xbe, 0x05, 0xe8,
-	0xb1, 0xed, 0x8e, 0x48, 0xe0, 0x07, 0xb6, 0x1b, 0x46, 0xcc, 0xfc, 0x56, 0x85, 0x1b, 0xa9, 0x66,
-	0xc9, 0xcc, 0x2b, 0x80, 0x58, 0x8e, 0x8c, 0x15, 0x75, 0xbb, 0xba, 0xf3, 0x55, 0x46, 0x56, 0x16,
-	0xe0, 0xb5, 0xda, 0x31, 0x58, 0xd7, 0x0d, 0x83, 0x2b, 0x9c, 0x40, 0x47, 0x5f, 0x43, 0xf1, 0x8c,
-	0x98, 0xe3, 0xf0, 0xac, 0x91, 0xbb, 0xab, 0x6c, 0xd7, 0x77, 0x1e, 0x5f, 0x63, 0x9d, 0x3d, 0x0e,
-	0xd4, 0x0f, 0xcd, 0x90, 0x60, 0x89, 0x8a, 0x3e, 0x02, 0x24, 0xbe, 0x0c, 0x8b, 0xd0, 0x61, 0x60,
-	0xfb, 0xcc, 0x24, 0x1b, 0xea, 0x5d, 0x65, 0xbb, 0x82, 0x37, 0x45, 0xcf, 0xee, 0xb4, 0xa3, 0xe9,
-	0xc3, 0xc6, 0x0c, 0xb7, 0x48, 0x03, 0xf5, 0x9c, 0x5c, 0x71, 0x8d, 0x54, 0x30, 0xfb, 0x44, 0x4f,
-	0xa0, 0x70, 0x61, 0x8e, 0x27, 0x84, 0xb3, 0x5c, 0xdd, 0xf9, 0xe1, 0xdb, 0xcc, 0x43, 0x9a, 0xe8,
-	0x54, 0x0e, 0x58, 0xcc, 0xff, 0x2c, 0xf7, 0xa9, 0xa2, 0x3f, 0x80, 0x6a, 0x82, 0x6f, 0x54, 0x07,
-	0x38, 0xe9, 0xed, 0x76, 0x07, 0xdd, 0xce, 0xa0, 0xbb, 0xab, 0xad, 0xa1, 0x75, 0xa8, 0x9c, 0xf4,
-	0xf6, 0xba, 0xed, 0x83, 0xc1, 0xde, 0x73, 0x4d, 0x41, 0x55, 0x28, 0x45, 0x44, 0x4e, 0xbf, 0x04,
-	0x84, 0xc9, 0xd0, 0xbb, 0x20, 0x01, 0x33, 0x64, 0xa9, 0x55, 0x74, 0x1b, 0x4a, 0xa1, 0x49, 0xcf,
-	0x0d, 0xdb, 0x92, 0x3c, 0x17, 0x19, 0xb9, 0x6f, 0xa1, 0x7d, 0x28, 0x9e, 0x99, 0xae, 0x35, 0x7e,
-	0x3b, 0xdf, 0x69, 0x51, 0x33, 0xf0, 0x3d, 0x3e, 0x11, 0x4b, 0x00, 0x66, 0xdd, 0xa9, 0x95, 0x85,
-	0x02, 0xf4, 0xe7, 0xa0, 0xf5, 0x43, 0x33, 0x08, 0x93, 0xec, 0x74, 0x21, 0xcf, 0xd6, 0x97, 0x16,
-	0xbd, 0xcc, 0x9a, 0x62, 0x67, 0x62, 0x3e, 0x5d, 0xff, 0xdf, 0x1c, 0x6c, 0x26, 0xb0, 0xa5, 0xa5,
-	0x3e, 0x83, 0x62, 0x40, 0xe8, 0x64, 0x1c, 0x72, 0xf8, 0xfa, 0xce, 0xa3, 0x8c, 0xf0, 0x73, 0x48,
-	0x2d, 0xcc, 0x61, 0xb0, 0x84, 0x43, 0xdb, 0xa0, 0x89, 0x19, 0x06, 0x09, 0x02, 0x2f, 0x30, 0x1c,
-	0x3a, 0xe2, 0x52, 0xab, 0xe0, 0xba, 0x68, 0xef, 0xb2, 0xe6, 0x43, 0x3a, 0x4a, 0x48, 0x55, 0xbd,
-	0xa6, 0x54, 0x91, 0x09, 0x9a, 0x4b, 0xc2, 0xd7, 0x5e, 0x70, 0x6e, 0x30, 0xd1, 0x06, 0xb6, 0x45,
-	0x1a, 0x79, 0x0e, 0xfa, 0x49, 0x46, 0xd0, 0x9e, 0x98, 0x7e, 0x24, 0x67, 0xe3, 0x0d, 0x37, 0xdd,
-	0xa0, 0xff, 0x00, 0x8a, 0xe2, 0x9f, 0x32, 0x4b, 0xea, 0x9f, 0x74, 0x3a, 0xdd, 0x7e, 0x5f, 0x5b,
-	0x43, 0x15, 0x28, 0xe0, 0xee, 0x00, 0x33, 0x0b, 0xab, 0x40, 0xe1, 0x71, 0x7b, 0xd0, 0x3e, 0xd0,
-	0x72, 0xfa, 0xf7, 0x61, 0xe3, 0x99, 0x69, 0x87, 0x59, 0x8c, 0x4b, 0xf7, 0x40, 0x9b, 0x8e, 0x95,
-	0xda, 0xd9, 0x4f, 0x69, 0x27, 0xbb, 0x68, 0xba, 0x97, 0x76, 0x38, 0xa3, 0x0f, 0x0d, 0x54, 0x12,
-	0x04, 0x52, 0x05, 0xec, 0x53, 0x7f, 0x0d, 0x1b, 0xfd, 0xd0, 0xf3, 0x33, 0x59, 0xfe, 0x8f, 0xa0,
-	0xc4, 0x4e, 0x1b, 0x6f, 0x12, 0x4a, 0xd3, 0xbf, 0xd3, 0x12, 0xa7, 0x51, 0x2b, 0x3a, 0x8d, 0x5a,
-	0xbb, 0xf2, 0xb4, 0xc2, 0xd1, 0x48, 0x74, 0x0b, 0x8a, 0xd4, 0x1e, 0xb9, 0xe6, 0x58, 0x7a, 0x0b,
-	0x49, 0xe9, 0x88, 0x19, 0x79, 0xb4, 0xb0, 0x34, 0xfc, 0x0e, 0xa0, 0x5d, 0x42, 0xc3, 0xc0, 0xbb,
-	0xca, 0xc4, 0xcf, 0x16, 0x14, 0x5e, 0x7a, 0xc1, 0x50, 0x6c, 0xc4, 0x32, 0x16, 0x04, 0xdb, 0x54,
-	0x29, 0x10, 0x89, 0xfd, 0x11, 0xa0, 0x7d, 0x97, 0x9d, 0x29, 0xd9, 0x14, 0xf1, 0x0f, 0x39, 0xb8,
-	0x91, 0x1a, 0x2f, 0x95, 0xb1, 0xfa, 0x3e, 0x64, 0x8e, 0x69, 0x42, 0xc5, 0x3e, 0x44, 0x47, 0x50,
-	0x14, 0x23, 0xa4, 0x24, 0xef, 0x2f, 0x01, 0x24, 0x8e, 0x29, 0x09, 0x27, 0x61, 0x16, 0x1a, 0xbd,
-	0xfa, 0x6e, 0x8d, 0xfe, 0x35, 0x68, 0xd1, 0xff, 0xa0, 0x6f, 0xd5, 0xcd, 0x57, 0x70, 0x63, 0xe8,
-	0x8d, 0xc7, 0x64, 0xc8, 0xac, 0xc1, 0xb0, 0xdd, 0x90, 0x04, 0x17, 0xe6, 0xf8, 0xed, 0x76, 0x83,
-	0xa6, 0xb3, 0xf6, 0xe5, 0x24, 0xfd, 0x05, 0x6c, 0x26, 0x16, 0x96, 0x8a, 0x78, 0x0c, 0x05, 0xca,
-	0x1a, 0xa4, 0x26, 0x3e, 0x5e, 0x52, 0x13, 0x14, 0x8b, 0xe9, 0xfa, 0x0d, 0x01, 0xde, 0xbd, 0x20,
-	0x6e, 0xfc, 0xb7, 0xf4, 0x5d, 0xd8, 0xec, 0x73, 0x33, 0xcd, 0x64, 0x87, 0x53, 0x13, 0xcf, 0xa5,
-	0x4c, 0x7c, 0x0b, 0x50, 0x12, 0x45, 0x1a, 0xe2, 0x15, 0x6c, 0x74, 0x2f, 0xc9, 0x30, 0x13, 0x72,
-	0x03, 0x4a, 0x43, 0xcf, 0x71, 0x4c, 0xd7, 0x6a, 0xe4, 0xee, 0xaa, 0xdb, 0x15, 0x1c, 0x91, 0xc9,
-	0xbd, 0xa8, 0x66, 0xdd, 0x8b, 0xfa, 0xdf, 0x29, 0xa0, 0x4d, 0xd7, 0x96, 0x82, 0x64, 0xdc, 0x87,
-	0x16, 0x03, 0x62, 0x6b, 0xd7, 0xb0, 0xa4, 0x64, 0x7b, 0xe4, 0x2e, 0x44, 0x3b, 0x09, 0x82, 0x84,
-	0x3b, 0x52, 0xaf, 0xe9, 0x8e, 0xf4, 0x3d, 0xf8, 0x9d, 0x88, 0x9d, 0x7e, 0x18, 0x10, 0xd3, 0xb1,
-	0xdd, 0xd1, 0xfe, 0xd1, 0x91, 0x4f, 0x04, 0xe3, 0x08, 0x41, 0xde, 0x32, 0x43, 0x53, 0x32, 0xc6,
-	0xbf, 0xd9, 0xa6, 0x1f, 0x8e, 0x3d, 0x1a, 0x6f, 0x7a, 0x4e, 0xe8, 0xff, 0xa1, 0x42, 0x63, 0x0e,
-	0x2a, 0x12, 0xef, 0x0b, 0x28, 0x50, 0x12, 0x4e, 0x7c, 0x69, 0x2a, 0xdd, 0xcc, 0x0c, 0x2f, 0xc6,
-	0x6b, 0xf5, 0x19, 0x18, 0x16, 0x98, 0x68, 0x04, 0xe5, 0x30, 0xbc, 0x32, 0xa8, 0xfd, 0xf3, 0x28,
-	0x20, 0x38, 0xb8, 0x2e, 0xfe, 0x80, 0x04, 0x8e, 0xed, 0x9a, 0xe3, 0xbe, 0xfd, 0x73, 0x82, 0x4b,
-	0x61, 0x78, 0xc5, 0x3e, 0xd0, 0x73, 0x66, 0xf0, 0x96, 0xed, 0x4a, 0xb1, 0x77, 0x56, 0x5d, 0x25,
-	0x21, 0x60, 0x2c, 0x10, 0x9b, 0x07, 0x50, 0xe0, 0xff, 0x69, 0x15, 0x43, 0xd4, 0x40, 0x0d, 0xc3,
-	0x2b, 0xce, 0x54, 0x19, 0xb3, 0xcf, 0xe6, 0x43, 0xa8, 0x25, 0xff, 0x01, 0x33, 0xa4, 0x33, 0x62,
-	0x8f, 0xce, 0x84, 0x81, 0x15, 0xb0, 0xa4, 0x98, 0x26, 0x5f, 0xdb, 0x96, 0x0c, 0x59, 0x0b, 0x58,
-	0x10, 0xfa, 0xbf, 0xe6, 0xe0, 0xce, 0x02, 0xc9, 0x48, 0x63, 0x7d, 0x91, 0x32, 0xd6, 0x77, 0x24,
-	0x85, 0xc8, 0xe2, 0x5f, 0xa4, 0x2c, 0xfe, 0x1d, 0x82, 0xb3, 0x6d, 0x73, 0x0b, 0x8a, 0xe4, 0xd2,
-	0x0e, 0x89, 0x25, 0x45, 0x25, 0xa9, 0xc4, 0x76, 0xca, 0x5f, 0x77, 0x3b, 0x1d, 0xc2, 0x56, 0x27,
-	0x20, 0x66, 0x48, 0xa4, 0x2b, 0x8f, 0xec, 0xff, 0x0e, 0x94, 0xcd, 0xf1, 0xd8, 0x1b, 0x4e, 0xd5,
-	0x5a, 0xe2, 0xf4, 0xbe, 0x85, 0x9a, 0x50, 0x3e, 0xf3, 0x68, 0xe8, 0x9a, 0x0e, 0x91, 0xce, 0x2b,
-	0xa6, 0xf5, 0x6f, 0x15, 0xb8, 0x39, 0x83, 0x27, 0xb5, 0x70, 0x0a, 0x75, 0x9b, 0x7a, 0x63, 0xfe,
-	0x07, 0x8d, 0xc4, 0x0d, 0xef, 0xc7, 0xcb, 0x1d, 0x35, 0xfb, 0x11, 0x06, 0xbf, 0xf0, 0xad, 0xdb,
-	0x49, 0x92, 0x5b, 0x1c, 0x5f, 0xdc, 0x92, 0x3b, 0x3d, 0x22, 0xf5, 0x7f, 0x54, 0xe0, 0xa6, 0x3c,
-	0xe1, 0xb3, 0xff, 0xd1, 0x79, 0x96, 0x73, 0xef, 0x9a, 0x65, 0xbd, 0x01, 0xb7, 0x66, 0xf9, 0x92,
-	0x3e, 0xff, 0xff, 0xf2, 0x80, 0xe6, 0x6f, 0x97, 0xe8, 0x7b, 0x50, 0xa3, 0xc4, 0xb5, 0x0c, 0x71,
-	0x5e, 0x88, 0xa3, 0xac, 0x8c, 0xab, 0xac, 0x4d, 0x1c, 0x1c, 0x94, 0xb9, 0x40, 0x72, 0x29, 0xb9,
-	0x2d, 0x63, 0xfe, 0x8d, 0xce, 0xa0, 0xf6, 0x92, 0x1a, 0xf1, 0xda, 0xdc, 0xa0, 0xea, 0x99, 0xdd,
-	0xda, 0x3c, 0x1f, 0xad, 0xc7, 0xfd, 0xf8, 0x7f, 0xe1, 0xea, 0x4b, 0x1a, 0x13, 0xe8, 0x97, 0x0a,
-	0xdc, 0x8e, 0xc2, 0x8a, 0xa9, 0xf8, 0x1c, 0xcf, 0x22, 0xb4, 0x91, 0xbf, 0xab, 0x6e, 0xd7, 0x77,
-	0x8e, 0xaf, 0x21, 0xbf, 0xb9, 0xc6, 0x43, 0xcf, 0x22, 0xf8, 0xa6, 0xbb, 0xa0, 0x95, 0xa2, 0x16,
-	0xdc, 0x70, 0x26, 0x34, 0x34, 0x84, 0x15, 0x18, 0x72, 0x50, 0xa3, 0xc0, 0xe5, 0xb2, 0xc9, 0xba,
-	0x52, 0xb6, 0x8a, 0xce, 0x61, 0xdd, 0xf1, 0x26, 0x6e, 0x68, 0x0c, 0xf9, 0xfd, 0x87, 0x36, 0x8a,
-	0x4b, 0x5d, 0x8c, 0x17, 0x48, 0xe9, 0x90, 0xc1, 0x89, 0xdb, 0x14, 0xc5, 0x35, 0x27, 0x41, 0x31,
-	0x45, 0x06, 0xc4, 0xf1, 0x42, 0x62, 0x30, 0x7f, 0x49, 0x1b, 0x25, 0xa1, 0x48, 0xd1, 0xc6, 0x5c,
-	0x03, 0xd5, 0x5b, 0x50, 0x4d, 0x88, 0x19, 0x95, 0x21, 0xdf, 0x3b, 0xea, 0x75, 0xb5, 0x35, 0x04,
-	0x50, 0xec, 0xec, 0xe1, 0xa3, 0xa3, 0x81, 0xb8, 0x35, 0xec, 0x1f, 0xb6, 0x9f, 0x74, 0xb5, 0x9c,
-	0xde, 0x85, 0x5a, 0x72, 0x41, 0x84, 0xa0, 0x7e, 0xd2, 0x7b, 0xda, 0x3b, 0x7a, 0xd6, 0x33, 0x0e,
-	0x8f, 0x4e, 0x7a, 0x03, 0x76, 0xdf, 0xa8, 0x03, 0xb4, 0x7b, 0xcf, 0xa7, 0xf4, 0x3a, 0x54, 0x7a,
-	0x47, 0x11, 0xa9, 0x34, 0x73, 0x9a, 0xa2, 0xff, 0xbb, 0x0a, 0x5b, 0x8b, 0x64, 0x8f, 0x2c, 0xc8,
-	0x33, 0x3d, 0xca, 0x1b, 0xdf, 0xbb, 0x57, 0x23, 0x47, 0x67, 0xe6, 0xeb, 0x9b, 0xd2, 0xc5, 0x57,
-	0x30, 0xff, 0x46, 0x06, 0x14, 0xc7, 0xe6, 0x29, 0x19, 0xd3, 0x86, 0xca, 0x73, 0x22, 0x4f, 0xae,
-	0xb3, 0xf6, 0x01, 0x47, 0x12, 0x09, 0x11, 0x09, 0x8b, 0x06, 0x50, 0x65, 0x4e, 0x8c, 0x0a, 0xd1,
-	0x49, 0xbf, 0xba, 0x93, 0x71, 0x95, 0xbd, 0xe9, 0x4c, 0x9c, 0x84, 0x69, 0x3e, 0x80, 0x6a, 0x62,
-	0xb1, 0x05, 0xf9, 0x8c, 0xad, 0x64, 0x3e, 0xa3, 0x92, 0x4c, 0x4e, 0x3c, 0x9a, 0xd7, 0x01, 0x93,
-	0x11, 0x33, 0x82, 0xbd, 0xa3, 0xfe, 0x40, 0xdc, 0x1c, 0x9f, 0xe0, 0xa3, 0x93, 0x63, 0x4d, 0x61,
-	0x8d, 0x83, 0x76, 0xff, 0xa9, 0x96, 0x8b, 0x6d, 0x44, 0xd5, 0x3b, 0x50, 0x4d, 0xf0, 0x95, 0xf2,
-	0xda, 0x4a, 0xda, 0x6b, 0x33, 0xbf, 0x69, 0x5a, 0x56, 0x40, 0x28, 0x95, 0x7c, 0x44, 0xa4, 0xfe,
-	0x02, 0x2a, 0xbb, 0xbd, 0xbe, 0x84, 0x68, 0x40, 0x89, 0x92, 0x80, 0xfd, 0x6f, 0x9e, 0x99, 0xaa,
-	0xe0, 0x88, 0x64, 0xe0, 0x94, 0x98, 0xc1, 0xf0, 0x8c, 0x50, 0x79, 0xd6, 0xc7, 0x34, 0x9b, 0xe5,
-	0xf1, 0x0c, 0x8f, 0xd0, 0x5d, 0x05, 0x47, 0xa4, 0xfe, 0x3f, 0x25, 0x80, 0x69, 0xb6, 0x01, 0xd5,
-	0x21, 0x17, 0xfb, 0xe0, 0x9c, 0x6d, 0x31, 0x3b, 0x48, 0x9c, 0x31, 0xfc, 0x1b, 0xed, 0xc0, 0x4d,
-	0x87, 0x8e, 0x7c, 0x73, 0x78, 0x6e, 0xc8, 0x24, 0x81, 0xd8, 0xaa, 0xdc, 0x9f, 0xd5, 0xf0, 0x0d,
-	0xd9, 0x29, 0x77, 0xa2, 0xc0, 0x3d, 0x00, 0x95, 0xb8, 0x17, 0xdc, 0xf7, 0x54, 0x77, 0x3e, 0x5b,
-	0x3a, 0x0b, 0xd2, 0xea, 0xba, 0x17, 0xc2, 0x56, 0x18, 0x0c, 0x32, 0x00, 0x2c, 0x72, 0x61, 0x0f,
-	0x89, 0xc1, 0x40, 0x0b, 0x1c, 0xf4, 0x8b, 0xe5, 0x41, 0x77, 0x39, 0x46, 0x0c, 0x5d, 0xb1, 0x22,
-	0x1a, 0xf5, 0xa0, 0x12, 0x10, 0xea, 0x4d, 0x82, 0x21, 0x11, 0x0e, 0x28, 0xfb, 0x45, 0x05, 0x47,
-	0xf3, 0xf0, 0x14, 0x02, 0xed, 0x42, 0x91, 0xfb, 0x1d, 0xe6, 0x61, 0xd4, 0xef, 0x4c, 0xa9, 0xa6,
-	0xc1, 0xb8, 0x27, 0xc1, 0x72, 0x2e, 0x7a, 0x02, 0x25, 0xc1, 0x22, 0x6d, 0x94, 0x39, 0xcc, 0x47,
-	0x59, 0x9d, 0x22, 0x9f, 0x85, 0xa3, 0xd9, 0x4c, 0xab, 0x13, 0x4a, 0x82, 0x46, 0x45, 0x68, 0x95,
-	0x7d, 0xa3, 0xf7, 0xa0, 0x22, 0xce, 0x60, 0xcb, 0x0e, 0x1a, 0x20, 0x8c, 0x93, 0x37, 0xec, 0xda,
-	0x01, 0x7a, 0x1f, 0xaa, 0x22, 0xd6, 0x32, 0xb8, 0x57, 0xa8, 0xf2, 0x6e, 0x10, 0x4d, 0xc7, 0xcc,
-	0x37, 0x88, 0x01, 0x24, 0x08, 0xc4, 0x80, 0x5a, 0x3c, 0x80, 0x04, 0x01, 0x1f, 0xf0, 0x07, 0xb0,
-	0xc1, 0x23, 0xd4, 0x51, 0xe0, 0x4d, 0x7c, 0x83, 0xdb, 0xd4, 0x3a, 0x1f, 0xb4, 0xce, 0x9a, 0x9f,
-	0xb0, 0xd6, 0x1e, 0x33, 0xae, 0x3b, 0x50, 0x7e, 0xe5, 0x9d, 0x8a, 0x01, 0x75, 0xb1, 0x0f, 0x5e,
-	0x79, 0xa7, 0x51, 0x57, 0x1c, 0x25, 0x6c, 0xa4, 0xa3, 0x84, 0x6f, 0xe0, 0xd6, 0xfc, 0x71, 0xc7,
-	0xa3, 0x05, 0xed, 0xfa, 0xd1, 0xc2, 0x96, 0xbb, 0xc8, 0x0f, 0x7f, 0x09, 0xaa, 0xe5, 0xd2, 0xc6,
-	0xe6, 0x52, 0xc6, 0x11, 0xef, 0x63, 0xcc, 0x26, 0x37, 0x3f, 0x81, 0x72, 0x64, 0x7d, 0xcb, 0xf8,
-	0xa5, 0xe6, 0x43, 0xa8, 0xa7, 0x6d, 0x77, 0x29, 0xaf, 0xf6, 0xcf, 0x39, 0xa8, 0xc4, 0x56, 0x8a,
-	0x5c, 0xb8, 0xc1, 0xa5, 0xc8, 0x42, 0x34, 0x63, 0x6a, 0xf4, 0x22, 0x30, 0xfc, 0x3c, 0xe3, 0xff,
-	0x6a, 0x47, 0x08, 0xf2, 0x86, 0x2a, 0x77, 0x00, 0x8a, 0x91, 0xa7, 0xeb, 0x7d, 0x0d, 0x1b, 0x63,
-	0xdb, 0x9d, 0x5c, 0x26, 0xd6, 0x12, 0x11, 0xdd, 0x1f, 0x66, 0x5c, 0xeb, 0x80, 0xcd, 0x9e, 0xae,
-	0x51, 0x1f, 0xa7, 0x68, 0xb4, 0x07, 0x05, 0xdf, 0x0b, 0xc2, 0xe8, 0x90, 0xca, 0x7a, 0x7c, 0x1c,
-	0x7b, 0x41, 0x78, 0x68, 0xfa, 0x3e, 0xbb, 0xb4, 0x08, 0x00, 0xfd, 0xdb, 0x1c, 0xdc, 0x5a, 0xfc,
-	0xc7, 0x50, 0x0f, 0xd4, 0xa1, 0x3f, 0x91, 0x42, 0x7a, 0xb8, 0xac, 0x90, 0x3a, 0xfe, 0x64, 0xca,
-	0x3f, 0x03, 0x42, 0xcf, 0xa0, 0xe8, 0x10, 0xc7, 0x0b, 0xae, 0xa4, 0x2c, 0x1e, 0x2d, 0x0b, 0x79,
-	0xc8, 0x67, 0x4f, 0x51, 0x25, 0x1c, 0xc2, 0x50, 0x96, 0xd6, 0x4b, 0xa5, 0x9f, 0x5c, 0x32, 0xad,
-	0x14, 0x41, 0xe2, 0x18, 0x47, 0xff, 0x04, 0x6e, 0x2e, 0xfc, 0x2b, 0xe8, 0x77, 0x01, 0x86, 0xfe,
-	0xc4, 0xe0, 0x69, 0x7f, 0x61, 0x41, 0x2a, 0xae, 0x0c, 0xfd, 0x49, 0x9f, 0x37, 0xe8, 0x2f, 0xa0,
-	0xf1, 0x26, 0x7e, 0x99, 0xf7, 0x11, 0x1c, 0x1b, 0xce, 0x29, 0x97, 0x81, 0x8a, 0xcb, 0xa2, 0xe1,
-	0xf0, 0x14, 0xe9, 0xb0, 0x1e, 0x75, 0x9a, 0x97, 0x6c, 0x80, 0xca, 0x07, 0x54, 0xe5, 0x00, 0xf3,
-	0xf2, 0xf0, 0x54, 0xff, 0x55, 0x0e, 0x36, 0x66, 0x58, 0x66, 0x57, 0x37, 0xe1, 0xf1, 0xa2, 0x4b,
-	0xb1, 0xa0, 0x98, 0xfb, 0x1b, 0xda, 0x56, 0x94, 0x4e, 0xe5, 0xdf, 0xfc, 0xe0, 0xf3, 0x65, 0xaa,
-	0x33, 0x67, 0xfb, 0x6c, 0xfb, 0x38, 0xa7, 0x76, 0x48, 0x79, 0x14, 0x52, 0xc0, 0x82, 0x40, 0xcf,
-	0xa1, 0x1e, 0x10, 0x7e, 0xe0, 0x5a, 0x86, 0xb0, 0xb2, 0xc2, 0x52, 0x56, 0x26, 0x39, 0x64, 0xc6,
-	0x86, 0xd7, 0x23, 0x24, 0x46, 0x51, 0xf4, 0x0c, 0xd6, 0xad, 0x2b, 0xd7, 0x74, 0xec, 0xa1, 0x44,
-	0x2e, 0xae, 0x8c, 0x5c, 0x93, 0x40, 0x1c, 0x58, 0x7f, 0x00, 0xd5, 0x44, 0x27, 0xfb, 0x63, 0x3c,
-	0xdc, 0x92, 0x32, 0x11, 0x44, 0xda, 0x5b, 0x14, 0xa4, 0xb7, 0xd0, 0x4f, 0xa1, 0x9a, 0xd8, 0x17,
-	0xcb, 0x4c, 0x65, 0xf2, 0x0c, 0x3d, 0x2e, 0xcf, 0x02, 0xce, 0x85, 0x1e, 0xba, 0x0d, 0x25, 0x16,
-	0xea, 0x18, 0xb6, 0xcf, 0x25, 0x5a, 0xc1, 0x45, 0x46, 0xee, 0xfb, 0xfa, 0x6f, 0x72, 0x50, 0x4f,
-	0x6f, 0xe9, 0xc8, 0x8e, 0x7c, 0x12, 0xd8, 0x9e, 0x95, 0xb0, 0xa3, 0x63, 0xde, 0xc0, 0x6c, 0x85,
-	0x75, 0x7f, 0x33, 0xf1, 0x42, 0x33, 0xb2, 0x95, 0xa1, 0x3f, 0xf9, 0x23, 0x46, 0xcf, 0xd8, 0xa0,
-	0x3a, 0x63, 0x83, 0xe8, 0x43, 0x40, 0xd2, 0x94, 0xc6, 0xb6, 0x63, 0x87, 0xc6, 0xe9, 0x55, 0x48,
-	0x84, 0x8e, 0x55, 0xac, 0x89, 0x9e, 0x03, 0xd6, 0xf1, 0x25, 0x6b, 0x67, 0x86, 0xe7, 0x79, 0x8e,
-	0x41, 0x87, 0x5e, 0x40, 0x0c, 0xd3, 0x7a, 0xc5, 0x6f, 0x2d, 0x2a, 0xae, 0x7a, 0x9e, 0xd3, 0x67,
-	0x6d, 0x6d, 0xeb, 0x15, 0x3b, 0xf9, 0x86, 0xfe, 0x84, 0x92, 0xd0, 0x60, 0x3f, 0x3c, 0x58, 0xa8,
-	0x60, 0x10, 0x4d, 0x1d, 0x7f, 0x42, 0xd1, 0xef, 0xc3, 0x7a, 0x34, 0x80, 0x1f, 0x7e, 0xf2, 0xd4,
-	0xad, 0xc9, 0x21, 0xbc, 0x0d, 0xe9, 0x50, 0x3b, 0x26, 0xc1, 0x90, 0xb8, 0xe1, 0xc0, 0x1e, 0x9e,
-	0xb3, 0xf3, 0x5d, 0xd9, 0x56, 0x70, 0xaa, 0xed, 0xab, 0x7c, 0xb9, 0xa4, 0x95, 0x71, 0xb4, 0x9a,
-	0x43, 0x1c, 0xaa, 0xff, 0x0c, 0x0a, 0x3c, 0x44, 0x60, 0x32, 0xe1, 0xc7, 0x2b, 0x3f, 0x7d, 0x65,
-	0x68, 0xc9, 0x1a, 0xf8, 0xd9, 0xfb, 0x1e, 0x54, 0xb8, 0xec, 0x13, 0x11, 0x3d, 0x8f, 0x3b, 0x79,
-	0x67, 0x13, 0xca, 0x01, 0x31, 0x2d, 0xcf, 0x1d, 0x47, 0xc9, 0xa0, 0x98, 0xd6, 0xbf, 0x81, 0xa2,
-	0x38, 0x67, 0xae, 0x81, 0xff, 0x11, 0x20, 0xf1, 0xbf, 0x99, 0x3e, 0x1d, 0x9b, 0x52, 0x19, 0x85,
-	0xf2, 0x17, 0x48, 0xd1, 0x73, 0x3c, 0xed, 0xd0, 0xff, 0x53, 0x11, 0xf1, 0xa8, 0x78, 0x1b, 0x62,
-	0x81, 0x2b, 0x33, 0x72, 0x76, 0x5b, 0x16, 0x49, 0xa8, 0x88, 0x44, 0xfb, 0x50, 0x94, 0x61, 0x67,
-	0x6e, 0xd5, 0xa7, 0x35, 0x09, 0x10, 0xa5, 0xa4, 0x89, 0xbc, 0x90, 0x2f, 0x9b, 0x92, 0x26, 0x22,
-	0x25, 0x4d, 0xd8, 0x6d, 0x52, 0x06, 0xc4, 0x02, 0x2e, 0xcf, 0xe3, 0xe1, 0xaa, 0x15, 0xe7, 0xfd,
-	0x89, 0xfe, 0xdf, 0x4a, 0xec, 0xa6, 0xa2, 0xfc, 0x3c, 0xfa, 0x1a, 0xca, 0x6c, 0xc7, 0x1b, 0x8e,
-	0xe9, 0xcb, 0xd7, 0xe6, 0xce, 0x6a, 0xa9, 0xff, 0xe8, 0x10, 0x13, 0xe1, 0x6c, 0xc9, 0x17, 0x14,
-	0x73, 0x77, 0xec, 0x2a, 0x11, 0xb9, 0x3b, 0xf6, 0x8d, 0x3e, 0x80, 0xba, 0x39, 0x09, 0x3d, 0xc3,
-	0xb4, 0x2e, 0x48, 0x10, 0xda, 0x94, 0x48, 0xdd, 0xaf, 0xb3, 0xd6, 0x76, 0xd4, 0xd8, 0xfc, 0x0c,
-	0x6a, 0x49, 0xcc, 0xb7, 0x85, 0x19, 0x85, 0x64, 0x98, 0xf1, 0xa7, 0x00, 0xd3, 0x5c, 0x17, 0xb3,
-	0x11, 0x72, 0x69, 0xb3, 0x5b, 0xbd, 0xbc, 0xbb, 0x16, 0x70, 0x99, 0x35, 0x74, 0xd8, 0x7d, 0x2a,
-	0x9d, 0x88, 0x2f, 0x44, 0x89, 0x78, 0xb6, 0x99, 0xd9, 0xfe, 0x3b, 0xb7, 0xc7, 0xe3, 0x38, 0xff,
-	0x56, 0xf1, 0x3c, 0xe7, 0x29, 0x6f, 0xd0, 0x7f, 0x9b, 0x13, 0xb6, 0x22, 0x9e, 0x54, 0x32, 0xdd,
-	0x5d, 0xde, 0x95, 0xaa, 0x1f, 0x00, 0xd0, 0xd0, 0x0c, 0x58, 0xcc, 0x64, 0x46, 0x19, 0xc0, 0xe6,
-	0x5c, 0x26, 0x7f, 0x10, 0xd5, 0x78, 0xe0, 0x8a, 0x1c, 0xdd, 0x0e, 0xd1, 0xe7, 0x50, 0x1b, 0x7a,
-	0x8e, 0x3f, 0x26, 0x72, 0x72, 0xe1, 0xad, 0x93, 0xab, 0xf1, 0xf8, 0x76, 0x98, 0xc8, 0x3b, 0x16,
-	0xaf, 0x9b, 0x77, 0xfc, 0x8d, 0x22, 0x5e, 0x86, 0x92, 0x0f, 0x53, 0x68, 0xb4, 0xa0, 0xfa, 0xe1,
-	0xc9, 0x8a, 0xaf, 0x5c, 0xdf, 0x55, 0xfa, 0xd0, 0xfc, 0x3c, 0x4b, 0xad, 0xc1, 0x9b, 0xa3, 0xd8,
-	0x7f, 0x53, 0xa1, 0x12, 0x3f, 0x0a, 0xcd, 0xe9, 0xfe, 0x53, 0xa8, 0xc4, 0x05, 0x36, 0xd2, 0x41,
-	0x7c, 0xa7, 0x7a, 0xe2, 0xc1, 0xe8, 0x25, 0x20, 0x73, 0x34, 0x8a, 0xa3, 0x53, 0x63, 0x42, 0xcd,
-	0x51, 0xf4, 0x24, 0xf7, 0xe9, 0x12, 0x72, 0x88, 0x8e, 0xb3, 0x13, 0x36, 0x1f, 0x6b, 0xe6, 0x68,
-	0x94, 0x6a, 0x41, 0x7f, 0x06, 0x37, 0xd3, 0x6b, 0x18, 0xa7, 0x57, 0x86, 0x6f, 0x5b, 0xf2, 0x8e,
-	0xbc, 0xb7, 0xec, 0xbb, 0x58, 0x2b, 0x05, 0xff, 0xe5, 0xd5, 0xb1, 0x6d, 0x09, 0x99, 0xa3, 0x60,
-	0xae, 0xa3, 0xf9, 0x17, 0x70, 0xfb, 0x0d, 0xc3, 0x17, 0xe8, 0xa0, 0x97, 0xae, 0xf7, 0x58, 0x5d,
-	0x08, 0x09, 0xed, 0xfd, 0x5a, 0x11, 0xcf, 0x77, 0x69, 0x99, 0xb4, 0x93, 0x61, 0xf5, 0xbd, 0x8c,
-	0xeb, 0x74, 0x8e, 0x4f, 0x04, 0x3c, 0x8f, 0xa4, 0xbf, 0x9a, 0x89, 0xa4, 0xb3, 0xc6, 0x4f, 0x22,
-	0x20, 0x15, 0x40, 0x12, 0x41, 0xff, 0x17, 0x15, 0xca, 0x11, 0x3a, 0xbf, 0xe1, 0x5e, 0xd1, 0x90,
-	0x38, 0x46, 0x9c, 0x7e, 0x53, 0x30, 0x88, 0x26, 0x9e, 0x14, 0x7a, 0x0f, 0x2a, 0xec, 0x22, 0x2d,
-	0xba, 0x73, 0xbc, 0xbb, 0xcc, 0x1a, 0x78, 0xe7, 0xfb, 0x50, 0x0d, 0xbd, 0xd0, 0x1c, 0x1b, 0x21,
-	0x3f, 0xde, 0x55, 0x31, 0x9b, 0x37, 0xf1, 0xc3, 0x1d, 0xfd, 0x00, 0x36, 0xc3, 0xb3, 0xc0, 0x0b,
-	0xc3, 0x31, 0x0b, 0x2d, 0x79, 0xa0, 0x23, 0xe2, 0x92, 0x3c, 0xd6, 0xe2, 0x0e, 0x11, 0x00, 0x51,
-	0xe6, 0xbd, 0xa7, 0x83, 0x99, 0xe9, 0x72, 0x27, 0x92, 0xc7, 0xeb, 0x71, 0x2b, 0x33, 0x6d, 0x76,
-	0x78, 0xfa, 0x22, 0x80, 0xe0, 0xbe, 0x42, 0xc1, 0x11, 0x89, 0x0c, 0xd8, 0x70, 0x88, 0x49, 0x27,
-	0x01, 0xb1, 0x8c, 0x97, 0x36, 0x19, 0x5b, 0x22, 0x31, 0x51, 0xcf, 0x7c, 0x3b, 0x88, 0xc4, 0xd2,
-	0x7a, 0xcc, 0x67, 0xe3, 0x7a, 0x04, 0x27, 0x68, 0x16, 0x39, 0x88, 0x2f, 0xb4, 0x01, 0xd5, 0xfe,
-	0xf3, 0xfe, 0xa0, 0x7b, 0x68, 0x1c, 0x1e, 0xed, 0x76, 0x65, 0x49, 0x4f, 0xbf, 0x8b, 0x05, 0xa9,
-	0xb0, 0xfe, 0xc1, 0xd1, 0xa0, 0x7d, 0x60, 0x0c, 0xf6, 0x3b, 0x4f, 0xfb, 0x5a, 0x0e, 0xdd, 0x84,
-	0xcd, 0xc1, 0x1e, 0x3e, 0x1a, 0x0c, 0x0e, 0xba, 0xbb, 0xc6, 0x71, 0x17, 0xef, 0x1f, 0xed, 0xf6,
-	0x35, 0x15, 0x21, 0xa8, 0x4f, 0x9b, 0x07, 0xfb, 0x87, 0x5d, 0x2d, 0x8f, 0xaa, 0x50, 0x3a, 0xee,
-	0xe2, 0x4e, 0xb7, 0x37, 0xd0, 0x0a, 0xfa, 0xaf, 0x54, 0xa8, 0x26, 0xb4, 0xc8, 0x0c, 0x39, 0xa0,
-	0xe2, 0x1a, 0x92, 0xc7, 0xec, 0x93, 0x3f, 0x41, 0x9a, 0xc3, 0x33, 0xa1, 0x9d, 0x3c, 0x16, 0x04,
-	0xbf, 0x7a, 0x98, 0x97, 0x89, 0x7d, 0x9e, 0xc7, 0x65, 0xc7, 0xbc, 0x14, 0x20, 0xdf, 0x83, 0xda,
-	0x39, 0x09, 0x5c, 0x32, 0x96, 0xfd, 0x42, 0x23, 0x55, 0xd1, 0x26, 0x86, 0x6c, 0x83, 0x26, 0x87,
-	0x4c, 0x61, 0x84, 0x3a, 0xea, 0xa2, 0xfd, 0x30, 0x02, 0xdb, 0x82, 0x82, 0xe8, 0x2e, 0x89, 0xf5,
-	0x39, 0xc1, 0x8e, 0x29, 0xfa, 0xda, 0xf4, 0x79, 0xc8, 0x97, 0xc7, 0xfc, 0x1b, 0x9d, 0xce, 0xeb,
-	0xa7, 0xc8, 0xf5, 0xf3, 0x60, 0x79, 0x73, 0x7e, 0x93, 0x8a, 0xce, 0x62, 0x15, 0x95, 0x40, 0xc5,
-	0x51, 0x1d, 0x4c, 0xa7, 0xdd, 0xd9, 0x63, 0x6a, 0x59, 0x87, 0xca, 0x61, 0xfb, 0xa7, 0xc6, 0x49,
-	0x9f, 0x67, 0xb5, 0x91, 0x06, 0xb5, 0xa7, 0x5d, 0xdc, 0xeb, 0x1e, 0xc8, 0x16, 0x15, 0x6d, 0x81,
-	0x26, 0x5b, 0xa6, 0xe3, 0xf2, 0x0c, 0x41, 0x7c, 0x16, 0x50, 0x19, 0xf2, 0xfd, 0x67, 0xed, 0x63,
-	0xad, 0xa8, 0xff, 0x57, 0x0e, 0x36, 0xc4, 0xb1, 0x10, 0xbf, 0xd8, 0xbf, 0xf9, 0xc5, 0x32, 0x99,
-	0xe5, 0xc9, 0xa5, 0xb3, 0x3c, 0x51, 0x10, 0xca, 0x4f, 0x75, 0x75, 0x1a, 0x84, 0xf2, 0xec, 0x50,
-	0xca, 0xe3, 0xe7, 0x97, 0xf1, 0xf8, 0x0d, 0x28, 0x39, 0x84, 0xc6, 0x7a, 0xab, 0xe0, 0x88, 0x44,
-	0x36, 0x54, 0x4d, 0xd7, 0xf5, 0x42, 0x53, 0xa4, 0x4e, 0x8b, 0x4b, 0x1d, 0x86, 0x33, 0xff, 0xb8,
-	0xd5, 0x9e, 0x22, 0x09, 0xc7, 0x9c, 0xc4, 0x6e, 0xfe, 0x04, 0xb4, 0xd9, 0x01, 0xcb, 0x1c, 0x87,
-	0xdf, 0xff, 0xe1, 0xf4, 0x34, 0x24, 0x6c, 0x5f, 0xc8, 0x37, 0x07, 0x6d, 0x8d, 0x11, 0xf8, 0xa4,
-	0xd7, 0xdb, 0xef, 0x3d, 0xd1, 0x14, 0x04, 0x50, 0xec, 0xfe, 0x74, 0x7f, 0xd0, 0xdd, 0xd5, 0x72,
-	0x3b, 0xbf, 0xde, 0x84, 0xa2, 0x60, 0x12, 0x7d, 0x2b, 0x23, 0x81, 0x64, 0x35, 0x28, 0xfa, 0xc9,
-	0xd2, 0x11, 0x75, 0xaa, 0xc2, 0xb4, 0xf9, 0x68, 0xe5, 0xf9, 0xf2, 0xf5, 0x6d, 0x0d, 0xfd, 0x8d,
-	0x02, 0xb5, 0xd4, 0xcb, 0x5b, 0xd6, 0xd4, 0xf1, 0x82, 0xe2, 0xd3, 0xe6, 0x8f, 0x57, 0x9a, 0x1b,
-	0xf3, 0xf2, 0x4b, 0x05, 0xaa, 0x89, 0xb2, 0x4b, 0xf4, 0x60, 0x95, 0x52, 0x4d, 0xc1, 0xc9, 0x67,
-	0xab, 0x57, 0x79, 0xea, 0x6b, 0x1f, 0x2b, 0xe8, 0xaf, 0x15, 0xa8, 0x26, 0x0a, 0x10, 0x33, 0xb3,
-	0x32, 0x5f, 0x2e, 0x99, 0x99, 0x95, 0x45, 0xf5, 0x8e, 0x6b, 0xe8, 0x2f, 0x15, 0xa8, 0xc4, 0xc5,
-	0x84, 0xe8, 0xfe, 0xf2, 0xe5, 0x87, 0x82, 0x89, 0x4f, 0x57, 0xad, 0x5b, 0xd4, 0xd7, 0xd0, 0x9f,
-	0x43, 0x39, 0xaa, 0xbc, 0x43, 0x59, 0x4f, 0xaf, 0x99, 0xb2, 0xbe, 0xe6, 0xfd, 0xa5, 0xe7, 0x25,
-	0x97, 0x8f, 0xca, 0xe1, 0x32, 0x2f, 0x3f, 0x53, 0xb8, 0xd7, 0xbc, 0xbf, 0xf4, 0xbc, 0x78, 0x79,
-	0x66, 0x09, 0x89, 0xaa, 0xb9, 0xcc, 0x96, 0x30, 0x5f, 0xae, 0x97, 0xd9, 0x12, 0x16, 0x15, 0xe9,
-	0x09, 0x46, 0x12, 0x75, 0x77, 0x99, 0x19, 0x99, 0xaf, 0xed, 0xcb, 0xcc, 0xc8, 0x82, 0x32, 0x3f,
-	0x7d, 0x0d, 0xfd, 0x42, 0x49, 0xde, 0x0b, 0xee, 0x2f, 0x5d, 0x5e, 0xb6, 0xa4, 0x49, 0xce, 0x15,
-	0xb8, 0xf1, 0x0d, 0xfa, 0x0b, 0x99, 0xc5, 0x10, 0xd5, 0x69, 0x68, 0x19, 0xb0, 0x54, 0x41, 0x5b,
-	0xf3, 0x93, 0xd5, 0x0e, 0x1b, 0xce, 0xc4, 0x5f, 0x29, 0x00, 0xd3, 0x3a, 0xb6, 0xcc, 0x4c, 0xcc,
-	0x15, 0xd0, 0x35, 0x1f, 0xac, 0x30, 0x33, 0xb9, 0x41, 0xa2, 0x3a, 0x9b, 0xcc, 0x1b, 0x64, 0xa6,
-	0xce, 0x2e, 0xf3, 0x06, 0x99, 0xad, 0x91, 0xd3, 0xd7, 0xd0, 0x3f, 0x29, 0xb0, 0x39, 0x57, 0xe7,
-	0x83, 0x1e, 0x5d, 0xb3, 0xd4, 0xab, 0xf9, 0xc5, 0xea, 0x00, 0x11, 0x6b, 0xdb, 0xca, 0xc7, 0x0a,
-	0xfa, 0x5b, 0x05, 0xd6, 0xd3, 0xf5, 0x0f, 0x99, 0x4f, 0xa9, 0x05, 0x15, 0x43, 0xcd, 0x87, 0xab,
-	0x4d, 0x8e, 0xa5, 0xf5, 0xf7, 0x0a, 0xd4, 0xd3, 0xa5, 0x30, 0xe8, 0xe1, 0x72, 0x6e, 0x61, 0x86,
-	0xa1, 0xcf, 0x57, 0x9c, 0x1d, 0x71, 0xf4, 0x65, 0xe9, 0x8f, 0x0b, 0x22, 0x7a, 0x2b, 0xf2, 0x9f,
-	0x1f, 0xfd, 0x7f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x76, 0x8b, 0xb6, 0x52, 0xb4, 0x33, 0x00, 0x00,
+	// 3867 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x5f, 0x6f, 0x1b, 0x49,
+	0x72, 0xf7, 0x70, 0x48, 0x8a, 0x2c, 0x4a, 0xd4, 0xa8, 0x2d, 0x7b, 0xb9, 0xdc, 0xe4, 0xd6, 0x37,
+	0xc1, 0x26, 0xc2, 0xdd, 0x2e, 0xbd, 0xa7, 0x4b, 0xd6, 0x6b, 0x9f, 0xf7, 0xbc, 0x5c, 0x8a, 0x96,
+	0xb4, 0x96, 0x28, 0xa5, 0x49, 0xc1, 0xe7, 0x38, 0xb7, 0x93, 0xd1, 0x4c, 0x9b, 0x1a, 0x8b, 0x9c,
+	0x99, 0x9d, 0x6e, 0xca, 0xd2, 0x05, 0x41, 0x82, 0x0b, 0x10, 0x5c, 0x80, 0x04, 0xc9, 0xcb, 0xe6,
+	0x5e, 0xf2, 0x74, 0x40, 0x9e, 0xf2, 0x05, 0x82, 0x0b, 0x0e, 0x08, 0x90, 0x87, 0x7c, 0x89, 0xbc,
+	0xe4, 0x29, 0x79, 0xcd, 0x37, 0x38, 0xf4, 0x9f, 0x19, 0xce, 0x88, 0xf2, 0x7a, 0x48, 0xf9, 0x89,
+	0x53, 0xd5, 0xdd, 0xbf, 0x2e, 0x76, 0x57, 0x57, 0x55, 0x57, 0x17, 0x98, 0xe1, 0x68, 0x32, 0xf4,
+	0x7c, 0x7a, 0xd7, 0x8d, 0xbc, 0x33, 0x12, 0xd1, 0xbb, 0x61, 0x14, 0xb0, 0x40, 0x51, 0x2d, 0x41,
+	0xa0, 0x0f, 0x4e, 0x6c, 0x7a, 0xe2, 0x39, 0x41, 0x14, 0xb6, 0xfc, 0x60, 0x6c, 0xbb, 0x2d, 0x35,
+	0xa6, 0xa5, 0xc6, 0xc8, 0x6e, 0xcd, 0xef, 0x0c, 0x83, 0x60, 0x38, 0x22, 0x12, 0xe1, 0x78, 0xf2,
+	0xe2, 0xae, 0x3b, 0x89, 0x6c, 0xe6, 0x05, 0xbe, 0x6a, 0x7f, 0xff, 0x72, 0x3b, 0xf3, 0xc6, 0x84,
+	0x32, 0x7b, 0x1c, 0xaa, 0x0e, 0x1f, 0xc4, 0xb2, 0xd0, 0x13, 0x3b, 0x22, 0xee, 0xdd, 0x13, 0x67,
+	0x44, 0x43, 0xe2, 0xf0, 0x5f, 0x8b, 0x7f, 0xa8, 0x6e, 0x1f, 0x5e, 0xea, 0x46, 0x59, 0x34, 0x71,
+	0x58, 0x2c, 0xb9, 0xcd, 0x58, 0xe4, 0x1d, 0x4f, 0x18, 0x91, 0xbd, 0xcd, 0x77, 0xe1, 0x9d, 0x81,
+	0x4d, 0x4f, 0x3b, 0x81, 0xff, 0xc2, 0x1b, 0xf6, 0x9d, 0x13, 0x32, 0xb6, 0x31, 0xf9, 0x7a, 0x42,
+	0x28, 0x33, 0xff, 0x14, 0x1a, 0xb3, 0x4d, 0x34, 0x0c, 0x7c, 0x4a, 0xd0, 0xe7, 0x50, 0xe4, 0x53,
+	0x36, 0xb4, 0x3b, 0xda, 0x46, 0x6d, 0xf3, 0xc3, 0xd6, 0xeb, 0x96, 0x40, 0xca, 0xd0, 0x52, 0xa2,
+	0xb6, 0xfa, 0x21, 0x71, 0xb0, 0x18, 0x69, 0xde, 0x82, 0x9b, 0x1d, 0x3b, 0xb4, 0x8f, 0xbd, 0x91,
+	0xc7, 0x3c, 0x42, 0xe3, 0x49, 0x27, 0xb0, 0x9e, 0x65, 0xab, 0x09, 0x7f, 0x0a, 0xcb, 0x4e, 0x8a,
+	0xaf, 0x26, 0xbe, 0xdf, 0xca, 0xb5, 0xf6, 0xad, 0x2d, 0x41, 0x65, 0x80, 0x33, 0x70, 0xe6, 0x3a,
+	0xa0, 0xc7, 0x9e, 0x3f, 0x24, 0x51, 0x18, 0x79, 0x3e, 0x8b, 0x85, 0xf9, 0x8d, 0x0e, 0x37, 0x33,
+	0x6c, 0x25, 0xcc, 0x4b, 0x80, 0x64, 0x1d, 0xb9, 0x28, 0xfa, 0x46, 0x6d, 0xf3, 0xcb, 0x9c, 0xa2,
+	0x5c, 0x81, 0xd7, 0x6a, 0x27, 0x60, 0x5d, 0x9f, 0x45, 0x17, 0x38, 0x85, 0x8e, 0xbe, 0x82, 0xf2,
+	0x09, 0xb1, 0x47, 0xec, 0xa4, 0x51, 0xb8, 0xa3, 0x6d, 0xd4, 0x37, 0x1f, 0x5f, 0x63, 0x9e, 0x1d,
+	0x01, 0xd4, 0x67, 0x36, 0x23, 0x58, 0xa1, 0xa2, 0x8f, 0x00, 0xc9, 0x2f, 0xcb, 0x25, 0xd4, 0x89,
+	0xbc, 0x90, 0xab, 0x64, 0x43, 0xbf, 0xa3, 0x6d, 0x54, 0xf1, 0x9a, 0x6c, 0xd9, 0x9a, 0x36, 0x34,
+	0x43, 0x58, 0xbd, 0x24, 0x2d, 0x32, 0x40, 0x3f, 0x25, 0x17, 0x62, 0x47, 0xaa, 0x98, 0x7f, 0xa2,
+	0x6d, 0x28, 0x9d, 0xd9, 0xa3, 0x09, 0x11, 0x22, 0xd7, 0x36, 0x7f, 0xf0, 0x26, 0xf5, 0x50, 0x2a,
+	0x3a, 0x5d, 0x07, 0x2c, 0xc7, 0x3f, 0x28, 0x7c, 0xaa, 0x99, 0xf7, 0xa1, 0x96, 0x92, 0x1b, 0xd5,
+	0x01, 0x8e, 0x7a, 0x5b, 0xdd, 0x41, 0xb7, 0x33, 0xe8, 0x6e, 0x19, 0x37, 0xd0, 0x0a, 0x54, 0x8f,
+	0x7a, 0x3b, 0xdd, 0xf6, 0xde, 0x60, 0xe7, 0x99, 0xa1, 0xa1, 0x1a, 0x2c, 0xc5, 0x44, 0xc1, 0x3c,
+	0x07, 0x84, 0x89, 0x13, 0x9c, 0x91, 0x88, 0x2b, 0xb2, 0xda, 0x55, 0xf4, 0x0e, 0x2c, 0x31, 0x9b,
+	0x9e, 0x5a, 0x9e, 0xab, 0x64, 0x2e, 0x73, 0x72, 0xd7, 0x45, 0xbb, 0x50, 0x3e, 0xb1, 0x7d, 0x77,
+	0xf4, 0x66, 0xb9, 0xb3, 0x4b, 0xcd, 0xc1, 0x77, 0xc4, 0x40, 0xac, 0x00, 0xb8, 0x76, 0x67, 0x66,
+	0x96, 0x1b, 0x60, 0x3e, 0x03, 0xa3, 0xcf, 0xec, 0x88, 0xa5, 0xc5, 0xe9, 0x42, 0x91, 0xcf, 0xdf,
+	0xd0, 0xe6, 0x9e, 0x53, 0x9e, 0x4c, 0x2c, 0x86, 0x9b, 0xff, 0x5f, 0x80, 0xb5, 0x14, 0xb6, 0xd2,
+	0xd4, 0xa7, 0x50, 0x8e, 0x08, 0x9d, 0x8c, 0x98, 0x80, 0xaf, 0x6f, 0x3e, 0xca, 0x09, 0x3f, 0x83,
+	0xd4, 0xc2, 0x02, 0x06, 0x2b, 0x38, 0xb4, 0x01, 0x86, 0x1c, 0x61, 0x91, 0x28, 0x0a, 0x22, 0x6b,
+	0x4c, 0x87, 0x62, 0xd5, 0xaa, 0xb8, 0x2e, 0xf9, 0x5d, 0xce, 0xde, 0xa7, 0xc3, 0xd4, 0xaa, 0xea,
+	0xd7, 0x5c, 0x55, 0x64, 0x83, 0xe1, 0x13, 0xf6, 0x2a, 0x88, 0x4e, 0x2d, 0xbe, 0xb4, 0x91, 0xe7,
+	0x92, 0x46, 0x51, 0x80, 0x7e, 0x92, 0x13, 0xb4, 0x27, 0x87, 0x1f, 0xa8, 0xd1, 0x78, 0xd5, 0xcf,
+	0x32, 0xcc, 0xef, 0x43, 0x59, 0xfe, 0x53, 0xae, 0x49, 0xfd, 0xa3, 0x4e, 0xa7, 0xdb, 0xef, 0x1b,
+	0x37, 0x50, 0x15, 0x4a, 0xb8, 0x3b, 0xc0, 0x5c, 0xc3, 0xaa, 0x50, 0x7a, 0xdc, 0x1e, 0xb4, 0xf7,
+	0x8c, 0x82, 0xf9, 0x3d, 0x58, 0x7d, 0x6a, 0x7b, 0x2c, 0x8f, 0x72, 0x99, 0x01, 0x18, 0xd3, 0xbe,
+	0x6a, 0x77, 0x76, 0x33, 0xbb, 0x93, 0x7f, 0x69, 0xba, 0xe7, 0x1e, 0xbb, 0xb4, 0x1f, 0x06, 0xe8,
+	0x24, 0x8a, 0xd4, 0x16, 0xf0, 0x4f, 0xf3, 0x15, 0xac, 0xf6, 0x59, 0x10, 0xe6, 0xd2, 0xfc, 0x1f,
+	0xc2, 0x12, 0xf7, 0x36, 0xc1, 0x84, 0x29, 0xd5, 0x7f, 0xb7, 0x25, 0xbd, 0x51, 0x2b, 0xf6, 0x46,
+	0xad, 0x2d, 0xe5, 0xad, 0x70, 0xdc, 0x13, 0xdd, 0x86, 0x32, 0xf5, 0x86, 0xbe, 0x3d, 0x52, 0xd6,
+	0x42, 0x51, 0x26, 0x02, 0x63, 0x3a, 0xb1, 0x52, 0xfc, 0x0e, 0xa0, 0x2d, 0x42, 0x59, 0x14, 0x5c,
+	0xe4, 0x92, 0x67, 0x1d, 0x4a, 0x2f, 0x82, 0xc8, 0x91, 0x07, 0xb1, 0x82, 0x25, 0xc1, 0x0f, 0x55,
+	0x06, 0x44, 0x61, 0x7f, 0x04, 0x68, 0xd7, 0xe7, 0x3e, 0x25, 0xdf, 0x46, 0xfc, 0x63, 0x01, 0x6e,
+	0x66, 0xfa, 0xab, 0xcd, 0x58, 0xfc, 0x1c, 0x72, 0xc3, 0x34, 0xa1, 0xf2, 0x1c, 0xa2, 0x03, 0x28,
+	0xcb, 0x1e, 0x6a, 0x25, 0xef, 0xcd, 0x01, 0x24, 0xdd, 0x94, 0x82, 0x53, 0x30, 0x57, 0x2a, 0xbd,
+	0xfe, 0x76, 0x95, 0xfe, 0x15, 0x18, 0xf1, 0xff, 0xa0, 0x6f, 0xdc, 0x9b, 0x2f, 0xe1, 0xa6, 0x13,
+	0x8c, 0x46, 0xc4, 0xe1, 0xda, 0x60, 0x79, 0x3e, 0x23, 0xd1, 0x99, 0x3d, 0x7a, 0xb3, 0xde, 0xa0,
+	0xe9, 0xa8, 0x5d, 0x35, 0xc8, 0x7c, 0x0e, 0x6b, 0xa9, 0x89, 0xd5, 0x46, 0x3c, 0x86, 0x12, 0xe5,
+	0x0c, 0xb5, 0x13, 0x1f, 0xcf, 0xb9, 0x13, 0x14, 0xcb, 0xe1, 0xe6, 0x4d, 0x09, 0xde, 0x3d, 0x23,
+	0x7e, 0xf2, 0xb7, 0xcc, 0x2d, 0x58, 0xeb, 0x0b, 0x35, 0xcd, 0xa5, 0x87, 0x53, 0x15, 0x2f, 0x64,
+	0x54, 0x7c, 0x1d, 0x50, 0x1a, 0x45, 0x29, 0xe2, 0x05, 0xac, 0x76, 0xcf, 0x89, 0x93, 0x0b, 0xb9,
+	0x01, 0x4b, 0x4e, 0x30, 0x1e, 0xdb, 0xbe, 0xdb, 0x28, 0xdc, 0xd1, 0x37, 0xaa, 0x38, 0x26, 0xd3,
+	0x67, 0x51, 0xcf, 0x7b, 0x16, 0xcd, 0xbf, 0xd7, 0xc0, 0x98, 0xce, 0xad, 0x16, 0x92, 0x4b, 0xcf,
+	0x5c, 0x0e, 0xc4, 0xe7, 0x5e, 0xc6, 0x8a, 0x52, 0xfc, 0xd8, 0x5c, 0x48, 0x3e, 0x89, 0xa2, 0x94,
+	0x39, 0xd2, 0xaf, 0x69, 0x8e, 0xcc, 0x1d, 0xf8, 0x9d, 0x58, 0x9c, 0x3e, 0x8b, 0x88, 0x3d, 0xf6,
+	0xfc, 0xe1, 0xee, 0xc1, 0x41, 0x48, 0xa4, 0xe0, 0x08, 0x41, 0xd1, 0xb5, 0x99, 0xad, 0x04, 0x13,
+	0xdf, 0xfc, 0xd0, 0x3b, 0xa3, 0x80, 0x26, 0x87, 0x5e, 0x10, 0xe6, 0x7f, 0xe9, 0xd0, 0x98, 0x81,
+	0x8a, 0x97, 0xf7, 0x39, 0x94, 0x28, 0x61, 0x93, 0x50, 0xa9, 0x4a, 0x37, 0xb7, 0xc0, 0x57, 0xe3,
+	0xb5, 0xfa, 0x1c, 0x0c, 0x4b, 0x4c, 0x34, 0x84, 0x0a, 0x63, 0x17, 0x16, 0xf5, 0x7e, 0x16, 0x07,
+	0x04, 0x7b, 0xd7, 0xc5, 0x1f, 0x90, 0x68, 0xec, 0xf9, 0xf6, 0xa8, 0xef, 0xfd, 0x8c, 0xe0, 0x25,
+	0xc6, 0x2e, 0xf8, 0x07, 0x7a, 0xc6, 0x15, 0xde, 0xf5, 0x7c, 0xb5, 0xec, 0x9d, 0x45, 0x67, 0x49,
+	0x2d, 0x30, 0x96, 0x88, 0xcd, 0x3d, 0x28, 0x89, 0xff, 0xb4, 0x88, 0x22, 0x1a, 0xa0, 0x33, 0x76,
+	0x21, 0x84, 0xaa, 0x60, 0xfe, 0xd9, 0x7c, 0x08, 0xcb, 0xe9, 0x7f, 0xc0, 0x15, 0xe9, 0x84, 0x78,
+	0xc3, 0x13, 0xa9, 0x60, 0x25, 0xac, 0x28, 0xbe, 0x93, 0xaf, 0x3c, 0x57, 0x85, 0xac, 0x25, 0x2c,
+	0x09, 0xf3, 0xdf, 0x0a, 0xf0, 0xee, 0x15, 0x2b, 0xa3, 0x94, 0xf5, 0x79, 0x46, 0x59, 0xdf, 0xd2,
+	0x2a, 0xc4, 0x1a, 0xff, 0x3c, 0xa3, 0xf1, 0x6f, 0x11, 0x9c, 0x1f, 0x9b, 0xdb, 0x50, 0x26, 0xe7,
+	0x1e, 0x23, 0xae, 0x5a, 0x2a, 0x45, 0xa5, 0x8e, 0x53, 0xf1, 0xba, 0xc7, 0x69, 0x1f, 0xd6, 0x3b,
+	0x11, 0xb1, 0x19, 0x51, 0xa6, 0x3c, 0xd6, 0xff, 0x77, 0xa1, 0x62, 0x8f, 0x46, 0x81, 0x33, 0xdd,
+	0xd6, 0x25, 0x41, 0xef, 0xba, 0xa8, 0x09, 0x95, 0x93, 0x80, 0x32, 0xdf, 0x1e, 0x13, 0x65, 0xbc,
+	0x12, 0xda, 0xfc, 0x46, 0x83, 0x5b, 0x97, 0xf0, 0xd4, 0x2e, 0x1c, 0x43, 0xdd, 0xa3, 0xc1, 0x48,
+	0xfc, 0x41, 0x2b, 0x75, 0xc3, 0xfb, 0xd1, 0x7c, 0xae, 0x66, 0x37, 0xc6, 0x10, 0x17, 0xbe, 0x15,
+	0x2f, 0x4d, 0x0a, 0x8d, 0x13, 0x93, 0xbb, 0xea, 0xa4, 0xc7, 0xa4, 0xf9, 0x4f, 0x1a, 0xdc, 0x52,
+	0x1e, 0x3e, 0xff, 0x1f, 0x9d, 0x15, 0xb9, 0xf0, 0xb6, 0x45, 0x36, 0x1b, 0x70, 0xfb, 0xb2, 0x5c,
+	0xca, 0xe6, 0xff, 0x47, 0x09, 0xd0, 0xec, 0xed, 0x12, 0x7d, 0x17, 0x96, 0x29, 0xf1, 0x5d, 0x4b,
+	0xfa, 0x0b, 0xe9, 0xca, 0x2a, 0xb8, 0xc6, 0x79, 0xd2, 0x71, 0x50, 0x6e, 0x02, 0xc9, 0xb9, 0x92,
+	0xb6, 0x82, 0xc5, 0x37, 0x3a, 0x81, 0xe5, 0x17, 0xd4, 0x4a, 0xe6, 0x16, 0x0a, 0x55, 0xcf, 0x6d,
+	0xd6, 0x66, 0xe5, 0x68, 0x3d, 0xee, 0x27, 0xff, 0x0b, 0xd7, 0x5e, 0xd0, 0x84, 0x40, 0xbf, 0xd0,
+	0xe0, 0x9d, 0x38, 0xac, 0x98, 0x2e, 0xdf, 0x38, 0x70, 0x09, 0x6d, 0x14, 0xef, 0xe8, 0x1b, 0xf5,
+	0xcd, 0xc3, 0x6b, 0xac, 0xdf, 0x0c, 0x73, 0x3f, 0x70, 0x09, 0xbe, 0xe5, 0x5f, 0xc1, 0xa5, 0xa8,
+	0x05, 0x37, 0xc7, 0x13, 0xca, 0x2c, 0xa9, 0x05, 0x96, 0xea, 0xd4, 0x28, 0x89, 0x75, 0x59, 0xe3,
+	0x4d, 0x19, 0x5d, 0x45, 0xa7, 0xb0, 0x32, 0x0e, 0x26, 0x3e, 0xb3, 0x1c, 0x71, 0xff, 0xa1, 0x8d,
+	0xf2, 0x5c, 0x17, 0xe3, 0x2b, 0x56, 0x69, 0x9f, 0xc3, 0xc9, 0xdb, 0x14, 0xc5, 0xcb, 0xe3, 0x14,
+	0xc5, 0x37, 0x32, 0x22, 0xe3, 0x80, 0x11, 0x8b, 0xdb, 0x4b, 0xda, 0x58, 0x92, 0x1b, 0x29, 0x79,
+	0xdc, 0x34, 0x50, 0xf4, 0x07, 0xb0, 0x3a, 0xa1, 0x24, 0xb2, 0xf8, 0xd1, 0xa2, 0xa1, 0xed, 0x10,
+	0xda, 0xa8, 0x88, 0x5e, 0x75, 0xce, 0xee, 0x25, 0x5c, 0xf4, 0x1d, 0x00, 0xe7, 0x84, 0x38, 0xa7,
+	0x61, 0xe0, 0xf9, 0xac, 0x51, 0x15, 0x7d, 0x52, 0x1c, 0xb3, 0x05, 0xb5, 0xd4, 0x7e, 0xa1, 0x0a,
+	0x14, 0x7b, 0x07, 0xbd, 0xae, 0x71, 0x03, 0x01, 0x94, 0x3b, 0x3b, 0xf8, 0xe0, 0x60, 0x20, 0xaf,
+	0x1f, 0xbb, 0xfb, 0xed, 0xed, 0xae, 0x51, 0x30, 0xbb, 0xb0, 0x9c, 0x96, 0x1c, 0x21, 0xa8, 0x1f,
+	0xf5, 0x9e, 0xf4, 0x0e, 0x9e, 0xf6, 0xac, 0xfd, 0x83, 0xa3, 0xde, 0x80, 0x5f, 0x5c, 0xea, 0x00,
+	0xed, 0xde, 0xb3, 0x29, 0xbd, 0x02, 0xd5, 0xde, 0x41, 0x4c, 0x6a, 0xcd, 0x82, 0xa1, 0x99, 0xff,
+	0xa9, 0xc3, 0xfa, 0x55, 0x9b, 0x88, 0x5c, 0x28, 0x72, 0x85, 0x50, 0x57, 0xc7, 0xb7, 0xaf, 0x0f,
+	0x02, 0x9d, 0x9f, 0x83, 0xd0, 0x56, 0xbe, 0xa2, 0x8a, 0xc5, 0x37, 0xb2, 0xa0, 0x3c, 0xb2, 0x8f,
+	0xc9, 0x88, 0x36, 0x74, 0x91, 0x5c, 0xd9, 0xbe, 0xce, 0xdc, 0x7b, 0x02, 0x49, 0x66, 0x56, 0x14,
+	0x2c, 0x1a, 0x40, 0x8d, 0x5b, 0x43, 0x2a, 0x97, 0x4e, 0x19, 0xe8, 0xcd, 0x9c, 0xb3, 0xec, 0x4c,
+	0x47, 0xe2, 0x34, 0x4c, 0xf3, 0x3e, 0xd4, 0x52, 0x93, 0x5d, 0x91, 0x18, 0x59, 0x4f, 0x27, 0x46,
+	0xaa, 0xe9, 0x2c, 0xc7, 0x23, 0x58, 0xbf, 0x6a, 0x8d, 0xb8, 0x12, 0xec, 0x1c, 0xf4, 0x07, 0xf2,
+	0x0a, 0xba, 0x8d, 0x0f, 0x8e, 0x0e, 0x0d, 0x8d, 0x33, 0x07, 0xed, 0xfe, 0x13, 0xa3, 0x90, 0xe8,
+	0x88, 0x6e, 0x76, 0xa0, 0x96, 0x92, 0x2b, 0x63, 0xfe, 0xb5, 0xac, 0xf9, 0xe7, 0x06, 0xd8, 0x76,
+	0xdd, 0x88, 0x50, 0xaa, 0xe4, 0x88, 0x49, 0xf3, 0x19, 0xac, 0x1c, 0xa5, 0x75, 0x96, 0xdb, 0x5d,
+	0x3e, 0xcc, 0x9a, 0x28, 0xbb, 0xbb, 0x82, 0x97, 0x38, 0x7d, 0xe4, 0xb9, 0x49, 0xd3, 0xd0, 0x93,
+	0x76, 0x5c, 0x35, 0x6d, 0x7b, 0x2e, 0xdf, 0x52, 0x11, 0x35, 0xe9, 0x82, 0x2d, 0xbe, 0xcd, 0xe7,
+	0x50, 0xdd, 0xea, 0xf5, 0x95, 0x74, 0x0d, 0x58, 0xa2, 0x24, 0xe2, 0x4b, 0x2a, 0xb2, 0x67, 0x55,
+	0x1c, 0x93, 0x5c, 0x6e, 0x4a, 0xec, 0xc8, 0x39, 0x21, 0x54, 0xc5, 0x23, 0x09, 0xcd, 0x47, 0x05,
+	0x22, 0x0b, 0x25, 0xd5, 0xa2, 0x8a, 0x63, 0xd2, 0xfc, 0xdf, 0x0a, 0xc0, 0x34, 0x23, 0x82, 0xea,
+	0x50, 0x48, 0xfc, 0x44, 0x41, 0xca, 0x93, 0xf2, 0x83, 0xe2, 0x1b, 0x6d, 0xc2, 0xad, 0x31, 0x1d,
+	0x86, 0xb6, 0x73, 0x6a, 0xa9, 0x44, 0x86, 0x34, 0x27, 0x42, 0xe8, 0x65, 0x7c, 0x53, 0x35, 0x2a,
+	0x6b, 0x21, 0x71, 0xf7, 0x40, 0x27, 0xfe, 0x99, 0xb0, 0x8f, 0xb5, 0xcd, 0x07, 0x73, 0x67, 0x6a,
+	0x5a, 0x5d, 0xff, 0x4c, 0xaa, 0x21, 0x87, 0x41, 0x16, 0x80, 0x4b, 0xce, 0x3c, 0x87, 0x58, 0x1c,
+	0xb4, 0x24, 0x40, 0x3f, 0x9f, 0x1f, 0x74, 0x4b, 0x60, 0x24, 0xd0, 0x55, 0x37, 0xa6, 0x51, 0x0f,
+	0xaa, 0x11, 0xa1, 0xc1, 0x24, 0x72, 0x88, 0x34, 0x92, 0xf9, 0x2f, 0x53, 0x38, 0x1e, 0x87, 0xa7,
+	0x10, 0x68, 0x0b, 0xca, 0xc2, 0x36, 0x72, 0x2b, 0xa8, 0x7f, 0x6b, 0xda, 0x37, 0x0b, 0x26, 0x8c,
+	0x14, 0x56, 0x63, 0xd1, 0x36, 0x2c, 0x49, 0x11, 0xb9, 0x99, 0xe4, 0x30, 0x1f, 0xe5, 0x35, 0xdc,
+	0x62, 0x14, 0x8e, 0x47, 0xf3, 0x5d, 0xe5, 0x06, 0x56, 0x18, 0xd2, 0x2a, 0x16, 0xdf, 0xe8, 0x3d,
+	0xa8, 0xca, 0x38, 0xc1, 0xf5, 0xa2, 0x06, 0x48, 0xbd, 0x17, 0x8c, 0x2d, 0x2f, 0x42, 0xef, 0x43,
+	0x4d, 0xc6, 0x83, 0x96, 0x30, 0x38, 0x35, 0xd1, 0x0c, 0x92, 0x75, 0xc8, 0xcd, 0x8e, 0xec, 0x40,
+	0xa2, 0x48, 0x76, 0x58, 0x4e, 0x3a, 0x90, 0x28, 0x12, 0x1d, 0x7e, 0x1f, 0x56, 0x45, 0x14, 0x3d,
+	0x8c, 0x82, 0x49, 0x28, 0x0c, 0x7e, 0x63, 0x45, 0x74, 0x5a, 0xe1, 0xec, 0x6d, 0xce, 0xe5, 0x67,
+	0x87, 0x9f, 0x8d, 0x97, 0xc1, 0xb1, 0xec, 0x50, 0x97, 0x47, 0xec, 0x65, 0x70, 0x1c, 0x37, 0x25,
+	0x91, 0xcc, 0x6a, 0x36, 0x92, 0xf9, 0x1a, 0x6e, 0xcf, 0xba, 0x64, 0x11, 0xd1, 0x18, 0xd7, 0x8f,
+	0x68, 0xd6, 0xfd, 0x2b, 0xb8, 0xe8, 0x0b, 0xd0, 0x5d, 0x9f, 0x36, 0xd6, 0xe6, 0x52, 0x8e, 0xe4,
+	0x1c, 0x63, 0x3e, 0x18, 0x3d, 0x87, 0x7a, 0xd6, 0xff, 0x35, 0x90, 0x80, 0xfb, 0xc3, 0x9c, 0x70,
+	0x19, 0x8b, 0x83, 0x57, 0x32, 0x4e, 0x93, 0x9f, 0xf9, 0x88, 0x50, 0x16, 0x44, 0xa4, 0x71, 0x53,
+	0x06, 0x8b, 0x8a, 0x6c, 0x7e, 0x02, 0x95, 0x58, 0xe9, 0xe7, 0xb1, 0xb4, 0xcd, 0x87, 0x50, 0xcf,
+	0x1e, 0x99, 0xb9, 0xec, 0xf4, 0xbf, 0x14, 0xa0, 0x9a, 0x1c, 0x0e, 0xe4, 0xc3, 0x4d, 0xb1, 0x79,
+	0x36, 0x23, 0xae, 0x35, 0x3d, 0x6b, 0x32, 0x66, 0xfe, 0x2c, 0xe7, 0xff, 0x6f, 0xc7, 0x08, 0xea,
+	0xf2, 0xae, 0x0e, 0x1e, 0x4a, 0x90, 0xa7, 0xf3, 0x7d, 0x05, 0xab, 0x23, 0xcf, 0x9f, 0x9c, 0xa7,
+	0xe6, 0x92, 0xc1, 0xee, 0x1f, 0xe5, 0x9c, 0x6b, 0x8f, 0x8f, 0x9e, 0xce, 0x51, 0x1f, 0x65, 0x68,
+	0xb4, 0x03, 0xa5, 0x30, 0x88, 0x58, 0xec, 0x76, 0xf3, 0x3a, 0xc4, 0xc3, 0x20, 0x62, 0xfb, 0x76,
+	0x18, 0xf2, 0xfb, 0x9c, 0x04, 0x30, 0xbf, 0x29, 0xc0, 0xed, 0xab, 0xff, 0x18, 0xea, 0x81, 0xee,
+	0x84, 0x13, 0xb5, 0x48, 0x0f, 0xe7, 0x5d, 0xa4, 0x4e, 0x38, 0x99, 0xca, 0xcf, 0x81, 0x78, 0x8e,
+	0x7b, 0x4c, 0xc6, 0x41, 0x74, 0xa1, 0xd6, 0xe2, 0xd1, 0xbc, 0x90, 0xfb, 0x62, 0xf4, 0x14, 0x55,
+	0xc1, 0x21, 0x0c, 0x15, 0x75, 0x68, 0xa8, 0x32, 0xcf, 0x73, 0x66, 0xdc, 0x62, 0x48, 0x9c, 0xe0,
+	0x98, 0x9f, 0xc0, 0xad, 0x2b, 0xff, 0x0a, 0xfa, 0x5d, 0x00, 0x27, 0x9c, 0x58, 0xe2, 0x45, 0x44,
+	0x6a, 0x90, 0x8e, 0xab, 0x4e, 0x38, 0xe9, 0x0b, 0x86, 0xf9, 0x1c, 0x1a, 0xaf, 0x93, 0x97, 0x1b,
+	0x3d, 0x29, 0xb1, 0x35, 0x3e, 0x16, 0x6b, 0xa0, 0xe3, 0x8a, 0x64, 0xec, 0x1f, 0x23, 0x13, 0x56,
+	0xe2, 0x46, 0xfb, 0x9c, 0x77, 0xd0, 0x45, 0x87, 0x9a, 0xea, 0x60, 0x9f, 0xef, 0x1f, 0x9b, 0xbf,
+	0x2c, 0xc0, 0xea, 0x25, 0x91, 0xf9, 0xad, 0x56, 0x1a, 0xda, 0x38, 0x5f, 0x20, 0x29, 0x6e, 0x75,
+	0x1d, 0xcf, 0x8d, 0x33, 0xcd, 0xe2, 0x5b, 0xf8, 0xdb, 0x50, 0x65, 0x81, 0x0b, 0x5e, 0xc8, 0x8f,
+	0xcf, 0xf8, 0xd8, 0x63, 0x54, 0xc4, 0x55, 0x25, 0x2c, 0x09, 0xf4, 0x0c, 0xea, 0x11, 0x11, 0x7e,
+	0xde, 0xb5, 0xa4, 0x96, 0x95, 0xe6, 0xd2, 0x32, 0x25, 0x21, 0x57, 0x36, 0xbc, 0x12, 0x23, 0x71,
+	0x8a, 0xa2, 0xa7, 0xb0, 0xe2, 0x5e, 0xf8, 0xf6, 0xd8, 0x73, 0x14, 0x72, 0x79, 0x61, 0xe4, 0x65,
+	0x05, 0x24, 0x80, 0xf9, 0xe3, 0x53, 0xaa, 0x91, 0xff, 0x31, 0x11, 0x40, 0xaa, 0x35, 0x91, 0x44,
+	0xd6, 0x5a, 0x94, 0x94, 0xb5, 0x30, 0x8f, 0xa1, 0x96, 0x3a, 0x17, 0xf3, 0x0c, 0xe5, 0xeb, 0xc9,
+	0x02, 0xb1, 0x9e, 0x25, 0x5c, 0x60, 0x01, 0x4f, 0xde, 0x88, 0x50, 0xcb, 0x0b, 0xc5, 0x8a, 0x56,
+	0x71, 0x99, 0x93, 0xbb, 0xa1, 0xf9, 0xeb, 0x02, 0xd4, 0xb3, 0x47, 0x3a, 0xd6, 0xa3, 0x90, 0x44,
+	0x5e, 0xe0, 0xa6, 0xf4, 0xe8, 0x50, 0x30, 0xb8, 0xae, 0xf0, 0xe6, 0xaf, 0x27, 0x01, 0xb3, 0x63,
+	0x5d, 0x71, 0xc2, 0xc9, 0x1f, 0x73, 0xfa, 0x92, 0x0e, 0xea, 0x97, 0x74, 0x10, 0x7d, 0x08, 0x48,
+	0xa9, 0xd2, 0xc8, 0x1b, 0x7b, 0xcc, 0x3a, 0xbe, 0x60, 0x44, 0xee, 0xb1, 0x8e, 0x0d, 0xd9, 0xb2,
+	0xc7, 0x1b, 0xbe, 0xe0, 0x7c, 0xae, 0x78, 0x41, 0x30, 0xb6, 0xa8, 0x13, 0x44, 0xc4, 0xb2, 0xdd,
+	0x97, 0xe2, 0x42, 0xa7, 0xe3, 0x5a, 0x10, 0x8c, 0xfb, 0x9c, 0xd7, 0x76, 0x5f, 0x72, 0x87, 0xeb,
+	0x84, 0x13, 0x4a, 0x98, 0xc5, 0x7f, 0x44, 0x8c, 0x52, 0xc5, 0x20, 0x59, 0x9d, 0x70, 0x42, 0xd1,
+	0xef, 0xc1, 0x4a, 0xdc, 0x41, 0xf8, 0x5c, 0xe5, 0xec, 0x97, 0x55, 0x17, 0xc1, 0x43, 0x26, 0x2c,
+	0x1f, 0x92, 0xc8, 0x21, 0x3e, 0x1b, 0x78, 0xce, 0xa9, 0xbc, 0x7d, 0x69, 0x38, 0xc3, 0xfb, 0xb2,
+	0x58, 0x59, 0x32, 0x2a, 0x38, 0x9e, 0x6d, 0x4c, 0xc6, 0xd4, 0xfc, 0x29, 0x94, 0x44, 0x64, 0xc2,
+	0xd7, 0x44, 0x78, 0x75, 0xe1, 0xf4, 0x55, 0xb0, 0xcc, 0x19, 0xc2, 0xe5, 0xbf, 0x07, 0x55, 0xb1,
+	0xf6, 0xa9, 0x3b, 0x8a, 0x88, 0x7b, 0x45, 0x63, 0x13, 0x2a, 0x11, 0xb1, 0xdd, 0xc0, 0x1f, 0xc5,
+	0x79, 0xb2, 0x84, 0x36, 0xbf, 0x86, 0xb2, 0xf4, 0x33, 0xd7, 0xc0, 0xff, 0x08, 0x90, 0xfc, 0xdf,
+	0x7c, 0x3f, 0xc7, 0x1e, 0xa5, 0x2a, 0xf8, 0x15, 0x8f, 0xb3, 0xb2, 0xe5, 0x70, 0xda, 0x60, 0xfe,
+	0xb7, 0x06, 0x30, 0x7d, 0x36, 0xe3, 0xbe, 0x93, 0x2b, 0x39, 0x4f, 0x24, 0xc8, 0xfc, 0x5c, 0x4c,
+	0xf2, 0xd4, 0x94, 0x8a, 0x76, 0x0b, 0x8b, 0xbe, 0x3a, 0x2a, 0x80, 0x38, 0x5b, 0x4f, 0x54, 0xae,
+	0x62, 0xde, 0x6c, 0x3d, 0x91, 0xd9, 0x7a, 0xc2, 0x2f, 0xda, 0x2a, 0x0e, 0x97, 0x70, 0x45, 0x11,
+	0x86, 0xd7, 0xdc, 0xe4, 0x49, 0x84, 0x98, 0xff, 0xa7, 0x25, 0x66, 0x2a, 0x7e, 0xba, 0x40, 0x5f,
+	0x41, 0x85, 0x9f, 0x78, 0x6b, 0x6c, 0x87, 0xea, 0x21, 0xbe, 0xb3, 0xd8, 0xab, 0x48, 0xec, 0xc4,
+	0x64, 0x14, 0xbd, 0x14, 0x4a, 0x8a, 0x9b, 0x3b, 0x7e, 0x39, 0x8a, 0xcd, 0x1d, 0xff, 0x46, 0x1f,
+	0x40, 0xdd, 0x9e, 0xb0, 0xc0, 0xb2, 0xdd, 0x33, 0x12, 0x31, 0x8f, 0x12, 0xb5, 0xf7, 0x2b, 0x9c,
+	0xdb, 0x8e, 0x99, 0xcd, 0x07, 0xb0, 0x9c, 0xc6, 0x7c, 0x53, 0x98, 0x51, 0x4a, 0x87, 0x19, 0x7f,
+	0x06, 0x30, 0x4d, 0x03, 0x72, 0x1d, 0xe1, 0x39, 0x45, 0xcb, 0x89, 0x6f, 0xe3, 0x25, 0x5c, 0xe1,
+	0x8c, 0x0e, 0xbf, 0x21, 0x66, 0xdf, 0x28, 0x4a, 0xf1, 0x1b, 0x05, 0x3f, 0xcc, 0xfc, 0xfc, 0x9d,
+	0x7a, 0xa3, 0x51, 0x92, 0x9a, 0xac, 0x06, 0xc1, 0xf8, 0x89, 0x60, 0x98, 0xbf, 0x29, 0x48, 0x5d,
+	0x91, 0xaf, 0x4d, 0xb9, 0xae, 0x4c, 0x6f, 0x6b, 0xab, 0xef, 0x03, 0x50, 0x66, 0x47, 0x3c, 0x66,
+	0xb2, 0xe3, 0xe4, 0x68, 0x73, 0xe6, 0x91, 0x63, 0x10, 0x97, 0xbf, 0xe0, 0xaa, 0xea, 0xdd, 0x66,
+	0xe8, 0x33, 0x58, 0x76, 0x82, 0x71, 0x38, 0x22, 0x6a, 0x70, 0xe9, 0x8d, 0x83, 0x6b, 0x49, 0xff,
+	0x36, 0x4b, 0xa5, 0x64, 0xcb, 0xd7, 0x4d, 0xc9, 0xfe, 0x5a, 0x93, 0x8f, 0x66, 0xe9, 0x37, 0x3b,
+	0x34, 0xbc, 0xa2, 0x30, 0x64, 0x7b, 0xc1, 0x07, 0xc0, 0x6f, 0xab, 0x0a, 0x69, 0x7e, 0x96, 0xa7,
+	0x0c, 0xe3, 0xf5, 0x51, 0xec, 0xbf, 0xeb, 0x50, 0x8d, 0xb7, 0x65, 0x76, 0xef, 0x3f, 0x85, 0x6a,
+	0x52, 0x7b, 0xd4, 0x28, 0xbc, 0x71, 0x85, 0xa7, 0x9d, 0xd1, 0x0b, 0x40, 0xf6, 0x70, 0x98, 0x44,
+	0xa7, 0xd6, 0x84, 0xda, 0xc3, 0xf8, 0xb5, 0xf2, 0xd3, 0x39, 0xd6, 0x21, 0x76, 0x67, 0x47, 0x7c,
+	0x3c, 0x36, 0xec, 0xe1, 0x30, 0xc3, 0x41, 0x7f, 0x0e, 0xb7, 0xb2, 0x73, 0x58, 0xc7, 0x17, 0x56,
+	0xe8, 0xb9, 0xea, 0x6a, 0xbe, 0x33, 0xef, 0x93, 0x61, 0x2b, 0x03, 0xff, 0xc5, 0xc5, 0xa1, 0xe7,
+	0xca, 0x35, 0x47, 0xd1, 0x4c, 0x43, 0xf3, 0x2f, 0xe1, 0x9d, 0xd7, 0x74, 0xbf, 0x62, 0x0f, 0x7a,
+	0xd9, 0x52, 0x98, 0xc5, 0x17, 0x21, 0xb5, 0x7b, 0xbf, 0xd2, 0x60, 0x6d, 0xa6, 0x03, 0x6a, 0xa7,
+	0xc3, 0xea, 0xbb, 0x39, 0xe7, 0xe9, 0x1c, 0x1e, 0x49, 0x78, 0x3e, 0x16, 0x7d, 0x79, 0x29, 0x92,
+	0xce, 0x1b, 0x3f, 0xc9, 0x80, 0x54, 0x02, 0x29, 0x04, 0xf3, 0x5f, 0x75, 0xa8, 0xc4, 0xe8, 0xe2,
+	0x62, 0x7d, 0x41, 0x19, 0x19, 0x5b, 0x49, 0x42, 0x51, 0xc3, 0x20, 0x59, 0x22, 0xcd, 0xf5, 0x1e,
+	0x54, 0xc5, 0x1d, 0x52, 0x34, 0x17, 0x44, 0x73, 0x85, 0x33, 0x44, 0xe3, 0xfb, 0x50, 0x63, 0x01,
+	0xb3, 0x47, 0x16, 0x13, 0xee, 0x5d, 0x97, 0xa3, 0x05, 0x4b, 0x38, 0x77, 0xf4, 0x7d, 0x58, 0x63,
+	0x27, 0x51, 0xc0, 0xd8, 0x88, 0x87, 0x96, 0x22, 0xd0, 0x91, 0x71, 0x49, 0x11, 0x1b, 0x49, 0x83,
+	0x0c, 0x80, 0x28, 0xb7, 0xde, 0xd3, 0xce, 0x5c, 0x75, 0x85, 0x11, 0x29, 0xe2, 0x95, 0x84, 0xcb,
+	0x55, 0x9b, 0x3b, 0xcf, 0x50, 0x06, 0x10, 0xc2, 0x56, 0x68, 0x38, 0x26, 0x91, 0x05, 0xab, 0x63,
+	0x62, 0xd3, 0x49, 0x44, 0x5c, 0xeb, 0x85, 0x47, 0x46, 0xae, 0xcc, 0x87, 0xd4, 0x73, 0xdf, 0x0e,
+	0xe2, 0x65, 0x69, 0x3d, 0x16, 0xa3, 0x71, 0x3d, 0x86, 0x93, 0x34, 0x8f, 0x1c, 0xe4, 0x17, 0x5a,
+	0x85, 0x5a, 0xff, 0x59, 0x7f, 0xd0, 0xdd, 0xb7, 0xf6, 0x0f, 0xb6, 0xba, 0xaa, 0xda, 0xa9, 0xdf,
+	0xc5, 0x92, 0xd4, 0x78, 0xfb, 0xe0, 0x60, 0xd0, 0xde, 0xb3, 0x06, 0xbb, 0x9d, 0x27, 0x7d, 0xa3,
+	0x80, 0x6e, 0xc1, 0xda, 0x60, 0x07, 0x1f, 0x0c, 0x06, 0x7b, 0xdd, 0x2d, 0xeb, 0xb0, 0x8b, 0x77,
+	0x0f, 0xb6, 0xfa, 0x86, 0xce, 0x33, 0xc3, 0x53, 0xf6, 0x60, 0x77, 0xbf, 0x6b, 0x14, 0x79, 0x7d,
+	0xcb, 0x61, 0x17, 0x77, 0xba, 0xbd, 0x81, 0x51, 0x32, 0x7f, 0xa9, 0x43, 0x2d, 0xb5, 0x8b, 0x5c,
+	0x91, 0x23, 0x2a, 0xaf, 0x21, 0x45, 0xcc, 0x3f, 0xc5, 0xeb, 0xac, 0xed, 0x9c, 0xc8, 0xdd, 0x29,
+	0x62, 0x49, 0x88, 0xab, 0x87, 0x7d, 0x9e, 0x3a, 0xe7, 0x45, 0x5c, 0x19, 0xdb, 0xe7, 0x12, 0xe4,
+	0xbb, 0xb0, 0x7c, 0x4a, 0x22, 0x9f, 0x8c, 0x54, 0xbb, 0xdc, 0x91, 0x9a, 0xe4, 0xc9, 0x2e, 0x1b,
+	0x60, 0xa8, 0x2e, 0x53, 0x18, 0xb9, 0x1d, 0x75, 0xc9, 0xdf, 0x8f, 0xc1, 0xd6, 0xa1, 0x24, 0x9b,
+	0x97, 0xe4, 0xfc, 0x82, 0x10, 0x99, 0xc6, 0x57, 0x76, 0x28, 0x42, 0xbe, 0x22, 0x16, 0xdf, 0xe8,
+	0x78, 0x76, 0x7f, 0xca, 0x62, 0x7f, 0xee, 0xcf, 0xaf, 0xce, 0xaf, 0xdb, 0xa2, 0x93, 0x64, 0x8b,
+	0x96, 0x40, 0xc7, 0x71, 0x89, 0x50, 0xa7, 0xdd, 0xd9, 0xe1, 0xdb, 0xb2, 0x02, 0xd5, 0xfd, 0xf6,
+	0x4f, 0xac, 0xa3, 0xbe, 0xc8, 0xd3, 0x23, 0x03, 0x96, 0x9f, 0x74, 0x71, 0xaf, 0xbb, 0xa7, 0x38,
+	0x3a, 0x5a, 0x07, 0x43, 0x71, 0xa6, 0xfd, 0x8a, 0x1c, 0x41, 0x7e, 0x96, 0x78, 0x5e, 0xb7, 0xff,
+	0xb4, 0x7d, 0x68, 0x94, 0xcd, 0xff, 0x29, 0xc0, 0xaa, 0x74, 0x0b, 0x49, 0x31, 0xc3, 0xeb, 0x1f,
+	0x73, 0xd3, 0xc9, 0xa5, 0x42, 0x36, 0xb9, 0x14, 0x07, 0xa1, 0xc2, 0xab, 0xeb, 0xd3, 0x20, 0x54,
+	0x24, 0xa5, 0x32, 0x16, 0xbf, 0x38, 0x8f, 0xc5, 0x6f, 0xc0, 0xd2, 0x98, 0xd0, 0x64, 0xdf, 0xaa,
+	0x38, 0x26, 0x91, 0x07, 0x35, 0xdb, 0xf7, 0x03, 0x66, 0xcb, 0x8c, 0x6d, 0x79, 0x2e, 0x67, 0x78,
+	0xe9, 0x1f, 0xb7, 0xda, 0x53, 0x24, 0x69, 0x98, 0xd3, 0xd8, 0xcd, 0x1f, 0x83, 0x71, 0xb9, 0xc3,
+	0x3c, 0xee, 0xf0, 0x7b, 0x3f, 0x98, 0x7a, 0x43, 0xc2, 0xcf, 0x85, 0x7a, 0x45, 0x31, 0x6e, 0x70,
+	0x02, 0x1f, 0xf5, 0x7a, 0xbb, 0xbd, 0x6d, 0x43, 0xe3, 0xcf, 0x30, 0xdd, 0x9f, 0xec, 0xf2, 0xb2,
+	0xc3, 0xc2, 0xe6, 0xaf, 0xd6, 0xa0, 0x2c, 0x85, 0x44, 0xdf, 0xa8, 0x48, 0x20, 0x5d, 0x28, 0x8b,
+	0x7e, 0x3c, 0x77, 0x44, 0x9d, 0x29, 0xbe, 0x6d, 0x3e, 0x5a, 0x78, 0xbc, 0x7a, 0x98, 0xbc, 0x81,
+	0xfe, 0x56, 0x83, 0xe5, 0xcc, 0xa3, 0x64, 0xde, 0x8c, 0xf5, 0x15, 0x75, 0xb9, 0xcd, 0x1f, 0x2d,
+	0x34, 0x36, 0x91, 0xe5, 0x17, 0x1a, 0xd4, 0x52, 0x15, 0xa9, 0xe8, 0xfe, 0x22, 0x55, 0xac, 0x52,
+	0x92, 0x07, 0x8b, 0x17, 0xc0, 0x9a, 0x37, 0x3e, 0xd6, 0xd0, 0xdf, 0x68, 0x50, 0x4b, 0xd5, 0x66,
+	0xe6, 0x16, 0x65, 0xb6, 0x92, 0xb4, 0xf9, 0x60, 0x91, 0xa1, 0xc9, 0x9a, 0xfc, 0x95, 0x06, 0xd5,
+	0xa4, 0xce, 0x12, 0xdd, 0x9b, 0xbf, 0x32, 0x53, 0x0a, 0xf1, 0xe9, 0xa2, 0x25, 0x9d, 0xe6, 0x0d,
+	0xf4, 0x17, 0x50, 0x89, 0x8b, 0x12, 0x51, 0x5e, 0xef, 0x75, 0xa9, 0xe2, 0xb1, 0x79, 0x6f, 0xee,
+	0x71, 0xe9, 0xe9, 0xe3, 0x4a, 0xc1, 0xdc, 0xd3, 0x5f, 0xaa, 0x69, 0x6c, 0xde, 0x9b, 0x7b, 0x5c,
+	0x32, 0x3d, 0xd7, 0x84, 0x54, 0x41, 0x61, 0x6e, 0x4d, 0x98, 0xad, 0x64, 0x6c, 0x3e, 0x58, 0x64,
+	0x68, 0x46, 0x90, 0x54, 0x49, 0x62, 0x6e, 0x41, 0x66, 0xcb, 0x1e, 0x9b, 0x0f, 0x16, 0x19, 0x9a,
+	0x08, 0xf2, 0x73, 0x2d, 0x7d, 0x2f, 0xb8, 0x37, 0x77, 0xe5, 0xdd, 0x9c, 0x2a, 0x39, 0x53, 0xfb,
+	0x27, 0x0e, 0xe8, 0xcf, 0x55, 0x16, 0x43, 0x16, 0xee, 0xa1, 0x79, 0xc0, 0x32, 0xb5, 0x7e, 0xcd,
+	0x4f, 0x16, 0x73, 0x36, 0x42, 0x88, 0xbf, 0xd6, 0x00, 0xa6, 0x25, 0x7e, 0xb9, 0x85, 0x98, 0xa9,
+	0x2d, 0x6c, 0xde, 0x5f, 0x60, 0x64, 0xfa, 0x80, 0xc4, 0x25, 0x48, 0xb9, 0x0f, 0xc8, 0xa5, 0x12,
+	0xc4, 0xe6, 0xbd, 0xb9, 0xc7, 0x25, 0xd3, 0xff, 0xb3, 0x06, 0x6b, 0x33, 0x25, 0x50, 0xe8, 0xd1,
+	0x35, 0xab, 0xe0, 0x9a, 0x9f, 0x2f, 0x0e, 0x10, 0x8b, 0xb6, 0xa1, 0x7d, 0xac, 0xa1, 0xbf, 0xd3,
+	0x60, 0x25, 0x5b, 0x1a, 0x92, 0xdb, 0x4b, 0x5d, 0x51, 0x4c, 0xd5, 0x7c, 0xb8, 0xd8, 0xe0, 0x64,
+	0xb5, 0xfe, 0x41, 0x83, 0xba, 0x3a, 0xdf, 0xb1, 0x3c, 0x0f, 0xe7, 0x33, 0x0b, 0x97, 0x04, 0xfa,
+	0x6c, 0xc1, 0xd1, 0xb1, 0x44, 0x5f, 0x2c, 0xfd, 0x49, 0x49, 0x46, 0x6f, 0x65, 0xf1, 0xf3, 0xc3,
+	0xdf, 0x0e, 0x00, 0xfc, 0xae, 0x5c, 0x4c, 0xcf, 0x34, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -46,6 +46,8 @@ func (b *driverPluginServer) Capabilities(ctx context.Context, req *proto.Capabi
 			MustCreateNetwork:     caps.MustInitiateNetwork,
 			NetworkIsolationModes: []proto.NetworkIsolationSpec_NetworkIsolationMode{},
 			RemoteTasks:           caps.RemoteTasks,
+			UserNamespaces:        caps.UserNamespaces,
+			Checkpoint:            caps.Checkpoint,
 		},
 	}
 
@@ -66,6 +66,8 @@ func taskConfigFromProto(pb *proto.TaskConfig) *TaskConfig {
 		AllocID:          pb.AllocId,
 		NetworkIsolation: NetworkIsolationSpecFromProto(pb.NetworkIsolationSpec),
 		DNS:              dnsConfigFromProto(pb.Dns),
+		UserNamespace:    UserNamespaceFromProto(pb.UserNamespace),
+		Restore:          pb.Restore,
 	}
 }
 
@@ -91,6 +93,8 @@ func taskConfigToProto(cfg *TaskConfig) *proto.TaskConfig {
 		AllocId:              cfg.AllocID,
 		NetworkIsolationSpec: NetworkIsolationSpecToProto(cfg.NetworkIsolation),
 		Dns:                  dnsConfigToProto(cfg.DNS),
+		UserNamespace:        UserNamespaceToProto(cfg.UserNamespace),
+		Restore:              cfg.Restore,
 	}
 	return pb
 }
@@ -713,3 +717,27 @@ func dnsConfigFromProto(pb *proto.DNSConfig) *DNSConfig {
 		Options:  pb.Options,
 	}
 }
+
+func UserNamespaceFromProto(pb *proto.UserNamespace) *UserNamespace {
+	if pb == nil {
+		return nil
+	}
+
+	return &UserNamespace{
+		HostUID: pb.HostUid,
+		HostGID: pb.HostGid,
+		Size:    pb.Size,
+	}
+}
+
+func UserNamespaceToProto(u *UserNamespace) *proto.UserNamespace {
+	if u == nil {
+		return nil
+	}
+
+	return &proto.UserNamespace{
+		HostUid: u.HostUID,
+		HostGid: u.HostGID,
+		Size:    u.Size,
+	}
+}
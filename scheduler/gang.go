@@ -0,0 +1,123 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// gangPlacement is a placement made for a task group that is part of a
+// gang. It is withheld from the plan if any allocation of the gang can't be
+// placed.
+type gangPlacement struct {
+	alloc *structs.Allocation
+
+	// stopped is the previous allocation stopped by the placement, if any
+	stopped *structs.Allocation
+}
+
+// withholdGangPlacements removes the placements of every gang with an
+// allocation that couldn't be placed from the plan, so that none of the
+// allocations of the gang are placed. The task groups of the gang are
+// marked as failed with an explanation, which creates a blocked evaluation
+// that retries the whole gang once there is capacity for it. The preemptions
+// of withheld placements are removed from the plan annotations as well. If
+// any gang placements remain, the plan is marked all at once so that the
+// plan applier can't commit only part of a gang.
+func (s *GenericScheduler) withholdGangPlacements(placements []*gangPlacement) {
+	gangs := make(map[string][]string)
+	for _, tg := range s.job.TaskGroups {
+		if gang := tg.GangName(); gang != "" {
+			gangs[gang] = append(gangs[gang], tg.Name)
+		}
+	}
+	if len(gangs) == 0 {
+		return
+	}
+
+	byGroup := make(map[string][]*gangPlacement)
+	for _, p := range placements {
+		byGroup[p.alloc.TaskGroup] = append(byGroup[p.alloc.TaskGroup], p)
+	}
+
+	withheldGangs := make(map[string]bool)
+	for gang, members := range gangs {
+		failed := 0
+		for _, name := range members {
+			if metric, ok := s.failedTGAllocs[name]; ok {
+				failed += metric.CoalescedFailures + 1
+			}
+		}
+		if failed == 0 {
+			continue
+		}
+
+		total := failed
+		for _, name := range members {
+			total += len(byGroup[name])
+		}
+
+		sort.Strings(members)
+		desc := fmt.Sprintf("%d of %d allocations of gang [%s] could not be placed",
+			failed, total, strings.Join(members, ", "))
+
+		for _, name := range members {
+			withheld := byGroup[name]
+			for _, p := range withheld {
+				s.plan.RemoveAlloc(p.alloc)
+				if p.stopped != nil {
+					s.plan.RemoveUpdate(p.stopped)
+				}
+				s.removeGangPreemptionAnnotations(name, p.alloc)
+			}
+
+			metric, ok := s.failedTGAllocs[name]
+			switch {
+			case ok:
+				metric.CoalescedFailures += len(withheld)
+			case len(withheld) > 0:
+				// Keep the metrics of a placement that would have
+				// succeeded, so it's clear the task group itself fits
+				metric = withheld[len(withheld)-1].alloc.Metrics.Copy()
+				metric.CoalescedFailures = len(withheld) - 1
+				if s.failedTGAllocs == nil {
+					s.failedTGAllocs = make(map[string]*structs.AllocMetric)
+				}
+				s.failedTGAllocs[name] = metric
+			default:
+				continue
+			}
+			metric.GangFailure = desc
+		}
+
+		withheldGangs[gang] = true
+		s.logger.Debug("withheld placements of gang", "task_groups", members,
+			"failed", failed, "total", total)
+	}
+
+	for _, p := range placements {
+		if !withheldGangs[s.job.LookupTaskGroup(p.alloc.TaskGroup).GangName()] {
+			s.plan.AllAtOnce = true
+			break
+		}
+	}
+}
+
+// removeGangPreemptionAnnotations removes the preemptions of a withheld
+// placement from the plan annotations, so that planning the job doesn't
+// report preemptions that won't happen.
+func (s *GenericScheduler) removeGangPreemptionAnnotations(tgName string, alloc *structs.Allocation) {
+	annotations := s.plan.Annotations
+	if annotations == nil || len(alloc.PreemptedAllocations) == 0 {
+		return
+	}
+
+	annotations.RemovePreemptedAllocs(alloc.PreemptedAllocations)
+	if desired, ok := annotations.DesiredTGUpdates[tgName]; ok {
+		for range alloc.PreemptedAllocations {
+			decrement(&desired.Preemptions)
+		}
+	}
+}
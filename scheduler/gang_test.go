@@ -0,0 +1,237 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// gangEval registers the job and an evaluation for it
+func gangEval(t *testing.T, h *Harness, job *structs.Job) *structs.Evaluation {
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	return eval
+}
+
+func TestServiceSched_Gang_Group(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name    string
+		count   int
+		placed  bool
+		failure string
+	}{
+		{
+			name:   "fits",
+			count:  2,
+			placed: true,
+		},
+		{
+			name:    "does not fit",
+			count:   4,
+			failure: "2 of 4 allocations of gang [web] could not be placed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewHarness(t)
+
+			// The node has room for two allocations
+			node := mock.Node()
+			require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+			job := mock.Job()
+			job.TaskGroups[0].Count = tc.count
+			job.TaskGroups[0].Tasks[0].Resources.CPU = 1500
+			job.TaskGroups[0].Gang = &structs.GangStrategy{Scope: structs.GangScopeGroup}
+			eval := gangEval(t, h, job)
+
+			require.NoError(t, h.Process(NewServiceScheduler, eval))
+			require.Len(t, h.Evals, 1)
+			outEval := h.Evals[0]
+
+			if tc.placed {
+				require.Len(t, h.Plans, 1)
+				require.Len(t, h.Plans[0].NodeAllocation[node.ID], tc.count)
+				require.True(t, h.Plans[0].AllAtOnce)
+				require.Empty(t, outEval.FailedTGAllocs)
+				return
+			}
+
+			// Nothing was placed and the gang is retried by a blocked eval
+			require.Empty(t, h.Plans)
+			require.Len(t, h.CreateEvals, 1)
+			require.Equal(t, structs.EvalStatusBlocked, h.CreateEvals[0].Status)
+			require.Equal(t, h.CreateEvals[0].ID, outEval.BlockedEval)
+
+			metric := outEval.FailedTGAllocs["web"]
+			require.NotNil(t, metric)
+			require.Equal(t, tc.count-1, metric.CoalescedFailures)
+			require.Equal(t, tc.failure, metric.GangFailure)
+			require.Equal(t, tc.count, outEval.QueuedAllocations["web"])
+		})
+	}
+}
+
+func TestServiceSched_Gang_Job(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// The "web" and "db" groups form a gang, and "db" doesn't fit. The
+	// "cache" group isn't part of the gang and is placed regardless.
+	job := mock.Job()
+	web := job.TaskGroups[0]
+	web.Count = 2
+	web.Gang = &structs.GangStrategy{Scope: structs.GangScopeJob}
+
+	db := web.Copy()
+	db.Name = "db"
+	db.Count = 1
+	db.Tasks[0].Resources.MemoryMB = 1 << 20
+
+	cache := web.Copy()
+	cache.Name = "cache"
+	cache.Count = 1
+	cache.Gang = nil
+
+	job.TaskGroups = append(job.TaskGroups, db, cache)
+	eval := gangEval(t, h, job)
+
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	require.Len(t, h.Plans, 1)
+	planned := h.Plans[0].NodeAllocation[node.ID]
+	require.Len(t, planned, 1)
+	require.Equal(t, "cache", planned[0].TaskGroup)
+	require.False(t, h.Plans[0].AllAtOnce)
+
+	require.Len(t, h.Evals, 1)
+	outEval := h.Evals[0]
+	require.Len(t, outEval.FailedTGAllocs, 2)
+
+	expected := "1 of 3 allocations of gang [db, web] could not be placed"
+	dbMetric := outEval.FailedTGAllocs["db"]
+	require.NotNil(t, dbMetric)
+	require.Zero(t, dbMetric.CoalescedFailures)
+	require.Equal(t, 1, dbMetric.NodesExhausted)
+	require.Equal(t, expected, dbMetric.GangFailure)
+
+	// The metrics of the withheld group show it would have fit
+	webMetric := outEval.FailedTGAllocs["web"]
+	require.NotNil(t, webMetric)
+	require.Equal(t, 1, webMetric.CoalescedFailures)
+	require.Equal(t, 1, webMetric.NodesEvaluated)
+	require.Zero(t, webMetric.NodesExhausted)
+	require.Equal(t, expected, webMetric.GangFailure)
+}
+
+func TestServiceSched_Gang_DestructiveUpdate(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Tasks[0].Resources.CPU = 1000
+	job.TaskGroups[0].Gang = &structs.GangStrategy{Scope: structs.GangScopeGroup}
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.Name = fmt.Sprintf("my-job.web[%d]", i)
+		alloc.AllocatedResources.Tasks["web"].Cpu.CpuShares = 1000
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	// Only one of the replacements fits once the allocation it replaces is
+	// stopped
+	job2 := job.Copy()
+	job2.TaskGroups[0].Tasks[0].Resources.CPU = 2400
+	eval := gangEval(t, h, job2)
+
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Neither of the running allocations was stopped
+	require.Empty(t, h.Plans)
+	require.Len(t, h.Evals, 1)
+	metric := h.Evals[0].FailedTGAllocs["web"]
+	require.NotNil(t, metric)
+	require.Equal(t, "1 of 2 allocations of gang [web] could not be placed", metric.GangFailure)
+}
+
+func TestServiceSched_Gang_WithheldPreemptions(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// A low priority allocation uses most of the node
+	low := mock.Job()
+	low.Priority = 20
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), low))
+	lowAlloc := mock.Alloc()
+	lowAlloc.Job = low
+	lowAlloc.JobID = low.ID
+	lowAlloc.NodeID = node.ID
+	lowAlloc.ClientStatus = structs.AllocClientStatusRunning
+	lowAlloc.AllocatedResources.Tasks["web"].Cpu.CpuShares = 3000
+	lowAlloc.AllocatedResources.Shared.Networks = nil
+	lowAlloc.AllocatedResources.Tasks["web"].Networks = nil
+	require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{lowAlloc}))
+
+	// The first allocation of the gang fits by preempting the low priority
+	// allocation, but the second doesn't fit at all
+	job := mock.Job()
+	job.Priority = 100
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Networks = nil
+	job.TaskGroups[0].Tasks[0].Resources.CPU = 2500
+	job.TaskGroups[0].Tasks[0].Resources.Networks = nil
+	job.TaskGroups[0].Gang = &structs.GangStrategy{Scope: structs.GangScopeGroup}
+	eval := gangEval(t, h, job)
+	eval.AnnotatePlan = true
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Planning the job reports neither placements nor preemptions
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+	require.Empty(t, plan.NodeAllocation)
+	require.Empty(t, plan.NodePreemptions)
+	require.NotNil(t, plan.Annotations)
+	require.Empty(t, plan.Annotations.PreemptedAllocs)
+	require.Empty(t, plan.Annotations.PreemptionReasons)
+	require.Zero(t, plan.Annotations.DesiredTGUpdates["web"].Preemptions)
+
+	metric := h.Evals[0].FailedTGAllocs["web"]
+	require.NotNil(t, metric)
+	require.Equal(t, "1 of 2 allocations of gang [web] could not be placed", metric.GangFailure)
+}
@@ -114,6 +114,16 @@ type GenericScheduler struct {
 	blocked        *structs.Evaluation
 	failedTGAllocs map[string]*structs.AllocMetric
 	queuedAllocs   map[string]int
+
+	// rebalanceTargets are the nodes that allocations migrated by a
+	// rebalance evaluation should be placed on, keyed by allocation ID.
+	rebalanceTargets map[string]*structs.Node
+
+	// windowOpens is when the earliest scheduling window of the task groups
+	// whose placements or updates were deferred opens, and windowEval is the
+	// evaluation created to retry them then.
+	windowOpens time.Time
+	windowEval  *structs.Evaluation
 }
 
 // NewServiceScheduler is a factory function to instantiate a new service scheduler
@@ -164,7 +174,8 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 		structs.EvalTriggerPeriodicJob, structs.EvalTriggerMaxPlans,
 		structs.EvalTriggerDeploymentWatcher, structs.EvalTriggerRetryFailedAlloc,
 		structs.EvalTriggerFailedFollowUp, structs.EvalTriggerPreemption,
-		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect:
+		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect,
+		structs.EvalTriggerRebalance, structs.EvalTriggerSchedulingWindow:
 	default:
 		desc := fmt.Sprintf("scheduler cannot handle '%s' evaluation reason",
 			eval.TriggeredBy)
@@ -255,6 +266,7 @@ func (s *GenericScheduler) process() (bool, error) {
 	}
 	s.queuedAllocs = make(map[string]int, numTaskGroups)
 	s.followUpEvals = nil
+	s.rebalanceTargets = make(map[string]*structs.Node)
 
 	// Create a plan
 	s.plan = s.eval.MakePlan(s.job)
@@ -301,6 +313,13 @@ func (s *GenericScheduler) process() (bool, error) {
 		s.logger.Debug("failed to place all allocations, blocked eval created", "blocked_eval_id", s.blocked.ID)
 	}
 
+	// If placements or updates were deferred until a scheduling window opens,
+	// create an evaluation to retry them then.
+	if err := s.createWindowEval(); err != nil {
+		s.logger.Error("failed to make scheduling window eval", "error", err)
+		return false, err
+	}
+
 	// If the plan is a no-op, we can bail. If AnnotatePlan is set submit the plan
 	// anyways to get the annotations.
 	if s.plan.IsNoOp() && !s.eval.AnnotatePlan {
@@ -375,6 +394,15 @@ func (s *GenericScheduler) computeJobAllocs() error {
 	// nodes to lost, but only if the scheduler has already marked them
 	updateNonTerminalAllocsToLost(s.plan, tainted, allocs)
 
+	// Mark any allocations that would score better elsewhere for migration
+	if s.eval.TriggeredBy == structs.EvalTriggerRebalance {
+		allocs, err = s.computeRebalance(allocs, tainted)
+		if err != nil {
+			return fmt.Errorf("failed to compute rebalance for job '%s': %v",
+				s.eval.JobID, err)
+		}
+	}
+
 	reconciler := NewAllocReconciler(s.logger,
 		genericAllocUpdateFn(s.ctx, s.stack, s.eval.ID),
 		s.batch, s.eval.JobID, s.job, s.deployment, allocs, tainted, s.eval.ID,
@@ -383,6 +411,10 @@ func (s *GenericScheduler) computeJobAllocs() error {
 	results := reconciler.Compute()
 	s.logger.Debug("reconciled current state with desired state", "results", log.Fmt("%#v", results))
 
+	// Defer the placements and updates of task groups outside of their
+	// scheduling window
+	s.applySchedulingWindows(results)
+
 	if s.eval.AnnotatePlan {
 		s.plan.Annotations = &structs.PlanAnnotations{
 			DesiredTGUpdates: results.desiredTGUpdates,
@@ -498,7 +530,7 @@ func (s *GenericScheduler) downgradedJobForPlacement(p placementResult) (string,
 // destructive updates to place and the set of new placements to place.
 func (s *GenericScheduler) computePlacements(destructive, place []placementResult) error {
 	// Get the base nodes
-	nodes, _, byDC, err := readyNodesInDCs(s.state, s.job.Datacenters)
+	nodes, _, byDC, err := readyNodesInDCsAndPool(s.state, s.job.Datacenters, s.job.NodePool)
 	if err != nil {
 		return err
 	}
@@ -514,6 +546,10 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 	// Capture current time to use as the start time for any rescheduled allocations
 	now := time.Now()
 
+	// Track the placements of task groups that are part of a gang, in case
+	// they need to be withheld
+	var gangPlacements []*gangPlacement
+
 	// Have to handle destructive changes first as we need to discount their
 	// resources. To understand this imagine the resources were reduced and the
 	// count was scaled up.
@@ -653,6 +689,14 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 				// Track the placement
 				s.plan.AppendAlloc(alloc, downgradedJob)
 
+				if s.job.LookupTaskGroup(tg.Name).GangName() != "" {
+					placement := &gangPlacement{alloc: alloc}
+					if stopPrevAlloc {
+						placement.stopped = prevAllocation
+					}
+					gangPlacements = append(gangPlacements, placement)
+				}
+
 			} else {
 				// Lazy initialize the failed map
 				if s.failedTGAllocs == nil {
@@ -675,6 +719,7 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 		}
 	}
 
+	s.withholdGangPlacements(gangPlacements)
 	return nil
 }
 
@@ -793,6 +838,11 @@ func (s *GenericScheduler) findPreferredNode(place placementResult) (*structs.No
 			return preferredNode, nil
 		}
 	}
+	if prev := place.PreviousAllocation(); prev != nil {
+		if target, ok := s.rebalanceTargets[prev.ID]; ok {
+			return target, nil
+		}
+	}
 	return nil, nil
 }
 
@@ -831,7 +881,7 @@ func (s *GenericScheduler) handlePreemptions(option *RankedNode, alloc *structs.
 		preemptedAllocIDs = append(preemptedAllocIDs, stop.ID)
 
 		if s.eval.AnnotatePlan && s.plan.Annotations != nil {
-			s.plan.Annotations.PreemptedAllocs = append(s.plan.Annotations.PreemptedAllocs, stop.Stub(nil))
+			s.plan.Annotations.AppendPreemptedAlloc(stop, option.PreemptionReasons[stop.ID])
 			if s.plan.Annotations.DesiredTGUpdates != nil {
 				desired := s.plan.Annotations.DesiredTGUpdates[missing.TaskGroup().Name]
 				desired.Preemptions += 1
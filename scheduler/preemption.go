@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"fmt"
 	"math"
 	"sort"
 
@@ -117,6 +118,17 @@ type Preemptor struct {
 	// currentAllocs is the candidate set used to find preemptible allocations
 	currentAllocs []*structs.Allocation
 
+	// policy is the preemption policy of the task group being placed
+	policy *structs.PreemptionPolicy
+
+	// budget is the number of allocations that may still be preempted for
+	// the task group in this evaluation, or -1 if there is no limit
+	budget int
+
+	// reasons explains why each allocation chosen for preemption was
+	// chosen, keyed by allocation ID
+	reasons map[string]string
+
 	// ctx is the context from the scheduler stack
 	ctx Context
 }
@@ -127,19 +139,76 @@ func NewPreemptor(jobPriority int, ctx Context, jobID *structs.NamespacedID) *Pr
 		jobPriority:        jobPriority,
 		jobID:              jobID,
 		allocDetails:       make(map[string]*allocInfo),
+		budget:             -1,
+		reasons:            make(map[string]string),
 		ctx:                ctx,
 	}
 }
 
-// SetNode sets the node
-func (p *Preemptor) SetNode(node *structs.Node) {
-	nodeRemainingResources := node.ComparableResources()
+// SetPolicy sets the preemption policy of the task group being placed, and
+// the number of allocations already preempted for it in this evaluation.
+func (p *Preemptor) SetPolicy(policy *structs.PreemptionPolicy, preempted int) {
+	p.policy = policy
+	p.budget = -1
+	if policy != nil && policy.MaxPreemptions > 0 {
+		p.budget = policy.MaxPreemptions - preempted
+		if p.budget < 0 {
+			p.budget = 0
+		}
+	}
+}
+
+// Reasons returns why each of the allocations chosen for preemption was
+// chosen, keyed by allocation ID.
+func (p *Preemptor) Reasons() map[string]string {
+	return p.reasons
+}
 
-	// Subtract the reserved resources of the node
-	if c := node.ComparableReservedResources(); c != nil {
-		nodeRemainingResources.Subtract(c)
+// preemptible returns whether the allocation may be preempted by the task
+// group being placed. Only allocations of jobs with a priority at least 10
+// lower than the job being placed may be preempted, and the preemption
+// policies of both task groups may further protect the allocation.
+func (p *Preemptor) preemptible(alloc *structs.Allocation) bool {
+	if p.jobPriority-alloc.Job.Priority < 10 {
+		return false
+	}
+	if p.policy != nil && p.policy.ProtectedPriority > 0 &&
+		alloc.Job.Priority >= p.policy.ProtectedPriority {
+		return false
+	}
+	if victim := alloc.Job.LookupPreemptionPolicy(alloc.TaskGroup); victim != nil && victim.Protected {
+		return false
 	}
-	p.nodeRemainingResources = nodeRemainingResources
+	return true
+}
+
+// choose records the allocations chosen for preemption along with the
+// resource each one frees. It returns nil if preempting the allocations
+// would exceed the preemption budget of the task group.
+func (p *Preemptor) choose(allocs []*structs.Allocation, frees func(*structs.Allocation) string) []*structs.Allocation {
+	if len(allocs) == 0 {
+		return nil
+	}
+	if p.budget >= 0 && len(allocs) > p.budget {
+		p.ctx.Logger().Named("preemption").Debug("preemption budget exhausted",
+			"needed", len(allocs), "remaining", p.budget)
+		return nil
+	}
+	if p.budget >= 0 {
+		p.budget -= len(allocs)
+	}
+	for _, alloc := range allocs {
+		p.reasons[alloc.ID] = fmt.Sprintf("job priority %d is lower than %d, frees %s",
+			alloc.Job.Priority, p.jobPriority, frees(alloc))
+	}
+	return allocs
+}
+
+// SetNode sets the node
+func (p *Preemptor) SetNode(node *structs.Node) {
+	// Start from the allocatable resources of the node, which subtract its
+	// reserved resources and apply its oversubscription ratios
+	p.nodeRemainingResources = node.ComparableAllocatableResources()
 }
 
 // SetCandidates initializes the candidate set from which preemptions are chosen
@@ -205,7 +274,7 @@ func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources)
 	}
 
 	// Group candidates by priority, filter out ineligible allocs
-	allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, p.currentAllocs)
+	allocsByPriority := p.filterAndGroupPreemptibleAllocs(p.currentAllocs)
 
 	var bestAllocs []*structs.Allocation
 	allRequirementsMet := false
@@ -260,8 +329,9 @@ func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources)
 	basePreemptionResource := GetBasePreemptionResourceFactory()
 	resourcesNeeded = resourceAsk.Comparable()
 	filteredBestAllocs := p.filterSuperset(bestAllocs, p.nodeRemainingResources, resourcesNeeded, basePreemptionResource)
-	return filteredBestAllocs
-
+	return p.choose(filteredBestAllocs, func(*structs.Allocation) string {
+		return "cpu, memory and disk"
+	})
 }
 
 // PreemptForNetwork tries to find allocations to preempt to meet network resources.
@@ -306,8 +376,8 @@ func (p *Preemptor) PreemptForNetwork(networkResourceAsk *structs.NetworkResourc
 		// We only check first network - TODO: why?!?!
 		net := networks[0]
 
-		// Filter out alloc that's ineligible due to priority
-		if p.jobPriority-alloc.Job.Priority < 10 {
+		// Filter out alloc that's ineligible due to priority or policy
+		if !p.preemptible(alloc) {
 			// Populate any reserved ports used by
 			// this allocation that cannot be preempted
 			for _, port := range net.ReservedPorts {
@@ -338,6 +408,10 @@ func (p *Preemptor) PreemptForNetwork(networkResourceAsk *structs.NetworkResourc
 	freeBandwidth := 0
 	preemptedDevice := ""
 
+	// preemptedPorts tracks the reserved port each allocation preempted to
+	// free a port is chosen for
+	var preemptedPorts map[string]int
+
 OUTER:
 	for device, currentAllocs := range deviceToAllocs {
 		preemptedDevice = device
@@ -359,6 +433,9 @@ OUTER:
 		// usedPortToAlloc tracks used ports by allocs in this device
 		usedPortToAlloc := make(map[int]*structs.Allocation)
 
+		// Reset the ports preempted allocations are chosen for
+		preemptedPorts = make(map[string]int)
+
 		// First try to satisfy needed reserved ports
 		if len(reservedPortsNeeded) > 0 {
 
@@ -379,6 +456,7 @@ OUTER:
 					allocResources := p.allocDetails[alloc.ID].resources
 					preemptedBandwidth += allocResources.Flattened.Networks[0].MBits
 					allocsToPreempt = append(allocsToPreempt, alloc)
+					preemptedPorts[alloc.ID] = port.Value
 				} else {
 					// Check if a higher priority allocation is using this port
 					// It cant be preempted so we skip to the next device
@@ -400,7 +478,7 @@ OUTER:
 		}
 
 		// Split by priority
-		allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, currentAllocs)
+		allocsByPriority := p.filterAndGroupPreemptibleAllocs(currentAllocs)
 
 		for _, allocsGrp := range allocsByPriority {
 			allocs := allocsGrp.allocs
@@ -450,7 +528,12 @@ OUTER:
 		},
 	}
 	filteredBestAllocs := p.filterSuperset(allocsToPreempt, nodeRemainingResources, resourcesNeeded, preemptionResourceFactory)
-	return filteredBestAllocs
+	return p.choose(filteredBestAllocs, func(alloc *structs.Allocation) string {
+		if port, ok := preemptedPorts[alloc.ID]; ok {
+			return fmt.Sprintf("reserved port %d on %s", port, preemptedDevice)
+		}
+		return fmt.Sprintf("bandwidth on %s", preemptedDevice)
+	})
 }
 
 // deviceGroupAllocs represents a group of allocs that share a device
@@ -517,7 +600,7 @@ func (p *Preemptor) PreemptForDevice(ask *structs.RequestedDevice, devAlloc *dev
 OUTER:
 	for deviceIDTuple, allocsGrp := range deviceToAllocs {
 		// First group and sort allocations using this device by priority
-		allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, allocsGrp.allocs)
+		allocsByPriority := p.filterAndGroupPreemptibleAllocs(allocsGrp.allocs)
 
 		// Reset preempted count for this device
 		preemptedCount := 0
@@ -548,7 +631,9 @@ OUTER:
 
 	// Find the combination of allocs with lowest net priority
 	if len(preemptionOptions) > 0 {
-		return selectBestAllocs(preemptionOptions, int(neededCount))
+		return p.choose(selectBestAllocs(preemptionOptions, int(neededCount)), func(*structs.Allocation) string {
+			return fmt.Sprintf("instances of device %q", ask.Name)
+		})
 	}
 
 	return nil
@@ -659,8 +744,8 @@ func scoreForNetwork(resourceUsed *structs.NetworkResource, resourceNeeded *stru
 }
 
 // filterAndGroupPreemptibleAllocs groups allocations by priority after filtering allocs
-// that are not preemptible based on the jobPriority arg
-func filterAndGroupPreemptibleAllocs(jobPriority int, current []*structs.Allocation) []*groupedAllocs {
+// that are not preemptible based on the job priority and preemption policies
+func (p *Preemptor) filterAndGroupPreemptibleAllocs(current []*structs.Allocation) []*groupedAllocs {
 	allocsByPriority := make(map[int][]*structs.Allocation)
 	for _, alloc := range current {
 		if alloc.Job == nil {
@@ -670,7 +755,7 @@ func filterAndGroupPreemptibleAllocs(jobPriority int, current []*structs.Allocat
 		// Skip allocs whose priority is within a delta of 10
 		// This also skips any allocs of the current job
 		// for which we are attempting preemption
-		if jobPriority-alloc.Job.Priority < 10 {
+		if !p.preemptible(alloc) {
 			continue
 		}
 		grpAllocs, ok := allocsByPriority[alloc.Job.Priority]
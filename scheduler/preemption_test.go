@@ -1385,6 +1385,118 @@ func TestPreemption(t *testing.T) {
 	}
 }
 
+// TestPreemption_Policy asserts that the preemption policies of the task
+// group being placed and of the preempted allocations are enforced
+func TestPreemption_Policy(t *testing.T) {
+	ci.Parallel(t)
+
+	lowPrioJob := mock.Job()
+	lowPrioJob.Priority = 30
+
+	protectedJob := mock.Job()
+	protectedJob.Priority = 20
+	protectedJob.TaskGroups[0].Preemption = &structs.PreemptionPolicy{Protected: true}
+
+	// The node is full, and placing the ask requires preempting two of the
+	// low priority allocations
+	allocIDs := []string{uuid.Generate(), uuid.Generate(), uuid.Generate(), uuid.Generate()}
+	resources := &structs.Resources{CPU: 1000, MemoryMB: 1024}
+
+	type testCase struct {
+		desc        string
+		policy      *structs.PreemptionPolicy
+		preempted   int
+		protected   string
+		priorPlaced bool
+	}
+
+	testCases := []testCase{
+		{
+			desc:      "no policy",
+			preempted: 2,
+			protected: allocIDs[0],
+		},
+		{
+			desc:      "within budget",
+			policy:    &structs.PreemptionPolicy{MaxPreemptions: 2},
+			preempted: 2,
+			protected: allocIDs[0],
+		},
+		{
+			desc:   "exceeds budget",
+			policy: &structs.PreemptionPolicy{MaxPreemptions: 1},
+		},
+		{
+			desc:        "budget used by earlier placements",
+			policy:      &structs.PreemptionPolicy{MaxPreemptions: 3},
+			priorPlaced: true,
+		},
+		{
+			desc:   "protected priority",
+			policy: &structs.PreemptionPolicy{ProtectedPriority: 30},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			node := mock.Node()
+			state, ctx := testContext(t)
+			require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+			allocs := []*structs.Allocation{
+				createAlloc(allocIDs[0], protectedJob, resources),
+				createAlloc(allocIDs[1], lowPrioJob, resources),
+				createAlloc(allocIDs[2], lowPrioJob, resources),
+				createAlloc(allocIDs[3], lowPrioJob, resources),
+			}
+			for _, alloc := range allocs {
+				alloc.NodeID = node.ID
+			}
+			require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, allocs))
+
+			// Earlier placements of the task group on another node
+			// preempted two allocations
+			if tc.priorPlaced {
+				placed := mock.Alloc()
+				ctx.plan.AppendAlloc(placed, nil)
+				for i := 0; i < 2; i++ {
+					preempted := mock.Alloc()
+					preempted.NodeID = placed.NodeID
+					ctx.plan.AppendPreemptedAlloc(preempted, placed.ID)
+				}
+			}
+
+			static := NewStaticRankIterator(ctx, []*RankedNode{{Node: node}})
+			binPackIter := NewBinPackIterator(ctx, static, true, 80, testSchedulerConfig)
+			job := mock.Job()
+			job.Priority = 80
+			binPackIter.SetJob(job)
+
+			taskGroup := job.TaskGroups[0]
+			taskGroup.Networks = nil
+			taskGroup.Tasks[0].Services = nil
+			taskGroup.Tasks[0].Resources = &structs.Resources{CPU: 1500, MemoryMB: 256}
+			taskGroup.Preemption = tc.policy
+			binPackIter.SetTaskGroup(taskGroup)
+
+			option := binPackIter.Next()
+			if tc.preempted == 0 {
+				require.Nil(t, option)
+				return
+			}
+
+			require.NotNil(t, option)
+			require.Len(t, option.PreemptedAllocs, tc.preempted)
+			require.Len(t, option.PreemptionReasons, tc.preempted)
+			for _, alloc := range option.PreemptedAllocs {
+				require.NotEqual(t, tc.protected, alloc.ID)
+				require.Equal(t, "job priority 30 is lower than 80, frees cpu, memory and disk",
+					option.PreemptionReasons[alloc.ID])
+			}
+		})
+	}
+}
+
 // TestPreemptionMultiple tests evicting multiple allocations in the same time
 func TestPreemptionMultiple(t *testing.T) {
 	ci.Parallel(t)
@@ -1517,6 +1629,71 @@ func TestPreemptionMultiple(t *testing.T) {
 	require.Equal(t, allocIDs, preempted)
 }
 
+// TestPreemption_PlanAnnotations asserts that the reasons allocations were
+// preempted are annotated, and that the preemption budget of a task group
+// holds across its placements
+func TestPreemption_PlanAnnotations(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	lowPrioJob := mock.Job()
+	lowPrioJob.Priority = 20
+	lowPrioJob.TaskGroups[0].Count = 4
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), lowPrioJob))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 4; i++ {
+		alloc := createAlloc(uuid.Generate(), lowPrioJob, &structs.Resources{CPU: 1000, MemoryMB: 1024})
+		alloc.NodeID = node.ID
+		alloc.Name = fmt.Sprintf("my-job.web[%d]", i)
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	// The first placement preempts two allocations, which uses up the
+	// preemption budget of the task group before the second placement
+	job := mock.Job()
+	job.Priority = 80
+	job.Preemption = &structs.PreemptionPolicy{MaxPreemptions: 2}
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Networks = nil
+	job.TaskGroups[0].Tasks[0].Services = nil
+	job.TaskGroups[0].Tasks[0].Resources = &structs.Resources{CPU: 1500, MemoryMB: 256}
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	eval := &structs.Evaluation{
+		Namespace:    structs.DefaultNamespace,
+		ID:           uuid.Generate(),
+		Priority:     job.Priority,
+		TriggeredBy:  structs.EvalTriggerJobRegister,
+		JobID:        job.ID,
+		Status:       structs.EvalStatusPending,
+		AnnotatePlan: true,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+	require.Len(t, plan.NodeAllocation[node.ID], 1)
+	require.Len(t, plan.NodePreemptions[node.ID], 2)
+
+	annotations := plan.Annotations
+	require.NotNil(t, annotations)
+	require.Len(t, annotations.PreemptedAllocs, 2)
+	for _, stub := range annotations.PreemptedAllocs {
+		require.Equal(t, "job priority 20 is lower than 80, frees cpu, memory and disk",
+			annotations.PreemptionReasons[stub.ID])
+	}
+
+	require.Len(t, h.Evals, 1)
+	require.Contains(t, h.Evals[0].FailedTGAllocs, "web")
+}
+
 // helper method to create allocations with given jobs and resources
 func createAlloc(id string, job *structs.Job, resource *structs.Resources) *structs.Allocation {
 	return createAllocInner(id, job, resource, nil, nil)
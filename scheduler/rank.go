@@ -3,6 +3,8 @@ package scheduler
 import (
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/nomad/lib/cpuset"
 
@@ -33,6 +35,10 @@ type RankedNode struct {
 	// PreemptedAllocs is used by the BinpackIterator to identify allocs
 	// that should be preempted in order to make the placement
 	PreemptedAllocs []*structs.Allocation
+
+	// PreemptionReasons explains why each of the PreemptedAllocs was
+	// chosen, keyed by allocation ID
+	PreemptionReasons map[string]string
 }
 
 func (r *RankedNode) GoString() string {
@@ -154,7 +160,9 @@ type BinPackIterator struct {
 	evict                  bool
 	priority               int
 	jobId                  structs.NamespacedID
+	jobPreemption          *structs.PreemptionPolicy
 	taskGroup              *structs.TaskGroup
+	preemption             *structs.PreemptionPolicy
 	memoryOversubscription bool
 	scoreFit               func(*structs.Node, *structs.ComparableResources) float64
 }
@@ -181,13 +189,55 @@ func NewBinPackIterator(ctx Context, source RankIterator, evict bool, priority i
 	return iter
 }
 
+// SetSchedulerConfiguration updates the scoring algorithm and memory
+// oversubscription setting, such as when the node pool of a job overrides the
+// cluster scheduler configuration.
+func (iter *BinPackIterator) SetSchedulerConfiguration(schedConfig *structs.SchedulerConfiguration) {
+	iter.scoreFit = structs.ScoreFitBinPack
+	if schedConfig.EffectiveSchedulerAlgorithm() == structs.SchedulerAlgorithmSpread {
+		iter.scoreFit = structs.ScoreFitSpread
+	}
+	iter.memoryOversubscription = schedConfig != nil && schedConfig.MemoryOversubscriptionEnabled
+}
+
 func (iter *BinPackIterator) SetJob(job *structs.Job) {
 	iter.priority = job.Priority
 	iter.jobId = job.NamespacedID()
+	iter.jobPreemption = job.Preemption
 }
 
 func (iter *BinPackIterator) SetTaskGroup(taskGroup *structs.TaskGroup) {
 	iter.taskGroup = taskGroup
+
+	// The preemption policy of the task group overrides that of the job
+	iter.preemption = iter.jobPreemption
+	if taskGroup.Preemption != nil {
+		iter.preemption = taskGroup.Preemption
+	}
+}
+
+// taskGroupPreemptions counts the allocations already preempted in the plan
+// to place allocations of the task group.
+func (iter *BinPackIterator) taskGroupPreemptions() int {
+	plan := iter.ctx.Plan()
+	placed := make(map[string]struct{})
+	for _, allocs := range plan.NodeAllocation {
+		for _, alloc := range allocs {
+			if alloc.TaskGroup == iter.taskGroup.Name {
+				placed[alloc.ID] = struct{}{}
+			}
+		}
+	}
+
+	count := 0
+	for _, allocs := range plan.NodePreemptions {
+		for _, alloc := range allocs {
+			if _, ok := placed[alloc.PreemptedByAllocation]; ok {
+				count++
+			}
+		}
+	}
+	return count
 }
 
 func (iter *BinPackIterator) Next() *RankedNode {
@@ -268,6 +318,13 @@ OUTER:
 		}
 		preemptor.SetPreemptions(currentPreemptions)
 
+		// Bound the preemptions of the task group by its preemption policy
+		preempted := 0
+		if iter.evict && iter.preemption != nil && iter.preemption.MaxPreemptions > 0 {
+			preempted = iter.taskGroupPreemptions()
+		}
+		preemptor.SetPolicy(iter.preemption, preempted)
+
 		// Check if we need task group network resource
 		if len(iter.taskGroup.Networks) > 0 {
 			ask := iter.taskGroup.Networks[0].Copy()
@@ -482,8 +539,28 @@ OUTER:
 					continue OUTER
 				}
 
+				// Pick the cores from a single NUMA node if the task asked for
+				// it, and bind the memory of the task to that NUMA node
+				var reservedCores []uint16
+				if topology := option.Node.NodeResources.NUMA; topology != nil && task.Resources.NUMA.Requested() {
+					used := numaMemoryUsed(topology, proposed, total.Tasks)
+					numaNode, cores := selectNUMACores(topology, availableCPUSet, used,
+						task.Resources.Cores, int64(task.Resources.MemoryMB))
+					if numaNode == nil && task.Resources.NUMA.Required() {
+						iter.ctx.Metrics().ExhaustedNode(option.Node, "numa")
+						continue OUTER
+					}
+					if numaNode != nil {
+						reservedCores = cores
+						taskResources.Memory.NUMANodes = []uint16{uint16(numaNode.ID)}
+					}
+				}
+				if reservedCores == nil {
+					reservedCores = availableCPUSet.ToSlice()[0:task.Resources.Cores]
+				}
+
 				// Set the task's reserved cores
-				taskResources.Cpu.ReservedCores = availableCPUSet.ToSlice()[0:task.Resources.Cores]
+				taskResources.Cpu.ReservedCores = reservedCores
 				// Total CPU usage on the node is still tracked by CPUShares. Even though the task will have the entire
 				// core reserved, we still track overall usage by cpu shares.
 				taskResources.Cpu.CpuShares = option.Node.NodeResources.Cpu.SharesPerCore() * int64(task.Resources.Cores)
@@ -531,6 +608,7 @@ OUTER:
 		}
 		if len(allocsToPreempt) > 0 {
 			option.PreemptedAllocs = allocsToPreempt
+			option.PreemptionReasons = preemptor.Reasons()
 		}
 
 		// Score the fit normally otherwise
@@ -758,6 +836,88 @@ func matchesAffinity(ctx Context, affinity *structs.Affinity, option *structs.No
 	return checkAffinity(ctx, affinity.Operand, lVal, rVal, lOk, rOk)
 }
 
+// NodeScoreIterator is used to apply a weighted score to nodes according to
+// the node scorers of the scheduler configuration, which are expressions over
+// node attributes and metadata defined by the operator.
+type NodeScoreIterator struct {
+	ctx       Context
+	source    RankIterator
+	scorers   []*structs.NodeScorer
+	sumWeight float64
+}
+
+// NewNodeScoreIterator is used to create a NodeScoreIterator that applies
+// the operator defined node scorers.
+func NewNodeScoreIterator(ctx Context, source RankIterator) *NodeScoreIterator {
+	return &NodeScoreIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+// SetSchedulerConfiguration sets the node scorers to apply.
+func (iter *NodeScoreIterator) SetSchedulerConfiguration(schedConfig *structs.SchedulerConfiguration) {
+	iter.scorers = nil
+	iter.sumWeight = 0
+	if schedConfig == nil {
+		return
+	}
+	iter.scorers = schedConfig.NodeScorers
+	for _, scorer := range iter.scorers {
+		iter.sumWeight += math.Abs(float64(scorer.Weight))
+	}
+}
+
+func (iter *NodeScoreIterator) Reset() {
+	iter.source.Reset()
+}
+
+func (iter *NodeScoreIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil {
+		return nil
+	}
+	if len(iter.scorers) == 0 || iter.sumWeight == 0 {
+		return option
+	}
+
+	totalScore := 0.0
+	for _, scorer := range iter.scorers {
+		val, ok := nodeScorerValue(scorer, option.Node)
+		if !ok {
+			// Nodes for which the expression can't be evaluated get the
+			// worst score for the weight, so that they're never preferred
+			// over nodes for which it can
+			val = 0
+			if scorer.Weight < 0 {
+				val = 1
+			}
+		}
+		totalScore += float64(scorer.Weight) * val
+	}
+	normScore := totalScore / iter.sumWeight
+	option.Scores = append(option.Scores, normScore)
+	iter.ctx.Metrics().ScoreNode(option.Node, "node-score", normScore)
+	return option
+}
+
+// nodeScorerValue returns the value of the scorer's expression for the node,
+// scaled to the range from 0 to 1. It returns false if the expression doesn't
+// resolve to a number for the node.
+func nodeScorerValue(scorer *structs.NodeScorer, node *structs.Node) (float64, bool) {
+	val, ok := resolveTarget(scorer.Expression, node)
+	if !ok {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+	if err != nil || math.IsNaN(f) {
+		return 0, false
+	}
+
+	scaled := (f - scorer.Min) / (scorer.Max - scorer.Min)
+	return math.Max(0, math.Min(1, scaled)), true
+}
+
 // ScoreNormalizationIterator is used to combine scores from various prior
 // iterators and combine them into one final score. The current implementation
 // averages the scores together.
@@ -866,3 +1026,77 @@ func preemptionScore(netPriority float64) float64 {
 	// This function manifests as an s curve that asympotically moves towards zero for large values of netPriority
 	return 1.0 / (1 + math.Exp(rate*(netPriority-origin)))
 }
+
+// numaMemoryUsed returns the memory reserved on each NUMA node of the
+// topology, keyed by NUMA node ID. The memory of a task bound to NUMA nodes
+// is attributed to those NUMA nodes. The memory of every other task may be
+// allocated from any NUMA node, so it is attributed to all NUMA nodes in
+// proportion to their size.
+func numaMemoryUsed(topology *structs.NodeNUMAResources, allocs []*structs.Allocation,
+	tasks map[string]*structs.AllocatedTaskResources) map[int]int64 {
+
+	used := make(map[int]int64, len(topology.Nodes))
+	var unbound int64
+	add := func(tr *structs.AllocatedTaskResources) {
+		if len(tr.Memory.NUMANodes) == 0 {
+			unbound += tr.Memory.MemoryMB
+			return
+		}
+		share := tr.Memory.MemoryMB / int64(len(tr.Memory.NUMANodes))
+		for _, id := range tr.Memory.NUMANodes {
+			used[int(id)] += share
+		}
+	}
+
+	for _, alloc := range allocs {
+		if alloc.AllocatedResources == nil {
+			continue
+		}
+		for _, tr := range alloc.AllocatedResources.Tasks {
+			add(tr)
+		}
+	}
+	for _, tr := range tasks {
+		add(tr)
+	}
+
+	var total int64
+	for _, node := range topology.Nodes {
+		total += node.MemoryMB
+	}
+	if unbound > 0 && total > 0 {
+		for _, node := range topology.Nodes {
+			used[node.ID] += unbound * node.MemoryMB / total
+		}
+	}
+	return used
+}
+
+// selectNUMACores returns a single NUMA node which has the requested number
+// of cores available and the requested memory free, along with the cores to
+// reserve on it. It returns nil if no such NUMA node exists. When several
+// NUMA nodes fit, the one with the fewest available cores is picked so that
+// larger NUMA nodes remain free for larger tasks.
+func selectNUMACores(topology *structs.NodeNUMAResources, available cpuset.CPUSet,
+	used map[int]int64, cores int, memoryMB int64) (*structs.NUMANode, []uint16) {
+
+	var bestNode *structs.NUMANode
+	var best []uint16
+	for _, node := range topology.Nodes {
+		if node.MemoryMB-used[node.ID] < memoryMB {
+			continue
+		}
+
+		free := cpuset.New(node.Cores...).Intersection(available).ToSlice()
+		if len(free) < cores {
+			continue
+		}
+		if bestNode == nil || len(free) < len(best) {
+			bestNode, best = node, free
+		}
+	}
+	if bestNode == nil {
+		return nil, nil
+	}
+	return bestNode, best[:cores]
+}
@@ -1381,6 +1381,182 @@ func TestBinPackIterator_ReservedCores(t *testing.T) {
 	require.Equal([]uint16{1}, out[0].TaskResources["web"].Cpu.ReservedCores)
 }
 
+func TestBinPackIterator_NUMA(t *testing.T) {
+	cases := []struct {
+		name      string
+		affinity  string
+		cores     int
+		memoryMB  int
+		expect    []uint16
+		expectMem []uint16
+		exhausted bool
+	}{
+		{
+			name:     "none spans numa nodes",
+			affinity: structs.NUMAAffinityNone,
+			cores:    2,
+			memoryMB: 1024,
+			expect:   []uint16{1, 2},
+		},
+		{
+			name:      "prefer single numa node",
+			affinity:  structs.NUMAAffinityPrefer,
+			cores:     2,
+			memoryMB:  1024,
+			expect:    []uint16{2, 3},
+			expectMem: []uint16{1},
+		},
+		{
+			name:      "require single numa node",
+			affinity:  structs.NUMAAffinityRequire,
+			cores:     2,
+			memoryMB:  1024,
+			expect:    []uint16{2, 3},
+			expectMem: []uint16{1},
+		},
+		{
+			name:      "prefer best fit numa node",
+			affinity:  structs.NUMAAffinityPrefer,
+			cores:     1,
+			memoryMB:  1024,
+			expect:    []uint16{1},
+			expectMem: []uint16{0},
+		},
+		{
+			name:      "require numa node with free memory",
+			affinity:  structs.NUMAAffinityRequire,
+			cores:     1,
+			memoryMB:  1200,
+			expect:    []uint16{2},
+			expectMem: []uint16{1},
+		},
+		{
+			name:      "require counts memory of unbound tasks",
+			affinity:  structs.NUMAAffinityRequire,
+			cores:     1,
+			memoryMB:  1600,
+			exhausted: true,
+		},
+		{
+			name:     "prefer falls back to spanning numa nodes",
+			affinity: structs.NUMAAffinityPrefer,
+			cores:    3,
+			memoryMB: 1024,
+			expect:   []uint16{1, 2, 3},
+		},
+		{
+			name:      "require exhausts node",
+			affinity:  structs.NUMAAffinityRequire,
+			cores:     3,
+			memoryMB:  1024,
+			exhausted: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			state, ctx := testContext(t)
+			node := &structs.Node{
+				ID: uuid.Generate(),
+				NodeResources: &structs.NodeResources{
+					Cpu: structs.NodeCpuResources{
+						CpuShares:          4096,
+						TotalCpuCores:      4,
+						ReservableCpuCores: []uint16{0, 1, 2, 3},
+					},
+					Memory: structs.NodeMemoryResources{
+						MemoryMB: 4096,
+					},
+					NUMA: &structs.NodeNUMAResources{
+						Nodes: []*structs.NUMANode{
+							{ID: 0, Cores: []uint16{0, 1}, MemoryMB: 2048, Distances: []int{10, 21}},
+							{ID: 1, Cores: []uint16{2, 3}, MemoryMB: 2048, Distances: []int{21, 10}},
+						},
+					},
+				},
+			}
+			static := NewStaticRankIterator(ctx, []*RankedNode{{Node: node}})
+
+			// Add an existing allocation bound to the first NUMA node, and
+			// one whose memory isn't bound to any NUMA node
+			j := mock.Job()
+			unbound := &structs.Allocation{
+				Namespace: structs.DefaultNamespace,
+				ID:        uuid.Generate(),
+				EvalID:    uuid.Generate(),
+				NodeID:    node.ID,
+				JobID:     j.ID,
+				Job:       j,
+				AllocatedResources: &structs.AllocatedResources{
+					Tasks: map[string]*structs.AllocatedTaskResources{
+						"web": {
+							Memory: structs.AllocatedMemoryResources{
+								MemoryMB: 1024,
+							},
+						},
+					},
+				},
+				DesiredStatus: structs.AllocDesiredStatusRun,
+				ClientStatus:  structs.AllocClientStatusPending,
+				TaskGroup:     "web",
+			}
+			alloc := &structs.Allocation{
+				Namespace: structs.DefaultNamespace,
+				ID:        uuid.Generate(),
+				EvalID:    uuid.Generate(),
+				NodeID:    node.ID,
+				JobID:     j.ID,
+				Job:       j,
+				AllocatedResources: &structs.AllocatedResources{
+					Tasks: map[string]*structs.AllocatedTaskResources{
+						"web": {
+							Cpu: structs.AllocatedCpuResources{
+								CpuShares:     1024,
+								ReservedCores: []uint16{0},
+							},
+							Memory: structs.AllocatedMemoryResources{
+								MemoryMB:  512,
+								NUMANodes: []uint16{0},
+							},
+						},
+					},
+				},
+				DesiredStatus: structs.AllocDesiredStatusRun,
+				ClientStatus:  structs.AllocClientStatusPending,
+				TaskGroup:     "web",
+			}
+			require.NoError(t, state.UpsertJobSummary(999, mock.JobSummary(alloc.JobID)))
+			require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc, unbound}))
+
+			taskGroup := &structs.TaskGroup{
+				EphemeralDisk: &structs.EphemeralDisk{},
+				Tasks: []*structs.Task{
+					{
+						Name: "web",
+						Resources: &structs.Resources{
+							Cores:    tc.cores,
+							MemoryMB: tc.memoryMB,
+							NUMA:     &structs.NUMA{Affinity: tc.affinity},
+						},
+					},
+				},
+			}
+			binp := NewBinPackIterator(ctx, static, false, 0, testSchedulerConfig)
+			binp.SetTaskGroup(taskGroup)
+
+			out := collectRanked(NewScoreNormalizationIterator(ctx, binp))
+			if tc.exhausted {
+				require.Empty(t, out)
+				require.Equal(t, 1, ctx.metrics.DimensionExhausted["numa"])
+				return
+			}
+			require.Len(t, out, 1)
+			require.Equal(t, tc.expect, out[0].TaskResources["web"].Cpu.ReservedCores)
+			require.Equal(t, tc.expectMem, out[0].TaskResources["web"].Memory.NUMANodes)
+		})
+	}
+}
+
 func TestBinPackIterator_ExistingAlloc(t *testing.T) {
 	state, ctx := testContext(t)
 	nodes := []*RankedNode{
@@ -2197,3 +2373,113 @@ func TestNodeAffinityIterator(t *testing.T) {
 	}
 
 }
+
+func TestNodeScoreIterator(t *testing.T) {
+	_, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+
+	nodes[0].Node.Meta["cost_per_hour"] = "2.5"
+	nodes[0].Node.Attributes["cpu.frequency"] = "4000"
+	nodes[1].Node.Meta["cost_per_hour"] = "20"
+	nodes[1].Node.Attributes["cpu.frequency"] = "2500"
+	nodes[2].Node.Meta["cost_per_hour"] = "not a number"
+	nodes[2].Node.Attributes["cpu.frequency"] = "1000"
+	delete(nodes[3].Node.Attributes, "cpu.frequency")
+
+	static := NewStaticRankIterator(ctx, nodes)
+	nodeScore := NewNodeScoreIterator(ctx, static)
+	nodeScore.SetSchedulerConfiguration(&structs.SchedulerConfiguration{
+		NodeScorers: []*structs.NodeScorer{
+			{
+				Name:       "cost",
+				Expression: "${meta.cost_per_hour}",
+				Weight:     -75,
+				Min:        0,
+				Max:        10,
+			},
+			{
+				Name:       "frequency",
+				Expression: "${attr.cpu.frequency}",
+				Weight:     25,
+				Min:        1000,
+				Max:        4000,
+			},
+		},
+	})
+
+	scoreNorm := NewScoreNormalizationIterator(ctx, nodeScore)
+
+	out := collectRanked(scoreNorm)
+	expectedScores := make(map[string]float64)
+	// Total weight = 100
+	// Node 0 costs a quarter of max and has the max frequency
+	expectedScores[nodes[0].Node.ID] = (-75*0.25 + 25*1.0) / 100
+
+	// Node 1 costs more than max and has half of the frequency range
+	expectedScores[nodes[1].Node.ID] = (-75*1.0 + 25*0.5) / 100
+
+	// Node 2 has an invalid cost, which gets the worst score for the
+	// negative weight, and the min frequency
+	expectedScores[nodes[2].Node.ID] = (-75*1.0 + 25*0.0) / 100
+
+	// Node 3 has neither value
+	expectedScores[nodes[3].Node.ID] = (-75*1.0 + 25*0.0) / 100
+
+	require.Len(t, out, 4)
+	for _, n := range out {
+		require.InDelta(t, expectedScores[n.Node.ID], n.FinalScore, 0.0001)
+		require.Len(t, n.Scores, 1)
+	}
+
+	// Nodes missing the cost are never preferred over the most expensive
+	// node with a known cost
+	require.Less(t, expectedScores[nodes[3].Node.ID], expectedScores[nodes[1].Node.ID])
+
+	// Without node scorers no score is appended
+	nodeScore.SetSchedulerConfiguration(&structs.SchedulerConfiguration{})
+	static.Reset()
+	for _, n := range nodes {
+		n.Scores = nil
+	}
+	out = collectRanked(nodeScore)
+	require.Len(t, out, 4)
+	for _, n := range out {
+		require.Empty(t, n.Scores)
+	}
+}
+
+func TestNodeScoreIterator_Unresolved(t *testing.T) {
+	_, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+
+	// Node 0 is as expensive as it gets, while node 1 has no cost
+	nodes[0].Node.Meta["cost_per_hour"] = "10"
+
+	static := NewStaticRankIterator(ctx, nodes)
+	nodeScore := NewNodeScoreIterator(ctx, static)
+	nodeScore.SetSchedulerConfiguration(&structs.SchedulerConfiguration{
+		NodeScorers: []*structs.NodeScorer{
+			{
+				Name:       "cost",
+				Expression: "${meta.cost_per_hour}",
+				Weight:     -50,
+				Min:        0,
+				Max:        10,
+			},
+		},
+	})
+
+	out := collectRanked(NewScoreNormalizationIterator(ctx, nodeScore))
+	require.Len(t, out, 2)
+	for _, n := range out {
+		require.InDelta(t, -1.0, n.FinalScore, 0.0001)
+	}
+}
@@ -0,0 +1,187 @@
+package scheduler
+
+import (
+	"sort"
+
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// rebalanceScoreThreshold is the minimum improvement of the normalized
+	// score of an allocation's placement for a rebalance evaluation to
+	// migrate it. Scores are in the range [-1, 1], so this avoids churning
+	// allocations over negligible differences between nodes.
+	rebalanceScoreThreshold = 0.1
+)
+
+// rebalanceCandidate is a running allocation that would score better on
+// another node.
+type rebalanceCandidate struct {
+	alloc       *structs.Allocation
+	target      *structs.Node
+	improvement float64
+}
+
+// computeRebalance returns the allocations of the job with any allocations
+// that would score meaningfully better on another node marked for
+// migration, so that the reconciler replaces them. The number of
+// allocations migrated per task group respects the task group's migrate
+// max_parallel in the same way as node drains, and only healthy allocations
+// are migrated. Running allocations without a deployment status count as
+// healthy. Jobs with an active deployment aren't rebalanced.
+func (s *GenericScheduler) computeRebalance(allocs []*structs.Allocation,
+	tainted map[string]*structs.Node) ([]*structs.Allocation, error) {
+
+	if s.batch || s.job == nil || s.job.Stopped() {
+		return allocs, nil
+	}
+	if s.deployment != nil && s.deployment.Active() {
+		s.logger.Debug("skipping rebalance of job with active deployment",
+			"deployment_id", s.deployment.ID)
+		return allocs, nil
+	}
+
+	nodes, _, _, err := readyNodesInDCsAndPool(s.state, s.job.Datacenters, s.job.NodePool)
+	if err != nil {
+		return nil, err
+	}
+	s.stack.SetNodes(nodes)
+
+	migrate := make(map[string]*structs.Allocation)
+	for _, tg := range s.job.TaskGroups {
+		// Allocations with sticky disks are tied to their nodes
+		if tg.EphemeralDisk != nil && tg.EphemeralDisk.Sticky {
+			continue
+		}
+
+		healthy := 0
+		var eligible []*structs.Allocation
+		for _, alloc := range allocs {
+			if alloc.TaskGroup != tg.Name || alloc.TerminalStatus() ||
+				alloc.ClientStatus != structs.AllocClientStatusRunning {
+				continue
+			}
+
+			// Allocations placed outside of a deployment have no health
+			// to wait for, so only those whose health is still pending or
+			// which are unhealthy are excluded
+			if ds := alloc.DeploymentStatus; ds != nil && !ds.IsHealthy() {
+				continue
+			}
+			healthy++
+
+			// Allocations which are already being migrated, which are on
+			// tainted nodes, or which are for an older version of the job
+			// are handled by the reconciler as usual.
+			if _, ok := tainted[alloc.NodeID]; ok ||
+				alloc.DesiredTransition.ShouldMigrate() ||
+				alloc.Job == nil || alloc.Job.JobModifyIndex != s.job.JobModifyIndex {
+				continue
+			}
+			eligible = append(eligible, alloc)
+		}
+
+		// Determine how many allocations can be migrated without the number
+		// of healthy allocations dropping below count - max_parallel.
+		maxParallel := 1
+		if tg.Migrate != nil {
+			maxParallel = tg.Migrate.MaxParallel
+		}
+		limit := healthy - (tg.Count - maxParallel)
+		if limit <= 0 || len(eligible) == 0 {
+			continue
+		}
+
+		var candidates []*rebalanceCandidate
+		for _, alloc := range eligible {
+			candidate, err := s.rebalanceCandidate(tg, alloc)
+			if err != nil {
+				return nil, err
+			}
+			if candidate != nil {
+				candidates = append(candidates, candidate)
+			}
+		}
+
+		// Migrate the allocations which improve the most first
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].improvement > candidates[j].improvement
+		})
+		if len(candidates) > limit {
+			candidates = candidates[:limit]
+		}
+
+		for _, candidate := range candidates {
+			s.logger.Debug("rebalancing allocation", "alloc_id", candidate.alloc.ID,
+				"node_id", candidate.alloc.NodeID, "target_node_id", candidate.target.ID,
+				"improvement", candidate.improvement)
+
+			alloc := candidate.alloc.Copy()
+			alloc.DesiredTransition.Migrate = pointer.Of(true)
+			migrate[alloc.ID] = alloc
+			s.rebalanceTargets[alloc.ID] = candidate.target
+		}
+	}
+
+	if len(migrate) == 0 {
+		return allocs, nil
+	}
+	result := make([]*structs.Allocation, len(allocs))
+	for i, alloc := range allocs {
+		if updated, ok := migrate[alloc.ID]; ok {
+			result[i] = updated
+		} else {
+			result[i] = alloc
+		}
+	}
+	return result, nil
+}
+
+// rebalanceCandidate scores the allocation on its current node and on the
+// best of all other nodes, and returns a candidate if the other node scores
+// meaningfully better. The allocation is treated as stopped while scoring so
+// that its own resources don't count against its current node.
+func (s *GenericScheduler) rebalanceCandidate(tg *structs.TaskGroup, alloc *structs.Allocation) (*rebalanceCandidate, error) {
+	node, err := s.state.NodeByID(nil, alloc.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, nil
+	}
+
+	s.plan.AppendStoppedAlloc(alloc, allocMigrating, "", "")
+	defer s.plan.PopUpdate(alloc)
+
+	// Score every node so that the improvement is judged against the best
+	// node in the cluster rather than the best of a sample
+	best := s.stack.Select(tg, &SelectOptions{AllocName: alloc.Name, ScoreAllNodes: true})
+	if best == nil || best.Node.ID == alloc.NodeID {
+		return nil, nil
+	}
+	bestScore := best.FinalScore
+	target := best.Node
+
+	// If the allocation no longer fits on its current node, selecting with
+	// the node preferred falls back to the other nodes. Treat the current
+	// placement as having the lowest possible score in that case.
+	currentScore := -1.0
+	current := s.stack.Select(tg, &SelectOptions{
+		AllocName:      alloc.Name,
+		PreferredNodes: []*structs.Node{node},
+	})
+	if current != nil && current.Node.ID == alloc.NodeID {
+		currentScore = current.FinalScore
+	}
+
+	improvement := bestScore - currentScore
+	if improvement < rebalanceScoreThreshold {
+		return nil, nil
+	}
+	return &rebalanceCandidate{
+		alloc:       alloc,
+		target:      target,
+		improvement: improvement,
+	}, nil
+}
@@ -0,0 +1,264 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// rebalanceHarness registers two nodes in each of two racks and a job with
+// an affinity for the second rack whose allocations are running on the nodes
+// of the first rack.
+func rebalanceHarness(t *testing.T, modifyJob func(*structs.Job),
+	modifyAlloc func(int, *structs.Allocation)) (*Harness, *structs.Job, []*structs.Node, []*structs.Allocation) {
+
+	h := NewHarness(t)
+
+	var nodes []*structs.Node
+	for i := 0; i < 4; i++ {
+		node := mock.Node()
+		node.Meta["rack"] = fmt.Sprintf("r%d", i/2+1)
+		node.ComputeClass()
+		require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+		nodes = append(nodes, node)
+	}
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Migrate = structs.DefaultMigrateStrategy()
+	job.Affinities = []*structs.Affinity{{
+		LTarget: "${meta.rack}",
+		RTarget: "r2",
+		Operand: "=",
+		Weight:  100,
+	}}
+	if modifyJob != nil {
+		modifyJob(job)
+	}
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = nodes[i].ID
+		alloc.Name = fmt.Sprintf("my-job.web[%d]", i)
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		alloc.DeploymentStatus = &structs.AllocDeploymentStatus{
+			Healthy: pointer.Of(true),
+		}
+		if modifyAlloc != nil {
+			modifyAlloc(i, alloc)
+		}
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	return h, job, nodes, allocs
+}
+
+func rebalanceEval(t *testing.T, h *Harness, job *structs.Job) *structs.Evaluation {
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerRebalance,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	return eval
+}
+
+func TestServiceSched_Rebalance(t *testing.T) {
+	ci.Parallel(t)
+
+	h, job, nodes, allocs := rebalanceHarness(t, nil, nil)
+	eval := rebalanceEval(t, h, job)
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+
+	// Only max_parallel allocations are migrated at once
+	var stopped []*structs.Allocation
+	for _, updates := range plan.NodeUpdate {
+		stopped = append(stopped, updates...)
+	}
+	require.Len(t, stopped, 1)
+	require.Contains(t, []string{allocs[0].ID, allocs[1].ID}, stopped[0].ID)
+	require.Equal(t, allocMigrating, stopped[0].DesiredDescription)
+
+	// The replacement is placed in the preferred rack
+	var placed []*structs.Allocation
+	for _, allocList := range plan.NodeAllocation {
+		placed = append(placed, allocList...)
+	}
+	require.Len(t, placed, 1)
+	require.Equal(t, stopped[0].ID, placed[0].PreviousAllocation)
+	require.Contains(t, []string{nodes[2].ID, nodes[3].ID}, placed[0].NodeID)
+
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
+func TestServiceSched_Rebalance_NoChange(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		modifyJob   func(*structs.Job)
+		modifyAlloc func(int, *structs.Allocation)
+	}{
+		{
+			name: "no improvement",
+			modifyJob: func(job *structs.Job) {
+				job.Affinities = nil
+			},
+		},
+		{
+			name: "unhealthy allocation",
+			modifyAlloc: func(i int, alloc *structs.Allocation) {
+				if i == 0 {
+					alloc.DeploymentStatus.Healthy = pointer.Of(false)
+				}
+			},
+		},
+		{
+			name: "pending allocation health",
+			modifyAlloc: func(i int, alloc *structs.Allocation) {
+				if i == 0 {
+					alloc.DeploymentStatus.Healthy = nil
+				}
+			},
+		},
+		{
+			name: "sticky ephemeral disk",
+			modifyJob: func(job *structs.Job) {
+				job.TaskGroups[0].EphemeralDisk.Sticky = true
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, job, _, _ := rebalanceHarness(t, tc.modifyJob, tc.modifyAlloc)
+			eval := rebalanceEval(t, h, job)
+			require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+			// No plan is submitted when there is nothing to do
+			require.Len(t, h.Plans, 0)
+			h.AssertEvalStatus(t, structs.EvalStatusComplete)
+		})
+	}
+}
+
+func TestServiceSched_Rebalance_ActiveDeployment(t *testing.T) {
+	ci.Parallel(t)
+
+	h, job, _, allocs := rebalanceHarness(t, nil, nil)
+
+	d := mock.Deployment()
+	d.JobID = job.ID
+	d.JobVersion = job.Version
+	d.JobCreateIndex = job.CreateIndex
+	d.JobModifyIndex = job.JobModifyIndex
+	require.NoError(t, h.State.UpsertDeployment(h.NextIndex(), d))
+
+	eval := rebalanceEval(t, h, job)
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	for _, plan := range h.Plans {
+		for _, updates := range plan.NodeUpdate {
+			for _, update := range updates {
+				require.NotContains(t, []string{allocs[0].ID, allocs[1].ID}, update.ID)
+			}
+		}
+	}
+}
+
+func TestServiceSched_Rebalance_NoDeploymentStatus(t *testing.T) {
+	ci.Parallel(t)
+
+	// Allocations of a job without an update block are placed outside of a
+	// deployment, so they have no deployment status but are still healthy
+	h, job, nodes, allocs := rebalanceHarness(t,
+		func(job *structs.Job) {
+			job.Update = structs.UpdateStrategy{}
+			job.TaskGroups[0].Update = nil
+		},
+		func(i int, alloc *structs.Allocation) {
+			alloc.DeploymentStatus = nil
+		})
+	eval := rebalanceEval(t, h, job)
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+
+	var stopped []*structs.Allocation
+	for _, updates := range plan.NodeUpdate {
+		stopped = append(stopped, updates...)
+	}
+	require.Len(t, stopped, 1)
+	require.Contains(t, []string{allocs[0].ID, allocs[1].ID}, stopped[0].ID)
+
+	var placed []*structs.Allocation
+	for _, allocList := range plan.NodeAllocation {
+		placed = append(placed, allocList...)
+	}
+	require.Len(t, placed, 1)
+	require.Contains(t, []string{nodes[2].ID, nodes[3].ID}, placed[0].NodeID)
+}
+
+func TestServiceSched_Rebalance_ScoresAllNodes(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Of many empty nodes, only one is mostly full and so is the best
+	// binpacking fit. The stack would only score a sample of the nodes.
+	var nodes []*structs.Node
+	for i := 0; i < 64; i++ {
+		node := mock.Node()
+		require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+		nodes = append(nodes, node)
+	}
+	target := nodes[len(nodes)-1]
+
+	other := mock.Alloc()
+	other.NodeID = target.ID
+	other.ClientStatus = structs.AllocClientStatusRunning
+	other.AllocatedResources.Tasks["web"].Cpu.CpuShares = 3000
+	other.AllocatedResources.Tasks["web"].Memory.MemoryMB = 6000
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), other.Job))
+	require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{other}))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Migrate = structs.DefaultMigrateStrategy()
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.NodeID = nodes[0].ID
+	alloc.Name = "my-job.web[0]"
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	alloc.DeploymentStatus = &structs.AllocDeploymentStatus{Healthy: pointer.Of(true)}
+	require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{alloc}))
+
+	eval := rebalanceEval(t, h, job)
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	require.Len(t, h.Plans, 1)
+	placed := h.Plans[0].NodeAllocation[target.ID]
+	require.Len(t, placed, 1)
+	require.Equal(t, alloc.ID, placed[0].PreviousAllocation)
+}
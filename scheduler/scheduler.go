@@ -100,9 +100,15 @@ type State interface {
 	// job ID
 	LatestDeploymentByJobID(ws memdb.WatchSet, namespace, jobID string) (*structs.Deployment, error)
 
+	// EvalsByJob returns the evaluations of the job
+	EvalsByJob(ws memdb.WatchSet, namespace, jobID string) ([]*structs.Evaluation, error)
+
 	// SchedulerConfig returns config options for the scheduler
 	SchedulerConfig() (uint64, *structs.SchedulerConfiguration, error)
 
+	// NodePoolByName is used to lookup a node pool by name
+	NodePoolByName(ws memdb.WatchSet, name string) (*structs.NodePool, error)
+
 	// CSIVolumeByID fetch CSI volumes, containing controller jobs
 	CSIVolumeByID(memdb.WatchSet, string, string) (*structs.CSIVolume, error)
 
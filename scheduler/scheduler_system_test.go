@@ -77,6 +77,56 @@ func TestSystemSched_JobRegister(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestSystemSched_JobRegister_NodePool(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create some nodes in the default pool and some in a custom pool.
+	nodes := createNodes(t, h, 10)
+	var poolNodeIDs []string
+	for i, node := range nodes {
+		if i%2 == 0 {
+			node = node.Copy()
+			node.NodePool = "pool-a"
+			require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+			poolNodeIDs = append(poolNodeIDs, node.ID)
+		}
+	}
+
+	// Create a system job scoped to the custom pool.
+	job := mock.SystemJob()
+	job.NodePool = "pool-a"
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	// Create a mock evaluation to register the job
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewSystemScheduler, eval))
+	require.Len(t, h.Plans, 1)
+
+	// Ensure an allocation was placed onto each node in the pool only.
+	var planned []*structs.Allocation
+	for _, allocList := range h.Plans[0].NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	require.Len(t, planned, len(poolNodeIDs))
+	for _, alloc := range planned {
+		require.Contains(t, poolNodeIDs, alloc.NodeID)
+	}
+
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
 func TestSystemSched_JobRegister_StickyAllocs(t *testing.T) {
 	ci.Parallel(t)
 
@@ -0,0 +1,620 @@
+// Package simulator runs the schedulers offline against an in-memory copy of
+// the cluster state, such as one restored from a raft snapshot. It lives
+// outside of the scheduler package so that callers can load the state with
+// the raftutil package without creating circular imports (via the nomad
+// package).
+package simulator
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-version"
+
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+)
+
+const (
+	// maxEvaluations bounds the number of evaluations processed by a single
+	// simulation, in case the schedulers keep creating follow-up
+	// evaluations.
+	maxEvaluations = 10000
+)
+
+// Simulator applies synthetic node and job changes to a state store and
+// processes the resulting evaluations with the real schedulers. It
+// implements the scheduler.Planner interface by applying every plan to the
+// state store directly, so the state store should be a copy that isn't used
+// by a running server. Deployments are advanced as if every placed
+// allocation became healthy and canaries were promoted.
+type Simulator struct {
+	logger log.Logger
+	state  *state.StateStore
+
+	nextIndex uint64
+	pending   []*structs.Evaluation
+
+	report *Report
+	jobs   map[structs.NamespacedID]*JobReport
+}
+
+// Report is the outcome of a simulation.
+type Report struct {
+	// NodesAdded, NodesDrained and JobsRegistered are the number of
+	// synthetic changes applied before the simulation.
+	NodesAdded     int
+	NodesDrained   int
+	JobsRegistered int
+
+	// Evaluations is the number of evaluations processed.
+	Evaluations int
+
+	// Jobs are the jobs which had evaluations processed, sorted by
+	// namespace and ID.
+	Jobs []*JobReport
+
+	// Before and After are the utilization of the cluster before the
+	// synthetic changes were applied and after the simulation.
+	Before *Utilization
+	After  *Utilization
+}
+
+// JobReport is the outcome of a simulation for a single job.
+type JobReport struct {
+	Namespace string
+	JobID     string
+
+	// TaskGroups are the outcomes of the simulation for each task group,
+	// keyed by name.
+	TaskGroups map[string]*TaskGroupReport
+}
+
+// TaskGroupReport is the outcome of a simulation for a single task group.
+type TaskGroupReport struct {
+	// Placed is the number of allocations placed.
+	Placed int
+
+	// Stopped is the number of allocations stopped, including allocations
+	// migrated off drained nodes.
+	Stopped int
+
+	// Preempted is the number of allocations of other jobs preempted to
+	// place allocations of the task group.
+	Preempted int
+
+	// Failed is the number of placements that failed across all of the
+	// evaluations of the job, and Metrics explains why the last one failed.
+	Failed  int
+	Metrics *structs.AllocMetric
+}
+
+// Utilization is the allocated and total capacity of the ready nodes of the
+// cluster.
+type Utilization struct {
+	Nodes int
+
+	CPU          int64
+	CPUAllocated int64
+
+	MemoryMB          int64
+	MemoryAllocatedMB int64
+}
+
+// New returns a simulator for the state store.
+func New(logger log.Logger, store *state.StateStore) (*Simulator, error) {
+	index, err := store.LatestIndex()
+	if err != nil {
+		return nil, err
+	}
+	before, err := ComputeUtilization(store)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Simulator{
+		logger:    logger.Named("simulator"),
+		state:     store,
+		nextIndex: index + 1,
+		report:    &Report{Before: before},
+		jobs:      make(map[structs.NamespacedID]*JobReport),
+	}, nil
+}
+
+// State returns the state store of the simulation.
+func (s *Simulator) State() *state.StateStore {
+	return s.state
+}
+
+// AddNodes registers count ready nodes copied from the template node, and
+// queues an evaluation for each system job so that they are placed on the
+// new nodes.
+func (s *Simulator) AddNodes(template *structs.Node, count int) ([]*structs.Node, error) {
+	nodes := make([]*structs.Node, 0, count)
+	for i := 0; i < count; i++ {
+		node := template.Copy()
+		node.ID = uuid.Generate()
+		node.SecretID = uuid.Generate()
+		node.Name = fmt.Sprintf("%s-simulated-%d", template.Name, s.report.NodesAdded+i)
+		node.Status = structs.NodeStatusReady
+		node.SchedulingEligibility = structs.NodeSchedulingEligible
+		node.DrainStrategy = nil
+		node.LastDrain = nil
+		node.Events = nil
+		node.CSIControllerPlugins = nil
+		node.CSINodePlugins = nil
+		if err := node.ComputeClass(); err != nil {
+			return nil, err
+		}
+
+		if err := s.state.UpsertNode(structs.NodeRegisterRequestType, s.index(), node); err != nil {
+			return nil, fmt.Errorf("failed to register node: %v", err)
+		}
+		nodes = append(nodes, node)
+	}
+	s.report.NodesAdded += count
+
+	iter, err := s.state.Jobs(nil)
+	if err != nil {
+		return nil, err
+	}
+	var evals []*structs.Evaluation
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		job := raw.(*structs.Job)
+		if (job.Type != structs.JobTypeSystem && job.Type != structs.JobTypeSysBatch) ||
+			job.Stopped() || job.IsPeriodic() || job.IsParameterized() {
+			continue
+		}
+		evals = append(evals, newEval(job, structs.EvalTriggerNodeUpdate))
+	}
+	return nodes, s.enqueue(evals)
+}
+
+// DrainNode marks the node as draining and all of its allocations for
+// migration, and queues an evaluation for each job with allocations on the
+// node. Unlike the drainer, all of the allocations are migrated at once.
+func (s *Simulator) DrainNode(nodeID string) error {
+	node, err := s.state.NodeByID(nil, nodeID)
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		return fmt.Errorf("node %q not found", nodeID)
+	}
+
+	drain := &structs.DrainStrategy{
+		DrainSpec: structs.DrainSpec{
+			Deadline: -1 * time.Second,
+		},
+		StartedAt: time.Now().UTC(),
+	}
+	if err := s.state.UpdateNodeDrain(structs.NodeUpdateDrainRequestType, s.index(),
+		nodeID, drain, false, time.Now().Unix(), nil, nil, ""); err != nil {
+		return fmt.Errorf("failed to drain node: %v", err)
+	}
+	s.report.NodesDrained++
+
+	allocs, err := s.state.AllocsByNodeTerminal(nil, nodeID, false)
+	if err != nil {
+		return err
+	}
+
+	transitions := make(map[string]*structs.DesiredTransition, len(allocs))
+	jobIDs := make(map[structs.NamespacedID]struct{})
+	for _, alloc := range allocs {
+		transitions[alloc.ID] = &structs.DesiredTransition{Migrate: pointer.Of(true)}
+		jobIDs[alloc.JobNamespacedID()] = struct{}{}
+	}
+
+	evals := make([]*structs.Evaluation, 0, len(jobIDs))
+	for id := range jobIDs {
+		job, err := s.state.JobByID(nil, id.Namespace, id.ID)
+		if err != nil {
+			return err
+		}
+		if job == nil {
+			continue
+		}
+		eval := newEval(job, structs.EvalTriggerNodeDrain)
+		eval.NodeID = nodeID
+		evals = append(evals, eval)
+	}
+	if err := s.state.UpdateAllocsDesiredTransitions(structs.AllocUpdateDesiredTransitionRequestType,
+		s.index(), transitions, evals); err != nil {
+		return fmt.Errorf("failed to migrate allocations: %v", err)
+	}
+	s.pending = append(s.pending, evals...)
+	return nil
+}
+
+// RegisterJob registers the job and queues an evaluation for it. The job
+// should have been canonicalized and validated.
+func (s *Simulator) RegisterJob(job *structs.Job) error {
+	if err := s.state.UpsertJob(structs.JobRegisterRequestType, s.index(), job); err != nil {
+		return fmt.Errorf("failed to register job %q: %v", job.ID, err)
+	}
+	s.report.JobsRegistered++
+
+	job, err := s.state.JobByID(nil, job.Namespace, job.ID)
+	if err != nil {
+		return err
+	}
+	s.jobReport(job.Namespace, job.ID)
+
+	// Periodic and parameterized jobs are only evaluated when launched
+	if job.IsPeriodic() || job.IsParameterized() {
+		return nil
+	}
+	return s.enqueue([]*structs.Evaluation{newEval(job, structs.EvalTriggerJobRegister)})
+}
+
+// Run processes the queued evaluations, and any evaluations the schedulers
+// create, advancing deployments until none of them can make progress, and
+// returns the report of the simulation.
+func (s *Simulator) Run() (*Report, error) {
+	for {
+		if err := s.processPending(); err != nil {
+			return nil, err
+		}
+		progress, err := s.advanceDeployments()
+		if err != nil {
+			return nil, err
+		}
+		if !progress {
+			break
+		}
+	}
+
+	after, err := ComputeUtilization(s.state)
+	if err != nil {
+		return nil, err
+	}
+	s.report.After = after
+
+	s.report.Jobs = make([]*JobReport, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		s.report.Jobs = append(s.report.Jobs, job)
+	}
+	sort.Slice(s.report.Jobs, func(i, j int) bool {
+		a, b := s.report.Jobs[i], s.report.Jobs[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.JobID < b.JobID
+	})
+	return s.report, nil
+}
+
+// processPending processes the queued evaluations.
+func (s *Simulator) processPending() error {
+	for len(s.pending) > 0 {
+		if s.report.Evaluations >= maxEvaluations {
+			return fmt.Errorf("simulation exceeded %d evaluations", maxEvaluations)
+		}
+
+		eval := s.pending[0]
+		s.pending = s.pending[1:]
+		s.report.Evaluations++
+
+		snap, err := s.state.Snapshot()
+		if err != nil {
+			return err
+		}
+		sched, err := scheduler.NewScheduler(eval.Type, s.logger, nil, snap, s)
+		if err != nil {
+			return err
+		}
+		if err := sched.Process(eval); err != nil {
+			return fmt.Errorf("failed to process evaluation %s for job %q: %v",
+				eval.ID, eval.JobID, err)
+		}
+	}
+	return nil
+}
+
+// advanceDeployments stands in for the deployment watcher. It marks the
+// allocations placed by running deployments as healthy, promotes canaries
+// once all of them are placed, and queues an evaluation for the job so that
+// the scheduler continues the deployment. It returns whether any deployment
+// made progress.
+func (s *Simulator) advanceDeployments() (bool, error) {
+	iter, err := s.state.Deployments(nil, state.SortDefault)
+	if err != nil {
+		return false, err
+	}
+	var deployments []*structs.Deployment
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		if d := raw.(*structs.Deployment); d.Status == structs.DeploymentStatusRunning {
+			deployments = append(deployments, d)
+		}
+	}
+
+	progress := false
+	for _, d := range deployments {
+		allocs, err := s.state.AllocsByDeployment(nil, d.ID)
+		if err != nil {
+			return false, err
+		}
+		var healthy []string
+		for _, alloc := range allocs {
+			if alloc.TerminalStatus() || alloc.DeploymentStatus.HasHealth() {
+				continue
+			}
+			healthy = append(healthy, alloc.ID)
+		}
+
+		job, err := s.state.JobByID(nil, d.Namespace, d.JobID)
+		if err != nil {
+			return false, err
+		}
+		if job == nil {
+			continue
+		}
+		advanced := false
+		if len(healthy) > 0 {
+			req := &structs.ApplyDeploymentAllocHealthRequest{
+				DeploymentAllocHealthRequest: structs.DeploymentAllocHealthRequest{
+					DeploymentID:         d.ID,
+					HealthyAllocationIDs: healthy,
+				},
+				Timestamp: time.Now().UTC(),
+			}
+			if err := s.state.UpdateDeploymentAllocHealth(structs.DeploymentAllocHealthRequestType,
+				s.index(), req); err != nil {
+				return false, fmt.Errorf("failed to set allocation health: %v", err)
+			}
+			advanced = true
+		}
+
+		if canariesPlaced(d) && d.RequiresPromotion() {
+			req := &structs.ApplyDeploymentPromoteRequest{
+				DeploymentPromoteRequest: structs.DeploymentPromoteRequest{
+					DeploymentID: d.ID,
+					All:          true,
+				},
+			}
+			if err := s.state.UpdateDeploymentPromotion(structs.DeploymentPromoteRequestType,
+				s.index(), req); err != nil {
+				return false, fmt.Errorf("failed to promote deployment: %v", err)
+			}
+			advanced = true
+		}
+
+		if !advanced {
+			continue
+		}
+		eval := newEval(job, structs.EvalTriggerDeploymentWatcher)
+		eval.DeploymentID = d.ID
+		if err := s.enqueue([]*structs.Evaluation{eval}); err != nil {
+			return false, err
+		}
+		progress = true
+	}
+	return progress, nil
+}
+
+// canariesPlaced returns whether all of the desired canaries of the
+// deployment have been placed.
+func canariesPlaced(d *structs.Deployment) bool {
+	for _, group := range d.TaskGroups {
+		if len(group.PlacedCanaries) < group.DesiredCanaries {
+			return false
+		}
+	}
+	return true
+}
+
+// SubmitPlan applies the plan to the state store.
+func (s *Simulator) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, scheduler.State, error) {
+	index := s.index()
+	now := time.Now().UTC().UnixNano()
+
+	result := &structs.PlanResult{
+		NodeUpdate:        plan.NodeUpdate,
+		NodeAllocation:    plan.NodeAllocation,
+		NodePreemptions:   plan.NodePreemptions,
+		Deployment:        plan.Deployment,
+		DeploymentUpdates: plan.DeploymentUpdates,
+		AllocIndex:        index,
+	}
+
+	report := s.jobReport(plan.Job.Namespace, plan.Job.ID)
+
+	var allocs []*structs.Allocation
+	for _, updateList := range plan.NodeUpdate {
+		for _, alloc := range updateList {
+			report.taskGroup(alloc.TaskGroup).Stopped++
+			allocs = append(allocs, alloc)
+		}
+	}
+	placedGroups := make(map[string]string)
+	for _, allocList := range plan.NodeAllocation {
+		for _, alloc := range allocList {
+			if alloc.CreateIndex == 0 {
+				report.taskGroup(alloc.TaskGroup).Placed++
+			}
+			if alloc.CreateTime == 0 {
+				alloc.CreateTime = now
+			}
+			alloc.ModifyTime = now
+			allocs = append(allocs, alloc)
+			placedGroups[alloc.ID] = alloc.TaskGroup
+		}
+	}
+
+	// Attribute each preemption to the task group of the allocation it was
+	// preempted for, and create evaluations for the preempted jobs like the
+	// plan applier does
+	var preempted []*structs.Allocation
+	var preemptionEvals []*structs.Evaluation
+	preemptedJobs := make(map[structs.NamespacedID]struct{})
+	for _, preemptions := range plan.NodePreemptions {
+		for _, alloc := range preemptions {
+			alloc.ModifyTime = now
+			preempted = append(preempted, alloc)
+			if tg, ok := placedGroups[alloc.PreemptedByAllocation]; ok {
+				report.taskGroup(tg).Preempted++
+			}
+
+			id := alloc.JobNamespacedID()
+			if _, ok := preemptedJobs[id]; ok {
+				continue
+			}
+			preemptedJobs[id] = struct{}{}
+			job, err := s.state.JobByID(nil, id.Namespace, id.ID)
+			if err != nil {
+				return nil, nil, err
+			}
+			if job != nil {
+				preemptionEvals = append(preemptionEvals, newEval(job, structs.EvalTriggerPreemption))
+			}
+		}
+	}
+
+	req := &structs.ApplyPlanResultsRequest{
+		AllocUpdateRequest: structs.AllocUpdateRequest{
+			Job:   plan.Job,
+			Alloc: allocs,
+		},
+		Deployment:        plan.Deployment,
+		DeploymentUpdates: plan.DeploymentUpdates,
+		EvalID:            plan.EvalID,
+		NodePreemptions:   preempted,
+		PreemptionEvals:   preemptionEvals,
+	}
+	if err := s.state.UpsertPlanResults(structs.ApplyPlanResultsRequestType, index, req); err != nil {
+		return nil, nil, err
+	}
+	s.pending = append(s.pending, preemptionEvals...)
+	return result, nil, nil
+}
+
+// UpdateEval records the outcome of an evaluation.
+func (s *Simulator) UpdateEval(eval *structs.Evaluation) error {
+	report := s.jobReport(eval.Namespace, eval.JobID)
+	for tg, metrics := range eval.FailedTGAllocs {
+		tgReport := report.taskGroup(tg)
+		tgReport.Failed += metrics.CoalescedFailures + 1
+		tgReport.Metrics = metrics
+	}
+	return s.state.UpsertEvals(structs.EvalUpdateRequestType, s.index(),
+		[]*structs.Evaluation{eval})
+}
+
+// CreateEval queues evaluations created by the schedulers. Blocked and
+// delayed evaluations are stored but not processed, since the simulation
+// does not advance time or change capacity on its own.
+func (s *Simulator) CreateEval(eval *structs.Evaluation) error {
+	if eval.Status == structs.EvalStatusPending && eval.WaitUntil.IsZero() {
+		return s.enqueue([]*structs.Evaluation{eval})
+	}
+	return s.state.UpsertEvals(structs.EvalUpdateRequestType, s.index(),
+		[]*structs.Evaluation{eval})
+}
+
+// ReblockEval is a no-op since blocked evaluations are never unblocked
+// during a simulation.
+func (s *Simulator) ReblockEval(*structs.Evaluation) error {
+	return nil
+}
+
+// ServersMeetMinimumVersion always returns true, since the simulation
+// schedules with the version of the running binary.
+func (s *Simulator) ServersMeetMinimumVersion(*version.Version, bool) bool {
+	return true
+}
+
+// ComputeUtilization returns the allocated and total capacity of the ready
+// nodes in the state store.
+func ComputeUtilization(store *state.StateStore) (*Utilization, error) {
+	iter, err := store.Nodes(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &Utilization{}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if !node.Ready() {
+			continue
+		}
+
+		capacity := node.ComparableAllocatableResources()
+		u.Nodes++
+		u.CPU += capacity.Flattened.Cpu.CpuShares
+		u.MemoryMB += capacity.Flattened.Memory.MemoryMB
+
+		allocs, err := store.AllocsByNodeTerminal(nil, node.ID, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, alloc := range allocs {
+			used := alloc.ComparableResources()
+			u.CPUAllocated += used.Flattened.Cpu.CpuShares
+			u.MemoryAllocatedMB += used.Flattened.Memory.MemoryMB
+		}
+	}
+	return u, nil
+}
+
+// enqueue stores the evaluations and queues them for processing.
+func (s *Simulator) enqueue(evals []*structs.Evaluation) error {
+	if len(evals) == 0 {
+		return nil
+	}
+	if err := s.state.UpsertEvals(structs.EvalUpdateRequestType, s.index(), evals); err != nil {
+		return err
+	}
+	s.pending = append(s.pending, evals...)
+	return nil
+}
+
+func (s *Simulator) index() uint64 {
+	idx := s.nextIndex
+	s.nextIndex++
+	return idx
+}
+
+func (s *Simulator) jobReport(namespace, jobID string) *JobReport {
+	id := structs.NamespacedID{ID: jobID, Namespace: namespace}
+	report, ok := s.jobs[id]
+	if !ok {
+		report = &JobReport{
+			Namespace:  namespace,
+			JobID:      jobID,
+			TaskGroups: make(map[string]*TaskGroupReport),
+		}
+		s.jobs[id] = report
+	}
+	return report
+}
+
+func (r *JobReport) taskGroup(name string) *TaskGroupReport {
+	report, ok := r.TaskGroups[name]
+	if !ok {
+		report = &TaskGroupReport{}
+		r.TaskGroups[name] = report
+	}
+	return report
+}
+
+func newEval(job *structs.Job, triggeredBy string) *structs.Evaluation {
+	now := time.Now().UTC().UnixNano()
+	return &structs.Evaluation{
+		ID:             uuid.Generate(),
+		Namespace:      job.Namespace,
+		Priority:       job.Priority,
+		Type:           job.Type,
+		TriggeredBy:    triggeredBy,
+		JobID:          job.ID,
+		JobModifyIndex: job.ModifyIndex,
+		Status:         structs.EvalStatusPending,
+		CreateTime:     now,
+		ModifyTime:     now,
+	}
+}
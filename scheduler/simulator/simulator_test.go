@@ -0,0 +1,230 @@
+package simulator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulator_RegisterJob(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	node := mock.Node()
+	require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+
+	sim, err := New(testlog.HCLogger(t), store)
+	require.NoError(t, err)
+
+	// Add capacity by cloning the existing node
+	added, err := sim.AddNodes(node, 2)
+	require.NoError(t, err)
+	require.Len(t, added, 2)
+	require.NotEqual(t, node.ID, added[0].ID)
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 4
+	require.NoError(t, sim.RegisterJob(job))
+
+	// A job that can't fit anywhere
+	bigJob := mock.Job()
+	bigJob.TaskGroups[0].Count = 1
+	bigJob.TaskGroups[0].Tasks[0].Resources.MemoryMB = 1 << 20
+	require.NoError(t, sim.RegisterJob(bigJob))
+
+	report, err := sim.Run()
+	require.NoError(t, err)
+
+	require.Equal(t, 2, report.NodesAdded)
+	require.Equal(t, 2, report.JobsRegistered)
+	require.Equal(t, 2, report.Evaluations)
+	require.Len(t, report.Jobs, 2)
+
+	require.Equal(t, 1, report.Before.Nodes)
+	require.Equal(t, 3, report.After.Nodes)
+	require.Zero(t, report.Before.CPUAllocated)
+	require.Equal(t, int64(4*500), report.After.CPUAllocated)
+	require.Equal(t, int64(4*256), report.After.MemoryAllocatedMB)
+
+	for _, jobReport := range report.Jobs {
+		tg := jobReport.TaskGroups["web"]
+		require.NotNil(t, tg)
+		switch jobReport.JobID {
+		case job.ID:
+			require.Equal(t, 4, tg.Placed)
+			require.Zero(t, tg.Failed)
+		case bigJob.ID:
+			require.Zero(t, tg.Placed)
+			require.Equal(t, 1, tg.Failed)
+			require.NotNil(t, tg.Metrics)
+			require.Equal(t, 3, tg.Metrics.NodesExhausted)
+		}
+	}
+
+	// The allocations are in the state store of the simulation
+	allocs, err := store.AllocsByJob(nil, job.Namespace, job.ID, false)
+	require.NoError(t, err)
+	require.Len(t, allocs, 4)
+}
+
+func TestSimulator_DrainNode(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	drained, other := mock.Node(), mock.Node()
+	require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100, drained))
+	require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 101, other))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 102, job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = drained.ID
+		alloc.Name = structs.AllocName(job.ID, "web", uint(i))
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 103, allocs))
+
+	sim, err := New(testlog.HCLogger(t), store)
+	require.NoError(t, err)
+	require.NoError(t, sim.DrainNode(drained.ID))
+	unknownID := uuid.Generate()
+	require.EqualError(t, sim.DrainNode(unknownID), fmt.Sprintf("node %q not found", unknownID))
+
+	report, err := sim.Run()
+	require.NoError(t, err)
+	require.Equal(t, 1, report.NodesDrained)
+	require.Equal(t, 2, report.Before.Nodes)
+	require.Equal(t, 1, report.After.Nodes)
+
+	require.Len(t, report.Jobs, 1)
+	tg := report.Jobs[0].TaskGroups["web"]
+	require.Equal(t, 2, tg.Stopped)
+	require.Equal(t, 2, tg.Placed)
+
+	// The allocations were migrated to the other node
+	out, err := store.AllocsByNodeTerminal(nil, other.ID, false)
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+}
+
+func TestSimulator_Deployment(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	node := mock.Node()
+	require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+
+	sim, err := New(testlog.HCLogger(t), store)
+	require.NoError(t, err)
+	_, err = sim.AddNodes(node, 2)
+	require.NoError(t, err)
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 3
+	job.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	job.TaskGroups[0].Update.MaxParallel = 1
+	job.TaskGroups[0].Update.Canary = 1
+	require.NoError(t, sim.RegisterJob(job))
+	_, err = sim.Run()
+	require.NoError(t, err)
+
+	// Update the job, which rolls out a canary which has to be promoted,
+	// followed by one allocation at a time
+	job = job.Copy()
+	job.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
+	require.NoError(t, sim.RegisterJob(job))
+	report, err := sim.Run()
+	require.NoError(t, err)
+
+	require.Len(t, report.Jobs, 1)
+	tg := report.Jobs[0].TaskGroups["web"]
+	require.Equal(t, 3+3, tg.Placed)
+	require.Equal(t, 3, tg.Stopped)
+	require.Zero(t, tg.Failed)
+
+	allocs, err := store.AllocsByJob(nil, job.Namespace, job.ID, false)
+	require.NoError(t, err)
+	running := 0
+	for _, alloc := range allocs {
+		if alloc.TerminalStatus() {
+			continue
+		}
+		running++
+		require.Equal(t, uint64(1), alloc.Job.Version)
+	}
+	require.Equal(t, 3, running)
+
+	deployment, err := store.LatestDeploymentByJobID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), deployment.JobVersion)
+	require.Equal(t, structs.DeploymentStatusSuccessful, deployment.Status)
+	require.True(t, deployment.TaskGroups["web"].Promoted)
+}
+
+func TestSimulator_Preemption(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	node := mock.Node()
+	require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+	require.NoError(t, store.SchedulerSetConfig(101, &structs.SchedulerConfiguration{
+		PreemptionConfig: structs.PreemptionConfig{ServiceSchedulerEnabled: true},
+	}))
+
+	sim, err := New(testlog.HCLogger(t), store)
+	require.NoError(t, err)
+
+	// A low priority job using most of the node
+	low := mock.Job()
+	low.Priority = 20
+	low.TaskGroups[0].Count = 1
+	low.TaskGroups[0].Tasks[0].Resources.CPU = 3000
+	require.NoError(t, sim.RegisterJob(low))
+
+	// A high priority job with a group that fits next to the low priority
+	// job, and one that can only be placed by preempting it
+	high := mock.Job()
+	high.Priority = 80
+	high.TaskGroups[0].Count = 1
+	high.TaskGroups[0].Tasks[0].Resources.CPU = 100
+	big := high.TaskGroups[0].Copy()
+	big.Name = "big"
+	big.Tasks[0].Resources.CPU = 3000
+	high.TaskGroups = append(high.TaskGroups, big)
+	require.NoError(t, sim.RegisterJob(high))
+
+	report, err := sim.Run()
+	require.NoError(t, err)
+	require.Len(t, report.Jobs, 2)
+
+	for _, jobReport := range report.Jobs {
+		switch jobReport.JobID {
+		case high.ID:
+			require.Equal(t, 1, jobReport.TaskGroups["web"].Placed)
+			require.Zero(t, jobReport.TaskGroups["web"].Preempted)
+			require.Equal(t, 1, jobReport.TaskGroups["big"].Placed)
+			require.Equal(t, 1, jobReport.TaskGroups["big"].Preempted)
+		case low.ID:
+			// The preempted job was evaluated again, and could not be
+			// placed
+			tg := jobReport.TaskGroups["web"]
+			require.Equal(t, 1, tg.Placed)
+			require.Equal(t, 1, tg.Failed)
+			require.NotNil(t, tg.Metrics)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package scheduler
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -270,3 +272,182 @@ func (iter *SpreadIterator) computeSpreadInfo(tg *structs.TaskGroup) {
 	}
 	iter.tgSpreadInfo[tg.Name] = spreadInfos
 }
+
+// SpreadSkewIterator is a FeasibleIterator which returns nodes that pass the
+// spread stanzas which set a max_skew. Placing on a node must not make the
+// number of allocations using the node's attribute value exceed the number
+// using the least used value by more than the allowed skew. The values are
+// those of the attribute across the base set of nodes that meet the
+// constraints of the job and task group, so values without any allocations
+// count as used zero times.
+type SpreadSkewIterator struct {
+	ctx    Context
+	source FeasibleIterator
+	job    *structs.Job
+	tg     *structs.TaskGroup
+
+	// jobSpreads is the slice of spreads at the job level which apply to
+	// all task groups
+	jobSpreads []*structs.Spread
+
+	// nodes is the base set of nodes whose attribute values allocations are
+	// spread across
+	nodes []*structs.Node
+
+	// hasSkewSpreads is used to early return when the task group has no
+	// spread with a max_skew
+	hasSkewSpreads bool
+
+	// groupSkewSets is a memoized map from task group to the property sets of
+	// the spreads with a max_skew that apply to it
+	groupSkewSets map[string][]*skewSet
+}
+
+// skewSet tracks the attribute values used by the allocations of a task group
+// for a spread with a max_skew.
+type skewSet struct {
+	pset    *propertySet
+	maxSkew int
+
+	// domain is the memoized set of values of the attribute across the
+	// nodes the task group can be placed on
+	domain map[string]struct{}
+}
+
+// NewSpreadSkewIterator creates a SpreadSkewIterator from a source.
+func NewSpreadSkewIterator(ctx Context, source FeasibleIterator) *SpreadSkewIterator {
+	return &SpreadSkewIterator{
+		ctx:           ctx,
+		source:        source,
+		groupSkewSets: make(map[string][]*skewSet),
+	}
+}
+
+// SetNodes sets the base set of nodes whose attribute values allocations are
+// spread across.
+func (iter *SpreadSkewIterator) SetNodes(nodes []*structs.Node) {
+	iter.nodes = nodes
+	for _, sets := range iter.groupSkewSets {
+		for _, set := range sets {
+			set.domain = nil
+		}
+	}
+}
+
+func (iter *SpreadSkewIterator) SetJob(job *structs.Job) {
+	iter.job = job
+	iter.jobSpreads = job.Spreads
+
+	// Reset the property sets so that an older version of the job doesn't
+	// leak its spreads into the current one
+	iter.groupSkewSets = make(map[string][]*skewSet)
+}
+
+func (iter *SpreadSkewIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tg = tg
+
+	// Build the property sets of the job and task group level spreads
+	if _, ok := iter.groupSkewSets[tg.Name]; !ok {
+		sets := []*skewSet{}
+		for _, spreads := range [][]*structs.Spread{iter.jobSpreads, tg.Spreads} {
+			for _, spread := range spreads {
+				if spread.MaxSkew <= 0 {
+					continue
+				}
+
+				pset := NewPropertySet(iter.ctx, iter.job)
+				pset.SetTargetAttribute(spread.Attribute, tg.Name)
+				sets = append(sets, &skewSet{pset: pset, maxSkew: spread.MaxSkew})
+			}
+		}
+		iter.groupSkewSets[tg.Name] = sets
+	}
+
+	iter.hasSkewSpreads = len(iter.groupSkewSets[tg.Name]) != 0
+}
+
+func (iter *SpreadSkewIterator) Next() *structs.Node {
+	for {
+		option := iter.source.Next()
+
+		// Hot path if there is nothing to check
+		if option == nil || !iter.hasSkewSpreads {
+			return option
+		}
+
+		if !iter.satisfiesSkew(option) {
+			continue
+		}
+
+		return option
+	}
+}
+
+// satisfiesSkew returns whether placing on the option keeps the skew of every
+// spread within its max_skew. If not the option is filtered.
+func (iter *SpreadSkewIterator) satisfiesSkew(option *structs.Node) bool {
+	for _, set := range iter.groupSkewSets[iter.tg.Name] {
+		nValue, errorMsg, usedCount := set.pset.UsedCount(option, iter.tg.Name)
+		if errorMsg != "" {
+			iter.ctx.Metrics().FilterNode(option, fmt.Sprintf("spread: %s", errorMsg))
+			return false
+		}
+
+		// Find the least used value, including values without allocations
+		combinedUse := set.pset.GetCombinedUseMap()
+		minCount := usedCount
+		for value := range iter.domainValues(set) {
+			if count := combinedUse[value]; count < minCount {
+				minCount = count
+			}
+		}
+
+		// Add one to include placement on this node in the skew
+		if skew := usedCount + 1 - minCount; skew > uint64(set.maxSkew) {
+			iter.ctx.Metrics().FilterNode(option, fmt.Sprintf("spread: %s=%s exceeds max skew of %d",
+				set.pset.targetAttribute, nValue, set.maxSkew))
+			return false
+		}
+	}
+
+	return true
+}
+
+// domainValues returns the set of values of the attribute of the skew set
+// across the base set of nodes that meet the constraints of the job and task
+// group. Values only found on nodes excluded by a constraint can never be
+// used, so they must not hold back the least used count.
+func (iter *SpreadSkewIterator) domainValues(set *skewSet) map[string]struct{} {
+	if set.domain != nil {
+		return set.domain
+	}
+
+	constraints := append([]*structs.Constraint{}, iter.job.Constraints...)
+	constraints = append(constraints, taskGroupConstraints(iter.tg).constraints...)
+	checker := NewConstraintChecker(iter.ctx, constraints)
+
+	values := make(map[string]struct{})
+OUTER:
+	for _, node := range iter.nodes {
+		for _, constraint := range constraints {
+			if !checker.meetsConstraint(constraint, node) {
+				continue OUTER
+			}
+		}
+		if value, ok := getProperty(node, set.pset.targetAttribute); ok {
+			values[value] = struct{}{}
+		}
+	}
+	set.domain = values
+	return values
+}
+
+func (iter *SpreadSkewIterator) Reset() {
+	iter.source.Reset()
+
+	for _, sets := range iter.groupSkewSets {
+		for _, set := range sets {
+			set.pset.PopulateProposed()
+		}
+	}
+}
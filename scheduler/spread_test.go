@@ -918,3 +918,170 @@ func TestSpreadPanicDowngrade(t *testing.T) {
 	require.NoError(t, processErr, "failed to process eval")
 	require.Len(t, h.Plans, 1)
 }
+
+// Test that nodes are infeasible when placing on them would exceed the max
+// skew of any of the spreads across multiple attributes
+func TestSpreadSkewIterator_MultipleAttributes(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	zones := []string{"a", "a", "b", "b"}
+	var nodes []*structs.Node
+	for i, zone := range zones {
+		node := mock.Node()
+		node.Meta["zone"] = zone
+		node.Meta["rack"] = fmt.Sprintf("r%d", i)
+		require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+		nodes = append(nodes, node)
+	}
+
+	job := mock.Job()
+	job.Spreads = []*structs.Spread{
+		{
+			Attribute: "${meta.zone}",
+			Weight:    50,
+			MaxSkew:   1,
+		},
+	}
+	tg := job.TaskGroups[0]
+	tg.Spreads = []*structs.Spread{
+		{
+			Attribute: "${meta.rack}",
+			Weight:    50,
+			MaxSkew:   1,
+		},
+	}
+
+	// Add an existing alloc in zone a on rack r0
+	upserting := []*structs.Allocation{
+		{
+			Namespace: structs.DefaultNamespace,
+			TaskGroup: tg.Name,
+			JobID:     job.ID,
+			Job:       job,
+			ID:        uuid.Generate(),
+			EvalID:    uuid.Generate(),
+			NodeID:    nodes[0].ID,
+		},
+	}
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, upserting))
+
+	static := NewStaticIterator(ctx, nodes)
+	skewIter := NewSpreadSkewIterator(ctx, static)
+	skewIter.SetNodes(nodes)
+	skewIter.SetJob(job)
+	skewIter.SetTaskGroup(tg)
+
+	// Only zone b is feasible
+	out := collectFeasible(skewIter)
+	require.ElementsMatch(t, []*structs.Node{nodes[2], nodes[3]}, out)
+	require.Equal(t, 2, ctx.Metrics().ConstraintFiltered["spread: ${meta.zone}=a exceeds max skew of 1"])
+
+	// Propose an alloc on rack r2 which balances the zones, leaving only the
+	// unused racks feasible
+	ctx.Plan().NodeAllocation[nodes[2].ID] = []*structs.Allocation{
+		{
+			Namespace: structs.DefaultNamespace,
+			TaskGroup: tg.Name,
+			JobID:     job.ID,
+			Job:       job,
+			ID:        uuid.Generate(),
+			NodeID:    nodes[2].ID,
+		},
+	}
+	ctx.Reset()
+	skewIter.Reset()
+
+	out = collectFeasible(skewIter)
+	require.ElementsMatch(t, []*structs.Node{nodes[1], nodes[3]}, out)
+	require.Equal(t, 1, ctx.Metrics().ConstraintFiltered["spread: ${meta.rack}=r2 exceeds max skew of 1"])
+}
+
+// Test that nodes missing the attribute of a spread with a max skew are
+// infeasible
+func TestSpreadSkewIterator_MissingAttribute(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	nodes := []*structs.Node{mock.Node(), mock.Node()}
+	nodes[0].Meta["zone"] = "a"
+	for i, node := range nodes {
+		require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+	}
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Spreads = []*structs.Spread{
+		{
+			Attribute: "${meta.zone}",
+			Weight:    50,
+			MaxSkew:   2,
+		},
+	}
+
+	static := NewStaticIterator(ctx, nodes)
+	skewIter := NewSpreadSkewIterator(ctx, static)
+	skewIter.SetNodes(nodes)
+	skewIter.SetJob(job)
+	skewIter.SetTaskGroup(tg)
+
+	out := collectFeasible(skewIter)
+	require.Equal(t, []*structs.Node{nodes[0]}, out)
+	require.Equal(t, 1, ctx.Metrics().ConstraintFiltered[`spread: missing property "${meta.zone}"`])
+}
+
+func TestSpreadSkewIterator_ConstrainedDomain(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	zones := []string{"a", "b", "c"}
+	var nodes []*structs.Node
+	for i, zone := range zones {
+		node := mock.Node()
+		node.Meta["zone"] = zone
+		require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+		nodes = append(nodes, node)
+	}
+
+	// Zone c is excluded by a constraint, so it never gets an allocation
+	job := mock.Job()
+	job.Constraints = append(job.Constraints, &structs.Constraint{
+		LTarget: "${meta.zone}",
+		RTarget: "c",
+		Operand: "!=",
+	})
+	tg := job.TaskGroups[0]
+	tg.Spreads = []*structs.Spread{
+		{
+			Attribute: "${meta.zone}",
+			Weight:    50,
+			MaxSkew:   1,
+		},
+	}
+
+	// Add an existing alloc in each of zones a and b
+	var upserting []*structs.Allocation
+	for _, node := range nodes[:2] {
+		upserting = append(upserting, &structs.Allocation{
+			Namespace: structs.DefaultNamespace,
+			TaskGroup: tg.Name,
+			JobID:     job.ID,
+			Job:       job,
+			ID:        uuid.Generate(),
+			EvalID:    uuid.Generate(),
+			NodeID:    node.ID,
+		})
+	}
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, upserting))
+
+	// Only the nodes meeting the constraint reach the iterator, and the
+	// excluded zone doesn't count as the least used value
+	static := NewStaticIterator(ctx, nodes[:2])
+	skewIter := NewSpreadSkewIterator(ctx, static)
+	skewIter.SetNodes(nodes)
+	skewIter.SetJob(job)
+	skewIter.SetTaskGroup(tg)
+
+	out := collectFeasible(skewIter)
+	require.ElementsMatch(t, nodes[:2], out)
+}
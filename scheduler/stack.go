@@ -36,6 +36,11 @@ type SelectOptions struct {
 	PreferredNodes []*structs.Node
 	Preempt        bool
 	AllocName      string
+
+	// ScoreAllNodes lifts the limit on the number of nodes scored, so that
+	// the best of every feasible node is selected rather than the best of
+	// a sample.
+	ScoreAllNodes bool
 }
 
 // GenericStack is the Stack used for the Generic scheduler. It is
@@ -58,6 +63,7 @@ type GenericStack struct {
 
 	distinctHostsConstraint    *DistinctHostsIterator
 	distinctPropertyConstraint *DistinctPropertyIterator
+	spreadSkew                 *SpreadSkewIterator
 	binPack                    *BinPackIterator
 	jobAntiAff                 *JobAntiAffinityIterator
 	nodeReschedulingPenalty    *NodeReschedulingPenaltyIterator
@@ -65,6 +71,7 @@ type GenericStack struct {
 	maxScore                   *MaxScoreIterator
 	nodeAffinity               *NodeAffinityIterator
 	spread                     *SpreadIterator
+	nodeScore                  *NodeScoreIterator
 	scoreNorm                  *ScoreNormalizationIterator
 }
 
@@ -75,6 +82,7 @@ func (s *GenericStack) SetNodes(baseNodes []*structs.Node) {
 
 	// Update the set of base nodes
 	s.source.SetNodes(baseNodes)
+	s.spreadSkew.SetNodes(baseNodes)
 
 	// Apply a limit function. This is to avoid scanning *every* possible node.
 	// For batch jobs we only need to evaluate 2 options and depend on the
@@ -102,6 +110,7 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 	s.jobConstraint.SetConstraints(job.Constraints)
 	s.distinctHostsConstraint.SetJob(job)
 	s.distinctPropertyConstraint.SetJob(job)
+	s.spreadSkew.SetJob(job)
 	s.binPack.SetJob(job)
 	s.jobAntiAff.SetJob(job)
 	s.nodeAffinity.SetJob(job)
@@ -110,6 +119,10 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
 	s.taskGroupCSIVolumes.SetJobID(job.ID)
 
+	schedConfig := nodePoolSchedulerConfig(s.ctx, job)
+	s.binPack.SetSchedulerConfiguration(schedConfig)
+	s.nodeScore.SetSchedulerConfiguration(schedConfig)
+
 	if contextual, ok := s.quota.(ContextualIterator); ok {
 		contextual.SetJob(job)
 	}
@@ -151,6 +164,7 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 	}
 	s.distinctHostsConstraint.SetTaskGroup(tg)
 	s.distinctPropertyConstraint.SetTaskGroup(tg)
+	s.spreadSkew.SetTaskGroup(tg)
 	s.wrappedChecks.SetTaskGroup(tg.Name)
 	s.binPack.SetTaskGroup(tg)
 	if options != nil {
@@ -174,6 +188,12 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 		}
 	}
 
+	if options != nil && options.ScoreAllNodes {
+		limit := s.limit.limit
+		s.limit.SetLimit(len(s.source.nodes))
+		defer s.limit.SetLimit(limit)
+	}
+
 	if contextual, ok := s.quota.(ContextualIterator); ok {
 		contextual.SetTaskGroup(tg)
 	}
@@ -204,6 +224,7 @@ type SystemStack struct {
 
 	distinctPropertyConstraint *DistinctPropertyIterator
 	binPack                    *BinPackIterator
+	nodeScore                  *NodeScoreIterator
 	scoreNorm                  *ScoreNormalizationIterator
 }
 
@@ -285,8 +306,12 @@ func NewSystemStack(sysbatch bool, ctx Context) *SystemStack {
 	// Create binpack iterator
 	s.binPack = NewBinPackIterator(ctx, rankSource, enablePreemption, 0, schedConfig)
 
+	// Apply the operator defined node scorers
+	s.nodeScore = NewNodeScoreIterator(ctx, s.binPack)
+	s.nodeScore.SetSchedulerConfiguration(schedConfig)
+
 	// Apply score normalization
-	s.scoreNorm = NewScoreNormalizationIterator(ctx, s.binPack)
+	s.scoreNorm = NewScoreNormalizationIterator(ctx, s.nodeScore)
 	return s
 }
 
@@ -299,6 +324,10 @@ func (s *SystemStack) SetJob(job *structs.Job) {
 	s.jobConstraint.SetConstraints(job.Constraints)
 	s.distinctPropertyConstraint.SetJob(job)
 	s.binPack.SetJob(job)
+
+	schedConfig := nodePoolSchedulerConfig(s.ctx, job)
+	s.binPack.SetSchedulerConfiguration(schedConfig)
+	s.nodeScore.SetSchedulerConfiguration(schedConfig)
 	s.ctx.Eligibility().SetJob(job)
 
 	if contextual, ok := s.quota.(ContextualIterator); ok {
@@ -340,6 +369,24 @@ func (s *SystemStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ran
 	return option
 }
 
+// nodePoolSchedulerConfig returns the cluster scheduler configuration with the
+// overrides of the node pool of the job applied.
+func nodePoolSchedulerConfig(ctx Context, job *structs.Job) *structs.SchedulerConfiguration {
+	_, schedConfig, err := ctx.State().SchedulerConfig()
+	if err != nil {
+		ctx.Logger().Named("stack").Error("failed to lookup scheduler configuration",
+			"error", err)
+	}
+
+	pool, err := ctx.State().NodePoolByName(nil, job.NodePool)
+	if err != nil {
+		ctx.Logger().Named("stack").Error("failed to lookup node pool",
+			"node_pool", job.NodePool, "error", err)
+		return schedConfig
+	}
+	return schedConfig.WithNodePool(pool)
+}
+
 // NewGenericStack constructs a stack used for selecting service placements
 func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// Create a new stack
@@ -395,12 +442,15 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// Filter on distinct property constraints.
 	s.distinctPropertyConstraint = NewDistinctPropertyIterator(ctx, s.distinctHostsConstraint)
 
+	// Filter on spreads with a max skew.
+	s.spreadSkew = NewSpreadSkewIterator(ctx, s.distinctPropertyConstraint)
+
 	// Create the quota iterator to determine if placements would result in
 	// the quota attached to the namespace of the job to go over.
 	// Note: the quota iterator must be the last feasibility iterator before
 	// we upgrade to ranking, or our quota usage will include ineligible
 	// nodes!
-	s.quota = NewQuotaIterator(ctx, s.distinctPropertyConstraint)
+	s.quota = NewQuotaIterator(ctx, s.spreadSkew)
 
 	// Upgrade from feasible to rank iterator
 	rankSource := NewFeasibleRankIterator(ctx, s.quota)
@@ -427,8 +477,12 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// Add the preemption options scoring iterator
 	preemptionScorer := NewPreemptionScoringIterator(ctx, s.spread)
 
+	// Apply the operator defined node scorers
+	s.nodeScore = NewNodeScoreIterator(ctx, preemptionScorer)
+	s.nodeScore.SetSchedulerConfiguration(schedConfig)
+
 	// Normalizes scores by averaging them across various scorers
-	s.scoreNorm = NewScoreNormalizationIterator(ctx, preemptionScorer)
+	s.scoreNorm = NewScoreNormalizationIterator(ctx, s.nodeScore)
 
 	// Apply a limit function. This is to avoid scanning *every* possible node.
 	s.limit = NewLimitIterator(ctx, s.scoreNorm, 2, skipScoreThreshold, maxSkip)
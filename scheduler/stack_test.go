@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/stretchr/testify/require"
@@ -450,6 +451,39 @@ func TestSystemStack_SetJob(t *testing.T) {
 	}
 }
 
+func TestStack_SetJob_NodePoolSchedulerConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+
+	// Create a node pool which enables memory oversubscription, which is
+	// disabled within the cluster scheduler configuration.
+	pool := mock.NodePool()
+	pool.SchedulerConfiguration = &structs.NodePoolSchedulerConfiguration{
+		MemoryOversubscriptionEnabled: pointer.Of(true),
+	}
+	require.NoError(t, state.UpsertNodePools(structs.MsgTypeTestSetup, 10, []*structs.NodePool{pool}))
+
+	job := mock.Job()
+	job.NodePool = pool.Name
+
+	genericStack := NewGenericStack(false, ctx)
+	require.False(t, genericStack.binPack.memoryOversubscription)
+	genericStack.SetJob(job)
+	require.True(t, genericStack.binPack.memoryOversubscription)
+
+	systemStack := NewSystemStack(false, ctx)
+	require.False(t, systemStack.binPack.memoryOversubscription)
+	systemStack.SetJob(job)
+	require.True(t, systemStack.binPack.memoryOversubscription)
+
+	// Jobs in other pools should use the cluster configuration.
+	otherJob := mock.Job()
+	otherJob.NodePool = structs.NodePoolDefault
+	systemStack.SetJob(otherJob)
+	require.False(t, systemStack.binPack.memoryOversubscription)
+}
+
 func TestSystemStack_Select_Size(t *testing.T) {
 	ci.Parallel(t)
 
@@ -346,9 +346,11 @@ func diffSystemAllocs(
 	return result
 }
 
-// readyNodesInDCs returns all the ready nodes in the given datacenters and a
-// mapping of each data center to the count of ready nodes.
-func readyNodesInDCs(state State, dcs []string) ([]*structs.Node, map[string]struct{}, map[string]int, error) {
+// readyNodesInDCsAndPool returns all the ready nodes in the given datacenters
+// and node pool, and a mapping of each data center to the count of ready
+// nodes. Nodes outside the node pool are neither returned nor considered not
+// ready.
+func readyNodesInDCsAndPool(state State, dcs []string, pool string) ([]*structs.Node, map[string]struct{}, map[string]int, error) {
 	// Index the DCs
 	dcMap := make(map[string]int, len(dcs))
 	for _, dc := range dcs {
@@ -369,8 +371,11 @@ func readyNodesInDCs(state State, dcs []string) ([]*structs.Node, map[string]str
 			break
 		}
 
-		// Filter on datacenter and status
+		// Filter on node pool, datacenter and status
 		node := raw.(*structs.Node)
+		if !nodeInPool(node, pool) {
+			continue
+		}
 		if !node.Ready() {
 			notReady[node.ID] = struct{}{}
 			continue
@@ -384,6 +389,20 @@ func readyNodesInDCs(state State, dcs []string) ([]*structs.Node, map[string]str
 	return out, notReady, dcMap, nil
 }
 
+// nodeInPool returns whether the node is a member of the node pool. Every
+// node is a member of the built-in "all" pool, and nodes without a pool are
+// members of the default pool.
+func nodeInPool(node *structs.Node, pool string) bool {
+	switch pool {
+	case structs.NodePoolAll:
+		return true
+	case "", structs.NodePoolDefault:
+		return node.NodePool == "" || node.NodePool == structs.NodePoolDefault
+	default:
+		return node.NodePool == pool
+	}
+}
+
 // retryMax is used to retry a callback until it returns success or
 // a maximum number of attempts is reached. An optional reset function may be
 // passed which is called after each failed iteration. If the reset function is
@@ -587,6 +606,8 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) bool {
 			return true
 		} else if !ar.Devices.Equals(&br.Devices) {
 			return true
+		} else if !ar.NUMA.Equals(br.NUMA) {
+			return true
 		}
 	}
 	return false
@@ -0,0 +1,203 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// windowEvalDesc is the description used for evaluations created to
+	// retry placements and updates once a scheduling window opens.
+	windowEvalDesc = "created to wait for scheduling window"
+)
+
+// applySchedulingWindows removes the placements and updates of task groups
+// whose scheduling window is closed from the reconciler results, and records
+// when the earliest of those windows opens so that an evaluation is created
+// to retry them then. The deferred placements and updates are removed from
+// the desired changes reported in plan annotations as well. Allocations that
+// are migrating keep running until their replacement is placed. The
+// deployment of the job is marked as waiting for the window while anything
+// is deferred.
+func (s *GenericScheduler) applySchedulingWindows(results *reconcileResults) {
+	s.windowOpens = time.Time{}
+	if s.job == nil || s.job.Stopped() {
+		return
+	}
+
+	now := time.Now().UTC()
+	closed := make(map[string]bool)
+	for _, tg := range s.job.TaskGroups {
+		if window := s.job.LookupSchedulingWindow(tg.Name); !window.Open(now) {
+			closed[tg.Name] = true
+		}
+	}
+
+	deferred := make(map[string]int)
+	if len(closed) != 0 {
+		desired := func(name string) *structs.DesiredUpdates {
+			if d, ok := results.desiredTGUpdates[name]; ok {
+				return d
+			}
+			return &structs.DesiredUpdates{}
+		}
+
+		migrating := make(map[string]bool)
+		place := results.place[:0]
+		for _, p := range results.place {
+			if name := p.taskGroup.Name; closed[name] {
+				deferred[name]++
+				d := desired(name)
+				switch {
+				case p.previousAlloc != nil && p.previousAlloc.DesiredTransition.ShouldMigrate():
+					migrating[p.previousAlloc.ID] = true
+					decrement(&d.Migrate)
+					d.Ignore++
+				case p.canary:
+					decrement(&d.Canary)
+				default:
+					decrement(&d.Place)
+				}
+				continue
+			}
+			place = append(place, p)
+		}
+		results.place = place
+
+		stop := results.stop[:0]
+		for _, s := range results.stop {
+			if migrating[s.alloc.ID] && s.statusDescription == allocMigrating {
+				continue
+			}
+			stop = append(stop, s)
+		}
+		results.stop = stop
+
+		destructive := results.destructiveUpdate[:0]
+		for _, p := range results.destructiveUpdate {
+			if name := p.placeTaskGroup.Name; closed[name] {
+				deferred[name]++
+				d := desired(name)
+				decrement(&d.DestructiveUpdate)
+				d.Ignore++
+				continue
+			}
+			destructive = append(destructive, p)
+		}
+		results.destructiveUpdate = destructive
+
+		inplace := results.inplaceUpdate[:0]
+		for _, alloc := range results.inplaceUpdate {
+			if closed[alloc.TaskGroup] {
+				deferred[alloc.TaskGroup]++
+				d := desired(alloc.TaskGroup)
+				decrement(&d.InPlaceUpdate)
+				d.Ignore++
+				continue
+			}
+			inplace = append(inplace, alloc)
+		}
+		results.inplaceUpdate = inplace
+	}
+
+	for name, count := range deferred {
+		next := s.job.LookupSchedulingWindow(name).Next(now)
+		if s.windowOpens.IsZero() || next.Before(s.windowOpens) {
+			s.windowOpens = next
+		}
+		s.logger.Debug("deferring allocations until scheduling window opens",
+			"task_group", name, "count", count, "window_opens", next)
+	}
+
+	s.updateWindowDeployment(results, len(deferred) != 0)
+}
+
+// decrement decrements a desired change count without wrapping around.
+func decrement(count *uint64) {
+	if *count > 0 {
+		*count--
+	}
+}
+
+// updateWindowDeployment sets the description of the running deployment of
+// the job to show whether it is waiting for a scheduling window.
+func (s *GenericScheduler) updateWindowDeployment(results *reconcileResults, waiting bool) {
+	// A deployment created by this evaluation can be updated directly
+	if d := results.deployment; d != nil {
+		if waiting && d.Status == structs.DeploymentStatusRunning {
+			d.StatusDescription = structs.DeploymentStatusDescriptionWaitingForWindow
+		}
+		return
+	}
+
+	d := s.deployment
+	if d == nil || d.Status != structs.DeploymentStatusRunning {
+		return
+	}
+	for _, update := range results.deploymentUpdates {
+		if update.DeploymentID == d.ID {
+			return
+		}
+	}
+
+	desc := structs.DeploymentStatusDescriptionWaitingForWindow
+	if !waiting {
+		if d.StatusDescription != structs.DeploymentStatusDescriptionWaitingForWindow {
+			return
+		}
+		desc = runningDeploymentDescription(d)
+	} else if d.StatusDescription == desc {
+		return
+	}
+
+	results.deploymentUpdates = append(results.deploymentUpdates, &structs.DeploymentStatusUpdate{
+		DeploymentID:      d.ID,
+		Status:            structs.DeploymentStatusRunning,
+		StatusDescription: desc,
+	})
+}
+
+// runningDeploymentDescription returns the description of a running
+// deployment that isn't waiting for anything but its allocations.
+func runningDeploymentDescription(d *structs.Deployment) string {
+	if d.RequiresPromotion() {
+		if d.HasAutoPromote() {
+			return structs.DeploymentStatusDescriptionRunningAutoPromotion
+		}
+		return structs.DeploymentStatusDescriptionRunningNeedsPromotion
+	}
+	return structs.DeploymentStatusDescriptionRunning
+}
+
+// createWindowEval creates an evaluation to retry the placements and updates
+// deferred until a scheduling window opens, unless the job already has one.
+func (s *GenericScheduler) createWindowEval() error {
+	if s.windowOpens.IsZero() || s.windowEval != nil {
+		return nil
+	}
+
+	evals, err := s.state.EvalsByJob(memdb.NewWatchSet(), s.eval.Namespace, s.eval.JobID)
+	if err != nil {
+		return fmt.Errorf("failed to get evals for job %q: %v", s.eval.JobID, err)
+	}
+	for _, eval := range evals {
+		if eval.ID != s.eval.ID && !eval.TerminalStatus() &&
+			eval.TriggeredBy == structs.EvalTriggerSchedulingWindow &&
+			eval.WaitUntil.Equal(s.windowOpens) {
+			s.logger.Debug("scheduling window eval already exists", "window_eval_id", eval.ID)
+			return nil
+		}
+	}
+
+	s.windowEval = s.eval.NextWindowEval(s.windowOpens)
+	s.windowEval.StatusDescription = windowEvalDesc
+	if err := s.planner.CreateEval(s.windowEval); err != nil {
+		return err
+	}
+	s.logger.Debug("placements deferred until scheduling window opens, followup eval created",
+		"window_eval_id", s.windowEval.ID, "wait_until", s.windowOpens)
+	return nil
+}
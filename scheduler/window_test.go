@@ -0,0 +1,234 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// closedWindow returns a scheduling window that opens an hour from now
+func closedWindow() (*structs.SchedulingWindow, time.Time) {
+	opens := time.Now().UTC().Add(time.Hour).Truncate(time.Minute)
+	return &structs.SchedulingWindow{
+		Start: opens.Format("15:04"),
+		End:   opens.Add(time.Hour).Format("15:04"),
+	}, opens
+}
+
+func TestServiceSched_Window_DefersPlacements(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// The "web" group waits for the window of the job, while the "api"
+	// group sets a window that is always open
+	window, opens := closedWindow()
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	job.Window = window
+	api := job.TaskGroups[0].Copy()
+	api.Name = "api"
+	api.Count = 1
+	api.Window = &structs.SchedulingWindow{Start: "00:00", End: "23:59"}
+	job.TaskGroups = append(job.TaskGroups, api)
+
+	// Windows of a minute a day close at midnight
+	if time.Now().UTC().Format("15:04") == "23:59" {
+		t.Skip("the always open window is closed")
+	}
+
+	eval := gangEval(t, h, job)
+	eval.AnnotatePlan = true
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Only the "api" group was placed
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+	planned := plan.NodeAllocation[node.ID]
+	require.Len(t, planned, 1)
+	require.Equal(t, "api", planned[0].TaskGroup)
+
+	// The annotations don't count the deferred placements
+	require.NotNil(t, plan.Annotations)
+	require.Equal(t, &structs.DesiredUpdates{}, plan.Annotations.DesiredTGUpdates["web"])
+	require.Equal(t, uint64(1), plan.Annotations.DesiredTGUpdates["api"].Place)
+
+	// The deployment shows that it is waiting for the window
+	require.NotNil(t, plan.Deployment)
+	require.Equal(t, structs.DeploymentStatusDescriptionWaitingForWindow, plan.Deployment.StatusDescription)
+
+	// An evaluation retries the "web" group once the window opens
+	require.Len(t, h.CreateEvals, 1)
+	windowEval := h.CreateEvals[0]
+	require.Equal(t, structs.EvalTriggerSchedulingWindow, windowEval.TriggeredBy)
+	require.Equal(t, structs.EvalStatusPending, windowEval.Status)
+	require.Equal(t, eval.ID, windowEval.PreviousEval)
+	require.True(t, opens.Equal(windowEval.WaitUntil), "expected %v, got %v", opens, windowEval.WaitUntil)
+
+	require.Len(t, h.Evals, 1)
+	require.Equal(t, structs.EvalStatusComplete, h.Evals[0].Status)
+	require.Zero(t, h.Evals[0].QueuedAllocations["web"])
+	require.Empty(t, h.Evals[0].BlockedEval)
+
+	// A later evaluation doesn't create another evaluation for the window
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{windowEval}))
+	eval2 := gangEval(t, h, job)
+	require.NoError(t, h.Process(NewServiceScheduler, eval2))
+	require.Len(t, h.CreateEvals, 1)
+}
+
+func TestServiceSched_Window_DefersUpdates(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.Name = fmt.Sprintf("my-job.web[%d]", i)
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	// A destructive update outside of the window leaves the running
+	// allocations untouched
+	window, opens := closedWindow()
+	job2 := job.Copy()
+	job2.Window = window
+	job2.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
+	eval := gangEval(t, h, job2)
+	eval.AnnotatePlan = true
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+	require.Empty(t, plan.NodeUpdate)
+	require.Empty(t, plan.NodeAllocation)
+
+	// The annotations show the deferred updates as ignored
+	require.NotNil(t, plan.Annotations)
+	desired := plan.Annotations.DesiredTGUpdates["web"]
+	require.Zero(t, desired.DestructiveUpdate)
+	require.Equal(t, uint64(2), desired.Ignore)
+	require.NotNil(t, plan.Deployment)
+	require.Equal(t, structs.DeploymentStatusDescriptionWaitingForWindow, plan.Deployment.StatusDescription)
+
+	require.Len(t, h.CreateEvals, 1)
+	require.True(t, opens.Equal(h.CreateEvals[0].WaitUntil))
+
+	// Once the window opens, the deployment is running again and the update
+	// proceeds. The window is opened by removing it from the job.
+	job3 := job2.Copy()
+	job3.Window = nil
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job3))
+	job3, err := h.State.JobByID(nil, job3.Namespace, job3.ID)
+	require.NoError(t, err)
+
+	d := plan.Deployment
+	d.JobVersion = job3.Version
+	d.JobModifyIndex = job3.JobModifyIndex
+	require.NoError(t, h.State.UpsertDeployment(h.NextIndex(), d))
+
+	eval2 := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerSchedulingWindow,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval2}))
+	require.NoError(t, h.Process(NewServiceScheduler, eval2))
+
+	require.Len(t, h.Plans, 2)
+	plan = h.Plans[1]
+	require.NotEmpty(t, plan.NodeUpdate[node.ID])
+	require.NotEmpty(t, plan.NodeAllocation[node.ID])
+	require.Len(t, plan.DeploymentUpdates, 1)
+	require.Equal(t, d.ID, plan.DeploymentUpdates[0].DeploymentID)
+	require.Equal(t, structs.DeploymentStatusRunning, plan.DeploymentUpdates[0].Status)
+	require.Equal(t, structs.DeploymentStatusDescriptionRunning, plan.DeploymentUpdates[0].StatusDescription)
+}
+
+func TestServiceSched_Window_DefersMigrations(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+	drainNode := mock.DrainNode()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), drainNode))
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	window, opens := closedWindow()
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.Window = window
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = drainNode.ID
+		alloc.Name = fmt.Sprintf("my-job.web[%d]", i)
+		alloc.DesiredTransition.Migrate = pointer.Of(true)
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	// Draining the node while the window is closed neither places the
+	// replacements nor stops the allocations they would replace
+	eval := &structs.Evaluation{
+		Namespace:    structs.DefaultNamespace,
+		ID:           uuid.Generate(),
+		Priority:     job.Priority,
+		TriggeredBy:  structs.EvalTriggerNodeUpdate,
+		JobID:        job.ID,
+		NodeID:       drainNode.ID,
+		Status:       structs.EvalStatusPending,
+		AnnotatePlan: true,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	for _, plan := range h.Plans {
+		require.Empty(t, plan.NodeUpdate)
+		require.Empty(t, plan.NodeAllocation)
+		if plan.Annotations != nil {
+			desired := plan.Annotations.DesiredTGUpdates["web"]
+			require.Zero(t, desired.Migrate)
+			require.Equal(t, uint64(2), desired.Ignore)
+		}
+	}
+
+	out, err := h.State.AllocsByJob(nil, job.Namespace, job.ID, false)
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+	for _, alloc := range out {
+		require.Equal(t, structs.AllocDesiredStatusRun, alloc.DesiredStatus)
+	}
+
+	require.Len(t, h.CreateEvals, 1)
+	require.True(t, opens.Equal(h.CreateEvals[0].WaitUntil))
+}